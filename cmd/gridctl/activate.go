@@ -46,7 +46,8 @@ Exit codes:
   0  skill activated
   1  skill not found
   2  infrastructure error (gateway unreachable, conflict, registry unavailable)`,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSkillName,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var err error
 		if activateFormat, err = resolveFormat(activateFormat, cmd.Flags().Changed("format"), *activateJSON); err != nil {
@@ -54,14 +55,13 @@ Exit codes:
 		}
 		port, err := resolveActivatePort(activateStack)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(activateExitInfrastructure)
+			exitWithError(cmd, activateExitInfrastructure, err)
 		}
 
 		baseURL := fmt.Sprintf("http://localhost:%d", port)
 		exit := runActivate(os.Stdout, os.Stderr, baseURL, args[0], activateFormat, activateQuiet)
 		if exit != activateExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
@@ -58,8 +58,7 @@ Exit codes:
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(addFormat, cmd.Flags().Changed("format"), *addAsJSON)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(addExitInfrastructure)
+			exitWithError(cmd, addExitInfrastructure, err)
 		}
 		return runAdd(cmd.Context(), args[0], format)
 	},
@@ -185,12 +184,12 @@ func runAdd(ctx context.Context, arg, format string) error {
 	stackPath, source, err := resolveStackFileTarget(addFile)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(addExitInfrastructure)
+		exitNow(addExitInfrastructure)
 	}
 	existingNames, err := stackServerNames(source)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "parsing %s: %v\n", stackPath, err)
-		os.Exit(addExitInfrastructure)
+		exitNow(addExitInfrastructure)
 	}
 
 	entry, err := resolveCatalogEntry(ctx, printer, arg)
@@ -322,7 +321,7 @@ func runAdd(ctx context.Context, arg, format string) error {
 	backupPath, err := writeServersToStack(stackPath, []config.MCPServer{server}, overwrites)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(addExitInfrastructure)
+		exitNow(addExitInfrastructure)
 	}
 	doc.BackupPath = backupPath
 	doc.Server.Added = true
@@ -495,7 +494,7 @@ func finishAdd(doc addDoc, format string, err error) error {
 	if strings.EqualFold(format, "json") {
 		if encodeErr := output.EncodeJSON(os.Stdout, doc); encodeErr != nil {
 			fmt.Fprintln(os.Stderr, encodeErr)
-			os.Exit(addExitInfrastructure)
+			exitNow(addExitInfrastructure)
 		}
 	}
 	return err
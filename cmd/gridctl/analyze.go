@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/output"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+const analyzeHTTPTimeout = 10 * time.Second
+
+// Exit codes — matched against the optimize/limits conventions so CI
+// scripts can rely on a stable contract.
+const (
+	analyzeExitOK             = 0
+	analyzeExitInfrastructure = 2
+)
+
+var (
+	analyzeStack  string
+	analyzeServer string
+	analyzeFormat string
+	analyzeJSON   *bool
+	analyzePlain  *bool
+)
+
+// toolAnalytics mirrors the GET /api/analytics/tools response.
+type toolAnalytics struct {
+	ObservedSince *time.Time `json:"observedSince,omitempty"`
+	TotalTools    int        `json:"totalTools"`
+	UsedTools     int        `json:"usedTools"`
+	TotalCalls    int64      `json:"totalCalls"`
+	Servers       []struct {
+		Name         string     `json:"name"`
+		ToolCount    int        `json:"toolCount"`
+		UsedTools    int        `json:"usedTools"`
+		Calls        int64      `json:"calls"`
+		LastCalledAt *time.Time `json:"lastCalledAt,omitempty"`
+		Tools        []struct {
+			Name         string     `json:"name"`
+			Calls        int64      `json:"calls"`
+			LastCalledAt *time.Time `json:"lastCalledAt,omitempty"`
+		} `json:"tools"`
+	} `json:"servers"`
+	UnusedServers []string `json:"unusedServers"`
+	UnusedTools   []string `json:"unusedTools"`
+}
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Report tool usage and never-used tools and servers",
+	Long: `Report how often each registered tool has been called and when it was
+last used, and list the tools and servers that have never been called.
+
+Use it to find dead weight: every registered tool's definition is sent to
+the agent on tools/list whether it is used or not, so unused tools and
+servers inflate agent context for nothing. Trim them with the server's
+'tools' whitelist or remove the server. 'gridctl optimize' prices the same
+data as weekly findings.
+
+Counts come from the gateway's usage tracking. They cover activity since
+the gateway started, or longer for servers with metrics persistence
+enabled; a young gateway may not have seen a rarely used tool yet.
+
+Exit codes:
+  0  report printed
+  2  infrastructure error (gateway unreachable)`,
+	Example: `  gridctl analyze                  Usage summary per server and never-used tools
+  gridctl analyze --server github  Per-tool breakdown for one server
+  gridctl analyze --json           Machine-readable report`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		if analyzeFormat, err = resolveFormat(analyzeFormat, cmd.Flags().Changed("format"), *analyzeJSON); err != nil {
+			return err
+		}
+		if err := resolvePlain(*analyzePlain, analyzeFormat); err != nil {
+			return err
+		}
+		port, err := resolveRunningPort("analyze", analyzeStack)
+		if err != nil {
+			exitWithError(cmd, analyzeExitInfrastructure, err)
+		}
+		report, err := fetchToolAnalytics(port)
+		if err != nil {
+			exitWithError(cmd, analyzeExitInfrastructure, err)
+		}
+
+		if strings.EqualFold(analyzeFormat, "json") {
+			return output.EncodeJSON(os.Stdout, report)
+		}
+		if analyzeServer != "" {
+			return renderAnalyzeServer(os.Stdout, report, analyzeServer, *analyzePlain)
+		}
+		renderAnalyzeReport(os.Stdout, report, *analyzePlain)
+		return nil
+	},
+}
+
+func init() {
+	analyzeCmd.Flags().StringVarP(&analyzeStack, "stack", "s", "", "Stack to query (auto-detected when only one stack is running)")
+	analyzeCmd.Flags().StringVar(&analyzeServer, "server", "", "Show the per-tool breakdown for one server")
+	_ = analyzeCmd.RegisterFlagCompletionFunc("server", completeServerFlag)
+	analyzeCmd.Flags().StringVar(&analyzeFormat, "format", "", "Output format: 'json' for machine-readable output (default: table)")
+	analyzeJSON = addJSONAlias(analyzeCmd)
+	analyzePlain = addPlainFlag(analyzeCmd)
+}
+
+// fetchToolAnalytics calls GET /api/analytics/tools on the local gateway.
+func fetchToolAnalytics(port int) (toolAnalytics, error) {
+	client := &http.Client{Timeout: analyzeHTTPTimeout}
+	resp, err := client.Get(fmt.Sprintf("http://localhost:%d/api/analytics/tools", port))
+	if err != nil {
+		return toolAnalytics{}, fmt.Errorf("analyze: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return toolAnalytics{}, fmt.Errorf("analyze: reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return toolAnalytics{}, fmt.Errorf("analyze: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var report toolAnalytics
+	if err := json.Unmarshal(body, &report); err != nil {
+		return toolAnalytics{}, fmt.Errorf("analyze: parsing response: %w", err)
+	}
+	return report, nil
+}
+
+// renderAnalyzeReport prints the per-server summary table followed by the
+// never-used servers and tools.
+func renderAnalyzeReport(w io.Writer, report toolAnalytics, plain bool) {
+	if len(report.Servers) == 0 {
+		fmt.Fprintln(w, "No MCP servers registered.")
+		return
+	}
+	fmt.Fprintf(w, "%s: %d of %d tools used, %d call(s)\n\n",
+		analyzeHorizon(report.ObservedSince), report.UsedTools, report.TotalTools, report.TotalCalls)
+
+	t := output.NewTableWriter(w, plain)
+	t.AppendHeader(table.Row{"SERVER", "TOOLS", "USED", "CALLS", "LAST CALL"})
+	for _, s := range report.Servers {
+		t.AppendRow(table.Row{s.Name, s.ToolCount, s.UsedTools, s.Calls, analyzeLastCall(s.LastCalledAt)})
+	}
+	t.Render()
+
+	if len(report.UnusedServers) > 0 {
+		fmt.Fprintf(w, "\nNever-used servers (%d):\n", len(report.UnusedServers))
+		for _, name := range report.UnusedServers {
+			fmt.Fprintf(w, "  %s\n", name)
+		}
+	}
+	if len(report.UnusedTools) > 0 {
+		fmt.Fprintf(w, "\nNever-used tools (%d):\n", len(report.UnusedTools))
+		for _, name := range report.UnusedTools {
+			fmt.Fprintf(w, "  %s\n", name)
+		}
+	}
+	if len(report.UnusedServers) == 0 && len(report.UnusedTools) == 0 {
+		fmt.Fprintln(w, "\nEvery registered tool has been called.")
+	}
+}
+
+// renderAnalyzeServer prints one server's tools, most-called first.
+func renderAnalyzeServer(w io.Writer, report toolAnalytics, name string, plain bool) error {
+	for _, s := range report.Servers {
+		if s.Name != name {
+			continue
+		}
+		fmt.Fprintf(w, "%s: %d of %d tools used, %d call(s)\n\n",
+			s.Name, s.UsedTools, s.ToolCount, s.Calls)
+		t := output.NewTableWriter(w, plain)
+		t.AppendHeader(table.Row{"TOOL", "CALLS", "LAST CALL"})
+		for _, tool := range s.Tools {
+			t.AppendRow(table.Row{tool.Name, tool.Calls, analyzeLastCall(tool.LastCalledAt)})
+		}
+		t.Render()
+		return nil
+	}
+	return usageErrorf("server %q is not registered with the gateway", name)
+}
+
+// analyzeHorizon states how far back the counts reach.
+func analyzeHorizon(since *time.Time) string {
+	if since == nil {
+		return "Tool usage"
+	}
+	return "Tool usage since " + since.Local().Format("2006-01-02 15:04")
+}
+
+func analyzeLastCall(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	return formatDuration(time.Since(*t))
+}
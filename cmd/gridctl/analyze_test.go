@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const analyzeFixture = `{
+  "observedSince": "2026-05-20T10:00:00Z",
+  "totalTools": 4, "usedTools": 2, "totalCalls": 3,
+  "servers": [
+    {"name": "github", "toolCount": 3, "usedTools": 2, "calls": 3, "lastCalledAt": "2026-05-24T09:13:00Z",
+     "tools": [
+       {"name": "create_issue", "calls": 2, "lastCalledAt": "2026-05-24T09:13:00Z"},
+       {"name": "list_repos", "calls": 1, "lastCalledAt": "2026-05-21T08:00:00Z"},
+       {"name": "delete_repo", "calls": 0}
+     ]},
+    {"name": "slack", "toolCount": 1, "usedTools": 0, "calls": 0, "tools": [{"name": "post", "calls": 0}]}
+  ],
+  "unusedServers": ["slack"],
+  "unusedTools": ["github__delete_repo"]
+}`
+
+func fetchAnalyzeFixture(t *testing.T) toolAnalytics {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/analytics/tools" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(analyzeFixture))
+	}))
+	defer server.Close()
+
+	report, err := fetchToolAnalytics(mustPort(t, server.URL))
+	if err != nil {
+		t.Fatalf("fetchToolAnalytics: %v", err)
+	}
+	return report
+}
+
+func TestRenderAnalyzeReport(t *testing.T) {
+	report := fetchAnalyzeFixture(t)
+	var buf bytes.Buffer
+	renderAnalyzeReport(&buf, report, true)
+	out := buf.String()
+	for _, want := range []string{"2 of 4 tools used, 3 call(s)", "github", "never", "Never-used servers (1):\n  slack", "Never-used tools (1):\n  github__delete_repo"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderAnalyzeServer(t *testing.T) {
+	report := fetchAnalyzeFixture(t)
+	var buf bytes.Buffer
+	if err := renderAnalyzeServer(&buf, report, "github", true); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Index(out, "create_issue") > strings.Index(out, "delete_repo") {
+		t.Errorf("tools should keep the most-called-first order:\n%s", out)
+	}
+
+	err := renderAnalyzeServer(&buf, report, "nope", true)
+	if err == nil || exitCodeFor(err) != exitConfig {
+		t.Errorf("unknown server: err = %v, want a usage error", err)
+	}
+}
+
+func TestFetchToolAnalytics_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "metrics accumulator not configured", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := fetchToolAnalytics(mustPort(t, server.URL))
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("err = %v, want the status in the error", err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/gridctl/gridctl/pkg/controller"
 	"github.com/gridctl/gridctl/pkg/output"
 	"github.com/gridctl/gridctl/pkg/provisioner"
+	"github.com/gridctl/gridctl/pkg/runtime"
 	"github.com/gridctl/gridctl/pkg/skills"
 
 	"github.com/spf13/cobra"
@@ -30,8 +31,15 @@ var (
 	applyFlash       bool
 	applyCodeMode    bool
 	applyLogFile     string
+
+	serveInCluster bool
+	// inClusterID is the container gridctl runs in under serve --in-cluster.
+	inClusterID string
 )
 
+// selfContainerID detects the container gridctl runs in; a seam for tests.
+var selfContainerID = runtime.SelfContainerID
+
 var applyCmd = &cobra.Command{
 	Use:   "apply [stack.yaml]",
 	Short: "Start MCP servers defined in a stack file",
@@ -89,6 +97,7 @@ func runServeStackless() error {
 		DaemonChild: applyDaemonChild,
 		LogFile:     applyLogFile,
 		LogLevel:    logLevel,
+		InCluster:   inClusterID,
 	})
 	ctrl.SetVersion(version)
 	ctrl.SetWebFS(WebFS)
@@ -102,6 +111,22 @@ func runServeStackless() error {
 	return ctrl.Serve(ctx)
 }
 
+// runServeInCluster runs gridctl as a container's main process: always in
+// the foreground, deploying stackPath when given, with container servers
+// reached over their networks (see controller.Config.InCluster).
+func runServeInCluster(args []string) error {
+	self, err := selfContainerID()
+	if err != nil {
+		return fmt.Errorf("--in-cluster: %w", err)
+	}
+	inClusterID = self
+	applyForeground = true
+	if len(args) == 0 {
+		return runServeStackless()
+	}
+	return runApply(args[0])
+}
+
 func runApply(stackPath string) error {
 	ctrl := controller.New(controller.Config{
 		StackPath:   stackPath,
@@ -118,6 +143,7 @@ func runApply(stackPath string) error {
 		Runtime:     runtimeFlag,
 		LogFile:     applyLogFile,
 		LogLevel:    logLevel,
+		InCluster:   inClusterID,
 	})
 	ctrl.SetVersion(version)
 	ctrl.SetWebFS(WebFS)
@@ -151,7 +177,7 @@ func runApply(stackPath string) error {
 	// Show pending skill update notice (non-blocking read from cache)
 	if !applyQuiet && !applyDaemonChild {
 		if notice := skills.FormatUpdateNotice(); notice != "" {
-			fmt.Print(notice)
+			output.New().Hint("%s", strings.TrimSpace(notice))
 		}
 	}
 
@@ -68,7 +68,8 @@ URL back when the browser cannot reach the daemon at all.`,
   gridctl auth login notion --no-browser
   gridctl auth login notion --manual
   gridctl auth login notion --timeout 10m`,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeServerNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runAuthLogin(os.Stdout, os.Stderr, args[0])
 	},
@@ -80,7 +81,8 @@ var authLogoutCmd = &cobra.Command{
 	Long: `Delete the stored tokens for a server, attempting best-effort
 revocation with the authorization server first. Use --all to log out of
 every OAuth-configured server.`,
-	Args: cobra.MaximumNArgs(1),
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if authLogoutAll {
 			return runAuthLogoutAll(os.Stdout)
@@ -103,16 +105,15 @@ Exit codes:
   0  every OAuth server is authorized (or none are configured)
   1  at least one server needs authorization
   2  infrastructure error (no running stack, gateway unreachable)`,
-	Args: cobra.MaximumNArgs(1),
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(authStatusFormat, cmd.Flags().Changed("format"), *authStatusJSON)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(authExitInfrastructure)
+			exitWithError(cmd, authExitInfrastructure, err)
 		}
 		if err := resolvePlain(*authStatusPlain, format); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(authExitInfrastructure)
+			exitWithError(cmd, authExitInfrastructure, err)
 		}
 		server := ""
 		if len(args) == 1 {
@@ -120,7 +121,7 @@ Exit codes:
 		}
 		exit := runAuthStatus(os.Stdout, os.Stderr, server, format)
 		if exit != authExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
@@ -133,7 +134,8 @@ var authResetCmd = &cobra.Command{
 for a server's authorization server. Use this when a provider-side change
 (revoked app, rotated client) leaves login failing; the next login starts
 from a clean slate.`,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeServerNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runAuthAction(os.Stdout, args[0], "reset", "Reset authorization state for %s\n")
 	},
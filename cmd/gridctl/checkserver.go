@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gridctl/gridctl/pkg/conformance"
+	"github.com/gridctl/gridctl/pkg/mcp"
+	"github.com/gridctl/gridctl/pkg/output"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes — matched against the doctor/optimize conventions so CI
+// scripts can rely on a stable contract.
+const (
+	checkServerExitOK     = 0
+	checkServerExitFailed = 1
+)
+
+var (
+	checkServerCommand string
+	checkServerURL     string
+	checkServerEnv     []string
+	checkServerHeader  string
+	checkServerTimeout time.Duration
+	checkServerFormat  string
+	checkServerJSON    *bool
+)
+
+var checkServerCmd = &cobra.Command{
+	Use:   "check-server -- <command> [args...] | --command <cmd> | --url <url>",
+	Short: "Run an MCP conformance suite against a candidate server",
+	Long: `Runs a conformance suite against an MCP server before you add it to a
+stack, and prints a pass/fail report.
+
+The target is a local stdio server (--command, or the arguments after --)
+or a Streamable HTTP endpoint (--url). The suite checks:
+
+  initialize          handshake succeeds and reports a protocol version
+  tools/list          the tool list is returned
+  tools/schema        tool names are valid and unique, and every input and
+                      output schema is a compilable JSON Schema object
+  ping                the server answers ping (warning only)
+  errors/unknown-tool calling a nonexistent tool is rejected promptly
+  timeout/recovery    the server still answers after an abandoned request
+
+Each step is bounded by --timeout.
+
+Exit codes:
+  0  all checks passed (warnings allowed)
+  1  one or more checks failed
+  3  invalid flags (no target, or both --command and --url)`,
+	Example: `  gridctl check-server -- npx -y @modelcontextprotocol/server-everything
+  gridctl check-server --command "uvx mcp-server-time"
+  gridctl check-server --url http://localhost:3000/mcp
+  gridctl check-server --url https://api.example.com/mcp --header "Authorization: Bearer $TOKEN"
+  gridctl check-server --json -- ./my-server`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveFormat(checkServerFormat, cmd.Flags().Changed("format"), *checkServerJSON)
+		if err != nil {
+			return err
+		}
+		client, target, cleanup, err := newCheckServerClient(args)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		report := conformance.Run(cmd.Context(), client, target, conformance.Options{Timeout: checkServerTimeout})
+		if strings.EqualFold(format, "json") {
+			if err := output.EncodeJSON(os.Stdout, report); err != nil {
+				return err
+			}
+		} else {
+			renderCheckServerReport(os.Stdout, report)
+		}
+		if !report.Passed {
+			cleanup()
+			exitNow(checkServerExitFailed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	checkServerCmd.Flags().StringVar(&checkServerCommand, "command", "", "Command that starts a stdio MCP server (split on whitespace; use -- for arguments with spaces)")
+	checkServerCmd.Flags().StringVar(&checkServerURL, "url", "", "Streamable HTTP endpoint of a running MCP server")
+	checkServerCmd.Flags().StringArrayVarP(&checkServerEnv, "env", "e", nil, "Environment variable for --command servers (KEY=VALUE, repeatable)")
+	checkServerCmd.Flags().StringVar(&checkServerHeader, "header", "", "Request header for --url servers (\"Name: value\")")
+	checkServerCmd.Flags().DurationVar(&checkServerTimeout, "timeout", conformance.DefaultTimeout, "Deadline for each protocol step")
+	checkServerCmd.Flags().StringVar(&checkServerFormat, "format", "", "Output format: 'json' for machine-readable output (default: report)")
+	checkServerJSON = addJSONAlias(checkServerCmd)
+}
+
+// newCheckServerClient builds the client for the requested target. The
+// returned cleanup stops a spawned process and is safe to call twice.
+func newCheckServerClient(args []string) (mcp.AgentClient, string, func(), error) {
+	command := args
+	if checkServerCommand != "" {
+		if len(args) > 0 {
+			return nil, "", nil, usageErrorf("use either --command or arguments after --, not both")
+		}
+		command = strings.Fields(checkServerCommand)
+	}
+
+	switch {
+	case len(command) > 0 && checkServerURL != "":
+		return nil, "", nil, usageErrorf("--url cannot be combined with a command")
+	case len(command) == 0 && checkServerURL == "":
+		return nil, "", nil, usageErrorf("specify a server with --command, --url, or arguments after --")
+	case checkServerTimeout <= 0:
+		return nil, "", nil, usageErrorf("--timeout must be positive")
+	}
+
+	if checkServerURL != "" {
+		if len(checkServerEnv) > 0 {
+			return nil, "", nil, usageErrorf("--env applies to --command servers only")
+		}
+		c := mcp.NewClient("candidate", checkServerURL)
+		if checkServerHeader != "" {
+			name, value, ok := strings.Cut(checkServerHeader, ":")
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, "", nil, usageErrorf("--header must be \"Name: value\", got %q", checkServerHeader)
+			}
+			c.SetHeaderSource(mcp.NewStaticHeaderSource(strings.TrimSpace(name), strings.TrimSpace(value)))
+		}
+		return c, checkServerURL, func() {}, nil
+	}
+
+	if checkServerHeader != "" {
+		return nil, "", nil, usageErrorf("--header applies to --url servers only")
+	}
+	env := make(map[string]string, len(checkServerEnv))
+	for _, kv := range checkServerEnv {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, "", nil, usageErrorf("--env must be KEY=VALUE, got %q", kv)
+		}
+		env[k] = v
+	}
+	wd, _ := os.Getwd()
+	c := mcp.NewProcessClient("candidate", command, wd, env)
+	return c, strings.Join(command, " "), func() { _ = c.Close() }, nil
+}
+
+// renderCheckServerReport prints the human report in the doctor layout.
+func renderCheckServerReport(w io.Writer, report conformance.Report) {
+	color := output.ColorEnabled(os.Stdout)
+	fmt.Fprintf(w, "\nTarget:   %s\n", report.Target)
+	if report.ServerName != "" {
+		fmt.Fprintf(w, "Server:   %s %s\n", report.ServerName, report.ServerVersion)
+	}
+	if report.ProtocolVersion != "" {
+		fmt.Fprintf(w, "Protocol: %s\n", report.ProtocolVersion)
+	}
+	fmt.Fprintln(w)
+	for _, c := range report.Checks {
+		line := fmt.Sprintf("  %s %-20s %s", checkServerStatusLabel(c.Status, color), c.ID, c.Message)
+		if c.DurationMS > 0 {
+			line += fmt.Sprintf(" (%s)", time.Duration(c.DurationMS)*time.Millisecond)
+		}
+		fmt.Fprintln(w, line)
+	}
+	verdict := "PASS"
+	if !report.Passed {
+		verdict = "FAIL"
+	}
+	fmt.Fprintf(w, "\nResult: %s, %d failure(s), %d warning(s)\n", verdict, report.FailureCount, report.WarningCount)
+}
+
+// checkServerStatusLabel pads the status word before styling so alignment
+// survives the ANSI escapes.
+func checkServerStatusLabel(status string, color bool) string {
+	padded := fmt.Sprintf("%-4s", status)
+	if !color {
+		return padded
+	}
+	var c lipgloss.Color
+	switch status {
+	case conformance.StatusPass:
+		c = output.ColorGreen
+	case conformance.StatusWarn:
+		c = output.ColorAmber
+	case conformance.StatusFail:
+		c = output.ColorRed
+	default:
+		c = output.ColorMuted
+	}
+	return lipgloss.NewStyle().Foreground(c).Bold(true).Render(padded)
+}
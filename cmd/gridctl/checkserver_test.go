@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/conformance"
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// resetCheckServerFlags restores the package-level flag state between tests.
+func resetCheckServerFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		checkServerCommand, checkServerURL, checkServerHeader = "", "", ""
+		checkServerEnv = nil
+		checkServerTimeout = conformance.DefaultTimeout
+	})
+}
+
+func TestNewCheckServerClientValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		url     string
+		env     []string
+		header  string
+		args    []string
+		wantErr string
+	}{
+		{name: "no target", wantErr: "specify a server"},
+		{name: "command and url", command: "srv", url: "http://x", wantErr: "cannot be combined"},
+		{name: "command and args", command: "srv", args: []string{"other"}, wantErr: "not both"},
+		{name: "env with url", url: "http://x", env: []string{"A=1"}, wantErr: "--env applies"},
+		{name: "header with command", command: "srv", header: "X: y", wantErr: "--header applies"},
+		{name: "bad env", command: "srv", env: []string{"NOVALUE"}, wantErr: "KEY=VALUE"},
+		{name: "bad header", url: "http://x", header: "no-colon", wantErr: "Name: value"},
+		{name: "url ok", url: "http://x", header: "Authorization: Bearer t"},
+		{name: "args ok", args: []string{"srv", "--flag"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCheckServerFlags(t)
+			checkServerCommand, checkServerURL, checkServerHeader = tt.command, tt.url, tt.header
+			checkServerEnv = tt.env
+			checkServerTimeout = time.Second
+
+			_, target, cleanup, err := newCheckServerClient(tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				cleanup()
+				if target == "" {
+					t.Error("expected a target label")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if exitCodeFor(err) != exitConfig {
+				t.Errorf("exit code = %d, want %d for flag mistakes", exitCodeFor(err), exitConfig)
+			}
+		})
+	}
+}
+
+// fakeMCPHandler is a minimal Streamable HTTP MCP server.
+func fakeMCPHandler(wantAuth string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if wantAuth != "" && r.Header.Get("Authorization") != wantAuth {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req jsonrpc.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			// The timeout/recovery check abandons a request mid-flight.
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		var result any
+		switch req.Method {
+		case "initialize":
+			result = mcp.InitializeResult{
+				ProtocolVersion: mcp.MCPProtocolVersion,
+				ServerInfo:      mcp.ServerInfo{Name: "fake", Version: "0.1.0"},
+			}
+		case "tools/list":
+			result = mcp.ToolsListResult{Tools: []mcp.Tool{{
+				Name:        "echo",
+				Description: "Echo input",
+				InputSchema: json.RawMessage(`{"type":"object","properties":{"text":{"type":"string"}}}`),
+			}}}
+		case "ping":
+			result = map[string]any{}
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(jsonrpc.NewErrorResponse(req.ID, jsonrpc.InvalidParams, "unknown tool"))
+			return
+		}
+		raw, _ := json.Marshal(result)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(jsonrpc.Response{JSONRPC: "2.0", ID: req.ID, Result: raw})
+	}
+}
+
+func TestCheckServerAgainstHTTPServer(t *testing.T) {
+	resetCheckServerFlags(t)
+	srv := httptest.NewServer(fakeMCPHandler("Bearer secret"))
+	defer srv.Close()
+
+	checkServerURL = srv.URL
+	checkServerHeader = "Authorization: Bearer secret"
+	checkServerTimeout = 2 * time.Second
+	client, target, cleanup, err := newCheckServerClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	report := conformance.Run(context.Background(), client, target, conformance.Options{Timeout: checkServerTimeout})
+	if !report.Passed {
+		t.Fatalf("expected the fake server to pass: %+v", report.Checks)
+	}
+
+	var buf bytes.Buffer
+	renderCheckServerReport(&buf, report)
+	for _, want := range []string{"Server:   fake 0.1.0", "pass errors/unknown-tool", "Result: PASS, 0 failure(s)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestCheckServerMissingCommandFails(t *testing.T) {
+	resetCheckServerFlags(t)
+	checkServerTimeout = time.Second
+	client, target, cleanup, err := newCheckServerClient([]string{"gridctl-definitely-not-a-real-binary"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	report := conformance.Run(context.Background(), client, target, conformance.Options{Timeout: checkServerTimeout})
+	if report.Passed || len(report.Checks) != 1 {
+		t.Fatalf("expected a single failed check, got %+v", report.Checks)
+	}
+	if !strings.Contains(report.Checks[0].Message, "could not start server") {
+		t.Errorf("message = %q", report.Checks[0].Message)
+	}
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/state"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// completionHTTPTimeout bounds each daemon lookup made while the shell waits
+// on <TAB>. A slow or wedged gateway yields no suggestions rather than a
+// frozen prompt.
+const completionHTTPTimeout = 2 * time.Second
+
+// Daemon endpoints whose JSON carries the names offered by dynamic
+// completion: a bare array or a list envelope ({"items": [...]}) of objects
+// with a "name" field.
+const (
+	completionServersPath = "/api/mcp-servers"
+	completionSkillsPath  = "/api/registry/skills?fields=name"
+)
+
+// completeServerNames suggests MCP server names from the running gateway,
+// or from the stack file when it is stopped, for commands taking a single
+// <server> argument.
+var completeServerNames = daemonNameCompletion(completionServersPath, 1, stackFileServerNames)
+
+// completeServerFlag suggests MCP server names for a --server flag value,
+// independent of how many positional arguments precede it.
+var completeServerFlag = daemonNameCompletion(completionServersPath, 0, stackFileServerNames)
+
+// completeSkillName suggests registry skill names for commands taking a
+// single <skill> argument.
+var completeSkillName = daemonNameCompletion(completionSkillsPath, 1, nil)
+
+// completeSkillNames suggests registry skill names for variadic [skill...]
+// arguments, skipping names already on the command line.
+var completeSkillNames = daemonNameCompletion(completionSkillsPath, 0, nil)
+
+// daemonNameCompletion returns a ValidArgsFunction that fetches names live
+// from path on the running daemon. The daemon is picked the same way the
+// command itself picks it: --stack when set, otherwise the single running
+// stack. When no daemon answers, fallback (if set) lists names from the
+// stack's files instead. maxArgs caps how many positional slots are
+// completed (0 means unbounded); names already given are never offered
+// twice. Every failure degrades to "no suggestions" since a completion
+// cannot print errors.
+func daemonNameCompletion(path string, maxArgs int, fallback func(stack string) []string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if maxArgs > 0 && len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		stack := completionStackFlag(cmd)
+		names, err := fetchDaemonNames(stack, path)
+		if err != nil {
+			cobra.CompDebugln(err.Error(), true)
+			if fallback == nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			names = fallback(stack)
+		}
+		return filterCompletions(names, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// fetchDaemonNames fetches names from path on the daemon of stack, or of
+// the single running stack when stack is "".
+func fetchDaemonNames(stack, path string) ([]string, error) {
+	port, err := resolveRunningPort("completion", stack)
+	if err != nil {
+		return nil, err
+	}
+	return fetchCompletionNames(fmt.Sprintf("http://localhost:%d", port), path)
+}
+
+// stackFileServerNames lists the MCP servers declared in the stack file of
+// stack (or of the only stack with state when stack is ""), so server
+// names still complete while the stack is stopped. Only the names are
+// read; extends and upstreams are not followed.
+func stackFileServerNames(stack string) []string {
+	var st *state.DaemonState
+	if stack != "" {
+		loaded, err := state.Load(stack)
+		if err != nil {
+			return nil
+		}
+		st = loaded
+	} else {
+		states, err := state.List()
+		if err != nil || len(states) != 1 {
+			return nil
+		}
+		st = &states[0]
+	}
+	if st.StackFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(st.StackFile)
+	if err != nil {
+		return nil
+	}
+	var doc struct {
+		MCPServers []struct {
+			Name string `yaml:"name"`
+		} `yaml:"mcp-servers"`
+	}
+	if yaml.Unmarshal(data, &doc) != nil {
+		return nil
+	}
+	names := make([]string, 0, len(doc.MCPServers))
+	for _, srv := range doc.MCPServers {
+		if srv.Name != "" {
+			names = append(names, srv.Name)
+		}
+	}
+	return names
+}
+
+// completeStackArgServerFlag is completeServerFlag for commands whose
+// optional positional argument names the stack (logs, telemetry wipe): a
+// stack given there picks whose servers are offered.
+func completeStackArgServerFlag(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	stack := completionStackFlag(cmd)
+	if len(args) > 0 {
+		stack = args[0]
+	}
+	names, err := fetchDaemonNames(stack, completionServersPath)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), true)
+		names = stackFileServerNames(stack)
+	}
+	return filterCompletions(names, nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeStackNames suggests stacks known to the local state directory
+// for commands taking a single [stack] argument. Stack names come from
+// state files rather than the API, so they complete even when several
+// stacks are running and no daemon has been picked yet.
+func completeStackNames(_ *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterCompletions(stateStackNames(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeStackNamesOrFiles is completeStackNames for arguments that also
+// accept a stack file path (destroy, reload): the shell falls back to file
+// completion when no stack name matches.
+func completeStackNamesOrFiles(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, _ := completeStackNames(cmd, args, toComplete)
+	return names, cobra.ShellCompDirectiveDefault
+}
+
+// completeStackThenServer completes the <stack> <server> pair taken by
+// 'telemetry tail': the server slot queries the daemon of the stack named
+// in the first argument.
+func completeStackThenServer(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeStackNames(cmd, args, toComplete)
+	case 1:
+		return completeStackArgServerFlag(cmd, args, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerStackFlagCompletion attaches stack-name completion to every
+// --stack flag in the command tree. It walks the tree at execution time
+// rather than in init because subcommand files register their flags in
+// their own init functions, some of which run after root.go's.
+func registerStackFlagCompletion(cmd *cobra.Command) {
+	if cmd.LocalFlags().Lookup("stack") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("stack", completeStackFlag)
+	}
+	for _, sub := range cmd.Commands() {
+		registerStackFlagCompletion(sub)
+	}
+}
+
+// completeStackFlag completes the value of a --stack flag.
+func completeStackFlag(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return filterCompletions(stateStackNames(), nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionStackFlag returns the --stack value visible to cmd (local or
+// inherited from a parent's persistent flags), or "" when unset.
+func completionStackFlag(cmd *cobra.Command) string {
+	if f := cmd.Flag("stack"); f != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// stateStackNames lists the stack names that have a daemon state file,
+// sorted. Unreadable state yields no names.
+func stateStackNames() []string {
+	states, err := state.List()
+	if err != nil && !os.IsNotExist(err) {
+		cobra.CompDebugln(fmt.Sprintf("completion: could not read state: %v", err), true)
+		return nil
+	}
+	names := make([]string, 0, len(states))
+	for _, s := range states {
+		names = append(names, s.StackName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fetchCompletionNames GETs path on the daemon at baseURL and returns the
+// "name" field of every element in the JSON array response.
+func fetchCompletionNames(baseURL, path string) ([]string, error) {
+	client := &http.Client{Timeout: completionHTTPTimeout}
+	resp, err := client.Get(baseURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("completion: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("completion: reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("completion: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var items []struct {
+		Name string `json:"name"`
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		var env struct {
+			Items json.RawMessage `json:"items"`
+		}
+		if err := json.Unmarshal(trimmed, &env); err != nil {
+			return nil, fmt.Errorf("completion: parsing response: %w", err)
+		}
+		body = env.Items
+	}
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, fmt.Errorf("completion: parsing response: %w", err)
+	}
+	names := make([]string, 0, len(items))
+	for _, it := range items {
+		if it.Name != "" {
+			names = append(names, it.Name)
+		}
+	}
+	return names, nil
+}
+
+// filterCompletions keeps the names that start with toComplete and are not
+// already present in args.
+func filterCompletions(names, args []string, toComplete string) []cobra.Completion {
+	out := make([]cobra.Completion, 0, len(names))
+	for _, n := range names {
+		if !strings.HasPrefix(n, toComplete) || slices.Contains(args, n) {
+			continue
+		}
+		out = append(out, n)
+	}
+	return out
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/state"
+
+	"github.com/spf13/cobra"
+)
+
+func TestFetchCompletionNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != completionServersPath {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name":"github","toolCount":3},{"name":""},{"name":"filesystem"}]`))
+	}))
+	defer server.Close()
+
+	names, err := fetchCompletionNames(server.URL, completionServersPath)
+	if err != nil {
+		t.Fatalf("fetchCompletionNames: %v", err)
+	}
+	want := []string{"github", "filesystem"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestFetchCompletionNames_ListEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fields") != "name" {
+			t.Errorf("expected a name-only projection, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"name":"code-review"},{"name":"deploy"}],"total":2}`))
+	}))
+	defer server.Close()
+
+	names, err := fetchCompletionNames(server.URL, completionSkillsPath)
+	if err != nil {
+		t.Fatalf("fetchCompletionNames: %v", err)
+	}
+	want := []string{"code-review", "deploy"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestFetchCompletionNames_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":"Registry not available"}`, http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if _, err := fetchCompletionNames(server.URL, completionSkillsPath); err == nil {
+		t.Fatal("expected an error for a 503 response")
+	}
+}
+
+func TestFetchCompletionNames_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	if _, err := fetchCompletionNames(url, completionServersPath); err == nil {
+		t.Fatal("expected an error for an unreachable daemon")
+	}
+}
+
+func TestFilterCompletions(t *testing.T) {
+	names := []string{"github", "gitlab", "filesystem"}
+	tests := []struct {
+		name       string
+		args       []string
+		toComplete string
+		want       []string
+	}{
+		{name: "empty prefix offers all", want: []string{"github", "gitlab", "filesystem"}},
+		{name: "prefix narrows", toComplete: "git", want: []string{"github", "gitlab"}},
+		{name: "no match", toComplete: "zzz", want: []string{}},
+		{name: "already given skipped", args: []string{"github"}, toComplete: "git", want: []string{"gitlab"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterCompletions(names, tt.args, tt.toComplete)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterCompletions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaemonNameCompletion_StopsAtMaxArgs(t *testing.T) {
+	complete := daemonNameCompletion(completionServersPath, 1, nil)
+	got, directive := complete(&cobra.Command{}, []string{"github"}, "")
+	if len(got) != 0 {
+		t.Errorf("expected no suggestions once the argument slot is filled, got %v", got)
+	}
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("directive = %v, want NoFileComp", directive)
+	}
+}
+
+func TestRegisterStackFlagCompletion(t *testing.T) {
+	root := &cobra.Command{Use: "root"}
+	withStack := &cobra.Command{Use: "with", Run: func(*cobra.Command, []string) {}}
+	withStack.Flags().String("stack", "", "")
+	parent := &cobra.Command{Use: "parent"}
+	parent.PersistentFlags().String("stack", "", "")
+	child := &cobra.Command{Use: "child", Run: func(*cobra.Command, []string) {}}
+	parent.AddCommand(child)
+	without := &cobra.Command{Use: "without", Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(withStack, parent, without)
+
+	registerStackFlagCompletion(root)
+
+	if _, ok := withStack.GetFlagCompletionFunc("stack"); !ok {
+		t.Error("expected --stack completion on a local flag")
+	}
+	if _, ok := child.GetFlagCompletionFunc("stack"); !ok {
+		t.Error("expected --stack completion inherited from a persistent flag")
+	}
+}
+
+func TestServerCompletion_FallsBackToStackFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	stackFile := filepath.Join(t.TempDir(), "stack.yaml")
+	if err := os.WriteFile(stackFile, []byte("name: prod\nmcp-servers:\n  - name: github\n    image: gh\n  - name: postgres\n    image: pg\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// A stopped stack: state on disk, no live daemon to ask.
+	if err := state.Save(&state.DaemonState{StackName: "prod", StackFile: stackFile}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := completeServerFlag(&cobra.Command{}, nil, "")
+	if want := []cobra.Completion{"github", "postgres"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("--server completion = %v, want %v", got, want)
+	}
+
+	logs := &cobra.Command{Use: "logs"}
+	logs.Flags().String("stack", "", "")
+	got, _ = completeStackArgServerFlag(logs, []string{"prod"}, "po")
+	if want := []cobra.Completion{"postgres"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("logs prod --server po<TAB> = %v, want %v", got, want)
+	}
+	got, _ = completeStackArgServerFlag(logs, []string{"other"}, "")
+	if len(got) != 0 {
+		t.Errorf("unknown stack should offer nothing, got %v", got)
+	}
+}
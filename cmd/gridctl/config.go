@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gridctl/gridctl/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+var configDocsFormat string
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the stack configuration surface",
+}
+
+var configDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Print the stack YAML option reference",
+	Long: `Print every option stack.yaml accepts: its YAML path, type, default,
+the release that introduced it, and a description.
+
+The reference is generated from the configuration types built into this
+binary, so it always matches the version you are running. List elements
+appear as '[]' in paths and map keys as '<key>'.`,
+	Example: `  gridctl config docs > stack-options.md
+  gridctl config docs --format json | jq '.[] | select(.path | startswith("gateway."))'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch configDocsFormat {
+		case "markdown", "json":
+		default:
+			return usageErrorf("unknown format %q (want markdown or json)", configDocsFormat)
+		}
+		opts, err := config.Reference()
+		if err != nil {
+			return fmt.Errorf("config docs: %w", err)
+		}
+		return writeConfigDocs(os.Stdout, opts, configDocsFormat)
+	},
+}
+
+func init() {
+	configDocsCmd.Flags().StringVar(&configDocsFormat, "format", "markdown", "Output format: markdown or json")
+
+	configCmd.AddCommand(configDocsCmd)
+}
+
+// writeConfigDocs renders the option reference as a markdown table or a
+// JSON array.
+func writeConfigDocs(w io.Writer, opts []config.Option, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(opts)
+	}
+
+	fmt.Fprintln(w, "| Option | Type | Default | Since | Description |")
+	fmt.Fprintln(w, "|--------|------|---------|-------|-------------|")
+	for _, o := range opts {
+		def := ""
+		if o.Default != "" {
+			def = "`" + o.Default + "`"
+		}
+		fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s |\n",
+			o.Path, o.Type, def, o.Since, strings.ReplaceAll(o.Description, "|", `\|`))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/config"
+)
+
+func TestWriteConfigDocs(t *testing.T) {
+	opts := []config.Option{
+		{Path: "gateway.code_mode", Type: "string", Default: "off", Description: `"off" | "on"`},
+		{Path: "name", Type: "string", Since: "0.2.0"},
+	}
+
+	var md bytes.Buffer
+	if err := writeConfigDocs(&md, opts, "markdown"); err != nil {
+		t.Fatalf("markdown: %v", err)
+	}
+	out := md.String()
+	if !strings.Contains(out, "| `gateway.code_mode` | string | `off` |  | \"off\" \\| \"on\" |") {
+		t.Errorf("pipes in descriptions should be escaped:\n%s", out)
+	}
+	if !strings.Contains(out, "| `name` | string |  | 0.2.0 |  |") {
+		t.Errorf("since column missing:\n%s", out)
+	}
+
+	var js bytes.Buffer
+	if err := writeConfigDocs(&js, opts, "json"); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var got []config.Option
+	if err := json.Unmarshal(js.Bytes(), &got); err != nil || len(got) != 2 || got[1].Since != "0.2.0" {
+		t.Errorf("json round trip = %+v (err %v)", got, err)
+	}
+}
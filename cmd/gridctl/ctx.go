@@ -106,20 +106,17 @@ Exit codes:
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(ctxStatusFormat, cmd.Flags().Changed("format"), *ctxStatusJSON)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		if err := resolvePlain(*ctxStatusPlain, format); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		mgr, err := contexts.NewManager()
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		if exit := runCtxStatus(cmd.Context(), os.Stdout, os.Stderr, mgr, format, *ctxStatusPlain); exit != ctxExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
@@ -144,25 +141,22 @@ Exit codes:
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(ctxSyncFormat, cmd.Flags().Changed("format"), *ctxSyncJSON)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		if err := resolvePlain(*ctxSyncPlain, format); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		if ctxSyncAll && len(args) > 0 {
 			fmt.Fprintln(os.Stderr, "cannot combine --all with named clients")
-			os.Exit(ctxExitInfrastructure)
+			exitNow(ctxExitInfrastructure)
 		}
 		if ctxSyncCheck && (len(args) > 0 || ctxSyncForce || ctxSyncDryRun) {
 			fmt.Fprintln(os.Stderr, "--check inspects all clients and performs no writes; it cannot be combined with named clients, --force, or --dry-run")
-			os.Exit(ctxExitInfrastructure)
+			exitNow(ctxExitInfrastructure)
 		}
 		mgr, err := contexts.NewManager()
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		var exit int
 		if ctxSyncCheck {
@@ -172,7 +166,7 @@ Exit codes:
 			exit = runCtxSync(cmd.Context(), os.Stdout, os.Stderr, mgr, args, opts, format, *ctxSyncPlain)
 		}
 		if exit != ctxExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
@@ -189,11 +183,10 @@ Exit codes: 0 no differences, 1 differences found, 2 error.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		mgr, err := contexts.NewManager()
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		if exit := runCtxDiff(cmd.Context(), os.Stdout, os.Stderr, mgr, args[0]); exit != ctxExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
@@ -13,9 +13,19 @@ import (
 // (optimize, traces, activate, limits, groups), which previously carried
 // verbatim copies of this resolution chain.
 func resolveRunningPort(cmdName, stackName string) (int, error) {
+	st, err := resolveRunningState(cmdName, stackName)
+	if err != nil {
+		return 0, err
+	}
+	return st.Port, nil
+}
+
+// resolveRunningState is resolveRunningPort for callers that also need the
+// stack's name or file, such as the gateway token lookup.
+func resolveRunningState(cmdName, stackName string) (*state.DaemonState, error) {
 	states, err := state.List()
 	if err != nil {
-		return 0, fmt.Errorf("%s: could not read state: %w", cmdName, err)
+		return nil, fmt.Errorf("%s: could not read state: %w", cmdName, err)
 	}
 	running := make([]state.DaemonState, 0, len(states))
 	for _, s := range states {
@@ -24,23 +34,23 @@ func resolveRunningPort(cmdName, stackName string) (int, error) {
 		}
 	}
 	if stackName != "" {
-		for _, s := range running {
-			if s.StackName == stackName {
-				return s.Port, nil
+		for i := range running {
+			if running[i].StackName == stackName {
+				return &running[i], nil
 			}
 		}
-		return 0, fmt.Errorf("%s: stack %q is not running", cmdName, stackName)
+		return nil, fmt.Errorf("%s: stack %q is not running", cmdName, stackName)
 	}
 	switch len(running) {
 	case 0:
-		return 0, fmt.Errorf("%s: gateway not running; try `gridctl status`", cmdName)
+		return nil, fmt.Errorf("%s: gateway not running; try `gridctl status`", cmdName)
 	case 1:
-		return running[0].Port, nil
+		return &running[0], nil
 	default:
 		names := make([]string, len(running))
 		for i, s := range running {
 			names[i] = s.StackName
 		}
-		return 0, fmt.Errorf("%s: multiple stacks running (%s); use --stack to pick one", cmdName, strings.Join(names, ", "))
+		return nil, fmt.Errorf("%s: multiple stacks running (%s); use --stack to pick one", cmdName, strings.Join(names, ", "))
 	}
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gridctl/gridctl/internal/demo"
+	"github.com/gridctl/gridctl/pkg/crash"
+
+	"github.com/spf13/cobra"
+)
+
+var demoPort int
+
+var demoCmd = &cobra.Command{
+	Use:   "demo",
+	Short: "Serve the web UI against a synthetic demo gateway",
+	Long: `Starts an in-memory gateway with synthetic MCP servers, tools, skills, and
+call history, and serves the API and web UI for it. No Docker, network
+access, or stack file is needed, and nothing is written to ~/.gridctl.
+
+The fixture data is identical on every run, which makes the demo suitable
+for UI development and documentation screenshots. Press Ctrl+C to stop.`,
+	Example: `  gridctl demo                 Serve the demo on port 8180
+  gridctl demo --port 9000     Serve it on another port`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return runDemo(ctx, os.Stdout)
+	},
+}
+
+func init() {
+	demoCmd.Flags().IntVarP(&demoPort, "port", "p", 8180, "Port for the API server and web UI")
+}
+
+func runDemo(ctx context.Context, out io.Writer) error {
+	webFS, err := WebFS()
+	if err != nil {
+		return fmt.Errorf("loading web UI: %w", err)
+	}
+
+	d, err := demo.New(ctx, demo.Options{WebFS: webFS, Port: demoPort})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = d.Close() }()
+
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", demoPort))
+	if err != nil {
+		return withExitCode(exitInfrastructure, fmt.Errorf("listening on port %d: %w", demoPort, err))
+	}
+	srv := &http.Server{
+		Handler:           crash.Handler("api", d.Handler()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintf(out, "Demo gateway running at http://localhost:%d (Ctrl+C to stop)\n", demoPort)
+	if webFS == nil {
+		fmt.Fprintln(out, "Web UI not embedded in this build; the API is served at /api")
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
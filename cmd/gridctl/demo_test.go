@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRunDemo_StopsOnCancel(t *testing.T) {
+	old := demoPort
+	demoPort = 0
+	t.Cleanup(func() { demoPort = old })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	if err := runDemo(ctx, &out); err != nil {
+		t.Fatalf("runDemo: %v", err)
+	}
+	if !strings.Contains(out.String(), "Demo gateway running") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -25,7 +25,8 @@ Accepts either the stack YAML file or the stack name shown by
 'gridctl status', so a moved or renamed file never blocks a teardown.`,
 	Example: `  gridctl destroy stack.yaml   Destroy by file
   gridctl destroy mystack      Destroy by stack name (see 'gridctl status')`,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeStackNamesOrFiles,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runDestroy(args[0])
 	},
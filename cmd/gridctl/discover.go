@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/discovery"
+	"github.com/gridctl/gridctl/pkg/output"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	discoverTimeout time.Duration
+	discoverFormat  string
+	discoverJSON    *bool
+	discoverPlain   *bool
+)
+
+var discoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Find gridctl gateways on the local network",
+	Long: `Send an mDNS query for gridctl gateways and list every gateway that
+answers, with its stack, version, and URL.
+
+Only gateways that opt in with 'gateway.advertise: true' answer. Queries
+are multicast, so gateways are found on the local network segment only.
+
+Discover reports facts and exits 0 even when nothing answers. It exits 2
+when the query cannot be sent (no network, multicast blocked).`,
+	Example: `  gridctl discover
+  gridctl discover --timeout 5s
+  gridctl discover --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		if discoverFormat, err = resolveFormat(discoverFormat, cmd.Flags().Changed("format"), *discoverJSON); err != nil {
+			return err
+		}
+		if err := resolvePlain(*discoverPlain, discoverFormat); err != nil {
+			return err
+		}
+		if discoverTimeout <= 0 {
+			return usageErrorf("discover: --timeout must be positive")
+		}
+
+		gateways, err := discovery.Browse(context.Background(), discoverTimeout)
+		if err != nil {
+			return withExitCode(exitInfrastructure, fmt.Errorf("discover: %w", err))
+		}
+		if strings.EqualFold(discoverFormat, "json") {
+			return output.EncodeJSON(os.Stdout, gateways)
+		}
+		renderDiscovered(os.Stdout, gateways, *discoverPlain)
+		return nil
+	},
+}
+
+func init() {
+	discoverCmd.Flags().DurationVar(&discoverTimeout, "timeout", 2*time.Second, "How long to wait for gateways to answer")
+	discoverCmd.Flags().StringVar(&discoverFormat, "format", "", "Output format: 'json' for machine-readable output (default: table)")
+	discoverJSON = addJSONAlias(discoverCmd)
+	discoverPlain = addPlainFlag(discoverCmd)
+}
+
+// renderDiscovered prints one row per gateway found.
+func renderDiscovered(w io.Writer, gateways []discovery.Gateway, plain bool) {
+	if len(gateways) == 0 {
+		fmt.Fprintln(w, "No gateways found. Gateways answer only with 'gateway.advertise: true'.")
+		return
+	}
+	t := output.NewTableWriter(w, plain)
+	t.AppendHeader(table.Row{"STACK", "URL", "HOST", "VERSION"})
+	for _, gw := range gateways {
+		stack := gw.Stack
+		if stack == "" {
+			stack = gw.Instance
+		}
+		t.AppendRow(table.Row{stack, gw.URL, gw.Host, gw.Version})
+	}
+	t.Render()
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/discovery"
+)
+
+func TestRenderDiscovered(t *testing.T) {
+	var buf bytes.Buffer
+	renderDiscovered(&buf, []discovery.Gateway{
+		{Instance: "prod", Stack: "prod", Version: "v1.2.3", Host: "lab-box.local", Port: 8180, URL: "http://192.168.1.20:8180"},
+		{Instance: "scratch", Host: "other.local", Port: 8181, URL: "http://other.local:8181"},
+	}, true)
+	out := buf.String()
+	for _, want := range []string{"prod", "http://192.168.1.20:8180", "lab-box.local", "v1.2.3", "scratch", "http://other.local:8181"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderDiscovered_NoneFound(t *testing.T) {
+	var buf bytes.Buffer
+	renderDiscovered(&buf, nil, true)
+	if !strings.Contains(buf.String(), "gateway.advertise: true") {
+		t.Errorf("expected an advertise hint, got %q", buf.String())
+	}
+}
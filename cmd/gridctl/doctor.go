@@ -85,7 +85,7 @@ Exit codes:
 		report := runDoctorChecks(ctx)
 		exit := renderDoctorReport(os.Stdout, report, doctorJSON, doctorQuiet)
 		if exit != doctorExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
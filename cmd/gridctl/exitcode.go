@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gridctl/gridctl/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+// Process exit codes shared by every command. Commands with their own
+// documented table (validate, pins, optimize, limits, ...) keep 0/1/2 with
+// the same meanings; codes 3 and 4 are only ever produced here so wrapper
+// scripts can branch on the failure class without grepping stderr.
+const (
+	exitOK             = 0
+	exitFailure        = 1 // the command ran and failed
+	exitInfrastructure = 2 // daemon, container runtime, or network unreachable
+	exitConfig         = 3 // invalid stack file, flag, or argument
+	exitPartial        = 4 // a multi-target operation failed for some targets
+)
+
+// exitError attaches an exit code to an error returned from RunE. Execute
+// unwraps it; plain errors exit with exitFailure unless they are
+// recognizably config or usage mistakes.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// withExitCode wraps err so the process exits with code. A nil err stays nil.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// exitCodeFor classifies err into one of the shared exit codes.
+func exitCodeFor(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var ee *exitError
+	if errors.As(err, &ee) {
+		return ee.code
+	}
+	if isUsageMistake(err) || isConfigError(err) {
+		return exitConfig
+	}
+	return exitFailure
+}
+
+// isConfigError reports whether err came from reading, parsing, or
+// validating a stack file. pkg/config wraps its errors, so both errors.As
+// and the stable message prefixes see through the chain.
+func isConfigError(err error) bool {
+	var verrs config.ValidationErrors
+	if errors.As(err, &verrs) {
+		return true
+	}
+	var verr config.ValidationError
+	if errors.As(err, &verr) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "reading stack file") || strings.Contains(msg, "parsing stack YAML")
+}
+
+// exitClass names an exit code in the JSON error document.
+func exitClass(code int) string {
+	switch code {
+	case exitInfrastructure:
+		return "infrastructure"
+	case exitConfig:
+		return "config"
+	case exitPartial:
+		return "partial"
+	}
+	return "failure"
+}
+
+// cliErrorDoc is the --json (or --format json) shape of a failed command,
+// written to stdout in place of the command's normal document.
+type cliErrorDoc struct {
+	Error cliErrorBody `json:"error"`
+}
+
+type cliErrorBody struct {
+	Message  string `json:"message"`
+	Class    string `json:"class"`
+	ExitCode int    `json:"exit_code"`
+	Command  string `json:"command,omitempty"`
+}
+
+// writeCLIErrorJSON encodes err as a cliErrorDoc. Encoding failures are
+// ignored: the exit code still carries the outcome.
+func writeCLIErrorJSON(w io.Writer, cmd *cobra.Command, err error, code int) {
+	doc := cliErrorDoc{Error: cliErrorBody{
+		Message:  err.Error(),
+		Class:    exitClass(code),
+		ExitCode: code,
+	}}
+	if cmd != nil {
+		doc.Error.Command = cmd.CommandPath()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(doc)
+}
+
+// wantsJSONOutput reports whether the user asked cmd for machine-readable
+// output via --json or --format json.
+func wantsJSONOutput(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	if f := cmd.Flags().Lookup("json"); f != nil && f.Value.String() == "true" {
+		return true
+	}
+	if f := cmd.Flags().Lookup("format"); f != nil && strings.EqualFold(f.Value.String(), "json") {
+		return true
+	}
+	return false
+}
+
+// reportCLIError prints err the way the user asked for output: a JSON
+// error document on stdout for --json, the usual error line on stderr
+// otherwise.
+func reportCLIError(stdout, stderr io.Writer, cmd *cobra.Command, err error, code int) {
+	if wantsJSONOutput(cmd) {
+		writeCLIErrorJSON(stdout, cmd, err, code)
+		return
+	}
+	printCLIError(stderr, cmd, err)
+}
+
+// exitWithError reports err and exits with code. It is for RunE bodies
+// that exit directly to keep a per-command exit table; everything else
+// should return the error and let Execute classify it.
+func exitWithError(cmd *cobra.Command, code int, err error) {
+	reportCLIError(os.Stdout, os.Stderr, cmd, err, code)
+	recordUsage(cmd, code)
+	os.Exit(code)
+}
+
+// runningCmd is the command being executed, set before its RunE so exitNow
+// can attribute the usage counter.
+var runningCmd *cobra.Command
+
+// osExit is os.Exit, swapped out by tests of exit paths.
+var osExit = os.Exit
+
+// exitNow records the running command's usage counter and exits with
+// code. RunE bodies with their own exit table (validate, optimize, limits,
+// ...) exit through it for outcomes that are not errors: they never return
+// to Execute, which records usage for everything else.
+func exitNow(code int) {
+	recordUsage(runningCmd, code)
+	osExit(code)
+}
+
+// usageErrorf is fmt.Errorf for invalid flag or argument combinations.
+func usageErrorf(format string, args ...any) error {
+	return withExitCode(exitConfig, fmt.Errorf(format, args...))
+}
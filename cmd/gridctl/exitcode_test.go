@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: exitOK},
+		{name: "plain runtime error", err: errors.New("boom"), want: exitFailure},
+		{name: "explicit code", err: withExitCode(exitPartial, errors.New("2 failed")), want: exitPartial},
+		{name: "wrapped explicit code", err: fmt.Errorf("ctx: %w", withExitCode(exitInfrastructure, errors.New("down"))), want: exitInfrastructure},
+		{name: "unknown command", err: errors.New(`unknown command "foo" for "gridctl"`), want: exitConfig},
+		{name: "arg count", err: errors.New("accepts 1 arg(s), received 0"), want: exitConfig},
+		{name: "missing stack file", err: fmt.Errorf("failed to load stack: %w", errors.New("reading stack file: open x: no such file")), want: exitConfig},
+		{name: "validation errors", err: fmt.Errorf("failed to load stack: %w", config.ValidationErrors{{Field: "name", Message: "is required"}}), want: exitConfig},
+		{name: "usage helper", err: usageErrorf("cannot combine --a with --b"), want: exitConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCodeFor(tt.err); got != tt.want {
+				t.Errorf("exitCodeFor(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithExitCodeNil(t *testing.T) {
+	if err := withExitCode(exitConfig, nil); err != nil {
+		t.Errorf("withExitCode(nil) = %v, want nil", err)
+	}
+}
+
+func TestWithExitCodePreservesMessage(t *testing.T) {
+	inner := errors.New("boom")
+	err := withExitCode(exitPartial, inner)
+	if err.Error() != "boom" {
+		t.Errorf("Error() = %q, want %q", err.Error(), "boom")
+	}
+	if !errors.Is(err, inner) {
+		t.Error("expected errors.Is to see the wrapped error")
+	}
+}
+
+func newJSONFlagCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "probe"}
+	cmd.Flags().String("format", "", "")
+	cmd.Flags().Bool("json", false, "")
+	return cmd
+}
+
+func TestWantsJSONOutput(t *testing.T) {
+	cmd := newJSONFlagCmd()
+	if wantsJSONOutput(cmd) {
+		t.Error("expected false with no flags set")
+	}
+	_ = cmd.Flags().Set("format", "JSON")
+	if !wantsJSONOutput(cmd) {
+		t.Error("expected true for --format JSON")
+	}
+
+	cmd = newJSONFlagCmd()
+	_ = cmd.Flags().Set("json", "true")
+	if !wantsJSONOutput(cmd) {
+		t.Error("expected true for --json")
+	}
+
+	if wantsJSONOutput(&cobra.Command{Use: "bare"}) || wantsJSONOutput(nil) {
+		t.Error("expected false for commands without output flags")
+	}
+}
+
+func TestReportCLIErrorJSON(t *testing.T) {
+	cmd := newJSONFlagCmd()
+	_ = cmd.Flags().Set("json", "true")
+
+	var stdout, stderr bytes.Buffer
+	reportCLIError(&stdout, &stderr, cmd, errors.New("gateway unreachable"), exitInfrastructure)
+
+	if stderr.Len() != 0 {
+		t.Errorf("expected nothing on stderr in JSON mode, got %q", stderr.String())
+	}
+	var doc cliErrorDoc
+	if err := json.Unmarshal(stdout.Bytes(), &doc); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout.String())
+	}
+	want := cliErrorBody{Message: "gateway unreachable", Class: "infrastructure", ExitCode: exitInfrastructure, Command: "probe"}
+	if doc.Error != want {
+		t.Errorf("error doc = %+v, want %+v", doc.Error, want)
+	}
+}
+
+func TestReportCLIErrorText(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	reportCLIError(&stdout, &stderr, newJSONFlagCmd(), errors.New("boom"), exitFailure)
+
+	if stdout.Len() != 0 {
+		t.Errorf("expected nothing on stdout in text mode, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "boom") {
+		t.Errorf("expected the error on stderr, got %q", stderr.String())
+	}
+}
+
+func TestExitClass(t *testing.T) {
+	for code, want := range map[int]string{
+		exitFailure:        "failure",
+		exitInfrastructure: "infrastructure",
+		exitConfig:         "config",
+		exitPartial:        "partial",
+	} {
+		if got := exitClass(code); got != want {
+			t.Errorf("exitClass(%d) = %q, want %q", code, got, want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/output"
 	"github.com/gridctl/gridctl/pkg/skills"
 	"github.com/gridctl/gridctl/pkg/state"
 
@@ -115,6 +116,7 @@ func isSensitiveKey(key string) bool {
 }
 
 func outputJSON(stack *config.Stack) error {
+	out := output.New()
 	data, err := json.MarshalIndent(stack, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling JSON: %w", err)
@@ -124,11 +126,12 @@ func outputJSON(stack *config.Stack) error {
 		return writeToDir(exportOutputDir, "stack.json", data)
 	}
 
-	fmt.Print(string(data))
+	out.Print("%s", string(data))
 	return nil
 }
 
 func outputYAML(stack *config.Stack) error {
+	out := output.New()
 	data, err := yaml.Marshal(stack)
 	if err != nil {
 		return fmt.Errorf("marshaling YAML: %w", err)
@@ -143,11 +146,12 @@ func outputYAML(stack *config.Stack) error {
 		return exportSkillsConfig(exportOutputDir)
 	}
 
-	fmt.Print(string(data))
+	out.Print("%s", string(data))
 	return nil
 }
 
 func writeToDir(dir, filename string, data []byte) error {
+	out := output.New()
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("creating output directory: %w", err)
 	}
@@ -157,7 +161,7 @@ func writeToDir(dir, filename string, data []byte) error {
 		return fmt.Errorf("writing %s: %w", path, err)
 	}
 
-	fmt.Printf("Wrote %s\n", path)
+	out.Print("Wrote %s\n", path)
 	return nil
 }
 
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -16,7 +15,7 @@ func resolveFormat(format string, formatChanged, asJSON bool) (string, error) {
 		return format, nil
 	}
 	if formatChanged && !strings.EqualFold(format, "json") {
-		return "", fmt.Errorf("cannot combine --json with --format=%s", format)
+		return "", usageErrorf("cannot combine --json with --format=%s", format)
 	}
 	return "json", nil
 }
@@ -41,7 +40,7 @@ func addPlainFlag(cmd *cobra.Command) *bool {
 // tables and JSON are different consumers; combining them is a mistake.
 func resolvePlain(plain bool, format string) error {
 	if plain && strings.EqualFold(format, "json") {
-		return fmt.Errorf("cannot combine --plain with --json (or --format=json)")
+		return usageErrorf("cannot combine --plain with --json (or --format=json)")
 	}
 	return nil
 }
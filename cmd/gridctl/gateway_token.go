@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/gridctl/gridctl/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// gatewayTokenEnv supplies the gateway credential to commands that call a
+// gateway's API when its stack sets gateway.auth.
+const gatewayTokenEnv = config.GatewayTokenEnv
+
+// gatewayTokenFlagUsage is the help text for the --token flag of commands
+// that call a gateway's API.
+const gatewayTokenFlagUsage = "Gateway auth token or API key (default: $" + gatewayTokenEnv + ", then the stack's gateway.auth.token)"
+
+// gatewayCredential is the header a request to a gateway carries to pass
+// gateway.auth. The zero value adds nothing.
+type gatewayCredential struct {
+	Header string
+	Value  string
+}
+
+// resolveGatewayCredential picks the credential for a gateway: the --token
+// flag, then GRIDCTL_GATEWAY_TOKEN, then gateway.auth.token from the stack
+// file when it resolves from the environment. The stack file also decides
+// the header and whether the value is sent as a bearer token; without one,
+// the value goes out as a bearer token in Authorization, the default
+// gateway.auth type. stackFile may be empty for a remote gateway.
+func resolveGatewayCredential(flagToken, stackFile string) gatewayCredential {
+	auth := readStackAuth(stackFile)
+	token := flagToken
+	if token == "" {
+		token = os.Getenv(gatewayTokenEnv)
+	}
+	if token == "" && auth != nil && auth.Token != "" {
+		expanded, unresolved, _ := config.ExpandString(auth.Token, config.EnvResolver())
+		if len(unresolved) == 0 {
+			token = expanded
+		}
+	}
+	if token == "" {
+		return gatewayCredential{}
+	}
+	header, value := auth.CredentialHeader(token)
+	return gatewayCredential{Header: header, Value: value}
+}
+
+// apply sets the credential on req.
+func (c gatewayCredential) apply(req *http.Request) {
+	if c.Header != "" {
+		req.Header.Set(c.Header, c.Value)
+	}
+}
+
+// readStackAuth returns the gateway.auth block of the stack file at path,
+// or nil when there is none or the file cannot be read. Only that block is
+// parsed, so a stack that fails full validation still yields its token.
+func readStackAuth(path string) *config.AuthConfig {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var doc struct {
+		Gateway struct {
+			Auth *config.AuthConfig `yaml:"auth"`
+		} `yaml:"gateway"`
+	}
+	if yaml.Unmarshal(data, &doc) != nil {
+		return nil
+	}
+	return doc.Gateway.Auth
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveGatewayCredential(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	bearer := write("bearer.yaml", "gateway:\n  auth:\n    type: bearer\n    token: ${STACK_TOKEN}\n")
+	apiKey := write("apikey.yaml", "gateway:\n  auth:\n    type: api_key\n    header: X-API-Key\n    token: from-file\n")
+	vaulted := write("vault.yaml", "gateway:\n  auth:\n    type: bearer\n    token: ${var:GW_TOKEN}\n")
+
+	tests := []struct {
+		name      string
+		flag      string
+		env       string
+		stackFile string
+		want      gatewayCredential
+	}{
+		{name: "nothing configured", want: gatewayCredential{}},
+		{name: "flag", flag: "f", env: "e", stackFile: bearer, want: gatewayCredential{"Authorization", "Bearer f"}},
+		{name: "env over stack file", env: "e", stackFile: bearer, want: gatewayCredential{"Authorization", "Bearer e"}},
+		{name: "stack file token", stackFile: bearer, want: gatewayCredential{"Authorization", "Bearer from-env"}},
+		{name: "api key header", stackFile: apiKey, want: gatewayCredential{"X-API-Key", "from-file"}},
+		{name: "api key header with flag", flag: "f", stackFile: apiKey, want: gatewayCredential{"X-API-Key", "f"}},
+		{name: "unresolved store reference", stackFile: vaulted, want: gatewayCredential{}},
+		{name: "missing stack file", flag: "f", stackFile: filepath.Join(dir, "gone.yaml"), want: gatewayCredential{"Authorization", "Bearer f"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(gatewayTokenEnv, tt.env)
+			t.Setenv("STACK_TOKEN", "from-env")
+			if got := resolveGatewayCredential(tt.flag, tt.stackFile); got != tt.want {
+				t.Errorf("resolveGatewayCredential() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
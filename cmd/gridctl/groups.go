@@ -63,22 +63,19 @@ Exit codes:
 		}
 		port, err := resolveGroupsPort(groupsStack)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(groupsExitInfrastructure)
+			exitWithError(cmd, groupsExitInfrastructure, err)
 		}
 
 		report, err := fetchGroupsReport(port)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(groupsExitInfrastructure)
+			exitWithError(cmd, groupsExitInfrastructure, err)
 		}
 
 		if strings.EqualFold(groupsFormat, "json") {
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
 			if err := enc.Encode(report); err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				os.Exit(groupsExitInfrastructure)
+				exitWithError(cmd, groupsExitInfrastructure, err)
 			}
 			return nil
 		}
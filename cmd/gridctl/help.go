@@ -21,9 +21,9 @@ const (
 
 // helpColorEnabled reports whether help output should carry ANSI colors.
 // Checked lazily at render time because --help bypasses PersistentPreRun,
-// so the --no-color flag must be consulted directly.
+// so the --no-color and --plain flags must be consulted directly.
 func helpColorEnabled() bool {
-	return !noColorFlag && output.ColorEnabled(os.Stdout)
+	return !noColorFlag && !plainFlag && output.ColorEnabled(os.Stdout)
 }
 
 // colorize applies color to text when color output is enabled.
@@ -66,8 +66,7 @@ Exit codes:
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(importFormat, cmd.Flags().Changed("format"), *importAsJSON)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(importExitInfrastructure)
+			exitWithError(cmd, importExitInfrastructure, err)
 		}
 		client := ""
 		if len(args) == 1 {
@@ -263,12 +262,12 @@ func runImport(client, format string) error {
 	stackPath, source, err := resolveStackFileTarget(importFile)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(importExitInfrastructure)
+		exitNow(importExitInfrastructure)
 	}
 	existingNames, err := stackServerNames(source)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "parsing %s: %v\n", stackPath, err)
-		os.Exit(importExitInfrastructure)
+		exitNow(importExitInfrastructure)
 	}
 
 	candidates, skipped := scanForCandidates(printer, scope)
@@ -434,7 +433,7 @@ func runImport(client, format string) error {
 	backupPath, err := writeImportedServers(stackPath, importable, overwrites)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(importExitInfrastructure)
+		exitNow(importExitInfrastructure)
 	}
 	doc.BackupPath = backupPath
 
@@ -650,7 +649,7 @@ func finishImport(_ *output.Printer, doc importDoc, format string, err error) er
 	if strings.EqualFold(format, "json") {
 		if encodeErr := output.EncodeJSON(os.Stdout, doc); encodeErr != nil {
 			fmt.Fprintln(os.Stderr, encodeErr)
-			os.Exit(importExitInfrastructure)
+			exitNow(importExitInfrastructure)
 		}
 	}
 	return err
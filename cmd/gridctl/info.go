@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/gridctl/gridctl/pkg/output"
@@ -44,13 +43,14 @@ func init() {
 }
 
 func runInfo(asJSON bool) error {
+	out := output.New()
 	info, err := runtime.DetectRuntime(runtime.DetectOptions{Explicit: runtimeFlag})
 	if err != nil {
 		if asJSON {
 			return output.EncodeJSON(os.Stdout, infoJSON{Error: err.Error()})
 		}
-		fmt.Println("Runtime:  not detected")
-		fmt.Printf("Error:    %v\n", err)
+		out.Println("Runtime:  not detected")
+		out.Print("Error:    %v\n", err)
 		return nil
 	}
 
@@ -69,18 +69,18 @@ func runInfo(asJSON bool) error {
 		return output.EncodeJSON(os.Stdout, doc)
 	}
 
-	fmt.Printf("Runtime:  %s\n", info.DisplayName())
-	fmt.Printf("Socket:   %s\n", info.SocketPath)
+	out.Print("Runtime:  %s\n", info.DisplayName())
+	out.Print("Socket:   %s\n", info.SocketPath)
 	if info.Version != "" {
-		fmt.Printf("Version:  %s\n", info.Version)
+		out.Print("Version:  %s\n", info.Version)
 	}
-	fmt.Printf("Host:     %s\n", info.HostAliasHostname())
+	out.Print("Host:     %s\n", info.HostAliasHostname())
 	if info.SELinux {
-		fmt.Println("SELinux:  enforcing")
+		out.Println("SELinux:  enforcing")
 	}
 	if info.IsRootless() {
-		fmt.Printf("Mode:     rootless\n")
-		fmt.Printf("Network:  %s\n", rootlessNetworkStack(info))
+		out.Print("Mode:     rootless\n")
+		out.Print("Network:  %s\n", rootlessNetworkStack(info))
 	}
 
 	return nil
@@ -38,7 +38,8 @@ var limitsCmd = &cobra.Command{
 	Short: "Show budget and rate limit consumption",
 	Long: `Show every configured budget and rate limit with its current
 consumption: spend against dollar caps (with the active calendar window)
-and token-bucket rate limits.
+and token-bucket rate limits, plus access schedules and whether their
+window is open.
 
 Limits are declared in stack.yaml under 'limits:' and enforced at tool-call
 dispatch. Budgets govern attributed cost only; calls whose model cannot be
@@ -65,29 +66,26 @@ Exit codes:
 		}
 		port, err := resolveLimitsPort(limitsStack)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(limitsExitInfrastructure)
+			exitWithError(cmd, limitsExitInfrastructure, err)
 		}
 
 		report, err := fetchLimitsReport(port)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(limitsExitInfrastructure)
+			exitWithError(cmd, limitsExitInfrastructure, err)
 		}
 
 		if strings.EqualFold(limitsFormat, "json") {
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
 			if err := enc.Encode(report); err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				os.Exit(limitsExitInfrastructure)
+				exitWithError(cmd, limitsExitInfrastructure, err)
 			}
 		} else {
 			renderLimitsTable(os.Stdout, report, *limitsPlain)
 		}
 
 		if limitsExceeded(report) {
-			os.Exit(limitsExitExceeded)
+			exitNow(limitsExitExceeded)
 		}
 		return nil
 	},
@@ -169,6 +167,11 @@ func renderLimitsTable(w io.Writer, report limits.StatusReport, plain bool) {
 		case e.Rate != nil:
 			limit = fmt.Sprintf("%d calls/min", e.Rate.CallsPerMinute)
 			used = fmt.Sprintf("burst %d", e.Rate.Burst)
+		case e.Schedule != nil:
+			limit = e.Schedule.Days + " " + e.Schedule.Hours
+			if e.Schedule.NextOpen != nil {
+				window = "opens " + e.Schedule.NextOpen.Format("2006-01-02 15:04")
+			}
 		}
 		t.AppendRow(table.Row{e.Kind, e.Scope, e.Key, limit, used, window, e.State})
 	}
@@ -46,7 +46,8 @@ The stack is auto-detected when exactly one is running.`,
   gridctl logs mystack -f          Follow the daemon log
   gridctl logs --server github     Container logs for the github server
   gridctl logs -n 20               Last 20 lines`,
-	Args: cobra.MaximumNArgs(1),
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeStackNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := logsStack
 		if len(args) == 1 {
@@ -65,6 +66,7 @@ func init() {
 	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Follow log output")
 	logsCmd.Flags().IntVarP(&logsTail, "tail", "n", 100, "Number of lines to show from the end of the log (0 or negative for all)")
 	logsCmd.Flags().StringVar(&logsServer, "server", "", "Stream container logs for this MCP server instead of the daemon log")
+	_ = logsCmd.RegisterFlagCompletionFunc("server", completeStackArgServerFlag)
 	logsCmd.Flags().StringVarP(&logsStack, "stack", "s", "", "Stack name (auto-detected when only one stack is running)")
 }
 
@@ -220,8 +222,8 @@ type dockerClientProvider interface {
 }
 
 // streamContainerLogs streams a containerized MCP server's logs via the
-// container runtime. Local-process and external servers have no container
-// to read from and get a clear error instead.
+// container runtime. Local-process, SSH, and external servers have no
+// container to read from and get a clear error instead.
 func streamContainerLogs(ctx context.Context, w io.Writer, stack, server string, tail int, follow bool) error {
 	rt, err := runtime.New()
 	if err != nil {
@@ -247,7 +249,7 @@ func streamContainerLogs(ctx context.Context, w io.Writer, stack, server string,
 		}
 	}
 	if id == "" {
-		msg := fmt.Sprintf("server %q has no container in stack %q (local-process and external servers have no container logs)", server, stack)
+		msg := fmt.Sprintf("server %q has no container in stack %q (local-process, SSH, and external servers have no container logs; their output is in the daemon log)", server, stack)
 		if len(names) > 0 {
 			msg += fmt.Sprintf("; containers: %s", strings.Join(names, ", "))
 		}
@@ -59,14 +59,12 @@ Exit codes:
 		}
 		port, err := resolveOptimizePort(optimizeStack)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(optimizeExitInfrastructure)
+			exitWithError(cmd, optimizeExitInfrastructure, err)
 		}
 
 		report, err := fetchOptimizeReport(port, optimizeStack, optimizeMinImpact, optimizeSeverity)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(optimizeExitInfrastructure)
+			exitWithError(cmd, optimizeExitInfrastructure, err)
 		}
 
 		switch strings.ToLower(optimizeFormat) {
@@ -79,7 +77,7 @@ Exit codes:
 		}
 
 		if hasActionableFindings(report.Findings) {
-			os.Exit(optimizeExitFindings)
+			exitNow(optimizeExitFindings)
 		}
 		return nil
 	},
@@ -86,12 +86,12 @@ Exit codes:
   0  all pins verified (or nothing pinned yet)
   1  drift detected
   2  infrastructure error (no stack, unreadable pin store, unknown server)`,
-	Args: cobra.MaximumNArgs(1),
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(pinsVerifyFormat, cmd.Flags().Changed("format"), *pinsVerifyJSON)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(pinsExitInfrastructure)
+			exitWithError(cmd, pinsExitInfrastructure, err)
 		}
 		server := ""
 		if len(args) == 1 {
@@ -99,11 +99,10 @@ Exit codes:
 		}
 		stackName, servers, err := loadPinsForCLI()
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(pinsExitInfrastructure)
+			exitWithError(cmd, pinsExitInfrastructure, err)
 		}
 		if exit := pinsVerifyExit(os.Stdout, os.Stderr, stackName, servers, server, format); exit != pinsExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
@@ -133,12 +132,12 @@ Exit codes:
      severity ('warn' or 'critical') present on pinned tools
   2  infrastructure error (no running stack, unknown server, API failure,
      or servers skipped with warnings)`,
-	Args: cobra.MaximumNArgs(1),
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(pinsDiffFormat, cmd.Flags().Changed("format"), *pinsDiffJSON)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(pinsExitInfrastructure)
+			exitWithError(cmd, pinsExitInfrastructure, err)
 		}
 		server := ""
 		if len(args) == 1 {
@@ -146,24 +145,21 @@ Exit codes:
 		}
 		st, err := resolveRunningStack()
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(pinsExitInfrastructure)
+			exitWithError(cmd, pinsExitInfrastructure, err)
 		}
 		if pinsDiffFailOn != "" && pinsDiffFailOn != pins.SeverityWarn && pinsDiffFailOn != pins.SeverityCritical {
 			fmt.Fprintf(os.Stderr, "invalid --fail-on-findings value %q: want 'warn' or 'critical'\n", pinsDiffFailOn)
-			os.Exit(pinsExitInfrastructure)
+			exitNow(pinsExitInfrastructure)
 		}
 		doc, warnings, err := buildPinsDiffDoc(st, server)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(pinsExitInfrastructure)
+			exitWithError(cmd, pinsExitInfrastructure, err)
 		}
 		exit := pinsDiffExit(os.Stdout, os.Stderr, doc, warnings, format)
 		if exit == pinsExitOK && pinsDiffFailOn != "" {
 			hit, err := pinsRecordFindingsAtOrAbove(st, server, pinsDiffFailOn)
 			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				os.Exit(pinsExitInfrastructure)
+				exitWithError(cmd, pinsExitInfrastructure, err)
 			}
 			if hit {
 				fmt.Fprintf(os.Stderr, "scan findings at or above %q present on pinned tools (see 'gridctl pins list' or the Pins workspace)\n", pinsDiffFailOn)
@@ -171,7 +167,7 @@ Exit codes:
 			}
 		}
 		if exit != pinsExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
@@ -245,17 +241,19 @@ Pass --expect with the live_server_hash from 'pins diff --format json' to
 bind the approval to the reviewed definitions: if the server's tools change
 again between review and approval, the approve is rejected instead of
 silently pinning definitions nobody saw.`,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeServerNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runPinsApprove(args[0], pinsApproveExpect)
 	},
 }
 
 var pinsResetCmd = &cobra.Command{
-	Use:   "reset <server>",
-	Short: "Delete pins for a server",
-	Long:  "Remove all pins for a server. It will be re-pinned on next deploy.",
-	Args:  cobra.ExactArgs(1),
+	Use:               "reset <server>",
+	Short:             "Delete pins for a server",
+	Long:              "Remove all pins for a server. It will be re-pinned on next deploy.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeServerNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runPinsReset(args[0])
 	},
@@ -409,6 +407,7 @@ func loadPinsForCLI() (string, map[string]*pins.ServerPins, error) {
 }
 
 func runPinsList(format string) error {
+	out := output.New()
 	stackName, servers, err := loadPinsForCLI()
 	if err != nil {
 		return err
@@ -423,7 +422,7 @@ func runPinsList(format string) error {
 	}
 
 	if len(servers) == 0 {
-		fmt.Printf("No pins found for stack '%s'. Deploy the stack first.\n", stackName)
+		out.Print("No pins found for stack '%s'. Deploy the stack first.\n", stackName)
 		return nil
 	}
 
@@ -762,6 +761,7 @@ func escapeNonPrintable(s string) string {
 }
 
 func runPinsApprove(server, expectHash string) error {
+	out := output.New()
 	st, err := resolveRunningStack()
 	if err != nil {
 		return err
@@ -810,11 +810,12 @@ func runPinsApprove(server, expectHash string) error {
 		return fmt.Errorf("parsing response: %w", err)
 	}
 
-	fmt.Printf("✓ Approved schema update for %s (%d tools re-pinned)\n", server, result.ToolCount)
+	out.Print("✓ Approved schema update for %s (%d tools re-pinned)\n", server, result.ToolCount)
 	return nil
 }
 
 func runPinsReset(server string) error {
+	out := output.New()
 	st, err := resolveRunningStack()
 	if err != nil {
 		return err
@@ -846,7 +847,7 @@ func runPinsReset(server string) error {
 		return fmt.Errorf("reset failed: %s", string(body))
 	}
 
-	fmt.Printf("✓ Pins reset for %s. Server will be re-pinned on next deploy.\n", server)
+	out.Print("✓ Pins reset for %s. Server will be re-pinned on next deploy.\n", server)
 	return nil
 }
 
@@ -51,6 +51,7 @@ func init() {
 }
 
 func runPlan(stackPath string) error {
+	out := output.New()
 	// Load and validate the proposed spec
 	proposed, result, err := config.ValidateStackFile(stackPath)
 	if err != nil {
@@ -82,18 +83,18 @@ func runPlan(stackPath string) error {
 
 	// Confirm or auto-approve
 	if !planAutoApprove && !planAutoApproveCI {
-		fmt.Print("\nApply these changes? [y/N] ")
+		out.Print("\nApply these changes? [y/N] ")
 		reader := bufio.NewReader(os.Stdin)
 		answer, _ := reader.ReadString('\n')
 		answer = strings.TrimSpace(strings.ToLower(answer))
 		if answer != "y" && answer != "yes" {
-			fmt.Println("Cancelled.")
+			out.Println("Cancelled.")
 			return nil
 		}
 	}
 
 	// Apply with Replace to handle running stacks
-	fmt.Println("\nApplying changes...")
+	out.Println("\nApplying changes...")
 	ctrl := controller.New(controller.Config{
 		StackPath:  stackPath,
 		Port:       applyPort,
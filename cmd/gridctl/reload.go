@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/output"
 	"github.com/gridctl/gridctl/pkg/reload"
 	"github.com/gridctl/gridctl/pkg/state"
 
@@ -16,6 +19,8 @@ import (
 
 const reloadHTTPTimeout = 60 * time.Second
 
+var reloadForce bool
+
 var reloadCmd = &cobra.Command{
 	Use:   "reload [stack-name]",
 	Short: "Reload configuration for a running stack",
@@ -24,17 +29,26 @@ var reloadCmd = &cobra.Command{
 The stack must be running with the --watch flag, or you can call
 this command to manually trigger a reload.
 
-If no stack name is provided, reloads all running stacks.`,
-	Args: cobra.MaximumNArgs(1),
+If no stack name is provided, reloads all running stacks.
+
+A reload that removes an MCP server whose tools registry skills still use
+is refused, and the dependent skills are listed. Use --force to remove the
+server anyway.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeStackNamesOrFiles,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 1 {
-			return reloadStack(args[0])
+			return reloadStack(args[0], reloadForce)
 		}
-		return reloadAllStacks()
+		return reloadAllStacks(reloadForce)
 	},
 }
 
-func reloadStack(name string) error {
+func init() {
+	reloadCmd.Flags().BoolVar(&reloadForce, "force", false, "Remove MCP servers even when skills depend on their tools")
+}
+
+func reloadStack(name string, force bool) error {
 	// Try to find by stack name first
 	st, err := state.Load(name)
 	if err != nil {
@@ -54,17 +68,18 @@ func reloadStack(name string) error {
 		return fmt.Errorf("stack '%s' is not running", st.StackName)
 	}
 
-	return callReloadAPI(st)
+	return callReloadAPI(st, force)
 }
 
-func reloadAllStacks() error {
+func reloadAllStacks(force bool) error {
+	out := output.New()
 	states, err := state.List()
 	if err != nil {
 		return fmt.Errorf("listing stacks: %w", err)
 	}
 
 	if len(states) == 0 {
-		fmt.Println("No running stacks found")
+		out.Println("No running stacks found")
 		return nil
 	}
 
@@ -74,9 +89,9 @@ func reloadAllStacks() error {
 			continue
 		}
 
-		fmt.Printf("Reloading stack '%s'...\n", st.StackName)
-		if err := callReloadAPI(&st); err != nil {
-			fmt.Printf("  Error: %v\n", err)
+		out.Print("Reloading stack '%s'...\n", st.StackName)
+		if err := callReloadAPI(&st, force); err != nil {
+			out.Print("  Error: %v\n", err)
 			lastErr = err
 		}
 	}
@@ -84,8 +99,12 @@ func reloadAllStacks() error {
 	return lastErr
 }
 
-func callReloadAPI(st *state.DaemonState) error {
+func callReloadAPI(st *state.DaemonState, force bool) error {
+	out := output.New()
 	url := fmt.Sprintf("http://localhost:%d/api/reload", st.Port)
+	if force {
+		url += "?force=true"
+	}
 
 	client := &http.Client{Timeout: reloadHTTPTimeout}
 	resp, err := client.Post(url, "application/json", nil)
@@ -108,26 +127,38 @@ func callReloadAPI(st *state.DaemonState) error {
 		return fmt.Errorf("parsing response: %w", err)
 	}
 
+	if len(result.Dependents) > 0 {
+		servers := make([]string, 0, len(result.Dependents))
+		for server := range result.Dependents {
+			servers = append(servers, server)
+		}
+		sort.Strings(servers)
+		out.Println("Reload refused: removed MCP servers are used by skills")
+		for _, server := range servers {
+			out.Print("  %s: %s\n", server, strings.Join(result.Dependents[server], ", "))
+		}
+		return fmt.Errorf("reload refused: skills depend on removed servers (use --force to remove them anyway)")
+	}
 	if !result.Success {
 		return fmt.Errorf("reload failed: %s", result.Message)
 	}
 
 	// Print results
-	fmt.Printf("Stack '%s' reloaded successfully\n", st.StackName)
+	out.Print("Stack '%s' reloaded successfully\n", st.StackName)
 	if len(result.Added) > 0 {
-		fmt.Printf("  Added: %v\n", result.Added)
+		out.Print("  Added: %v\n", result.Added)
 	}
 	if len(result.Removed) > 0 {
-		fmt.Printf("  Removed: %v\n", result.Removed)
+		out.Print("  Removed: %v\n", result.Removed)
 	}
 	if len(result.Modified) > 0 {
-		fmt.Printf("  Modified: %v\n", result.Modified)
+		out.Print("  Modified: %v\n", result.Modified)
 	}
 	if len(result.Errors) > 0 {
-		fmt.Printf("  Errors: %v\n", result.Errors)
+		out.Print("  Errors: %v\n", result.Errors)
 	}
 	if result.Message != "" && len(result.Added)+len(result.Removed)+len(result.Modified) == 0 {
-		fmt.Printf("  %s\n", result.Message)
+		out.Print("  %s\n", result.Message)
 	}
 
 	return nil
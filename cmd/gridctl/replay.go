@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+	"github.com/gridctl/gridctl/pkg/mcp"
+	"github.com/gridctl/gridctl/pkg/output"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+const replayHTTPTimeout = 60 * time.Second
+
+// Exit codes — matched against the optimize/limits conventions so CI
+// scripts can rely on a stable contract.
+const (
+	replayExitOK             = 0
+	replayExitFailures       = 1
+	replayExitInfrastructure = 2
+)
+
+var (
+	replayStack  string
+	replayURL    string
+	replayFormat string
+	replayToken  string
+	replayJSON   *bool
+	replayPlain  *bool
+)
+
+// replayResult is the outcome of re-sending one recorded request.
+type replayResult struct {
+	Method     string `json:"method"`
+	Target     string `json:"target,omitempty"`
+	OK         bool   `json:"ok"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"durationMs"`
+}
+
+// replayReport is the machine-readable shape of `gridctl replay --json`.
+type replayReport struct {
+	File     string         `json:"file"`
+	Gateway  string         `json:"gateway"`
+	Sent     int            `json:"sent"`
+	Failed   int            `json:"failed"`
+	Requests []replayResult `json:"requests"`
+}
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <session.jsonl>",
+	Short: "Re-send a recorded MCP session against a gateway",
+	Long: `Re-send the requests of one recorded MCP session, in order, against a
+running gateway and report each outcome. Run it after an upgrade to check
+that the same traffic still succeeds.
+
+Sessions are recorded with 'gateway.record_requests: true', one JSONL file
+per session under ~/.gridctl/requests/<stack>/. The recorded initialize is
+replayed first, so the new session carries the same client identity (and
+so the same client scope and group). Params were redacted at record time:
+a tool call whose arguments held a secret is replayed with [REDACTED] in
+its place and may fail for that reason alone.
+
+A request fails when the gateway answers with a JSON-RPC error, an HTTP
+error, or a tool result with isError set.
+
+A gateway with gateway.auth is called with --token, else
+$GRIDCTL_GATEWAY_TOKEN, else (for a local stack) the token in its stack file.
+
+Exit codes:
+  0  every request succeeded
+  1  one or more requests failed
+  2  infrastructure error (gateway unreachable, unreadable file)`,
+	Example: `  gridctl replay ~/.gridctl/requests/prod/5f2c.jsonl
+  gridctl replay session.jsonl --url http://staging:8180 --token "$STAGING_TOKEN"
+  gridctl replay session.jsonl --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		if replayFormat, err = resolveFormat(replayFormat, cmd.Flags().Changed("format"), *replayJSON); err != nil {
+			return err
+		}
+		if err := resolvePlain(*replayPlain, replayFormat); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			exitWithError(cmd, replayExitInfrastructure, fmt.Errorf("replay: %w", err))
+		}
+		records, err := mcp.ReadRecordedRequests(data)
+		if err != nil {
+			return usageErrorf("replay: %s: %v", args[0], err)
+		}
+
+		base := strings.TrimRight(replayURL, "/")
+		stackFile := ""
+		if base == "" {
+			st, err := resolveRunningState("replay", replayStack)
+			if err != nil {
+				exitWithError(cmd, replayExitInfrastructure, err)
+			}
+			base = fmt.Sprintf("http://localhost:%d", st.Port)
+			stackFile = st.StackFile
+		}
+
+		results, err := replaySession(base, records, resolveGatewayCredential(replayToken, stackFile))
+		if err != nil {
+			exitWithError(cmd, replayExitInfrastructure, err)
+		}
+		report := replayReport{File: args[0], Gateway: base, Sent: len(results), Requests: results}
+		for _, r := range results {
+			if !r.OK {
+				report.Failed++
+			}
+		}
+
+		if strings.EqualFold(replayFormat, "json") {
+			if err := output.EncodeJSON(os.Stdout, report); err != nil {
+				return err
+			}
+		} else {
+			renderReplayReport(os.Stdout, report, *replayPlain)
+		}
+		if report.Failed > 0 {
+			exitNow(replayExitFailures)
+		}
+		return nil
+	},
+}
+
+func init() {
+	replayCmd.Flags().StringVarP(&replayStack, "stack", "s", "", "Stack to replay against (auto-detected when only one stack is running)")
+	replayCmd.Flags().StringVar(&replayURL, "url", "", "Gateway base URL to replay against instead of a local stack")
+	replayCmd.Flags().StringVar(&replayToken, "token", "", gatewayTokenFlagUsage)
+	replayCmd.Flags().StringVar(&replayFormat, "format", "", "Output format: 'json' for machine-readable output (default: table)")
+	replayJSON = addJSONAlias(replayCmd)
+	replayPlain = addPlainFlag(replayCmd)
+}
+
+// replaySession opens a fresh session on the gateway's /mcp endpoint and
+// re-sends every recorded request in order. The recorded initialize (or a
+// default one when the recording has none) opens the session and is not
+// reported. Every request carries cred. An error means the session could
+// not be opened at all.
+func replaySession(base string, records []mcp.RecordedRequest, cred gatewayCredential) ([]replayResult, error) {
+	client := &http.Client{Timeout: replayHTTPTimeout}
+	endpoint := base + "/mcp"
+
+	initParams := json.RawMessage(`{"protocolVersion":"` + mcp.MCPProtocolVersion + `","capabilities":{},"clientInfo":{"name":"gridctl-replay","version":"` + version + `"}}`)
+	if len(records) > 0 && records[0].Method == "initialize" {
+		if len(records[0].Params) > 0 {
+			initParams = records[0].Params
+		}
+		records = records[1:]
+	}
+	resp, header, err := replayPost(client, endpoint, "", cred, jsonrpc.Request{JSONRPC: "2.0", ID: rawID(0), Method: "initialize", Params: initParams})
+	if err != nil {
+		return nil, fmt.Errorf("replay: initialize: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("replay: initialize: %s", resp.Error.Message)
+	}
+	session := header.Get("Mcp-Session-Id")
+	if session == "" {
+		return nil, fmt.Errorf("replay: initialize: gateway returned no Mcp-Session-Id")
+	}
+	defer replayDelete(client, endpoint, session, cred)
+
+	results := make([]replayResult, 0, len(records))
+	for i, rec := range records {
+		if rec.Method == "initialize" {
+			continue
+		}
+		id := rec.ID
+		if id == nil && !strings.HasPrefix(rec.Method, "notifications/") {
+			id = rawID(i + 1)
+		}
+		result := replayResult{Method: rec.Method, Target: replayTarget(rec)}
+		start := time.Now()
+		resp, _, err := replayPost(client, endpoint, session, cred, jsonrpc.Request{JSONRPC: "2.0", ID: id, Method: rec.Method, Params: rec.Params})
+		result.DurationMS = time.Since(start).Milliseconds()
+		switch {
+		case err != nil:
+			result.Error = err.Error()
+		case resp.Error != nil:
+			result.Error = fmt.Sprintf("JSON-RPC %d: %s", resp.Error.Code, resp.Error.Message)
+		case rec.Method == "tools/call" && toolResultIsError(resp.Result):
+			result.Error = "tool returned isError"
+		default:
+			result.OK = true
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// replayPost sends one JSON-RPC request and decodes the response.
+func replayPost(client *http.Client, endpoint, session string, cred gatewayCredential, req jsonrpc.Request) (jsonrpc.Response, http.Header, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return jsonrpc.Response{}, nil, err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return jsonrpc.Response{}, nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
+	cred.apply(httpReq)
+	if session != "" {
+		httpReq.Header.Set("Mcp-Session-Id", session)
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return jsonrpc.Response{}, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return jsonrpc.Response{}, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return jsonrpc.Response{}, resp.Header, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	var out jsonrpc.Response
+	if err := json.Unmarshal(data, &out); err != nil {
+		return jsonrpc.Response{}, resp.Header, fmt.Errorf("parsing response: %w", err)
+	}
+	return out, resp.Header, nil
+}
+
+// replayDelete ends the replay session. Failures are ignored: the gateway
+// expires idle sessions on its own.
+func replayDelete(client *http.Client, endpoint, session string, cred gatewayCredential) {
+	req, err := http.NewRequest(http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return
+	}
+	cred.apply(req)
+	req.Header.Set("Mcp-Session-Id", session)
+	if resp, err := client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// replayTarget names what a request acted on: the tool of a tools/call,
+// the prompt of a prompts/get, or the URI of a resources/read.
+func replayTarget(rec mcp.RecordedRequest) string {
+	var p struct {
+		Name string `json:"name"`
+		URI  string `json:"uri"`
+	}
+	if len(rec.Params) == 0 || json.Unmarshal(rec.Params, &p) != nil {
+		return ""
+	}
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.URI
+}
+
+func toolResultIsError(raw json.RawMessage) bool {
+	var r struct {
+		IsError bool `json:"isError"`
+	}
+	return json.Unmarshal(raw, &r) == nil && r.IsError
+}
+
+func rawID(n int) *json.RawMessage {
+	id := json.RawMessage(fmt.Sprintf("%d", n))
+	return &id
+}
+
+// renderReplayReport prints one row per replayed request and a summary.
+func renderReplayReport(w io.Writer, report replayReport, plain bool) {
+	if len(report.Requests) == 0 {
+		fmt.Fprintln(w, "No requests to replay.")
+		return
+	}
+	t := output.NewTableWriter(w, plain)
+	t.AppendHeader(table.Row{"#", "METHOD", "TARGET", "RESULT", "TIME"})
+	for i, r := range report.Requests {
+		outcome := "ok"
+		if !r.OK {
+			outcome = "FAIL: " + r.Error
+		}
+		t.AppendRow(table.Row{i + 1, r.Method, r.Target, outcome, fmt.Sprintf("%dms", r.DurationMS)})
+	}
+	t.Render()
+	fmt.Fprintf(w, "\n%d request(s) replayed against %s, %d failed\n", report.Sent, report.Gateway, report.Failed)
+}
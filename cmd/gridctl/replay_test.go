@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+func TestReplaySession(t *testing.T) {
+	server := httptest.NewServer(mcp.NewStreamableHTTPServer(mcp.NewGateway(), nil))
+	defer server.Close()
+
+	records := []mcp.RecordedRequest{
+		{Method: "initialize", Params: json.RawMessage(`{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"cursor","version":"1"}}`)},
+		{Method: "notifications/initialized"},
+		{Method: "tools/list"},
+		{Method: "nope/unknown"},
+	}
+	results, err := replaySession(server.URL, records, gatewayCredential{})
+	if err != nil {
+		t.Fatalf("replaySession: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("results = %+v, want 3 (initialize is not reported)", results)
+	}
+	if !results[0].OK || !results[1].OK {
+		t.Errorf("notification and tools/list should succeed: %+v", results[:2])
+	}
+	if results[2].OK || !strings.Contains(results[2].Error, "Unknown method") {
+		t.Errorf("unknown method should fail: %+v", results[2])
+	}
+
+	var buf bytes.Buffer
+	renderReplayReport(&buf, replayReport{Gateway: server.URL, Sent: 3, Failed: 1, Requests: results}, true)
+	if !strings.Contains(buf.String(), "3 request(s) replayed") || !strings.Contains(buf.String(), "FAIL") {
+		t.Errorf("report:\n%s", buf.String())
+	}
+}
+
+func TestReplaySession_Unreachable(t *testing.T) {
+	server := httptest.NewServer(nil)
+	server.Close()
+	if _, err := replaySession(server.URL, nil, gatewayCredential{}); err == nil {
+		t.Error("expected an error against a closed gateway")
+	}
+}
+
+func TestReplaySession_GatewayToken(t *testing.T) {
+	mcpServer := mcp.NewStreamableHTTPServer(mcp.NewGateway(), nil)
+	var unauthorized int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			unauthorized++
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mcpServer.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	records := []mcp.RecordedRequest{{Method: "tools/list"}}
+	if _, err := replaySession(server.URL, records, gatewayCredential{}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("without a token: err = %v, want a 401", err)
+	}
+	unauthorized = 0
+	results, err := replaySession(server.URL, records, resolveGatewayCredential("s3cret", ""))
+	if err != nil {
+		t.Fatalf("with a token: %v", err)
+	}
+	if len(results) != 1 || !results[0].OK {
+		t.Errorf("results = %+v, want tools/list to succeed", results)
+	}
+	if unauthorized != 0 {
+		t.Errorf("%d request(s) went out without the token", unauthorized)
+	}
+}
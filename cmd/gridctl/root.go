@@ -19,6 +19,7 @@ import (
 var (
 	runtimeFlag  string
 	noColorFlag  bool
+	plainFlag    bool
 	logLevelFlag string
 	// logLevel is the parsed global --log-level, consumed by subcommands
 	// that configure their own slog handlers (apply, plan, serve).
@@ -53,7 +54,9 @@ them via a single MCP gateway.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		runningCmd = cmd
 		output.SetNoColor(noColorFlag)
+		output.SetPlainMode(plainFlag || output.DetectPlainMode(os.Stdout))
 		lvl, err := parseLogLevelFlag(logLevelFlag)
 		if err != nil {
 			return err
@@ -77,13 +80,17 @@ func parseLogLevelFlag(s string) (slog.Level, error) {
 	case "debug", "info", "warn", "warning", "error":
 		return logging.ParseLevel(s), nil
 	}
-	return 0, fmt.Errorf("invalid --log-level %q (allowed: debug, info, warn, error)\nRun 'gridctl --help' for usage", s)
+	return 0, usageErrorf("invalid --log-level %q (allowed: debug, info, warn, error)\nRun 'gridctl --help' for usage", s)
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&runtimeFlag, "runtime", "", "Container runtime to use (docker, podman). Auto-detected if not set.")
 	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output (also honors NO_COLOR and TERM=dumb)")
 	rootCmd.PersistentFlags().StringVar(&logLevelFlag, "log-level", "info", "Minimum log level: debug, info, warn, error")
+	// Commands with their own --plain shadow this one (cobra prefers the
+	// local definition): on table commands it already means plain tables,
+	// and var get/export keep their "show unmasked value" meaning.
+	rootCmd.PersistentFlags().BoolVar(&plainFlag, "plain", false, "Plain output: no color, spinners, or box-drawing, and no log timestamps (on by default when stdout is not a terminal; GRIDCTL_PLAIN=1 or 0 overrides)")
 
 	initHelp()
 
@@ -101,43 +108,52 @@ func init() {
 
 	// Flag mistakes keep a short usage pointer; runtime errors do not.
 	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
-		return fmt.Errorf("%w\nRun '%s --help' for usage", err, cmd.CommandPath())
+		return withExitCode(exitConfig, fmt.Errorf("%w\nRun '%s --help' for usage", err, cmd.CommandPath()))
 	})
 
 	for cmd, group := range map[*cobra.Command]string{
-		initCmd:      groupStack,
-		applyCmd:     groupStack,
-		planCmd:      groupStack,
-		validateCmd:  groupStack,
-		reloadCmd:    groupStack,
-		destroyCmd:   groupStack,
-		exportCmd:    groupStack,
-		statusCmd:    groupStack,
-		serveCmd:     groupStack,
-		stopCmd:      groupStack,
-		logsCmd:      groupStack,
-		searchCmd:    groupCatalog,
-		addCmd:       groupCatalog,
-		linkCmd:      groupClients,
-		groupsCmd:    groupClients,
-		unlinkCmd:    groupClients,
-		importCmd:    groupClients,
-		ctxCmd:       groupClients,
-		skillCmd:     groupSkills,
-		activateCmd:  groupSkills,
-		varCmd:       groupConfig,
-		vaultCmd:     groupConfig, // hidden; grouped for completeness
-		pinsCmd:      groupConfig,
-		authCmd:      groupConfig,
-		tracesCmd:    groupObserve,
-		telemetryCmd: groupObserve,
-		optimizeCmd:  groupObserve,
-		limitsCmd:    groupObserve,
-		infoCmd:      groupSystem,
-		doctorCmd:    groupSystem,
-		openCmd:      groupSystem,
-		versionCmd:   groupSystem,
-		upgradeCmd:   groupSystem,
+		initCmd:          groupStack,
+		applyCmd:         groupStack,
+		planCmd:          groupStack,
+		validateCmd:      groupStack,
+		reloadCmd:        groupStack,
+		destroyCmd:       groupStack,
+		exportCmd:        groupStack,
+		statusCmd:        groupStack,
+		serveCmd:         groupStack,
+		stopCmd:          groupStack,
+		logsCmd:          groupStack,
+		searchCmd:        groupCatalog,
+		addCmd:           groupCatalog,
+		checkServerCmd:   groupCatalog,
+		linkCmd:          groupClients,
+		groupsCmd:        groupClients,
+		unlinkCmd:        groupClients,
+		importCmd:        groupClients,
+		ctxCmd:           groupClients,
+		skillCmd:         groupSkills,
+		activateCmd:      groupSkills,
+		varCmd:           groupConfig,
+		configCmd:        groupConfig,
+		vaultCmd:         groupConfig, // hidden; grouped for completeness
+		pinsCmd:          groupConfig,
+		authCmd:          groupConfig,
+		sshCmd:           groupConfig,
+		tracesCmd:        groupObserve,
+		telemetryCmd:     groupObserve,
+		optimizeCmd:      groupObserve,
+		analyzeCmd:       groupObserve,
+		replayCmd:        groupObserve,
+		limitsCmd:        groupObserve,
+		topCmd:           groupObserve,
+		infoCmd:          groupSystem,
+		doctorCmd:        groupSystem,
+		discoverCmd:      groupSystem,
+		supportBundleCmd: groupSystem,
+		openCmd:          groupSystem,
+		demoCmd:          groupSystem,
+		versionCmd:       groupSystem,
+		upgradeCmd:       groupSystem,
 	} {
 		cmd.GroupID = group
 		rootCmd.AddCommand(cmd)
@@ -145,12 +161,16 @@ func init() {
 }
 
 func Execute() {
+	registerStackFlagCompletion(rootCmd)
+
 	// ExecuteC returns the command that was (or would have been) executed,
 	// so help pointers name the right command path.
 	cmd, err := rootCmd.ExecuteC()
+	code := exitCodeFor(err)
+	recordUsage(cmd, code)
 	if err != nil {
-		printCLIError(os.Stderr, cmd, err)
-		os.Exit(1)
+		reportCLIError(os.Stdout, os.Stderr, cmd, err, code)
+		os.Exit(code)
 	}
 }
 
@@ -210,18 +230,34 @@ func isUsageMistake(err error) bool {
 }
 
 var serveCmd = &cobra.Command{
-	Use:   "serve",
+	Use:   "serve [stack.yaml]",
 	Short: "Start the API server and web UI without a stack",
 	Long: `Starts the gridctl API server and web UI in stackless mode.
 
 Vault and wizard endpoints are fully functional. Stack-dependent endpoints
-return 503 until a stack is loaded via 'gridctl apply <stack.yaml>'.`,
+return 503 until a stack is loaded via 'gridctl apply <stack.yaml>'.
+
+With --in-cluster, gridctl runs as the main process of a container and
+manages sibling containers through the mounted Docker socket. It stays in
+the foreground, deploys the stack file when one is given, and joins each
+MCP server's network to dial it by name instead of through host ports.`,
+	Example: `  gridctl serve
+  docker run -v /var/run/docker.sock:/var/run/docker.sock -p 8180:8180 \
+    -v ./stack.yaml:/stack.yaml gridctl serve --in-cluster /stack.yaml`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if serveInCluster {
+			return runServeInCluster(args)
+		}
+		if len(args) == 1 {
+			return fmt.Errorf("serve takes a stack file only with --in-cluster; use 'gridctl apply %s'", args[0])
+		}
 		return runServeStackless()
 	},
 }
 
 func init() {
+	serveCmd.Flags().BoolVar(&serveInCluster, "in-cluster", false, "Run as a container managing sibling containers via the mounted Docker socket")
 	serveCmd.Flags().IntVarP(&applyPort, "port", "p", 8180, "Port for the API server and web UI")
 	serveCmd.Flags().BoolVarP(&applyForeground, "foreground", "f", false, "Run in foreground (don't daemonize)")
 	serveCmd.Flags().BoolVar(&applyDaemonChild, "daemon-child", false, "Internal flag for daemon process")
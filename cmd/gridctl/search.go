@@ -66,8 +66,7 @@ Exit codes:
 			err = fmt.Errorf("invalid --source %q (allowed: curated, registry, all)", searchSource)
 		}
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(searchExitInfrastructure)
+			exitWithError(cmd, searchExitInfrastructure, err)
 		}
 		query := ""
 		if len(args) == 1 {
@@ -122,7 +121,7 @@ func runSearch(ctx context.Context, query, format, source string, plain bool) er
 		curated, err = catalog.FilterCurated(query)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err)
-			os.Exit(searchExitInfrastructure)
+			exitNow(searchExitInfrastructure)
 		}
 	}
 
@@ -162,7 +161,7 @@ func runSearch(ctx context.Context, query, format, source string, plain bool) er
 	if jsonMode {
 		if err := output.EncodeJSON(os.Stdout, doc); err != nil {
 			fmt.Fprintln(os.Stderr, err)
-			os.Exit(searchExitInfrastructure)
+			exitNow(searchExitInfrastructure)
 		}
 		return nil
 	}
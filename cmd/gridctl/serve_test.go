@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/runtime"
+)
+
+func TestServe_StackFileRequiresInCluster(t *testing.T) {
+	_, _, err := executeCommand(t, "serve", "stack.yaml")
+	if err == nil || !strings.Contains(err.Error(), "--in-cluster") {
+		t.Errorf("err = %v, want a pointer to --in-cluster", err)
+	}
+}
+
+func TestServe_InClusterOutsideContainer(t *testing.T) {
+	old := selfContainerID
+	selfContainerID = func() (string, error) { return "", runtime.ErrNotInContainer }
+	t.Cleanup(func() {
+		selfContainerID = old
+		serveInCluster = false
+	})
+
+	_, _, err := executeCommand(t, "serve", "--in-cluster")
+	if !errors.Is(err, runtime.ErrNotInContainer) {
+		t.Errorf("err = %v, want ErrNotInContainer", err)
+	}
+	if inClusterID != "" {
+		t.Errorf("inClusterID = %q, want unset on failure", inClusterID)
+	}
+}
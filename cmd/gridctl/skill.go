@@ -325,12 +325,16 @@ func runSkillAdd(repoURL string) error {
 		return gitpkg.RedactError(classified)
 	}
 
-	printer := output.New()
+	return printImportResult(output.New(), result)
+}
 
+// printImportResult reports a git import's imported and skipped skills and
+// fails when nothing was imported.
+func printImportResult(printer *output.Printer, result *skills.ImportResult) error {
 	for _, imported := range result.Imported {
 		printer.Info("Imported skill", "name", imported.Name)
 		if len(imported.Findings) > 0 {
-			fmt.Print(skills.FormatFindings(imported.Findings))
+			printer.Print("%s", skills.FormatFindings(imported.Findings))
 		}
 	}
 
@@ -350,6 +354,7 @@ func runSkillAdd(repoURL string) error {
 }
 
 func runSkillList() error {
+	out := output.New()
 	store, err := loadRegistry()
 	if err != nil {
 		return err
@@ -357,7 +362,7 @@ func runSkillList() error {
 
 	allSkills := store.ListSkills()
 	if len(allSkills) == 0 {
-		fmt.Println("No skills in registry")
+		out.Println("No skills in registry")
 		return nil
 	}
 
@@ -395,7 +400,7 @@ func runSkillList() error {
 
 	if skillListFormat == "json" {
 		data, _ := json.MarshalIndent(entries, "", "  ")
-		fmt.Println(string(data))
+		out.Println(string(data))
 		return nil
 	}
 
@@ -412,7 +417,7 @@ func runSkillList() error {
 
 	// Show update notice if available
 	if notice := skills.FormatUpdateNotice(); notice != "" {
-		fmt.Print(notice)
+		out.Hint("%s", strings.TrimSpace(notice))
 	}
 
 	return nil
@@ -560,12 +565,15 @@ func runSkillUpdate(name string) error {
 			names = append(names, n)
 		}
 		sort.Strings(names)
-		fmt.Printf("Skipped pinned sources: %s (use 'gridctl skill update <name>' to force)\n", strings.Join(names, ", "))
+		printer.Print("Skipped pinned sources: %s (use 'gridctl skill update <name>' to force)\n", strings.Join(names, ", "))
 	}
 
-	fmt.Printf("Synced %d source(s), %d skill(s) updated, %d failed, %d pinned\n",
+	printer.Print("Synced %d source(s), %d skill(s) updated, %d failed, %d pinned\n",
 		len(syncedSources), updatedSkills, len(failedSources), len(pinnedSources))
 
+	if len(failedSources) > 0 {
+		return withExitCode(exitPartial, fmt.Errorf("%d source(s) failed to update", len(failedSources)))
+	}
 	return nil
 }
 
@@ -627,14 +635,14 @@ func runSkillInfo(name string) error {
 		)
 
 		if !info.LastChecked.IsZero() {
-			fmt.Printf("  Last checked: %s\n", info.LastChecked.Format(time.RFC3339))
+			printer.Print("  Last checked: %s\n", info.LastChecked.Format(time.RFC3339))
 		}
 	}
 
 	if sk, err := store.GetSkill(name); err == nil && len(sk.AcceptanceCriteria) > 0 {
-		fmt.Println("\nAcceptance Criteria:")
+		printer.Println("\nAcceptance Criteria:")
 		for i, c := range sk.AcceptanceCriteria {
-			fmt.Printf("  %d. %s\n", i+1, c)
+			printer.Print("  %d. %s\n", i+1, c)
 		}
 	}
 
@@ -642,6 +650,7 @@ func runSkillInfo(name string) error {
 }
 
 func runSkillValidate(name string) error {
+	out := output.New()
 	store, err := loadRegistry()
 	if err != nil {
 		return err
@@ -656,16 +665,16 @@ func runSkillValidate(name string) error {
 
 	if !result.Valid() {
 		for _, e := range result.Errors {
-			fmt.Printf("  ✗ %s: %s\n", name, e)
+			out.Print("  ✗ %s: %s\n", name, e)
 		}
 	}
 
 	for _, w := range result.Warnings {
-		fmt.Printf("⚠  %s: %s\n", name, w)
+		out.Print("⚠  %s: %s\n", name, w)
 	}
 
 	if result.Valid() && len(result.Warnings) == 0 {
-		fmt.Printf("✓ %s is valid\n", name)
+		out.Print("✓ %s is valid\n", name)
 	}
 
 	return nil
@@ -711,8 +720,8 @@ func runSkillTry(repoURL string) error {
 		return fmt.Errorf("no skills were imported")
 	}
 
-	fmt.Printf("\nSkill(s) will be automatically removed in %s\n", duration)
-	fmt.Println("Press Ctrl+C to remove immediately and exit.")
+	printer.Print("\nSkill(s) will be automatically removed in %s\n", duration)
+	printer.Println("Press Ctrl+C to remove immediately and exit.")
 
 	// Countdown with periodic updates
 	deadline := time.Now().Add(duration)
@@ -742,16 +751,16 @@ func runSkillTry(repoURL string) error {
 	for {
 		select {
 		case <-sigCh:
-			fmt.Println("\nCleaning up ephemeral skills...")
+			printer.Println("\nCleaning up ephemeral skills...")
 			cleanup()
 			return nil
 		case <-ticker.C:
 			remaining := time.Until(deadline).Round(time.Second)
 			if remaining > 0 {
-				fmt.Printf("  ⏱ %s remaining before auto-cleanup\n", remaining)
+				printer.Print("  ⏱ %s remaining before auto-cleanup\n", remaining)
 			}
 		case <-time.After(time.Until(deadline)):
-			fmt.Println("\nDuration expired. Cleaning up ephemeral skills...")
+			printer.Println("\nDuration expired. Cleaning up ephemeral skills...")
 			cleanup()
 			return nil
 		}
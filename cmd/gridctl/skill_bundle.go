@@ -0,0 +1,234 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gridctl/gridctl/pkg/output"
+	"github.com/gridctl/gridctl/pkg/registry"
+	"github.com/gridctl/gridctl/pkg/skills"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	skillExportOutput string
+	skillExportFormat string
+	skillExportForce  bool
+
+	skillImportRename     string
+	skillImportForce      bool
+	skillImportTrust      bool
+	skillImportNoActivate bool
+)
+
+var skillExportCmd = &cobra.Command{
+	Use:   "export <name>...",
+	Short: "Export a skill as a portable .tar.gz bundle",
+	Long: `Bundle a skill's SKILL.md, its supporting files (scripts/, references/,
+assets/, and anything else in the skill directory), and a manifest with
+per-file checksums into a gzipped tarball that 'gridctl skill import'
+installs on another gateway.
+
+Hidden files, including the git import sidecar, are not bundled.
+
+With --format claude, each named skill is written as a directory in the
+Claude Skills layout (<dir>/<name>/SKILL.md) instead, ready to commit under
+a project's .claude/skills/ or copy to ~/.claude/skills/. Only frontmatter
+Claude understands is kept; gridctl-only fields such as tags, arguments,
+and acceptance_criteria are dropped with a warning.`,
+	Example: `  gridctl skill export deploy
+  gridctl skill export deploy -o /tmp/deploy.tar.gz
+  gridctl skill export deploy -o - | ssh host gridctl skill import -
+  gridctl skill export deploy review --format claude
+  gridctl skill export deploy --format claude -o ~/.claude/skills`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch skillExportFormat {
+		case "bundle":
+			if len(args) != 1 {
+				return fmt.Errorf("bundle export takes exactly one skill name")
+			}
+			return runSkillExport(args[0])
+		case "claude":
+			return runSkillExportClaude(args)
+		default:
+			return fmt.Errorf("unknown format %q (valid: bundle, claude)", skillExportFormat)
+		}
+	},
+}
+
+var skillImportCmd = &cobra.Command{
+	Use:   "import <file.tar.gz|dir>",
+	Short: "Import a skill from a bundle",
+	Long: `Install a skill from a bundle created by 'gridctl skill export'. The
+archive's checksums are verified, and the skill is validated and
+security-scanned like a git import before anything is written.
+
+A directory in the Claude Skills layout is imported too: either one skill
+directory holding SKILL.md, or a skills root such as .claude/skills/ with
+one skill per subdirectory. Claude-only frontmatter keys (model,
+disable-model-invocation, ...) are kept in metadata under a "claude-"
+prefix so a later --format claude export restores them.
+
+The skill is activated on import unless --no-activate is set. An existing
+skill of the same name is left alone unless --force is set; --rename
+imports under a different name instead. Pass '-' to read from stdin.`,
+	Example: `  gridctl skill import deploy.tar.gz
+  gridctl skill import deploy.tar.gz --rename deploy-staging
+  gridctl skill import - < deploy.tar.gz
+  gridctl skill import .claude/skills
+  gridctl skill import ~/.claude/skills/review`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSkillImport(args[0])
+	},
+}
+
+func init() {
+	skillExportCmd.Flags().StringVarP(&skillExportOutput, "output", "o", "", "Write the bundle to this path, or '-' for stdout (default: <name>.tar.gz; with --format claude, the skills directory, default .claude/skills)")
+	skillExportCmd.Flags().StringVar(&skillExportFormat, "format", "bundle", "Export format: bundle or claude")
+	skillExportCmd.Flags().BoolVar(&skillExportForce, "force", false, "With --format claude, replace existing skill directories")
+
+	skillImportCmd.Flags().StringVar(&skillImportRename, "rename", "", "Import the skill under a different name")
+	skillImportCmd.Flags().BoolVar(&skillImportForce, "force", false, "Overwrite an existing skill of the same name")
+	skillImportCmd.Flags().BoolVar(&skillImportTrust, "trust", false, "Skip security scan confirmation")
+	skillImportCmd.Flags().BoolVar(&skillImportNoActivate, "no-activate", false, "Import as draft instead of active")
+
+	skillCmd.AddCommand(skillExportCmd)
+	skillCmd.AddCommand(skillImportCmd)
+}
+
+func runSkillExport(name string) error {
+	store, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+	if _, err := store.GetSkill(name); err != nil {
+		return err
+	}
+
+	if skillExportOutput == "-" {
+		return store.ExportBundle(name, os.Stdout)
+	}
+	path := skillExportOutput
+	if path == "" {
+		path = name + ".tar.gz"
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating bundle file: %w", err)
+	}
+	if err := store.ExportBundle(name, f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing bundle file: %w", err)
+	}
+
+	output.New().Info("Exported skill", "name", name, "file", path)
+	return nil
+}
+
+func runSkillExportClaude(names []string) error {
+	store, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+	if skillExportOutput == "-" {
+		return fmt.Errorf("--format claude writes directories and cannot export to stdout")
+	}
+	root := skillExportOutput
+	if root == "" {
+		root = filepath.Join(".claude", "skills")
+	}
+
+	printer := output.New()
+	for _, name := range names {
+		dir, dropped, err := store.ExportClaude(name, root, skillExportForce)
+		if errors.Is(err, registry.ErrExists) {
+			return fmt.Errorf("%w (use --force to replace it)", err)
+		}
+		if err != nil {
+			return err
+		}
+		printer.Info("Exported skill", "name", name, "dir", dir)
+		if len(dropped) > 0 {
+			printer.Warn("Dropped gridctl-only fields", "name", name, "fields", strings.Join(dropped, ", "))
+		}
+	}
+	return nil
+}
+
+func runSkillImport(path string) error {
+	var bundles []*registry.Bundle
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		bundles, err = registry.ReadClaudeSkills(path)
+		if err != nil {
+			return err
+		}
+		if len(bundles) > 1 && skillImportRename != "" {
+			return fmt.Errorf("--rename needs a single skill, but %s holds %d", path, len(bundles))
+		}
+	} else {
+		var in io.Reader = os.Stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("opening bundle: %w", err)
+			}
+			defer f.Close()
+			in = f
+		}
+		bundle, err := registry.ReadBundle(in)
+		if err != nil {
+			return err
+		}
+		bundles = []*registry.Bundle{bundle}
+	}
+
+	store, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+	result := &skills.ImportResult{}
+	importer := newImporter(store)
+	for _, bundle := range bundles {
+		r, err := importer.ImportBundle(bundle, skills.ImportOptions{
+			Trust:      skillImportTrust,
+			NoActivate: skillImportNoActivate,
+			Force:      skillImportForce,
+			Rename:     skillImportRename,
+		})
+		if err != nil {
+			return err
+		}
+		result.Imported = append(result.Imported, r.Imported...)
+		result.Skipped = append(result.Skipped, r.Skipped...)
+		result.Warnings = append(result.Warnings, r.Warnings...)
+	}
+
+	printer := output.New()
+	for _, imported := range result.Imported {
+		printer.Info("Imported skill", "name", imported.Name)
+		if len(imported.Findings) > 0 {
+			printer.Print("%s", skills.FormatFindings(imported.Findings))
+		}
+	}
+	for _, skipped := range result.Skipped {
+		printer.Warn("Skipped skill", "name", skipped.Name, "reason", skipped.Reason)
+	}
+	for _, warning := range result.Warnings {
+		printer.Warn(warning)
+	}
+	if len(result.Imported) == 0 {
+		return fmt.Errorf("no skills were imported")
+	}
+	return nil
+}
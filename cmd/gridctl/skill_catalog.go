@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+
+	gitpkg "github.com/gridctl/gridctl/pkg/git"
+	"github.com/gridctl/gridctl/pkg/output"
+	"github.com/gridctl/gridctl/pkg/skills"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	skillCatalogSource string
+
+	skillSearchFormat string
+	skillSearchJSON   *bool
+	skillSearchPlain  *bool
+
+	skillInstallRef        string
+	skillInstallRename     string
+	skillInstallForce      bool
+	skillInstallTrust      bool
+	skillInstallNoActivate bool
+	skillInstallAuthToken  string
+	skillInstallVaultKey   string
+	skillInstallSSHKey     string
+)
+
+// loadSkillCatalog reads the configured catalog index; a seam so tests run
+// without a network.
+var loadSkillCatalog = func(ctx context.Context, source string) (*skills.CatalogIndex, bool, error) {
+	return skills.NewCatalogClient(source, slog.Default()).Index(ctx)
+}
+
+const skillCatalogLong = `
+
+The catalog is a JSON index of skills published in git repositories. Its
+source is --catalog, then GRIDCTL_SKILL_CATALOG, then 'catalog:' in
+~/.gridctl/skills.yaml, and may be an http(s) URL of the index, a local
+file or directory, or a git repository with catalog.json at its root.`
+
+var skillSearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search the skill catalog",
+	Long: `Search the skill catalog by ID, description, or tag. Without a query,
+every entry is listed. Install a result with 'gridctl skill install <id>'.` + skillCatalogLong,
+	Example: `  gridctl skill search review
+  gridctl skill search --catalog https://skills.example.com/catalog.json
+  gridctl skill search --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveFormat(skillSearchFormat, cmd.Flags().Changed("format"), *skillSearchJSON)
+		if err != nil {
+			return err
+		}
+		if err := resolvePlain(*skillSearchPlain, format); err != nil {
+			return err
+		}
+		query := ""
+		if len(args) == 1 {
+			query = args[0]
+		}
+		return runSkillSearch(cmd.Context(), query, format, *skillSearchPlain)
+	},
+}
+
+var skillInstallCmd = &cobra.Command{
+	Use:   "install <org/skill>",
+	Short: "Install a skill from the skill catalog",
+	Long: `Look up a skill in the catalog and import it from its repository, like
+'gridctl skill add' with the catalog's repo, ref, and path. The skill is
+validated and security-scanned before anything is written, and is
+recorded as a remote skill so 'gridctl skill update' keeps it current.` + skillCatalogLong,
+	Example: `  gridctl skill install acme/code-review
+  gridctl skill install acme/code-review --ref v2.0.0 --no-activate`,
+	Args:    cobra.ExactArgs(1),
+	PreRunE: validateSkillAuthFlags(&skillInstallAuthToken, &skillInstallVaultKey),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSkillInstall(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{skillSearchCmd, skillInstallCmd} {
+		cmd.Flags().StringVar(&skillCatalogSource, "catalog", "", "Catalog index URL, path, or git repository (overrides skills.yaml)")
+	}
+
+	skillSearchCmd.Flags().StringVar(&skillSearchFormat, "format", "", "Output format (json)")
+	skillSearchJSON = addJSONAlias(skillSearchCmd)
+	skillSearchPlain = addPlainFlag(skillSearchCmd)
+
+	skillInstallCmd.Flags().StringVar(&skillInstallRef, "ref", "", "Git ref to install instead of the catalog's")
+	skillInstallCmd.Flags().StringVar(&skillInstallRename, "rename", "", "Install the skill under a different name")
+	skillInstallCmd.Flags().BoolVar(&skillInstallForce, "force", false, "Overwrite an existing skill of the same name")
+	skillInstallCmd.Flags().BoolVar(&skillInstallTrust, "trust", false, "Skip security scan confirmation")
+	skillInstallCmd.Flags().BoolVar(&skillInstallNoActivate, "no-activate", false, "Import as draft instead of active")
+	skillInstallCmd.Flags().StringVar(&skillInstallAuthToken, "auth-token", "", "Personal Access Token for a private skill repository (HTTPS only; not persisted)")
+	skillInstallCmd.Flags().StringVar(&skillInstallVaultKey, "vault-key", "", "Resolve the PAT from this vault key (e.g. GIT_TOKEN)")
+	skillInstallCmd.Flags().StringVar(&skillInstallSSHKey, "ssh-key", "", "Use an SSH private key at this path (SSH URLs only)")
+
+	skillCmd.AddCommand(skillSearchCmd)
+	skillCmd.AddCommand(skillInstallCmd)
+}
+
+// skillCatalog resolves the catalog source and loads its index, warning on
+// printer when a stale cached index is served.
+func skillCatalog(ctx context.Context, printer *output.Printer) (*skills.CatalogIndex, error) {
+	source, err := skills.ResolveCatalogSource(skillCatalogSource)
+	if err != nil {
+		return nil, err
+	}
+	idx, stale, err := loadSkillCatalog(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if stale {
+		printer.Warn("skill catalog unavailable; showing the cached index (may be stale)")
+	}
+	return idx, nil
+}
+
+func runSkillSearch(ctx context.Context, query, format string, plain bool) error {
+	// In JSON mode stdout carries exactly one document.
+	jsonMode := strings.EqualFold(format, "json")
+	printer := output.New()
+	if jsonMode {
+		printer = output.NewWithWriter(os.Stderr)
+	}
+
+	idx, err := skillCatalog(ctx, printer)
+	if err != nil {
+		return err
+	}
+	matches := idx.Search(query)
+
+	if jsonMode {
+		data, _ := json.MarshalIndent(matches, "", "  ")
+		output.New().Println(string(data))
+		return nil
+	}
+	if len(matches) == 0 {
+		printer.Println("No skills match")
+		return nil
+	}
+	t := output.NewTableWriter(os.Stdout, plain)
+	t.AppendHeader(table.Row{"ID", "Description", "Tags"})
+	for _, e := range matches {
+		t.AppendRow(table.Row{e.ID, truncate(e.Description, 60), strings.Join(e.Tags, ", ")})
+	}
+	t.Render()
+	return nil
+}
+
+func runSkillInstall(ctx context.Context, id string) error {
+	printer := output.New()
+	idx, err := skillCatalog(ctx, printer)
+	if err != nil {
+		return err
+	}
+	entry, err := idx.Find(id)
+	if err != nil {
+		return err
+	}
+
+	store, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+	authCfg, err := buildAuthConfigFromFlags(skillInstallAuthToken, skillInstallVaultKey, skillInstallSSHKey)
+	if err != nil {
+		return err
+	}
+	ref := entry.Ref
+	if skillInstallRef != "" {
+		ref = skillInstallRef
+	}
+
+	result, err := newImporter(store).Import(skills.ImportOptions{
+		Repo:       entry.Repo,
+		Ref:        ref,
+		Path:       entry.Path,
+		Trust:      skillInstallTrust,
+		NoActivate: skillInstallNoActivate,
+		Force:      skillInstallForce,
+		Rename:     skillInstallRename,
+		Auth:       authCfg,
+	})
+	if err != nil {
+		classified := gitpkg.ClassifyError(err)
+		printSkillAuthHint(classified)
+		return gitpkg.RedactError(classified)
+	}
+	return printImportResult(printer, result)
+}
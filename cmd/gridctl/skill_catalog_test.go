@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/skills"
+)
+
+func stubSkillCatalog(t *testing.T, idx *skills.CatalogIndex) {
+	t.Helper()
+	origLoad, origSource := loadSkillCatalog, skillCatalogSource
+	t.Cleanup(func() { loadSkillCatalog, skillCatalogSource = origLoad, origSource })
+	skillCatalogSource = "https://skills.example.com/catalog.json"
+	loadSkillCatalog = func(ctx context.Context, source string) (*skills.CatalogIndex, bool, error) {
+		return idx, false, nil
+	}
+}
+
+func TestRunSkillSearch_JSON(t *testing.T) {
+	stubSkillCatalog(t, &skills.CatalogIndex{Version: 1, Skills: []skills.CatalogEntry{
+		{ID: "acme/code-review", Description: "Review pull requests", Repo: "https://github.com/acme/skills", Tags: []string{"git"}},
+		{ID: "acme/deploy", Description: "Ship a release", Repo: "https://github.com/acme/skills"},
+	}})
+
+	out := captureStdout(t, func() {
+		if err := runSkillSearch(context.Background(), "review", "json", false); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var entries []skills.CatalogEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("stdout is not a single JSON document: %v\n%s", err, out)
+	}
+	if len(entries) != 1 || entries[0].ID != "acme/code-review" {
+		t.Errorf("entries = %+v, want acme/code-review only", entries)
+	}
+}
+
+func TestRunSkillInstall_UnknownID(t *testing.T) {
+	stubSkillCatalog(t, &skills.CatalogIndex{Version: 1})
+
+	err := runSkillInstall(context.Background(), "acme/missing")
+	if !errors.Is(err, skills.ErrCatalogEntryNotFound) {
+		t.Errorf("err = %v, want ErrCatalogEntryNotFound", err)
+	}
+}
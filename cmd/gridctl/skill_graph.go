@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const skillGraphHTTPTimeout = 10 * time.Second
+
+var (
+	skillGraphStack  string
+	skillGraphServer string
+	skillGraphFormat string
+)
+
+var skillGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show which skills depend on which tools and servers",
+	Long: `Print the dependency graph of registry skills on the tools they use and
+the MCP servers that provide them, from the running gateway.
+
+A skill depends on a tool when it names the prefixed tool
+(github__create_issue) in allowed-tools or mentions it in its body, and on
+a whole server through a 'github__*' allowed-tools entry. Body mentions
+are drawn dashed or dotted, and tools or servers named in allowed-tools
+that the gateway does not have are marked missing.
+
+Use --server before decommissioning a backend: it keeps only that server,
+its tools that skills use, and the skills that would lose them.`,
+	Example: `  gridctl skill graph | dot -Tsvg > skills.svg
+  gridctl skill graph --format mermaid
+  gridctl skill graph --server github --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch skillGraphFormat {
+		case "dot", "mermaid", "json":
+		default:
+			return usageErrorf("unknown format %q (want dot, mermaid, or json)", skillGraphFormat)
+		}
+		port, err := resolveRunningPort("skill graph", skillGraphStack)
+		if err != nil {
+			return withExitCode(exitInfrastructure, err)
+		}
+		return fetchSkillGraph(os.Stdout, port, skillGraphServer, skillGraphFormat)
+	},
+}
+
+func init() {
+	skillGraphCmd.Flags().StringVarP(&skillGraphStack, "stack", "s", "", "Stack to query (auto-detected when only one stack is running)")
+	skillGraphCmd.Flags().StringVar(&skillGraphServer, "server", "", "Show only what depends on this server")
+	_ = skillGraphCmd.RegisterFlagCompletionFunc("server", completeServerFlag)
+	skillGraphCmd.Flags().StringVar(&skillGraphFormat, "format", "dot", "Output format: dot, mermaid, or json")
+
+	skillCmd.AddCommand(skillGraphCmd)
+}
+
+// fetchSkillGraph calls GET /api/registry/graph on the local gateway and
+// copies the rendered graph to w.
+func fetchSkillGraph(w io.Writer, port int, server, format string) error {
+	q := url.Values{"format": {format}}
+	if server != "" {
+		q.Set("server", server)
+	}
+	client := &http.Client{Timeout: skillGraphHTTPTimeout}
+	resp, err := client.Get(fmt.Sprintf("http://localhost:%d/api/registry/graph?%s", port, q.Encode()))
+	if err != nil {
+		return withExitCode(exitInfrastructure, fmt.Errorf("skill graph: %w", err))
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return withExitCode(exitInfrastructure, fmt.Errorf("skill graph: reading response: %w", err))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return withExitCode(exitInfrastructure, fmt.Errorf("skill graph: %s: %s", resp.Status, strings.TrimSpace(string(body))))
+	}
+	_, err = w.Write(body)
+	return err
+}
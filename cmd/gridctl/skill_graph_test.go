@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchSkillGraph(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/registry/graph" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("server"); got != "github" {
+			t.Errorf("server = %q, want github", got)
+		}
+		if got := r.URL.Query().Get("format"); got != "mermaid" {
+			t.Errorf("format = %q, want mermaid", got)
+		}
+		_, _ = w.Write([]byte("flowchart LR\n"))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	if err := fetchSkillGraph(&buf, mustPort(t, server.URL), "github", "mermaid"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "flowchart LR\n" {
+		t.Errorf("output = %q", buf.String())
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gridctl/gridctl/pkg/output"
+	"github.com/gridctl/gridctl/pkg/registry"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes, following the validate/optimize convention.
+const (
+	skillLintExitOK       = 0
+	skillLintExitFindings = 1
+)
+
+var (
+	skillLintFormat string
+	skillLintJSON   *bool
+	skillLintPlain  *bool
+)
+
+// skillLintReport is one linted skill in --json output.
+type skillLintReport struct {
+	Skill    string                 `json:"skill"`
+	Findings []registry.LintFinding `json:"findings"`
+}
+
+var skillLintCmd = &cobra.Command{
+	Use:   "lint [name|path...]",
+	Short: "Check skills for likely mistakes",
+	Long: `Check skills against lint rules that go beyond 'gridctl skill validate':
+arguments and placeholders that do not line up, missing descriptions,
+supporting files the body never points to, and allowed-tools entries that
+grant too much.
+
+Arguments are registry skill names, or paths to a SKILL.md file or a skill
+directory, so a skills repository can be linted in CI before it is
+imported. Without arguments every registry skill is linted.
+
+Suppress a finding with an HTML comment in the body:
+
+  <!-- gridctl-lint-disable unused-argument -->          whole skill
+  <!-- gridctl-lint-disable-next-line undefined-placeholder -->
+
+Without rule IDs, every rule is disabled.
+
+Rules:
+` + skillLintRuleList() + `
+Exit codes:
+  0  no findings
+  1  findings reported`,
+	Example: `  gridctl skill lint
+  gridctl skill lint code-review deploy
+  gridctl skill lint ./skills/code-review --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveFormat(skillLintFormat, cmd.Flags().Changed("format"), *skillLintJSON)
+		if err != nil {
+			return err
+		}
+		if err := resolvePlain(*skillLintPlain, format); err != nil {
+			return err
+		}
+		reports, err := lintSkills(args)
+		if err != nil {
+			return err
+		}
+		if code := renderSkillLint(reports, format, *skillLintPlain); code != skillLintExitOK {
+			exitNow(code)
+		}
+		return nil
+	},
+}
+
+func init() {
+	skillLintCmd.Flags().StringVar(&skillLintFormat, "format", "", "Output format (json)")
+	skillLintJSON = addJSONAlias(skillLintCmd)
+	skillLintPlain = addPlainFlag(skillLintCmd)
+
+	skillCmd.AddCommand(skillLintCmd)
+}
+
+// skillLintRuleList renders the rule table for the command help.
+func skillLintRuleList() string {
+	var b strings.Builder
+	for _, r := range registry.LintRules {
+		fmt.Fprintf(&b, "  %-30s %s\n", r.ID, r.Summary)
+	}
+	return b.String()
+}
+
+// lintSkills lints each target, or every registry skill when there are none.
+func lintSkills(targets []string) ([]skillLintReport, error) {
+	var store *registry.Store
+	registryStore := func() (*registry.Store, error) {
+		if store != nil {
+			return store, nil
+		}
+		var err error
+		store, err = loadRegistry()
+		return store, err
+	}
+
+	if len(targets) == 0 {
+		s, err := registryStore()
+		if err != nil {
+			return nil, err
+		}
+		for _, sk := range s.ListSkills() {
+			targets = append(targets, sk.Name)
+		}
+	}
+
+	reports := make([]skillLintReport, 0, len(targets))
+	for _, target := range targets {
+		var (
+			findings []registry.LintFinding
+			err      error
+		)
+		info, statErr := os.Stat(target)
+		switch {
+		case statErr == nil && info.IsDir():
+			findings, err = registry.LintDir(target)
+		case statErr == nil && filepath.Base(target) == "SKILL.md":
+			findings, err = registry.LintDir(filepath.Dir(target))
+		case statErr == nil:
+			var data []byte
+			if data, err = os.ReadFile(target); err == nil {
+				findings, err = registry.LintSkillMD(data)
+			}
+		default:
+			s, serr := registryStore()
+			if serr != nil {
+				return nil, serr
+			}
+			findings, err = s.Lint(target)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("linting %s: %w", target, err)
+		}
+		if findings == nil {
+			findings = []registry.LintFinding{}
+		}
+		reports = append(reports, skillLintReport{Skill: target, Findings: findings})
+	}
+	return reports, nil
+}
+
+// renderSkillLint prints the reports and returns the exit code.
+func renderSkillLint(reports []skillLintReport, format string, plain bool) int {
+	out := output.New()
+	total := 0
+	for _, r := range reports {
+		total += len(r.Findings)
+	}
+
+	if format == "json" {
+		data, _ := json.MarshalIndent(reports, "", "  ")
+		out.Println(string(data))
+	} else if total == 0 {
+		out.Print("✓ %d skill(s) passed lint\n", len(reports))
+	} else {
+		t := output.NewTableWriter(os.Stdout, plain)
+		t.AppendHeader(table.Row{"Skill", "Severity", "Rule", "Location", "Message"})
+		for _, r := range reports {
+			for _, f := range r.Findings {
+				location := f.File
+				if f.Line > 0 {
+					location = fmt.Sprintf("%s:%d", f.File, f.Line)
+				}
+				t.AppendRow(table.Row{r.Skill, f.Severity, f.Rule, location, f.Message})
+			}
+		}
+		t.Render()
+	}
+
+	if total > 0 {
+		return skillLintExitFindings
+	}
+	return skillLintExitOK
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/registry"
+)
+
+func TestLintSkills_PathTargets(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "review")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: review\ndescription: Review a diff\nallowed-tools: \"*\"\n---\n\nReview the change.\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := lintSkills([]string{dir, filepath.Join(dir, "SKILL.md")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports, want 2", len(reports))
+	}
+	for _, r := range reports {
+		if len(r.Findings) != 1 || r.Findings[0].Rule != registry.LintRuleBroadAllowedTools {
+			t.Errorf("%s: findings = %+v, want one broad-allowed-tools", r.Skill, r.Findings)
+		}
+	}
+
+	var code int
+	out := captureStdout(t, func() { code = renderSkillLint(reports, "json", false) })
+	if code != skillLintExitFindings {
+		t.Errorf("exit code = %d, want %d", code, skillLintExitFindings)
+	}
+	var decoded []skillLintReport
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("stdout is not a single JSON document: %v\n%s", err, out)
+	}
+}
@@ -83,20 +83,18 @@ Exit codes:
   0  synced cleanly
   1  a projection was skipped (drift or unmanaged path) or failed
   2  infrastructure error (unknown skill or client, lockfile conflict)`,
+	ValidArgsFunction: completeSkillNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(skillProjectSyncFormat, cmd.Flags().Changed("format"), *skillProjectSyncJSON)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		if err := resolvePlain(*skillProjectSyncPlain, format); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		mgr, err := newSkillProjectManager()
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		opts := skillsync.SyncOptions{
 			Clients: skillProjectSyncClients,
@@ -105,7 +103,7 @@ Exit codes:
 			Force:   skillProjectSyncForce,
 		}
 		if exit := runSkillProjectSync(cmd.Context(), os.Stdout, os.Stderr, mgr, args, opts, format, *skillProjectSyncPlain); exit != ctxExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
@@ -130,20 +128,17 @@ Exit codes:
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(skillProjectStatusFormat, cmd.Flags().Changed("format"), *skillProjectStatusJSON)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		if err := resolvePlain(*skillProjectStatusPlain, format); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		mgr, err := newSkillProjectManager()
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(ctxExitInfrastructure)
+			exitWithError(cmd, ctxExitInfrastructure, err)
 		}
 		if exit := runSkillProjectStatus(cmd.Context(), os.Stdout, os.Stderr, mgr, format, *skillProjectStatusPlain); exit != ctxExitOK {
-			os.Exit(exit)
+			exitNow(exit)
 		}
 		return nil
 	},
@@ -155,6 +150,7 @@ var skillProjectUnsyncCmd = &cobra.Command{
 	Long: `Removes projections gridctl created: symlinks are unlinked and copied
 directories removed after a timestamped backup. Files gridctl did not
 create are never touched. Removed skills leave the projection set.`,
+	ValidArgsFunction: completeSkillNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := resolveFormat(skillProjectUnsyncFormat, cmd.Flags().Changed("format"), *skillProjectUnsyncJSON)
 		if err != nil {
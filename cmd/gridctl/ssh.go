@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/gridctl/gridctl/pkg/output"
+	"github.com/gridctl/gridctl/pkg/sshhosts"
+	"github.com/gridctl/gridctl/pkg/state"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	sshTrustPort int
+	sshTrustFile string
+	sshTrustYes  bool
+)
+
+// fetchSSHHostKey reads a host's key; a seam so tests run without a network.
+var fetchSSHHostKey = sshhosts.FetchHostKey
+
+var sshCmd = &cobra.Command{
+	Use:   "ssh",
+	Short: "Manage SSH host keys for SSH servers",
+	Long: `Manage the SSH host keys gridctl trusts for SSH-backed MCP servers.
+
+Keys are kept in ~/.gridctl/known_hosts. An SSH server whose host is listed
+there connects with strict host key checking; other hosts are trusted on
+first use (accept-new) unless the server sets ssh.knownHostsFile.`,
+}
+
+var sshTrustCmd = &cobra.Command{
+	Use:   "trust <host>",
+	Short: "Fetch a host's SSH key and trust it",
+	Long: `Connect to an SSH host, show the key it presents, and after confirmation
+append it to the gridctl-managed known_hosts file (or --file). Compare the
+fingerprint with one obtained out of band before accepting.
+
+A host already trusted with the same key is left alone. A host trusted with
+a different key is refused: remove the old entry first if the key was
+rotated.`,
+	Example: `  gridctl ssh trust 10.0.0.5
+  gridctl ssh trust bastion.example.com --port 2222
+  gridctl ssh trust 10.0.0.5 --file ~/.ssh/gridctl_known_hosts --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		host, port, err := parseSSHTarget(args[0], sshTrustPort, cmd.Flags().Changed("port"))
+		if err != nil {
+			return withExitCode(exitConfig, err)
+		}
+		return runSSHTrust(cmd, host, port)
+	},
+}
+
+func init() {
+	sshTrustCmd.Flags().IntVar(&sshTrustPort, "port", sshhosts.DefaultPort, "SSH port")
+	sshTrustCmd.Flags().StringVar(&sshTrustFile, "file", "", "known_hosts file to update (default ~/.gridctl/known_hosts)")
+	sshTrustCmd.Flags().BoolVarP(&sshTrustYes, "yes", "y", false, "Trust the key without prompting")
+	sshCmd.AddCommand(sshTrustCmd)
+}
+
+// parseSSHTarget accepts host, user@host, or host:port. A port in the
+// argument conflicts with an explicit --port.
+func parseSSHTarget(arg string, port int, portSet bool) (string, int, error) {
+	if _, after, ok := strings.Cut(arg, "@"); ok {
+		arg = after
+	}
+	if h, p, err := net.SplitHostPort(arg); err == nil {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 || n > 65535 {
+			return "", 0, fmt.Errorf("invalid port in %q", arg)
+		}
+		if portSet && n != port {
+			return "", 0, fmt.Errorf("port %d in %q conflicts with --port %d", n, arg, port)
+		}
+		return h, n, nil
+	}
+	if port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("--port must be between 1 and 65535")
+	}
+	if arg == "" {
+		return "", 0, fmt.Errorf("host is required")
+	}
+	return arg, port, nil
+}
+
+func runSSHTrust(cmd *cobra.Command, host string, port int) error {
+	path := sshTrustFile
+	if path == "" {
+		path = state.KnownHostsPath()
+	}
+	addr := sshhosts.Address(host, port)
+
+	key, err := fetchSSHHostKey(cmd.Context(), host, port)
+	if err != nil {
+		return withExitCode(exitInfrastructure, err)
+	}
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Host key for %s\n  Type:        %s\n  Fingerprint: %s\n", addr, key.Type(), sshhosts.Fingerprint(key))
+
+	printer := output.NewWithWriter(out)
+	switch err := sshhosts.Check(path, host, port, key); {
+	case err == nil:
+		printer.Info("Host already trusted", "host", addr, "file", path)
+		return nil
+	case errors.Is(err, sshhosts.ErrKeyMismatch):
+		return fmt.Errorf("%s is trusted with a different key in %s: if the host key was rotated, remove the old entry and retry", addr, path)
+	case !errors.Is(err, sshhosts.ErrNotTrusted):
+		return err
+	}
+
+	if !sshTrustYes {
+		fmt.Fprint(out, "\nTrust this host key? [y/N] ")
+		answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Fprintln(out, "Cancelled")
+			return nil
+		}
+	}
+	if err := sshhosts.Append(path, host, port, key); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	printer.Info("Host trusted", "host", addr, "file", path)
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func stubSSHHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := fetchSSHHostKey
+	t.Cleanup(func() {
+		fetchSSHHostKey = orig
+		sshTrustPort, sshTrustFile, sshTrustYes = 22, "", false
+		rootCmd.SetIn(nil)
+	})
+	fetchSSHHostKey = func(context.Context, string, int) (ssh.PublicKey, error) { return key, nil }
+	return key
+}
+
+func TestParseSSHTarget(t *testing.T) {
+	tests := []struct {
+		arg      string
+		port     int
+		portSet  bool
+		wantHost string
+		wantPort int
+		wantErr  bool
+	}{
+		{"10.0.0.5", 22, false, "10.0.0.5", 22, false},
+		{"admin@10.0.0.5", 2222, true, "10.0.0.5", 2222, false},
+		{"bastion.example.com:2200", 22, false, "bastion.example.com", 2200, false},
+		{"bastion.example.com:2200", 2222, true, "", 0, true},
+		{"host:notaport", 22, false, "", 0, true},
+		{"host", 70000, true, "", 0, true},
+	}
+	for _, tt := range tests {
+		host, port, err := parseSSHTarget(tt.arg, tt.port, tt.portSet)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSSHTarget(%q) error = %v, wantErr %v", tt.arg, err, tt.wantErr)
+			continue
+		}
+		if host != tt.wantHost || port != tt.wantPort {
+			t.Errorf("parseSSHTarget(%q) = %q, %d; want %q, %d", tt.arg, host, port, tt.wantHost, tt.wantPort)
+		}
+	}
+}
+
+func TestSSHTrust_AppendsOnce(t *testing.T) {
+	stubSSHHostKey(t)
+	file := filepath.Join(t.TempDir(), "known_hosts")
+
+	out, _, err := executeCommand(t, "ssh", "trust", "10.0.0.5", "--port", "2222", "--file", file, "--yes")
+	if err != nil {
+		t.Fatalf("ssh trust: %v", err)
+	}
+	if !strings.Contains(out, "Fingerprint: SHA256:") {
+		t.Errorf("output should show the fingerprint, got %q", out)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "[10.0.0.5]:2222 ssh-ed25519 ") {
+		t.Errorf("known_hosts = %q", data)
+	}
+
+	out, _, err = executeCommand(t, "ssh", "trust", "10.0.0.5", "--port", "2222", "--file", file, "--yes")
+	if err != nil {
+		t.Fatalf("second ssh trust: %v", err)
+	}
+	if !strings.Contains(out, "already trusted") {
+		t.Errorf("second run should report the host as trusted, got %q", out)
+	}
+	again, _ := os.ReadFile(file)
+	if string(again) != string(data) {
+		t.Error("trusting a listed host should not append a duplicate entry")
+	}
+}
+
+func TestSSHTrust_DeclinedPrompt(t *testing.T) {
+	stubSSHHostKey(t)
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	rootCmd.SetIn(strings.NewReader("n\n"))
+
+	out, _, err := executeCommand(t, "ssh", "trust", "10.0.0.5", "--file", file)
+	if err != nil {
+		t.Fatalf("ssh trust: %v", err)
+	}
+	if !strings.Contains(out, "Cancelled") {
+		t.Errorf("declining should cancel, got %q", out)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Error("declining should not write known_hosts")
+	}
+}
+
+func TestSSHTrust_RefusesChangedKey(t *testing.T) {
+	stubSSHHostKey(t)
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	if _, _, err := executeCommand(t, "ssh", "trust", "10.0.0.5", "--file", file, "--yes"); err != nil {
+		t.Fatal(err)
+	}
+
+	stubSSHHostKey(t) // the host now presents a different key
+	_, _, err := executeCommand(t, "ssh", "trust", "10.0.0.5", "--file", file, "--yes")
+	if err == nil || !strings.Contains(err.Error(), "different key") {
+		t.Errorf("err = %v, want a key mismatch refusal", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gridctl/gridctl/pkg/output"
 	"github.com/gridctl/gridctl/pkg/state"
 	"github.com/spf13/cobra"
 )
@@ -41,6 +42,7 @@ func init() {
 }
 
 func runStop() error {
+	out := output.New()
 	const name = "gridctl"
 
 	return state.WithLock(name, 5*time.Second, func() error {
@@ -54,13 +56,13 @@ func runStop() error {
 			return fmt.Errorf("no stackless daemon is running")
 		}
 
-		fmt.Printf("Stopping gridctl daemon (pid %d)...\n", st.PID)
+		out.Print("Stopping gridctl daemon (pid %d)...\n", st.PID)
 		if err := state.KillDaemon(st); err != nil {
 			return fmt.Errorf("could not stop daemon: %w", err)
 		}
 
 		_ = state.Delete(name)
-		fmt.Println("gridctl stopped")
+		out.Println("gridctl stopped")
 		return nil
 	})
 }
@@ -71,6 +73,7 @@ func runStop() error {
 // Anything ambiguous falls through to the legacy "nothing to stop"
 // error so the user never sees us act on guesswork.
 func runStopOrphanFallback() error {
+	out := output.New()
 	pid, ok, ferr := findOrphan(stopDefaultPort)
 	if ferr != nil || !ok {
 		return fmt.Errorf("no stackless daemon is running")
@@ -84,6 +87,6 @@ func runStopOrphanFallback() error {
 	if err := state.KillDaemon(orphan); err != nil {
 		return fmt.Errorf("could not stop orphan daemon (pid %d): %w", pid, err)
 	}
-	fmt.Printf("Stopped orphan gridctl daemon (pid %d)\n", pid)
+	out.Print("Stopped orphan gridctl daemon (pid %d)\n", pid)
 	return nil
 }
@@ -0,0 +1,426 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/logging"
+	"github.com/gridctl/gridctl/pkg/output"
+	"github.com/gridctl/gridctl/pkg/state"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	bundleHTTPTimeout = 5 * time.Second
+	// bundleLogTailBytes caps how much of the log file is read to find the
+	// last --log-lines lines, so a runaway log cannot balloon the bundle.
+	bundleLogTailBytes = 8 << 20
+)
+
+var (
+	bundleStack    string
+	bundleOutput   string
+	bundleLogLines int
+	bundleToken    string
+)
+
+// bundleFile is one entry in the support bundle tarball.
+type bundleFile struct {
+	Name string
+	Data []byte
+}
+
+// bundleManifest is manifest.json at the root of the bundle: what produced
+// it, for which stack, and which sections could not be collected.
+type bundleManifest struct {
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit"`
+	Built     string    `json:"built"`
+	CreatedAt time.Time `json:"created_at"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	GoVersion string    `json:"go_version"`
+	Stack     string    `json:"stack,omitempty"`
+	Files     []string  `json:"files"`
+	Skipped   []string  `json:"skipped,omitempty"`
+}
+
+var supportBundleCmd = &cobra.Command{
+	Use:   "support-bundle",
+	Short: "Collect a redacted diagnostics tarball for bug reports",
+	Long: `Collect everything maintainers usually ask for into one tarball:
+
+  manifest.json     gridctl version, platform, and what was collected
+  doctor.json       the 'gridctl doctor' environment checks
+  state.json        the stack's daemon state (PID, port, start time)
+  stack.yaml        the stack file, with secret values redacted
+  gateway.log       the last --log-lines lines of the gateway log, redacted
+  status.json       the running gateway's /api/status, including each
+                    server's reported name, version, and protocol version
+                    (status.error.txt says why when it cannot be fetched)
+
+Secrets are redacted before anything is written: values under secret-like
+keys (token, password, api_key, ...) in the stack file, and bearer tokens,
+Authorization headers, and key=value secrets in logs and status. ${var:...}
+references are kept, since they name a secret without revealing it. Review
+the bundle before attaching it to a public issue.
+
+The stack is picked like other commands: --stack, or the only running
+stack. A stopped stack named with --stack still contributes its stack file
+and logs. With no stack at all the bundle holds the environment checks only.
+
+A gateway with gateway.auth is called with --token, else $GRIDCTL_GATEWAY_TOKEN,
+else the token in the stack file.`,
+	Example: `  gridctl support-bundle                   Bundle the running stack
+  gridctl support-bundle -s prod -o b.tgz  Bundle the 'prod' stack to b.tgz
+  gridctl support-bundle --log-lines 5000  Include more of the gateway log`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := output.New()
+		if bundleLogLines < 0 {
+			return usageErrorf("--log-lines must be non-negative")
+		}
+		st, err := resolveBundleStack(bundleStack)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+		files, skipped := collectSupportBundle(ctx, st, bundleLogLines, bundleToken)
+
+		path := bundleOutput
+		if path == "" {
+			name := "env"
+			if st != nil {
+				name = st.StackName
+			}
+			path = fmt.Sprintf("gridctl-support-%s-%s.tar.gz", name, time.Now().Format("20060102-150405"))
+		}
+		if err := writeSupportBundle(path, files); err != nil {
+			return err
+		}
+		out.Print("Wrote %s (%d files)\n", path, len(files))
+		for _, s := range skipped {
+			out.Print("  skipped: %s\n", s)
+		}
+		return nil
+	},
+}
+
+func init() {
+	supportBundleCmd.Flags().StringVarP(&bundleStack, "stack", "s", "", "Stack to collect (auto-detected when only one stack is running)")
+	supportBundleCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Output path (default: gridctl-support-<stack>-<time>.tar.gz)")
+	supportBundleCmd.Flags().IntVar(&bundleLogLines, "log-lines", 1000, "Number of trailing gateway log lines to include")
+	supportBundleCmd.Flags().StringVar(&bundleToken, "token", "", gatewayTokenFlagUsage)
+}
+
+// resolveBundleStack picks the stack to collect. An explicitly named stack
+// need not be running; without --stack the only running stack is used, none
+// yields a nil state (environment-only bundle), and several is a usage error.
+func resolveBundleStack(name string) (*state.DaemonState, error) {
+	if name != "" {
+		if st, err := state.Load(name); err == nil {
+			return st, nil
+		}
+		return &state.DaemonState{StackName: name}, nil
+	}
+	states, err := state.List()
+	if err != nil {
+		return nil, nil
+	}
+	var running []state.DaemonState
+	for _, s := range states {
+		if state.IsRunning(&s) {
+			running = append(running, s)
+		}
+	}
+	switch len(running) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &running[0], nil
+	default:
+		names := make([]string, len(running))
+		for i, s := range running {
+			names[i] = s.StackName
+		}
+		return nil, usageErrorf("support-bundle: multiple stacks running (%s); use --stack to pick one", strings.Join(names, ", "))
+	}
+}
+
+// collectSupportBundle gathers every section it can. A section that cannot
+// be collected is named in skipped (and the manifest) rather than failing
+// the bundle: a half-broken install is exactly when one is needed.
+func collectSupportBundle(ctx context.Context, st *state.DaemonState, logLines int, token string) (files []bundleFile, skipped []string) {
+	add := func(name string, data []byte) {
+		files = append(files, bundleFile{Name: name, Data: data})
+	}
+	addJSON := func(name string, v any) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", name, err))
+			return
+		}
+		add(name, append(data, '\n'))
+	}
+
+	addJSON("doctor.json", runDoctorChecks(ctx))
+
+	if st != nil {
+		if st.PID != 0 {
+			addJSON("state.json", st)
+		} else {
+			skipped = append(skipped, "state.json: no state file for stack "+st.StackName)
+		}
+
+		if st.StackFile != "" {
+			if data, err := os.ReadFile(st.StackFile); err != nil {
+				skipped = append(skipped, fmt.Sprintf("stack.yaml: %v", err))
+			} else {
+				add("stack.yaml", redactStackYAML(data))
+			}
+		} else {
+			skipped = append(skipped, "stack.yaml: stack file unknown (stack not started)")
+		}
+
+		if logLines > 0 {
+			if data, err := tailLines(state.LogPath(st.StackName), logLines); err != nil {
+				skipped = append(skipped, fmt.Sprintf("gateway.log: %v", err))
+			} else {
+				add("gateway.log", []byte(logging.RedactString(string(data))))
+			}
+		}
+
+		if state.IsRunning(st) {
+			cred := resolveGatewayCredential(token, st.StackFile)
+			if data, err := fetchBundleStatus(ctx, st.Port, cred); err != nil {
+				// The note travels with the bundle, so a maintainer reading
+				// it knows why the status is missing.
+				note := fmt.Sprintf("GET /api/status failed: %v\n", err)
+				if errors.Is(err, errGatewayUnauthorized) {
+					note += fmt.Sprintf("The gateway requires auth; rerun with --token or %s.\n", gatewayTokenEnv)
+				}
+				add("status.error.txt", []byte(note))
+				skipped = append(skipped, fmt.Sprintf("status.json: %v", err))
+			} else {
+				add("status.json", []byte(logging.RedactString(string(data))))
+			}
+		} else {
+			skipped = append(skipped, "status.json: gateway not running")
+		}
+	} else {
+		skipped = append(skipped, "stack sections: no running stack (use --stack to name one)")
+	}
+
+	manifest := bundleManifest{
+		Version:   version,
+		Commit:    commit,
+		Built:     date,
+		CreatedAt: time.Now().UTC(),
+		OS:        goruntime.GOOS,
+		Arch:      goruntime.GOARCH,
+		GoVersion: goruntime.Version(),
+		Files:     []string{"manifest.json"},
+		Skipped:   skipped,
+	}
+	if st != nil {
+		manifest.Stack = st.StackName
+	}
+	for _, f := range files {
+		manifest.Files = append(manifest.Files, f.Name)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return files, skipped
+	}
+	return append([]bundleFile{{Name: "manifest.json", Data: append(data, '\n')}}, files...), skipped
+}
+
+// redactStackYAML replaces scalar values under secret-like keys with
+// [REDACTED] and scrubs secret patterns from every other scalar, keeping
+// comments and layout. ${var:...} and ${vault:...} references are left
+// intact. Unparseable YAML is redacted line by line instead.
+func redactStackYAML(data []byte) []byte {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []byte(logging.RedactString(string(data)))
+	}
+	redactYAMLNode(&doc, false)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return []byte(logging.RedactString(string(data)))
+	}
+	_ = enc.Close()
+	return buf.Bytes()
+}
+
+// redactYAMLNode redacts the scalars under n. Sensitivity is inherited, so
+// everything nested under a sensitive key (gateway.auth.keys[].key, an
+// upstream's auth block) is redacted too, except the descriptive fields in
+// structuralKeys that keep the block readable.
+func redactYAMLNode(n *yaml.Node, sensitive bool) {
+	switch n.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, c := range n.Content {
+			redactYAMLNode(c, sensitive)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			childSensitive := sensitive || isSensitiveKey(key) || strings.EqualFold(key, "key")
+			if sensitive && structuralKeys[key] {
+				childSensitive = false
+			}
+			redactYAMLNode(n.Content[i+1], childSensitive)
+		}
+	case yaml.ScalarNode:
+		if isVariableRef(n.Value) {
+			return
+		}
+		if sensitive && n.Value != "" {
+			n.Value = "[REDACTED]"
+			n.Style = 0
+			return
+		}
+		n.Value = logging.RedactString(n.Value)
+	}
+}
+
+// structuralKeys name fields of auth blocks that describe a credential
+// rather than hold one.
+var structuralKeys = map[string]bool{"type": true, "header": true, "name": true}
+
+// isVariableRef reports whether a value is a single ${var:...} or
+// ${vault:...} reference, which names a secret without revealing it.
+func isVariableRef(v string) bool {
+	return (strings.HasPrefix(v, "${var:") || strings.HasPrefix(v, "${vault:")) &&
+		strings.HasSuffix(v, "}") && strings.Count(v, "${") == 1
+}
+
+// tailLines returns the last n lines of the file at path, reading at most
+// bundleLogTailBytes from its end.
+func tailLines(path string, n int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := info.Size() - bundleLogTailBytes
+	if offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		// Drop the partial first line.
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return bytes.Join(lines, nil), nil
+}
+
+// errGatewayUnauthorized marks a status fetch the gateway's auth refused.
+var errGatewayUnauthorized = errors.New("401 Unauthorized")
+
+// fetchBundleStatus calls GET /api/status on the local gateway with cred
+// and returns the body re-indented for reading.
+func fetchBundleStatus(ctx context.Context, port int, cred gatewayCredential) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%d/api/status", port), nil)
+	if err != nil {
+		return nil, err
+	}
+	cred.apply(req)
+	client := &http.Client{Timeout: bundleHTTPTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, errGatewayUnauthorized
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return body, nil
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// writeSupportBundle writes files as a gzipped tarball under a single
+// top-level directory named after the archive. The file is created 0600:
+// even redacted, it describes the local setup.
+func writeSupportBundle(path string, files []bundleFile) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("support-bundle: %w", err)
+	}
+	defer f.Close()
+
+	root := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".gz"), ".tar")
+	root = strings.TrimSuffix(root, ".tgz")
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, file := range files {
+		hdr := &tar.Header{
+			Name:    root + "/" + file.Name,
+			Mode:    0o600,
+			Size:    int64(len(file.Data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("support-bundle: %w", err)
+		}
+		if _, err := tw.Write(file.Data); err != nil {
+			return fmt.Errorf("support-bundle: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("support-bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("support-bundle: %w", err)
+	}
+	return f.Close()
+}
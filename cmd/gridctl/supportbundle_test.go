@@ -0,0 +1,222 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/state"
+)
+
+func TestRedactStackYAML(t *testing.T) {
+	in := []byte(`# production stack
+name: prod
+gateway:
+  auth:
+    type: bearer
+    token: s3cr3t-gateway-token
+mcp-servers:
+  - name: github
+    image: ghcr.io/github/github-mcp-server
+    env:
+      GITHUB_PERSONAL_ACCESS_TOKEN: ghp_abcdef123456
+      GITHUB_API_KEY: ${var:GITHUB_API_KEY}
+      LOG_LEVEL: debug
+    command: ["serve", "--header", "Authorization: Bearer abc.def.ghi"]
+`)
+	out := string(redactStackYAML(in))
+
+	for _, secret := range []string{"s3cr3t-gateway-token", "ghp_abcdef123456", "abc.def.ghi"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("secret %q survived redaction:\n%s", secret, out)
+		}
+	}
+	for _, keep := range []string{"# production stack", "type: bearer", "${var:GITHUB_API_KEY}", "LOG_LEVEL: debug", "ghcr.io/github/github-mcp-server"} {
+		if !strings.Contains(out, keep) {
+			t.Errorf("expected %q to be kept:\n%s", keep, out)
+		}
+	}
+}
+
+func TestRedactStackYAML_NestedAuth(t *testing.T) {
+	in := []byte(`name: prod
+gateway:
+  auth:
+    type: api_key
+    header: X-API-Key
+    keys:
+      - name: ci
+        key: sk-live-SUPERSECRET
+upstreams:
+  - name: dept-a
+    url: http://dept-a:8180
+    auth:
+      type: header
+      header: X-Upstream-Key
+      value: upstream-header-secret
+  - name: dept-b
+    url: http://dept-b:8180
+    auth:
+      type: oauth
+      client_id: dept-b-client
+      client_secret: oauth-client-secret
+`)
+	out := string(redactStackYAML(in))
+
+	for _, secret := range []string{"sk-live-SUPERSECRET", "upstream-header-secret", "oauth-client-secret", "dept-b-client"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("secret %q survived redaction:\n%s", secret, out)
+		}
+	}
+	for _, keep := range []string{"type: api_key", "header: X-API-Key", "name: ci", "type: header", "name: dept-a", "url: http://dept-a:8180"} {
+		if !strings.Contains(out, keep) {
+			t.Errorf("expected %q to be kept:\n%s", keep, out)
+		}
+	}
+}
+
+func TestRedactStackYAML_Unparseable(t *testing.T) {
+	out := string(redactStackYAML([]byte("name: [unclosed\npassword=hunter2\n")))
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("fallback redaction missed a secret: %q", out)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.log")
+	if err := os.WriteFile(path, []byte("one\ntwo\nthree\nfour\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := tailLines(path, 2)
+	if err != nil {
+		t.Fatalf("tailLines: %v", err)
+	}
+	if string(got) != "three\nfour\n" {
+		t.Errorf("tail = %q, want last two lines", got)
+	}
+	got, _ = tailLines(path, 10)
+	if string(got) != "one\ntwo\nthree\nfour\n" {
+		t.Errorf("tail beyond file length = %q, want whole file", got)
+	}
+}
+
+func TestWriteSupportBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	files := []bundleFile{{Name: "manifest.json", Data: []byte("{}\n")}, {Name: "gateway.log", Data: []byte("line\n")}}
+	if err := writeSupportBundle(path, files); err != nil {
+		t.Fatalf("writeSupportBundle: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("bundle mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+	}
+	want := []string{"bundle/manifest.json", "bundle/gateway.log"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("entries = %v, want %v", names, want)
+	}
+}
+
+func TestResolveBundleStack(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	st, err := resolveBundleStack("")
+	if err != nil || st != nil {
+		t.Fatalf("no stacks: got %+v, %v; want nil, nil", st, err)
+	}
+
+	st, err = resolveBundleStack("stopped")
+	if err != nil || st == nil || st.StackName != "stopped" || st.PID != 0 {
+		t.Errorf("named stack without state: got %+v, %v", st, err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		if err := state.Save(&state.DaemonState{StackName: name, StackFile: "/x.yaml", PID: os.Getpid(), Port: 8181, StartedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := resolveBundleStack(""); err == nil || exitCodeFor(err) != exitConfig {
+		t.Errorf("several running stacks should be a usage error, got %v", err)
+	}
+}
+
+func TestFetchBundleStatus_SendsGatewayToken(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(gatewayTokenEnv, "")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"gateway":{"name":"demo"}}`))
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fetchBundleStatus(context.Background(), port, gatewayCredential{}); !errors.Is(err, errGatewayUnauthorized) {
+		t.Fatalf("without a token: err = %v, want errGatewayUnauthorized", err)
+	}
+	data, err := fetchBundleStatus(context.Background(), port, resolveGatewayCredential("s3cret", ""))
+	if err != nil {
+		t.Fatalf("with a token: %v", err)
+	}
+	if !strings.Contains(string(data), `"demo"`) {
+		t.Errorf("status body = %s", data)
+	}
+
+	// A refused fetch leaves a note in the bundle rather than only in the
+	// terminal output.
+	st := &state.DaemonState{StackName: "demo", PID: os.Getpid(), Port: port, StartedAt: time.Now()}
+	files, _ := collectSupportBundle(context.Background(), st, 0, "")
+	var note string
+	for _, f := range files {
+		if f.Name == "status.error.txt" {
+			note = string(f.Data)
+		}
+	}
+	if !strings.Contains(note, "401") || !strings.Contains(note, gatewayTokenEnv) {
+		t.Errorf("status.error.txt = %q, want the 401 and a pointer to %s", note, gatewayTokenEnv)
+	}
+}
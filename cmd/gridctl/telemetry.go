@@ -39,7 +39,10 @@ var telemetryCmd = &cobra.Command{
 
 These commands operate directly on the on-disk files and do not require a
 running daemon. Persistence itself is configured per-stack and per-server in
-the stack YAML.`,
+the stack YAML.
+
+'usage' and 'export' manage the separate, anonymous CLI usage counters
+under ~/.gridctl/usage/ (off by default, never sent anywhere).`,
 }
 
 var telemetryStatusCmd = &cobra.Command{
@@ -49,7 +52,8 @@ var telemetryStatusCmd = &cobra.Command{
 
 With no argument, walks every stack under ~/.gridctl/telemetry/. Pass a stack
 name to scope the report. Use --json for machine-readable output.`,
-	Args: cobra.MaximumNArgs(1),
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeStackNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format := ""
 		if telemetryStatusJSON {
@@ -75,7 +79,8 @@ removes everything under ~/.gridctl/telemetry/.
 --server scopes the wipe to a single MCP server.
 --signal scopes the wipe to one signal type (logs, metrics, traces).
 -y / --yes skips the confirmation prompt.`,
-	Args: cobra.MaximumNArgs(1),
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeStackNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		stack := ""
 		if len(args) == 1 {
@@ -91,7 +96,8 @@ var telemetryTailCmd = &cobra.Command{
 	Long: `Follows the active <signal>.jsonl file for the given stack and server,
 printing new NDJSON lines as they're written. Lumberjack rotations are
 detected automatically. Press Ctrl-C to exit.`,
-	Args: cobra.ExactArgs(2),
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeStackThenServer,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runTelemetryTail(args[0], args[1], telemetryTailSignal)
 	},
@@ -102,6 +108,7 @@ func init() {
 	telemetryStatusPlain = addPlainFlag(telemetryStatusCmd)
 
 	telemetryWipeCmd.Flags().StringVar(&telemetryWipeServer, "server", "", "Limit to a single MCP server")
+	_ = telemetryWipeCmd.RegisterFlagCompletionFunc("server", completeStackArgServerFlag)
 	telemetryWipeCmd.Flags().StringVar(&telemetryWipeSignal, "signal", "", "Limit to a single signal (logs, metrics, traces)")
 	telemetryWipeCmd.Flags().BoolVarP(&telemetryWipeYes, "yes", "y", false, "Skip confirmation prompt")
 
@@ -116,8 +123,8 @@ func init() {
 // stackInventory pairs a stack name with its inventory records so the CLI can
 // render a multi-stack report without losing the parent stack on each row.
 type stackInventory struct {
-	Stack   string                       `json:"stack"`
-	Records []telemetry.InventoryRecord  `json:"records"`
+	Stack   string                      `json:"stack"`
+	Records []telemetry.InventoryRecord `json:"records"`
 }
 
 // statusRow is the flat per-(stack, signal) row used for tables and JSON
@@ -181,6 +188,7 @@ func gatherInventories(stack string) ([]stackInventory, error) {
 }
 
 func runTelemetryStatus(stack string, asJSON bool) error {
+	out := output.New()
 	invs, err := gatherInventories(stack)
 	if err != nil {
 		return err
@@ -199,9 +207,9 @@ func runTelemetryStatus(stack string, asJSON bool) error {
 
 	if len(rows) == 0 {
 		if stack != "" {
-			fmt.Printf("No persisted telemetry for stack %q\n", stack)
+			out.Print("No persisted telemetry for stack %q\n", stack)
 		} else {
-			fmt.Println("No persisted telemetry")
+			out.Println("No persisted telemetry")
 		}
 		return nil
 	}
@@ -249,6 +257,7 @@ func renderStatusTable(w io.Writer, rows []statusRow, plain bool) {
 }
 
 func runTelemetryWipe(stack, server, signal string, yes bool) error {
+	out := output.New()
 	if signal != "" && !telemetry.IsValidSignal(signal) {
 		return fmt.Errorf("invalid signal %q (expected logs, metrics, or traces)", signal)
 	}
@@ -262,18 +271,18 @@ func runTelemetryWipe(stack, server, signal string, yes bool) error {
 	// prompt enumerates exactly what Wipe will delete.
 	matching := filterInventories(invs, server, signal)
 	if isInventoryEmpty(matching) {
-		fmt.Println("Nothing to wipe — no matching telemetry found")
+		out.Println("Nothing to wipe — no matching telemetry found")
 		return nil
 	}
 
 	if !yes {
 		printWipeSummary(os.Stdout, matching, server, signal)
-		fmt.Print("Proceed? [y/N] ")
+		out.Print("Proceed? [y/N] ")
 		reader := bufio.NewReader(os.Stdin)
 		ans, _ := reader.ReadString('\n')
 		ans = strings.TrimSpace(strings.ToLower(ans))
 		if ans != "y" && ans != "yes" {
-			fmt.Println("Cancelled")
+			out.Println("Cancelled")
 			return nil
 		}
 	}
@@ -289,7 +298,7 @@ func runTelemetryWipe(stack, server, signal string, yes bool) error {
 	}
 
 	totalBytes, totalFiles, _ := summarizeInventories(matching)
-	fmt.Printf("Wiped %d %s (%s) across %d %s\n",
+	out.Print("Wiped %d %s (%s) across %d %s\n",
 		totalFiles, plural(totalFiles, "file", "files"),
 		formatBytes(totalBytes),
 		len(matching), plural(len(matching), "stack", "stacks"))
@@ -387,6 +396,7 @@ func printWipeSummary(w io.Writer, invs []stackInventory, server, signal string)
 }
 
 func runTelemetryTail(stack, server, signal string) error {
+	printer := output.New()
 	if !telemetry.IsValidSignal(signal) {
 		return fmt.Errorf("invalid signal %q (expected logs, metrics, or traces)", signal)
 	}
@@ -424,7 +434,7 @@ func runTelemetryTail(stack, server, signal string) error {
 	for {
 		select {
 		case <-sigCh:
-			fmt.Println()
+			printer.Println()
 			return nil
 		case ev, ok := <-watcher.Events:
 			if !ok {
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/output"
+	"github.com/gridctl/gridctl/pkg/state"
+	"github.com/gridctl/gridctl/pkg/usage"
+
+	"github.com/spf13/cobra"
+)
+
+var telemetryExportOutput string
+
+var telemetryUsageCmd = &cobra.Command{
+	Use:   "usage [on|off]",
+	Short: "Opt in to (or out of) anonymous local usage counters",
+	Long: `Controls anonymous CLI usage counters, which are off by default.
+
+When on, each gridctl invocation increments local counters under
+~/.gridctl/usage/: the command path (never its arguments), the failure
+class of failed runs, and, for 'gridctl apply', the stack's server-count
+bucket and the transport of each server. Nothing is sent anywhere; use
+'gridctl telemetry export' to produce a report you can attach to an issue.
+
+'off' deletes every counter. DO_NOT_TRACK=1 suspends recording without
+changing the setting. With no argument, prints the current setting.`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []cobra.Completion{"on", "off"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return runTelemetryUsageStatus(os.Stdout, state.UsagePath())
+		}
+		return runTelemetryUsageSet(os.Stdout, state.UsagePath(), args[0] == "on")
+	},
+}
+
+var telemetryExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Print the aggregate usage report",
+	Long: `Prints the anonymous usage counters as a JSON report suitable for
+attaching to an issue: command counts, failure classes, stack-size buckets,
+and transport counts, plus the gridctl version, OS, and architecture.
+
+The report is built entirely from local counters; run 'gridctl telemetry
+usage on' first to start collecting.`,
+	Example: `  gridctl telemetry export                   Print the report
+  gridctl telemetry export -o usage.json     Write it to a file`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := io.Writer(os.Stdout)
+		if telemetryExportOutput != "" {
+			f, err := os.Create(telemetryExportOutput) // #nosec G304 -- user-chosen output path
+			if err != nil {
+				return fmt.Errorf("creating %s: %w", telemetryExportOutput, err)
+			}
+			defer f.Close()
+			w = f
+		}
+		return runTelemetryExport(w, state.UsagePath(), time.Now())
+	},
+}
+
+func init() {
+	telemetryExportCmd.Flags().StringVarP(&telemetryExportOutput, "output", "o", "", "Write the report to a file instead of stdout")
+
+	telemetryCmd.AddCommand(telemetryUsageCmd)
+	telemetryCmd.AddCommand(telemetryExportCmd)
+}
+
+func runTelemetryUsageStatus(w io.Writer, path string) error {
+	c, err := usage.Load(path)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !c.Enabled:
+		fmt.Fprintln(w, "Usage counters: off (enable with 'gridctl telemetry usage on')")
+	case usage.DoNotTrack():
+		fmt.Fprintln(w, "Usage counters: on, suspended by DO_NOT_TRACK")
+	default:
+		fmt.Fprintf(w, "Usage counters: on since %s (%s)\n", c.Since.Format(time.DateOnly), path)
+	}
+	return nil
+}
+
+func runTelemetryUsageSet(w io.Writer, path string, enabled bool) error {
+	if err := usage.SetEnabled(path, enabled, time.Now()); err != nil {
+		return err
+	}
+	if enabled {
+		fmt.Fprintln(w, "Usage counters on. Nothing leaves this machine; see them with 'gridctl telemetry export'.")
+	} else {
+		fmt.Fprintln(w, "Usage counters off; collected counters deleted.")
+	}
+	return nil
+}
+
+func runTelemetryExport(w io.Writer, path string, now time.Time) error {
+	c, err := usage.Load(path)
+	if err != nil {
+		return err
+	}
+	return output.EncodeJSON(w, c.Export(version, now))
+}
+
+// recordUsage folds one finished invocation into the opt-in usage
+// counters. It runs after every command and must never affect the
+// outcome, so failures are logged at debug level and dropped.
+func recordUsage(cmd *cobra.Command, code int) {
+	if cmd == nil || !usageRecordable(cmd) {
+		return
+	}
+	ev := usage.Event{Command: strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")}
+	if cmd == rootCmd {
+		ev.Command = ""
+	}
+	if code != exitOK {
+		ev.ErrorClass = exitClass(code)
+	}
+	if cmd == applyCmd && code == exitOK {
+		if args := cmd.Flags().Args(); len(args) == 1 {
+			ev.Stack = stackUsageShape(args[0])
+		}
+	}
+	if err := usage.Record(state.UsagePath(), ev); err != nil {
+		slog.Debug("recording usage counters", "error", err)
+	}
+}
+
+// usageRecordable excludes shell-completion requests, help, and the
+// daemon child re-exec, none of which are a user running a command.
+func usageRecordable(cmd *cobra.Command) bool {
+	if strings.HasPrefix(cmd.Name(), "__") || cmd.Name() == "help" {
+		return false
+	}
+	if f := cmd.Flags().Lookup("daemon-child"); f != nil && f.Value.String() == "true" {
+		return false
+	}
+	return true
+}
+
+// stackUsageShape reads the anonymous shape of the stack at path: the
+// server count and each server's transport or adapter kind. Names, images,
+// and URLs are never read out. An unreadable stack yields nil.
+func stackUsageShape(path string) *usage.StackShape {
+	stack, _, err := config.ValidateStackFile(path)
+	if err != nil {
+		return nil
+	}
+	shape := &usage.StackShape{Servers: len(stack.MCPServers)}
+	for i := range stack.MCPServers {
+		shape.Transports = append(shape.Transports, serverUsageKind(&stack.MCPServers[i]))
+	}
+	return shape
+}
+
+// serverUsageKind labels a server by how gridctl reaches it.
+func serverUsageKind(s *config.MCPServer) string {
+	switch {
+	case s.IsExternal():
+		return "external"
+	case s.IsLocalProcess():
+		return "local-process"
+	case s.IsSSH():
+		return "ssh"
+	case s.IsOpenAPI():
+		return "openapi"
+	}
+	transport := s.Transport
+	if transport == "" {
+		transport = "http"
+	}
+	return "container-" + transport
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/state"
+	"github.com/gridctl/gridctl/pkg/usage"
+)
+
+func TestTelemetryUsageOnOffAndExport(t *testing.T) {
+	t.Setenv("DO_NOT_TRACK", "")
+	path := filepath.Join(t.TempDir(), "usage.json")
+
+	var buf bytes.Buffer
+	if err := runTelemetryUsageStatus(&buf, path); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "off") {
+		t.Errorf("expected default status off, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := runTelemetryUsageSet(&buf, path, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := usage.Record(path, usage.Event{Command: "status"}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	if err := runTelemetryExport(&buf, path, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	var report usage.Report
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("export is not JSON: %v\n%s", err, buf.String())
+	}
+	if report.Commands["status"] != 1 {
+		t.Errorf("commands = %v, want status=1", report.Commands)
+	}
+
+	buf.Reset()
+	if err := runTelemetryUsageSet(&buf, path, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected 'usage off' to delete the counters")
+	}
+}
+
+func TestExitCodePathRecordsUsage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DO_NOT_TRACK", "")
+	var buf bytes.Buffer
+	if err := runTelemetryUsageSet(&buf, state.UsagePath(), true); err != nil {
+		t.Fatal(err)
+	}
+	stack := filepath.Join(t.TempDir(), "stack.yaml")
+	if err := os.WriteFile(stack, []byte("name: demo\nmcp-servers:\n  - name: broken\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// validate reports errors through its own exit code rather than by
+	// returning an error, so Execute never sees the outcome.
+	type exited struct{ code int }
+	osExit = func(code int) { panic(exited{code}) }
+	t.Cleanup(func() { osExit = os.Exit })
+	rootCmd.SetArgs([]string{"validate", stack})
+	t.Cleanup(func() { rootCmd.SetArgs(nil) })
+	captureStdout(t, func() {
+		defer func() {
+			if r, ok := recover().(exited); !ok || r.code != 1 {
+				t.Errorf("validate exit = %+v, want exit 1", r)
+			}
+		}()
+		_ = rootCmd.Execute()
+	})
+
+	c, err := usage.Load(state.UsagePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Commands["validate"] != 1 || c.ErrorClasses["failure"] != 1 {
+		t.Errorf("counters = commands %v, error classes %v; want validate=1, failure=1", c.Commands, c.ErrorClasses)
+	}
+}
+
+func TestServerUsageKind(t *testing.T) {
+	tests := []struct {
+		server config.MCPServer
+		want   string
+	}{
+		{config.MCPServer{Image: "ghcr.io/x/y"}, "container-http"},
+		{config.MCPServer{Image: "ghcr.io/x/y", Transport: "stdio"}, "container-stdio"},
+		{config.MCPServer{URL: "https://example.com/mcp"}, "external"},
+		{config.MCPServer{Command: []string{"npx", "srv"}}, "local-process"},
+		{config.MCPServer{Command: []string{"srv"}, SSH: &config.SSHConfig{Host: "h", User: "u"}}, "ssh"},
+		{config.MCPServer{OpenAPI: &config.OpenAPIConfig{Spec: "spec.yaml"}}, "openapi"},
+	}
+	for _, tt := range tests {
+		if got := serverUsageKind(&tt.server); got != tt.want {
+			t.Errorf("serverUsageKind(%+v) = %q, want %q", tt.server, got, tt.want)
+		}
+	}
+}
+
+func TestUsageRecordableSkipsCompletionAndHelp(t *testing.T) {
+	for _, name := range []string{"__complete", "__completeNoDesc", "help"} {
+		cmd, _, err := rootCmd.Find([]string{name})
+		if err != nil || cmd == rootCmd {
+			continue
+		}
+		if usageRecordable(cmd) {
+			t.Errorf("expected %q to be excluded from usage counters", name)
+		}
+	}
+	if !usageRecordable(statusCmd) {
+		t.Error("expected ordinary commands to be recordable")
+	}
+}
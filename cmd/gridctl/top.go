@@ -0,0 +1,418 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gridctl/gridctl/pkg/logging"
+	"github.com/gridctl/gridctl/pkg/output"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	topHTTPTimeout     = 5 * time.Second
+	topDefaultInterval = 2 * time.Second
+	topMinInterval     = 500 * time.Millisecond
+)
+
+var (
+	topStack    string
+	topInterval time.Duration
+	topErrors   int
+)
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Live terminal dashboard for a running gateway",
+	Long: `Opens a live dashboard of a running gateway in the terminal: MCP server
+health, tool-call rates per server, and the most recent gateway errors,
+refreshed from the daemon API every --interval.
+
+Built for operators working over SSH without the web UI. Press q or Ctrl+C
+to quit.
+
+When stdout is not a terminal, or --plain is set, a single snapshot is
+printed without color and the command exits (like 'top -b -n 1').`,
+	Example: `  gridctl top                  Dashboard for the running stack
+  gridctl top -s my-stack      Pick a stack when several are running
+  gridctl top --interval 5s    Refresh every five seconds
+  gridctl top | cat            Print one plain snapshot`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if topInterval < topMinInterval {
+			return usageErrorf("--interval must be at least %s", topMinInterval)
+		}
+		if topErrors < 0 {
+			return usageErrorf("--errors must not be negative")
+		}
+		port, err := resolveRunningPort("top", topStack)
+		if err != nil {
+			return withExitCode(exitInfrastructure, err)
+		}
+		src := &topSource{
+			client:  &http.Client{Timeout: topHTTPTimeout},
+			baseURL: fmt.Sprintf("http://localhost:%d", port),
+			errors:  topErrors,
+		}
+		if output.PlainMode() || !output.IsTerminal(os.Stdout) {
+			return runTopOnce(cmd.Context(), os.Stdout, src)
+		}
+		return runTopLive(cmd.Context(), src, topInterval)
+	},
+}
+
+func init() {
+	topCmd.Flags().StringVarP(&topStack, "stack", "s", "", "Stack to watch (auto-detected when only one stack is running)")
+	topCmd.Flags().DurationVar(&topInterval, "interval", topDefaultInterval, "Refresh interval")
+	topCmd.Flags().IntVar(&topErrors, "errors", 8, "Number of recent errors to show")
+}
+
+// topSnapshot is one poll of the daemon API. Calls holds cumulative tool
+// calls per server; rates are derived from two consecutive snapshots.
+type topSnapshot struct {
+	At      time.Time
+	Servers []mcpServerAPI
+	Calls   map[string]int64
+	Errors  []logging.BufferedEntry
+}
+
+// topSource polls the gateway API endpoints the dashboard renders.
+type topSource struct {
+	client  *http.Client
+	baseURL string
+	errors  int
+}
+
+// fetch takes one snapshot. Server health is required; call counts and
+// errors degrade to empty when their endpoints are unavailable (no metrics
+// accumulator, no log buffer) so the dashboard still shows health.
+func (s *topSource) fetch(ctx context.Context) (topSnapshot, error) {
+	snap := topSnapshot{At: time.Now()}
+	if err := s.getJSON(ctx, "/api/mcp-servers", &snap.Servers); err != nil {
+		return snap, err
+	}
+
+	var usage struct {
+		Servers map[string]map[string]struct {
+			Calls int64 `json:"calls"`
+		} `json:"servers"`
+	}
+	if err := s.getJSON(ctx, "/api/tools/usage", &usage); err == nil {
+		snap.Calls = make(map[string]int64, len(usage.Servers))
+		for server, tools := range usage.Servers {
+			for _, t := range tools {
+				snap.Calls[server] += t.Calls
+			}
+		}
+	}
+
+	if s.errors > 0 {
+		path := fmt.Sprintf("/api/logs?level=ERROR&lines=%d", s.errors)
+		var logs struct {
+			Items []logging.BufferedEntry `json:"items"`
+		}
+		if err := s.getJSON(ctx, path, &logs); err == nil {
+			snap.Errors = logs.Items
+		}
+	}
+	return snap, nil
+}
+
+func (s *topSource) getJSON(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("top: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("top: reading %s: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("top: %s: %s", path, resp.Status)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("top: parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// topRates converts the call-count delta between two snapshots into calls
+// per minute. A missing previous snapshot, or a counter that went backwards
+// (gateway restart), yields no rate rather than a misleading one.
+func topRates(prev, cur *topSnapshot) map[string]float64 {
+	if prev == nil || cur == nil || prev.Calls == nil || cur.Calls == nil {
+		return nil
+	}
+	elapsed := cur.At.Sub(prev.At)
+	if elapsed <= 0 {
+		return nil
+	}
+	rates := make(map[string]float64, len(cur.Calls))
+	for server, n := range cur.Calls {
+		delta := n - prev.Calls[server]
+		if delta < 0 {
+			continue
+		}
+		rates[server] = float64(delta) / elapsed.Minutes()
+	}
+	return rates
+}
+
+// topHealth classifies a server for the HEALTH column.
+func topHealth(s mcpServerAPI) string {
+	switch {
+	case s.RegFailed:
+		return "failed"
+	case s.AuthStatus == "needs_auth":
+		return "needs auth"
+	case s.Healthy == nil:
+		return "unknown"
+	case *s.Healthy:
+		return "healthy"
+	default:
+		return "unhealthy"
+	}
+}
+
+// topStyles holds the dashboard styles; the zero value renders unstyled.
+type topStyles struct {
+	title, header, muted, good, warn, bad lipgloss.Style
+}
+
+func newTopStyles(color bool) topStyles {
+	if !color {
+		return topStyles{}
+	}
+	return topStyles{
+		title:  lipgloss.NewStyle().Foreground(output.ColorAmber).Bold(true),
+		header: lipgloss.NewStyle().Foreground(output.ColorGray).Bold(true),
+		muted:  lipgloss.NewStyle().Foreground(output.ColorMuted),
+		good:   lipgloss.NewStyle().Foreground(output.ColorGreen),
+		warn:   lipgloss.NewStyle().Foreground(output.ColorAmber),
+		bad:    lipgloss.NewStyle().Foreground(output.ColorRed),
+	}
+}
+
+// renderTop draws one dashboard frame. err is the most recent poll error,
+// shown above the (possibly stale) last good snapshot.
+func renderTop(snap *topSnapshot, rates map[string]float64, err error, st topStyles, width int) string {
+	var b strings.Builder
+	header := "gridctl top"
+	if snap != nil && !snap.At.IsZero() {
+		header += "  " + snap.At.Format("15:04:05")
+	}
+	b.WriteString(st.title.Render(header) + "\n")
+	if err != nil {
+		b.WriteString(st.bad.Render("error: "+err.Error()) + "\n")
+	}
+	if snap == nil {
+		b.WriteString(st.muted.Render("connecting...") + "\n")
+		return b.String()
+	}
+
+	servers := append([]mcpServerAPI(nil), snap.Servers...)
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+	healthy := 0
+	for _, s := range servers {
+		if topHealth(s) == "healthy" {
+			healthy++
+		}
+	}
+	fmt.Fprintf(&b, "%s\n\n", st.muted.Render(fmt.Sprintf("%d/%d servers healthy", healthy, len(servers))))
+
+	nameWidth := len("SERVER")
+	for _, s := range servers {
+		nameWidth = max(nameWidth, len(s.Name))
+	}
+	row := func(name, health, transport, calls, rate string) string {
+		return fmt.Sprintf("%-*s  %-10s  %-10s  %8s  %9s", nameWidth, name, health, transport, calls, rate)
+	}
+	b.WriteString(st.header.Render(row("SERVER", "HEALTH", "TRANSPORT", "CALLS", "CALLS/MIN")) + "\n")
+	for _, s := range servers {
+		health := topHealth(s)
+		calls, rate := "-", "-"
+		if snap.Calls != nil {
+			calls = fmt.Sprintf("%d", snap.Calls[s.Name])
+		}
+		if r, ok := rates[s.Name]; ok {
+			rate = fmt.Sprintf("%.1f", r)
+		}
+		line := row(s.Name, health, topTransport(s), calls, rate)
+		switch health {
+		case "healthy":
+			line = st.good.Render(line)
+		case "unknown", "needs auth":
+			line = st.warn.Render(line)
+		default:
+			line = st.bad.Render(line)
+		}
+		b.WriteString(line + "\n")
+		if s.HealthError != "" && health != "healthy" {
+			b.WriteString(st.muted.Render(truncateTop("  "+s.HealthError, width)) + "\n")
+		}
+	}
+	if len(servers) == 0 {
+		b.WriteString(st.muted.Render("no MCP servers") + "\n")
+	}
+
+	b.WriteString("\n" + st.header.Render("RECENT ERRORS") + "\n")
+	if len(snap.Errors) == 0 {
+		b.WriteString(st.muted.Render("none") + "\n")
+	}
+	for _, e := range snap.Errors {
+		ts := e.Timestamp
+		if t, perr := time.Parse(time.RFC3339Nano, e.Timestamp); perr == nil {
+			ts = t.Local().Format("15:04:05")
+		}
+		msg := e.Message
+		if e.Component != "" {
+			msg = e.Component + ": " + msg
+		}
+		if errAttr, ok := e.Attrs["error"]; ok {
+			msg += fmt.Sprintf(" (%v)", errAttr)
+		}
+		b.WriteString(st.muted.Render(ts) + "  " + st.bad.Render(truncateTop(msg, width-len(ts)-2)) + "\n")
+	}
+	return b.String()
+}
+
+// topTransport names how the gateway reaches a server.
+func topTransport(s mcpServerAPI) string {
+	switch {
+	case s.OpenAPI:
+		return "openapi"
+	case s.SSH:
+		return "ssh"
+	case s.External:
+		return "external"
+	case s.LocalProcess:
+		return "process"
+	case s.Transport != "":
+		return s.Transport
+	default:
+		return "-"
+	}
+}
+
+// truncateTop clips s to width runes; width <= 0 leaves s untouched.
+func truncateTop(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
+
+// runTopOnce prints a single unstyled snapshot for pipes and CI logs.
+func runTopOnce(ctx context.Context, w io.Writer, src *topSource) error {
+	snap, err := src.fetch(ctx)
+	if err != nil {
+		return withExitCode(exitInfrastructure, err)
+	}
+	_, err = io.WriteString(w, renderTop(&snap, nil, nil, topStyles{}, 0))
+	return err
+}
+
+// topSnapshotMsg carries a completed poll into the bubbletea update loop.
+// Manual marks a poll the user asked for with "r": the scheduled tick chain
+// is already running, so it must not start another.
+type topSnapshotMsg struct {
+	snap   topSnapshot
+	err    error
+	manual bool
+}
+
+type topTickMsg struct{}
+
+// topModel is the bubbletea model behind the live dashboard.
+type topModel struct {
+	ctx      context.Context
+	src      *topSource
+	interval time.Duration
+	styles   topStyles
+
+	prev, cur *topSnapshot
+	err       error
+	width     int
+}
+
+func (m topModel) poll(manual bool) tea.Cmd {
+	return func() tea.Msg {
+		snap, err := m.src.fetch(m.ctx)
+		return topSnapshotMsg{snap: snap, err: err, manual: manual}
+	}
+}
+
+func (m topModel) Init() tea.Cmd {
+	return m.poll(false)
+}
+
+func (m topModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "r":
+			return m, m.poll(true)
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case topSnapshotMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			snap := msg.snap
+			m.prev, m.cur = m.cur, &snap
+		}
+		if msg.manual {
+			return m, nil
+		}
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg { return topTickMsg{} })
+	case topTickMsg:
+		return m, m.poll(false)
+	}
+	return m, nil
+}
+
+func (m topModel) View() string {
+	frame := renderTop(m.cur, topRates(m.prev, m.cur), m.err, m.styles, m.width)
+	return frame + "\n" + m.styles.muted.Render("q quit · r refresh")
+}
+
+// runTopLive runs the interactive dashboard on the alternate screen until
+// the user quits or ctx is cancelled.
+func runTopLive(ctx context.Context, src *topSource, interval time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	m := topModel{
+		ctx:      ctx,
+		src:      src,
+		interval: interval,
+		styles:   newTopStyles(output.ColorEnabled(os.Stdout)),
+	}
+	_, err := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newTopTestServer(t *testing.T, usageStatus int) *topSource {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/mcp-servers", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name":"github","transport":"http","healthy":true},
+			{"name":"slack","external":true,"healthy":false,"healthError":"connection refused"},
+			{"name":"atlassian","transport":"http","authStatus":"needs_auth"}
+		]`))
+	})
+	mux.HandleFunc("/api/tools/usage", func(w http.ResponseWriter, r *http.Request) {
+		if usageStatus != http.StatusOK {
+			http.Error(w, "no accumulator", usageStatus)
+			return
+		}
+		_, _ = w.Write([]byte(`{"servers":{"github":{"search":{"calls":4},"get_issue":{"calls":2}}}}`))
+	})
+	mux.HandleFunc("/api/logs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("level") != "ERROR" {
+			t.Errorf("expected errors-only log query, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"items":[{"level":"ERROR","ts":"2026-03-14T15:09:26Z","msg":"health check failed","component":"gateway","attrs":{"error":"connection refused"}}],"total":1}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return &topSource{client: srv.Client(), baseURL: srv.URL, errors: 5}
+}
+
+func TestTopSourceFetch(t *testing.T) {
+	snap, err := newTopTestServer(t, http.StatusOK).fetch(context.Background())
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(snap.Servers) != 3 {
+		t.Errorf("servers = %d, want 3", len(snap.Servers))
+	}
+	if snap.Calls["github"] != 6 {
+		t.Errorf("github calls = %d, want the per-tool sum 6", snap.Calls["github"])
+	}
+	if len(snap.Errors) != 1 || snap.Errors[0].Message != "health check failed" {
+		t.Errorf("errors = %+v", snap.Errors)
+	}
+}
+
+func TestTopSourceFetchWithoutMetrics(t *testing.T) {
+	snap, err := newTopTestServer(t, http.StatusServiceUnavailable).fetch(context.Background())
+	if err != nil {
+		t.Fatalf("a missing metrics accumulator must not fail the dashboard: %v", err)
+	}
+	if snap.Calls != nil {
+		t.Errorf("calls = %v, want nil so the columns render as '-'", snap.Calls)
+	}
+}
+
+func TestTopRates(t *testing.T) {
+	t0 := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	prev := &topSnapshot{At: t0, Calls: map[string]int64{"github": 10, "slack": 50}}
+	cur := &topSnapshot{At: t0.Add(30 * time.Second), Calls: map[string]int64{"github": 13, "slack": 2, "new": 1}}
+
+	rates := topRates(prev, cur)
+	if rates["github"] != 6 {
+		t.Errorf("github rate = %v, want 6/min", rates["github"])
+	}
+	if _, ok := rates["slack"]; ok {
+		t.Error("a counter that went backwards must not produce a rate")
+	}
+	if rates["new"] != 2 {
+		t.Errorf("new server rate = %v, want 2/min", rates["new"])
+	}
+	if topRates(nil, cur) != nil {
+		t.Error("expected no rates without a previous snapshot")
+	}
+}
+
+func TestRenderTop(t *testing.T) {
+	snap, err := newTopTestServer(t, http.StatusOK).fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame := renderTop(&snap, map[string]float64{"github": 1.5}, nil, topStyles{}, 0)
+
+	for _, want := range []string{
+		"1/3 servers healthy",
+		"CALLS/MIN",
+		"healthy",
+		"unhealthy",
+		"needs auth",
+		"connection refused",
+		"1.5",
+		"gateway: health check failed (connection refused)",
+	} {
+		if !strings.Contains(frame, want) {
+			t.Errorf("frame missing %q:\n%s", want, frame)
+		}
+	}
+	// Rows are sorted by server name.
+	if strings.Index(frame, "atlassian") > strings.Index(frame, "github") {
+		t.Errorf("expected servers sorted by name:\n%s", frame)
+	}
+	if strings.Contains(frame, "\x1b[") {
+		t.Error("unstyled frame must not contain ANSI escapes")
+	}
+}
+
+func TestRunTopOnce(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runTopOnce(context.Background(), &buf, newTopTestServer(t, http.StatusOK)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "RECENT ERRORS") {
+		t.Errorf("snapshot = %q", buf.String())
+	}
+}
+
+func TestTopModelManualRefresh(t *testing.T) {
+	m := topModel{ctx: context.Background(), src: newTopTestServer(t, http.StatusOK), interval: time.Second}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil {
+		t.Fatal("expected r to fetch a snapshot")
+	}
+	msg, ok := cmd().(topSnapshotMsg)
+	if !ok || !msg.manual || msg.err != nil {
+		t.Fatalf("r produced %+v, want a manual snapshot", msg)
+	}
+	next, cmd := m.Update(msg)
+	if next.(topModel).cur == nil {
+		t.Error("manual snapshot was not stored")
+	}
+	if cmd != nil {
+		t.Error("pressing r must not start a second tick chain")
+	}
+}
+
+func TestTopModelUpdate(t *testing.T) {
+	m := topModel{interval: time.Second}
+
+	next, cmd := m.Update(topSnapshotMsg{snap: topSnapshot{At: time.Now()}})
+	m = next.(topModel)
+	if m.cur == nil || cmd == nil {
+		t.Fatal("expected the snapshot stored and the next tick scheduled")
+	}
+
+	next, _ = m.Update(topSnapshotMsg{err: context.DeadlineExceeded})
+	m = next.(topModel)
+	if m.cur == nil || m.err == nil {
+		t.Error("a failed poll must keep the last good snapshot and surface the error")
+	}
+
+	next, cmd = m.Update(topSnapshotMsg{snap: topSnapshot{At: time.Now()}, manual: true})
+	m = next.(topModel)
+	if cmd != nil {
+		t.Error("a manual refresh must not schedule another tick")
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatal("expected q to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected q to produce tea.QuitMsg")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/gridctl/gridctl/pkg/output"
 	"github.com/gridctl/gridctl/pkg/tracing"
 	"github.com/spf13/cobra"
 )
@@ -58,6 +59,7 @@ With a trace ID, shows the full span waterfall.`,
 func init() {
 	tracesCmd.Flags().StringVarP(&tracesStack, "stack", "s", "", "Stack to query (defaults to first running stack)")
 	tracesCmd.Flags().StringVar(&tracesServer, "server", "", "Filter by server name")
+	_ = tracesCmd.RegisterFlagCompletionFunc("server", completeServerFlag)
 	tracesCmd.Flags().BoolVar(&tracesErrorsOnly, "errors", false, "Show only error traces")
 	tracesCmd.Flags().StringVar(&tracesMinDuration, "min-duration", "", "Minimum trace duration (e.g. 100ms, 1s)")
 	tracesCmd.Flags().BoolVar(&tracesJSON, "json", false, "Output as JSON")
@@ -110,6 +112,7 @@ func fetchTraces(port int) ([]tracing.TraceRecord, error) {
 
 // runTracesList prints a table of recent traces.
 func runTracesList(port int) error {
+	out := output.New()
 	records, err := fetchTraces(port)
 	if err != nil {
 		return err
@@ -120,7 +123,7 @@ func runTracesList(port int) error {
 		return enc.Encode(records)
 	}
 	if len(records) == 0 {
-		fmt.Println("No traces yet")
+		out.Println("No traces yet")
 		return nil
 	}
 	printTracesTable(os.Stdout, records)
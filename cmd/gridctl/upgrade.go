@@ -18,6 +18,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gridctl/gridctl/pkg/output"
+
 	"github.com/Masterminds/semver/v3"
 	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
@@ -62,6 +64,7 @@ func init() {
 }
 
 func runUpgrade() error {
+	out := output.New()
 	// Resolve the running binary's absolute path so we can detect brew + replace it.
 	exePath, err := resolveExecutable()
 	if err != nil {
@@ -70,8 +73,8 @@ func runUpgrade() error {
 
 	// Brew detection — defer to `brew upgrade` unless --force.
 	if isHomebrewPath(exePath) && !upgradeForce {
-		fmt.Printf("gridctl is installed via Homebrew at %s\n", exePath)
-		fmt.Println("Run `brew upgrade gridctl/tap/gridctl` to update.")
+		out.Print("gridctl is installed via Homebrew at %s\n", exePath)
+		out.Println("Run `brew upgrade gridctl/tap/gridctl` to update.")
 		return nil
 	}
 
@@ -92,20 +95,20 @@ func runUpgrade() error {
 	if upgradeVersion != "" {
 		targetLabel = "Target version: "
 	}
-	fmt.Printf("Current version: %s\n", currentTag)
-	fmt.Printf("%s%s\n", targetLabel, targetTag)
+	out.Print("Current version: %s\n", currentTag)
+	out.Print("%s%s\n", targetLabel, targetTag)
 
 	// Compare. Use semver where possible; fall back to exact-string equality.
 	cmp, ok := compareTags(currentTag, targetTag)
 	switch {
 	case upgradeVersion == "" && ok && cmp >= 0 && !upgradeForce:
-		fmt.Println("gridctl is up to date.")
+		out.Println("gridctl is up to date.")
 		return nil
 	case upgradeCheck:
 		if ok && cmp >= 0 {
-			fmt.Println("gridctl is up to date.")
+			out.Println("gridctl is up to date.")
 		} else {
-			fmt.Println("An update is available. Run `gridctl upgrade` to update.")
+			out.Println("An update is available. Run `gridctl upgrade` to update.")
 		}
 		return nil
 	}
@@ -116,14 +119,14 @@ func runUpgrade() error {
 		if !isatty.IsTerminal(os.Stdin.Fd()) {
 			return fmt.Errorf("non-interactive shell detected; pass --yes to confirm the upgrade")
 		}
-		fmt.Printf("\nUpgrade gridctl to %s? [y/N] ", targetTag)
+		out.Print("\nUpgrade gridctl to %s? [y/N] ", targetTag)
 		reader := bufio.NewReader(os.Stdin)
 		line, _ := reader.ReadString('\n')
 		switch strings.ToLower(strings.TrimSpace(line)) {
 		case "y", "yes":
 			// proceed
 		default:
-			fmt.Println("Aborted.")
+			out.Println("Aborted.")
 			return nil
 		}
 	}
@@ -140,7 +143,7 @@ func runUpgrade() error {
 	archiveURL := fmt.Sprintf("https://github.com/%s/releases/download/%s/%s", upgradeRepo, targetTag, archiveName)
 	checksumsURL := fmt.Sprintf("https://github.com/%s/releases/download/%s/checksums.txt", upgradeRepo, targetTag)
 
-	fmt.Printf("\n  Downloading %s\n", archiveName)
+	out.Print("\n  Downloading %s\n", archiveName)
 	archivePath := filepath.Join(tmpDir, archiveName)
 	if err := downloadFile(archiveURL, archivePath, upgradeMaxArchiveSize); err != nil {
 		return fmt.Errorf("downloading release: %w", err)
@@ -150,11 +153,11 @@ func runUpgrade() error {
 		return fmt.Errorf("downloading checksums: %w", err)
 	}
 
-	fmt.Println("  Verifying SHA256")
+	out.Println("  Verifying SHA256")
 	if err := verifySHA256(archivePath, checksumsPath, archiveName); err != nil {
 		return err
 	}
-	fmt.Println("  ✓ checksum matches")
+	out.Println("  ✓ checksum matches")
 
 	binPath := filepath.Join(tmpDir, "gridctl")
 	if err := extractGridctlBinary(archivePath, binPath); err != nil {
@@ -165,7 +168,7 @@ func runUpgrade() error {
 		return fmt.Errorf("replacing binary: %w", err)
 	}
 
-	fmt.Printf("\nUpgraded gridctl from %s to %s\n", currentTag, targetTag)
+	out.Print("\nUpgraded gridctl from %s to %s\n", currentTag, targetTag)
 	return nil
 }
 
@@ -2,10 +2,10 @@ package main
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
 
 	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/output"
 
 	"github.com/spf13/cobra"
 )
@@ -65,32 +65,33 @@ func runValidate(stackPath string) error {
 
 	// Exit codes: 0=valid, 1=errors, 2=warnings only
 	if result.ErrorCount > 0 {
-		os.Exit(1)
+		exitNow(1)
 	}
 	if result.WarningCount > 0 {
-		os.Exit(2)
+		exitNow(2)
 	}
 
 	return nil
 }
 
 func printValidationResult(path string, result *config.ValidationResult) {
+	out := output.New()
 	if result.Valid && result.WarningCount == 0 {
-		fmt.Printf("✓ %s is valid\n", path)
+		out.Print("✓ %s is valid\n", path)
 		return
 	}
 
 	if result.Valid && result.WarningCount > 0 {
-		fmt.Printf("⚠ %s is valid with %d warning(s)\n", path, result.WarningCount)
+		out.Print("⚠ %s is valid with %d warning(s)\n", path, result.WarningCount)
 	} else {
-		fmt.Printf("✗ %s has %d error(s)", path, result.ErrorCount)
+		out.Print("✗ %s has %d error(s)", path, result.ErrorCount)
 		if result.WarningCount > 0 {
-			fmt.Printf(" and %d warning(s)", result.WarningCount)
+			out.Print(" and %d warning(s)", result.WarningCount)
 		}
-		fmt.Println()
+		out.Println()
 	}
 
-	fmt.Println()
+	out.Println()
 	for _, issue := range result.Issues {
 		var prefix string
 		switch issue.Severity {
@@ -101,6 +102,6 @@ func printValidationResult(path string, result *config.ValidationResult) {
 		case config.SeverityInfo:
 			prefix = "  ℹ"
 		}
-		fmt.Printf("%s %s: %s\n", prefix, issue.Field, issue.Message)
+		out.Print("%s %s: %s\n", prefix, issue.Field, issue.Message)
 	}
 }
@@ -279,6 +279,7 @@ func validateAndNormalize(typeName, value string) (string, error) {
 }
 
 func runVarSet(key string) error {
+	out := output.New()
 	if varSetSecret && varSetPlaintext {
 		return fmt.Errorf("--secret and --plaintext are mutually exclusive")
 	}
@@ -296,9 +297,9 @@ func runVarSet(key string) error {
 	if value == "" {
 		// Interactive: read from terminal or stdin
 		if isatty.IsTerminal(os.Stdin.Fd()) {
-			fmt.Printf("Enter value for %s: ", key)
+			out.Print("Enter value for %s: ", key)
 			raw, err := term.ReadPassword(int(os.Stdin.Fd()))
-			fmt.Println() // newline after hidden input
+			out.Println() // newline after hidden input
 			if err != nil {
 				return fmt.Errorf("reading input: %w", err)
 			}
@@ -352,6 +353,7 @@ func runVarSet(key string) error {
 }
 
 func runVarGet(key string) error {
+	printer := output.New()
 	store, err := loadVault()
 	if err != nil {
 		return err
@@ -374,15 +376,16 @@ func runVarGet(key string) error {
 	// --plain or plaintext variable: show raw value alone (legacy --plain
 	// behaviour for tooling pipes the raw value out).
 	if varGetPlain || !v.IsSecret {
-		fmt.Println(v.Value)
+		printer.Println(v.Value)
 		return nil
 	}
 
-	fmt.Printf("%s = %s  (type: %s, %s)\n", key, maskValue(v.Value), v.Type, visibility)
+	printer.Print("%s = %s  (type: %s, %s)\n", key, maskValue(v.Value), v.Type, visibility)
 	return nil
 }
 
 func runVarList() error {
+	printer := output.New()
 	store, err := loadVault()
 	if err != nil {
 		return err
@@ -410,12 +413,12 @@ func runVarList() error {
 			out = append(out, entry{Key: v.Key, Type: string(v.Type), Visibility: vis, Set: v.Set})
 		}
 		data, _ := json.MarshalIndent(out, "", "  ")
-		fmt.Println(string(data))
+		printer.Println(string(data))
 		return nil
 	}
 
 	if len(vars) == 0 {
-		fmt.Println("No variables stored")
+		printer.Println("No variables stored")
 		return nil
 	}
 
@@ -433,6 +436,7 @@ func runVarList() error {
 }
 
 func runVarDelete(key string) error {
+	out := output.New()
 	store, err := loadVault()
 	if err != nil {
 		return err
@@ -447,12 +451,12 @@ func runVarDelete(key string) error {
 	}
 
 	if !varDeleteForce {
-		fmt.Printf("Delete %s? [y/N] ", key)
+		out.Print("Delete %s? [y/N] ", key)
 		reader := bufio.NewReader(os.Stdin)
 		answer, _ := reader.ReadString('\n')
 		answer = strings.TrimSpace(strings.ToLower(answer))
 		if answer != "y" && answer != "yes" {
-			fmt.Println("Cancelled")
+			out.Println("Cancelled")
 			return nil
 		}
 	}
@@ -492,6 +496,7 @@ func runVarImport(file string) error {
 }
 
 func runVarExport() error {
+	printer := output.New()
 	store, err := loadVault()
 	if err != nil {
 		return err
@@ -503,7 +508,7 @@ func runVarExport() error {
 
 	vars := store.List()
 	if len(vars) == 0 {
-		fmt.Println("No variables stored")
+		printer.Println("No variables stored")
 		return nil
 	}
 
@@ -533,29 +538,30 @@ func runVarExport() error {
 			})
 		}
 		data, _ := json.MarshalIndent(out, "", "  ")
-		fmt.Println(string(data))
+		printer.Println(string(data))
 	default: // env
 		for _, v := range vars {
 			// Markers above the KEY=VALUE line only when they differ from
 			// the .env defaults (secret/string) so existing .env consumers
 			// can keep using vanilla parsers.
 			if !v.IsSecret {
-				fmt.Println("# @public")
+				printer.Println("# @public")
 			}
 			if v.Type != "" && v.Type != vault.TypeString {
-				fmt.Printf("# @type=%s\n", v.Type)
+				printer.Print("# @type=%s\n", v.Type)
 			}
 			val := v.Value
 			if !varExportPlain && v.IsSecret {
 				val = maskValue(val)
 			}
-			fmt.Printf("%s=%s\n", v.Key, val)
+			printer.Print("%s=%s\n", v.Key, val)
 		}
 	}
 	return nil
 }
 
 func runVarSetsList() error {
+	out := output.New()
 	store, err := loadVault()
 	if err != nil {
 		return err
@@ -567,7 +573,7 @@ func runVarSetsList() error {
 
 	sets := store.ListSets()
 	if len(sets) == 0 {
-		fmt.Println("No variable sets defined")
+		out.Println("No variable sets defined")
 		return nil
 	}
 
@@ -645,13 +651,14 @@ func runVarLock() error {
 }
 
 func runVarUnlock() error {
+	out := output.New()
 	store, err := loadVault()
 	if err != nil {
 		return err
 	}
 
 	if !store.IsLocked() {
-		fmt.Println("Variable store is already unlocked")
+		out.Println("Variable store is already unlocked")
 		return nil
 	}
 
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/gridctl/gridctl/pkg/output"
 	"github.com/gridctl/gridctl/pkg/state"
 	"github.com/gridctl/gridctl/pkg/vault"
 
@@ -27,6 +28,7 @@ func loadVault() (*vault.Store, error) {
 
 // ensureUnlocked prompts for the passphrase when the store is locked.
 func ensureUnlocked(store *vault.Store) error {
+	out := output.New()
 	if !store.IsLocked() {
 		return nil
 	}
@@ -36,9 +38,9 @@ func ensureUnlocked(store *vault.Store) error {
 		if !isatty.IsTerminal(os.Stdin.Fd()) {
 			return fmt.Errorf("vault is locked. Set GRIDCTL_VAULT_PASSPHRASE or run 'gridctl var unlock'")
 		}
-		fmt.Print("Vault passphrase: ")
+		out.Print("Vault passphrase: ")
 		raw, err := term.ReadPassword(int(os.Stdin.Fd()))
-		fmt.Println()
+		out.Println()
 		if err != nil {
 			return fmt.Errorf("reading passphrase: %w", err)
 		}
@@ -50,6 +52,7 @@ func ensureUnlocked(store *vault.Store) error {
 
 // promptPassphrase reads a hidden passphrase from the terminal.
 func promptPassphrase(prompt string) (string, error) {
+	out := output.New()
 	pass := os.Getenv("GRIDCTL_VAULT_PASSPHRASE")
 	if pass != "" {
 		return pass, nil
@@ -59,9 +62,9 @@ func promptPassphrase(prompt string) (string, error) {
 		return "", fmt.Errorf("interactive input required. Set GRIDCTL_VAULT_PASSPHRASE for non-interactive use")
 	}
 
-	fmt.Print(prompt)
+	out.Print("%s", prompt)
 	raw, err := term.ReadPassword(int(os.Stdin.Fd()))
-	fmt.Println()
+	out.Println()
 	if err != nil {
 		return "", fmt.Errorf("reading input: %w", err)
 	}
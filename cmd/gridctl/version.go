@@ -1,8 +1,6 @@
 package main
 
 import (
-	"fmt"
-
 	"github.com/gridctl/gridctl/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +18,7 @@ var versionCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		printer := output.New()
 		printer.Banner(version)
-		fmt.Printf("  commit: %s\n", commit)
-		fmt.Printf("  built:  %s\n", date)
+		printer.Print("  commit: %s\n", commit)
+		printer.Print("  built:  %s\n", date)
 	},
 }
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// toolAnalyticsTool is one registered tool in GET /api/analytics/tools.
+// Unlike GET /api/tools/usage, never-called tools are listed with zero
+// calls so the report can name them.
+type toolAnalyticsTool struct {
+	Name         string     `json:"name"`
+	Calls        int64      `json:"calls"`
+	LastCalledAt *time.Time `json:"lastCalledAt,omitempty"`
+}
+
+// toolAnalyticsServer rolls a server's tool usage up. LastCalledAt is the
+// most recent call to any of its tools.
+type toolAnalyticsServer struct {
+	Name         string              `json:"name"`
+	ToolCount    int                 `json:"toolCount"`
+	UsedTools    int                 `json:"usedTools"`
+	Calls        int64               `json:"calls"`
+	LastCalledAt *time.Time          `json:"lastCalledAt,omitempty"`
+	Tools        []toolAnalyticsTool `json:"tools"`
+}
+
+// toolAnalyticsResponse is the GET /api/analytics/tools envelope.
+// UnusedTools holds prefixed names (server__tool) of registered tools with
+// no recorded calls, excluding tools of servers in UnusedServers, which are
+// reported once as a whole.
+type toolAnalyticsResponse struct {
+	ObservedSince *time.Time            `json:"observedSince,omitempty"`
+	TotalTools    int                   `json:"totalTools"`
+	UsedTools     int                   `json:"usedTools"`
+	TotalCalls    int64                 `json:"totalCalls"`
+	Servers       []toolAnalyticsServer `json:"servers"`
+	UnusedServers []string              `json:"unusedServers"`
+	UnusedTools   []string              `json:"unusedTools"`
+}
+
+// handleToolAnalytics serves GET /api/analytics/tools: every registered
+// tool joined with its recorded call count and last-called time, per-server
+// rollups, and the never-used tools and servers. The usage side is the same
+// accumulator data GET /api/tools/usage serves, so it carries the same
+// observedSince caveat. Returns 503 when no accumulator is wired.
+func (s *Server) handleToolAnalytics(w http.ResponseWriter, _ *http.Request) {
+	if s.metricsAccumulator == nil {
+		writeJSONError(w, "metrics accumulator not configured", http.StatusServiceUnavailable)
+		return
+	}
+	if s.gateway == nil {
+		writeJSONError(w, "gateway not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	resp := toolAnalyticsResponse{
+		Servers:       []toolAnalyticsServer{},
+		UnusedServers: []string{},
+		UnusedTools:   []string{},
+	}
+	if started := s.metricsAccumulator.StartedAt(); !started.IsZero() {
+		t := started.UTC()
+		resp.ObservedSince = &t
+	}
+
+	usage := s.metricsAccumulator.ToolUsageSnapshot()
+	statuses := s.gateway.Status()
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	for _, ms := range statuses {
+		srv := toolAnalyticsServer{Name: ms.Name, ToolCount: len(ms.Tools), Tools: make([]toolAnalyticsTool, 0, len(ms.Tools))}
+		var unused []string
+		for _, tool := range ms.Tools {
+			entry := toolAnalyticsTool{Name: tool}
+			if stat, ok := usage[ms.Name][tool]; ok && stat.Calls > 0 {
+				entry.Calls = stat.Calls
+				srv.Calls += stat.Calls
+				srv.UsedTools++
+				if !stat.LastCalledAt.IsZero() {
+					t := stat.LastCalledAt.UTC()
+					entry.LastCalledAt = &t
+					if srv.LastCalledAt == nil || t.After(*srv.LastCalledAt) {
+						srv.LastCalledAt = &t
+					}
+				}
+			} else {
+				unused = append(unused, mcp.PrefixTool(ms.Name, tool))
+			}
+			srv.Tools = append(srv.Tools, entry)
+		}
+		sort.Slice(srv.Tools, func(i, j int) bool {
+			if srv.Tools[i].Calls != srv.Tools[j].Calls {
+				return srv.Tools[i].Calls > srv.Tools[j].Calls
+			}
+			return srv.Tools[i].Name < srv.Tools[j].Name
+		})
+
+		resp.TotalTools += srv.ToolCount
+		resp.UsedTools += srv.UsedTools
+		resp.TotalCalls += srv.Calls
+		if srv.ToolCount > 0 && srv.UsedTools == 0 {
+			resp.UnusedServers = append(resp.UnusedServers, ms.Name)
+		} else {
+			sort.Strings(unused)
+			resp.UnusedTools = append(resp.UnusedTools, unused...)
+		}
+		resp.Servers = append(resp.Servers, srv)
+	}
+	writeJSON(w, resp)
+}
+
+// defaultUsageDays and maxUsageDays bound the days parameter of
+// GET /api/analytics/usage; the upper bound is the accumulator's retention.
+const (
+	defaultUsageDays = 7
+	maxUsageDays     = 90
+)
+
+// dailyUsageRow is one (day, client, server, tool) row in
+// GET /api/analytics/usage. CostUSD is absent for unpriced rows, never $0.
+type dailyUsageRow struct {
+	Date         string   `json:"date"`
+	Client       string   `json:"client,omitempty"`
+	Server       string   `json:"server"`
+	Tool         string   `json:"tool"`
+	Calls        int64    `json:"calls"`
+	InputTokens  int64    `json:"inputTokens"`
+	OutputTokens int64    `json:"outputTokens"`
+	CostUSD      *float64 `json:"costUsd,omitempty"`
+}
+
+// handleUsageAnalytics serves GET /api/analytics/usage: calls, tokens, and
+// cost per local calendar day, client, server, and tool for the last days
+// days (default 7, at most 90), newest first. Tokens and cost are the
+// ones a tool reported in its result's _meta when it did, otherwise the
+// gateway's estimate. The rollup is held in memory and starts empty on
+// every gateway start. Returns 503 when no accumulator is wired.
+func (s *Server) handleUsageAnalytics(w http.ResponseWriter, r *http.Request) {
+	if s.metricsAccumulator == nil {
+		writeJSONError(w, "metrics accumulator not configured", http.StatusServiceUnavailable)
+		return
+	}
+	days := defaultUsageDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxUsageDays {
+			writeJSONError(w, "days must be an integer between 1 and "+strconv.Itoa(maxUsageDays), http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+
+	snapshot := s.metricsAccumulator.DailyUsageSnapshot(days)
+	rows := make([]dailyUsageRow, 0, len(snapshot))
+	for _, d := range snapshot {
+		row := dailyUsageRow{
+			Date:         d.Date,
+			Client:       d.Client,
+			Server:       d.Server,
+			Tool:         d.Tool,
+			Calls:        d.Calls,
+			InputTokens:  d.InputTokens,
+			OutputTokens: d.OutputTokens,
+		}
+		if d.CostMicroUSD > 0 {
+			usd := d.CostUSD()
+			row.CostUSD = &usd
+		}
+		rows = append(rows, row)
+	}
+	writeList(w, r, rows)
+}
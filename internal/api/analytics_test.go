@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+func decodeToolAnalytics(t *testing.T, srv *Server) (toolAnalyticsResponse, int) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/tools", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	var resp toolAnalyticsResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal response: %v (body=%s)", err, rec.Body.String())
+		}
+	}
+	return resp, rec.Code
+}
+
+func TestHandleToolAnalytics_JoinsRegistryWithUsage(t *testing.T) {
+	srv := newTestServerWithMetrics(t)
+	srv.gateway.Router().AddClient(newMockAgentClient("github", []mcp.Tool{
+		{Name: "create_issue"}, {Name: "list_repos"}, {Name: "delete_repo"},
+	}))
+	registerMockServerMeta(srv.gateway, "github", mcp.TransportHTTP)
+	srv.gateway.Router().AddClient(newMockAgentClient("slack", []mcp.Tool{{Name: "post"}}))
+	registerMockServerMeta(srv.gateway, "slack", mcp.TransportHTTP)
+
+	srv.metricsAccumulator.RecordToolCall("github", "create_issue")
+	srv.metricsAccumulator.RecordToolCall("github", "create_issue")
+	srv.metricsAccumulator.RecordToolCall("github", "list_repos")
+
+	resp, code := decodeToolAnalytics(t, srv)
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if resp.TotalTools != 4 || resp.UsedTools != 2 || resp.TotalCalls != 3 {
+		t.Errorf("totals = %d tools, %d used, %d calls", resp.TotalTools, resp.UsedTools, resp.TotalCalls)
+	}
+	if len(resp.Servers) != 2 || resp.Servers[0].Name != "github" {
+		t.Fatalf("servers = %+v", resp.Servers)
+	}
+	gh := resp.Servers[0]
+	if gh.Tools[0].Name != "create_issue" || gh.Tools[0].Calls != 2 {
+		t.Errorf("tools should be sorted by calls: %+v", gh.Tools)
+	}
+	if gh.LastCalledAt == nil {
+		t.Error("server lastCalledAt should be set")
+	}
+	if len(resp.UnusedTools) != 1 || resp.UnusedTools[0] != "github__delete_repo" {
+		t.Errorf("unusedTools = %v", resp.UnusedTools)
+	}
+	if len(resp.UnusedServers) != 1 || resp.UnusedServers[0] != "slack" {
+		t.Errorf("unusedServers = %v (its tools are reported through the server)", resp.UnusedServers)
+	}
+}
+
+func TestHandleToolAnalytics_NoAccumulator(t *testing.T) {
+	srv := newTestServer(t)
+	if _, code := decodeToolAnalytics(t, srv); code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", code)
+	}
+}
+
+func TestHandleUsageAnalytics_DailyRows(t *testing.T) {
+	srv := newTestServerWithMetrics(t)
+	now := time.Now()
+	srv.metricsAccumulator.RecordDailyUsage(now, "agent", "llm", "summarize", 100, 50, 0.02)
+	srv.metricsAccumulator.RecordDailyUsage(now, "", "github", "search", 10, 5, 0)
+	srv.metricsAccumulator.RecordDailyUsage(now.AddDate(0, 0, -10), "", "github", "search", 10, 5, 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/usage", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Items []dailyUsageRow `json:"items"`
+		Total int             `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Total != 2 {
+		t.Fatalf("total = %d, want the 10-day-old row outside the default window: %+v", resp.Total, resp.Items)
+	}
+	for _, row := range resp.Items {
+		switch row.Server {
+		case "llm":
+			if row.CostUSD == nil || *row.CostUSD != 0.02 {
+				t.Errorf("llm row cost = %v, want 0.02", row.CostUSD)
+			}
+		case "github":
+			if row.CostUSD != nil {
+				t.Errorf("unpriced row should omit costUsd, got %v", *row.CostUSD)
+			}
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/analytics/usage?days=30", nil)
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Total != 3 {
+		t.Errorf("days=30 total = %d (err %v), want 3", resp.Total, err)
+	}
+}
+
+func TestHandleUsageAnalytics_BadDays(t *testing.T) {
+	srv := newTestServerWithMetrics(t)
+	for _, days := range []string{"0", "91", "week"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/analytics/usage?days="+days, nil)
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("days=%s: status = %d, want 400", days, rec.Code)
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/gridctl/gridctl/pkg/provisioner"
 	"github.com/gridctl/gridctl/pkg/registry"
 	"github.com/gridctl/gridctl/pkg/reload"
+	"github.com/gridctl/gridctl/pkg/retention"
 	"github.com/gridctl/gridctl/pkg/runtime/docker"
 	"github.com/gridctl/gridctl/pkg/tracing"
 	"github.com/gridctl/gridctl/pkg/vault"
@@ -47,12 +48,15 @@ type Server struct {
 	pinStore           *pins.PinStore
 	vaultStore         *vault.Store
 	metricsAccumulator *metrics.Accumulator
+	latencyRecorder    *mcp.LatencyRecorder
 	traceBuffer        *tracing.Buffer
 	stackFile          string
 	allowedOrigins     []string
+	basePath           string
 	authType           string
 	authToken          string
 	authHeader         string
+	authKeys           []APIKey
 
 	gatewayAddr   string // e.g. "http://localhost:8180" — used to build MCP config for CLI proxy
 	tokenizerName string // active tokenizer mode: "embedded" or "api"
@@ -84,6 +88,10 @@ type Server struct {
 	// calls and must reflect hot-reload policy swaps.
 	limitsStatus func() limits.StatusReport
 
+	// retentionStatus returns the retention janitor's deletion totals for
+	// GET /api/retention. Nil when no retention: block is configured.
+	retentionStatus func() retention.Report
+
 	// startWatcher, when set, starts a file watcher on the given stack path.
 	// Injected by GatewayBuilder so POST /api/stack/initialize can activate live reload.
 	startWatcher func(stackPath string)
@@ -160,6 +168,12 @@ func (s *Server) SetAllowedOrigins(origins []string) {
 	s.streamableServer.SetAllowedOrigins(origins)
 }
 
+// SetRequestLogger enables recording of inbound MCP requests on /mcp for
+// later replay.
+func (s *Server) SetRequestLogger(l *mcp.RequestLogger) {
+	s.streamableServer.SetRequestLogger(l)
+}
+
 // SetAuth configures authentication for the server.
 // When configured, all requests (except /health and /ready) must include a valid token.
 func (s *Server) SetAuth(authType, token, header string) {
@@ -168,6 +182,12 @@ func (s *Server) SetAuth(authType, token, header string) {
 	s.authHeader = header
 }
 
+// SetAuthKeys configures named API keys accepted alongside the SetAuth
+// token. Each key's name becomes the client identity of requests using it.
+func (s *Server) SetAuthKeys(keys []APIKey) {
+	s.authKeys = keys
+}
+
 // SetOAuthBroker wires the downstream OAuth broker: enables the
 // /api/servers/{name}/auth/* endpoints and mounts the /oauth/callback
 // route (outside the inbound auth middleware).
@@ -218,6 +238,11 @@ func (s *Server) MetricsAccumulator() *metrics.Accumulator {
 	return s.metricsAccumulator
 }
 
+// SetLatencyRecorder sets the per-tool call latency recorder.
+func (s *Server) SetLatencyRecorder(rec *mcp.LatencyRecorder) {
+	s.latencyRecorder = rec
+}
+
 // SetTraceBuffer sets the distributed tracing ring buffer.
 func (s *Server) SetTraceBuffer(buf *tracing.Buffer) {
 	s.traceBuffer = buf
@@ -379,6 +404,13 @@ func (s *Server) Handler() http.Handler {
 
 	mux.HandleFunc("GET /api/mcp-servers/{name}/logs", s.handleMCPServerLogs)
 	mux.HandleFunc("POST /api/mcp-servers/{name}/restart", s.handleMCPServerRestart)
+	mux.HandleFunc("POST /api/mcp-servers/{name}/pause", s.handlePauseMCPServer)
+	mux.HandleFunc("POST /api/mcp-servers/{name}/resume", s.handleResumeMCPServer)
+	mux.HandleFunc("GET /api/maintenance", s.handleGetMaintenance)
+	mux.HandleFunc("POST /api/maintenance", s.handleSetMaintenance)
+	mux.HandleFunc("GET /api/approvals", s.handleGetApprovals)
+	mux.HandleFunc("POST /api/approvals/{id}/approve", s.handleApproveToolCall)
+	mux.HandleFunc("POST /api/approvals/{id}/deny", s.handleDenyToolCall)
 	mux.HandleFunc("PUT /api/mcp-servers/tools", s.handleSetServerToolsBatch)
 	mux.HandleFunc("PUT /api/mcp-servers/{name}/tools", s.handleSetServerTools)
 	mux.HandleFunc("PUT /api/mcp-servers/{name}/model", s.handleSetServerModel)
@@ -393,6 +425,9 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/api/tools", s.handleTools)
 	mux.HandleFunc("GET /api/tools/catalog", s.handleToolsCatalog)
 	mux.HandleFunc("GET /api/tools/usage", s.handleToolsUsage)
+	mux.HandleFunc("GET /api/tools/latency", s.handleToolsLatency)
+	mux.HandleFunc("GET /api/analytics/tools", s.handleToolAnalytics)
+	mux.HandleFunc("GET /api/analytics/usage", s.handleUsageAnalytics)
 	mux.HandleFunc("GET /api/skills/usage", s.handleSkillsUsage)
 	mux.HandleFunc("/api/logs", s.handleGatewayLogs)
 	mux.HandleFunc("/api/metrics/tokens", s.handleMetricsTokens)
@@ -403,6 +438,8 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("POST /api/clients/{slug}/scope/preview", s.handleClientScopePreview)
 	mux.HandleFunc("PUT /api/clients/{slug}/scope", s.handleSetClientScope)
 	mux.HandleFunc("PUT /api/clients/{slug}/model", s.handleSetClientModel)
+	mux.HandleFunc("GET /api/clients/context-budget", s.handleClientContextBudgets)
+	mux.HandleFunc("GET /api/clients/{slug}/context-budget", s.handleClientContextBudget)
 	mux.HandleFunc("/api/clients", s.handleClients)
 	mux.HandleFunc("GET /api/pricing/models", s.handlePricingModels)
 	mux.HandleFunc("/api/reload", s.handleReload)
@@ -464,6 +501,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("GET /api/stack/recipes", s.handleStackRecipes)
 	mux.HandleFunc("GET /api/catalog", s.handleCatalog)
 	mux.HandleFunc("GET /api/limits", s.handleLimits)
+	mux.HandleFunc("GET /api/retention", s.handleRetention)
 	mux.HandleFunc("GET /api/groups", s.handleGroups)
 	mux.HandleFunc("POST /api/stack/append", s.handleStackAppend)
 	mux.HandleFunc("POST /api/stack/initialize", s.handleStackInitialize)
@@ -509,27 +547,32 @@ func (s *Server) Handler() http.Handler {
 
 	// Registry endpoints
 	mux.HandleFunc("GET /api/registry/status", s.handleRegistryStatus)
+	mux.HandleFunc("GET /api/registry/graph", s.handleRegistryGraph)
 	mux.HandleFunc("GET /api/registry/skills", s.handleRegistrySkillsList)
 	mux.HandleFunc("POST /api/registry/skills", s.handleRegistrySkillCreate)
 	mux.HandleFunc("POST /api/registry/skills/validate", s.handleRegistryValidate)
+	mux.HandleFunc("POST /api/registry/skills/import", s.handleRegistrySkillImport)
 	mux.HandleFunc("PUT /api/registry/skills/batch", s.handleRegistrySkillsBatch)
 	mux.HandleFunc("GET /api/registry/skills/{name}", s.handleRegistrySkillGet)
 	mux.HandleFunc("PUT /api/registry/skills/{name}", s.handleRegistrySkillPut)
 	mux.HandleFunc("DELETE /api/registry/skills/{name}", s.handleRegistrySkillDelete)
 	mux.HandleFunc("POST /api/registry/skills/{name}/activate", s.handleRegistrySkillActivate)
 	mux.HandleFunc("POST /api/registry/skills/{name}/disable", s.handleRegistrySkillDisable)
+	mux.HandleFunc("GET /api/registry/skills/{name}/bundle", s.handleRegistrySkillBundle)
+	mux.HandleFunc("GET /api/registry/skills/{name}/changelog", s.handleRegistrySkillChangelog)
+	mux.HandleFunc("GET /api/registry/skills/{name}/lint", s.handleRegistrySkillLint)
 	mux.HandleFunc("GET /api/registry/skills/{name}/files", s.handleRegistrySkillFileList)
+	mux.HandleFunc("POST /api/registry/skills/{name}/files", s.handleRegistrySkillFileUpload)
 	mux.HandleFunc("GET /api/registry/skills/{name}/files/{path...}", s.handleRegistrySkillFileGet)
 	mux.HandleFunc("PUT /api/registry/skills/{name}/files/{path...}", s.handleRegistrySkillFilePut)
 	mux.HandleFunc("DELETE /api/registry/skills/{name}/files/{path...}", s.handleRegistrySkillFileDelete)
 
 	// Static files (UI) - served at root
 	if s.staticFS != nil {
-		fileServer := http.FileServer(http.FS(s.staticFS))
-		mux.Handle("/", spaHandler(fileServer, s.staticFS))
+		mux.Handle("/", spaHandler(s.staticFS))
 	}
 
-	handler := authMiddleware(s.authType, s.authToken, s.authHeader, mux)
+	handler := authMiddleware(s.authType, s.authToken, s.authHeader, s.authKeys, mux)
 
 	// The OAuth authorization callback mounts OUTSIDE the inbound auth
 	// middleware: the browser performing the redirect carries no gateway
@@ -548,6 +591,9 @@ func (s *Server) Handler() http.Handler {
 		extraHeaders = append(extraHeaders, s.authHeader)
 	}
 	handler = corsMiddleware(s.allowedOrigins, extraHeaders, handler)
+	if s.basePath != "" {
+		handler = basePathMiddleware(s.basePath, handler)
+	}
 	return handler
 }
 
@@ -594,6 +640,13 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 		EffectiveClientModels map[string]EffectiveModel `json:"effective_client_models,omitempty"`
 		EffectiveServerModels map[string]EffectiveModel `json:"effective_server_models,omitempty"`
 		StackName             string                    `json:"stack_name,omitempty"`
+		// Maintenance is present while maintenance mode is on or any
+		// server is paused.
+		Maintenance *mcp.MaintenanceStatus `json:"maintenance,omitempty"`
+		// ReloadRefused is present while the stack file removes servers
+		// skills depend on and no reload has been forced, which is how a
+		// refusal from the --watch watcher shows up outside the log.
+		ReloadRefused *reload.Refusal `json:"reload_refused,omitempty"`
 	}{
 		Gateway: ServerInfo{
 			Name:      s.gateway.ServerInfo().Name,
@@ -610,9 +663,15 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 	if s.stackFile != "" {
 		status.StackName = s.stackName
 	}
+	if m := s.gateway.Maintenance(); m.Enabled || len(m.PausedServers) > 0 {
+		status.Maintenance = &m
+	}
 	if cm := s.gateway.CodeModeStatus(); cm != "off" {
 		status.CodeMode = cm
 	}
+	if s.reloadHandler != nil {
+		status.ReloadRefused = s.reloadHandler.LastRefusal()
+	}
 	if s.registryServer != nil && s.registryServer.HasContent() {
 		regStatus := s.registryServer.Store().Status()
 		status.Registry = &regStatus
@@ -676,13 +735,11 @@ func (s *Server) handleTools(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, _ := s.gateway.HandleToolsListUnscoped()
-	// Always serialize an empty inventory as [], never null: web consumers
-	// index into the list (e.g. fuzzy search) where a null would throw.
-	if result != nil && result.Tools == nil {
-		result.Tools = []mcp.Tool{}
+	var tools []mcp.Tool
+	if result, _ := s.gateway.HandleToolsListUnscoped(); result != nil {
+		tools = result.Tools
 	}
-	writeJSON(w, result)
+	writeList(w, r, tools)
 }
 
 // handleToolsCatalog returns the full downstream tool inventory (each tool's
@@ -732,9 +789,21 @@ type MCPServerStatus struct {
 	// ProtocolVersion is the MCP protocol version the downstream server
 	// reported at initialize; empty for lax servers and OpenAPI adapters.
 	ProtocolVersion string `json:"protocolVersion,omitempty"`
+	// ServerName and ServerVersion are the serverInfo the downstream
+	// server reported at initialize.
+	ServerName    string `json:"serverName,omitempty"`
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// Capabilities are what the downstream server declared at initialize.
+	Capabilities *mcp.Capabilities `json:"capabilities,omitempty"`
+	// IdentityChanges lists how the server differs after a reconnect from
+	// what it declared when it registered (version, protocol, capabilities).
+	IdentityChanges []string `json:"identityChanges,omitempty"`
 	// RegistrationFailed marks a server that never registered with the
 	// gateway; the UI shows it as failed instead of omitting the node.
 	RegistrationFailed bool `json:"registrationFailed,omitempty"`
+	// SchemaIssues lists tools whose inputSchema is missing or invalid;
+	// repaired entries are advertised with the gateway's fixed schema.
+	SchemaIssues []mcp.SchemaIssue `json:"schemaIssues,omitempty"`
 	// Model is the pricing model DECLARED on this server in stack.yaml
 	// (model: field only — a gateway default_model is not folded in here).
 	// Empty when the server inherits the default or has no attribution.
@@ -778,7 +847,12 @@ func (s *Server) getMCPServerStatuses() []MCPServerStatus {
 			HealthError:        ms.HealthError,
 			ToolWhitelist:      ms.ToolWhitelist,
 			ProtocolVersion:    ms.ProtocolVersion,
+			ServerName:         ms.ServerName,
+			ServerVersion:      ms.ServerVersion,
+			Capabilities:       ms.Capabilities,
+			IdentityChanges:    ms.IdentityChanges,
 			RegistrationFailed: ms.RegistrationFailed,
+			SchemaIssues:       ms.SchemaIssues,
 			Model:              declaredModels[ms.Name],
 			Replicas:           ms.Replicas,
 			Autoscale:          ms.Autoscale,
@@ -842,26 +916,6 @@ func corsMiddleware(allowedOrigins []string, extraHeaders []string, next http.Ha
 	})
 }
 
-// spaHandler wraps the file server to handle SPA routing.
-func spaHandler(fileServer http.Handler, staticFS fs.FS) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		path := r.URL.Path
-		if path == "/" {
-			path = "index.html"
-		} else if path[0] == '/' {
-			path = path[1:]
-		}
-
-		// Check if file exists
-		if _, err := fs.Stat(staticFS, path); err != nil {
-			// File doesn't exist, serve index.html for SPA routing
-			r.URL.Path = "/"
-		}
-
-		fileServer.ServeHTTP(w, r)
-	})
-}
-
 // ResourceStatus contains status information for a resource container.
 type ResourceStatus struct {
 	Name   string `json:"name"`
@@ -964,7 +1018,7 @@ func (s *Server) handleGatewayLogs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if s.logBuffer == nil {
-		writeJSON(w, []logging.BufferedEntry{})
+		writeList(w, r, []logging.BufferedEntry{})
 		return
 	}
 
@@ -994,10 +1048,7 @@ func (s *Server) handleGatewayLogs(w http.ResponseWriter, r *http.Request) {
 		entries = filtered
 	}
 
-	if entries == nil {
-		entries = []logging.BufferedEntry{}
-	}
-	writeJSON(w, entries)
+	writeList(w, r, entries)
 }
 
 // handleMetricsTokens handles token metrics requests.
@@ -1174,8 +1225,10 @@ func parseRange(s string) time.Duration {
 	}
 }
 
-// handleReload triggers a configuration reload from disk.
-// POST /api/reload
+// handleReload triggers a configuration reload from disk. A reload that
+// removes servers skills depend on is refused with 409 and the dependents
+// unless force=true.
+// POST /api/reload[?force=true]
 func (s *Server) handleReload(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -1187,13 +1240,17 @@ func (s *Server) handleReload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := s.reloadHandler.Reload(r.Context())
+	force := r.URL.Query().Get("force") == "true"
+	result, err := s.reloadHandler.ReloadWithOptions(r.Context(), reload.Options{Force: force})
 	if err != nil {
 		writeJSONError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	if !result.Success {
+	switch {
+	case len(result.Dependents) > 0:
+		w.WriteHeader(http.StatusConflict)
+	case !result.Success:
 		w.WriteHeader(http.StatusBadRequest)
 	}
 	writeJSON(w, result)
@@ -432,12 +432,12 @@ func TestHandleTools_Empty(t *testing.T) {
 	}
 	assertContentType(t, rec, "application/json")
 
-	var result mcp.ToolsListResult
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	var result []mcp.Tool
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
-	if len(result.Tools) != 0 {
-		t.Errorf("expected no tools, got %d", len(result.Tools))
+	if len(result) != 0 {
+		t.Errorf("expected no tools, got %d", len(result))
 	}
 }
 
@@ -450,7 +450,7 @@ func TestHandleTools_EmptySerializesArrayNotNull(t *testing.T) {
 	srv := newTestServer(t)
 	handler := srv.Handler()
 
-	for _, path := range []string{"/api/tools", "/api/tools/catalog"} {
+	for path, key := range map[string]string{"/api/tools": "items", "/api/tools/catalog": "tools"} {
 		req := httptest.NewRequest(http.MethodGet, path, nil)
 		rec := httptest.NewRecorder()
 		handler.ServeHTTP(rec, req)
@@ -459,11 +459,11 @@ func TestHandleTools_EmptySerializesArrayNotNull(t *testing.T) {
 			t.Errorf("%s: expected 200, got %d", path, rec.Code)
 		}
 		body := strings.TrimSpace(rec.Body.String())
-		if strings.Contains(body, `"tools":null`) {
-			t.Errorf("%s: tools serialized as null: %s", path, body)
+		if strings.Contains(body, `"`+key+`":null`) {
+			t.Errorf("%s: %s serialized as null: %s", path, key, body)
 		}
-		if !strings.Contains(body, `"tools":[]`) {
-			t.Errorf("%s: expected \"tools\":[] in body, got %s", path, body)
+		if !strings.Contains(body, `"`+key+`":[]`) {
+			t.Errorf("%s: expected \"%s\":[] in body, got %s", path, key, body)
 		}
 	}
 }
@@ -487,17 +487,17 @@ func TestHandleTools_WithTools(t *testing.T) {
 		t.Errorf("expected 200, got %d", rec.Code)
 	}
 
-	var result mcp.ToolsListResult
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	var result []mcp.Tool
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
-	if len(result.Tools) != 2 {
-		t.Errorf("expected 2 tools, got %d", len(result.Tools))
+	if len(result) != 2 {
+		t.Errorf("expected 2 tools, got %d", len(result))
 	}
 
 	// Tools should be prefixed with server name
 	toolNames := make(map[string]bool)
-	for _, tool := range result.Tools {
+	for _, tool := range result {
 		toolNames[tool.Name] = true
 	}
 	if !toolNames["toolbox__read-file"] {
@@ -537,7 +537,7 @@ func TestHandleGatewayLogs_NoBuffer(t *testing.T) {
 	}
 
 	var result []any
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 	if len(result) != 0 {
@@ -567,7 +567,7 @@ func TestHandleGatewayLogs_WithEntries(t *testing.T) {
 	}
 
 	var result []logging.BufferedEntry
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 	if len(result) != 2 {
@@ -601,7 +601,7 @@ func TestHandleGatewayLogs_LevelFilter(t *testing.T) {
 	}
 
 	var result []logging.BufferedEntry
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 	if len(result) != 1 {
@@ -626,7 +626,7 @@ func TestHandleGatewayLogs_MultiLevelFilter(t *testing.T) {
 	handler.ServeHTTP(rec, req)
 
 	var result []logging.BufferedEntry
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 	if len(result) != 2 {
@@ -655,7 +655,7 @@ func TestHandleGatewayLogs_LinesParam(t *testing.T) {
 	handler.ServeHTTP(rec, req)
 
 	var result []logging.BufferedEntry
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 	if len(result) != 5 {
@@ -679,7 +679,7 @@ func TestHandleGatewayLogs_InvalidLinesParam(t *testing.T) {
 	}
 
 	var result []logging.BufferedEntry
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 	if len(result) != 1 {
@@ -700,7 +700,7 @@ func TestHandleGatewayLogs_CaseInsensitiveLevelFilter(t *testing.T) {
 	handler.ServeHTTP(rec, req)
 
 	var result []logging.BufferedEntry
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 	if len(result) != 1 {
@@ -724,7 +724,7 @@ func TestHandleGatewayLogs_EmptyBuffer(t *testing.T) {
 	}
 
 	var result []any
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 	if len(result) != 0 {
@@ -1214,6 +1214,9 @@ func (m *mockDockerClient) NetworkCreate(_ context.Context, _ string, _ network.
 	return network.CreateResponse{}, nil
 }
 func (m *mockDockerClient) NetworkRemove(_ context.Context, _ string) error { return nil }
+func (m *mockDockerClient) NetworkConnect(_ context.Context, _, _ string, _ *network.EndpointSettings) error {
+	return nil
+}
 func (m *mockDockerClient) ImageList(_ context.Context, _ image.ListOptions) ([]image.Summary, error) {
 	return nil, nil
 }
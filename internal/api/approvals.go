@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// decideApprovalRequest is the optional body of the approve and deny
+// endpoints. A request made with a named API key is recorded under the key's
+// name; otherwise By is only a claim, recorded after unauthenticatedApprover.
+// Reason is recorded and, on denial, shown to the calling agent.
+type decideApprovalRequest struct {
+	By     string `json:"by,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// unauthenticatedApprover marks an approval decision made without a named
+// API key: with no auth or the shared token, the gateway cannot tell who
+// decided, nor that it was not the client that made the call.
+const unauthenticatedApprover = "unauthenticated"
+
+// handleGetApprovals lists tool calls waiting for approval and recent
+// decisions.
+// GET /api/approvals
+func (s *Server) handleGetApprovals(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.gateway.Approvals())
+}
+
+// handleApproveToolCall lets a parked call proceed.
+// POST /api/approvals/{id}/approve
+func (s *Server) handleApproveToolCall(w http.ResponseWriter, r *http.Request) {
+	s.decideApproval(w, r, true)
+}
+
+// handleDenyToolCall rejects a parked call.
+// POST /api/approvals/{id}/deny
+func (s *Server) handleDenyToolCall(w http.ResponseWriter, r *http.Request) {
+	s.decideApproval(w, r, false)
+}
+
+func (s *Server) decideApproval(w http.ResponseWriter, r *http.Request, approve bool) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, setToolsRequestMaxBytes))
+	if err != nil {
+		writeJSONError(w, "Failed to read request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req decideApprovalRequest
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeJSONError(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	id := r.PathValue("id")
+	name := mcp.AuthenticatedClientFromContext(r.Context())
+	switch {
+	case name != "":
+		if approve && s.approvalRequestedBy(id, name) {
+			writeJSONError(w, "A client cannot approve its own tool call", http.StatusForbidden)
+			return
+		}
+		req.By = name
+	case approve && len(s.authKeys) > 0:
+		// Any client holding the shared token could approve its own call.
+		writeJSONError(w, "Approving a tool call requires a named API key (gateway.auth.keys)", http.StatusForbidden)
+		return
+	case req.By != "":
+		req.By = unauthenticatedApprover + " (by: " + req.By + ")"
+	default:
+		req.By = unauthenticatedApprover
+	}
+	if err := s.gateway.DecideApproval(id, approve, req.By, req.Reason); err != nil {
+		if errors.Is(err, mcp.ErrApprovalNotFound) {
+			writeJSONError(w, "No pending approval: "+id, http.StatusNotFound)
+			return
+		}
+		writeJSONError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	decision := mcp.ApprovalApproved
+	if !approve {
+		decision = mcp.ApprovalDenied
+	}
+	writeJSON(w, map[string]string{"id": id, "decision": decision})
+}
+
+// approvalRequestedBy reports whether the pending call id was made by the
+// client an API key named name authenticates, matching client identities
+// the way session ownership does.
+func (s *Server) approvalRequestedBy(id, name string) bool {
+	for _, p := range s.gateway.Approvals().Pending {
+		if p.ID == id {
+			return p.Client != "" && mcp.NormalizeClientID(p.Client) == mcp.NormalizeClientID(name)
+		}
+	}
+	return false
+}
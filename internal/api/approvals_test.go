@@ -0,0 +1,157 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+func TestHandleApprovals(t *testing.T) {
+	srv := newTestServer(t)
+	srv.gateway.SetServerMeta(mcp.MCPServerConfig{Name: "github", Transport: mcp.TransportHTTP, RequireApproval: []string{"delete_repo"}})
+	handler := srv.Handler()
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	list := func() mcp.ApprovalsStatus {
+		t.Helper()
+		rec := do(http.MethodGet, "/api/approvals", "")
+		var st mcp.ApprovalsStatus
+		if err := json.Unmarshal(rec.Body.Bytes(), &st); err != nil {
+			t.Fatalf("decoding approvals: %v", err)
+		}
+		return st
+	}
+
+	if rec := do(http.MethodGet, "/api/approvals", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"pending":[]`) {
+		t.Fatalf("empty list: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodPost, "/api/approvals/nope/approve", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("approving an unknown id: got %d, want 404", rec.Code)
+	}
+
+	done := make(chan *mcp.ToolCallResult, 1)
+	go func() {
+		res, _ := srv.gateway.HandleToolsCall(t.Context(), mcp.ToolCallParams{Name: "github__delete_repo"})
+		done <- res
+	}()
+	var pending []mcp.PendingApproval
+	for deadline := time.Now().Add(5 * time.Second); len(pending) == 0; pending = list().Pending {
+		if time.Now().After(deadline) {
+			t.Fatal("call was never parked for approval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if rec := do(http.MethodPost, "/api/approvals/"+pending[0].ID+"/deny", `{`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid body: got %d, want 400", rec.Code)
+	}
+	rec := do(http.MethodPost, "/api/approvals/"+pending[0].ID+"/deny", `{"by":"alice","reason":"change freeze"}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"decision":"denied"`) {
+		t.Fatalf("deny: %d %s", rec.Code, rec.Body.String())
+	}
+	if res := <-done; !res.IsError || !strings.Contains(res.Content[0].Text, "change freeze") {
+		t.Errorf("denied call result = %+v", res)
+	}
+	if st := list(); len(st.Recent) != 1 || st.Recent[0].DecidedBy != "unauthenticated (by: alice)" {
+		t.Errorf("recent = %+v", st.Recent)
+	}
+}
+
+func TestHandleApprovals_SelfApproval(t *testing.T) {
+	srv := newTestServer(t)
+	srv.gateway.SetServerMeta(mcp.MCPServerConfig{Name: "github", Transport: mcp.TransportHTTP, RequireApproval: []string{"delete_repo"}})
+	handler := srv.Handler()
+
+	decide := func(id, action, client string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/approvals/"+id+"/"+action, nil)
+		req = req.WithContext(mcp.WithAuthenticatedClient(req.Context(), client))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	done := make(chan *mcp.ToolCallResult, 1)
+	go func() {
+		ctx := mcp.WithClientAccessID(t.Context(), "ci-bot")
+		res, _ := srv.gateway.HandleToolsCall(ctx, mcp.ToolCallParams{Name: "github__delete_repo"})
+		done <- res
+	}()
+	var pending []mcp.PendingApproval
+	for deadline := time.Now().Add(5 * time.Second); len(pending) == 0; pending = srv.gateway.Approvals().Pending {
+		if time.Now().After(deadline) {
+			t.Fatal("call was never parked for approval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	id := pending[0].ID
+
+	if rec := decide(id, "approve", "CI-Bot"); rec.Code != http.StatusForbidden {
+		t.Fatalf("self-approval: got %d, want 403: %s", rec.Code, rec.Body.String())
+	}
+	if len(srv.gateway.Approvals().Pending) != 1 {
+		t.Fatal("refused self-approval removed the pending call")
+	}
+	if rec := decide(id, "approve", "alice"); rec.Code != http.StatusOK {
+		t.Fatalf("approval by another client: got %d: %s", rec.Code, rec.Body.String())
+	}
+	if res := <-done; res.IsError && strings.Contains(res.Content[0].Text, "approv") {
+		t.Errorf("approved call result = %+v", res)
+	}
+	if st := srv.gateway.Approvals(); len(st.Recent) != 1 || st.Recent[0].DecidedBy != "alice" {
+		t.Errorf("recent = %+v", st.Recent)
+	}
+}
+
+func TestHandleApprovals_SharedTokenWithKeys(t *testing.T) {
+	srv := newTestServer(t)
+	srv.gateway.SetServerMeta(mcp.MCPServerConfig{Name: "github", Transport: mcp.TransportHTTP, RequireApproval: []string{"delete_repo"}})
+	srv.SetAuth("bearer", "shared-token", "")
+	srv.SetAuthKeys([]APIKey{{Name: "alice", Key: "alice-key"}})
+	handler := srv.Handler()
+
+	decide := func(id, action, token string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/approvals/"+id+"/"+action, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	done := make(chan *mcp.ToolCallResult, 1)
+	go func() {
+		res, _ := srv.gateway.HandleToolsCall(t.Context(), mcp.ToolCallParams{Name: "github__delete_repo"})
+		done <- res
+	}()
+	var pending []mcp.PendingApproval
+	for deadline := time.Now().Add(5 * time.Second); len(pending) == 0; pending = srv.gateway.Approvals().Pending {
+		if time.Now().After(deadline) {
+			t.Fatal("call was never parked for approval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	id := pending[0].ID
+
+	if rec := decide(id, "approve", "shared-token"); rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "named API key") {
+		t.Fatalf("shared-token approval: got %d, want 403: %s", rec.Code, rec.Body.String())
+	}
+	if rec := decide(id, "deny", "shared-token"); rec.Code != http.StatusOK {
+		t.Fatalf("shared-token denial: got %d: %s", rec.Code, rec.Body.String())
+	}
+	<-done
+	if st := srv.gateway.Approvals(); len(st.Recent) != 1 || st.Recent[0].DecidedBy != unauthenticatedApprover {
+		t.Errorf("recent = %+v", st.Recent)
+	}
+}
@@ -4,14 +4,25 @@ import (
 	"crypto/subtle"
 	"net/http"
 	"strings"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
 )
 
+// APIKey is a named gateway credential. Requests presenting Key act as the
+// client Name.
+type APIKey struct {
+	Name string
+	Key  string
+}
+
 // authMiddleware returns middleware that validates bearer tokens or API keys.
-// If token is empty, all requests pass through (no auth configured).
+// If both token and keys are empty, all requests pass through (no auth
+// configured). A request matching one of keys carries the key's name as its
+// authenticated client identity; the shared token carries none.
 // Auth is only enforced on protected paths (API, MCP, A2A endpoints).
 // Static web UI files are served without authentication.
-func authMiddleware(authType, token, header string, next http.Handler) http.Handler {
-	if token == "" {
+func authMiddleware(authType, token, header string, keys []APIKey, next http.Handler) http.Handler {
+	if token == "" && len(keys) == 0 {
 		return next
 	}
 	if header == "" {
@@ -37,15 +48,36 @@ func authMiddleware(authType, token, header string, next http.Handler) http.Hand
 			provided = val
 		}
 
-		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+		name, ok := matchCredential(provided, token, keys)
+		if !ok {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
+		if name != "" {
+			r = r.WithContext(mcp.WithAuthenticatedClient(r.Context(), name))
+		}
 
 		next.ServeHTTP(w, r)
 	})
 }
 
+// matchCredential compares provided against the shared token and every key
+// in constant time, without stopping at the first match. It returns the
+// matched key's name ("" for the token) and whether anything matched.
+func matchCredential(provided, token string, keys []APIKey) (string, bool) {
+	if provided == "" {
+		return "", false
+	}
+	var name string
+	matched := token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+	for _, k := range keys {
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(k.Key)) == 1 && !matched {
+			name, matched = k.Name, true
+		}
+	}
+	return name, matched
+}
+
 // isProtectedPath returns true for paths that require authentication:
 // API, MCP, SSE, A2A, and well-known endpoints.
 func isProtectedPath(path string) bool {
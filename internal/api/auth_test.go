@@ -4,11 +4,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
 )
 
 func TestAuthMiddleware_NoToken(t *testing.T) {
 	// When no token is configured, all requests pass through
-	handler := authMiddleware("bearer", "", "", okHandler())
+	handler := authMiddleware("bearer", "", "", nil, okHandler())
 
 	req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
 	rec := httptest.NewRecorder()
@@ -47,7 +49,7 @@ func TestAuthMiddleware_BearerToken(t *testing.T) {
 		},
 	}
 
-	handler := authMiddleware("bearer", "mysecret", "", okHandler())
+	handler := authMiddleware("bearer", "mysecret", "", nil, okHandler())
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -105,7 +107,7 @@ func TestAuthMiddleware_APIKey(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := authMiddleware("api_key", "myapikey", tt.headerName, okHandler())
+			handler := authMiddleware("api_key", "myapikey", tt.headerName, nil, okHandler())
 
 			req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
 			if tt.header != "" {
@@ -122,7 +124,7 @@ func TestAuthMiddleware_APIKey(t *testing.T) {
 }
 
 func TestAuthMiddleware_HealthBypass(t *testing.T) {
-	handler := authMiddleware("bearer", "mysecret", "", okHandler())
+	handler := authMiddleware("bearer", "mysecret", "", nil, okHandler())
 
 	paths := []string{"/health", "/ready"}
 	for _, path := range paths {
@@ -140,7 +142,7 @@ func TestAuthMiddleware_HealthBypass(t *testing.T) {
 }
 
 func TestAuthMiddleware_ProtectedPaths(t *testing.T) {
-	handler := authMiddleware("bearer", "mysecret", "", okHandler())
+	handler := authMiddleware("bearer", "mysecret", "", nil, okHandler())
 
 	paths := []string{"/api/status", "/mcp", "/sse", "/api/tools", "/api/mcp-servers"}
 	for _, path := range paths {
@@ -158,7 +160,7 @@ func TestAuthMiddleware_ProtectedPaths(t *testing.T) {
 }
 
 func TestAuthMiddleware_OptionsPassthrough(t *testing.T) {
-	handler := authMiddleware("bearer", "mysecret", "", okHandler())
+	handler := authMiddleware("bearer", "mysecret", "", nil, okHandler())
 
 	req := httptest.NewRequest(http.MethodOptions, "/api/status", nil)
 	rec := httptest.NewRecorder()
@@ -170,7 +172,7 @@ func TestAuthMiddleware_OptionsPassthrough(t *testing.T) {
 }
 
 func TestAuthMiddleware_BearerEmptyToken(t *testing.T) {
-	handler := authMiddleware("bearer", "mysecret", "", okHandler())
+	handler := authMiddleware("bearer", "mysecret", "", nil, okHandler())
 
 	req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
 	req.Header.Set("Authorization", "Bearer ")
@@ -183,7 +185,7 @@ func TestAuthMiddleware_BearerEmptyToken(t *testing.T) {
 }
 
 func TestAuthMiddleware_StaticFilesBypass(t *testing.T) {
-	handler := authMiddleware("bearer", "mysecret", "", okHandler())
+	handler := authMiddleware("bearer", "mysecret", "", nil, okHandler())
 
 	paths := []string{"/", "/index.html", "/assets/index-abc123.js", "/assets/index-abc123.css", "/favicon.png", "/favicon.ico"}
 	for _, path := range paths {
@@ -202,7 +204,7 @@ func TestAuthMiddleware_StaticFilesBypass(t *testing.T) {
 
 func TestAuthMiddleware_TimingSafe(t *testing.T) {
 	// Verify that partial matches are rejected (not vulnerable to prefix attacks)
-	handler := authMiddleware("api_key", "mysecretkey", "", okHandler())
+	handler := authMiddleware("api_key", "mysecretkey", "", nil, okHandler())
 
 	partials := []string{"mysecret", "mysecretke", "mysecretkey!", ""}
 	for _, partial := range partials {
@@ -219,6 +221,67 @@ func TestAuthMiddleware_TimingSafe(t *testing.T) {
 	}
 }
 
+func TestAuthMiddleware_NamedKeys(t *testing.T) {
+	keys := []APIKey{
+		{Name: "ci-bot", Key: "key-one"},
+		{Name: "ci-bot", Key: "key-one-rotated"},
+		{Name: "reviewer", Key: "key-two"},
+	}
+	var gotIdentity string
+	handler := authMiddleware("bearer", "shared", "", keys, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotIdentity = mcp.AuthenticatedClientFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name         string
+		header       string
+		wantStatus   int
+		wantIdentity string
+	}{
+		{"named key", "Bearer key-one", http.StatusOK, "ci-bot"},
+		{"rotated key shares name", "Bearer key-one-rotated", http.StatusOK, "ci-bot"},
+		{"other key", "Bearer key-two", http.StatusOK, "reviewer"},
+		{"shared token has no identity", "Bearer shared", http.StatusOK, ""},
+		{"unknown key", "Bearer nope", http.StatusUnauthorized, ""},
+		{"empty bearer", "Bearer ", http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIdentity = ""
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			req.Header.Set("Authorization", tt.header)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if gotIdentity != tt.wantIdentity {
+				t.Errorf("identity = %q, want %q", gotIdentity, tt.wantIdentity)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_KeysWithoutToken(t *testing.T) {
+	handler := authMiddleware("api_key", "", "X-API-Key", []APIKey{{Name: "ci-bot", Key: "key-one"}}, okHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("missing key: expected 401, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/status", nil)
+	req.Header.Set("X-API-Key", "key-one")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("valid key: expected 200, got %d", rec.Code)
+	}
+}
+
 func TestIsProtectedPath(t *testing.T) {
 	protected := []string{"/api/status", "/api/tools", "/api/mcp-servers", "/mcp", "/sse", "/message", "/a2a/agent1", "/.well-known/agent.json"}
 	for _, path := range protected {
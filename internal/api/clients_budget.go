@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// handleClientContextBudgets reports the tools/list and prompts/list context
+// cost of every configured client profile plus the unlisted-client default
+// ("*"), flagging any over gateway.context_budget_tokens. Deploy reads it to
+// warn about oversized tool surfaces.
+//
+// GET /api/clients/context-budget
+func (s *Server) handleClientContextBudgets(w http.ResponseWriter, _ *http.Request) {
+	if s.gateway == nil {
+		writeJSONError(w, "Gateway unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, s.gateway.ContextBudgets())
+}
+
+// handleClientContextBudget reports one client's context cost, after its
+// access scope is applied. An unlisted client gets the default policy's
+// surface, exactly as it would on connect.
+//
+// GET /api/clients/{slug}/context-budget
+func (s *Server) handleClientContextBudget(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	if mcp.NormalizeClientID(slug) == "" {
+		writeStructuredError(w, http.StatusBadRequest, errCodeInvalidClient,
+			"Client identifier is empty after normalization.",
+			"Provide a non-empty client slug.")
+		return
+	}
+	if s.gateway == nil {
+		writeJSONError(w, "Gateway unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, s.gateway.ContextBudget(slug))
+}
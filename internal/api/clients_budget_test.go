@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+func TestHandleClientContextBudget(t *testing.T) {
+	srv := newTestServer(t)
+	srv.gateway.Router().AddClient(newMockAgentClient("github", []mcp.Tool{
+		{Name: "create_issue", Description: "Create an issue"}, {Name: "list_repos"},
+	}))
+	srv.gateway.Router().RefreshTools()
+	srv.gateway.SetClientAccessPolicy(mcp.NewClientAccessPolicy(&mcp.ClientAccessSpec{
+		Profiles: map[string]mcp.ClientProfileSpec{
+			"cursor": {Tools: []string{"github__list_repos"}},
+		},
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/clients/cursor/context-budget", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var budget mcp.ContextBudget
+	if err := json.Unmarshal(rec.Body.Bytes(), &budget); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if budget.Client != "cursor" || budget.Tools != 1 || budget.Tokens == 0 {
+		t.Errorf("budget = %+v, want cursor with 1 tool", budget)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/clients/context-budget", nil)
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var budgets []mcp.ContextBudget
+	if err := json.Unmarshal(rec.Body.Bytes(), &budgets); err != nil {
+		t.Fatalf("unmarshal list: %v", err)
+	}
+	if len(budgets) != 2 || budgets[0].Client != "cursor" || budgets[1].Client != mcp.DefaultClientKey {
+		t.Errorf("budgets = %+v", budgets)
+	}
+}
@@ -39,7 +39,7 @@ func (s *Server) handleGroupSSE(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	fmt.Fprint(w, "event: endpoint\n")
-	fmt.Fprintf(w, "data: POST /groups/%s/mcp\n\n", name)
+	fmt.Fprintf(w, "data: POST %s/groups/%s/mcp\n\n", mcp.ForwardedPrefix(r), name)
 	flusher.Flush()
 }
 
@@ -60,11 +60,16 @@ func (s *Server) configuredGroupName(raw string) string {
 
 // handleGroups handles GET /api/groups: every configured group resolved
 // against the live tool surface. With no groups: block it returns
-// configured: false and an empty array, never an error.
+// configured: false and an empty array, never an error. Each group's url
+// is built from the request, so it names the reverse proxy when there is one.
 func (s *Server) handleGroups(w http.ResponseWriter, r *http.Request) {
 	resp := mcp.GroupsReport{Groups: []mcp.GroupStatus{}}
 	if s.gateway != nil {
 		if status := s.gateway.GroupsStatus(); len(status) > 0 {
+			base := mcp.ExternalBaseURL(r)
+			for i := range status {
+				status[i].URL = base + status[i].Endpoint
+			}
 			resp.Configured = true
 			resp.Groups = status
 		}
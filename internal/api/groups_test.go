@@ -55,12 +55,30 @@ func TestHandleGroups_ReportsResolvedGroups(t *testing.T) {
 	g := resp.Groups[0]
 	assert.Equal(t, "release", g.Name)
 	assert.Equal(t, "/groups/release/mcp", g.Endpoint)
+	assert.Equal(t, "http://example.com/groups/release/mcp", g.URL)
 	assert.Equal(t, "create_issue", g.Overrides["github__create_issue"])
 	// No servers connected: membership resolves to zero against the live
 	// surface, but the group itself is still reported.
 	assert.Equal(t, 0, g.MemberCount)
 }
 
+func TestHandleGroups_URLFollowsForwardedHeaders(t *testing.T) {
+	s := NewServer(groupsGateway(), nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/groups", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "tools.example.com")
+	req.Header.Set("X-Forwarded-Prefix", "/gridctl/")
+	w := httptest.NewRecorder()
+
+	s.handleGroups(w, req)
+
+	var resp mcp.GroupsReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Groups, 1)
+	assert.Equal(t, "/groups/release/mcp", resp.Groups[0].Endpoint)
+	assert.Equal(t, "https://tools.example.com/gridctl/groups/release/mcp", resp.Groups[0].URL)
+}
+
 func TestHandleGroupMCP_UnknownGroup404s(t *testing.T) {
 	s := NewServer(groupsGateway(), nil)
 
@@ -92,6 +110,13 @@ func TestHandleGroupSSE_PointsAtGroupPath(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "data: POST /groups/release/mcp")
 
+	req = httptest.NewRequest(http.MethodGet, "/groups/release/sse", nil)
+	req.SetPathValue("name", "release")
+	req.Header.Set("X-Forwarded-Prefix", "/gridctl")
+	w = httptest.NewRecorder()
+	s.handleGroupSSE(w, req)
+	assert.Contains(t, w.Body.String(), "data: POST /gridctl/groups/release/mcp")
+
 	req = httptest.NewRequest(http.MethodGet, "/groups/nope/sse", nil)
 	req.SetPathValue("name", "nope")
 	w = httptest.NewRecorder()
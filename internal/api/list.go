@@ -0,0 +1,217 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// List endpoint conventions. Collection endpoints (GET /api/tools,
+// /api/logs, /api/registry/skills, and any new list endpoint) answer with
+// listEnvelope instead of a bare array and accept the same query
+// parameters:
+//
+//	limit   page size, 1..maxListLimit; omitted returns every item
+//	cursor  opaque nextCursor from the previous page of the same query
+//	sort    item field to sort by; a leading "-" sorts descending
+//	fields  comma-separated item fields to keep (projection)
+//
+// Sorting and projection operate on the items' JSON field names, so they
+// follow the documented response shapes rather than Go struct names.
+const maxListLimit = 1000
+
+// listEnvelope is the response shape of every list endpoint. Total counts
+// the items matching the query before paging; NextCursor is set while more
+// pages remain.
+type listEnvelope struct {
+	Items      any    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	Total      int    `json:"total"`
+}
+
+// listQuery is the parsed form of the list query parameters.
+type listQuery struct {
+	limit  int // 0 = unbounded
+	offset int
+	sort   string
+	desc   bool
+	fields []string
+}
+
+// parseListQuery reads limit, cursor, sort, and fields from the request.
+func parseListQuery(r *http.Request) (listQuery, error) {
+	q := r.URL.Query()
+	var lq listQuery
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxListLimit {
+			return lq, fmt.Errorf("limit must be an integer between 1 and %d", maxListLimit)
+		}
+		lq.limit = n
+	}
+	if v := q.Get("cursor"); v != "" {
+		offset, err := decodeListCursor(v)
+		if err != nil {
+			return lq, err
+		}
+		lq.offset = offset
+	}
+	if v := strings.TrimSpace(q.Get("sort")); v != "" {
+		lq.desc = strings.HasPrefix(v, "-")
+		lq.sort = strings.TrimPrefix(v, "-")
+	}
+	if v := q.Get("fields"); v != "" {
+		for _, f := range strings.Split(v, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				lq.fields = append(lq.fields, f)
+			}
+		}
+	}
+	return lq, nil
+}
+
+// writeList applies the request's list query to items and writes the
+// envelope. A malformed query is a 400. Items are converted to their JSON
+// objects only when sorting or projection needs it, so the common
+// unsorted, unprojected page keeps the original values.
+func writeList[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	lq, err := parseListQuery(r)
+	if err != nil {
+		writeJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	env, err := applyListQuery(lq, items)
+	if err != nil {
+		writeJSONError(w, "Failed to build list: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, env)
+}
+
+// applyListQuery sorts, pages, and projects items.
+func applyListQuery[T any](lq listQuery, items []T) (listEnvelope, error) {
+	env := listEnvelope{Total: len(items)}
+	if lq.sort == "" && len(lq.fields) == 0 {
+		page, next := listPage(items, lq)
+		if page == nil {
+			page = []T{}
+		}
+		env.Items, env.NextCursor = page, next
+		return env, nil
+	}
+
+	objs, err := toJSONObjects(items)
+	if err != nil {
+		return env, err
+	}
+	if lq.sort != "" {
+		sort.SliceStable(objs, func(i, j int) bool {
+			a, b := objs[i][lq.sort], objs[j][lq.sort]
+			if a == nil || b == nil {
+				// Items without the field sort last in either direction.
+				return a != nil && b == nil
+			}
+			c := compareJSONValues(a, b)
+			if lq.desc {
+				return c > 0
+			}
+			return c < 0
+		})
+	}
+	page, next := listPage(objs, lq)
+	if len(lq.fields) > 0 {
+		for i, obj := range page {
+			projected := make(map[string]any, len(lq.fields))
+			for _, f := range lq.fields {
+				if v, ok := obj[f]; ok {
+					projected[f] = v
+				}
+			}
+			page[i] = projected
+		}
+	}
+	if page == nil {
+		page = []map[string]any{}
+	}
+	env.Items, env.NextCursor = page, next
+	return env, nil
+}
+
+// listPage returns the page of items the query selects and the cursor for
+// the page after it ("" on the last page).
+func listPage[T any](items []T, lq listQuery) ([]T, string) {
+	if lq.offset >= len(items) {
+		return nil, ""
+	}
+	items = items[lq.offset:]
+	if lq.limit == 0 || len(items) <= lq.limit {
+		return items, ""
+	}
+	return items[:lq.limit], encodeListCursor(lq.offset + lq.limit)
+}
+
+func encodeListCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("o:" + strconv.Itoa(offset)))
+}
+
+func decodeListCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if v, ok := strings.CutPrefix(string(raw), "o:"); ok {
+			if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+				return n, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid cursor")
+}
+
+// toJSONObjects converts items to their JSON object form.
+func toJSONObjects[T any](items []T) ([]map[string]any, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var objs []map[string]any
+	if err := json.Unmarshal(data, &objs); err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+// compareJSONValues orders two non-null decoded JSON values: numbers
+// numerically, strings lexically, false before true. Mismatched types
+// compare by their text.
+func compareJSONValues(a, b any) int {
+	switch av := a.(type) {
+	case float64:
+		if bv, ok := b.(float64); ok {
+			switch {
+			case av < bv:
+				return -1
+			case av > bv:
+				return 1
+			}
+			return 0
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv)
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			switch {
+			case av == bv:
+				return 0
+			case !av:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
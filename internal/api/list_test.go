@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// decodeListItems decodes a list envelope body and unmarshals its items
+// into out.
+func decodeListItems(body io.Reader, out any) error {
+	var env struct {
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.NewDecoder(body).Decode(&env); err != nil {
+		return err
+	}
+	return json.Unmarshal(env.Items, out)
+}
+
+type listItem struct {
+	Name  string `json:"name"`
+	Size  int    `json:"size"`
+	Owner string `json:"owner,omitempty"`
+}
+
+var listItems = []listItem{
+	{Name: "c", Size: 2, Owner: "ops"},
+	{Name: "a", Size: 3},
+	{Name: "b", Size: 1, Owner: "dev"},
+}
+
+func runList(t *testing.T, query string) (*httptest.ResponseRecorder, listEnvelope, []map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/things"+query, nil)
+	rec := httptest.NewRecorder()
+	writeList(rec, req, listItems)
+	if rec.Code != http.StatusOK {
+		return rec, listEnvelope{}, nil
+	}
+	var env struct {
+		Items      []map[string]any `json:"items"`
+		NextCursor string           `json:"nextCursor"`
+		Total      int              `json:"total"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&env); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	return rec, listEnvelope{NextCursor: env.NextCursor, Total: env.Total}, env.Items
+}
+
+func listNames(items []map[string]any) []string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		name, _ := item["name"].(string)
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestWriteList_NoQueryReturnsEverything(t *testing.T) {
+	_, env, items := runList(t, "")
+	if env.Total != 3 || len(items) != 3 || env.NextCursor != "" {
+		t.Fatalf("got total=%d items=%d cursor=%q", env.Total, len(items), env.NextCursor)
+	}
+}
+
+func TestWriteList_EmptySerializesArray(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/things", nil)
+	rec := httptest.NewRecorder()
+	writeList(rec, req, []listItem(nil))
+
+	if got, want := rec.Body.String(), "{\"items\":[],\"total\":0}\n"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestWriteList_CursorPaging(t *testing.T) {
+	var seen []string
+	query := "?limit=2"
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("paging did not terminate")
+		}
+		_, env, items := runList(t, query)
+		if env.Total != 3 {
+			t.Errorf("total = %d, want 3", env.Total)
+		}
+		seen = append(seen, listNames(items)...)
+		if env.NextCursor == "" {
+			break
+		}
+		query = "?limit=2&cursor=" + env.NextCursor
+	}
+	if len(seen) != 3 || seen[0] != "c" || seen[2] != "b" {
+		t.Errorf("paged items = %v, want [c a b]", seen)
+	}
+}
+
+func TestWriteList_Sort(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"?sort=name", []string{"a", "b", "c"}},
+		{"?sort=-name", []string{"c", "b", "a"}},
+		{"?sort=size", []string{"b", "c", "a"}},
+		{"?sort=-size", []string{"a", "c", "b"}},
+		// Items missing the field sort last in either direction.
+		{"?sort=owner", []string{"b", "c", "a"}},
+		{"?sort=-owner", []string{"c", "b", "a"}},
+	}
+	for _, tc := range tests {
+		_, _, items := runList(t, tc.query)
+		got := listNames(items)
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: got %v, want %v", tc.query, got, tc.want)
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: got %v, want %v", tc.query, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestWriteList_SortThenPage(t *testing.T) {
+	_, env, items := runList(t, "?sort=name&limit=2")
+	if got := listNames(items); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("first page = %v, want [a b]", got)
+	}
+	_, _, items = runList(t, "?sort=name&limit=2&cursor="+env.NextCursor)
+	if got := listNames(items); len(got) != 1 || got[0] != "c" {
+		t.Errorf("second page = %v, want [c]", got)
+	}
+}
+
+func TestWriteList_Fields(t *testing.T) {
+	_, _, items := runList(t, "?fields=name,%20owner")
+	for _, item := range items {
+		if _, ok := item["size"]; ok {
+			t.Errorf("size should be projected out: %v", item)
+		}
+		if _, ok := item["name"]; !ok {
+			t.Errorf("name missing: %v", item)
+		}
+	}
+	// Absent fields are omitted, not emitted as null.
+	if _, ok := items[1]["owner"]; ok {
+		t.Errorf("owner should be absent for %v", items[1])
+	}
+}
+
+func TestWriteList_BadQuery(t *testing.T) {
+	for _, query := range []string{"?limit=0", "?limit=abc", "?limit=1001", "?cursor=bogus", "?cursor=" + encodeListCursor(-1)} {
+		rec, _, _ := runList(t, query)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestWriteList_CursorPastEnd(t *testing.T) {
+	_, env, items := runList(t, "?cursor="+encodeListCursor(10))
+	if len(items) != 0 || env.NextCursor != "" || env.Total != 3 {
+		t.Errorf("got items=%v cursor=%q total=%d", items, env.NextCursor, env.Total)
+	}
+}
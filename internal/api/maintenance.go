@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// setMaintenanceRequest is the wire shape for POST /api/maintenance.
+// Message replaces the default tool error while maintenance is on.
+type setMaintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+}
+
+// handleGetMaintenance reports maintenance mode and paused servers.
+// GET /api/maintenance
+func (s *Server) handleGetMaintenance(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.gateway.Maintenance())
+}
+
+// handleSetMaintenance turns maintenance mode on or off. While on, every
+// tools/call returns the maintenance message as a tool error; status, logs,
+// and the rest of the API keep working.
+// POST /api/maintenance
+func (s *Server) handleSetMaintenance(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, setToolsRequestMaxBytes))
+	if err != nil {
+		writeJSONError(w, "Failed to read request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req setMaintenanceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.gateway.SetMaintenance(req.Enabled, req.Message)
+	writeJSON(w, s.gateway.Maintenance())
+}
+
+// handlePauseMCPServer rejects tool calls to one server until it is resumed.
+// POST /api/mcp-servers/{name}/pause
+func (s *Server) handlePauseMCPServer(w http.ResponseWriter, r *http.Request) {
+	s.setServerPaused(w, r.PathValue("name"), true)
+}
+
+// handleResumeMCPServer lifts a server's pause.
+// POST /api/mcp-servers/{name}/resume
+func (s *Server) handleResumeMCPServer(w http.ResponseWriter, r *http.Request) {
+	s.setServerPaused(w, r.PathValue("name"), false)
+}
+
+func (s *Server) setServerPaused(w http.ResponseWriter, name string, paused bool) {
+	// Resuming a server that has since left the stack is allowed, so a
+	// stale pause can always be cleared.
+	if paused && !s.hasMCPServer(name) {
+		writeJSONError(w, "MCP server not found: "+name, http.StatusNotFound)
+		return
+	}
+	s.gateway.SetServerPaused(name, paused)
+	writeJSON(w, s.gateway.Maintenance())
+}
+
+// hasMCPServer reports whether the gateway knows a server by name.
+func (s *Server) hasMCPServer(name string) bool {
+	for _, st := range s.gateway.Status() {
+		if st.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+func TestHandleMaintenance(t *testing.T) {
+	srv := newTestServer(t)
+	registerMockServerMeta(srv.gateway, "github", mcp.TransportHTTP)
+	handler := srv.Handler()
+
+	do := func(method, path, body string) (*httptest.ResponseRecorder, mcp.MaintenanceStatus) {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		var st mcp.MaintenanceStatus
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &st); err != nil {
+				t.Fatalf("decoding %s %s: %v", method, path, err)
+			}
+		}
+		return rec, st
+	}
+
+	rec, st := do(http.MethodPost, "/api/maintenance", `{"enabled":true,"message":"upgrading"}`)
+	if rec.Code != http.StatusOK || !st.Enabled || st.Message != "upgrading" {
+		t.Fatalf("enable: %d %+v", rec.Code, st)
+	}
+	res, err := srv.gateway.HandleToolsCall(t.Context(), mcp.ToolCallParams{Name: "github__query"})
+	if err != nil || !res.IsError || res.Content[0].Text != "upgrading" {
+		t.Errorf("tools/call during maintenance = %+v, %v", res, err)
+	}
+
+	if rec, _ := do(http.MethodPost, "/api/mcp-servers/missing/pause", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("pausing an unknown server: got %d, want 404", rec.Code)
+	}
+	rec, st = do(http.MethodPost, "/api/mcp-servers/github/pause", "")
+	if rec.Code != http.StatusOK || len(st.PausedServers) != 1 {
+		t.Fatalf("pause: %d %+v", rec.Code, st)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
+	statusRec := httptest.NewRecorder()
+	handler.ServeHTTP(statusRec, req)
+	if statusRec.Code != http.StatusOK || !strings.Contains(statusRec.Body.String(), `"maintenance":{"enabled":true`) {
+		t.Errorf("status during maintenance: %d %s", statusRec.Code, statusRec.Body.String())
+	}
+
+	do(http.MethodPost, "/api/maintenance", `{"enabled":false}`)
+	rec, st = do(http.MethodPost, "/api/mcp-servers/github/resume", "")
+	if rec.Code != http.StatusOK || st.Enabled || len(st.PausedServers) != 0 {
+		t.Errorf("resume: %d %+v", rec.Code, st)
+	}
+	if rec, _ := do(http.MethodPost, "/api/maintenance", `{`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid JSON: got %d, want 400", rec.Code)
+	}
+}
@@ -23,6 +23,12 @@ const (
 	// errCodeReloadFailed is returned on 502 when the YAML write succeeded
 	// but the gateway reload reported an error.
 	errCodeReloadFailed = "reload_failed"
+	// errCodeVersionRequired is returned on 428 when a skill update does
+	// not name the version it was edited from.
+	errCodeVersionRequired = "version_required"
+	// errCodeSkillModified is returned on 409 when a skill update names a
+	// version that has since been replaced.
+	errCodeSkillModified = "skill_modified"
 	// errCodeUnknownTool is returned on 400 when the request includes a tool
 	// name that the server has not advertised.
 	errCodeUnknownTool = "unknown_tool"
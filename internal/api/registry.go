@@ -4,10 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gridctl/gridctl/pkg/registry"
 	"github.com/gridctl/gridctl/pkg/skills"
@@ -23,18 +29,27 @@ func (s *Server) handleRegistryStatus(w http.ResponseWriter, _ *http.Request) {
 	writeJSON(w, s.registryServer.Store().Status())
 }
 
-// handleRegistrySkillsList returns all skills.
+// handleRegistrySkillsList returns skills, optionally filtered by tag (repeatable,
+// all must match), state, and a full-text query q over name/description/body.
 // GET /api/registry/skills
-func (s *Server) handleRegistrySkillsList(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleRegistrySkillsList(w http.ResponseWriter, r *http.Request) {
 	if s.registryServer == nil {
 		writeJSONError(w, "Registry not available", http.StatusServiceUnavailable)
 		return
 	}
-	skills := s.registryServer.Store().ListSkills()
-	if skills == nil {
-		skills = []*registry.AgentSkill{}
+	params := r.URL.Query()
+	q := registry.SkillQuery{
+		Tags:  params["tag"],
+		State: registry.ItemState(params.Get("state")),
+		Text:  params.Get("q"),
 	}
-	writeJSON(w, skills)
+	switch q.State {
+	case "", registry.StateDraft, registry.StateActive, registry.StateDisabled:
+	default:
+		writeJSONError(w, "state must be one of draft, active, disabled", http.StatusBadRequest)
+		return
+	}
+	writeList(w, r, s.registryServer.Store().FindSkills(q))
 }
 
 // handleRegistrySkillCreate creates a new skill.
@@ -80,6 +95,7 @@ func (s *Server) handleRegistrySkillGet(w http.ResponseWriter, r *http.Request)
 		writeJSONError(w, "Skill not found: "+name, http.StatusNotFound)
 		return
 	}
+	w.Header().Set("ETag", `"`+sk.Version+`"`)
 	writeJSON(w, sk)
 }
 
@@ -91,6 +107,9 @@ func (s *Server) handleRegistrySkillPut(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	name := r.PathValue("name")
+	if s.rejectSyncedSkill(w, name) {
+		return
+	}
 	var sk registry.AgentSkill
 	if err := json.NewDecoder(r.Body).Decode(&sk); err != nil {
 		writeJSONError(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
@@ -101,16 +120,156 @@ func (s *Server) handleRegistrySkillPut(w http.ResponseWriter, r *http.Request)
 		writeJSONError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	if _, err := s.registryServer.Store().GetSkill(name); err != nil {
+	prev, err := s.registryServer.Store().GetSkill(name)
+	if err != nil {
 		writeJSONError(w, "Skill not found: "+name, http.StatusNotFound)
 		return
 	}
-	if err := s.registryServer.Store().SaveSkill(&sk); err != nil {
+
+	// Optimistic concurrency: the caller must name the version it edited,
+	// via If-Match (the ETag from GET) or the skill's own version field.
+	version := strings.Trim(strings.TrimPrefix(r.Header.Get("If-Match"), "W/"), `"`)
+	if version == "" {
+		version = sk.Version
+	}
+	if version == "" {
+		writeStructuredError(w, http.StatusPreconditionRequired, errCodeVersionRequired,
+			"Skill updates must name the version being edited.",
+			"Send the ETag from GET /api/registry/skills/{name} as If-Match, or the skill's version field.")
+		return
+	}
+	switch err := s.registryServer.Store().UpdateSkill(&sk, version); {
+	case err == nil:
+		// proceed
+	case errors.Is(err, registry.ErrVersionConflict):
+		current, _ := s.registryServer.Store().GetSkill(name)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(skillConflictResponse{
+			Error: structuredErrorPayload{
+				Code:    errCodeSkillModified,
+				Message: "The skill was changed by someone else since you opened it.",
+				Hint:    "Compare your version with the current one, merge, and save again.",
+			},
+			Current:   current,
+			Submitted: sk,
+		})
+		return
+	case errors.Is(err, registry.ErrNotFound):
+		writeJSONError(w, "Skill not found: "+name, http.StatusNotFound)
+		return
+	default:
 		writeJSONError(w, "Failed to save skill: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 	s.refreshRegistryRouter()
-	writeJSON(w, sk)
+
+	diff := registry.DiffSkills(prev, &sk)
+	if !diff.Empty() {
+		entry := registry.ChangelogEntry{Time: time.Now().UTC(), SkillDiff: diff}
+		if err := s.registryServer.Store().AppendChangelog(name, entry); err != nil {
+			slog.Warn("recording skill changelog", "skill", name, "error", err)
+		}
+	}
+	w.Header().Set("ETag", `"`+sk.Version+`"`)
+	writeJSON(w, skillSaveResponse{
+		AgentSkill: sk,
+		Changes:    &skillChanges{SkillDiff: diff, BodyDiff: unifiedDiff(prev.Body, sk.Body, 3)},
+	})
+}
+
+// skillSaveResponse is the saved skill plus what the save changed. The skill
+// fields stay at the top level so callers reading the old response shape are
+// unaffected.
+type skillSaveResponse struct {
+	registry.AgentSkill
+	Changes *skillChanges `json:"changes,omitempty"`
+}
+
+// skillConflictResponse is the 409 body for a stale skill update: the
+// structured error plus both versions, so the editor can offer a merge.
+type skillConflictResponse struct {
+	Error     structuredErrorPayload `json:"error"`
+	Current   *registry.AgentSkill   `json:"current"`
+	Submitted registry.AgentSkill    `json:"submitted"`
+}
+
+// skillChanges is a registry.SkillDiff with a unified diff of the body.
+type skillChanges struct {
+	registry.SkillDiff
+	BodyDiff string `json:"bodyDiff,omitempty"`
+}
+
+// handleRegistrySkillChangelog returns a skill's recorded edits, newest first.
+// GET /api/registry/skills/{name}/changelog
+func (s *Server) handleRegistrySkillChangelog(w http.ResponseWriter, r *http.Request) {
+	if s.registryServer == nil {
+		writeJSONError(w, "Registry not available", http.StatusServiceUnavailable)
+		return
+	}
+	name := r.PathValue("name")
+	entries, err := s.registryServer.Store().Changelog(name)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			writeJSONError(w, "Skill not found: "+name, http.StatusNotFound)
+			return
+		}
+		writeJSONError(w, "Failed to read changelog: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeList(w, r, entries)
+}
+
+// handleRegistrySkillLint returns a skill's lint findings, errors first.
+// GET /api/registry/skills/{name}/lint
+func (s *Server) handleRegistrySkillLint(w http.ResponseWriter, r *http.Request) {
+	if s.registryServer == nil {
+		writeJSONError(w, "Registry not available", http.StatusServiceUnavailable)
+		return
+	}
+	name := r.PathValue("name")
+	findings, err := s.registryServer.Store().Lint(name)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			writeJSONError(w, "Skill not found: "+name, http.StatusNotFound)
+			return
+		}
+		writeJSONError(w, "Failed to lint skill: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeList(w, r, findings)
+}
+
+// handleRegistryGraph returns the dependency graph of skills on tools and
+// the servers that provide them. ?server= narrows it to what depends on one
+// server; ?format=dot or mermaid renders it as text.
+// GET /api/registry/graph
+func (s *Server) handleRegistryGraph(w http.ResponseWriter, r *http.Request) {
+	if s.registryServer == nil {
+		writeJSONError(w, "Registry not available", http.StatusServiceUnavailable)
+		return
+	}
+	var serverTools map[string][]string
+	if s.gateway != nil {
+		serverTools = registry.ServerTools(s.gateway.Status())
+	}
+	graph := registry.BuildDependencyGraph(s.registryServer.Store().ListSkills(), serverTools)
+	if server := r.URL.Query().Get("server"); server != "" {
+		graph = graph.ForServer(server)
+	}
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		writeJSON(w, graph)
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		_ = graph.WriteDOT(w)
+	case "mermaid":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_ = graph.WriteMermaid(w)
+	default:
+		writeJSONError(w, fmt.Sprintf("Unknown format %q (want json, dot, or mermaid)", format), http.StatusBadRequest)
+	}
 }
 
 // handleRegistrySkillDelete deletes a skill.
@@ -280,6 +439,10 @@ func (s *Server) handleRegistrySkillsBatch(w http.ResponseWriter, r *http.Reques
 	writeJSON(w, resp)
 }
 
+// maxFileUploadBytes caps a multipart upload request. Each file is further
+// capped by its skill's file policy (registry.MaxFileSizeLimit at most).
+const maxFileUploadBytes = 200 << 20
+
 // handleRegistrySkillFileList lists files in a skill directory.
 // GET /api/registry/skills/{name}/files
 func (s *Server) handleRegistrySkillFileList(w http.ResponseWriter, r *http.Request) {
@@ -303,7 +466,8 @@ func (s *Server) handleRegistrySkillFileList(w http.ResponseWriter, r *http.Requ
 	writeJSON(w, files)
 }
 
-// handleRegistrySkillFileGet reads a file from a skill directory.
+// handleRegistrySkillFileGet reads a file from a skill directory. With
+// ?download=true the file is served as an attachment.
 // GET /api/registry/skills/{name}/files/{path...}
 func (s *Server) handleRegistrySkillFileGet(w http.ResponseWriter, r *http.Request) {
 	if s.registryServer == nil {
@@ -329,7 +493,14 @@ func (s *Server) handleRegistrySkillFileGet(w http.ResponseWriter, r *http.Reque
 		}
 		return
 	}
-	w.Header().Set("Content-Type", detectContentType(filePath))
+	ct := detectContentType(filePath)
+	w.Header().Set("Content-Type", ct)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	if ct == "application/octet-stream" || r.URL.Query().Get("download") == "true" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(filePath)}))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	}
 	_, _ = w.Write(data)
 }
 
@@ -350,18 +521,130 @@ func (s *Server) handleRegistrySkillFilePut(w http.ResponseWriter, r *http.Reque
 		writeJSONError(w, "Skill not found: "+name, http.StatusNotFound)
 		return
 	}
-	data, err := io.ReadAll(io.LimitReader(r.Body, 1<<20)) // 1MB limit
+	if s.rejectSyncedSkill(w, name) {
+		return
+	}
+	store := s.registryServer.Store()
+	limit := store.FileLimit(name)
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
 	if err != nil {
-		writeJSONError(w, "Failed to read body: "+err.Error(), http.StatusBadRequest)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSONError(w, fmt.Sprintf("File exceeds the %d byte limit for skill %s", limit, name), http.StatusRequestEntityTooLarge)
+		} else {
+			writeJSONError(w, "Failed to read body: "+err.Error(), http.StatusBadRequest)
+		}
 		return
 	}
-	if err := s.registryServer.Store().WriteFile(name, filePath, data); err != nil {
-		writeJSONError(w, "Failed to write file: "+err.Error(), http.StatusInternalServerError)
+	if err := store.WriteFile(name, filePath, data); err != nil {
+		writeFileError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleRegistrySkillFileUpload writes the files of a multipart/form-data
+// body to a skill directory. Each part's file name (base name only) is
+// written under the optional dir query parameter. Every file is checked
+// against the skill's file policy before any is written.
+// POST /api/registry/skills/{name}/files
+func (s *Server) handleRegistrySkillFileUpload(w http.ResponseWriter, r *http.Request) {
+	if s.registryServer == nil {
+		writeJSONError(w, "Registry not available", http.StatusServiceUnavailable)
+		return
+	}
+	name := r.PathValue("name")
+	store := s.registryServer.Store()
+	if _, err := store.GetSkill(name); err != nil {
+		writeJSONError(w, "Skill not found: "+name, http.StatusNotFound)
+		return
+	}
+	if s.rejectSyncedSkill(w, name) {
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxFileUploadBytes)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		writeJSONError(w, "Expected a multipart/form-data body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dir := r.URL.Query().Get("dir")
+	limit := store.FileLimit(name)
+	type upload struct {
+		path string
+		data []byte
+	}
+	var uploads []upload
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeUploadReadError(w, err)
+			return
+		}
+		if part.FileName() == "" {
+			continue // plain form field
+		}
+		filePath := path.Join(dir, path.Base(filepath.ToSlash(part.FileName())))
+		// Read one byte past the limit so an oversized file is detected
+		// without buffering all of it.
+		data, err := io.ReadAll(io.LimitReader(part, limit+1))
+		if err != nil {
+			writeUploadReadError(w, err)
+			return
+		}
+		if err := store.CheckFile(name, filePath, int64(len(data))); err != nil {
+			if errors.Is(err, registry.ErrFileTooLarge) || errors.Is(err, registry.ErrFileTypeNotAllowed) {
+				writeFileError(w, err)
+			} else {
+				writeJSONError(w, err.Error(), http.StatusBadRequest)
+			}
+			return
+		}
+		uploads = append(uploads, upload{filePath, data})
+	}
+	if len(uploads) == 0 {
+		writeJSONError(w, "No files in upload", http.StatusBadRequest)
+		return
+	}
+
+	written := make([]registry.SkillFile, 0, len(uploads))
+	for _, u := range uploads {
+		if err := store.WriteFile(name, u.path, u.data); err != nil {
+			writeFileError(w, err)
+			return
+		}
+		written = append(written, registry.SkillFile{Path: u.path, Size: int64(len(u.data))})
+	}
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, written)
+}
+
+// writeFileError maps a skill file write error to an HTTP status.
+func writeFileError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, registry.ErrFileTooLarge):
+		writeJSONError(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, registry.ErrFileTypeNotAllowed):
+		writeJSONError(w, err.Error(), http.StatusUnsupportedMediaType)
+	default:
+		writeJSONError(w, "Failed to write file: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// writeUploadReadError reports a failure reading a multipart upload.
+func writeUploadReadError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeJSONError(w, fmt.Sprintf("Upload exceeds %d MB", maxFileUploadBytes>>20), http.StatusRequestEntityTooLarge)
+		return
+	}
+	writeJSONError(w, "Failed to read upload: "+err.Error(), http.StatusBadRequest)
+}
+
 // handleRegistrySkillFileDelete deletes a file from a skill directory.
 // DELETE /api/registry/skills/{name}/files/{path...}
 func (s *Server) handleRegistrySkillFileDelete(w http.ResponseWriter, r *http.Request) {
@@ -379,6 +662,9 @@ func (s *Server) handleRegistrySkillFileDelete(w http.ResponseWriter, r *http.Re
 		writeJSONError(w, "Skill not found: "+name, http.StatusNotFound)
 		return
 	}
+	if s.rejectSyncedSkill(w, name) {
+		return
+	}
 	if err := s.registryServer.Store().DeleteFile(name, filePath); err != nil {
 		writeJSONError(w, "Failed to delete file: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -386,24 +672,32 @@ func (s *Server) handleRegistrySkillFileDelete(w http.ResponseWriter, r *http.Re
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// detectContentType returns a MIME type based on file extension.
+// textFileExts are the skill file extensions served inline. Everything
+// else is user-uploaded content the browser must not render, so it goes out
+// as an octet-stream attachment.
+var textFileExts = map[string]bool{
+	".md":   true,
+	".txt":  true,
+	".sh":   true,
+	".py":   true,
+	".js":   true,
+	".ts":   true,
+	".json": true,
+	".yaml": true,
+	".yml":  true,
+	".toml": true,
+	".csv":  true,
+}
+
+// detectContentType returns the Content-Type to serve a skill file with:
+// text/plain for the textFileExts allow list, application/octet-stream for
+// anything else. Types a browser would render (HTML, SVG, XML) are never
+// returned, so an uploaded file cannot run script on the gridctl origin.
 func detectContentType(path string) string {
-	switch filepath.Ext(path) {
-	case ".md":
-		return "text/markdown"
-	case ".sh":
-		return "text/x-shellscript"
-	case ".py":
-		return "text/x-python"
-	case ".json":
-		return "application/json"
-	case ".yaml", ".yml":
-		return "text/yaml"
-	case ".csv":
-		return "text/csv"
-	default:
-		return "application/octet-stream"
+	if textFileExts[strings.ToLower(filepath.Ext(path))] {
+		return "text/plain; charset=utf-8"
 	}
+	return "application/octet-stream"
 }
 
 // handleRegistryValidate validates SKILL.md content without saving.
@@ -436,6 +730,27 @@ func (s *Server) handleRegistryValidate(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// rejectSyncedSkill answers 409 and returns true when the skill is kept in
+// sync by a skills.yaml source: its content comes from upstream, and a
+// local edit would only block the next sync. Detaching it makes it local.
+func (s *Server) rejectSyncedSkill(w http.ResponseWriter, name string) bool {
+	store := s.registryServer.Store()
+	sk, err := store.GetSkill(name)
+	if err != nil {
+		return false
+	}
+	dir := sk.Dir
+	if dir == "" {
+		dir = sk.Name
+	}
+	origin, err := skills.ReadOrigin(filepath.Join(store.Dir(), "skills", dir))
+	if err != nil || origin.Source == "" {
+		return false
+	}
+	writeJSONError(w, fmt.Sprintf("Skill %q is synced from source %q and is read-only; change it upstream, or detach it to edit locally", name, origin.Source), http.StatusConflict)
+	return true
+}
+
 // refreshRegistryRouter refreshes the registry and re-registers with the gateway router.
 // This handles progressive disclosure: if the registry gains content, it registers;
 // if all content is removed, the registry is deregistered.
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gridctl/gridctl/pkg/registry"
+	"github.com/gridctl/gridctl/pkg/skills"
+)
+
+// maxBundleUploadBytes caps an uploaded bundle archive. Compressed skill
+// bundles are small; registry.ReadBundle separately caps the uncompressed
+// contents.
+const maxBundleUploadBytes = 20 << 20
+
+// handleRegistrySkillBundle downloads a skill as a .tar.gz bundle.
+// GET /api/registry/skills/{name}/bundle
+func (s *Server) handleRegistrySkillBundle(w http.ResponseWriter, r *http.Request) {
+	if s.registryServer == nil {
+		writeJSONError(w, "Registry not available", http.StatusServiceUnavailable)
+		return
+	}
+	name := r.PathValue("name")
+	data, err := s.registryServer.Store().BundleBytes(name)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			writeJSONError(w, "Skill not found: "+name, http.StatusNotFound)
+		} else {
+			writeJSONError(w, "Failed to export skill: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`.tar.gz"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	_, _ = w.Write(data)
+}
+
+// handleRegistrySkillImport installs a skill from a bundle archive sent as
+// the request body. Query parameters mirror the CLI flags: rename, force,
+// trust, and noActivate. Responds 201 with the import result, or 409 with
+// the same result shape when the skill was skipped (exists, invalid, or
+// has security findings without trust).
+// POST /api/registry/skills/import
+func (s *Server) handleRegistrySkillImport(w http.ResponseWriter, r *http.Request) {
+	if s.registryServer == nil {
+		writeJSONError(w, "Registry not available", http.StatusServiceUnavailable)
+		return
+	}
+	q := r.URL.Query()
+	opts := skills.ImportOptions{Rename: q.Get("rename")}
+	for key, dst := range map[string]*bool{"force": &opts.Force, "trust": &opts.Trust, "noActivate": &opts.NoActivate} {
+		if v := q.Get(key); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				writeJSONError(w, "Invalid "+key+" parameter: "+v, http.StatusBadRequest)
+				return
+			}
+			*dst = b
+		}
+	}
+
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(http.MaxBytesReader(w, r.Body, maxBundleUploadBytes)); err != nil {
+		writeJSONError(w, "Failed to read bundle: "+err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	bundle, err := registry.ReadBundle(&body)
+	if err != nil {
+		writeJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	store := s.registryServer.Store()
+	imp := skills.NewImporter(store, store.Dir(), s.lockFilePath(), slog.Default())
+	result, err := imp.ImportBundle(bundle, opts)
+	if err != nil {
+		writeJSONError(w, "Import failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status := http.StatusCreated
+	if len(result.Imported) == 0 {
+		status = http.StatusConflict
+	} else {
+		s.refreshRegistryRouter()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	writeJSON(w, result)
+}
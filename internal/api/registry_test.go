@@ -1,9 +1,11 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
@@ -50,6 +52,16 @@ func seedSkill(t *testing.T, regServer *registry.Server, name string, state regi
 	}
 }
 
+// ifMatch returns the If-Match value for the stored version of a skill.
+func ifMatch(t *testing.T, regServer *registry.Server, name string) string {
+	t.Helper()
+	sk, err := regServer.Store().GetSkill(name)
+	if err != nil {
+		t.Fatalf("failed to get skill: %v", err)
+	}
+	return `"` + sk.Version + `"`
+}
+
 // --- Status endpoint ---
 
 func TestHandleRegistry_Status(t *testing.T) {
@@ -106,7 +118,7 @@ func TestHandleRegistry_ListSkills_Empty(t *testing.T) {
 	}
 
 	var result []registry.AgentSkill
-	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+	if err := decodeListItems(rec.Body, &result); err != nil {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 	if len(result) != 0 {
@@ -114,6 +126,57 @@ func TestHandleRegistry_ListSkills_Empty(t *testing.T) {
 	}
 }
 
+func TestHandleRegistry_ListSkills_Filtered(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	for _, sk := range []*registry.AgentSkill{
+		{Name: "ping-host", Description: "Ping a host", State: registry.StateActive, Tags: []string{"network"}},
+		{Name: "trace-route", Description: "Trace the route to a host", State: registry.StateDraft, Tags: []string{"network", "debug"}},
+		{Name: "ping-db", Description: "Check the database answers", State: registry.StateActive, Tags: []string{"database"}},
+	} {
+		if err := regServer.Store().SaveSkill(sk); err != nil {
+			t.Fatalf("failed to seed skill: %v", err)
+		}
+	}
+	handler := srv.Handler()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"tag=network", []string{"ping-host", "trace-route"}},
+		{"tag=network&tag=debug", []string{"trace-route"}},
+		{"tag=network&state=active&q=ping", []string{"ping-host"}},
+		{"q=HOST", []string{"ping-host", "trace-route"}},
+		{"tag=unknown", nil},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/registry/skills?"+tt.query, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", tt.query, rec.Code)
+		}
+		var result []registry.AgentSkill
+		if err := decodeListItems(rec.Body, &result); err != nil {
+			t.Fatalf("%s: invalid JSON: %v", tt.query, err)
+		}
+		var names []string
+		for _, sk := range result {
+			names = append(names, sk.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: got %v, want %v", tt.query, names, tt.want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/registry/skills?state=bogus", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid state: expected 400, got %d", rec.Code)
+	}
+}
+
 // --- Skills: create ---
 
 func TestHandleRegistry_CreateSkill(t *testing.T) {
@@ -230,6 +293,7 @@ func TestHandleRegistry_UpdateSkill(t *testing.T) {
 	handler := srv.Handler()
 	body := `{"description":"Updated description","state":"active"}`
 	req := httptest.NewRequest(http.MethodPut, "/api/registry/skills/updatable-skill", strings.NewReader(body))
+	req.Header.Set("If-Match", ifMatch(t, regServer, "updatable-skill"))
 	rec := httptest.NewRecorder()
 	handler.ServeHTTP(rec, req)
 
@@ -249,6 +313,251 @@ func TestHandleRegistry_UpdateSkill(t *testing.T) {
 	}
 }
 
+func TestHandleRegistry_UpdateSkill_RecordsChangelog(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	seedSkill(t, regServer, "tracked", registry.StateDraft)
+	handler := srv.Handler()
+
+	body := `{"description":"Test skill: tracked","state":"active","body":"# tracked\n\nSkill instructions.\n\n## Rollback\n\nRevert."}`
+	req := httptest.NewRequest(http.MethodPut, "/api/registry/skills/tracked", strings.NewReader(body))
+	req.Header.Set("If-Match", ifMatch(t, regServer, "tracked"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+
+	var result struct {
+		Name    string `json:"name"`
+		Changes struct {
+			Fields        []registry.FieldChange `json:"fields"`
+			SectionsAdded []string               `json:"sectionsAdded"`
+			BodyDiff      string                 `json:"bodyDiff"`
+		} `json:"changes"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Name != "tracked" {
+		t.Errorf("expected skill fields at the top level, got name %q", result.Name)
+	}
+	if len(result.Changes.Fields) != 1 || result.Changes.Fields[0].Field != "state" {
+		t.Errorf("expected only state to change, got %+v", result.Changes.Fields)
+	}
+	if len(result.Changes.SectionsAdded) != 1 || result.Changes.SectionsAdded[0] != "Rollback" {
+		t.Errorf("expected Rollback section added, got %v", result.Changes.SectionsAdded)
+	}
+	if !strings.Contains(result.Changes.BodyDiff, "+## Rollback") {
+		t.Errorf("expected body diff, got %q", result.Changes.BodyDiff)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/registry/skills/tracked/changelog", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var list struct {
+		Items []registry.ChangelogEntry `json:"items"`
+		Total int                       `json:"total"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if list.Total != 1 || list.Items[0].Fields[0].New != "active" {
+		t.Errorf("unexpected changelog: %+v", list)
+	}
+}
+
+func TestHandleRegistry_Changelog_NotFound(t *testing.T) {
+	srv, _ := setupRegistryTestServer(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/registry/skills/ghost/changelog", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestHandleRegistry_Lint(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	sk := &registry.AgentSkill{
+		Name:        "linted",
+		Description: "Lint me",
+		State:       registry.StateActive,
+		Arguments:   []registry.SkillArgument{{Name: "target", Description: "What to check"}},
+		Body:        "# Linted\n\nCheck the change.",
+	}
+	if err := regServer.Store().SaveSkill(sk); err != nil {
+		t.Fatalf("failed to seed skill: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/registry/skills/linted/lint", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var list struct {
+		Items []registry.LintFinding `json:"items"`
+		Total int                    `json:"total"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if list.Total != 1 || list.Items[0].Rule != registry.LintRuleUnusedArgument {
+		t.Errorf("expected one unused-argument finding, got %+v", list)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/registry/skills/ghost/lint", nil)
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown skill, got %d", rec.Code)
+	}
+}
+
+func TestHandleRegistry_Graph(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	srv.gateway.Router().AddClient(newMockAgentClient("github", []mcp.Tool{{Name: "create_issue"}}))
+	registerMockServerMeta(srv.gateway, "github", mcp.TransportHTTP)
+	srv.gateway.Router().AddClient(newMockAgentClient("slack", []mcp.Tool{{Name: "post"}}))
+	registerMockServerMeta(srv.gateway, "slack", mcp.TransportHTTP)
+	sk := &registry.AgentSkill{
+		Name:        "file-bug",
+		Description: "File a bug",
+		State:       registry.StateActive,
+		Body:        "Call github__create_issue with the details.",
+	}
+	if err := regServer.Store().SaveSkill(sk); err != nil {
+		t.Fatalf("failed to seed skill: %v", err)
+	}
+	handler := srv.Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/registry/graph?server=github", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var graph registry.DependencyGraph
+	if err := json.NewDecoder(rec.Body).Decode(&graph); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(graph.Nodes) != 3 || len(graph.Edges) != 2 {
+		t.Errorf("expected skill, tool, and server with two edges, got %+v", graph)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/registry/graph?format=mermaid", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), "flowchart LR") {
+		t.Errorf("mermaid: got %d %q", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/registry/graph?format=svg", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown format: expected 400, got %d", rec.Code)
+	}
+}
+
+func TestHandleRegistry_UpdateSkill_VersionRequired(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	seedSkill(t, regServer, "guarded", registry.StateDraft)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/registry/skills/guarded", strings.NewReader(`{"description":"x"}`))
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusPreconditionRequired {
+		t.Fatalf("expected 428, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), errCodeVersionRequired) {
+		t.Errorf("expected %s code, got %s", errCodeVersionRequired, rec.Body.String())
+	}
+}
+
+func TestHandleRegistry_UpdateSkill_Conflict(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	seedSkill(t, regServer, "shared", registry.StateDraft)
+	handler := srv.Handler()
+
+	// Both editors open the skill; the ETag from GET is what they edit from.
+	req := httptest.NewRequest(http.MethodGet, "/api/registry/skills/shared", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag on GET")
+	}
+
+	// The first save wins; the version field in the body works like If-Match.
+	var opened registry.AgentSkill
+	if err := json.NewDecoder(rec.Body).Decode(&opened); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	opened.Description = "First editor"
+	first, _ := json.Marshal(opened)
+	req = httptest.NewRequest(http.MethodPut, "/api/registry/skills/shared", strings.NewReader(string(first)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("first save: expected 200, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("ETag") == etag {
+		t.Error("expected the ETag to change after a save")
+	}
+
+	// The second editor's save is stale and gets both versions back.
+	req = httptest.NewRequest(http.MethodPut, "/api/registry/skills/shared", strings.NewReader(`{"description":"Second editor"}`))
+	req.Header.Set("If-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("second save: expected 409, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var conflict skillConflictResponse
+	if err := json.NewDecoder(rec.Body).Decode(&conflict); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if conflict.Error.Code != errCodeSkillModified {
+		t.Errorf("expected code %s, got %s", errCodeSkillModified, conflict.Error.Code)
+	}
+	if conflict.Current == nil || conflict.Current.Description != "First editor" {
+		t.Errorf("expected current version from the first editor, got %+v", conflict.Current)
+	}
+	if conflict.Submitted.Description != "Second editor" {
+		t.Errorf("expected submitted version, got %+v", conflict.Submitted)
+	}
+
+	sk, _ := regServer.Store().GetSkill("shared")
+	if sk.Description != "First editor" {
+		t.Errorf("stale save overwrote the skill: %q", sk.Description)
+	}
+}
+
+func TestHandleRegistry_UpdateSkill_SyncedReadOnly(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	seedSkill(t, regServer, "acme-review", registry.StateActive)
+	skillDir := filepath.Join(regServer.Store().Dir(), "skills", "acme-review")
+	if err := skills.WriteOrigin(skillDir, &skills.Origin{Repo: "https://github.com/acme/prompts", Source: "acme"}); err != nil {
+		t.Fatalf("failed to write origin: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/registry/skills/acme-review", strings.NewReader(`{"description":"edited"}`))
+	req.Header.Set("If-Match", ifMatch(t, regServer, "acme-review"))
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "read-only") {
+		t.Errorf("expected a read-only error, got %s", rec.Body.String())
+	}
+}
+
 func TestHandleRegistry_UpdateSkill_NotFound(t *testing.T) {
 	srv, _ := setupRegistryTestServer(t)
 	handler := srv.Handler()
@@ -736,8 +1045,11 @@ func TestHandleRegistry_WriteAndReadFile(t *testing.T) {
 	}
 
 	ct := rec.Header().Get("Content-Type")
-	if ct != "text/x-shellscript" {
-		t.Errorf("expected Content-Type text/x-shellscript, got %q", ct)
+	if ct != "text/plain; charset=utf-8" {
+		t.Errorf("expected Content-Type text/plain, got %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); cd != "" {
+		t.Errorf("text file should be served inline, got Content-Disposition %q", cd)
 	}
 
 	// List files should show the new file
@@ -792,6 +1104,179 @@ func TestHandleRegistry_DeleteFile(t *testing.T) {
 	}
 }
 
+// multipartUpload builds a multipart/form-data body with one file part per
+// entry in files (name -> content).
+func multipartUpload(t *testing.T, files map[string]string) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for name, content := range files {
+		fw, err := mw.CreateFormFile("file", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = fw.Write([]byte(content))
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &body, mw.FormDataContentType()
+}
+
+func TestHandleRegistry_UploadFiles(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	seedSkill(t, regServer, "file-skill", registry.StateActive)
+	handler := srv.Handler()
+
+	body, contentType := multipartUpload(t, map[string]string{"spec.pdf": "%PDF-1.7", "../data.csv": "a,b\n1,2"})
+	req := httptest.NewRequest(http.MethodPost, "/api/registry/skills/file-skill/files?dir=references", body)
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+	var written []registry.SkillFile
+	_ = json.NewDecoder(rec.Body).Decode(&written)
+	if len(written) != 2 {
+		t.Fatalf("expected 2 written files, got %+v", written)
+	}
+	// Part file names are reduced to their base name.
+	for _, p := range []string{"references/spec.pdf", "references/data.csv"} {
+		if _, err := regServer.Store().ReadFile("file-skill", p); err != nil {
+			t.Errorf("ReadFile(%q): %v", p, err)
+		}
+	}
+}
+
+func TestHandleRegistry_UploadFiles_PolicyRejectsBatch(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	sk := &registry.AgentSkill{
+		Name:        "pdf-only",
+		Description: "Ships PDFs",
+		State:       registry.StateActive,
+		Files:       &registry.FilePolicy{MaxSize: "8B", Allow: []string{".pdf"}},
+	}
+	if err := regServer.Store().SaveSkill(sk); err != nil {
+		t.Fatal(err)
+	}
+	handler := srv.Handler()
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  int
+	}{
+		{"extension not allowed", map[string]string{"ok.pdf": "%PDF", "run.sh": "echo"}, http.StatusUnsupportedMediaType},
+		{"file too large", map[string]string{"big.pdf": "%PDF-1.7 and more"}, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType := multipartUpload(t, tt.files)
+			req := httptest.NewRequest(http.MethodPost, "/api/registry/skills/pdf-only/files", body)
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d; body: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			// Nothing from a rejected batch is written.
+			files, _ := regServer.Store().ListFiles("pdf-only")
+			if len(files) != 0 {
+				t.Errorf("expected no files written, got %+v", files)
+			}
+		})
+	}
+}
+
+func TestHandleRegistry_WriteFile_OverPolicyLimit(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	sk := &registry.AgentSkill{
+		Name:        "small",
+		Description: "Small files only",
+		State:       registry.StateActive,
+		Files:       &registry.FilePolicy{MaxSize: "4B"},
+	}
+	if err := regServer.Store().SaveSkill(sk); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/registry/skills/small/files/notes.txt", strings.NewReader("too long"))
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleRegistry_ReadFile_Download(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	seedSkill(t, regServer, "file-skill", registry.StateActive)
+	if err := regServer.Store().WriteFile("file-skill", "references/user guide.pdf", []byte("%PDF-1.7")); err != nil {
+		t.Fatal(err)
+	}
+	handler := srv.Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/registry/skills/file-skill/files/references/user%20guide.pdf?download=true", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="user guide.pdf"` {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/octet-stream" {
+		t.Errorf("Content-Type = %q, want application/octet-stream", ct)
+	}
+
+	// Files outside the text allow list are attachments even without download.
+	req = httptest.NewRequest(http.MethodGet, "/api/registry/skills/file-skill/files/references/user%20guide.pdf", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="user guide.pdf"` {
+		t.Errorf("Content-Disposition without download = %q", cd)
+	}
+}
+
+func TestHandleRegistry_ReadFile_HTMLNotRendered(t *testing.T) {
+	srv, regServer := setupRegistryTestServer(t)
+	seedSkill(t, regServer, "file-skill", registry.StateActive)
+	handler := srv.Handler()
+
+	body := "<script>alert(document.cookie)</script>"
+	req := httptest.NewRequest(http.MethodPut, "/api/registry/skills/file-skill/files/assets/page.html", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 for write, got %d; body: %s", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/registry/skills/file-skill/files/assets/page.html", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	h := rec.Header()
+	if ct := h.Get("Content-Type"); ct != "application/octet-stream" {
+		t.Errorf("Content-Type = %q, want application/octet-stream", ct)
+	}
+	if cd := h.Get("Content-Disposition"); cd != `attachment; filename=page.html` {
+		t.Errorf("Content-Disposition = %q, want attachment", cd)
+	}
+	if v := h.Get("X-Content-Type-Options"); v != "nosniff" {
+		t.Errorf("X-Content-Type-Options = %q, want nosniff", v)
+	}
+	if v := h.Get("Content-Security-Policy"); v != "sandbox" {
+		t.Errorf("Content-Security-Policy = %q, want sandbox", v)
+	}
+}
+
 func TestHandleRegistry_Files_SkillNotFound(t *testing.T) {
 	srv, _ := setupRegistryTestServer(t)
 	handler := srv.Handler()
@@ -810,8 +1295,8 @@ func TestHandleRegistry_Files_MethodNotAllowed(t *testing.T) {
 	seedSkill(t, regServer, "file-skill", registry.StateActive)
 	handler := srv.Handler()
 
-	// POST to files listing should be method not allowed
-	req := httptest.NewRequest(http.MethodPost, "/api/registry/skills/file-skill/files", nil)
+	// PATCH to files listing should be method not allowed
+	req := httptest.NewRequest(http.MethodPatch, "/api/registry/skills/file-skill/files", nil)
 	rec := httptest.NewRecorder()
 	handler.ServeHTTP(rec, req)
 
@@ -827,13 +1312,18 @@ func TestDetectContentType(t *testing.T) {
 		path     string
 		expected string
 	}{
-		{"readme.md", "text/markdown"},
-		{"script.sh", "text/x-shellscript"},
-		{"main.py", "text/x-python"},
-		{"config.json", "application/json"},
-		{"stack.yaml", "text/yaml"},
-		{"stack.yml", "text/yaml"},
-		{"data.csv", "text/csv"},
+		{"readme.md", "text/plain; charset=utf-8"},
+		{"README.MD", "text/plain; charset=utf-8"},
+		{"script.sh", "text/plain; charset=utf-8"},
+		{"main.py", "text/plain; charset=utf-8"},
+		{"config.json", "text/plain; charset=utf-8"},
+		{"stack.yaml", "text/plain; charset=utf-8"},
+		{"stack.yml", "text/plain; charset=utf-8"},
+		{"data.csv", "text/plain; charset=utf-8"},
+		{"page.html", "application/octet-stream"},
+		{"icon.svg", "application/octet-stream"},
+		{"feed.xml", "application/octet-stream"},
+		{"manual.pdf", "application/octet-stream"},
 		{"binary.bin", "application/octet-stream"},
 		{"noext", "application/octet-stream"},
 	}
@@ -943,3 +1433,85 @@ func TestHandleRegistry_SkillsBatch_EmptyArray(t *testing.T) {
 	}
 }
 
+// --- Skills: bundles ---
+
+func TestHandleRegistry_SkillBundleRoundTrip(t *testing.T) {
+	srcServer, srcReg := setupRegistryTestServer(t)
+	seedSkill(t, srcReg, "deploy", registry.StateActive)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/registry/skills/deploy/bundle", nil)
+	rec := httptest.NewRecorder()
+	srcServer.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("export: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/gzip" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	bundle := rec.Body.Bytes()
+
+	dstServer, dstReg := setupRegistryTestServer(t)
+	handler := dstServer.Handler()
+	req = httptest.NewRequest(http.MethodPost, "/api/registry/skills/import", strings.NewReader(string(bundle)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("import: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, err := dstReg.Store().GetSkill("deploy"); err != nil {
+		t.Fatalf("imported skill missing: %v", err)
+	}
+
+	// The imported skill carries a version, so a conditional update works.
+	req = httptest.NewRequest(http.MethodPut, "/api/registry/skills/deploy", strings.NewReader(`{"description":"Edited after import"}`))
+	req.Header.Set("If-Match", ifMatch(t, dstReg, "deploy"))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("conditional update after import: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// Importing again without force is a conflict; rename succeeds.
+	req = httptest.NewRequest(http.MethodPost, "/api/registry/skills/import", strings.NewReader(string(bundle)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("re-import: expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+	req = httptest.NewRequest(http.MethodPost, "/api/registry/skills/import?rename=deploy-copy&noActivate=true", strings.NewReader(string(bundle)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("rename import: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	sk, err := dstReg.Store().GetSkill("deploy-copy")
+	if err != nil || sk.State != registry.StateDraft {
+		t.Errorf("renamed skill = %+v, %v", sk, err)
+	}
+}
+
+func TestHandleRegistry_SkillBundleErrors(t *testing.T) {
+	srv, _ := setupRegistryTestServer(t)
+	handler := srv.Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/registry/skills/missing/bundle", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("export missing: expected 404, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/registry/skills/import", strings.NewReader("not a bundle"))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("import garbage: expected 400, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/registry/skills/import?force=maybe", strings.NewReader(""))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad force param: expected 400, got %d", rec.Code)
+	}
+}
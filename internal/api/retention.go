@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gridctl/gridctl/pkg/retention"
+)
+
+// SetRetentionStatusFunc installs the closure GET /api/retention reads.
+func (s *Server) SetRetentionStatusFunc(fn func() retention.Report) {
+	s.retentionStatus = fn
+}
+
+// handleRetention handles GET /api/retention: what the retention janitor
+// has deleted per category since the daemon started. With no retention:
+// block it returns configured: false and an empty categories array.
+func (s *Server) handleRetention(w http.ResponseWriter, r *http.Request) {
+	if s.retentionStatus == nil {
+		writeJSON(w, retention.Report{Categories: []retention.CategoryStats{}})
+		return
+	}
+	writeJSON(w, s.retentionStatus())
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/retention"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getRetention(t *testing.T, s *Server) retention.Report {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/retention", nil)
+	w := httptest.NewRecorder()
+	s.handleRetention(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var report retention.Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	return report
+}
+
+func TestHandleRetention_Unwired(t *testing.T) {
+	report := getRetention(t, &Server{})
+
+	assert.False(t, report.Configured)
+	assert.NotNil(t, report.Categories)
+	assert.Empty(t, report.Categories)
+}
+
+func TestHandleRetention_ReturnsJanitorReport(t *testing.T) {
+	s := &Server{}
+	s.SetRetentionStatusFunc(func() retention.Report {
+		return retention.Report{
+			Configured: true,
+			Interval:   "1h0m0s",
+			Categories: []retention.CategoryStats{{Category: "request_logs", DeletedFiles: 3, DeletedBytes: 2048}},
+		}
+	})
+
+	report := getRetention(t, s)
+	assert.True(t, report.Configured)
+	require.Len(t, report.Categories, 1)
+	assert.Equal(t, int64(3), report.Categories[0].DeletedFiles)
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 
 	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/crash"
 	gitpkg "github.com/gridctl/gridctl/pkg/git"
 	"github.com/gridctl/gridctl/pkg/registry"
 	"github.com/gridctl/gridctl/pkg/skills"
@@ -828,6 +829,7 @@ func (s *Server) handleSkillSourcesSyncAll(w http.ResponseWriter, r *http.Reques
 
 		wg.Add(1)
 		go func(idx int, sourceName string, source skills.LockedSource) {
+			defer crash.Recover("skill-source-sync", "source", sourceName)
 			defer wg.Done()
 			sem <- struct{}{}
 			defer func() { <-sem }()
@@ -3,6 +3,8 @@ package api
 import (
 	"net/http"
 	"time"
+
+	"github.com/gridctl/gridctl/pkg/metrics"
 )
 
 // skillUsageStat is the wire shape for one skill's usage in
@@ -12,6 +14,9 @@ import (
 type skillUsageStat struct {
 	Calls        int64      `json:"calls"`
 	LastCalledAt *time.Time `json:"lastCalledAt"`
+	// Variants breaks Calls down by the variant served, for skills with
+	// variants. Counted since this gateway process started.
+	Variants map[string]skillUsageStat `json:"variants,omitempty"`
 }
 
 // skillUsageResponse is the GET /api/skills/usage envelope. Skills maps each
@@ -46,13 +51,25 @@ func (s *Server) handleSkillsUsage(w http.ResponseWriter, _ *http.Request) {
 		t := started.UTC()
 		resp.ObservedSince = &t
 	}
+	variants := s.metricsAccumulator.PromptVariantSnapshot()
 	for name, stat := range s.metricsAccumulator.PromptUsageSnapshot() {
-		entry := skillUsageStat{Calls: stat.Calls}
-		if !stat.LastCalledAt.IsZero() {
-			t := stat.LastCalledAt.UTC()
-			entry.LastCalledAt = &t
+		entry := newSkillUsageStat(stat)
+		for variant, vstat := range variants[name] {
+			if entry.Variants == nil {
+				entry.Variants = make(map[string]skillUsageStat)
+			}
+			entry.Variants[variant] = newSkillUsageStat(vstat)
 		}
 		resp.Skills[name] = entry
 	}
 	writeJSON(w, resp)
 }
+
+func newSkillUsageStat(stat metrics.ToolStat) skillUsageStat {
+	entry := skillUsageStat{Calls: stat.Calls}
+	if !stat.LastCalledAt.IsZero() {
+		t := stat.LastCalledAt.UTC()
+		entry.LastCalledAt = &t
+	}
+	return entry
+}
@@ -51,6 +51,27 @@ func TestHandleSkillsUsage_ReportsPerSkillCounts(t *testing.T) {
 	}
 }
 
+func TestHandleSkillsUsage_ReportsVariants(t *testing.T) {
+	srv := newTestServerWithMetrics(t)
+	for _, v := range []string{"concise", "concise", "default"} {
+		srv.metricsAccumulator.RecordPromptGet("code-review")
+		srv.metricsAccumulator.RecordPromptVariant("code-review", v)
+	}
+	srv.metricsAccumulator.RecordPromptGet("summarize")
+
+	resp, code := decodeSkillUsage(t, srv)
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	variants := resp.Skills["code-review"].Variants
+	if variants["concise"].Calls != 2 || variants["default"].Calls != 1 {
+		t.Errorf("variants = %+v", variants)
+	}
+	if resp.Skills["summarize"].Variants != nil {
+		t.Errorf("summarize has no variants; got %+v", resp.Skills["summarize"].Variants)
+	}
+}
+
 func TestHandleSkillsUsage_EmptyIsObjectNotNull(t *testing.T) {
 	srv := newTestServerWithMetrics(t)
 	resp, code := decodeSkillUsage(t, srv)
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"html"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// basePathMeta is the <meta> name the web UI reads to discover the path
+// prefix it is mounted under (API_BASE in web/src/lib/basePath.ts).
+const basePathMeta = "gridctl-base-path"
+
+// SetBasePath mounts the gateway under a path prefix (e.g. "/gridctl") for
+// reverse proxies that forward a sub-path without stripping it. Requests
+// under the prefix have it removed before routing; requests without it are
+// served as before, so proxies that strip the prefix keep working. The
+// value must be normalized (leading slash, no trailing slash); "" or "/"
+// mounts at the root.
+func (s *Server) SetBasePath(p string) {
+	if p == "/" {
+		p = ""
+	}
+	s.basePath = p
+}
+
+// basePathMiddleware strips base from request paths under it and redirects
+// the bare prefix to its trailing-slash form so relative asset URLs in the
+// UI resolve inside the prefix. Requests without an X-Forwarded-Prefix are
+// given base as theirs, so URLs the gateway hands out carry the prefix
+// whether or not the proxy stripped it.
+func basePathMiddleware(base string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		if p == base {
+			target := base + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+			return
+		}
+		r2 := r.Clone(r.Context())
+		if r2.Header.Get("X-Forwarded-Prefix") == "" {
+			r2.Header.Set("X-Forwarded-Prefix", base)
+		}
+		if strings.HasPrefix(p, base+"/") {
+			r2.URL.Path = strings.TrimPrefix(p, base)
+			if r.URL.RawPath != "" {
+				r2.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, base)
+			}
+		}
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// spaHandler serves the embedded web UI. Existing files are served as-is
+// (hashed build assets with a long immutable cache lifetime); every other
+// path gets index.html so client-side routes survive a reload. index.html
+// is rewritten to carry a <base href> and the base-path meta tag for the
+// request's X-Forwarded-Prefix (set from gateway.base_path when the proxy
+// sends none), so the UI's relative asset URLs and API calls resolve under
+// it. Directories are never listed, unknown /api/ paths are a JSON 404
+// rather than the UI, and only GET and HEAD are served.
+func spaHandler(staticFS fs.FS) http.Handler {
+	fileServer := http.FileServer(http.FS(staticFS))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			writeJSONError(w, "Not found", http.StatusNotFound)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		if name == "" || name == "index.html" {
+			serveIndex(w, r, staticFS, mcp.ForwardedPrefix(r))
+			return
+		}
+		info, err := fs.Stat(staticFS, name)
+		if err != nil || info.IsDir() {
+			serveIndex(w, r, staticFS, mcp.ForwardedPrefix(r))
+			return
+		}
+		if strings.HasPrefix(name, "assets/") {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		}
+		fileServer.ServeHTTP(w, r)
+	})
+}
+
+// serveIndex writes index.html with the base-path tags injected. It is
+// never cached: it names the current build's hashed assets.
+func serveIndex(w http.ResponseWriter, r *http.Request, staticFS fs.FS, base string) {
+	data, err := fs.ReadFile(staticFS, "index.html")
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, "index.html", time.Time{}, bytes.NewReader(injectBasePath(data, base)))
+}
+
+// injectBasePath adds <base href="{base}/"> and the base-path meta tag at
+// the top of <head>, ahead of any relative URL they must apply to.
+func injectBasePath(index []byte, base string) []byte {
+	escaped := html.EscapeString(base)
+	tags := `<base href="` + escaped + `/" /><meta name="` + basePathMeta + `" content="` + escaped + `" />`
+	i := bytes.Index(index, []byte("<head>"))
+	if i < 0 {
+		return append([]byte(tags), index...)
+	}
+	i += len("<head>")
+	out := make([]byte, 0, len(index)+len(tags))
+	out = append(out, index[:i]...)
+	out = append(out, tags...)
+	return append(out, index[i:]...)
+}
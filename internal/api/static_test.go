@@ -0,0 +1,131 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+func newStaticTestServer(t *testing.T, basePath string) http.Handler {
+	t.Helper()
+	webFS := fstest.MapFS{
+		"index.html":       {Data: []byte("<!doctype html><html><head><title>Gridctl</title></head><body></body></html>")},
+		"favicon.png":      {Data: []byte("png")},
+		"assets/app-1a.js": {Data: []byte("console.log(1)")},
+	}
+	srv := NewServer(mcp.NewGateway(), webFS)
+	srv.SetBasePath(basePath)
+	return srv.Handler()
+}
+
+func serveStatic(h http.Handler, method, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec
+}
+
+func TestSPAHandler_IndexInjectsBasePath(t *testing.T) {
+	h := newStaticTestServer(t, "")
+
+	for _, target := range []string{"/", "/index.html", "/logs-window", "/assets/"} {
+		rec := serveStatic(h, http.MethodGet, target)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", target, rec.Code)
+		}
+		body := rec.Body.String()
+		if !strings.Contains(body, `<head><base href="/" /><meta name="gridctl-base-path" content="" />`) {
+			t.Errorf("%s: base tags not injected: %s", target, body)
+		}
+		if got := rec.Header().Get("Cache-Control"); got != "no-cache" {
+			t.Errorf("%s: Cache-Control = %q, want no-cache", target, got)
+		}
+	}
+}
+
+func TestSPAHandler_AssetsAreImmutable(t *testing.T) {
+	h := newStaticTestServer(t, "")
+
+	rec := serveStatic(h, http.MethodGet, "/assets/app-1a.js")
+	if rec.Code != http.StatusOK || rec.Body.String() != "console.log(1)" {
+		t.Fatalf("asset: got %d %q", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Cache-Control"); !strings.Contains(got, "immutable") {
+		t.Errorf("Cache-Control = %q, want immutable", got)
+	}
+	if got := rec.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("X-Content-Type-Options = %q, want nosniff", got)
+	}
+	if got := serveStatic(h, http.MethodGet, "/favicon.png").Header().Get("Cache-Control"); got != "" {
+		t.Errorf("unhashed file Cache-Control = %q, want none", got)
+	}
+}
+
+func TestSPAHandler_UnknownAPIPathIs404(t *testing.T) {
+	h := newStaticTestServer(t, "")
+
+	rec := serveStatic(h, http.MethodGet, "/api/does-not-exist")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	assertContentType(t, rec, "application/json")
+}
+
+func TestSPAHandler_RejectsWrites(t *testing.T) {
+	h := newStaticTestServer(t, "")
+
+	rec := serveStatic(h, http.MethodPost, "/logs-window")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+}
+
+func TestBasePath_PrefixedAndStrippedRequests(t *testing.T) {
+	h := newStaticTestServer(t, "/gridctl")
+
+	for _, target := range []string{"/gridctl/", "/gridctl/logs-window", "/"} {
+		rec := serveStatic(h, http.MethodGet, target)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", target, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), `<base href="/gridctl/" /><meta name="gridctl-base-path" content="/gridctl" />`) {
+			t.Errorf("%s: base path not injected: %s", target, rec.Body.String())
+		}
+	}
+	for _, target := range []string{"/gridctl/api/tools", "/api/tools"} {
+		if rec := serveStatic(h, http.MethodGet, target); rec.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", target, rec.Code)
+		}
+	}
+	if rec := serveStatic(h, http.MethodGet, "/gridctl/assets/app-1a.js"); rec.Body.String() != "console.log(1)" {
+		t.Errorf("prefixed asset: got %q", rec.Body.String())
+	}
+}
+
+func TestBasePath_BarePrefixRedirects(t *testing.T) {
+	h := newStaticTestServer(t, "/gridctl")
+
+	rec := serveStatic(h, http.MethodGet, "/gridctl?x=1")
+	if rec.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected 301, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Location"); got != "/gridctl/?x=1" {
+		t.Errorf("Location = %q, want /gridctl/?x=1", got)
+	}
+}
+
+func TestSPAHandler_ForwardedPrefixWithoutBasePath(t *testing.T) {
+	h := newStaticTestServer(t, "")
+
+	req := httptest.NewRequest(http.MethodGet, "/logs-window", nil)
+	req.Header.Set("X-Forwarded-Prefix", "/tools")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), `<base href="/tools/" />`) {
+		t.Errorf("forwarded prefix not injected: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,16 @@
+package api
+
+import "net/http"
+
+// handleToolsLatency serves GET /api/tools/latency: per-tool call counts,
+// error counts, and latency percentiles from the gateway's latency
+// interceptor, as a list envelope (sort=-p95Ms finds the slowest tools).
+// Latencies live in memory only and reset when the gateway restarts.
+// Returns 503 when no recorder is wired, mirroring GET /api/tools/usage.
+func (s *Server) handleToolsLatency(w http.ResponseWriter, r *http.Request) {
+	if s.latencyRecorder == nil {
+		writeJSONError(w, "latency recorder not configured", http.StatusServiceUnavailable)
+		return
+	}
+	writeList(w, r, s.latencyRecorder.Snapshot())
+}
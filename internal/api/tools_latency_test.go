@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+func TestHandleToolsLatency_SortsBySlowest(t *testing.T) {
+	srv := newTestServer(t)
+	rec := mcp.NewLatencyRecorder()
+	srv.SetLatencyRecorder(rec)
+	for tool, d := range map[string]time.Duration{"github__search": 5 * time.Millisecond, "github__create_issue": 40 * time.Millisecond} {
+		call := &mcp.InterceptedCall{GateCall: mcp.GateCall{PrefixedTool: tool, ServerName: "github"}}
+		rec.After(context.Background(), call, &mcp.ToolCallOutcome{Result: &mcp.ToolCallResult{}, Duration: d})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tools/latency?sort=-p95Ms", nil)
+	w := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body=%s)", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Items []mcp.ToolLatency `json:"items"`
+		Total int               `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Total != 2 || resp.Items[0].Tool != "github__create_issue" || resp.Items[0].P95Ms != 40 {
+		t.Errorf("response = %+v, want create_issue first at 40ms", resp)
+	}
+}
+
+func TestHandleToolsLatency_NoRecorder(t *testing.T) {
+	srv := newTestServer(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/tools/latency", nil)
+	w := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", w.Code)
+	}
+}
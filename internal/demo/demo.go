@@ -0,0 +1,201 @@
+// Package demo builds a self-contained gridctl gateway for demos, web UI
+// development, and documentation screenshots. Servers, tools, skills, and
+// call history are synthetic and identical on every run; nothing touches
+// Docker, the network, or the user's ~/.gridctl state.
+package demo
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/gridctl/gridctl/internal/api"
+	"github.com/gridctl/gridctl/pkg/logging"
+	"github.com/gridctl/gridctl/pkg/mcp"
+	"github.com/gridctl/gridctl/pkg/metrics"
+	"github.com/gridctl/gridctl/pkg/registry"
+	"github.com/gridctl/gridctl/pkg/token"
+	"github.com/gridctl/gridctl/pkg/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// StackName is the stack name the demo gateway reports.
+const StackName = "demo"
+
+// Options configures a demo gateway.
+type Options struct {
+	// WebFS serves the web UI; nil serves the API only.
+	WebFS fs.FS
+
+	// Port is the port the caller will serve Handler on, reported to the
+	// UI as the gateway address.
+	Port int
+
+	// Logger receives demo log output in addition to the in-memory log
+	// buffer the UI reads. Nil keeps logs in the buffer only.
+	Logger slog.Handler
+}
+
+// Demo is a running demo gateway. Serve Handler and call Close when done.
+type Demo struct {
+	Gateway *mcp.Gateway
+	API     *api.Server
+
+	tracing *tracing.Provider
+	dir     string
+}
+
+// New builds the demo gateway: it registers the synthetic servers, seeds a
+// scratch skill registry, and replays the fixed call history so metrics,
+// traces, and logs are populated before the first page load.
+func New(ctx context.Context, opts Options) (*Demo, error) {
+	dir, err := os.MkdirTemp("", "gridctl-demo-")
+	if err != nil {
+		return nil, fmt.Errorf("creating demo registry: %w", err)
+	}
+	d := &Demo{dir: dir}
+	if err := d.build(ctx, opts); err != nil {
+		_ = d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Demo) build(ctx context.Context, opts Options) error {
+	logBuffer := logging.NewLogBuffer(1000)
+	logger := slog.New(logging.NewBufferHandler(logBuffer, opts.Logger))
+
+	gateway := mcp.NewGateway()
+	gateway.SetLogger(logger)
+	gateway.SetName("gridctl-" + StackName)
+	d.Gateway = gateway
+
+	for _, srv := range fixtureServers {
+		gateway.Router().AddClient(newFixtureClient(srv))
+		gateway.SetServerMeta(mcp.MCPServerConfig{
+			Name:         srv.name,
+			Transport:    srv.transport,
+			External:     srv.transport == mcp.TransportHTTP,
+			LocalProcess: srv.transport == mcp.TransportStdio,
+		})
+	}
+
+	store := registry.NewStore(d.dir)
+	for i := range fixtureSkills {
+		sk := fixtureSkills[i]
+		if err := store.SaveSkill(&sk); err != nil {
+			return fmt.Errorf("seeding skill %q: %w", sk.Name, err)
+		}
+	}
+	registryServer := registry.New(store)
+	if err := registryServer.Initialize(ctx); err != nil {
+		return err
+	}
+	gateway.Router().AddClient(registryServer)
+	gateway.Router().RefreshTools()
+
+	counter, err := token.NewTiktokenCounter()
+	if err != nil {
+		return fmt.Errorf("failed to initialize embedded tokenizer: %w", err)
+	}
+	accumulator := metrics.NewAccumulator(10000)
+	observer := metrics.NewObserver(counter, accumulator)
+	gateway.SetToolCallObserver(observer)
+	gateway.SetPromptGetObserver(observer)
+	gateway.SetTokenCounter(counter)
+	gateway.SetFormatSavingsRecorder(accumulator)
+
+	d.tracing = tracing.NewProvider(tracing.DefaultConfig())
+	d.tracing.SetLogger(logger)
+	if err := d.tracing.Init(ctx); err != nil {
+		return fmt.Errorf("initializing tracing: %w", err)
+	}
+
+	server := api.NewServer(gateway, opts.WebFS)
+	server.SetStackName(StackName)
+	server.SetLogBuffer(logBuffer)
+	server.SetAllowedOrigins([]string{"*"})
+	server.SetRegistryServer(registryServer)
+	server.SetMetricsAccumulator(accumulator)
+	server.SetTokenizerName("embedded")
+	server.SetTraceBuffer(d.tracing.Buffer)
+	if opts.Port > 0 {
+		server.SetGatewayAddr(fmt.Sprintf("http://localhost:%d", opts.Port))
+	}
+	d.API = server
+
+	logger.Info("demo gateway ready", "servers", len(fixtureServers), "skills", len(fixtureSkills))
+	return d.replay(ctx)
+}
+
+// replay drives the fixed call history through the gateway, each call under
+// its own root span so the traces view shows one trace per call.
+func (d *Demo) replay(ctx context.Context) error {
+	tracer := otel.Tracer("gridctl.demo")
+	for _, c := range fixtureHistory {
+		callCtx, span := tracer.Start(mcp.WithClientID(ctx, c.client), "mcp.tools/call")
+		span.SetAttributes(
+			attribute.String("mcp.method.name", "tools/call"),
+			attribute.String("tool.name", c.tool),
+		)
+		_, err := d.Gateway.HandleToolsCall(callCtx, mcp.ToolCallParams{Name: c.tool, Arguments: c.args})
+		span.End()
+		if err != nil {
+			return fmt.Errorf("replaying %s: %w", c.tool, err)
+		}
+	}
+	return nil
+}
+
+// Handler returns the API and web UI handler.
+func (d *Demo) Handler() http.Handler {
+	return d.API.Handler()
+}
+
+// Close stops tracing, closes the gateway, and removes the scratch registry.
+func (d *Demo) Close() error {
+	if d.tracing != nil {
+		_ = d.tracing.Shutdown(context.Background())
+	}
+	if d.Gateway != nil {
+		d.Gateway.Close()
+	}
+	return os.RemoveAll(d.dir)
+}
+
+// fixtureClient is an mcp.AgentClient answering from a fixtureServer.
+type fixtureClient struct {
+	server fixtureServer
+	tools  []mcp.Tool
+}
+
+func newFixtureClient(s fixtureServer) *fixtureClient {
+	tools := make([]mcp.Tool, len(s.tools))
+	for i, t := range s.tools {
+		tools[i] = t.tool
+	}
+	return &fixtureClient{server: s, tools: tools}
+}
+
+func (c *fixtureClient) Name() string                         { return c.server.name }
+func (c *fixtureClient) Initialize(_ context.Context) error   { return nil }
+func (c *fixtureClient) RefreshTools(_ context.Context) error { return nil }
+func (c *fixtureClient) Tools() []mcp.Tool                    { return c.tools }
+func (c *fixtureClient) IsInitialized() bool                  { return true }
+func (c *fixtureClient) ServerInfo() mcp.ServerInfo {
+	return mcp.ServerInfo{Name: c.server.name, Version: "1.0.0"}
+}
+
+func (c *fixtureClient) CallTool(_ context.Context, name string, _ map[string]any) (*mcp.ToolCallResult, error) {
+	for _, t := range c.server.tools {
+		if t.tool.Name == name {
+			return &mcp.ToolCallResult{Content: []mcp.Content{mcp.NewTextContent(t.result)}, IsError: t.err}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown tool %q", name)
+}
@@ -0,0 +1,84 @@
+package demo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func getJSON(t *testing.T, h http.Handler, path string, v any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d: %s", path, rec.Code, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("GET %s: decoding: %v", path, err)
+	}
+}
+
+func TestNew_SeedsFixtures(t *testing.T) {
+	d, err := New(context.Background(), Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = d.Close() })
+	h := d.Handler()
+
+	var status struct {
+		MCPServers []struct {
+			Name      string `json:"name"`
+			ToolCount int    `json:"toolCount"`
+		} `json:"mcp-servers"`
+		Registry *struct {
+			TotalSkills int `json:"totalSkills"`
+		} `json:"registry"`
+		TokenUsage *struct {
+			Session struct {
+				TotalTokens int64 `json:"total_tokens"`
+			} `json:"session"`
+		} `json:"token_usage"`
+	}
+	getJSON(t, h, "/api/status", &status)
+
+	names := map[string]bool{}
+	for _, s := range status.MCPServers {
+		names[s.Name] = true
+	}
+	for _, srv := range fixtureServers {
+		if !names[srv.name] {
+			t.Errorf("status is missing server %q: %+v", srv.name, status.MCPServers)
+		}
+	}
+	if status.Registry == nil || status.Registry.TotalSkills != len(fixtureSkills) {
+		t.Errorf("registry = %+v, want %d skills", status.Registry, len(fixtureSkills))
+	}
+	if status.TokenUsage == nil || status.TokenUsage.Session.TotalTokens == 0 {
+		t.Errorf("token usage not seeded: %+v", status.TokenUsage)
+	}
+
+	var traces struct {
+		Total int `json:"total"`
+	}
+	getJSON(t, h, "/api/traces", &traces)
+	if traces.Total < len(fixtureHistory) {
+		t.Errorf("traces = %d, want at least %d", traces.Total, len(fixtureHistory))
+	}
+}
+
+func TestClose_RemovesScratchRegistry(t *testing.T) {
+	d, err := New(context.Background(), Options{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stat(d.dir); !os.IsNotExist(err) {
+		t.Errorf("scratch dir %s still exists", d.dir)
+	}
+}
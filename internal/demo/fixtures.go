@@ -0,0 +1,143 @@
+package demo
+
+import (
+	"encoding/json"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+	"github.com/gridctl/gridctl/pkg/registry"
+)
+
+// fixtureTool is one synthetic tool and the canned answer it gives.
+type fixtureTool struct {
+	tool   mcp.Tool
+	result string
+	err    bool // answer with IsError, to show failures in the UI
+}
+
+// fixtureServer is one synthetic MCP server.
+type fixtureServer struct {
+	name      string
+	transport mcp.Transport
+	tools     []fixtureTool
+}
+
+// fixtureCall is one entry of the seeded call history.
+type fixtureCall struct {
+	client string
+	tool   string // server-prefixed
+	args   map[string]any
+}
+
+func schema(props string) json.RawMessage {
+	return json.RawMessage(`{"type":"object","properties":{` + props + `}}`)
+}
+
+var fixtureServers = []fixtureServer{
+	{
+		name:      "github",
+		transport: mcp.TransportHTTP,
+		tools: []fixtureTool{
+			{
+				tool: mcp.Tool{
+					Name:        "search_issues",
+					Description: "Search issues and pull requests",
+					InputSchema: schema(`"query":{"type":"string"},"state":{"type":"string","enum":["open","closed"]}`),
+				},
+				result: `[{"number":412,"title":"Gateway drops SSE keepalive after reload","state":"open"},{"number":398,"title":"Document tool groups","state":"open"}]`,
+			},
+			{
+				tool: mcp.Tool{
+					Name:        "get_pull_request",
+					Description: "Fetch a pull request with its review status",
+					InputSchema: schema(`"number":{"type":"integer"}`),
+				},
+				result: `{"number":415,"title":"Add demo mode","state":"open","reviews":[{"user":"octo","state":"APPROVED"}]}`,
+			},
+			{
+				tool: mcp.Tool{
+					Name:        "create_issue",
+					Description: "Open a new issue",
+					InputSchema: schema(`"title":{"type":"string"},"body":{"type":"string"}`),
+				},
+				result: `{"number":416,"url":"https://github.com/example/demo/issues/416"}`,
+			},
+		},
+	},
+	{
+		name:      "postgres",
+		transport: mcp.TransportStdio,
+		tools: []fixtureTool{
+			{
+				tool: mcp.Tool{
+					Name:        "query",
+					Description: "Run a read-only SQL query",
+					InputSchema: schema(`"sql":{"type":"string"}`),
+				},
+				result: "id,email,plan\n1,ada@example.com,pro\n2,grace@example.com,team\n3,linus@example.com,free",
+			},
+			{
+				tool: mcp.Tool{
+					Name:        "list_tables",
+					Description: "List tables in the public schema",
+					InputSchema: schema(``),
+				},
+				result: `["accounts","invoices","subscriptions","events"]`,
+			},
+		},
+	},
+	{
+		name:      "slack",
+		transport: mcp.TransportHTTP,
+		tools: []fixtureTool{
+			{
+				tool: mcp.Tool{
+					Name:        "post_message",
+					Description: "Post a message to a channel",
+					InputSchema: schema(`"channel":{"type":"string"},"text":{"type":"string"}`),
+				},
+				result: `{"ok":true,"ts":"1760000000.000100"}`,
+			},
+			{
+				tool: mcp.Tool{
+					Name:        "list_channels",
+					Description: "List public channels",
+					InputSchema: schema(``),
+				},
+				result: `{"ok":false,"error":"missing_scope","needed":"channels:read"}`,
+				err:    true,
+			},
+		},
+	},
+}
+
+var fixtureSkills = []registry.AgentSkill{
+	{
+		Name:        "triage-issues",
+		Description: "Label and prioritize new GitHub issues",
+		State:       registry.StateActive,
+		Body:        "# Triage issues\n\n1. Search open issues without labels with `github__search_issues`.\n2. Label each one by area and severity.\n3. Post a summary to #eng-triage with `slack__post_message`.\n",
+	},
+	{
+		Name:        "weekly-report",
+		Description: "Summarize signups and revenue for the week",
+		State:       registry.StateActive,
+		Body:        "# Weekly report\n\nQuery `accounts` and `invoices` with `postgres__query`, then post the totals to #metrics.\n",
+	},
+	{
+		Name:        "release-notes",
+		Description: "Draft release notes from merged pull requests",
+		State:       registry.StateDraft,
+		Body:        "# Release notes\n\nCollect merged pull requests since the last tag and group them by area.\n",
+	},
+}
+
+var fixtureHistory = []fixtureCall{
+	{client: "claude-desktop", tool: "github__search_issues", args: map[string]any{"query": "is:open label:bug", "state": "open"}},
+	{client: "claude-desktop", tool: "github__get_pull_request", args: map[string]any{"number": 415}},
+	{client: "cursor", tool: "postgres__list_tables", args: map[string]any{}},
+	{client: "cursor", tool: "postgres__query", args: map[string]any{"sql": "SELECT id, email, plan FROM accounts LIMIT 3"}},
+	{client: "claude-desktop", tool: "slack__list_channels", args: map[string]any{}},
+	{client: "claude-desktop", tool: "slack__post_message", args: map[string]any{"channel": "#eng-triage", "text": "2 open bugs need an owner"}},
+	{client: "cursor", tool: "postgres__query", args: map[string]any{"sql": "SELECT count(*) FROM invoices WHERE paid"}},
+	{client: "claude-desktop", tool: "github__create_issue", args: map[string]any{"title": "Flaky reload test", "body": "Seen twice this week."}},
+}
@@ -81,6 +81,9 @@ func (m *mockDockerClient) NetworkCreate(context.Context, string, network.Create
 	return network.CreateResponse{}, nil
 }
 func (m *mockDockerClient) NetworkRemove(context.Context, string) error { return nil }
+func (m *mockDockerClient) NetworkConnect(context.Context, string, string, *network.EndpointSettings) error {
+	return nil
+}
 func (m *mockDockerClient) ImageList(context.Context, image.ListOptions) ([]image.Summary, error) {
 	return nil, nil
 }
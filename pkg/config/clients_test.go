@@ -87,6 +87,21 @@ func TestValidate_Clients(t *testing.T) {
 			wantErr: true,
 			errMsg:  "references unknown MCP server 'slack'",
 		},
+		{
+			name: "valid skill allow-list",
+			clients: &ClientsConfig{
+				Profiles: map[string]ClientProfile{"reviewer": {Skills: []string{"code-review"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid skill name",
+			clients: &ClientsConfig{
+				Profiles: map[string]ClientProfile{"reviewer": {Skills: []string{"Code Review"}}},
+			},
+			wantErr: true,
+			errMsg:  "clients.profiles[reviewer].skills[0]",
+		},
 	}
 
 	for _, tc := range tests {
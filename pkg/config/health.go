@@ -6,6 +6,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/gridctl/gridctl/pkg/features"
 	"github.com/gridctl/gridctl/pkg/pricing"
 )
 
@@ -170,6 +171,19 @@ func (r *ValidationResult) addWarnings(s *Stack) {
 		r.WarningCount++
 	}
 
+	// Warn about feature flags this build does not know: typos, or flags
+	// that graduated or were removed. They are ignored at startup.
+	for i, name := range s.Features {
+		if features.ValidName(name) && !features.IsRegistered(name) {
+			r.Issues = append(r.Issues, ValidationIssue{
+				Field:    fmt.Sprintf("features[%d]", i),
+				Message:  fmt.Sprintf("unknown feature flag %q is ignored", name),
+				Severity: SeverityWarning,
+			})
+			r.WarningCount++
+		}
+	}
+
 	r.addModelWarnings(s)
 }
 
@@ -231,6 +245,10 @@ func ValidateStackFile(path string) (*Stack, *ValidationResult, error) {
 		return nil, nil, fmt.Errorf("parsing stack YAML: %w", err)
 	}
 
+	// Federate upstream gateways as external servers; entries that fail are
+	// reported with the rest of the validation issues.
+	upstreamErr := expandUpstreams(&stack)
+
 	// Expand env vars (no vault — validate-only doesn't need secrets)
 	expandStackVars(&stack, EnvResolver())
 
@@ -239,6 +257,17 @@ func ValidateStackFile(path string) (*Stack, *ValidationResult, error) {
 
 	// Validate with severity
 	result := ValidateWithIssues(&stack)
+	if ve, ok := upstreamErr.(ValidationErrors); ok {
+		result.Valid = false
+		for _, e := range ve {
+			result.Issues = append(result.Issues, ValidationIssue{
+				Field:    e.Field,
+				Message:  e.Message,
+				Severity: SeverityError,
+			})
+			result.ErrorCount++
+		}
+	}
 
 	return &stack, result, nil
 }
@@ -72,6 +72,26 @@ func TestValidateWithIssues_WarningNoAuth(t *testing.T) {
 	assert.True(t, found, "expected warning about missing auth")
 }
 
+func TestValidateWithIssues_WarningUnknownFeature(t *testing.T) {
+	stack := &Stack{
+		Name:       "test",
+		Network:    Network{Name: "test-net"},
+		MCPServers: []MCPServer{{Name: "s1", Image: "alpine", Port: 3000}},
+		Features:   []string{"no-such-feature"},
+	}
+
+	result := ValidateWithIssues(stack)
+	assert.True(t, result.Valid, "unknown flags must not fail validation")
+
+	found := false
+	for _, issue := range result.Issues {
+		if issue.Field == "features[0]" && issue.Severity == SeverityWarning {
+			found = true
+		}
+	}
+	assert.True(t, found, "expected warning about the unknown feature flag")
+}
+
 func TestValidateWithIssues_MixedErrorsAndWarnings(t *testing.T) {
 	stack := &Stack{
 		// Missing name — error
@@ -159,6 +159,60 @@ func TestValidate_Limits(t *testing.T) {
 			wantErr: true,
 			errMsg:  "duplicate rate limit",
 		},
+		{
+			name: "valid schedules",
+			limits: &LimitsConfig{
+				Schedules: []ScheduleLimit{
+					{Tool: "github__delete_repo", Days: []string{"mon", "tue", "wed", "thu", "fri"}, Hours: "09:00-17:00"},
+					{Server: "gitlab", Days: []string{"Mon", "Tue", "Wed", "Thu", "Sat", "Sun"}},
+					{Client: "cursor", Hours: "22:00-06:00"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "schedule without days or hours",
+			limits: &LimitsConfig{
+				Schedules: []ScheduleLimit{{Server: "github"}},
+			},
+			wantErr: true,
+			errMsg:  "must set 'days', 'hours', or both",
+		},
+		{
+			name: "schedule unknown day",
+			limits: &LimitsConfig{
+				Schedules: []ScheduleLimit{{Server: "github", Days: []string{"friday"}}},
+			},
+			wantErr: true,
+			errMsg:  `unknown day "friday"`,
+		},
+		{
+			name: "schedule malformed hours",
+			limits: &LimitsConfig{
+				Schedules: []ScheduleLimit{{Server: "github", Hours: "9am-5pm"}},
+			},
+			wantErr: true,
+			errMsg:  "limits.schedules[0].hours",
+		},
+		{
+			name: "schedule empty hour range",
+			limits: &LimitsConfig{
+				Schedules: []ScheduleLimit{{Server: "github", Hours: "09:00-09:00"}},
+			},
+			wantErr: true,
+			errMsg:  "start and end are equal",
+		},
+		{
+			name: "duplicate schedule scope",
+			limits: &LimitsConfig{
+				Schedules: []ScheduleLimit{
+					{Server: "github", Hours: "09:00-17:00"},
+					{Server: "github", Days: []string{"mon"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "duplicate schedule",
+		},
 		{
 			name: "same scope across budget and rate lists is fine",
 			limits: &LimitsConfig{
@@ -237,6 +291,10 @@ limits:
     - server: github
       calls_per_minute: 30
       burst: 10
+  schedules:
+    - tool: github__delete_repo
+      days: [mon, tue, wed, thu, fri]
+      hours: "09:00-17:00"
 `
 	var stack Stack
 	if err := yaml.Unmarshal([]byte(src), &stack); err != nil {
@@ -251,6 +309,9 @@ limits:
 	if got := stack.Limits.RateLimits[0]; got.Server != "github" || got.CallsPerMinute != 30 || got.Burst != 10 {
 		t.Errorf("rate_limits[0] = %+v", got)
 	}
+	if got := stack.Limits.Schedules[0]; got.Tool != "github__delete_repo" || len(got.Days) != 5 || got.Hours != "09:00-17:00" {
+		t.Errorf("schedules[0] = %+v", got)
+	}
 
 	out, err := yaml.Marshal(&stack)
 	if err != nil {
@@ -260,7 +321,7 @@ limits:
 	if err := yaml.Unmarshal(out, &reparsed); err != nil {
 		t.Fatalf("re-unmarshal: %v", err)
 	}
-	if reparsed.Limits == nil || len(reparsed.Limits.Budgets) != 2 || len(reparsed.Limits.RateLimits) != 1 {
+	if reparsed.Limits == nil || len(reparsed.Limits.Budgets) != 2 || len(reparsed.Limits.RateLimits) != 1 || len(reparsed.Limits.Schedules) != 1 {
 		t.Fatalf("round-trip lost entries: %+v", reparsed.Limits)
 	}
 }
@@ -55,6 +55,9 @@ func LoadStack(path string, opts ...LoadOption) (*Stack, error) {
 	if err := resolveExtends(&stack, absPath, visited, 0); err != nil {
 		return nil, err
 	}
+	if err := expandUpstreams(&stack); err != nil {
+		return nil, err
+	}
 
 	// Build resolver
 	var resolve Resolver
@@ -178,6 +181,10 @@ func expandStackVars(s *Stack, resolve Resolver) (unresolvedVault []string, empt
 		if s.Gateway.Auth != nil {
 			s.Gateway.Auth.Token = expandField(
 				Consumer{Kind: RefKindGateway, Field: "auth.token"}, s.Gateway.Auth.Token)
+			for i := range s.Gateway.Auth.Keys {
+				s.Gateway.Auth.Keys[i].Key = expandField(
+					Consumer{Kind: RefKindGateway, Field: fmt.Sprintf("auth.keys[%d].key", i)}, s.Gateway.Auth.Keys[i].Key)
+			}
 		}
 	}
 
@@ -231,6 +238,11 @@ func expandStackVars(s *Stack, resolve Resolver) (unresolvedVault []string, empt
 			srv.SSH.IdentityFile = expandField(site("ssh.identityFile"), srv.SSH.IdentityFile)
 			srv.SSH.KnownHostsFile = expandField(site("ssh.knownHostsFile"), srv.SSH.KnownHostsFile)
 			srv.SSH.JumpHost = expandField(site("ssh.jumpHost"), srv.SSH.JumpHost)
+			if d := srv.SSH.Deploy; d != nil {
+				d.Binary = expandField(site("ssh.deploy.binary"), d.Binary)
+				d.Path = expandField(site("ssh.deploy.path"), d.Path)
+				d.Image = expandField(site("ssh.deploy.image"), d.Image)
+			}
 		}
 
 		if srv.OpenAPI != nil {
@@ -281,6 +293,9 @@ func resolveRelativePaths(s *Stack, basePath string) {
 		if s.MCPServers[i].SSH != nil && s.MCPServers[i].SSH.KnownHostsFile != "" {
 			s.MCPServers[i].SSH.KnownHostsFile = expandTildeAndResolvePath(s.MCPServers[i].SSH.KnownHostsFile, basePath)
 		}
+		if s.MCPServers[i].SSH != nil && s.MCPServers[i].SSH.Deploy != nil && s.MCPServers[i].SSH.Deploy.Binary != "" {
+			s.MCPServers[i].SSH.Deploy.Binary = expandTildeAndResolvePath(s.MCPServers[i].SSH.Deploy.Binary, basePath)
+		}
 
 		// Resolve source.auth.ssh_key_path (mirrors SSH.IdentityFile handling).
 		if s.MCPServers[i].Source != nil && s.MCPServers[i].Source.Auth != nil && s.MCPServers[i].Source.Auth.SSHKeyPath != "" {
@@ -379,7 +394,7 @@ func resolveExtends(child *Stack, childAbsPath string, visited map[string]bool,
 
 // mergeStacks merges parent into child using child-wins semantics:
 //   - MCPServers and Resources: child entries kept as-is; parent-only entries appended
-//   - Gateway, Logging, Secrets, Network/Networks: inherited from parent when child omits them
+//   - Gateway, Logging, Secrets, Features, Network/Networks: inherited from parent when child omits them
 func mergeStacks(child, parent *Stack) {
 	// MCPServers: child wins on name collision; parent-only servers appended
 	if len(parent.MCPServers) > 0 {
@@ -407,6 +422,19 @@ func mergeStacks(child, parent *Stack) {
 		}
 	}
 
+	// Upstreams: same merge-by-name algorithm
+	if len(parent.Upstreams) > 0 {
+		childUpstreamNames := make(map[string]bool, len(child.Upstreams))
+		for _, u := range child.Upstreams {
+			childUpstreamNames[u.Name] = true
+		}
+		for _, u := range parent.Upstreams {
+			if !childUpstreamNames[u.Name] {
+				child.Upstreams = append(child.Upstreams, u)
+			}
+		}
+	}
+
 	// Top-level blocks: inherit from parent when child has no value
 	if child.Gateway == nil {
 		child.Gateway = parent.Gateway
@@ -414,9 +442,15 @@ func mergeStacks(child, parent *Stack) {
 	if child.Logging == nil {
 		child.Logging = parent.Logging
 	}
+	if child.Retention == nil {
+		child.Retention = parent.Retention
+	}
 	if child.Secrets == nil {
 		child.Secrets = parent.Secrets
 	}
+	if child.Features == nil {
+		child.Features = parent.Features
+	}
 	if child.Network.Name == "" && len(child.Networks) == 0 {
 		child.Network = parent.Network
 		child.Networks = parent.Networks
@@ -1030,6 +1030,52 @@ mcp-servers:
 	}
 }
 
+func TestLoadStack_Extends_FeaturesInheritance(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "base.yaml"), `
+version: "1"
+name: base
+features: [lazy-start]
+mcp-servers:
+  - name: server1
+    url: https://api.example.com/mcp
+`)
+	writeFile(t, filepath.Join(dir, "child.yaml"), `
+version: "1"
+name: child
+extends: ./base.yaml
+mcp-servers:
+  - name: server2
+    url: https://api2.example.com/mcp
+`)
+	writeFile(t, filepath.Join(dir, "optout.yaml"), `
+version: "1"
+name: optout
+extends: ./base.yaml
+features: []
+mcp-servers:
+  - name: server2
+    url: https://api2.example.com/mcp
+`)
+
+	stack, err := LoadStack(filepath.Join(dir, "child.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stack.Features) != 1 || stack.Features[0] != "lazy-start" {
+		t.Errorf("expected inherited features [lazy-start], got %v", stack.Features)
+	}
+
+	stack, err = LoadStack(filepath.Join(dir, "optout.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stack.Features) != 0 {
+		t.Errorf("an explicit empty list should override the parent, got %v", stack.Features)
+	}
+}
+
 func TestLoadStack_Extends_MultiLevel(t *testing.T) {
 	dir := t.TempDir()
 
@@ -281,7 +281,8 @@ func compareSSH(a, b *SSHConfig) bool {
 	if a == nil || b == nil {
 		return true
 	}
-	return a.Host != b.Host || a.User != b.User || a.Port != b.Port || a.IdentityFile != b.IdentityFile
+	return a.Host != b.Host || a.User != b.User || a.Port != b.Port || a.IdentityFile != b.IdentityFile ||
+		!SSHDeployEqual(a.Deploy, b.Deploy)
 }
 
 func buildSummary(items []DiffItem) string {
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"embed"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// typeSources are the files declaring the stack types. Field descriptions
+// are read from their doc comments so the reference cannot drift from the
+// code it documents.
+//
+//go:embed types.go upstream.go
+var typeSources embed.FS
+
+// Option is one stack YAML option in the generated configuration reference.
+type Option struct {
+	// Path is the option's dotted YAML path. "[]" marks a list element and
+	// "<key>" a map key, e.g. "mcp-servers[].auth.type".
+	Path string `json:"path"`
+	// Type is "string", "int", "number", "bool", "duration", "object", or
+	// "list of"/"map of" one of those.
+	Type string `json:"type"`
+	// Default is the value used when the option is omitted, from the
+	// field's default tag. Empty when there is none or it is the zero value.
+	Default string `json:"default,omitempty"`
+	// Since is the release that introduced the option, from the field's
+	// since tag. Empty for options that predate the tag.
+	Since string `json:"since,omitempty"`
+	// Description is the field's doc comment.
+	Description string `json:"description,omitempty"`
+}
+
+// Reference walks the Stack type and returns every YAML option it accepts,
+// in declaration order. Paths, types, defaults, and since-versions come
+// from the struct fields and their tags; descriptions come from the fields'
+// doc comments. Fields tagged yaml:"-" are skipped.
+func Reference() ([]Option, error) {
+	docs, err := fieldDocs()
+	if err != nil {
+		return nil, err
+	}
+	var opts []Option
+	walkOptions(reflect.TypeFor[Stack](), "", docs, map[reflect.Type]bool{}, &opts)
+	return opts, nil
+}
+
+// walkOptions appends the options of struct type t under prefix. seen guards
+// against recursive types.
+func walkOptions(t reflect.Type, prefix string, docs map[string]string, seen map[reflect.Type]bool, opts *[]Option) {
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, inline := yamlName(f)
+		if name == "-" {
+			continue
+		}
+		if inline {
+			walkOptions(indirect(f.Type), prefix, docs, seen, opts)
+			continue
+		}
+		path := prefix + name
+		*opts = append(*opts, Option{
+			Path:        path,
+			Type:        optionType(f.Type),
+			Default:     f.Tag.Get("default"),
+			Since:       f.Tag.Get("since"),
+			Description: docs[t.Name()+"."+f.Name],
+		})
+
+		// Descend into nested blocks: objects directly, list and map
+		// elements under their "[]" and "<key>" segments.
+		ft := indirect(f.Type)
+		switch ft.Kind() {
+		case reflect.Struct:
+			if ft != reflect.TypeFor[time.Time]() {
+				walkOptions(ft, path+".", docs, seen, opts)
+			}
+		case reflect.Slice:
+			if elem := indirect(ft.Elem()); elem.Kind() == reflect.Struct {
+				walkOptions(elem, path+"[].", docs, seen, opts)
+			}
+		case reflect.Map:
+			if elem := indirect(ft.Elem()); elem.Kind() == reflect.Struct {
+				walkOptions(elem, path+".<key>.", docs, seen, opts)
+			}
+		}
+	}
+}
+
+// yamlName returns the YAML key for a field the way yaml.v3 derives it, and
+// whether the field is inlined into its parent.
+func yamlName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("yaml")
+	name, flags, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return "-", false
+	}
+	if strings.Contains(","+flags+",", ",inline,") {
+		return "", true
+	}
+	if name == "" {
+		name = strings.ToLower(f.Name)
+	}
+	return name, false
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// optionType names a field's YAML type for the reference.
+func optionType(t reflect.Type) string {
+	t = indirect(t)
+	if t == reflect.TypeFor[time.Duration]() {
+		return "duration"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Struct:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "list of " + optionType(t.Elem())
+	case reflect.Map:
+		return "map of " + optionType(t.Elem())
+	default:
+		return "any"
+	}
+}
+
+// fieldDocs parses the embedded type sources and returns each struct
+// field's doc comment (or trailing line comment) keyed by "Type.Field",
+// flattened to one line.
+func fieldDocs() (map[string]string, error) {
+	entries, err := typeSources.ReadDir(".")
+	if err != nil {
+		return nil, err
+	}
+	docs := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		src, err := typeSources.ReadFile(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, e.Name(), src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			for _, field := range st.Fields.List {
+				text := field.Doc.Text()
+				if text == "" {
+					text = field.Comment.Text()
+				}
+				text = strings.Join(strings.Fields(text), " ")
+				if text == "" {
+					continue
+				}
+				names := field.Names
+				if len(names) == 0 {
+					// Embedded field: keyed by its type name.
+					if id, ok := field.Type.(*ast.Ident); ok {
+						names = []*ast.Ident{id}
+					}
+				}
+				for _, id := range names {
+					docs[spec.Name.Name+"."+id.Name] = text
+				}
+			}
+			return false
+		})
+	}
+	return docs, nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestReference_AllDescribed keeps every stack option documented: a field
+// added without a doc comment shows up in 'gridctl config docs' with an empty
+// description.
+func TestReference_AllDescribed(t *testing.T) {
+	opts, err := Reference()
+	if err != nil {
+		t.Fatalf("Reference() error: %v", err)
+	}
+	for _, o := range opts {
+		if o.Description == "" {
+			t.Errorf("%s: missing description; add a doc comment to its field", o.Path)
+		}
+	}
+}
+
+func TestReference_Options(t *testing.T) {
+	opts, err := Reference()
+	if err != nil {
+		t.Fatalf("Reference() error: %v", err)
+	}
+	byPath := make(map[string]Option, len(opts))
+	for _, o := range opts {
+		if _, dup := byPath[o.Path]; dup {
+			t.Errorf("duplicate option path %q", o.Path)
+		}
+		byPath[o.Path] = o
+	}
+
+	tests := []struct {
+		path, typ, def string
+		described      bool
+	}{
+		{path: "version", typ: "string", def: "1"},
+		{path: "mcp-servers", typ: "list of object"},
+		{path: "mcp-servers[].replicas", typ: "int", def: "1", described: true},
+		{path: "mcp-servers[].env", typ: "map of string"},
+		{path: "gateway.tracing.enabled", typ: "bool", def: "true", described: true},
+		{path: "gateway.analytics.sample_rate", typ: "number", def: "1.0", described: true},
+		{path: "gateway.auth.keys[].name", typ: "string", described: true},
+		{path: "groups.<key>.tools", typ: "list of string"},
+		// Inlined LogOutputConfig fields surface on logging directly.
+		{path: "logging.format", typ: "string", described: true},
+		{path: "logging.console.timezone", typ: "string", def: "Local", described: true},
+	}
+	for _, tt := range tests {
+		o, ok := byPath[tt.path]
+		if !ok {
+			t.Errorf("option %q missing", tt.path)
+			continue
+		}
+		if o.Type != tt.typ {
+			t.Errorf("%s: type = %q, want %q", tt.path, o.Type, tt.typ)
+		}
+		if o.Default != tt.def {
+			t.Errorf("%s: default = %q, want %q", tt.path, o.Default, tt.def)
+		}
+		if tt.described && o.Description == "" {
+			t.Errorf("%s: missing description", tt.path)
+		}
+		if strings.Contains(o.Description, "\n") {
+			t.Errorf("%s: description should be one line: %q", tt.path, o.Description)
+		}
+	}
+
+	for _, skipped := range []string{"references", "logging.logoutputconfig"} {
+		if _, ok := byPath[skipped]; ok {
+			t.Errorf("option %q should not be listed", skipped)
+		}
+	}
+}
@@ -2,26 +2,37 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
 // Stack represents the complete gridctl configuration.
 type Stack struct {
-	Version    string                 `yaml:"version"`
-	Name       string                 `yaml:"name"`
-	Extends    string                 `yaml:"extends,omitempty"` // Path to a parent stack file for composition
-	Gateway    *GatewayConfig         `yaml:"gateway,omitempty"`
-	Logging    *LoggingConfig         `yaml:"logging,omitempty"`
-	Telemetry  *TelemetryConfig       `yaml:"telemetry,omitempty"` // Opt-in disk persistence for logs/metrics/traces
-	Secrets    *Secrets               `yaml:"secrets,omitempty"`   // Variable set references
-	Network    Network                `yaml:"network"`             // Single network (simple mode)
-	Networks   []Network              `yaml:"networks,omitempty"`  // Multiple networks (advanced mode)
-	MCPServers []MCPServer            `yaml:"mcp-servers"`
-	Resources  []Resource             `yaml:"resources,omitempty"`
+	Version    string                 `yaml:"version" default:"1"`                      // Stack file format version
+	Name       string                 `yaml:"name"`                                     // Stack name; keys the daemon state and log files and the default network name (<name>-net)
+	Extends    string                 `yaml:"extends,omitempty"`                        // Path to a parent stack file for composition
+	Gateway    *GatewayConfig         `yaml:"gateway,omitempty"`                        // Gateway listener, auth, and policy settings
+	Logging    *LoggingConfig         `yaml:"logging,omitempty"`                        // Log file output and rotation
+	Telemetry  *TelemetryConfig       `yaml:"telemetry,omitempty"`                      // Opt-in disk persistence for logs/metrics/traces
+	Secrets    *Secrets               `yaml:"secrets,omitempty"`                        // Variable set references
+	Network    Network                `yaml:"network"`                                  // Single network (simple mode)
+	Networks   []Network              `yaml:"networks,omitempty"`                       // Multiple networks (advanced mode)
+	MCPServers []MCPServer            `yaml:"mcp-servers"`                              // MCP servers the gateway aggregates
+	Resources  []Resource             `yaml:"resources,omitempty"`                      // Supporting containers (databases, caches) started alongside the servers
 	Clients    *ClientsConfig         `yaml:"clients,omitempty"`                        // Optional per-client access scoping (NetworkPolicy semantics)
 	Limits     *LimitsConfig          `yaml:"limits,omitempty" json:"limits,omitempty"` // Optional budgets and rate limits enforced at dispatch
 	Groups     map[string]GroupConfig `yaml:"groups,omitempty" json:"groups,omitempty"` // Optional named tool bundles, each at /groups/{name}/mcp
 
+	// Upstreams federates other gridctl gateways into this one. Loading
+	// expands each entry into an external MCP server (see Upstream), so it
+	// is always empty on a loaded stack.
+	Upstreams []Upstream `yaml:"upstreams,omitempty" json:"upstreams,omitempty"`
+
+	// Retention limits the history gridctl keeps on disk (request
+	// recordings, crash reports, backups). A background janitor enforces
+	// it; nil runs no janitor.
+	Retention *HistoryRetentionConfig `yaml:"retention,omitempty" json:"retention,omitempty"`
+
 	// ClientModels declares which model each connecting client runs, purely
 	// for cost attribution: tool calls from a declared client are priced at
 	// that model's rates ahead of any per-server model or gateway
@@ -32,6 +43,11 @@ type Stack struct {
 	// Empty (the default) disables the client pricing tier.
 	ClientModels map[string]string `yaml:"client_models,omitempty" json:"client_models,omitempty"`
 
+	// Features switches on experimental capabilities by flag name for this
+	// deployment (see pkg/features). GRIDCTL_FEATURES overrides the list at
+	// startup. Names no subsystem registers are warned about and ignored.
+	Features []string `yaml:"features,omitempty" json:"features,omitempty"`
+
 	// References is the variable-usage index, derived during expandStackVars:
 	// which consumers reference each ${var:KEY}/${vault:KEY} key. It is computed
 	// from the stack, not persisted with it — the yaml/json "-" tags keep it out
@@ -54,13 +70,13 @@ type Stack struct {
 // reconciled with the connecting client's normalized identity, so the same
 // string keys configuration, enforcement, and the Stack view.
 //
-// Scope coverage for v1 is tools only: skills (served as MCP prompts) and
-// resources remain globally visible. This is an explicit, documented decision;
-// extending scope to prompts/resources is deferred.
+// A profile's Skills allow-list scopes the registry skills served as MCP
+// prompts and skills:// resources. Skill scoping only narrows: clients that
+// match no profile, and profiles without skills, still see every active skill.
 type ClientsConfig struct {
 	// Default is the policy for clients that match no profile: "deny" (the
 	// default when empty) or "allow".
-	Default string `yaml:"default,omitempty"`
+	Default string `yaml:"default,omitempty" default:"deny"`
 	// Profiles maps a stable client identifier to its access allow-list.
 	Profiles map[string]ClientProfile `yaml:"profiles,omitempty"`
 }
@@ -79,6 +95,9 @@ type ClientProfile struct {
 	// Tools is an allow-list of prefixed tool names. Empty means all tools
 	// within the allowed servers.
 	Tools []string `yaml:"tools,omitempty"`
+	// Skills is an allow-list of registry skill names served to this client
+	// as prompts and resources. Empty means all active skills.
+	Skills []string `yaml:"skills,omitempty"`
 }
 
 // LimitsConfig is the optional top-level `limits:` block: declarative budget
@@ -94,8 +113,9 @@ type ClientProfile struct {
 // records tokens but no dollars, so it spends outside every budget's sight.
 // Rate limits need no pricing and are the recommended backstop.
 type LimitsConfig struct {
-	Budgets    []BudgetLimit `yaml:"budgets,omitempty" json:"budgets,omitempty"`
-	RateLimits []RateLimit   `yaml:"rate_limits,omitempty" json:"rate_limits,omitempty"`
+	Budgets    []BudgetLimit   `yaml:"budgets,omitempty" json:"budgets,omitempty"`         // Dollar spend caps per calendar window
+	RateLimits []RateLimit     `yaml:"rate_limits,omitempty" json:"rate_limits,omitempty"` // Call rate caps (token bucket)
+	Schedules  []ScheduleLimit `yaml:"schedules,omitempty" json:"schedules,omitempty"`     // Time windows outside which calls are denied
 }
 
 // BudgetLimit caps attributed dollar spend for one scope over a calendar
@@ -105,9 +125,9 @@ type LimitsConfig struct {
 // in-flight calls can overshoot the cap by their own cost; the next call
 // after the cap is reached is denied.
 type BudgetLimit struct {
-	Client string `yaml:"client,omitempty" json:"client,omitempty"`
-	Server string `yaml:"server,omitempty" json:"server,omitempty"`
-	Tool   string `yaml:"tool,omitempty" json:"tool,omitempty"`
+	Client string `yaml:"client,omitempty" json:"client,omitempty"` // Scope: a stable client identifier
+	Server string `yaml:"server,omitempty" json:"server,omitempty"` // Scope: a stack server name
+	Tool   string `yaml:"tool,omitempty" json:"tool,omitempty"`     // Scope: a prefixed tool name ("server__tool")
 	// MaxUSD is the cap for the window, in dollars. Must be positive.
 	MaxUSD float64 `yaml:"max_usd" json:"max_usd"`
 	// Period is "daily", "weekly", or "monthly".
@@ -121,15 +141,32 @@ type BudgetLimit struct {
 // capacity: how many calls may land at once before the sustained rate
 // applies. Zero means a default of max(5, calls_per_minute/6).
 type RateLimit struct {
-	Client string `yaml:"client,omitempty" json:"client,omitempty"`
-	Server string `yaml:"server,omitempty" json:"server,omitempty"`
-	Tool   string `yaml:"tool,omitempty" json:"tool,omitempty"`
+	Client string `yaml:"client,omitempty" json:"client,omitempty"` // Scope: a stable client identifier
+	Server string `yaml:"server,omitempty" json:"server,omitempty"` // Scope: a stack server name
+	Tool   string `yaml:"tool,omitempty" json:"tool,omitempty"`     // Scope: a prefixed tool name ("server__tool")
 	// CallsPerMinute is the sustained rate. Must be positive.
 	CallsPerMinute int `yaml:"calls_per_minute" json:"calls_per_minute"`
 	// Burst is the bucket capacity; 0 selects the default.
 	Burst int `yaml:"burst,omitempty" json:"burst,omitempty"`
 }
 
+// ScheduleLimit is a time window outside which calls in its scope are
+// denied, in the daemon's local timezone. Days and Hours both narrow the
+// window; at least one must be set. An Hours range whose end is not after
+// its start wraps past midnight ("22:00-06:00"), and Days then names the day
+// the window opens on.
+type ScheduleLimit struct {
+	Client string `yaml:"client,omitempty" json:"client,omitempty"` // Scope: a stable client identifier
+	Server string `yaml:"server,omitempty" json:"server,omitempty"` // Scope: a stack server name
+	Tool   string `yaml:"tool,omitempty" json:"tool,omitempty"`     // Scope: a prefixed tool name ("server__tool")
+	// Days lists the weekdays calls are allowed on ("mon".."sun"). Empty
+	// means every day.
+	Days []string `yaml:"days,omitempty" json:"days,omitempty"`
+	// Hours is the allowed time of day as "HH:MM-HH:MM", end exclusive.
+	// Empty means all day.
+	Hours string `yaml:"hours,omitempty" json:"hours,omitempty"`
+}
+
 // GroupConfig is one entry of the optional top-level `groups:` block: a
 // named cross-server tool bundle served at its own MCP endpoint
 // (/groups/{name}/mcp). Groups are the curation axis; per-client scoping
@@ -138,7 +175,7 @@ type RateLimit struct {
 // (exclusion always last). Omitting the whole block preserves legacy
 // behavior (Article IX): no group endpoints exist and /mcp is unchanged.
 type GroupConfig struct {
-	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"` // Human-readable summary of the bundle
 	// Servers includes every tool of the named stack servers.
 	Servers []string `yaml:"servers,omitempty" json:"servers,omitempty"`
 	// Tools includes specific prefixed tool names ("github__create_issue").
@@ -163,10 +200,10 @@ type GroupOverride struct {
 	// Description replaces the tool's description verbatim. Empty keeps
 	// the original.
 	Description     string `yaml:"description,omitempty" json:"description,omitempty"`
-	ReadOnlyHint    *bool  `yaml:"read_only_hint,omitempty" json:"read_only_hint,omitempty"`
-	DestructiveHint *bool  `yaml:"destructive_hint,omitempty" json:"destructive_hint,omitempty"`
-	IdempotentHint  *bool  `yaml:"idempotent_hint,omitempty" json:"idempotent_hint,omitempty"`
-	OpenWorldHint   *bool  `yaml:"open_world_hint,omitempty" json:"open_world_hint,omitempty"`
+	ReadOnlyHint    *bool  `yaml:"read_only_hint,omitempty" json:"read_only_hint,omitempty"`     // Sets the tool's readOnlyHint annotation; unset passes the server's through
+	DestructiveHint *bool  `yaml:"destructive_hint,omitempty" json:"destructive_hint,omitempty"` // Sets the tool's destructiveHint annotation; unset passes the server's through
+	IdempotentHint  *bool  `yaml:"idempotent_hint,omitempty" json:"idempotent_hint,omitempty"`   // Sets the tool's idempotentHint annotation; unset passes the server's through
+	OpenWorldHint   *bool  `yaml:"open_world_hint,omitempty" json:"open_world_hint,omitempty"`   // Sets the tool's openWorldHint annotation; unset passes the server's through
 }
 
 // limitScopeKey returns the entry's scope kind ("client", "server", or
@@ -200,17 +237,97 @@ func (r RateLimit) ScopeKey() (kind, key string, ok bool) {
 	return limitScopeKey(r.Client, r.Server, r.Tool)
 }
 
+// ScopeKey returns the schedule's scope kind and key; ok=false when the
+// entry does not set exactly one of client/server/tool.
+func (s ScheduleLimit) ScopeKey() (kind, key string, ok bool) {
+	return limitScopeKey(s.Client, s.Server, s.Tool)
+}
+
+// scheduleWeekdays maps the accepted day names to time.Weekday.
+var scheduleWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// AllowedDays returns which weekdays the schedule allows, indexed by
+// time.Weekday. Empty Days allows every day. Day names are case-insensitive.
+func (s ScheduleLimit) AllowedDays() ([7]bool, error) {
+	var days [7]bool
+	if len(s.Days) == 0 {
+		for i := range days {
+			days[i] = true
+		}
+		return days, nil
+	}
+	for _, name := range s.Days {
+		wd, ok := scheduleWeekdays[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return days, fmt.Errorf("unknown day %q (want mon, tue, wed, thu, fri, sat, or sun)", name)
+		}
+		days[wd] = true
+	}
+	return days, nil
+}
+
+// AllowedHours returns the allowed time-of-day range as offsets from
+// midnight. Empty Hours returns 0 and 24h. An end not after the start means
+// the range wraps past midnight.
+func (s ScheduleLimit) AllowedHours() (start, end time.Duration, err error) {
+	if s.Hours == "" {
+		return 0, 24 * time.Hour, nil
+	}
+	from, to, ok := strings.Cut(s.Hours, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid hours %q (want HH:MM-HH:MM)", s.Hours)
+	}
+	if start, err = parseClock(from); err != nil {
+		return 0, 0, fmt.Errorf("invalid hours %q: %w", s.Hours, err)
+	}
+	if end, err = parseClock(to); err != nil {
+		return 0, 0, fmt.Errorf("invalid hours %q: %w", s.Hours, err)
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("invalid hours %q: start and end are equal", s.Hours)
+	}
+	return start, end, nil
+}
+
+// parseClock parses "HH:MM" (24-hour) into an offset from midnight.
+func parseClock(v string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(v))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", strings.TrimSpace(v))
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
 // LoggingConfig configures log file output with automatic rotation.
 type LoggingConfig struct {
 	// File is the path to the log file. When set, logs are written to both the
 	// in-memory ring buffer (web UI) and this file simultaneously.
 	File string `yaml:"file,omitempty" json:"file,omitempty"`
 	// MaxSizeMB is the maximum log file size in megabytes before rotation (default: 100).
-	MaxSizeMB int `yaml:"maxSizeMB,omitempty" json:"maxSizeMB,omitempty"`
+	MaxSizeMB int `yaml:"maxSizeMB,omitempty" json:"maxSizeMB,omitempty" default:"100"`
 	// MaxAgeDays is the maximum number of days to retain old log files (default: 7).
-	MaxAgeDays int `yaml:"maxAgeDays,omitempty" json:"maxAgeDays,omitempty"`
+	MaxAgeDays int `yaml:"maxAgeDays,omitempty" json:"maxAgeDays,omitempty" default:"7"`
 	// MaxBackups is the maximum number of compressed old log files to keep (default: 3).
-	MaxBackups int `yaml:"maxBackups,omitempty" json:"maxBackups,omitempty"`
+	MaxBackups int `yaml:"maxBackups,omitempty" json:"maxBackups,omitempty" default:"3"`
+	// LogOutputConfig sets the file's format and timestamps (default: JSON
+	// with RFC3339Nano local timestamps).
+	LogOutputConfig `yaml:",inline"`
+	// Console sets the format and timestamps of the daemon's stderr output,
+	// which is captured in its daemon log (default: text).
+	Console *LogOutputConfig `yaml:"console,omitempty" json:"console,omitempty"`
+}
+
+// LogOutputConfig configures how one log output renders entries.
+type LogOutputConfig struct {
+	// Format is json or text.
+	Format string `yaml:"format,omitempty" json:"format,omitempty"`
+	// TimeFormat is rfc3339, rfc3339nano, unix, unixmilli, or a Go time layout.
+	TimeFormat string `yaml:"timeFormat,omitempty" json:"timeFormat,omitempty"`
+	// Timezone is UTC, Local (default), or an IANA zone such as Europe/Berlin.
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty" default:"Local"`
 }
 
 // TelemetryConfig configures opt-in disk persistence for the three signals
@@ -234,9 +351,9 @@ type TelemetryConfig struct {
 // TelemetryPersistence is the stack-global signal toggle. Stack-global is
 // binary (a bool) — the per-server override carries the tri-state.
 type TelemetryPersistence struct {
-	Logs    bool `yaml:"logs,omitempty" json:"logs,omitempty"`
-	Metrics bool `yaml:"metrics,omitempty" json:"metrics,omitempty"`
-	Traces  bool `yaml:"traces,omitempty" json:"traces,omitempty"`
+	Logs    bool `yaml:"logs,omitempty" json:"logs,omitempty"`       // Persist logs to <server>/logs.jsonl
+	Metrics bool `yaml:"metrics,omitempty" json:"metrics,omitempty"` // Persist metrics to <server>/metrics.jsonl
+	Traces  bool `yaml:"traces,omitempty" json:"traces,omitempty"`   // Persist traces to <server>/traces.jsonl as OTLP-JSON
 }
 
 // RetentionConfig controls lumberjack rotation for persisted telemetry files.
@@ -246,9 +363,55 @@ type TelemetryPersistence struct {
 // camelCase, but is closer to a runtime-rotation knob than a control-plane
 // resource).
 type RetentionConfig struct {
-	MaxSizeMB  int `yaml:"max_size_mb,omitempty" json:"max_size_mb,omitempty"`
-	MaxBackups int `yaml:"max_backups,omitempty" json:"max_backups,omitempty"`
-	MaxAgeDays int `yaml:"max_age_days,omitempty" json:"max_age_days,omitempty"`
+	MaxSizeMB  int `yaml:"max_size_mb,omitempty" json:"max_size_mb,omitempty" default:"100"` // Active file size in MB before rotation
+	MaxBackups int `yaml:"max_backups,omitempty" json:"max_backups,omitempty" default:"5"`   // Rotated files kept per signal
+	MaxAgeDays int `yaml:"max_age_days,omitempty" json:"max_age_days,omitempty" default:"7"` // Age in days after which rotated files are deleted
+}
+
+// HistoryRetentionConfig bounds the history gridctl accumulates on disk
+// outside the telemetry files (which rotate per telemetry.retention). Each
+// category is swept only when its policy is set; without a retention: block
+// nothing is deleted beyond the built-in caps (three client config backups,
+// fifty crash reports).
+type HistoryRetentionConfig struct {
+	// Interval is how often the janitor sweeps, as a Go duration (default: 1h).
+	Interval string `yaml:"interval,omitempty" json:"interval,omitempty" default:"1h"`
+	// RequestLogs covers recorded request sessions (gateway.record_requests).
+	RequestLogs *HistoryRetentionPolicy `yaml:"request_logs,omitempty" json:"request_logs,omitempty"`
+	// DaemonLogs covers daemon logs of stacks that are not running.
+	DaemonLogs *HistoryRetentionPolicy `yaml:"daemon_logs,omitempty" json:"daemon_logs,omitempty"`
+	// CrashReports covers daemon crash reports.
+	CrashReports *HistoryRetentionPolicy `yaml:"crash_reports,omitempty" json:"crash_reports,omitempty"`
+	// SkillBackups covers the SKILL.md.pre-* copies kept when skill updates
+	// replace local edits; limits apply per skill.
+	SkillBackups *HistoryRetentionPolicy `yaml:"skill_backups,omitempty" json:"skill_backups,omitempty"`
+	// ClientBackups covers the backups taken when gridctl edits a client
+	// config or the stack file; limits apply per file.
+	ClientBackups *HistoryRetentionPolicy `yaml:"client_backups,omitempty" json:"client_backups,omitempty"`
+}
+
+// HistoryRetentionPolicy limits one category. Zero fields are unlimited.
+type HistoryRetentionPolicy struct {
+	MaxAgeDays int `yaml:"max_age_days,omitempty" json:"max_age_days,omitempty"` // Delete files last modified more than this many days ago
+	MaxSizeMB  int `yaml:"max_size_mb,omitempty" json:"max_size_mb,omitempty"`   // Keep at most this many megabytes, deleting the oldest files first
+	Keep       int `yaml:"keep,omitempty" json:"keep,omitempty"`                 // Keep at most this many files, deleting the oldest first
+}
+
+// DefaultHistoryRetentionInterval is the janitor's sweep interval when
+// retention.interval is unset.
+const DefaultHistoryRetentionInterval = time.Hour
+
+// SweepInterval returns the parsed interval, or the default when unset or
+// invalid (validation reports the latter).
+func (r *HistoryRetentionConfig) SweepInterval() time.Duration {
+	if r == nil || r.Interval == "" {
+		return DefaultHistoryRetentionInterval
+	}
+	d, err := time.ParseDuration(r.Interval)
+	if err != nil || d <= 0 {
+		return DefaultHistoryRetentionInterval
+	}
+	return d
 }
 
 // MCPServerTelemetry holds per-server telemetry persistence overrides. Each
@@ -257,19 +420,53 @@ type RetentionConfig struct {
 // global). Never default these to &false in SetDefaults — that would collapse
 // inherit and explicit-off into the same value.
 type MCPServerTelemetry struct {
-	Persist MCPServerPersistence `yaml:"persist,omitempty" json:"persist,omitempty"`
+	Persist MCPServerPersistence `yaml:"persist,omitempty" json:"persist,omitempty"` // Per-signal overrides of telemetry.persist
 }
 
 // MCPServerPersistence is the *bool tri-state mirror of TelemetryPersistence.
 type MCPServerPersistence struct {
-	Logs    *bool `yaml:"logs,omitempty" json:"logs,omitempty"`
-	Metrics *bool `yaml:"metrics,omitempty" json:"metrics,omitempty"`
-	Traces  *bool `yaml:"traces,omitempty" json:"traces,omitempty"`
+	Logs    *bool `yaml:"logs,omitempty" json:"logs,omitempty"`       // Persist this server's logs; unset inherits telemetry.persist.logs
+	Metrics *bool `yaml:"metrics,omitempty" json:"metrics,omitempty"` // Persist this server's metrics; unset inherits telemetry.persist.metrics
+	Traces  *bool `yaml:"traces,omitempty" json:"traces,omitempty"`   // Persist this server's traces; unset inherits telemetry.persist.traces
 }
 
 // Secrets configures automatic secret injection from variable sets.
 type Secrets struct {
-	Sets []string `yaml:"sets,omitempty" json:"sets,omitempty"`
+	Sets []string `yaml:"sets,omitempty" json:"sets,omitempty"` // Variable sets injected into every server's env; explicit env values win
+}
+
+// Analytics modes.
+const (
+	AnalyticsModeFull      = "full"
+	AnalyticsModeAggregate = "aggregate"
+)
+
+// AnalyticsConfig selects how much per-call detail usage analytics keep.
+type AnalyticsConfig struct {
+	// Mode is "full" (default) or "aggregate". Aggregate keeps only counts,
+	// token and cost totals, and latencies: tracing is turned off, so no
+	// per-call record (client, timing, trace) is stored or exported, and
+	// request recording and the tool call audit log are refused.
+	Mode string `yaml:"mode,omitempty" json:"mode,omitempty" default:"full"`
+	// SampleRate is the fraction of tool calls, in (0, 1], whose latency
+	// enters the per-tool percentile window. Call and error counts and the
+	// maximum stay exact. Default: 1.0.
+	SampleRate float64 `yaml:"sample_rate,omitempty" json:"sample_rate,omitempty" default:"1.0"`
+}
+
+// AggregateOnly reports whether analytics are restricted to aggregates.
+func (a *AnalyticsConfig) AggregateOnly() bool {
+	return a != nil && a.Mode == AnalyticsModeAggregate
+}
+
+// ProvenanceConfig controls provenance metadata on tool results.
+type ProvenanceConfig struct {
+	// Enabled turns provenance stamping on. Default: false.
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	// GatewayID identifies this gateway in provenance records, so results
+	// from several gateways can be told apart downstream. Default: the
+	// stack name.
+	GatewayID string `yaml:"gateway_id,omitempty" json:"gateway_id,omitempty"`
 }
 
 // TracingConfig configures distributed tracing for the gateway.
@@ -278,17 +475,17 @@ type TracingConfig struct {
 	// A pointer so an omitted `enabled:` inherits the default-on behavior
 	// rather than YAML's zero value (false); set it explicitly to false to
 	// disable tracing.
-	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty" default:"true"`
 	// Sampling is the head-based sampling rate [0.0, 1.0]. Default: 1.0.
-	Sampling float64 `yaml:"sampling,omitempty" json:"sampling,omitempty"`
+	Sampling float64 `yaml:"sampling,omitempty" json:"sampling,omitempty" default:"1.0"`
 	// Retention is how long completed traces are kept in memory (e.g. "24h"). Default: "24h".
-	Retention string `yaml:"retention,omitempty" json:"retention,omitempty"`
+	Retention string `yaml:"retention,omitempty" json:"retention,omitempty" default:"24h"`
 	// Export selects an exporter: "otlp" or "" (none).
 	Export string `yaml:"export,omitempty" json:"export,omitempty"`
 	// Endpoint is the OTLP endpoint URL (e.g. "http://localhost:4318").
 	Endpoint string `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
 	// MaxTraces is the in-memory ring buffer capacity (number of traces). Default: 1000.
-	MaxTraces int `yaml:"max_traces,omitempty" json:"max_traces,omitempty"`
+	MaxTraces int `yaml:"max_traces,omitempty" json:"max_traces,omitempty" default:"1000"`
 }
 
 // GatewayConfig holds optional gateway-level configuration.
@@ -298,31 +495,84 @@ type GatewayConfig struct {
 	// Copilot) display this value rather than the entry key from their own
 	// config file, so distinct gateways need distinct names to be told apart.
 	// Empty keeps the default "gridctl-gateway".
-	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty" default:"gridctl-gateway"`
 
 	// AllowedOrigins lists origins for CORS.
 	// When not set, defaults to ["*"] (allow all) for backward compatibility.
 	// Set explicit origins to restrict cross-origin access.
-	AllowedOrigins []string    `yaml:"allowed_origins,omitempty"`
-	Auth           *AuthConfig `yaml:"auth,omitempty"`
+	AllowedOrigins []string    `yaml:"allowed_origins,omitempty" default:"[\"*\"]"`
+	Auth           *AuthConfig `yaml:"auth,omitempty"` // Token or API keys required on every request except /health and /ready
+
+	// Advertise announces the gateway on the local network over mDNS
+	// (service type _gridctl._tcp) with its stack name, port, and version,
+	// so 'gridctl discover' on another machine can find it. Default: false.
+	Advertise bool `yaml:"advertise,omitempty" json:"advertise,omitempty"`
+
+	// BasePath mounts the gateway (web UI, REST API, and MCP endpoints)
+	// under a path prefix such as "/gridctl", for reverse proxies that
+	// forward a sub-path. Requests without the prefix are still served, so
+	// proxies that strip it work too. Empty mounts at the root.
+	BasePath string `yaml:"base_path,omitempty" json:"base_path,omitempty"`
 
 	// CodeMode controls whether the gateway replaces individual tool definitions
 	// with two meta-tools (search + execute). Values: "off" (default), "on".
 	// Experimental: may change without notice.
-	CodeMode string `yaml:"code_mode,omitempty"`
+	CodeMode string `yaml:"code_mode,omitempty" default:"off"`
 	// CodeModeTimeout is the execution timeout in seconds (default: 30).
 	// Experimental: may change without notice.
-	CodeModeTimeout int `yaml:"code_mode_timeout,omitempty"`
+	CodeModeTimeout int `yaml:"code_mode_timeout,omitempty" default:"30"`
 
 	// OutputFormat sets the default output format for tool call results.
 	// Values: "json" (default), "toon", "csv", "text".
 	// Per-server output_format overrides this value.
-	OutputFormat string `yaml:"output_format,omitempty"`
+	OutputFormat string `yaml:"output_format,omitempty" default:"json"`
 
 	// MaxToolResultBytes sets the maximum size of a tool result in bytes before truncation.
 	// Results exceeding this limit are truncated with a suffix indicating the original size.
 	// Default: 65536 (64KB). Set to 0 to use the default.
-	MaxToolResultBytes int `yaml:"maxToolResultBytes,omitempty" json:"maxToolResultBytes,omitempty"`
+	MaxToolResultBytes int `yaml:"maxToolResultBytes,omitempty" json:"maxToolResultBytes,omitempty" default:"65536"`
+
+	// RepairToolSchemas lets the gateway fix trivially broken downstream
+	// tool input schemas before advertising them: a missing schema becomes
+	// {"type": "object"} and an object schema without "type" gains it.
+	// Problems are reported in /api/status either way. Default: false.
+	RepairToolSchemas bool `yaml:"repair_tool_schemas,omitempty" json:"repair_tool_schemas,omitempty"`
+
+	// ValidateToolArguments checks tools/call arguments against the tool's
+	// input schema at the gateway and rejects non-conforming calls with a
+	// structured error before they reach the server. Individual servers opt
+	// out with validate_arguments: false. Default: false.
+	ValidateToolArguments bool `yaml:"validate_tool_arguments,omitempty" json:"validate_tool_arguments,omitempty"`
+
+	// ContextBudgetTokens is the per-client ceiling, in estimated tokens,
+	// on the tools/list and prompts/list payloads a client receives after
+	// its access scope is applied. Deploy warns about clients over it.
+	// Default: 0 (no check).
+	ContextBudgetTokens int `yaml:"context_budget_tokens,omitempty" json:"context_budget_tokens,omitempty"`
+
+	// RecordRequests writes every inbound MCP request, redacted, to one
+	// JSONL file per session under ~/.gridctl/requests/<stack>/, for
+	// replay with 'gridctl replay' after an upgrade. Default: false.
+	RecordRequests bool `yaml:"record_requests,omitempty" json:"record_requests,omitempty"`
+
+	// ApprovalTimeout is how long a call to a tool listed in a server's
+	// require_approval waits for a human decision before it is rejected.
+	// Accepts any time.Duration string (e.g. "10m"). Default: 5m.
+	ApprovalTimeout string `yaml:"approval_timeout,omitempty" json:"approval_timeout,omitempty" default:"5m"`
+
+	// AuditToolCalls logs one structured "tool call audit" line per
+	// tools/call: tool, server, client, argument names (never values),
+	// duration, and outcome. Default: false.
+	AuditToolCalls bool `yaml:"audit_tool_calls,omitempty" json:"audit_tool_calls,omitempty"`
+
+	// Analytics controls how much per-call detail the gateway keeps for
+	// usage analytics. When nil, full detail is kept.
+	Analytics *AnalyticsConfig `yaml:"analytics,omitempty" json:"analytics,omitempty"`
+
+	// Provenance attaches origin metadata (server, tool, timestamp, gateway
+	// id, run id) to every tool result's _meta. When nil, results are
+	// forwarded without it.
+	Provenance *ProvenanceConfig `yaml:"provenance,omitempty" json:"provenance,omitempty"`
 
 	// Tracing configures distributed tracing. When nil, tracing is enabled with defaults.
 	Tracing *TracingConfig `yaml:"tracing,omitempty" json:"tracing,omitempty"`
@@ -341,7 +591,7 @@ type GatewayConfig struct {
 	// Values: "embedded" (default) uses the cl100k_base BPE vocabulary (pure Go, no network).
 	// "api" uses Anthropic's count_tokens endpoint for exact counts — Anthropic-specific,
 	// requires network access and an API key, wrong for non-Anthropic model routing.
-	Tokenizer string `yaml:"tokenizer,omitempty"`
+	Tokenizer string `yaml:"tokenizer,omitempty" default:"embedded"`
 	// TokenizerAPIKey overrides ANTHROPIC_API_KEY for the api tokenizer mode.
 	// When unset, the api tokenizer falls back to the ANTHROPIC_API_KEY environment variable.
 	TokenizerAPIKey string `yaml:"tokenizer_api_key,omitempty"`
@@ -359,15 +609,15 @@ type SchemaPinningConfig struct {
 	// A pointer so an omitted `enabled:` inherits the default-on behavior
 	// rather than YAML's zero value (false); set it explicitly to false to
 	// disable pinning for the whole stack.
-	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty" default:"true"`
 	// Action is the response when drift is detected: "warn" (default) or "block".
 	// warn: log a structured diff and continue serving.
 	// block: reject all tool calls from the drifted server until approved.
-	Action string `yaml:"action,omitempty" json:"action,omitempty"`
+	Action string `yaml:"action,omitempty" json:"action,omitempty" default:"warn"`
 	// Scan controls the poisoning heuristics run over tool definitions at
 	// pin and drift time. Default: true. Findings are always advisory; this
 	// toggle never affects hashing, drift detection, or the approve flow.
-	Scan *bool `yaml:"scan,omitempty" json:"scan,omitempty"`
+	Scan *bool `yaml:"scan,omitempty" json:"scan,omitempty" default:"true"`
 	// ScanIgnore suppresses scan findings by code (e.g. ["P004"]). Useful
 	// for silencing a heuristic that false-positives on a legitimate stack.
 	ScanIgnore []string `yaml:"scan_ignore,omitempty" json:"scan_ignore,omitempty"`
@@ -379,53 +629,126 @@ type AuthConfig struct {
 	// Type is the auth mechanism: "bearer" or "api_key".
 	Type string `yaml:"type"`
 	// Token is the expected token value (supports env var references via $VAR or ${VAR}).
-	Token string `yaml:"token"`
+	// Optional when Keys are set; requests using it carry no client identity.
+	Token string `yaml:"token,omitempty"`
 	// Header is the header name for api_key auth (default: "Authorization").
-	Header string `yaml:"header,omitempty"`
+	Header string `yaml:"header,omitempty" default:"Authorization"`
+	// Keys are named credentials accepted alongside Token. A request
+	// authenticated by a key takes the key's name as its client identity,
+	// so keys double as agent names under clients:, limits:, and metrics.
+	Keys []AuthKey `yaml:"keys,omitempty"`
+}
+
+// GatewayTokenEnv supplies the gateway credential to gridctl commands that
+// call a running gateway's API when its stack sets gateway.auth.
+const GatewayTokenEnv = "GRIDCTL_GATEWAY_TOKEN"
+
+// CredentialHeader returns the header a client sends token in to pass this
+// gateway.auth: "Authorization: Bearer <token>" for bearer auth, the default
+// (also when a is nil), otherwise the raw token in the configured header.
+func (a *AuthConfig) CredentialHeader(token string) (header, value string) {
+	if a == nil || a.Type == "" || a.Type == "bearer" {
+		return "Authorization", "Bearer " + token
+	}
+	header = a.Header
+	if header == "" {
+		header = "Authorization"
+	}
+	return header, token
+}
+
+// AuthKey is one named gateway credential.
+type AuthKey struct {
+	// Name is the client identity requests with this key act as. Several
+	// keys may share a name, for example during rotation.
+	Name string `yaml:"name"`
+	// Key is the credential value (supports env var references via $VAR or ${VAR}).
+	Key string `yaml:"key"`
 }
 
 // Network defines the Docker network configuration.
 type Network struct {
-	Name   string `yaml:"name"`
-	Driver string `yaml:"driver"`
+	Name   string `yaml:"name"`                    // Network name; in simple mode defaults to <stack name>-net
+	Driver string `yaml:"driver" default:"bridge"` // "bridge", "host", or "none"
 }
 
 // MCPServer defines an MCP server (container-based or external).
 type MCPServer struct {
-	Name         string            `yaml:"name"`
-	Image        string            `yaml:"image,omitempty"`
-	Source       *Source           `yaml:"source,omitempty"`
-	URL          string            `yaml:"url,omitempty"`       // External server URL (no container)
-	Port         int               `yaml:"port,omitempty"`      // For HTTP transport (container-based)
-	Transport    string            `yaml:"transport,omitempty"` // "http" (default), "stdio", or "sse"
-	Command      []string          `yaml:"command,omitempty"`   // Override container command or remote command for SSH
-	Env          map[string]string `yaml:"env,omitempty"`
-	BuildArgs    map[string]string `yaml:"build_args,omitempty"`
-	Network      string            `yaml:"network,omitempty"`       // Network to join (for multi-network mode)
-	SSH          *SSHConfig        `yaml:"ssh,omitempty"`           // SSH connection config for remote servers
-	OpenAPI      *OpenAPIConfig    `yaml:"openapi,omitempty"`       // OpenAPI spec config for API-backed servers
-	Tools        []string          `yaml:"tools,omitempty"`         // Tool whitelist (empty = all tools exposed)
-	OutputFormat string            `yaml:"output_format,omitempty"` // Output format override: "json", "toon", "csv", "text"
-	PinSchemas   *bool             `yaml:"pin_schemas,omitempty"`   // Override gateway schema pinning for this server (nil = inherit)
+	Name         string            `yaml:"name"`                               // Unique server name; prefixes its tools ("{name}__tool")
+	Image        string            `yaml:"image,omitempty"`                    // Container image to run
+	Source       *Source           `yaml:"source,omitempty"`                   // Build the container image from a git or local source instead
+	URL          string            `yaml:"url,omitempty"`                      // External server URL (no container)
+	Port         int               `yaml:"port,omitempty"`                     // For HTTP transport (container-based)
+	Transport    string            `yaml:"transport,omitempty" default:"http"` // "http" (default), "stdio", or "sse"
+	Command      []string          `yaml:"command,omitempty"`                  // Override container command or remote command for SSH
+	Env          map[string]string `yaml:"env,omitempty"`                      // Environment variables for the server process or container
+	BuildArgs    map[string]string `yaml:"build_args,omitempty"`               // Docker build arguments for source builds
+	Network      string            `yaml:"network,omitempty"`                  // Network to join (for multi-network mode)
+	SSH          *SSHConfig        `yaml:"ssh,omitempty"`                      // SSH connection config for remote servers
+	OpenAPI      *OpenAPIConfig    `yaml:"openapi,omitempty"`                  // OpenAPI spec config for API-backed servers
+	Tools        []string          `yaml:"tools,omitempty"`                    // Tool whitelist (empty = all tools exposed)
+	OutputFormat string            `yaml:"output_format,omitempty"`            // Output format override: "json", "toon", "csv", "text"
+	PinSchemas   *bool             `yaml:"pin_schemas,omitempty"`              // Override gateway schema pinning for this server (nil = inherit)
+
+	// ValidateArguments overrides gateway.validate_tool_arguments for this
+	// server; false opts out servers whose schemas are looser in practice
+	// than they declare. nil inherits the gateway setting.
+	ValidateArguments *bool `yaml:"validate_arguments,omitempty" json:"validate_arguments,omitempty"`
+
+	// LenientResponses tolerates common spec violations in this server's
+	// tools/call results (a bare string instead of a content array, a single
+	// content object, untyped content items, isError as a string or spelled
+	// is_error) and normalizes them instead of failing the call.
+	LenientResponses bool `yaml:"lenient_responses,omitempty" json:"lenient_responses,omitempty"`
+
+	// Roots restricts the filesystem roots this server sees when it asks the
+	// gateway for roots/list: absolute paths or file:// URIs. Roots reported
+	// by connected clients are narrowed to these; when no client reports any,
+	// these are the list. Empty forwards every client root unchanged. Paths
+	// are passed through as-is, so for container servers list them as the
+	// container sees them. Not valid on OpenAPI servers.
+	Roots []string `yaml:"roots,omitempty" json:"roots,omitempty"`
+	// Sampling lets this server send sampling/createMessage: the gateway
+	// declares the sampling capability to it and forwards each request to
+	// the client whose tool call triggered it, which runs the completion
+	// with its own model. Default false: the capability is not offered and
+	// such requests are refused. Not valid on OpenAPI servers.
+	Sampling bool `yaml:"sampling,omitempty" json:"sampling,omitempty"`
 	// ReadyTimeout overrides the HTTP/SSE readiness wait for container-based servers.
 	// Accepts any time.Duration string (e.g. "60s", "2m"). Empty/"0" inherits the gateway default (30s).
 	// Ignored for stdio, local process, SSH, OpenAPI, and external transports.
-	ReadyTimeout string `yaml:"ready_timeout,omitempty"`
+	ReadyTimeout string `yaml:"ready_timeout,omitempty" default:"30s"`
 
 	// PingTimeout overrides the per-ping deadline used by the gateway health monitor.
 	// Accepts any time.Duration string (e.g. "10s"). Empty/"0" inherits DefaultPingTimeout (5s).
 	// Tune this for slow upstreams (e.g. HTTP servers with many tools) where the
 	// 5s default can flake under autoscale spawn load.
-	PingTimeout string `yaml:"ping_timeout,omitempty"`
+	PingTimeout string `yaml:"ping_timeout,omitempty" default:"5s"`
+
+	// RequireApproval lists tools (unprefixed names) whose calls are parked
+	// until a human approves them through the API; calls left undecided
+	// past gateway.approval_timeout are rejected.
+	RequireApproval []string `yaml:"require_approval,omitempty" json:"require_approval,omitempty"`
+
+	// Timeout bounds each tool call the gateway dispatches to this server.
+	// Accepts any time.Duration string (e.g. "5s", "2m"). Empty/"0" inherits
+	// the gateway default (30s). Raise it for slow upstreams such as OpenAPI
+	// backends; lower it so local tools fail fast.
+	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" default:"30s"`
+
+	// CircuitBreaker fails tool calls to this server fast after consecutive
+	// failures instead of letting every caller wait out a hung backend.
+	// nil (the default) disables it.
+	CircuitBreaker *CircuitBreakerConfig `yaml:"circuit_breaker,omitempty" json:"circuit_breaker,omitempty"`
 
 	// Replicas is the number of independent processes to spawn for this server.
 	// Defaults to 1. Values >1 load-balance JSON-RPC tool calls across replicas
 	// using ReplicaPolicy. Not supported for external URL or OpenAPI transports.
-	Replicas int `yaml:"replicas,omitempty" json:"replicas,omitempty"`
+	Replicas int `yaml:"replicas,omitempty" json:"replicas,omitempty" default:"1"`
 
 	// ReplicaPolicy selects the dispatch policy when Replicas > 1.
 	// Valid values: "round-robin" (default), "least-connections".
-	ReplicaPolicy string `yaml:"replica_policy,omitempty" json:"replica_policy,omitempty"`
+	ReplicaPolicy string `yaml:"replica_policy,omitempty" json:"replica_policy,omitempty" default:"round-robin"`
 
 	// Autoscale, when set, replaces the static Replicas count with reactive
 	// autoscaling bounded by Min and Max. Mutually exclusive with Replicas.
@@ -469,8 +792,8 @@ type ServerAuth struct {
 	// client_secret when the provider issued one) bypasses dynamic client
 	// registration for authorization servers that do not support it.
 	Scopes       []string `yaml:"scopes,omitempty"`
-	ClientID     string   `yaml:"client_id,omitempty"`
-	ClientSecret string   `yaml:"client_secret,omitempty"`
+	ClientID     string   `yaml:"client_id,omitempty"`     // Pre-registered OAuth client ID; skips dynamic client registration
+	ClientSecret string   `yaml:"client_secret,omitempty"` // OAuth client secret, when the provider issued one
 }
 
 // ClientModelAttribution returns the client ID -> model mapping used to
@@ -540,10 +863,10 @@ type AutoscaleConfig struct {
 	TargetInFlight int `yaml:"target_in_flight" json:"target_in_flight"`
 	// ScaleUpAfter is how long the window median must exceed the target
 	// before spawning a replica. Default 30s. Minimum 10s.
-	ScaleUpAfter string `yaml:"scale_up_after,omitempty" json:"scale_up_after,omitempty"`
+	ScaleUpAfter string `yaml:"scale_up_after,omitempty" json:"scale_up_after,omitempty" default:"30s"`
 	// ScaleDownAfter is how long the window median must be below the target
 	// before reaping a replica. Default 5m. Minimum 1m.
-	ScaleDownAfter string `yaml:"scale_down_after,omitempty" json:"scale_down_after,omitempty"`
+	ScaleDownAfter string `yaml:"scale_down_after,omitempty" json:"scale_down_after,omitempty" default:"5m"`
 	// WarmPool keeps this many extra idle-ready replicas above the load-derived
 	// target at all times. Default 0. Must satisfy Min + WarmPool <= Max.
 	WarmPool int `yaml:"warm_pool,omitempty" json:"warm_pool,omitempty"`
@@ -576,6 +899,19 @@ func (a *AutoscaleConfig) ResolvedScaleDownAfter() time.Duration {
 	return d
 }
 
+// ResolvedApprovalTimeout parses ApprovalTimeout; returns 0 when unset or
+// invalid so the gateway falls back to its default.
+func (g *GatewayConfig) ResolvedApprovalTimeout() time.Duration {
+	if g == nil || g.ApprovalTimeout == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(g.ApprovalTimeout)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
 // ResolvedReadyTimeout parses ReadyTimeout; returns 0 when unset or invalid
 // so the gateway falls back to its default.
 func (s *MCPServer) ResolvedReadyTimeout() time.Duration {
@@ -602,14 +938,64 @@ func (s *MCPServer) ResolvedPingTimeout() time.Duration {
 	return d
 }
 
+// ResolvedTimeout parses Timeout; returns 0 when unset or invalid so the
+// gateway falls back to its default tool call timeout.
+func (s *MCPServer) ResolvedTimeout() time.Duration {
+	if s.Timeout == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(s.Timeout)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// DefaultCircuitBreakerFailures is the consecutive failure count that opens
+// a circuit when circuit_breaker.failures is unset.
+const DefaultCircuitBreakerFailures = 5
+
+// CircuitBreakerConfig opens a server's circuit after Failures consecutive
+// failed tool calls (transport errors and timeouts; tool error results do not
+// count). While open, calls fail immediately; after Cooldown one trial call
+// is let through, and its outcome closes or reopens the circuit.
+type CircuitBreakerConfig struct {
+	Failures int    `yaml:"failures,omitempty" json:"failures,omitempty" default:"5"`   // Default 5
+	Cooldown string `yaml:"cooldown,omitempty" json:"cooldown,omitempty" default:"30s"` // Duration string; default 30s
+}
+
+// ResolvedFailures returns Failures, or DefaultCircuitBreakerFailures when unset.
+func (c *CircuitBreakerConfig) ResolvedFailures() int {
+	if c.Failures <= 0 {
+		return DefaultCircuitBreakerFailures
+	}
+	return c.Failures
+}
+
+// ResolvedCooldown parses Cooldown. Returns 0 (the gateway default) when
+// unset or invalid; validation rejects invalid values at load time.
+func (c *CircuitBreakerConfig) ResolvedCooldown() time.Duration {
+	if c.Cooldown == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.Cooldown)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
 // OpenAPIConfig defines an MCP server backed by an OpenAPI specification.
-// The spec is parsed and each operation becomes an MCP tool.
+// The spec is parsed and each operation becomes an MCP tool, or with GroupBy
+// set, each tag or top-level path becomes one tool taking an operation name.
 type OpenAPIConfig struct {
 	Spec       string            `yaml:"spec"`                 // URL or local file path to OpenAPI spec (JSON or YAML)
 	BaseURL    string            `yaml:"baseUrl,omitempty"`    // Override the server URL from the spec
 	Auth       *OpenAPIAuth      `yaml:"auth,omitempty"`       // Authentication configuration
 	TLS        *OpenAPITLS       `yaml:"tls,omitempty"`        // TLS/mTLS configuration (transport-layer)
 	Operations *OperationsFilter `yaml:"operations,omitempty"` // Filter which operations become tools
+	GroupBy    string            `yaml:"groupBy,omitempty"`    // "tag" or "path": one composite tool per group instead of per operation
+	Mock       bool              `yaml:"mock,omitempty"`       // Serve example responses from the spec instead of calling the API
 }
 
 // OpenAPIAuth defines authentication for OpenAPI HTTP requests.
@@ -651,12 +1037,53 @@ type OperationsFilter struct {
 
 // SSHConfig defines SSH connection parameters for remote MCP servers.
 type SSHConfig struct {
-	Host           string `yaml:"host"`                     // Required: hostname or IP address
-	User           string `yaml:"user"`                     // Required: SSH username
-	Port           int    `yaml:"port,omitempty"`           // Optional: SSH port (default 22)
-	IdentityFile   string `yaml:"identityFile,omitempty"`   // Optional: path to SSH private key
-	KnownHostsFile string `yaml:"knownHostsFile,omitempty"` // Optional: path to known_hosts file; enables StrictHostKeyChecking=yes
-	JumpHost       string `yaml:"jumpHost,omitempty"`       // Optional: bastion/jump host ([user@]host[:port])
+	Host           string `yaml:"host"`                        // Required: hostname or IP address
+	User           string `yaml:"user"`                        // Required: SSH username
+	Port           int    `yaml:"port,omitempty" default:"22"` // Optional: SSH port (default 22)
+	IdentityFile   string `yaml:"identityFile,omitempty"`      // Optional: path to SSH private key
+	KnownHostsFile string `yaml:"knownHostsFile,omitempty"`    // Optional: path to known_hosts file; enables StrictHostKeyChecking=yes
+	JumpHost       string `yaml:"jumpHost,omitempty"`          // Optional: bastion/jump host ([user@]host[:port])
+
+	// Deploy provisions the server on the remote host before it starts.
+	Deploy *SSHDeployConfig `yaml:"deploy,omitempty"`
+}
+
+// SSHDeployConfig keeps an SSH server's workload on the remote host in sync
+// with the stack. Exactly one of Binary or Image is set.
+type SSHDeployConfig struct {
+	Binary string `yaml:"binary,omitempty"` // Local executable copied to the remote host when its checksum differs
+	Path   string `yaml:"path,omitempty"`   // Remote destination for Binary (default .gridctl/bin/<server> under the remote home)
+	Image  string `yaml:"image,omitempty"`  // Container image run with docker on the remote host
+	// Pull is docker's pull policy for Image on each start: "always"
+	// (default) picks up a moved tag, "missing" pulls only an absent image,
+	// "never" runs whatever the host has.
+	Pull string `yaml:"pull,omitempty" default:"always"`
+}
+
+// SSHDeployEqual reports whether two ssh.deploy blocks are the same.
+func SSHDeployEqual(a, b *SSHDeployConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// PullPolicy returns the docker pull policy for Image, "always" when Pull is
+// unset.
+func (d *SSHDeployConfig) PullPolicy() string {
+	if d.Pull == "" {
+		return "always"
+	}
+	return d.Pull
+}
+
+// RemotePath returns where Binary is installed on the remote host. Paths are
+// relative to the remote home unless absolute.
+func (d *SSHDeployConfig) RemotePath(server string) string {
+	if d.Path == "" {
+		return ".gridctl/bin/" + server
+	}
+	return strings.TrimPrefix(d.Path, "~/")
 }
 
 // IsExternal returns true if this is an external MCP server (URL-only, no container).
@@ -669,9 +1096,28 @@ func (s *MCPServer) IsLocalProcess() bool {
 	return len(s.Command) > 0 && s.Image == "" && s.Source == nil && s.URL == "" && s.SSH == nil
 }
 
-// IsSSH returns true if this is an SSH-based MCP server (ssh config with command).
+// IsSSH returns true if this is an SSH-based MCP server (ssh config with
+// command, or with ssh.deploy supplying the workload).
 func (s *MCPServer) IsSSH() bool {
-	return s.SSH != nil && len(s.Command) > 0 && s.Image == "" && s.Source == nil && s.URL == ""
+	return s.SSH != nil && (len(s.Command) > 0 || s.SSH.Deploy != nil) && s.Image == "" && s.Source == nil && s.URL == ""
+}
+
+// SSHRemoteCommand returns the command an SSH server runs on the remote host.
+// With ssh.deploy.image it runs the image with docker under the deploy's pull
+// policy, passing Command as the container's arguments; with ssh.deploy.binary and no Command it runs the
+// deployed binary.
+func (s *MCPServer) SSHRemoteCommand() []string {
+	if s.SSH == nil || s.SSH.Deploy == nil {
+		return s.Command
+	}
+	d := s.SSH.Deploy
+	if d.Image != "" {
+		return append([]string{"docker", "run", "-i", "--rm", "--pull=" + d.PullPolicy(), d.Image}, s.Command...)
+	}
+	if len(s.Command) == 0 {
+		return []string{d.RemotePath(s.Name)}
+	}
+	return s.Command
 }
 
 // IsOpenAPI returns true if this is an OpenAPI-based MCP server.
@@ -713,12 +1159,12 @@ func (s *MCPServer) PersistTraces(stack *Stack) bool {
 
 // Source defines how to build an MCP server from source code.
 type Source struct {
-	Type       string      `yaml:"type"` // "git" or "local"
-	URL        string      `yaml:"url,omitempty"`
-	Ref        string      `yaml:"ref,omitempty"`
-	Path       string      `yaml:"path,omitempty"`
-	Dockerfile string      `yaml:"dockerfile,omitempty"`
-	Auth       *SourceAuth `yaml:"auth,omitempty"`
+	Type       string      `yaml:"type"`                                      // "git" or "local"
+	URL        string      `yaml:"url,omitempty"`                             // Git repository URL (git sources)
+	Ref        string      `yaml:"ref,omitempty" default:"main"`              // Branch, tag, or commit to build (git sources)
+	Path       string      `yaml:"path,omitempty"`                            // Source directory, relative to the stack file (local sources)
+	Dockerfile string      `yaml:"dockerfile,omitempty" default:"Dockerfile"` // Dockerfile path relative to the source root
+	Auth       *SourceAuth `yaml:"auth,omitempty"`                            // Credentials for private git repositories
 }
 
 // SourceAuth is the declarative auth block on an MCP server git source. Raw
@@ -726,19 +1172,19 @@ type Source struct {
 // which is resolved against the live vault at clone time. Never add a Token
 // field to this struct: anything with a yaml tag here gets persisted to disk.
 type SourceAuth struct {
-	Method        string `yaml:"method,omitempty"`         // "", "none", "token", "ssh-agent", "ssh-key"
-	CredentialRef string `yaml:"credential_ref,omitempty"` // e.g. "${vault:GIT_TOKEN}" — resolved on every clone/fetch
-	SSHUser       string `yaml:"ssh_user,omitempty"`       // defaults to "git" when empty
-	SSHKeyPath    string `yaml:"ssh_key_path,omitempty"`   // required for method "ssh-key"
+	Method        string `yaml:"method,omitempty"`                 // "", "none", "token", "ssh-agent", "ssh-key"
+	CredentialRef string `yaml:"credential_ref,omitempty"`         // e.g. "${vault:GIT_TOKEN}" — resolved on every clone/fetch
+	SSHUser       string `yaml:"ssh_user,omitempty" default:"git"` // defaults to "git" when empty
+	SSHKeyPath    string `yaml:"ssh_key_path,omitempty"`           // required for method "ssh-key"
 }
 
 // Resource defines a supporting container (database, cache, etc).
 type Resource struct {
-	Name    string            `yaml:"name"`
-	Image   string            `yaml:"image"`
-	Env     map[string]string `yaml:"env,omitempty"`
-	Ports   []string          `yaml:"ports,omitempty"`
-	Volumes []string          `yaml:"volumes,omitempty"`
+	Name    string            `yaml:"name"`              // Unique resource name, also its hostname on the stack network
+	Image   string            `yaml:"image"`             // Container image to run
+	Env     map[string]string `yaml:"env,omitempty"`     // Container environment variables
+	Ports   []string          `yaml:"ports,omitempty"`   // Port mappings ("host:container")
+	Volumes []string          `yaml:"volumes,omitempty"` // Volume mounts ("source:target[:mode]")
 	Network string            `yaml:"network,omitempty"` // Network to join (for multi-network mode)
 }
 
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Upstream declares another gridctl gateway that this stack aggregates. Each
+// upstream is federated as one external MCP server named after it, so its
+// tools reach clients under the usual "{name}__" prefix (an upstream tool
+// already named "github__create_issue" becomes
+// "dept-a__github__create_issue") and its reachability is tracked by the
+// gateway health monitor like any other server.
+type Upstream struct {
+	// Name is the federated server's name and the prefix on the upstream's
+	// tools.
+	Name string `yaml:"name" json:"name"`
+
+	// URL is the upstream gateway's base URL (e.g. "http://dept-a:8180"),
+	// without the /mcp suffix. A path prefix the upstream is served under
+	// behind a reverse proxy is kept.
+	URL string `yaml:"url" json:"url"`
+
+	// Group federates only one of the upstream's tool groups
+	// (/groups/{group}/mcp) instead of its full tool set.
+	Group string `yaml:"group,omitempty" json:"group,omitempty"`
+
+	Tools       []string    `yaml:"tools,omitempty" json:"tools,omitempty"`               // Tool whitelist, as for mcp-servers
+	Auth        *ServerAuth `yaml:"auth,omitempty" json:"auth,omitempty"`                 // Credentials the upstream gateway requires
+	PingTimeout string      `yaml:"ping_timeout,omitempty" json:"ping_timeout,omitempty"` // Health ping deadline override
+	Timeout     string      `yaml:"timeout,omitempty" json:"timeout,omitempty"`           // Tool call timeout override
+}
+
+// endpoint returns the MCP endpoint of the upstream gateway.
+func (u Upstream) endpoint() string {
+	base := strings.TrimRight(u.URL, "/")
+	if u.Group != "" {
+		return base + "/groups/" + u.Group + "/mcp"
+	}
+	return base + "/mcp"
+}
+
+// expandUpstreams validates s.Upstreams and replaces them with the external
+// MCP servers they stand for, the same way extends is resolved away before
+// the rest of loading sees the stack. Running it before variable expansion
+// lets upstream URLs and auth use ${VAR} references.
+func expandUpstreams(s *Stack) error {
+	if len(s.Upstreams) == 0 {
+		return nil
+	}
+	var errs ValidationErrors
+
+	taken := make(map[string]bool, len(s.MCPServers)+len(s.Upstreams))
+	for _, server := range s.MCPServers {
+		taken[server.Name] = true
+	}
+	servers := make([]MCPServer, 0, len(s.Upstreams))
+	for i, up := range s.Upstreams {
+		prefix := fmt.Sprintf("upstreams[%d]", i)
+
+		switch {
+		case up.Name == "":
+			errs = append(errs, ValidationError{prefix + ".name", "is required"})
+		case taken[up.Name]:
+			errs = append(errs, ValidationError{prefix + ".name", fmt.Sprintf("'%s' is already used by another MCP server or upstream", up.Name)})
+		default:
+			taken[up.Name] = true
+		}
+		if up.URL == "" {
+			errs = append(errs, ValidationError{prefix + ".url", "is required"})
+		} else if !strings.Contains(up.URL, "${") {
+			if u, err := url.Parse(up.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, ValidationError{prefix + ".url", "must be an http:// or https:// gateway URL"})
+			} else if strings.HasSuffix(strings.TrimRight(u.Path, "/"), "/mcp") {
+				errs = append(errs, ValidationError{prefix + ".url", "must be the gateway base URL, without /mcp"})
+			}
+		}
+		if up.Group != "" && !groupNameRe.MatchString(up.Group) {
+			errs = append(errs, ValidationError{prefix + ".group", "must match ^[a-z0-9][a-z0-9_-]{0,31}$"})
+		}
+
+		servers = append(servers, MCPServer{
+			Name:        up.Name,
+			URL:         up.endpoint(),
+			Transport:   "http",
+			Tools:       up.Tools,
+			Auth:        up.Auth,
+			PingTimeout: up.PingTimeout,
+			Timeout:     up.Timeout,
+		})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	s.MCPServers = append(s.MCPServers, servers...)
+	s.Upstreams = nil
+	return nil
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadStack_Upstreams(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DEPT_B_TOKEN", "s3cret")
+	writeFile(t, filepath.Join(dir, "team.yaml"), `
+version: "1"
+name: team
+network:
+  name: team-net
+mcp-servers:
+  - name: local
+    url: https://local.example/mcp
+upstreams:
+  - name: dept-a
+    url: http://dept-a:8180/
+  - name: dept-b
+    url: https://gw.example/dept-b
+    group: readonly
+    tools: [github__list_issues]
+    ping_timeout: 10s
+    timeout: 2m
+    auth:
+      type: bearer
+      token: ${DEPT_B_TOKEN}
+`)
+
+	stack, err := LoadStack(filepath.Join(dir, "team.yaml"))
+	if err != nil {
+		t.Fatalf("LoadStack: %v", err)
+	}
+	if len(stack.Upstreams) != 0 {
+		t.Errorf("upstreams not consumed: %+v", stack.Upstreams)
+	}
+	if len(stack.MCPServers) != 3 {
+		t.Fatalf("expected 3 servers, got %d", len(stack.MCPServers))
+	}
+
+	a := stack.MCPServers[1]
+	if a.Name != "dept-a" || a.URL != "http://dept-a:8180/mcp" || a.Transport != "http" || !a.IsExternal() {
+		t.Errorf("dept-a = %+v", a)
+	}
+	b := stack.MCPServers[2]
+	if b.URL != "https://gw.example/dept-b/groups/readonly/mcp" {
+		t.Errorf("dept-b url = %q", b.URL)
+	}
+	if len(b.Tools) != 1 || b.PingTimeout != "10s" || b.Timeout != "2m" {
+		t.Errorf("dept-b tools/ping_timeout/timeout = %v/%q/%q", b.Tools, b.PingTimeout, b.Timeout)
+	}
+	if b.Auth == nil || b.Auth.Token != "s3cret" {
+		t.Errorf("dept-b auth not expanded: %+v", b.Auth)
+	}
+}
+
+func TestLoadStack_UpstreamsInheritedViaExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "base.yaml"), `
+version: "1"
+name: base
+network:
+  name: base-net
+upstreams:
+  - name: dept-a
+    url: http://dept-a:8180
+  - name: dept-b
+    url: http://dept-b:8180
+`)
+	writeFile(t, filepath.Join(dir, "team.yaml"), `
+version: "1"
+name: team
+extends: ./base.yaml
+upstreams:
+  - name: dept-a
+    url: http://dept-a.staging:8180
+`)
+
+	stack, err := LoadStack(filepath.Join(dir, "team.yaml"))
+	if err != nil {
+		t.Fatalf("LoadStack: %v", err)
+	}
+	if len(stack.MCPServers) != 2 {
+		t.Fatalf("expected 2 servers, got %+v", stack.MCPServers)
+	}
+	if stack.MCPServers[0].URL != "http://dept-a.staging:8180/mcp" {
+		t.Errorf("child upstream should win, got %q", stack.MCPServers[0].URL)
+	}
+	if stack.MCPServers[1].Name != "dept-b" {
+		t.Errorf("expected inherited dept-b, got %q", stack.MCPServers[1].Name)
+	}
+}
+
+func TestExpandUpstreams_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		upstream Upstream
+		field    string
+	}{
+		{"missing name", Upstream{URL: "http://a:8180"}, "upstreams[0].name"},
+		{"name collides with server", Upstream{Name: "local", URL: "http://a:8180"}, "upstreams[0].name"},
+		{"missing url", Upstream{Name: "a"}, "upstreams[0].url"},
+		{"non-http url", Upstream{Name: "a", URL: "ftp://a"}, "upstreams[0].url"},
+		{"mcp endpoint instead of base", Upstream{Name: "a", URL: "http://a:8180/mcp"}, "upstreams[0].url"},
+		{"bad group", Upstream{Name: "a", URL: "http://a:8180", Group: "Not Valid"}, "upstreams[0].group"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Stack{
+				MCPServers: []MCPServer{{Name: "local", URL: "https://local.example/mcp"}},
+				Upstreams:  []Upstream{tc.upstream},
+			}
+			err := expandUpstreams(s)
+			if err == nil || !strings.Contains(err.Error(), tc.field) {
+				t.Fatalf("err = %v, want error on %s", err, tc.field)
+			}
+			if len(s.MCPServers) != 1 {
+				t.Errorf("servers appended despite error: %+v", s.MCPServers)
+			}
+		})
+	}
+}
+
+func TestValidateStackFile_ReportsUpstreamErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stack.yaml")
+	writeFile(t, path, `
+version: "1"
+name: team
+network:
+  name: team-net
+upstreams:
+  - name: dept-a
+`)
+
+	_, result, err := ValidateStackFile(path)
+	if err != nil {
+		t.Fatalf("ValidateStackFile: %v", err)
+	}
+	if result.Valid {
+		t.Fatal("expected invalid result")
+	}
+	found := false
+	for _, issue := range result.Issues {
+		if issue.Field == "upstreams[0].url" && issue.Severity == SeverityError {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("missing upstreams[0].url issue in %+v", result.Issues)
+	}
+}
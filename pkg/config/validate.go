@@ -4,9 +4,15 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/gridctl/gridctl/pkg/features"
+	"github.com/gridctl/gridctl/pkg/logging"
+	"github.com/gridctl/gridctl/pkg/sshhosts"
 )
 
 // scanIgnoreCodeRe matches poisoning-scan finding codes ("P001"), case-insensitive
@@ -68,6 +74,18 @@ func Validate(s *Stack) error {
 		errs = append(errs, ValidationError{"stack.name", "is required"})
 	}
 
+	seenFeatures := make(map[string]bool, len(s.Features))
+	for i, name := range s.Features {
+		field := fmt.Sprintf("features[%d]", i)
+		switch {
+		case !features.ValidName(name):
+			errs = append(errs, ValidationError{field, fmt.Sprintf("invalid feature flag name %q (lowercase words joined by hyphens)", name)})
+		case seenFeatures[name]:
+			errs = append(errs, ValidationError{field, fmt.Sprintf("duplicate feature flag %q", name)})
+		}
+		seenFeatures[name] = true
+	}
+
 	// Gateway code_mode validation
 	if s.Gateway != nil && s.Gateway.CodeMode != "" {
 		validModes := map[string]bool{"off": true, "on": true}
@@ -91,6 +109,43 @@ func Validate(s *Stack) error {
 	if s.Gateway != nil && s.Gateway.MaxToolResultBytes < 0 {
 		errs = append(errs, ValidationError{"gateway.maxToolResultBytes", "must be a non-negative integer"})
 	}
+	if s.Gateway != nil && s.Gateway.ContextBudgetTokens < 0 {
+		errs = append(errs, ValidationError{"gateway.context_budget_tokens", "must be a non-negative integer"})
+	}
+	if s.Gateway != nil && s.Gateway.ApprovalTimeout != "" {
+		if d, err := time.ParseDuration(s.Gateway.ApprovalTimeout); err != nil {
+			errs = append(errs, ValidationError{"gateway.approval_timeout", fmt.Sprintf("invalid duration %q (expected e.g. \"5m\")", s.Gateway.ApprovalTimeout)})
+		} else if d <= 0 {
+			errs = append(errs, ValidationError{"gateway.approval_timeout", "must be positive"})
+		}
+	}
+	if s.Gateway != nil && s.Gateway.Analytics != nil {
+		a := s.Gateway.Analytics
+		switch a.Mode {
+		case "", AnalyticsModeFull, AnalyticsModeAggregate:
+		default:
+			errs = append(errs, ValidationError{"gateway.analytics.mode", "must be 'full' or 'aggregate'"})
+		}
+		if a.SampleRate < 0 || a.SampleRate > 1 {
+			errs = append(errs, ValidationError{"gateway.analytics.sample_rate", "must be between 0 and 1"})
+		}
+		if a.AggregateOnly() {
+			if s.Gateway.RecordRequests {
+				errs = append(errs, ValidationError{"gateway.record_requests", "records raw request arguments; cannot be combined with analytics mode 'aggregate'"})
+			}
+			if t := s.Gateway.Tracing; t != nil && t.Enabled != nil && *t.Enabled {
+				errs = append(errs, ValidationError{"gateway.tracing.enabled", "keeps per-call traces; cannot be combined with analytics mode 'aggregate'"})
+			}
+			if s.Gateway.AuditToolCalls {
+				errs = append(errs, ValidationError{"gateway.audit_tool_calls", "logs one line per call; cannot be combined with analytics mode 'aggregate'"})
+			}
+		}
+	}
+	if s.Gateway != nil && s.Gateway.BasePath != "" {
+		if msg := validateBasePath(s.Gateway.BasePath); msg != "" {
+			errs = append(errs, ValidationError{"gateway.base_path", msg})
+		}
+	}
 
 	// Gateway schema pinning action validation. Unknown values must be
 	// rejected: the gateway only honors "block", so a typo would silently
@@ -110,6 +165,19 @@ func Validate(s *Stack) error {
 		}
 	}
 
+	// Logging output validation
+	if s.Logging != nil {
+		errs = append(errs, validateLogOutput("logging", s.Logging.LogOutputConfig)...)
+		if s.Logging.Console != nil {
+			errs = append(errs, validateLogOutput("logging.console", *s.Logging.Console)...)
+		}
+	}
+
+	// History retention validation
+	if s.Retention != nil {
+		errs = append(errs, validateHistoryRetention(s.Retention)...)
+	}
+
 	// Telemetry retention validation
 	if s.Telemetry != nil && s.Telemetry.Retention != nil {
 		errs = append(errs, validateTelemetryRetention(s.Telemetry.Retention)...)
@@ -124,8 +192,22 @@ func Validate(s *Stack) error {
 		} else if auth.Type != "bearer" && auth.Type != "api_key" {
 			errs = append(errs, ValidationError{authPrefix + ".type", "must be 'bearer' or 'api_key'"})
 		}
-		if auth.Token == "" {
-			errs = append(errs, ValidationError{authPrefix + ".token", "is required"})
+		if auth.Token == "" && len(auth.Keys) == 0 {
+			errs = append(errs, ValidationError{authPrefix + ".token", "is required unless 'keys' are set"})
+		}
+		seenKeys := make(map[string]bool, len(auth.Keys))
+		for i, k := range auth.Keys {
+			keyPrefix := fmt.Sprintf("%s.keys[%d]", authPrefix, i)
+			if strings.TrimSpace(k.Name) == "" {
+				errs = append(errs, ValidationError{keyPrefix + ".name", "is required"})
+			}
+			switch {
+			case k.Key == "":
+				errs = append(errs, ValidationError{keyPrefix + ".key", "is required"})
+			case k.Key == auth.Token || seenKeys[k.Key]:
+				errs = append(errs, ValidationError{keyPrefix + ".key", "duplicates another credential; each key must be unique"})
+			}
+			seenKeys[k.Key] = true
 		}
 		if auth.Header != "" && auth.Type != "api_key" {
 			errs = append(errs, ValidationError{authPrefix + ".header", "only applicable when type is 'api_key'"})
@@ -184,7 +266,7 @@ func Validate(s *Stack) error {
 		hasImage := server.Image != ""
 		hasSource := server.Source != nil
 		hasURL := server.URL != ""
-		hasSSH := server.SSH != nil && len(server.Command) > 0
+		hasSSH := server.SSH != nil && (len(server.Command) > 0 || server.SSH.Deploy != nil)
 		hasCommand := len(server.Command) > 0 && !hasImage && !hasSource && !hasURL && !hasSSH // command-only = local process
 		hasOpenAPI := server.OpenAPI != nil
 
@@ -210,7 +292,7 @@ func Validate(s *Stack) error {
 		}
 
 		if count == 0 {
-			errs = append(errs, ValidationError{prefix, "must have 'image', 'source', 'url', 'command', 'ssh' with 'command', or 'openapi'"})
+			errs = append(errs, ValidationError{prefix, "must have 'image', 'source', 'url', 'command', 'ssh' with 'command' or 'deploy', or 'openapi'"})
 		} else if count > 1 {
 			errs = append(errs, ValidationError{prefix, "can only have one of 'image', 'source', 'url', 'command', 'ssh', or 'openapi'"})
 		}
@@ -270,6 +352,17 @@ func Validate(s *Stack) error {
 			if server.SSH.KnownHostsFile != "" {
 				if _, err := os.Stat(server.SSH.KnownHostsFile); err != nil {
 					errs = append(errs, ValidationError{sshPrefix + ".knownHostsFile", fmt.Sprintf("file not found or not readable: %s", server.SSH.KnownHostsFile)})
+				} else if server.SSH.Host != "" {
+					// Strict host key checking refuses an unlisted host, so
+					// catch it here rather than as an opaque ssh failure.
+					listed, err := sshhosts.Listed(server.SSH.KnownHostsFile, server.SSH.Host, server.SSH.Port)
+					if err != nil {
+						errs = append(errs, ValidationError{sshPrefix + ".knownHostsFile", err.Error()})
+					} else if !listed {
+						errs = append(errs, ValidationError{sshPrefix + ".knownHostsFile", fmt.Sprintf(
+							"has no key for %s; run 'gridctl ssh trust %s --file %s'",
+							sshhosts.Address(server.SSH.Host, server.SSH.Port), trustTarget(server.SSH), server.SSH.KnownHostsFile)})
+					}
 				}
 			}
 			if server.SSH.JumpHost != "" {
@@ -277,6 +370,9 @@ func Validate(s *Stack) error {
 					errs = append(errs, ValidationError{sshPrefix + ".jumpHost", "invalid format"})
 				}
 			}
+			if server.SSH.Deploy != nil {
+				errs = append(errs, validateSSHDeploy(server.SSH.Deploy, sshPrefix+".deploy")...)
+			}
 			// Transport must be stdio for SSH servers (they use stdin/stdout over SSH)
 			if server.Transport != "" && server.Transport != "stdio" {
 				errs = append(errs, ValidationError{prefix + ".transport", "must be 'stdio' for SSH servers"})
@@ -356,6 +452,12 @@ func Validate(s *Stack) error {
 					errs = append(errs, ValidationError{openapiPrefix + ".operations", "cannot use both 'include' and 'exclude'"})
 				}
 			}
+			switch server.OpenAPI.GroupBy {
+			case "", "tag", "path":
+				// valid
+			default:
+				errs = append(errs, ValidationError{openapiPrefix + ".groupBy", "must be 'tag' or 'path'"})
+			}
 			// Transport is not applicable for OpenAPI servers (uses HTTP internally)
 			if server.Transport != "" {
 				errs = append(errs, ValidationError{prefix + ".transport", "not applicable for OpenAPI servers"})
@@ -428,6 +530,54 @@ func Validate(s *Stack) error {
 			}
 		}
 
+		// timeout validation: must parse as a duration and be non-negative.
+		// Empty is valid and falls back to the gateway's default.
+		if server.Timeout != "" {
+			d, err := time.ParseDuration(server.Timeout)
+			if err != nil {
+				errs = append(errs, ValidationError{prefix + ".timeout", fmt.Sprintf("invalid duration %q (expected e.g. \"5s\" or \"2m\")", server.Timeout)})
+			} else if d < 0 {
+				errs = append(errs, ValidationError{prefix + ".timeout", "must be non-negative"})
+			}
+		}
+
+		for j, tool := range server.RequireApproval {
+			field := fmt.Sprintf("%s.require_approval[%d]", prefix, j)
+			switch {
+			case tool == "":
+				errs = append(errs, ValidationError{field, "must not be empty"})
+			case len(server.Tools) > 0 && !slices.Contains(server.Tools, tool):
+				errs = append(errs, ValidationError{field, fmt.Sprintf("tool '%s' is not in this server's tools whitelist", tool)})
+			}
+		}
+
+		if cb := server.CircuitBreaker; cb != nil {
+			if cb.Failures < 0 {
+				errs = append(errs, ValidationError{prefix + ".circuit_breaker.failures", "must be non-negative"})
+			}
+			if cb.Cooldown != "" {
+				d, err := time.ParseDuration(cb.Cooldown)
+				if err != nil {
+					errs = append(errs, ValidationError{prefix + ".circuit_breaker.cooldown", fmt.Sprintf("invalid duration %q (expected e.g. \"30s\")", cb.Cooldown)})
+				} else if d < 0 {
+					errs = append(errs, ValidationError{prefix + ".circuit_breaker.cooldown", "must be non-negative"})
+				}
+			}
+		}
+
+		// roots validation: each entry is an absolute path or file:// URI.
+		for j, root := range server.Roots {
+			if !strings.HasPrefix(root, "file://") && !filepath.IsAbs(root) {
+				errs = append(errs, ValidationError{fmt.Sprintf("%s.roots[%d]", prefix, j), fmt.Sprintf("%q must be an absolute path or file:// URI", root)})
+			}
+		}
+		if len(server.Roots) > 0 && server.IsOpenAPI() {
+			errs = append(errs, ValidationError{prefix + ".roots", "not supported for OpenAPI servers"})
+		}
+		if server.Sampling && server.IsOpenAPI() {
+			errs = append(errs, ValidationError{prefix + ".sampling", "not supported for OpenAPI servers"})
+		}
+
 		// Replica validation.
 		// Zero is accepted as "unspecified" and defaulted to 1 by Stack.SetDefaults;
 		// only reject truly invalid values here.
@@ -551,10 +701,65 @@ func validateClients(s *Stack, serverNames map[string]bool) ValidationErrors {
 				})
 			}
 		}
+		for i, skill := range profile.Skills {
+			if !skillNameRe.MatchString(skill) {
+				errs = append(errs, ValidationError{
+					fmt.Sprintf("%s.skills[%d]", prefix, i),
+					fmt.Sprintf("skill '%s' must be a lowercase alphanumeric name with hyphens", skill),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// validateSSHDeploy checks an ssh.deploy block. Values end up in a remote
+// shell command, so shell metacharacters are rejected.
+func validateSSHDeploy(d *SSHDeployConfig, prefix string) []ValidationError {
+	var errs []ValidationError
+	switch {
+	case d.Binary == "" && d.Image == "":
+		errs = append(errs, ValidationError{prefix, "must set 'binary' or 'image'"})
+	case d.Binary != "" && d.Image != "":
+		errs = append(errs, ValidationError{prefix, "can only set one of 'binary' or 'image'"})
+	}
+	if d.Binary != "" {
+		if info, err := os.Stat(d.Binary); err != nil || info.IsDir() {
+			errs = append(errs, ValidationError{prefix + ".binary", fmt.Sprintf("file not found: %s", d.Binary)})
+		}
+	}
+	if d.Path != "" {
+		if d.Binary == "" {
+			errs = append(errs, ValidationError{prefix + ".path", "only valid with 'binary'"})
+		} else if strings.ContainsAny(d.Path, " \t\n;|&$`'\"") {
+			errs = append(errs, ValidationError{prefix + ".path", "invalid format"})
+		}
+	}
+	if d.Image != "" && strings.ContainsAny(d.Image, " \t\n;|&$`'\"") {
+		errs = append(errs, ValidationError{prefix + ".image", "invalid format"})
+	}
+	if d.Pull != "" {
+		if d.Image == "" {
+			errs = append(errs, ValidationError{prefix + ".pull", "only valid with 'image'"})
+		} else if d.Pull != "always" && d.Pull != "missing" && d.Pull != "never" {
+			errs = append(errs, ValidationError{prefix + ".pull", fmt.Sprintf("must be 'always', 'missing', or 'never', got '%s'", d.Pull)})
+		}
 	}
 	return errs
 }
 
+// trustTarget renders the host argument for a 'gridctl ssh trust' hint.
+func trustTarget(s *SSHConfig) string {
+	if s.Port != 0 && s.Port != sshhosts.DefaultPort {
+		return fmt.Sprintf("%s --port %d", s.Host, s.Port)
+	}
+	return s.Host
+}
+
+// skillNameRe mirrors the registry's skill name rule. Whether the skill exists
+// is a runtime property of the registry, so unknown names are not rejected.
+var skillNameRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
 // validateLimits checks the optional `limits:` block. Each entry must scope
 // to exactly one of client/server/tool; server and tool scopes must reference
 // declared servers (tool existence itself is a runtime property, mirroring
@@ -636,6 +841,27 @@ func validateLimits(s *Stack, serverNames map[string]bool) ValidationErrors {
 			errs = append(errs, ValidationError{prefix + ".burst", "must not be negative"})
 		}
 	}
+
+	seenSchedules := make(map[string]bool, len(s.Limits.Schedules))
+	for i := range s.Limits.Schedules {
+		sc := &s.Limits.Schedules[i]
+		prefix := fmt.Sprintf("limits.schedules[%d]", i)
+		if scope := validateScope(prefix, sc.Client, sc.Server, sc.Tool); scope != "" {
+			if seenSchedules[scope] {
+				errs = append(errs, ValidationError{prefix, fmt.Sprintf("duplicate schedule for %s", scope)})
+			}
+			seenSchedules[scope] = true
+		}
+		if len(sc.Days) == 0 && sc.Hours == "" {
+			errs = append(errs, ValidationError{prefix, "must set 'days', 'hours', or both"})
+		}
+		if _, err := sc.AllowedDays(); err != nil {
+			errs = append(errs, ValidationError{prefix + ".days", err.Error()})
+		}
+		if _, _, err := sc.AllowedHours(); err != nil {
+			errs = append(errs, ValidationError{prefix + ".hours", err.Error()})
+		}
+	}
 	return errs
 }
 
@@ -950,6 +1176,63 @@ func validateAutoscale(server MCPServer, prefix string) ValidationErrors {
 // block and emits a soft warning when the worst-case footprint per server
 // exceeds telemetryWarnBytesPerServer. Hard bounds: every field must be a
 // positive integer; max_size_mb must be >= 1.
+// validateLogOutput checks one log output's format and timestamp settings.
+func validateLogOutput(prefix string, o LogOutputConfig) ValidationErrors {
+	var errs ValidationErrors
+	if _, err := logging.ParseOutputFormat(o.Format); err != nil {
+		errs = append(errs, ValidationError{prefix + ".format", "must be 'json' or 'text'"})
+	}
+	if _, err := logging.ParseTimeFormat(o.TimeFormat); err != nil {
+		errs = append(errs, ValidationError{prefix + ".timeFormat", "must be rfc3339, rfc3339nano, unix, unixmilli, or a Go time layout"})
+	}
+	if _, err := logging.LoadLocation(o.Timezone); err != nil {
+		errs = append(errs, ValidationError{prefix + ".timezone", fmt.Sprintf("unknown timezone %q", o.Timezone)})
+	}
+	return errs
+}
+
+// minHistoryRetentionInterval keeps the janitor from walking the registry
+// and client config directories in a tight loop.
+const minHistoryRetentionInterval = time.Minute
+
+// validateHistoryRetention checks the retention: block.
+func validateHistoryRetention(r *HistoryRetentionConfig) ValidationErrors {
+	var errs ValidationErrors
+	if r.Interval != "" {
+		d, err := time.ParseDuration(r.Interval)
+		if err != nil {
+			errs = append(errs, ValidationError{"retention.interval", fmt.Sprintf("invalid duration %q", r.Interval)})
+		} else if d < minHistoryRetentionInterval {
+			errs = append(errs, ValidationError{"retention.interval", "must be at least 1m"})
+		}
+	}
+	for _, p := range []struct {
+		name   string
+		policy *HistoryRetentionPolicy
+	}{
+		{"request_logs", r.RequestLogs},
+		{"daemon_logs", r.DaemonLogs},
+		{"crash_reports", r.CrashReports},
+		{"skill_backups", r.SkillBackups},
+		{"client_backups", r.ClientBackups},
+	} {
+		if p.policy == nil {
+			continue
+		}
+		prefix := "retention." + p.name
+		if p.policy.MaxAgeDays < 0 {
+			errs = append(errs, ValidationError{prefix + ".max_age_days", "must be >= 0"})
+		}
+		if p.policy.MaxSizeMB < 0 {
+			errs = append(errs, ValidationError{prefix + ".max_size_mb", "must be >= 0"})
+		}
+		if p.policy.Keep < 0 {
+			errs = append(errs, ValidationError{prefix + ".keep", "must be >= 0"})
+		}
+	}
+	return errs
+}
+
 func validateTelemetryRetention(r *RetentionConfig) ValidationErrors {
 	var errs ValidationErrors
 	const prefix = "telemetry.retention"
@@ -1013,3 +1296,21 @@ func validateSource(s *Source, prefix string) ValidationErrors {
 
 	return errs
 }
+
+// validateBasePath checks a gateway.base_path value, returning "" when it is
+// usable: an absolute URL path with no query, fragment, dot segments, or
+// whitespace.
+func validateBasePath(p string) string {
+	if !strings.HasPrefix(p, "/") {
+		return "must start with '/'"
+	}
+	if strings.ContainsAny(p, "?#\\ \t") {
+		return "must be a plain path without query, fragment, backslashes, or spaces"
+	}
+	for _, seg := range strings.Split(p, "/") {
+		if seg == "." || seg == ".." {
+			return "must not contain '.' or '..' segments"
+		}
+	}
+	return ""
+}
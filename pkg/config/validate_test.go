@@ -1,9 +1,15 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/gridctl/gridctl/pkg/sshhosts"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestValidate_StackLevel(t *testing.T) {
@@ -248,6 +254,106 @@ func TestValidate_GatewayOutputFormat(t *testing.T) {
 	}
 }
 
+func TestValidate_LoggingOutput(t *testing.T) {
+	base := func(l *LoggingConfig) *Stack {
+		return &Stack{
+			Name:       "test",
+			Network:    Network{Name: "test-net"},
+			MCPServers: []MCPServer{{Name: "s1", Image: "alpine", Port: 3000}},
+			Logging:    l,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		logging *LoggingConfig
+		errMsg  string
+	}{
+		{
+			name:    "json file with UTC RFC3339",
+			logging: &LoggingConfig{File: "/tmp/g.log", LogOutputConfig: LogOutputConfig{Format: "json", TimeFormat: "rfc3339", Timezone: "UTC"}},
+		},
+		{
+			name:    "text console with IANA zone and Go layout",
+			logging: &LoggingConfig{Console: &LogOutputConfig{Format: "text", TimeFormat: "2006-01-02 15:04:05", Timezone: "Europe/Berlin"}},
+		},
+		{
+			name:    "invalid file format",
+			logging: &LoggingConfig{LogOutputConfig: LogOutputConfig{Format: "xml"}},
+			errMsg:  "logging.format",
+		},
+		{
+			name:    "invalid console time format",
+			logging: &LoggingConfig{Console: &LogOutputConfig{TimeFormat: "iso"}},
+			errMsg:  "logging.console.timeFormat",
+		},
+		{
+			name:    "unknown timezone",
+			logging: &LoggingConfig{LogOutputConfig: LogOutputConfig{Timezone: "Mars/Olympus"}},
+			errMsg:  "logging.timezone",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(base(tc.logging))
+			if tc.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+					t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate_HistoryRetention(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention *HistoryRetentionConfig
+		errMsg    string
+	}{
+		{
+			name:      "valid policies",
+			retention: &HistoryRetentionConfig{Interval: "30m", RequestLogs: &HistoryRetentionPolicy{MaxAgeDays: 7, MaxSizeMB: 500}, ClientBackups: &HistoryRetentionPolicy{Keep: 10}},
+		},
+		{
+			name:      "interval too short",
+			retention: &HistoryRetentionConfig{Interval: "10s"},
+			errMsg:    "retention.interval",
+		},
+		{
+			name:      "invalid interval",
+			retention: &HistoryRetentionConfig{Interval: "hourly"},
+			errMsg:    "retention.interval",
+		},
+		{
+			name:      "negative keep",
+			retention: &HistoryRetentionConfig{SkillBackups: &HistoryRetentionPolicy{Keep: -1}},
+			errMsg:    "retention.skill_backups.keep",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(&Stack{
+				Name:       "test",
+				Network:    Network{Name: "test-net"},
+				MCPServers: []MCPServer{{Name: "s1", Image: "alpine", Port: 3000}},
+				Retention:  tc.retention,
+			})
+			if tc.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+					t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestValidate_GatewayAuth(t *testing.T) {
 	base := func() *Stack {
 		return &Stack{
@@ -319,6 +425,50 @@ func TestValidate_GatewayAuth(t *testing.T) {
 				return s
 			}(),
 		},
+		{
+			name: "keys without token",
+			stack: func() *Stack {
+				s := base()
+				s.Gateway = &GatewayConfig{Auth: &AuthConfig{Type: "bearer", Keys: []AuthKey{
+					{Name: "ci-bot", Key: "k1"},
+					{Name: "ci-bot", Key: "k2"},
+				}}}
+				return s
+			}(),
+		},
+		{
+			name: "key missing name",
+			stack: func() *Stack {
+				s := base()
+				s.Gateway = &GatewayConfig{Auth: &AuthConfig{Type: "bearer", Keys: []AuthKey{{Key: "k1"}}}}
+				return s
+			}(),
+			wantErr: true,
+			errMsg:  "gateway.auth.keys[0].name",
+		},
+		{
+			name: "key missing value",
+			stack: func() *Stack {
+				s := base()
+				s.Gateway = &GatewayConfig{Auth: &AuthConfig{Type: "bearer", Keys: []AuthKey{{Name: "ci-bot"}}}}
+				return s
+			}(),
+			wantErr: true,
+			errMsg:  "gateway.auth.keys[0].key",
+		},
+		{
+			name: "duplicate key value",
+			stack: func() *Stack {
+				s := base()
+				s.Gateway = &GatewayConfig{Auth: &AuthConfig{Type: "bearer", Token: "shared", Keys: []AuthKey{
+					{Name: "a", Key: "k1"},
+					{Name: "b", Key: "shared"},
+				}}}
+				return s
+			}(),
+			wantErr: true,
+			errMsg:  "gateway.auth.keys[1].key",
+		},
 	}
 
 	for _, tc := range tests {
@@ -670,6 +820,14 @@ func TestValidate_MCPServer(t *testing.T) {
 			wantErr: true,
 			errMsg:  "cannot use both 'include' and 'exclude'",
 		},
+		{
+			name: "OpenAPI unknown groupBy",
+			stack: base([]MCPServer{
+				{Name: "s1", OpenAPI: &OpenAPIConfig{Spec: "spec.json", GroupBy: "resource"}},
+			}),
+			wantErr: true,
+			errMsg:  "openapi.groupBy",
+		},
 		{
 			name: "OpenAPI transport set rejected",
 			stack: base([]MCPServer{
@@ -932,6 +1090,98 @@ func TestValidate_MCPServer(t *testing.T) {
 			wantErr: true,
 			errMsg:  "must be non-negative",
 		},
+		{
+			name: "timeout: valid duration accepted",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, Timeout: "2m"},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "timeout: malformed value rejected",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, Timeout: "two minutes"},
+			}),
+			wantErr: true,
+			errMsg:  "timeout: invalid duration",
+		},
+		{
+			name: "timeout: negative duration rejected",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, Timeout: "-5s"},
+			}),
+			wantErr: true,
+			errMsg:  "timeout: must be non-negative",
+		},
+		{
+			name: "require_approval: whitelisted tool accepted",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, Tools: []string{"read", "delete"}, RequireApproval: []string{"delete"}},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "require_approval: tool outside the whitelist rejected",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, Tools: []string{"read"}, RequireApproval: []string{"delete"}},
+			}),
+			wantErr: true,
+			errMsg:  "require_approval[0]: tool 'delete' is not in this server's tools whitelist",
+		},
+		{
+			name: "require_approval: empty name rejected",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, RequireApproval: []string{""}},
+			}),
+			wantErr: true,
+			errMsg:  "require_approval[0]: must not be empty",
+		},
+		{
+			name: "circuit_breaker: defaults accepted",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, CircuitBreaker: &CircuitBreakerConfig{}},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "circuit_breaker: malformed cooldown rejected",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, CircuitBreaker: &CircuitBreakerConfig{Failures: 3, Cooldown: "soon"}},
+			}),
+			wantErr: true,
+			errMsg:  "circuit_breaker.cooldown: invalid duration",
+		},
+		{
+			name: "circuit_breaker: negative failures rejected",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, CircuitBreaker: &CircuitBreakerConfig{Failures: -1}},
+			}),
+			wantErr: true,
+			errMsg:  "circuit_breaker.failures: must be non-negative",
+		},
+		{
+			name: "roots: absolute paths and file URIs accepted",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, Roots: []string{"/workspace", "file:///data"}},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "roots: relative path rejected",
+			stack: base([]MCPServer{
+				{Name: "s1", Image: "alpine", Port: 3000, Roots: []string{"workspace"}},
+			}),
+			wantErr: true,
+			errMsg:  "must be an absolute path or file:// URI",
+		},
+		{
+			name: "sampling: rejected on OpenAPI servers",
+			stack: base([]MCPServer{
+				{Name: "s1", Sampling: true, OpenAPI: &OpenAPIConfig{Spec: "https://example.com/spec.json"}},
+			}),
+			wantErr: true,
+			errMsg:  "sampling: not supported for OpenAPI servers",
+		},
 	}
 
 	for _, tc := range tests {
@@ -1265,6 +1515,112 @@ func TestValidate_SSH_NewFields(t *testing.T) {
 	}
 }
 
+func TestValidate_SSH_KnownHostsEntry(t *testing.T) {
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sshhosts.Append(knownHosts, "10.0.0.1", 22, key); err != nil {
+		t.Fatal(err)
+	}
+	stack := func(host string, port int) *Stack {
+		return &Stack{
+			Name:    "test",
+			Network: Network{Name: "net"},
+			MCPServers: []MCPServer{{
+				Name:    "srv",
+				SSH:     &SSHConfig{Host: host, User: "mcp", Port: port, KnownHostsFile: knownHosts},
+				Command: []string{"/opt/server"},
+			}},
+		}
+	}
+
+	if err := Validate(stack("10.0.0.1", 0)); err != nil {
+		t.Errorf("listed host: unexpected error: %v", err)
+	}
+	err = Validate(stack("10.0.0.2", 2222))
+	if err == nil || !strings.Contains(err.Error(), "gridctl ssh trust 10.0.0.2 --port 2222") {
+		t.Errorf("unlisted host: expected a trust hint, got %v", err)
+	}
+}
+
+func TestValidate_SSH_Deploy(t *testing.T) {
+	bin := filepath.Join(t.TempDir(), "server")
+	if err := os.WriteFile(bin, []byte("bin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		deploy  *SSHDeployConfig
+		command []string
+		wantErr string
+	}{
+		{name: "binary without command", deploy: &SSHDeployConfig{Binary: bin}},
+		{name: "binary with path", deploy: &SSHDeployConfig{Binary: bin, Path: "/opt/mcp/server"}, command: []string{"/opt/mcp/server", "--stdio"}},
+		{name: "image", deploy: &SSHDeployConfig{Image: "ghcr.io/acme/mcp:1.2"}},
+		{name: "neither", deploy: &SSHDeployConfig{}, wantErr: "must set 'binary' or 'image'"},
+		{name: "both", deploy: &SSHDeployConfig{Binary: bin, Image: "alpine"}, wantErr: "only set one of"},
+		{name: "missing binary", deploy: &SSHDeployConfig{Binary: filepath.Join(t.TempDir(), "nope")}, wantErr: "file not found"},
+		{name: "path without binary", deploy: &SSHDeployConfig{Image: "alpine", Path: "/opt/x"}, wantErr: "only valid with 'binary'"},
+		{name: "unsafe image", deploy: &SSHDeployConfig{Image: "alpine; rm -rf /"}, wantErr: "ssh.deploy.image: invalid format"},
+		{name: "image pull never", deploy: &SSHDeployConfig{Image: "alpine", Pull: "never"}},
+		{name: "unknown pull policy", deploy: &SSHDeployConfig{Image: "alpine", Pull: "sometimes"}, wantErr: "ssh.deploy.pull: must be"},
+		{name: "pull without image", deploy: &SSHDeployConfig{Binary: bin, Pull: "always"}, wantErr: "only valid with 'image'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&Stack{
+				Name:    "test",
+				Network: Network{Name: "net"},
+				MCPServers: []MCPServer{{
+					Name:    "srv",
+					SSH:     &SSHConfig{Host: "10.0.0.1", User: "mcp", Deploy: tt.deploy},
+					Command: tt.command,
+				}},
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMCPServer_SSHRemoteCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		server MCPServer
+		want   []string
+	}{
+		{"plain", MCPServer{Name: "s", SSH: &SSHConfig{}, Command: []string{"/opt/server"}}, []string{"/opt/server"}},
+		{"binary default path", MCPServer{Name: "s", SSH: &SSHConfig{Deploy: &SSHDeployConfig{Binary: "/b"}}}, []string{".gridctl/bin/s"}},
+		{"binary home path", MCPServer{Name: "s", SSH: &SSHConfig{Deploy: &SSHDeployConfig{Binary: "/b", Path: "~/bin/s"}}}, []string{"bin/s"}},
+		{"binary with command", MCPServer{Name: "s", SSH: &SSHConfig{Deploy: &SSHDeployConfig{Binary: "/b"}}, Command: []string{".gridctl/bin/s", "serve"}}, []string{".gridctl/bin/s", "serve"}},
+		{"image", MCPServer{Name: "s", SSH: &SSHConfig{Deploy: &SSHDeployConfig{Image: "acme/mcp:1"}}, Command: []string{"--stdio"}}, []string{"docker", "run", "-i", "--rm", "--pull=always", "acme/mcp:1", "--stdio"}},
+		{"image pull missing", MCPServer{Name: "s", SSH: &SSHConfig{Deploy: &SSHDeployConfig{Image: "acme/mcp:1", Pull: "missing"}}}, []string{"docker", "run", "-i", "--rm", "--pull=missing", "acme/mcp:1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.server.IsSSH() {
+				t.Fatal("expected an SSH server")
+			}
+			if got := tt.server.SSHRemoteCommand(); strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("SSHRemoteCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidate_Replicas(t *testing.T) {
 	baseContainer := func(replicas int, policy string) *Stack {
 		return &Stack{
@@ -1692,3 +2048,139 @@ func TestValidate_SchemaPinningScanIgnore(t *testing.T) {
 		t.Errorf("expected scan_ignore in error, got %q", err.Error())
 	}
 }
+
+func TestValidate_GatewayBasePath(t *testing.T) {
+	withBasePath := func(p string) *Stack {
+		return &Stack{
+			Name:       "test",
+			Network:    Network{Name: "test-net"},
+			MCPServers: []MCPServer{{Name: "s1", Image: "alpine", Port: 3000}},
+			Gateway:    &GatewayConfig{BasePath: p},
+		}
+	}
+
+	for _, p := range []string{"/gridctl", "/tools/gridctl/", "/"} {
+		if err := Validate(withBasePath(p)); err != nil {
+			t.Errorf("%q rejected: %v", p, err)
+		}
+	}
+	for _, p := range []string{"gridctl", "/grid ctl", "/gridctl?x=1", "/a/../b"} {
+		err := Validate(withBasePath(p))
+		if err == nil {
+			t.Errorf("%q accepted", p)
+			continue
+		}
+		if !strings.Contains(err.Error(), "base_path") {
+			t.Errorf("expected base_path in error, got %q", err.Error())
+		}
+	}
+}
+
+func TestValidate_GatewayApprovalTimeout(t *testing.T) {
+	withTimeout := func(v string) *Stack {
+		return &Stack{
+			Name:       "test",
+			Network:    Network{Name: "test-net"},
+			MCPServers: []MCPServer{{Name: "s1", Image: "alpine", Port: 3000}},
+			Gateway:    &GatewayConfig{ApprovalTimeout: v},
+		}
+	}
+
+	if err := Validate(withTimeout("10m")); err != nil {
+		t.Errorf("10m rejected: %v", err)
+	}
+	for _, v := range []string{"ten minutes", "0s", "-1m"} {
+		err := Validate(withTimeout(v))
+		if err == nil {
+			t.Errorf("%q accepted", v)
+			continue
+		}
+		if !strings.Contains(err.Error(), "approval_timeout") {
+			t.Errorf("expected approval_timeout in error, got %q", err.Error())
+		}
+	}
+}
+
+func TestValidate_GatewayAnalytics(t *testing.T) {
+	withAnalytics := func(gw GatewayConfig) *Stack {
+		return &Stack{
+			Name:       "test",
+			Network:    Network{Name: "test-net"},
+			MCPServers: []MCPServer{{Name: "s1", Image: "alpine", Port: 3000}},
+			Gateway:    &gw,
+		}
+	}
+	enabled := true
+
+	tests := []struct {
+		name   string
+		gw     GatewayConfig
+		errMsg string
+	}{
+		{name: "aggregate with sampling", gw: GatewayConfig{Analytics: &AnalyticsConfig{Mode: "aggregate", SampleRate: 0.1}}},
+		{name: "full", gw: GatewayConfig{Analytics: &AnalyticsConfig{Mode: "full"}, RecordRequests: true}},
+		{name: "unknown mode", gw: GatewayConfig{Analytics: &AnalyticsConfig{Mode: "minimal"}}, errMsg: "gateway.analytics.mode"},
+		{name: "sample rate above one", gw: GatewayConfig{Analytics: &AnalyticsConfig{SampleRate: 1.5}}, errMsg: "gateway.analytics.sample_rate"},
+		{name: "negative sample rate", gw: GatewayConfig{Analytics: &AnalyticsConfig{SampleRate: -0.1}}, errMsg: "gateway.analytics.sample_rate"},
+		{
+			name:   "aggregate refuses request recording",
+			gw:     GatewayConfig{Analytics: &AnalyticsConfig{Mode: "aggregate"}, RecordRequests: true},
+			errMsg: "gateway.record_requests",
+		},
+		{
+			name:   "aggregate refuses explicit tracing",
+			gw:     GatewayConfig{Analytics: &AnalyticsConfig{Mode: "aggregate"}, Tracing: &TracingConfig{Enabled: &enabled}},
+			errMsg: "gateway.tracing.enabled",
+		},
+		{
+			name:   "aggregate refuses the audit log",
+			gw:     GatewayConfig{Analytics: &AnalyticsConfig{Mode: "aggregate"}, AuditToolCalls: true},
+			errMsg: "gateway.audit_tool_calls",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(withAnalytics(tc.gw))
+			if tc.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidate_Features(t *testing.T) {
+	tests := []struct {
+		name     string
+		features []string
+		errMsg   string
+	}{
+		{name: "well formed", features: []string{"lazy-start", "streamable-http"}},
+		{name: "bad name", features: []string{"Lazy_Start"}, errMsg: "features[0]"},
+		{name: "duplicate", features: []string{"lazy-start", "lazy-start"}, errMsg: "duplicate feature flag"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(&Stack{
+				Name:       "test",
+				Network:    Network{Name: "test-net"},
+				MCPServers: []MCPServer{{Name: "s1", Image: "alpine", Port: 3000}},
+				Features:   tc.features,
+			})
+			if tc.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
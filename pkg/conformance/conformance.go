@@ -0,0 +1,295 @@
+// Package conformance runs a black-box MCP conformance suite against a
+// candidate server before it is added to a stack: the initialize
+// handshake, tools/list, tool schema validity, error behavior for bad
+// calls, and recovery after a timed-out request.
+//
+// The suite drives any mcp.AgentClient, so the same checks cover local
+// processes (stdio) and remote Streamable HTTP endpoints.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// Check statuses. Words, not just colors, so meaning survives NO_COLOR.
+const (
+	StatusPass = "pass"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// DefaultTimeout bounds each individual protocol step.
+const DefaultTimeout = 10 * time.Second
+
+// probeToolName is called to exercise error behavior. It is deliberately
+// implausible so no real server exposes it.
+const probeToolName = "gridctl_conformance_probe_nonexistent_tool"
+
+// toolNamePattern is the tool name shape the MCP spec recommends and most
+// clients enforce (letters, digits, underscore, hyphen, dot; 1-128 chars).
+var toolNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,128}$`)
+
+// errStepTimeout marks a step that hit its per-step deadline, as opposed to
+// one the server answered with an error.
+var errStepTimeout = errors.New("no response")
+
+// Options tunes a conformance run.
+type Options struct {
+	// Timeout bounds each protocol step. Zero uses DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Check is one verdict line of the report.
+type Check struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`
+	Message    string `json:"message"`
+	DurationMS int64  `json:"duration_ms,omitempty"`
+}
+
+// Report is the outcome of a conformance run.
+type Report struct {
+	Target          string  `json:"target"`
+	ServerName      string  `json:"server_name,omitempty"`
+	ServerVersion   string  `json:"server_version,omitempty"`
+	ProtocolVersion string  `json:"protocol_version,omitempty"`
+	ToolCount       int     `json:"tool_count"`
+	Passed          bool    `json:"passed"`
+	FailureCount    int     `json:"failure_count"`
+	WarningCount    int     `json:"warning_count"`
+	Checks          []Check `json:"checks"`
+}
+
+// connector matches transports that need setup before the handshake (local
+// processes). The suite connects with the run context so the process
+// outlives the per-step deadlines.
+type connector interface {
+	Connect(ctx context.Context) error
+}
+
+// protocolVersioner matches clients that record the negotiated version.
+type protocolVersioner interface {
+	ProtocolVersion() string
+}
+
+// Run executes the suite against client. target labels the report (the
+// command line or URL). Run never returns an error: every failure,
+// including a server that cannot start, is a failed check.
+func Run(ctx context.Context, client mcp.AgentClient, target string, opts Options) Report {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	r := &runner{ctx: ctx, client: client, timeout: opts.Timeout}
+	r.report.Target = target
+
+	if r.initialize() {
+		if r.listTools() {
+			r.validateSchemas()
+		}
+		r.ping()
+		r.errorBehavior()
+		r.timeoutRecovery()
+	}
+
+	r.summarize()
+	return r.report
+}
+
+type runner struct {
+	ctx     context.Context
+	client  mcp.AgentClient
+	timeout time.Duration
+	report  Report
+}
+
+func (r *runner) add(id, status, msg string, d time.Duration) {
+	r.report.Checks = append(r.report.Checks, Check{ID: id, Status: status, Message: msg, DurationMS: d.Milliseconds()})
+}
+
+// step runs fn under the per-step deadline and reports how long it took.
+func (r *runner) step(fn func(ctx context.Context) error) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(r.ctx, r.timeout)
+	defer cancel()
+	start := time.Now()
+	err := fn(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w within %s", errStepTimeout, r.timeout)
+	}
+	return time.Since(start), err
+}
+
+func (r *runner) initialize() bool {
+	if c, ok := r.client.(connector); ok {
+		if err := c.Connect(r.ctx); err != nil {
+			r.add("initialize", StatusFail, fmt.Sprintf("could not start server: %v", err), 0)
+			return false
+		}
+	}
+	d, err := r.step(r.client.Initialize)
+	if err != nil {
+		r.add("initialize", StatusFail, err.Error(), d)
+		return false
+	}
+
+	info := r.client.ServerInfo()
+	r.report.ServerName = info.Name
+	r.report.ServerVersion = info.Version
+	if pv, ok := r.client.(protocolVersioner); ok {
+		r.report.ProtocolVersion = pv.ProtocolVersion()
+	}
+
+	var gaps []string
+	if r.report.ProtocolVersion == "" {
+		gaps = append(gaps, "protocolVersion")
+	}
+	if info.Name == "" {
+		gaps = append(gaps, "serverInfo.name")
+	}
+	if len(gaps) > 0 {
+		r.add("initialize", StatusWarn, "handshake succeeded but the result omits "+strings.Join(gaps, " and "), d)
+		return true
+	}
+	r.add("initialize", StatusPass, fmt.Sprintf("%s %s, protocol %s", info.Name, info.Version, r.report.ProtocolVersion), d)
+	return true
+}
+
+func (r *runner) listTools() bool {
+	d, err := r.step(r.client.RefreshTools)
+	if err != nil {
+		r.add("tools/list", StatusFail, err.Error(), d)
+		return false
+	}
+	n := len(r.client.Tools())
+	r.report.ToolCount = n
+	if n == 0 {
+		r.add("tools/list", StatusWarn, "server lists no tools", d)
+		return true
+	}
+	r.add("tools/list", StatusPass, fmt.Sprintf("%d tool(s)", n), d)
+	return true
+}
+
+// validateSchemas checks every tool's name, description, and input/output
+// schemas. Each problem is reported once per tool so a large server with
+// one broken tool still produces a readable report.
+func (r *runner) validateSchemas() {
+	tools := r.client.Tools()
+	if len(tools) == 0 {
+		r.add("tools/schema", StatusSkip, "no tools to validate", 0)
+		return
+	}
+
+	var failures, warnings []string
+	seen := make(map[string]bool, len(tools))
+	for _, t := range tools {
+		switch {
+		case !toolNamePattern.MatchString(t.Name):
+			failures = append(failures, fmt.Sprintf("%q: invalid tool name", t.Name))
+		case seen[t.Name]:
+			failures = append(failures, fmt.Sprintf("%q: duplicate tool name", t.Name))
+		}
+		seen[t.Name] = true
+
+		if err := mcp.ValidateToolSchema(t.InputSchema); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: input %v", t.Name, err))
+		}
+		if len(t.OutputSchema) > 0 {
+			if err := mcp.ValidateToolSchema(t.OutputSchema); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: output %v", t.Name, err))
+			}
+		}
+		if strings.TrimSpace(t.Description) == "" {
+			warnings = append(warnings, t.Name+": no description")
+		}
+	}
+
+	switch {
+	case len(failures) > 0:
+		r.add("tools/schema", StatusFail, strings.Join(failures, "; "), 0)
+	case len(warnings) > 0:
+		r.add("tools/schema", StatusWarn, strings.Join(warnings, "; "), 0)
+	default:
+		r.add("tools/schema", StatusPass, fmt.Sprintf("%d tool schema(s) valid", len(tools)), 0)
+	}
+}
+
+func (r *runner) ping() {
+	p, ok := r.client.(mcp.Pingable)
+	if !ok {
+		r.add("ping", StatusSkip, "transport does not support ping", 0)
+		return
+	}
+	d, err := r.step(p.Ping)
+	if err != nil {
+		// ping is a base-protocol utility; many servers skip it, and the
+		// gateway falls back to tools/list for health checks.
+		r.add("ping", StatusWarn, fmt.Sprintf("ping failed (health checks fall back to tools/list): %v", err), d)
+		return
+	}
+	r.add("ping", StatusPass, "responds to ping", d)
+}
+
+// errorBehavior calls a tool that does not exist. A conforming server
+// answers promptly with a JSON-RPC error or an isError result; silently
+// "succeeding" or hanging both fail.
+func (r *runner) errorBehavior() {
+	var result *mcp.ToolCallResult
+	d, err := r.step(func(ctx context.Context) error {
+		var callErr error
+		result, callErr = r.client.CallTool(ctx, probeToolName, map[string]any{})
+		return callErr
+	})
+	switch {
+	case errors.Is(err, errStepTimeout):
+		r.add("errors/unknown-tool", StatusFail, "unknown tool call hung: "+err.Error(), d)
+	case err != nil:
+		r.add("errors/unknown-tool", StatusPass, "rejected with "+firstLine(err.Error()), d)
+	case result != nil && result.IsError:
+		r.add("errors/unknown-tool", StatusPass, "rejected with an isError result", d)
+	default:
+		r.add("errors/unknown-tool", StatusFail, "calling a nonexistent tool reported success", d)
+	}
+}
+
+// timeoutRecovery abandons a request almost immediately, as a client
+// timeout would, and then verifies the server still answers. Servers that
+// wedge on a cancelled request take the whole gateway entry down with them.
+func (r *runner) timeoutRecovery() {
+	abandon, cancel := context.WithTimeout(r.ctx, time.Millisecond)
+	_, _ = r.client.CallTool(abandon, probeToolName, map[string]any{})
+	cancel()
+
+	d, err := r.step(r.client.RefreshTools)
+	if err != nil {
+		r.add("timeout/recovery", StatusFail, "server unresponsive after an abandoned request: "+err.Error(), d)
+		return
+	}
+	r.add("timeout/recovery", StatusPass, "still responsive after an abandoned request", d)
+}
+
+func (r *runner) summarize() {
+	for _, c := range r.report.Checks {
+		switch c.Status {
+		case StatusFail:
+			r.report.FailureCount++
+		case StatusWarn:
+			r.report.WarningCount++
+		}
+	}
+	r.report.Passed = r.report.FailureCount == 0
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,186 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// fakeClient is a scriptable mcp.AgentClient.
+type fakeClient struct {
+	initErr    error
+	info       mcp.ServerInfo
+	version    string
+	tools      []mcp.Tool
+	listErr    error
+	callResult *mcp.ToolCallResult
+	callErr    error
+	hangCalls  bool
+	wedged     bool // tools/list hangs once any call was abandoned
+	abandoned  bool
+	connectErr error
+}
+
+func (f *fakeClient) Name() string { return "candidate" }
+func (f *fakeClient) Initialize(ctx context.Context) error {
+	return f.initErr
+}
+func (f *fakeClient) RefreshTools(ctx context.Context) error {
+	if f.wedged && f.abandoned {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return f.listErr
+}
+func (f *fakeClient) Tools() []mcp.Tool          { return f.tools }
+func (f *fakeClient) IsInitialized() bool        { return f.initErr == nil }
+func (f *fakeClient) ServerInfo() mcp.ServerInfo { return f.info }
+func (f *fakeClient) ProtocolVersion() string    { return f.version }
+func (f *fakeClient) CallTool(ctx context.Context, name string, args map[string]any) (*mcp.ToolCallResult, error) {
+	if f.hangCalls || ctx.Err() != nil {
+		<-ctx.Done()
+		f.abandoned = true
+		return nil, ctx.Err()
+	}
+	return f.callResult, f.callErr
+}
+
+type fakeConnectingClient struct{ *fakeClient }
+
+func (f fakeConnectingClient) Connect(ctx context.Context) error {
+	return f.connectErr
+}
+
+var objectSchema = json.RawMessage(`{"type":"object","properties":{"q":{"type":"string"}}}`)
+
+func goodClient() *fakeClient {
+	return &fakeClient{
+		info:    mcp.ServerInfo{Name: "demo", Version: "1.0.0"},
+		version: mcp.MCPProtocolVersion,
+		tools: []mcp.Tool{
+			{Name: "search", Description: "Search things", InputSchema: objectSchema},
+			{Name: "get", Description: "Get a thing", InputSchema: objectSchema},
+		},
+		callErr: errors.New("RPC error -32602: unknown tool"),
+	}
+}
+
+func statusOf(r Report, id string) string {
+	for _, c := range r.Checks {
+		if c.ID == id {
+			return c.Status
+		}
+	}
+	return ""
+}
+
+func TestRunConformingServer(t *testing.T) {
+	r := Run(context.Background(), goodClient(), "demo-server", Options{Timeout: time.Second})
+	if !r.Passed || r.FailureCount != 0 {
+		t.Fatalf("expected a pass, got %+v", r)
+	}
+	for _, id := range []string{"initialize", "tools/list", "tools/schema", "errors/unknown-tool", "timeout/recovery"} {
+		if got := statusOf(r, id); got != StatusPass {
+			t.Errorf("%s = %q, want pass", id, got)
+		}
+	}
+	if statusOf(r, "ping") != StatusSkip {
+		t.Error("expected ping skipped for a client without Ping")
+	}
+	if r.ServerName != "demo" || r.ToolCount != 2 || r.Target != "demo-server" {
+		t.Errorf("report header = %+v", r)
+	}
+}
+
+func TestRunInitializeFailureStopsSuite(t *testing.T) {
+	c := goodClient()
+	c.initErr = errors.New("initialize: unsupported protocol version")
+	r := Run(context.Background(), c, "x", Options{Timeout: time.Second})
+	if r.Passed || len(r.Checks) != 1 || r.Checks[0].Status != StatusFail {
+		t.Errorf("expected a single failed initialize check, got %+v", r.Checks)
+	}
+}
+
+func TestRunConnectFailure(t *testing.T) {
+	c := goodClient()
+	c.connectErr = errors.New("exec: \"nope\": executable file not found")
+	r := Run(context.Background(), fakeConnectingClient{c}, "nope", Options{Timeout: time.Second})
+	if r.Passed || !strings.Contains(r.Checks[0].Message, "could not start server") {
+		t.Errorf("checks = %+v", r.Checks)
+	}
+}
+
+func TestRunSchemaProblems(t *testing.T) {
+	c := goodClient()
+	c.tools = []mcp.Tool{
+		{Name: "ok", Description: "fine", InputSchema: objectSchema},
+		{Name: "bad name!", Description: "x", InputSchema: objectSchema},
+		{Name: "ok", Description: "dup", InputSchema: objectSchema},
+		{Name: "nil_schema", Description: "x"},
+		{Name: "array_schema", Description: "x", InputSchema: json.RawMessage(`{"type":"array"}`)},
+		{Name: "broken", Description: "x", InputSchema: json.RawMessage(`{"type":"object","properties":{"a":{"type":7}}}`)},
+	}
+	r := Run(context.Background(), c, "x", Options{Timeout: time.Second})
+	if statusOf(r, "tools/schema") != StatusFail {
+		t.Fatalf("expected schema failure, got %+v", r.Checks)
+	}
+	msg := r.Checks[2].Message
+	for _, want := range []string{`"bad name!": invalid tool name`, `"ok": duplicate`, "nil_schema: input schema is missing", "array_schema", "broken: input schema does not compile"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("schema message missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestRunMissingDescriptionWarns(t *testing.T) {
+	c := goodClient()
+	c.tools = []mcp.Tool{{Name: "terse", InputSchema: objectSchema}}
+	r := Run(context.Background(), c, "x", Options{Timeout: time.Second})
+	if !r.Passed || statusOf(r, "tools/schema") != StatusWarn || r.WarningCount != 1 {
+		t.Errorf("expected a warning-only pass, got %+v", r)
+	}
+}
+
+func TestRunErrorBehavior(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *mcp.ToolCallResult
+		err    error
+		want   string
+	}{
+		{"rpc error", nil, errors.New("RPC error -32602: unknown tool"), StatusPass},
+		{"isError result", &mcp.ToolCallResult{IsError: true}, nil, StatusPass},
+		{"silent success", &mcp.ToolCallResult{}, nil, StatusFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := goodClient()
+			c.callResult, c.callErr = tt.result, tt.err
+			r := Run(context.Background(), c, "x", Options{Timeout: time.Second})
+			if got := statusOf(r, "errors/unknown-tool"); got != tt.want {
+				t.Errorf("errors/unknown-tool = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunHangingServer(t *testing.T) {
+	c := goodClient()
+	c.hangCalls = true
+	c.wedged = true
+	r := Run(context.Background(), c, "x", Options{Timeout: 50 * time.Millisecond})
+	if got := statusOf(r, "errors/unknown-tool"); got != StatusFail {
+		t.Errorf("hanging call = %q, want fail", got)
+	}
+	if got := statusOf(r, "timeout/recovery"); got != StatusFail {
+		t.Errorf("wedged server = %q, want fail", got)
+	}
+	if r.Passed {
+		t.Error("expected the report to fail")
+	}
+}
@@ -11,10 +11,12 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/gridctl/gridctl/pkg/config"
 	"github.com/gridctl/gridctl/pkg/logging"
+	"github.com/gridctl/gridctl/pkg/mcp"
 	"github.com/gridctl/gridctl/pkg/output"
 	"github.com/gridctl/gridctl/pkg/pins"
 	"github.com/gridctl/gridctl/pkg/runtime"
@@ -61,6 +63,7 @@ type Config struct {
 	Replace     bool       // Stop a running stack before deploying (used by plan apply)
 	LogFile     string     // Path to log file (overrides stack.yaml logging.file)
 	LogLevel    slog.Level // Minimum slog level (global --log-level; zero value is info)
+	InCluster   string     // ID of the container gridctl runs in (serve --in-cluster); empty otherwise
 }
 
 // effectiveLogLevel resolves the slog level for handlers built from cfg.
@@ -512,6 +515,12 @@ func (sc *StackController) runDaemonMode(ctx context.Context, stack *config.Stac
 		summaries := BuildWorkloadSummaries(stack, result)
 		printer.Summary(summaries)
 		printer.Info("Gateway running", "url", fmt.Sprintf("http://localhost:%d", st.Port))
+		var auth *config.AuthConfig
+		if stack.Gateway != nil {
+			auth = stack.Gateway.Auth
+		}
+		warnSchemaIssues(printer, daemon.SchemaIssues(st.Port, auth))
+		warnContextBudgets(printer, daemon.ContextBudgets(st.Port, auth))
 		// Teardown instructions always print (scripts capture them); extra
 		// conversational hints go through Printer.Hint and are TTY-only.
 		printer.Print("\nUse 'gridctl destroy %s' to stop\n", sc.config.StackPath)
@@ -527,6 +536,54 @@ func (sc *StackController) runDaemonMode(ctx context.Context, stack *config.Stac
 	return nil
 }
 
+// warnSchemaIssues prints one deploy warning per downstream tool whose
+// input schema is missing or invalid, in server order.
+func warnSchemaIssues(printer *output.Printer, issues map[string][]mcp.SchemaIssue) {
+	if len(issues) == 0 {
+		return
+	}
+	names := make([]string, 0, len(issues))
+	for name := range issues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	unrepaired := false
+	for _, name := range names {
+		for _, issue := range issues[name] {
+			if issue.Repaired {
+				printer.Warn("Tool input schema repaired", "server", name, "tool", issue.Tool, "problem", issue.Problem)
+				continue
+			}
+			unrepaired = true
+			printer.Warn("Tool input schema invalid", "server", name, "tool", issue.Tool, "problem", issue.Problem)
+		}
+	}
+	if unrepaired {
+		printer.Hint("Set gateway.repair_tool_schemas: true to fix missing schemas and missing \"type\": \"object\" automatically")
+	}
+}
+
+// warnContextBudgets prints one deploy warning per client whose tool and
+// prompt surface is estimated above gateway.context_budget_tokens.
+func warnContextBudgets(printer *output.Printer, budgets []mcp.ContextBudget) {
+	over := false
+	for _, b := range budgets {
+		if !b.OverBudget {
+			continue
+		}
+		over = true
+		client := b.Client
+		if client == mcp.DefaultClientKey {
+			client = "(unlisted clients)"
+		}
+		printer.Warn("Client context over budget", "client", client,
+			"tokens", b.Tokens, "budget", b.Budget, "tools", b.Tools, "prompts", b.Prompts)
+	}
+	if over {
+		printer.Hint("Narrow the client's scope under clients:, trim server tool whitelists, or enable gateway code_mode")
+	}
+}
+
 // newGatewayBuilder creates a configured GatewayBuilder.
 func (sc *StackController) newGatewayBuilder(stack *config.Stack, rt *runtime.Orchestrator, result *runtime.UpResult) (*GatewayBuilder, error) {
 	builder := NewGatewayBuilder(sc.config, stack, sc.config.StackPath, rt, result)
@@ -702,7 +759,7 @@ func getRunningContainers(ctx context.Context, rt *runtime.Orchestrator, stack *
 			})
 		case server.IsSSH():
 			result.MCPServers = append(result.MCPServers, runtime.MCPServerResult{
-				Name: server.Name, SSH: true, Command: server.Command,
+				Name: server.Name, SSH: true, Command: server.SSHRemoteCommand(),
 				SSHHost: server.SSH.Host, SSHUser: server.SSH.User,
 				SSHPort: server.SSH.Port, SSHIdentityFile: server.SSH.IdentityFile,
 			})
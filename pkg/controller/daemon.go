@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/mcp"
 	"github.com/gridctl/gridctl/pkg/state"
 )
 
@@ -176,3 +178,72 @@ func (d *DaemonManager) WaitForHealth(port int, timeout time.Duration) error {
 	}
 	return fmt.Errorf("health check timed out after %v", timeout)
 }
+
+// apiGet issues a short GET against the running gateway's API. When the
+// stack sets gateway.auth it carries the stack's token, or
+// GRIDCTL_GATEWAY_TOKEN when the stack only configures named keys.
+func apiGet(port int, path string, auth *config.AuthConfig) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d%s", port, path), nil)
+	if err != nil {
+		return nil, err
+	}
+	if auth != nil {
+		token := auth.Token
+		if token == "" {
+			token = os.Getenv(config.GatewayTokenEnv)
+		}
+		if token != "" {
+			req.Header.Set(auth.CredentialHeader(token))
+		}
+	}
+	client := &http.Client{Timeout: 2 * time.Second}
+	return client.Do(req)
+}
+
+// SchemaIssues fetches the tool input schema problems the running gateway
+// found at registration, keyed by server name. auth is the stack's
+// gateway.auth, nil when unset. It is best-effort: any failure returns nil
+// so deploy output never blocks on it.
+func (d *DaemonManager) SchemaIssues(port int, auth *config.AuthConfig) map[string][]mcp.SchemaIssue {
+	resp, err := apiGet(port, "/api/mcp-servers", auth)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	var servers []mcp.MCPServerStatus
+	if err := json.NewDecoder(resp.Body).Decode(&servers); err != nil {
+		return nil
+	}
+	var issues map[string][]mcp.SchemaIssue
+	for _, s := range servers {
+		if len(s.SchemaIssues) == 0 {
+			continue
+		}
+		if issues == nil {
+			issues = make(map[string][]mcp.SchemaIssue)
+		}
+		issues[s.Name] = s.SchemaIssues
+	}
+	return issues
+}
+
+// ContextBudgets fetches the per-client context budget report from a running
+// gateway. Like SchemaIssues it is best-effort: any failure returns nil.
+func (d *DaemonManager) ContextBudgets(port int, auth *config.AuthConfig) []mcp.ContextBudget {
+	resp, err := apiGet(port, "/api/clients/context-budget", auth)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	var budgets []mcp.ContextBudget
+	if err := json.NewDecoder(resp.Body).Decode(&budgets); err != nil {
+		return nil
+	}
+	return budgets
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"testing"
 	"time"
+
+	"github.com/gridctl/gridctl/pkg/config"
 )
 
 func TestNewDaemonManager(t *testing.T) {
@@ -154,3 +156,24 @@ func extractPort(t *testing.T, url string) int {
 	t.Fatalf("no port found in URL: %s", url)
 	return 0
 }
+
+func TestDaemonManager_SchemaIssues_SendsGatewayCredential(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name":"github","schemaIssues":[{"tool":"search","problem":"missing inputSchema"}]}]`))
+	}))
+	defer server.Close()
+	port := extractPort(t, server.URL)
+	dm := NewDaemonManager(Config{})
+
+	if issues := dm.SchemaIssues(port, nil); issues != nil {
+		t.Errorf("without a credential: got %v, want nil", issues)
+	}
+	auth := &config.AuthConfig{Type: "api_key", Header: "X-API-Key", Token: "s3cret"}
+	if issues := dm.SchemaIssues(port, auth); len(issues["github"]) != 1 {
+		t.Errorf("with the stack's credential: got %v, want one issue for github", issues)
+	}
+}
@@ -19,6 +19,9 @@ import (
 	"github.com/gridctl/gridctl/internal/api"
 	"github.com/gridctl/gridctl/internal/probe"
 	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/crash"
+	"github.com/gridctl/gridctl/pkg/discovery"
+	"github.com/gridctl/gridctl/pkg/features"
 	"github.com/gridctl/gridctl/pkg/limits"
 	"github.com/gridctl/gridctl/pkg/logging"
 	"github.com/gridctl/gridctl/pkg/mcp"
@@ -28,6 +31,7 @@ import (
 	"github.com/gridctl/gridctl/pkg/provisioner"
 	"github.com/gridctl/gridctl/pkg/registry"
 	"github.com/gridctl/gridctl/pkg/reload"
+	"github.com/gridctl/gridctl/pkg/retention"
 	"github.com/gridctl/gridctl/pkg/runtime"
 	"github.com/gridctl/gridctl/pkg/skills"
 	"github.com/gridctl/gridctl/pkg/skillsync"
@@ -90,12 +94,20 @@ type GatewayBuilder struct {
 	limitsMu     sync.Mutex
 	limitsPolicy *limits.Policy
 
+	// janitor enforces the retention: block (nil when none is configured).
+	// Created at Build so /api/retention can report on it; started by Run.
+	janitor *retention.Janitor
+
 	// modelAttribution holds the client and server model mappings used to
 	// price tool calls. Stored behind an atomic pointer so the hot-reload
 	// hook can swap both mappings together without racing in-flight
 	// observations; the observer's resolver closure reads through it on
 	// every call.
 	modelAttribution atomic.Pointer[modelAttribution]
+
+	// latency records per-tool call latency for GET /api/tools/latency. It
+	// outlives hot reloads, which only rebuild the interceptor chain.
+	latency *mcp.LatencyRecorder
 }
 
 // modelAttribution is the resolved cost-attribution state derived from a
@@ -206,6 +218,17 @@ func installSchemaPinning(gateway *mcp.Gateway, server *api.Server, stack *confi
 	gateway.SetSchemaVerifier(pins.NewGatewayAdapter(ps), action)
 }
 
+// logFeatures reports the experimental features this gateway runs with, and
+// the requested flags it ignored because no subsystem registers them.
+func logFeatures(logger *slog.Logger, enabled, unknown []string) {
+	if len(enabled) > 0 {
+		logger.Info("experimental features enabled", "features", enabled)
+	}
+	if len(unknown) > 0 {
+		logger.Warn("ignoring unknown feature flags", "features", unknown)
+	}
+}
+
 // BuildAndRun constructs the gateway and runs it until shutdown.
 // This is the main blocking call that replaces the old runGateway() function.
 func (b *GatewayBuilder) BuildAndRun(ctx context.Context, verbose bool) error {
@@ -220,6 +243,11 @@ func (b *GatewayBuilder) BuildAndRun(ctx context.Context, verbose bool) error {
 func (b *GatewayBuilder) Build(verbose bool) (*GatewayInstance, error) {
 	inst := &GatewayInstance{}
 
+	// Phase 0: Resolve experimental feature flags before any subsystem is
+	// built, so every check sees the final set.
+	enabledFeatures, unknownFeatures := features.Resolve(b.stack.Features, os.Getenv(features.EnvVar))
+	features.SetEnabled(enabledFeatures)
+
 	// Phase 1: Create MCP Gateway
 	inst.Gateway = mcp.NewGateway()
 	inst.Gateway.SetDockerClient(b.rt.DockerClient())
@@ -251,6 +279,18 @@ func (b *GatewayBuilder) Build(verbose bool) (*GatewayInstance, error) {
 		inst.Gateway.SetMaxToolResultBytes(b.stack.Gateway.MaxToolResultBytes)
 	}
 
+	// Phase 1a3b: Opt in to tool schema auto-repair and argument validation
+	if b.stack.Gateway != nil && b.stack.Gateway.RepairToolSchemas {
+		inst.Gateway.SetSchemaRepair(true)
+	}
+	if b.stack.Gateway != nil && b.stack.Gateway.ValidateToolArguments {
+		inst.Gateway.SetArgumentValidation(true)
+	}
+	if b.stack.Gateway != nil && b.stack.Gateway.ContextBudgetTokens > 0 {
+		inst.Gateway.SetContextBudget(b.stack.Gateway.ContextBudgetTokens)
+	}
+	inst.Gateway.SetApprovalTimeout(b.stack.Gateway.ResolvedApprovalTimeout())
+
 	// Phase 1a4: Install the per-client access policy (nil when no clients:
 	// block is configured, preserving legacy "everyone sees everything").
 	inst.Gateway.SetClientAccessPolicy(mcp.NewClientAccessPolicy(clientAccessSpec(b.stack)))
@@ -275,6 +315,7 @@ func (b *GatewayBuilder) Build(verbose bool) (*GatewayInstance, error) {
 		return nil, logErr
 	}
 	inst.Gateway.SetLogger(slog.New(inst.Handler))
+	logFeatures(slog.New(inst.Handler), enabledFeatures, unknownFeatures)
 
 	// Seed the in-memory log buffer from any pre-existing per-server
 	// logs.jsonl files BEFORE registry init or any other component starts
@@ -333,10 +374,16 @@ func (b *GatewayBuilder) Build(verbose bool) (*GatewayInstance, error) {
 		inst.APIServer.SetOAuthBroker(inst.Broker)
 	}
 
+	// Phase 5b: History retention janitor (started by Run).
+	if b.stack.Retention != nil {
+		b.janitor = retention.New(b.retentionTargets(b.stack.Retention, regDir), slog.New(inst.Handler))
+		inst.APIServer.SetRetentionStatusFunc(b.janitor.Report)
+	}
+
 	// Phase 6: Create HTTP server
 	inst.HTTPServer = &http.Server{
 		Addr:              fmt.Sprintf(":%d", b.config.Port),
-		Handler:           inst.APIServer.Handler(),
+		Handler:           crash.Handler("api", inst.APIServer.Handler()),
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
@@ -427,6 +474,22 @@ func (b *GatewayBuilder) Run(ctx context.Context, inst *GatewayInstance, verbose
 	gateway := inst.Gateway
 	bufferHandler := inst.Handler
 
+	// Recovered goroutine panics are logged through the daemon handler and
+	// written as crash reports carrying the most recent log entries.
+	crashLabels := map[string]string{"version": b.version}
+	if b.stack != nil {
+		crashLabels["stack"] = b.stack.Name
+	}
+	crashCfg := crash.Config{
+		Dir:    state.CrashDir(),
+		Logger: slog.New(bufferHandler),
+		Labels: crashLabels,
+	}
+	if inst.LogBuffer != nil {
+		crashCfg.Recent = inst.LogBuffer.GetRecent
+	}
+	crash.Configure(crashCfg)
+
 	// Start periodic session cleanup
 	gateway.StartCleanup(ctx)
 	defer gateway.Close()
@@ -455,6 +518,9 @@ func (b *GatewayBuilder) Run(ctx context.Context, inst *GatewayInstance, verbose
 		registrar.SetRuntime(b.rt.Runtime())
 	}
 	registrar.SetBasePort(b.config.BasePort)
+	if b.config.InCluster != "" {
+		registrar.SetInCluster(b.config.InCluster)
+	}
 	if inst.Broker != nil {
 		registrar.SetAuthBroker(inst.Broker)
 		// After a successful login, re-drive registration for the server so
@@ -462,6 +528,7 @@ func (b *GatewayBuilder) Run(ctx context.Context, inst *GatewayInstance, verbose
 		// from the login request's context: registration outlives it.
 		inst.Broker.SetOnAuthorized(func(name string) {
 			go func() {
+				defer crash.Recover("oauth-reregister", "server", name)
 				for _, srv := range b.stack.MCPServers {
 					if srv.Name != name {
 						continue
@@ -481,12 +548,29 @@ func (b *GatewayBuilder) Run(ctx context.Context, inst *GatewayInstance, verbose
 	gateway.StartHealthMonitor(ctx, mcp.DefaultHealthCheckInterval)
 	gateway.StartAutoscaler(ctx, mcp.DefaultAutoscalerInterval)
 
+	// Announce the gateway on the LAN when opted in. Failure (no multicast
+	// route, port 5353 unavailable) only costs discoverability.
+	if b.stack.Gateway != nil && b.stack.Gateway.Advertise {
+		svc := discovery.Service{Instance: b.stack.Name, Port: b.config.Port, Stack: b.stack.Name, Version: b.version}
+		if _, err := discovery.Advertise(ctx, svc, slog.New(bufferHandler)); err != nil {
+			slog.New(bufferHandler).Warn("mDNS advertisement unavailable", "error", err)
+		}
+	}
+
 	// Start background skill update check (non-blocking)
 	skills.CheckUpdatesBackground(
 		filepath.Join(state.BaseDir(), "registry"),
 		slog.New(bufferHandler),
 	)
 
+	// Keep skills.yaml sources with auto_update synced on their interval.
+	b.startSkillSourceSync(ctx, inst, slog.New(bufferHandler))
+
+	// Start the retention janitor (nil when no retention: block is configured).
+	if b.janitor != nil {
+		b.janitor.Start(ctx, b.stack.Retention.SweepInterval())
+	}
+
 	// Start the telemetry metrics flusher (no-op when no server opts in).
 	if b.telemetry != nil && b.telemetry.metricsFlusher != nil {
 		b.telemetry.metricsFlusher.Start()
@@ -514,11 +598,26 @@ func (b *GatewayBuilder) buildLogging(verbose bool) (*logging.LogBuffer, slog.Ha
 
 	logLevel := effectiveLogLevel(b.config)
 
+	// Console output defaults to JSON when verbose and text in a daemon
+	// child; stack.yaml logging.console overrides format and timestamps.
 	var innerHandler slog.Handler
-	if verbose {
-		innerHandler = slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})
-	} else if b.config.DaemonChild {
-		innerHandler = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})
+	if verbose || b.config.DaemonChild {
+		consoleOpts := logging.OutputOpts{Format: logging.FormatText}
+		if verbose {
+			consoleOpts.Format = logging.FormatJSON
+		}
+		if b.stack.Logging != nil && b.stack.Logging.Console != nil {
+			c := b.stack.Logging.Console
+			opts, err := logging.NewOutputOpts(c.Format, c.TimeFormat, c.Timezone)
+			if err != nil {
+				return nil, nil, fmt.Errorf("logging.console: %w", err)
+			}
+			if opts.Format == "" {
+				opts.Format = consoleOpts.Format
+			}
+			consoleOpts = opts
+		}
+		innerHandler = logging.NewOutputHandler(os.Stderr, logLevel, consoleOpts)
 	}
 
 	// Wire file output: CLI flag takes precedence over stack.yaml logging.file.
@@ -532,6 +631,12 @@ func (b *GatewayBuilder) buildLogging(verbose bool) (*logging.LogBuffer, slog.Ha
 			fileOpts.MaxSizeMB = b.stack.Logging.MaxSizeMB
 			fileOpts.MaxAgeDays = b.stack.Logging.MaxAgeDays
 			fileOpts.MaxBackups = b.stack.Logging.MaxBackups
+			out := b.stack.Logging.LogOutputConfig
+			opts, err := logging.NewOutputOpts(out.Format, out.TimeFormat, out.Timezone)
+			if err != nil {
+				return nil, nil, fmt.Errorf("logging: %w", err)
+			}
+			fileOpts.Output = opts
 		}
 		fileHandler, err := logging.NewFileHandler(logFilePath, fileOpts)
 		if err != nil {
@@ -593,8 +698,27 @@ func (b *GatewayBuilder) buildAPIServer(gateway *mcp.Gateway, logBuffer *logging
 		server.SetAllowedOrigins([]string{"*"})
 	}
 
+	if b.stack.Gateway != nil && b.stack.Gateway.BasePath != "" {
+		server.SetBasePath(strings.TrimRight(b.stack.Gateway.BasePath, "/"))
+	}
+
+	if b.stack.Gateway != nil && b.stack.Gateway.RecordRequests {
+		requestLog := mcp.NewRequestLogger(state.RequestLogDir(b.stack.Name))
+		if handler != nil {
+			requestLog.SetLogger(slog.New(handler))
+		}
+		server.SetRequestLogger(requestLog)
+	}
+
 	if b.stack.Gateway != nil && b.stack.Gateway.Auth != nil {
 		server.SetAuth(b.stack.Gateway.Auth.Type, b.stack.Gateway.Auth.Token, b.stack.Gateway.Auth.Header)
+		if len(b.stack.Gateway.Auth.Keys) > 0 {
+			keys := make([]api.APIKey, 0, len(b.stack.Gateway.Auth.Keys))
+			for _, k := range b.stack.Gateway.Auth.Keys {
+				keys = append(keys, api.APIKey{Name: k.Name, Key: k.Key})
+			}
+			server.SetAuthKeys(keys)
+		}
 	}
 
 	if registryServer != nil {
@@ -646,6 +770,12 @@ func (b *GatewayBuilder) buildAPIServer(gateway *mcp.Gateway, logBuffer *logging
 		return b.currentLimitsPolicy().Status()
 	})
 
+	// Tool call interceptors: latency recording always (sampled per
+	// gateway.analytics.sample_rate), audit logging and provenance when set.
+	b.latency = mcp.NewLatencyRecorder()
+	b.applyInterceptors(gateway, b.stack, limitsLogger)
+	server.SetLatencyRecorder(b.latency)
+
 	// Wire the wizard's "Discover tools" probe. Scope: external URL
 	// servers only — container / stdio / local-process / SSH / OpenAPI are
 	// curated post-deploy from the Stack sidebar.
@@ -727,6 +857,7 @@ func clientAccessSpec(stack *config.Stack) *mcp.ClientAccessSpec {
 			Aliases: profile.Aliases,
 			Servers: profile.Servers,
 			Tools:   profile.Tools,
+			Skills:  profile.Skills,
 		}
 	}
 	return spec
@@ -763,6 +894,31 @@ func groupsSpec(stack *config.Stack) mcp.GroupsSpec {
 	return spec
 }
 
+// startSkillSourceSync starts the background sync of skills.yaml sources
+// into the registry, refreshing the registry when a sync installs skills.
+// Credential references resolve against the vault.
+func (b *GatewayBuilder) startSkillSourceSync(ctx context.Context, inst *GatewayInstance, logger *slog.Logger) {
+	if inst.RegistryServer == nil {
+		return
+	}
+	store := inst.RegistryServer.Store()
+	imp := skills.NewImporter(store, store.Dir(), skills.LockFilePath(), logger)
+	imp.SetCredentialResolver(func(ref string) (string, error) {
+		if b.vaultStore == nil {
+			return "", fmt.Errorf("vault not configured; cannot resolve %s", ref)
+		}
+		expanded, unresolved, _ := config.ExpandString(ref, config.VaultResolver(b.vaultStore))
+		if len(unresolved) > 0 {
+			return "", fmt.Errorf("vault key %q not found", unresolved[0])
+		}
+		return expanded, nil
+	})
+
+	syncer := skills.NewSourceSyncer(imp, skills.SkillsConfigPath(), logger)
+	syncer.OnChange(func() { refreshRegistry(ctx, inst, logger) })
+	syncer.Start(ctx, skills.DefaultSourceSyncTick)
+}
+
 // lintGroupRenamesAgainstSkills warns when an active skill's SKILL.md still
 // references the ORIGINAL unprefixed name of a tool a group renames: agents
 // following the skill would call a name the group no longer exposes. A
@@ -832,6 +988,30 @@ func (b *GatewayBuilder) applyLimitsPolicy(gateway *mcp.Gateway, stack *config.S
 	b.limitsMu.Unlock()
 }
 
+// applyInterceptors installs the tool call interceptor chain for stack. Audit
+// runs first so its After hook logs the final result after every other
+// interceptor has seen it.
+func (b *GatewayBuilder) applyInterceptors(gateway *mcp.Gateway, stack *config.Stack, logger *slog.Logger) {
+	var chain []mcp.ToolCallInterceptor
+	if stack.Gateway != nil && stack.Gateway.AuditToolCalls {
+		chain = append(chain, mcp.NewAuditInterceptor(logger))
+	}
+	if stack.Gateway != nil && stack.Gateway.Provenance != nil && stack.Gateway.Provenance.Enabled {
+		gatewayID := stack.Gateway.Provenance.GatewayID
+		if gatewayID == "" {
+			gatewayID = stack.Name
+		}
+		chain = append(chain, mcp.NewProvenanceInterceptor(gatewayID))
+	}
+	var sampleRate float64
+	if stack.Gateway != nil && stack.Gateway.Analytics != nil {
+		sampleRate = stack.Gateway.Analytics.SampleRate
+	}
+	b.latency.SetSampleRate(sampleRate)
+	chain = append(chain, b.latency)
+	gateway.SetToolCallInterceptors(chain)
+}
+
 // currentLimitsPolicy returns the live policy under the swap lock.
 func (b *GatewayBuilder) currentLimitsPolicy() *limits.Policy {
 	b.limitsMu.Lock()
@@ -1121,8 +1301,13 @@ func gatewayDefaultModel(cfg *config.Stack) string {
 }
 
 // buildTracingConfig extracts tracing config from gateway config with defaults.
+// Aggregate-only analytics turn tracing off: traces are per-call records.
 func buildTracingConfig(gw *config.GatewayConfig) *tracing.Config {
 	cfg := tracing.DefaultConfig()
+	if gw != nil && gw.Analytics.AggregateOnly() {
+		cfg.Enabled = false
+		return cfg
+	}
 	if gw == nil || gw.Tracing == nil {
 		return cfg
 	}
@@ -1168,6 +1353,15 @@ func (b *GatewayBuilder) setupHotReload(ctx context.Context, inst *GatewayInstan
 		}
 		return registrar.RegisterOne(ctx, server, runtimes, stackPath)
 	})
+	// Refuse reloads that drop a server skills still use, listing them from
+	// the same dependency graph as GET /api/registry/graph.
+	reloadHandler.SetDependentsFunc(func(server string) []string {
+		if inst.RegistryServer == nil {
+			return nil
+		}
+		graph := registry.BuildDependencyGraph(inst.RegistryServer.Store().ListSkills(), registry.ServerTools(inst.Gateway.Status()))
+		return graph.SkillsUsing(server)
+	})
 	// After a successful reload, refresh per-server telemetry writers so a
 	// YAML-toggled persist setting takes effect without restart. The
 	// callback fires under reload.Handler.mu — keep it allocation-light.
@@ -1183,6 +1377,11 @@ func (b *GatewayBuilder) setupHotReload(ctx context.Context, inst *GatewayInstan
 		// call. Current-window spend carries over for unchanged entries;
 		// raising a cap mid-window never refills spent budget.
 		b.applyLimitsPolicy(inst.Gateway, newCfg, slog.New(handler))
+		// Rebuild the interceptor chain so toggling `audit_tool_calls` or
+		// the latency sample rate takes effect on the next call; recorded
+		// latencies carry over.
+		b.applyInterceptors(inst.Gateway, newCfg, slog.New(handler))
+		inst.Gateway.SetApprovalTimeout(newCfg.Gateway.ResolvedApprovalTimeout())
 		// Rebuild the group policy so `groups:` edits change endpoint
 		// surfaces on the next request. Stateless recompile, no carry-over.
 		// Re-lint skills afterward: a reload can introduce renames whose
@@ -1201,6 +1400,9 @@ func (b *GatewayBuilder) setupHotReload(ctx context.Context, inst *GatewayInstan
 	startWatcher := func(stackPath string) {
 		watchCtx, _ := context.WithCancel(ctx) //nolint:govet,gosec // cancel called on process exit via ctx
 
+		// The watcher never forces: an edit that removes servers skills
+		// depend on is refused, logged, and reported as reload_refused in
+		// GET /api/status until 'gridctl reload --force' applies it.
 		watcher := reload.NewWatcher(stackPath, func() error {
 			result, err := reloadHandler.Reload(watchCtx)
 			if err != nil {
@@ -1215,6 +1417,7 @@ func (b *GatewayBuilder) setupHotReload(ctx context.Context, inst *GatewayInstan
 		watcher.SetLogger(slog.New(handler))
 
 		go func() {
+			defer crash.Recover("stack-watcher")
 			if err := watcher.Watch(watchCtx); err != nil && err != context.Canceled {
 				slog.New(handler).Error("file watcher error", "error", err)
 			}
@@ -1245,6 +1448,7 @@ func (b *GatewayBuilder) setupHotReload(ctx context.Context, inst *GatewayInstan
 		})
 		regWatcher.SetLogger(regLogger)
 		go func() {
+			defer crash.Recover("registry-watcher")
 			if err := regWatcher.Watch(ctx); err != nil && err != context.Canceled {
 				regLogger.Error("registry watcher error", "error", err)
 			}
@@ -129,6 +129,11 @@ func TestBuildTracingConfig_Enabled(t *testing.T) {
 			gw:   &config.GatewayConfig{Tracing: &config.TracingConfig{Enabled: boolPtr(true)}},
 			want: true,
 		},
+		{
+			name: "aggregate analytics disables",
+			gw:   &config.GatewayConfig{Analytics: &config.AnalyticsConfig{Mode: config.AnalyticsModeAggregate}},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/provisioner"
+	"github.com/gridctl/gridctl/pkg/retention"
+	"github.com/gridctl/gridctl/pkg/state"
+)
+
+// Retention categories, as named in the retention: block and GET /api/retention.
+const (
+	retentionRequestLogs   = "request_logs"
+	retentionDaemonLogs    = "daemon_logs"
+	retentionCrashReports  = "crash_reports"
+	retentionSkillBackups  = "skill_backups"
+	retentionClientBackups = "client_backups"
+)
+
+// retentionTargets maps the stack's retention: block to janitor targets.
+// Targets are rebuilt on every sweep: client backups follow whichever
+// clients are installed, and daemon logs skip stacks running at the time.
+// Request logs skip sessions recorded to within the last interval.
+func (b *GatewayBuilder) retentionTargets(cfg *config.HistoryRetentionConfig, regDir string) func() []retention.Target {
+	return func() []retention.Target {
+		var targets []retention.Target
+		if p := retentionPolicy(cfg.RequestLogs); !p.IsZero() {
+			targets = append(targets, retention.Target{
+				Category: retentionRequestLogs,
+				Dir:      state.RequestsDir(),
+				Pattern:  "*.jsonl",
+				// One directory per stack, each limited separately.
+				Recursive: true,
+				Skip:      requestLogInUse(cfg.SweepInterval()),
+				Policy:    p,
+			})
+		}
+		if p := retentionPolicy(cfg.DaemonLogs); !p.IsZero() {
+			targets = append(targets, retention.Target{
+				Category: retentionDaemonLogs,
+				Dir:      state.LogDir(),
+				Pattern:  "*.log",
+				Skip:     b.daemonLogInUse,
+				Policy:   p,
+			})
+		}
+		if p := retentionPolicy(cfg.CrashReports); !p.IsZero() {
+			targets = append(targets, retention.Target{
+				Category: retentionCrashReports,
+				Dir:      state.CrashDir(),
+				Pattern:  "crash-*.json",
+				Policy:   p,
+			})
+		}
+		if p := retentionPolicy(cfg.SkillBackups); !p.IsZero() {
+			targets = append(targets, retention.Target{
+				Category:  retentionSkillBackups,
+				Dir:       regDir,
+				Pattern:   "SKILL.md.pre-*",
+				Recursive: true,
+				Policy:    p,
+			})
+		}
+		if p := retentionPolicy(cfg.ClientBackups); !p.IsZero() {
+			var configPaths []string
+			for _, c := range provisioner.NewRegistry().DetectAll() {
+				configPaths = append(configPaths, c.ConfigPath)
+			}
+			if b.stackPath != "" {
+				configPaths = append(configPaths, b.stackPath)
+			}
+			for _, path := range configPaths {
+				targets = append(targets, retention.Target{
+					Category: retentionClientBackups,
+					Dir:      filepath.Dir(path),
+					Pattern:  provisioner.BackupPattern(path),
+					Policy:   p,
+				})
+			}
+		}
+		return targets
+	}
+}
+
+// daemonLogInUse reports whether a daemon log belongs to this stack or to
+// another stack whose daemon is running.
+func (b *GatewayBuilder) daemonLogInUse(path string) bool {
+	name := strings.TrimSuffix(filepath.Base(path), ".log")
+	if b.stack != nil && name == b.stack.Name {
+		return true
+	}
+	st, err := state.Load(name)
+	return err == nil && state.IsRunning(st)
+}
+
+// requestLogInUse returns a Skip for recorded request sessions that exempts
+// files written to within the last sweep interval, so a session still being
+// recorded, by this stack or another running one, keeps its file.
+func requestLogInUse(interval time.Duration) func(path string) bool {
+	return func(path string) bool {
+		info, err := os.Stat(path)
+		return err == nil && time.Since(info.ModTime()) < interval
+	}
+}
+
+// retentionPolicy converts a configured policy to janitor units.
+func retentionPolicy(p *config.HistoryRetentionPolicy) retention.Policy {
+	if p == nil {
+		return retention.Policy{}
+	}
+	return retention.Policy{
+		MaxAge:  time.Duration(p.MaxAgeDays) * 24 * time.Hour,
+		MaxSize: int64(p.MaxSizeMB) << 20,
+		Keep:    p.Keep,
+	}
+}
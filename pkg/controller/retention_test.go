@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRequestLogInUse(t *testing.T) {
+	dir := t.TempDir()
+	live := filepath.Join(dir, "live.jsonl")
+	idle := filepath.Join(dir, "idle.jsonl")
+	for _, p := range []string{live, idle} {
+		if err := os.WriteFile(p, []byte("{}\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(idle, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	inUse := requestLogInUse(time.Hour)
+	if !inUse(live) {
+		t.Error("a session written to within the interval should be skipped")
+	}
+	if inUse(idle) {
+		t.Error("a session idle for longer than the interval should be swept")
+	}
+	if inUse(filepath.Join(dir, "gone.jsonl")) {
+		t.Error("a missing file should not be skipped")
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/gridctl/gridctl/pkg/config"
 	"github.com/gridctl/gridctl/pkg/logging"
 	"github.com/gridctl/gridctl/pkg/mcp"
 	"github.com/gridctl/gridctl/pkg/mcpauth"
 	"github.com/gridctl/gridctl/pkg/runtime"
+	"github.com/gridctl/gridctl/pkg/sshhosts"
+	"github.com/gridctl/gridctl/pkg/state"
 )
 
 // ServerRegistrar handles MCP server registration with the gateway.
@@ -38,6 +41,11 @@ type ServerRegistrar struct {
 	// per-server authorization state. nil disables brokering (such servers
 	// register with no auth and land in needs-auth on the first 401).
 	broker *mcpauth.Broker
+
+	// inClusterSelf is the ID of the container gridctl runs in (serve
+	// --in-cluster). When set, container HTTP/SSE servers are dialed by
+	// name on their networks instead of through published host ports.
+	inClusterSelf string
 }
 
 // NewServerRegistrar creates a ServerRegistrar.
@@ -75,6 +83,30 @@ func (r *ServerRegistrar) SetAuthBroker(b *mcpauth.Broker) {
 	r.broker = b
 }
 
+// SetInCluster marks gridctl as running in the container self, so container
+// servers are reached over their networks rather than via host ports.
+func (r *ServerRegistrar) SetInCluster(self string) {
+	r.inClusterSelf = self
+}
+
+// containerEndpoint returns the MCP URL the gateway dials for a container
+// HTTP/SSE server: its published host port, or in-cluster its name and
+// container port after joining its networks. An in-cluster failure is
+// logged and falls back to the host port, which then fails readiness.
+func (r *ServerRegistrar) containerEndpoint(name string, hostPort, port int, id runtime.WorkloadID) string {
+	if r.inClusterSelf == "" || id == "" {
+		return fmt.Sprintf("http://localhost:%d/mcp", hostPort)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), inClusterReachTimeout)
+	defer cancel()
+	addr, err := reachContainer(ctx, r.runtime, r.inClusterSelf, id, port)
+	if err != nil {
+		r.logger.Warn("in-cluster: cannot reach server over its network", "server", name, "error", err)
+		return fmt.Sprintf("http://localhost:%d/mcp", hostPort)
+	}
+	return "http://" + addr + "/mcp"
+}
+
 // wireOAuth registers an external oauth-type server with the broker and
 // returns its live header source. Returns nil (no auth attached) for
 // non-oauth configs or when no broker is wired.
@@ -187,7 +219,9 @@ func (r *ServerRegistrar) RegisterOne(ctx context.Context, server config.MCPServ
 	}
 	cfgs := make([]mcp.MCPServerConfig, 0, len(replicas))
 	for _, rep := range replicas {
-		cfgs = append(cfgs, r.buildConfigFromMCPServer(server, rep.HostPort, rep.ContainerID, stackPath))
+		cfg := r.buildConfigFromMCPServer(server, rep.HostPort, rep.ContainerID, stackPath)
+		applyCallSettings(&cfg, server)
+		cfgs = append(cfgs, cfg)
 	}
 	policy := server.ReplicaPolicy
 	if policy == "" {
@@ -207,6 +241,7 @@ func (r *ServerRegistrar) registerAutoscaled(ctx context.Context, server config.
 
 	template := r.buildConfigFromMCPServer(server, 0, "", stackPath)
 	template.CleanupOnReadyFailure = nil // spawner's own cleanup takes over
+	applyCallSettings(&template, server)
 
 	var spawner mcp.Spawner
 	switch {
@@ -241,6 +276,7 @@ func (r *ServerRegistrar) registerAutoscaled(ctx context.Context, server config.
 			Ports:     NewAtomicPortAllocator(hostPortBase),
 			Logger:    r.logger,
 			InitialID: 0,
+			InCluster: r.inClusterSelf,
 		})
 	default:
 		return fmt.Errorf("autoscale not supported for %s transport", server.Name)
@@ -302,7 +338,9 @@ func (r *ServerRegistrar) buildReplicaConfigs(server runtime.MCPServerResult, se
 			SSHIdentityFile: server.SSHIdentityFile,
 			OpenAPIConfig:   server.OpenAPIConfig,
 		}
-		cfgs = append(cfgs, r.buildServerConfig(perReplica, serverCfg, stackPath))
+		cfg := r.buildServerConfig(perReplica, serverCfg, stackPath)
+		applyCallSettings(&cfg, serverCfg)
+		cfgs = append(cfgs, cfg)
 	}
 	return cfgs
 }
@@ -315,49 +353,59 @@ func (r *ServerRegistrar) buildServerConfig(server runtime.MCPServerResult, serv
 
 	if server.External {
 		return mcp.MCPServerConfig{
-			Name:         server.Name,
-			Transport:    transport,
-			Endpoint:     server.URL,
-			External:     true,
-			Auth:         mapServerAuth(serverCfg.Auth),
-			HeaderSource: r.wireOAuth(server.Name, server.URL, &serverCfg),
-			Tools:        serverCfg.Tools,
-			OutputFormat: serverCfg.OutputFormat,
-			PinSchemas:   serverCfg.PinSchemas,
-			PingTimeout:  serverCfg.ResolvedPingTimeout(),
+			Name:              server.Name,
+			Transport:         transport,
+			Endpoint:          server.URL,
+			External:          true,
+			Auth:              mapServerAuth(serverCfg.Auth),
+			HeaderSource:      r.wireOAuth(server.Name, server.URL, &serverCfg),
+			Tools:             serverCfg.Tools,
+			OutputFormat:      serverCfg.OutputFormat,
+			PinSchemas:        serverCfg.PinSchemas,
+			ValidateArguments: serverCfg.ValidateArguments,
+			LenientResponses:  serverCfg.LenientResponses,
+			Roots:             serverCfg.Roots,
+			PingTimeout:       serverCfg.ResolvedPingTimeout(),
 		}
 	}
 	if server.LocalProcess {
 		return mcp.MCPServerConfig{
-			Name:         server.Name,
-			LocalProcess: true,
-			Command:      server.Command,
-			WorkDir:      filepath.Dir(stackPath),
-			Env:          serverCfg.Env,
-			Tools:        serverCfg.Tools,
-			OutputFormat: serverCfg.OutputFormat,
-			PinSchemas:   serverCfg.PinSchemas,
-			PingTimeout:  serverCfg.ResolvedPingTimeout(),
+			Name:              server.Name,
+			LocalProcess:      true,
+			Command:           server.Command,
+			WorkDir:           filepath.Dir(stackPath),
+			Env:               serverCfg.Env,
+			Tools:             serverCfg.Tools,
+			OutputFormat:      serverCfg.OutputFormat,
+			PinSchemas:        serverCfg.PinSchemas,
+			ValidateArguments: serverCfg.ValidateArguments,
+			LenientResponses:  serverCfg.LenientResponses,
+			Roots:             serverCfg.Roots,
+			PingTimeout:       serverCfg.ResolvedPingTimeout(),
 		}
 	}
 	if server.SSH {
 		cfg := mcp.MCPServerConfig{
-			Name:            server.Name,
-			SSH:             true,
-			Command:         server.Command,
-			SSHHost:         server.SSHHost,
-			SSHUser:         server.SSHUser,
-			SSHPort:         server.SSHPort,
-			SSHIdentityFile: server.SSHIdentityFile,
-			Env:             serverCfg.Env,
-			Tools:           serverCfg.Tools,
-			OutputFormat:    serverCfg.OutputFormat,
-			PinSchemas:      serverCfg.PinSchemas,
-			PingTimeout:     serverCfg.ResolvedPingTimeout(),
+			Name:              server.Name,
+			SSH:               true,
+			Command:           server.Command,
+			SSHHost:           server.SSHHost,
+			SSHUser:           server.SSHUser,
+			SSHPort:           server.SSHPort,
+			SSHIdentityFile:   server.SSHIdentityFile,
+			Env:               serverCfg.Env,
+			Tools:             serverCfg.Tools,
+			OutputFormat:      serverCfg.OutputFormat,
+			PinSchemas:        serverCfg.PinSchemas,
+			ValidateArguments: serverCfg.ValidateArguments,
+			LenientResponses:  serverCfg.LenientResponses,
+			Roots:             serverCfg.Roots,
+			PingTimeout:       serverCfg.ResolvedPingTimeout(),
 		}
 		if serverCfg.SSH != nil {
-			cfg.SSHKnownHostsFile = serverCfg.SSH.KnownHostsFile
+			cfg.SSHKnownHostsFile = sshKnownHostsFile(serverCfg.SSH)
 			cfg.SSHJumpHost = serverCfg.SSH.JumpHost
+			cfg.SSHDeployBinary, cfg.SSHDeployPath = sshDeployBinary(server.Name, serverCfg.SSH)
 		}
 		return cfg
 	}
@@ -365,18 +413,23 @@ func (r *ServerRegistrar) buildServerConfig(server runtime.MCPServerResult, serv
 		cfg := r.buildOpenAPIConfig(server.Name, server.OpenAPIConfig, serverCfg.Tools)
 		cfg.OutputFormat = serverCfg.OutputFormat
 		cfg.PinSchemas = serverCfg.PinSchemas
+		cfg.ValidateArguments = serverCfg.ValidateArguments
+		cfg.LenientResponses = serverCfg.LenientResponses
 		cfg.PingTimeout = serverCfg.ResolvedPingTimeout()
 		return cfg
 	}
 	if transport == mcp.TransportStdio {
 		return mcp.MCPServerConfig{
-			Name:         server.Name,
-			Transport:    transport,
-			ContainerID:  string(server.WorkloadID),
-			Tools:        serverCfg.Tools,
-			OutputFormat: serverCfg.OutputFormat,
-			PinSchemas:   serverCfg.PinSchemas,
-			PingTimeout:  serverCfg.ResolvedPingTimeout(),
+			Name:              server.Name,
+			Transport:         transport,
+			ContainerID:       string(server.WorkloadID),
+			Tools:             serverCfg.Tools,
+			OutputFormat:      serverCfg.OutputFormat,
+			PinSchemas:        serverCfg.PinSchemas,
+			ValidateArguments: serverCfg.ValidateArguments,
+			LenientResponses:  serverCfg.LenientResponses,
+			Roots:             serverCfg.Roots,
+			PingTimeout:       serverCfg.ResolvedPingTimeout(),
 		}
 	}
 	// Container HTTP/SSE
@@ -392,46 +445,58 @@ func (r *ServerRegistrar) buildConfigFromMCPServer(server config.MCPServer, host
 
 	if server.IsExternal() {
 		return mcp.MCPServerConfig{
-			Name:         server.Name,
-			Transport:    transport,
-			Endpoint:     server.URL,
-			External:     true,
-			Auth:         mapServerAuth(server.Auth),
-			HeaderSource: r.wireOAuth(server.Name, server.URL, &server),
-			Tools:        server.Tools,
-			OutputFormat: server.OutputFormat,
-			PinSchemas:   server.PinSchemas,
-			PingTimeout:  server.ResolvedPingTimeout(),
+			Name:              server.Name,
+			Transport:         transport,
+			Endpoint:          server.URL,
+			External:          true,
+			Auth:              mapServerAuth(server.Auth),
+			HeaderSource:      r.wireOAuth(server.Name, server.URL, &server),
+			Tools:             server.Tools,
+			OutputFormat:      server.OutputFormat,
+			PinSchemas:        server.PinSchemas,
+			ValidateArguments: server.ValidateArguments,
+			LenientResponses:  server.LenientResponses,
+			Roots:             server.Roots,
+			PingTimeout:       server.ResolvedPingTimeout(),
 		}
 	}
 	if server.IsLocalProcess() {
 		return mcp.MCPServerConfig{
-			Name:         server.Name,
-			LocalProcess: true,
-			Command:      server.Command,
-			WorkDir:      filepath.Dir(stackPath),
-			Env:          server.Env,
-			Tools:        server.Tools,
-			OutputFormat: server.OutputFormat,
-			PinSchemas:   server.PinSchemas,
-			PingTimeout:  server.ResolvedPingTimeout(),
+			Name:              server.Name,
+			LocalProcess:      true,
+			Command:           server.Command,
+			WorkDir:           filepath.Dir(stackPath),
+			Env:               server.Env,
+			Tools:             server.Tools,
+			OutputFormat:      server.OutputFormat,
+			PinSchemas:        server.PinSchemas,
+			ValidateArguments: server.ValidateArguments,
+			LenientResponses:  server.LenientResponses,
+			Roots:             server.Roots,
+			PingTimeout:       server.ResolvedPingTimeout(),
 		}
 	}
 	if server.IsSSH() {
+		deployBinary, deployPath := sshDeployBinary(server.Name, server.SSH)
 		return mcp.MCPServerConfig{
 			Name:              server.Name,
 			SSH:               true,
-			Command:           server.Command,
+			Command:           server.SSHRemoteCommand(),
 			SSHHost:           server.SSH.Host,
 			SSHUser:           server.SSH.User,
 			SSHPort:           server.SSH.Port,
 			SSHIdentityFile:   server.SSH.IdentityFile,
-			SSHKnownHostsFile: server.SSH.KnownHostsFile,
+			SSHKnownHostsFile: sshKnownHostsFile(server.SSH),
 			SSHJumpHost:       server.SSH.JumpHost,
+			SSHDeployBinary:   deployBinary,
+			SSHDeployPath:     deployPath,
 			Env:               server.Env,
 			Tools:             server.Tools,
 			OutputFormat:      server.OutputFormat,
 			PinSchemas:        server.PinSchemas,
+			ValidateArguments: server.ValidateArguments,
+			LenientResponses:  server.LenientResponses,
+			Roots:             server.Roots,
 			PingTimeout:       server.ResolvedPingTimeout(),
 		}
 	}
@@ -439,18 +504,23 @@ func (r *ServerRegistrar) buildConfigFromMCPServer(server config.MCPServer, host
 		cfg := r.buildOpenAPIConfig(server.Name, server.OpenAPI, server.Tools)
 		cfg.OutputFormat = server.OutputFormat
 		cfg.PinSchemas = server.PinSchemas
+		cfg.ValidateArguments = server.ValidateArguments
+		cfg.LenientResponses = server.LenientResponses
 		cfg.PingTimeout = server.ResolvedPingTimeout()
 		return cfg
 	}
 	if transport == mcp.TransportStdio {
 		return mcp.MCPServerConfig{
-			Name:         server.Name,
-			Transport:    transport,
-			ContainerID:  containerID,
-			Tools:        server.Tools,
-			OutputFormat: server.OutputFormat,
-			PinSchemas:   server.PinSchemas,
-			PingTimeout:  server.ResolvedPingTimeout(),
+			Name:              server.Name,
+			Transport:         transport,
+			ContainerID:       containerID,
+			Tools:             server.Tools,
+			OutputFormat:      server.OutputFormat,
+			PinSchemas:        server.PinSchemas,
+			ValidateArguments: server.ValidateArguments,
+			LenientResponses:  server.LenientResponses,
+			Roots:             server.Roots,
+			PingTimeout:       server.ResolvedPingTimeout(),
 		}
 	}
 	// Container HTTP/SSE
@@ -465,16 +535,61 @@ func (r *ServerRegistrar) buildContainerHTTPConfig(name string, transport mcp.Tr
 	return mcp.MCPServerConfig{
 		Name:                  name,
 		Transport:             transport,
-		Endpoint:              fmt.Sprintf("http://localhost:%d/mcp", hostPort),
+		Endpoint:              r.containerEndpoint(name, hostPort, serverCfg.Port, id),
 		Tools:                 serverCfg.Tools,
 		OutputFormat:          serverCfg.OutputFormat,
 		PinSchemas:            serverCfg.PinSchemas,
+		ValidateArguments:     serverCfg.ValidateArguments,
+		LenientResponses:      serverCfg.LenientResponses,
+		Roots:                 serverCfg.Roots,
 		ReadyTimeout:          serverCfg.ResolvedReadyTimeout(),
 		PingTimeout:           serverCfg.ResolvedPingTimeout(),
 		CleanupOnReadyFailure: r.cleanupClosure(name, id),
 	}
 }
 
+// applyCallSettings copies a server's tool call timeout, approval list, and
+// circuit_breaker block onto its gateway config. They apply to every
+// transport, so they are set here once rather than in each transport branch
+// of the builders.
+func applyCallSettings(cfg *mcp.MCPServerConfig, server config.MCPServer) {
+	cfg.Timeout = server.ResolvedTimeout()
+	cfg.RequireApproval = server.RequireApproval
+	cfg.Sampling = server.Sampling
+	if server.CircuitBreaker == nil {
+		return
+	}
+	cfg.CircuitBreakerFailures = server.CircuitBreaker.ResolvedFailures()
+	cfg.CircuitBreakerCooldown = server.CircuitBreaker.ResolvedCooldown()
+}
+
+// managedKnownHostsPath is the known_hosts file 'gridctl ssh trust' writes;
+// a var so tests can point it at a temp file.
+var managedKnownHostsPath = state.KnownHostsPath
+
+// sshDeployBinary returns the local binary an SSH server syncs to its host
+// before starting, and the remote path, or empty strings when it deploys none.
+func sshDeployBinary(name string, s *config.SSHConfig) (string, string) {
+	if s.Deploy == nil || s.Deploy.Binary == "" {
+		return "", ""
+	}
+	return s.Deploy.Binary, s.Deploy.RemotePath(name)
+}
+
+// sshKnownHostsFile picks the known_hosts file that enables strict host key
+// checking for an SSH server: the configured knownHostsFile, else the
+// gridctl-managed file when it lists the host. Empty keeps accept-new TOFU.
+func sshKnownHostsFile(s *config.SSHConfig) string {
+	if s.KnownHostsFile != "" {
+		return s.KnownHostsFile
+	}
+	path := managedKnownHostsPath()
+	if listed, err := sshhosts.Listed(path, s.Host, s.Port); err == nil && listed {
+		return path
+	}
+	return ""
+}
+
 // buildOpenAPIConfig constructs an MCPServerConfig for OpenAPI-backed servers.
 func (r *ServerRegistrar) buildOpenAPIConfig(name string, openAPICfg *config.OpenAPIConfig, tools []string) mcp.MCPServerConfig {
 	cfg := mcp.MCPServerConfig{
@@ -484,6 +599,8 @@ func (r *ServerRegistrar) buildOpenAPIConfig(name string, openAPICfg *config.Ope
 			Spec:     openAPICfg.Spec,
 			BaseURL:  openAPICfg.BaseURL,
 			NoExpand: r.noExpand,
+			GroupBy:  openAPICfg.GroupBy,
+			Mock:     openAPICfg.Mock,
 		},
 		Tools: tools,
 	}
@@ -583,3 +700,17 @@ func (r *ServerRegistrar) cleanupClosure(name string, id runtime.WorkloadID) fun
 		return r.runtime.Remove(ctx, id)
 	}
 }
+
+// inClusterReachTimeout bounds the inspect and network attach done to reach
+// a container server from inside gridctl's own container.
+const inClusterReachTimeout = 30 * time.Second
+
+// reachContainer returns a container workload's address as seen from the
+// container self, attaching self to the workload's networks as needed.
+func reachContainer(ctx context.Context, rt runtime.WorkloadRuntime, self string, id runtime.WorkloadID, port int) (string, error) {
+	reacher, ok := rt.(runtime.ContainerReacher)
+	if !ok {
+		return "", fmt.Errorf("runtime cannot reach containers from inside a container: %w", runtime.ErrNotSupported)
+	}
+	return reacher.ReachFromContainer(ctx, self, id, port)
+}
@@ -2,13 +2,19 @@ package controller
 
 import (
 	"context"
+	"crypto/ed25519"
+	"fmt"
 	"log/slog"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gridctl/gridctl/pkg/config"
 	"github.com/gridctl/gridctl/pkg/mcp"
 	"github.com/gridctl/gridctl/pkg/runtime"
+	"github.com/gridctl/gridctl/pkg/sshhosts"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestResolveTransport(t *testing.T) {
@@ -219,6 +225,54 @@ func TestServerRegistrar_BuildServerConfig_ContainerHTTP(t *testing.T) {
 	}
 }
 
+// reachingRuntime is a container runtime that can route from inside a
+// container, as serve --in-cluster needs.
+type reachingRuntime struct {
+	runtime.WorkloadRuntime
+	self string
+}
+
+func (r *reachingRuntime) ReachFromContainer(_ context.Context, self string, id runtime.WorkloadID, port int) (string, error) {
+	r.self = self
+	return fmt.Sprintf("%s:%d", id, port), nil
+}
+
+func TestServerRegistrar_BuildServerConfig_ContainerHTTP_InCluster(t *testing.T) {
+	rt := &reachingRuntime{}
+	r := NewServerRegistrar(mcp.NewGateway(), false)
+	r.SetRuntime(rt)
+	r.SetInCluster("gridctl-self")
+
+	server := runtime.MCPServerResult{
+		Name:       "http-server",
+		WorkloadID: runtime.WorkloadID("demo-http-server"),
+		HostPort:   9001,
+	}
+	serverCfg := config.MCPServer{Name: "http-server", Transport: "http", Port: 3000}
+
+	cfg := r.buildServerConfig(server, serverCfg, "/path/to/stack.yaml")
+
+	if cfg.Endpoint != "http://demo-http-server:3000/mcp" {
+		t.Errorf("expected the container port on the stack network, got '%s'", cfg.Endpoint)
+	}
+	if rt.self != "gridctl-self" {
+		t.Errorf("expected the runtime to attach gridctl's container, got %q", rt.self)
+	}
+}
+
+func TestServerRegistrar_BuildServerConfig_ContainerHTTP_InClusterUnsupported(t *testing.T) {
+	r := NewServerRegistrar(mcp.NewGateway(), false)
+	r.SetRuntime(&recordingRuntime{})
+	r.SetInCluster("gridctl-self")
+
+	server := runtime.MCPServerResult{Name: "http-server", WorkloadID: "c1", HostPort: 9001}
+	cfg := r.buildServerConfig(server, config.MCPServer{Name: "http-server", Port: 3000}, "/path/to/stack.yaml")
+
+	if cfg.Endpoint != "http://localhost:9001/mcp" {
+		t.Errorf("expected the host port fallback, got '%s'", cfg.Endpoint)
+	}
+}
+
 func TestServerRegistrar_BuildConfigFromMCPServer_External(t *testing.T) {
 	r := NewServerRegistrar(mcp.NewGateway(), false)
 
@@ -285,6 +339,99 @@ func TestServerRegistrar_BuildConfigFromMCPServer_SSH(t *testing.T) {
 	}
 }
 
+func TestServerRegistrar_BuildConfigFromMCPServer_SSHManagedKnownHosts(t *testing.T) {
+	managed := filepath.Join(t.TempDir(), "known_hosts")
+	orig := managedKnownHostsPath
+	managedKnownHostsPath = func() string { return managed }
+	t.Cleanup(func() { managedKnownHostsPath = orig })
+
+	r := NewServerRegistrar(mcp.NewGateway(), false)
+	server := config.MCPServer{
+		Name:    "remote",
+		Command: []string{"/opt/server"},
+		SSH:     &config.SSHConfig{Host: "10.0.0.1", User: "admin", Port: 2222},
+	}
+
+	// Untrusted host keeps accept-new.
+	if cfg := r.buildConfigFromMCPServer(server, 0, "", "/path/stack.yaml"); cfg.SSHKnownHostsFile != "" {
+		t.Errorf("untrusted host should not use a known_hosts file, got %q", cfg.SSHKnownHostsFile)
+	}
+
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sshhosts.Append(managed, "10.0.0.1", 2222, key); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := r.buildConfigFromMCPServer(server, 0, "", "/path/stack.yaml"); cfg.SSHKnownHostsFile != managed {
+		t.Errorf("trusted host should use the managed file, got %q", cfg.SSHKnownHostsFile)
+	}
+
+	// An explicit knownHostsFile always wins.
+	server.SSH.KnownHostsFile = "/etc/ssh/known_hosts"
+	if cfg := r.buildConfigFromMCPServer(server, 0, "", "/path/stack.yaml"); cfg.SSHKnownHostsFile != "/etc/ssh/known_hosts" {
+		t.Errorf("explicit knownHostsFile should win, got %q", cfg.SSHKnownHostsFile)
+	}
+}
+
+func TestServerRegistrar_BuildConfigFromMCPServer_SSHDeploy(t *testing.T) {
+	r := NewServerRegistrar(mcp.NewGateway(), false)
+
+	binary := config.MCPServer{
+		Name: "remote",
+		SSH:  &config.SSHConfig{Host: "10.0.0.1", User: "admin", Deploy: &config.SSHDeployConfig{Binary: "/build/server"}},
+	}
+	cfg := r.buildConfigFromMCPServer(binary, 0, "", "/path/stack.yaml")
+	if cfg.SSHDeployBinary != "/build/server" || cfg.SSHDeployPath != ".gridctl/bin/remote" {
+		t.Errorf("deploy = %q -> %q", cfg.SSHDeployBinary, cfg.SSHDeployPath)
+	}
+	if len(cfg.Command) != 1 || cfg.Command[0] != ".gridctl/bin/remote" {
+		t.Errorf("expected the deployed binary as the command, got %v", cfg.Command)
+	}
+
+	image := config.MCPServer{
+		Name: "remote",
+		SSH:  &config.SSHConfig{Host: "10.0.0.1", User: "admin", Deploy: &config.SSHDeployConfig{Image: "acme/mcp:1"}},
+	}
+	cfg = r.buildConfigFromMCPServer(image, 0, "", "/path/stack.yaml")
+	if cfg.SSHDeployBinary != "" {
+		t.Errorf("image deploys should not sync a binary, got %q", cfg.SSHDeployBinary)
+	}
+	if got := strings.Join(cfg.Command, " "); got != "docker run -i --rm --pull=always acme/mcp:1" {
+		t.Errorf("command = %q", got)
+	}
+}
+
+func TestApplyCallSettings(t *testing.T) {
+	var cfg mcp.MCPServerConfig
+	applyCallSettings(&cfg, config.MCPServer{Name: "s"})
+	if cfg.CircuitBreakerFailures != 0 {
+		t.Errorf("no circuit_breaker block should leave the breaker off, got %d", cfg.CircuitBreakerFailures)
+	}
+	if cfg.Timeout != 0 {
+		t.Errorf("no timeout should inherit the gateway default, got %v", cfg.Timeout)
+	}
+
+	applyCallSettings(&cfg, config.MCPServer{Name: "s", Timeout: "2m", RequireApproval: []string{"delete"}, Sampling: true, CircuitBreaker: &config.CircuitBreakerConfig{Cooldown: "1m"}})
+	if cfg.CircuitBreakerFailures != config.DefaultCircuitBreakerFailures || cfg.CircuitBreakerCooldown != time.Minute {
+		t.Errorf("breaker = %d / %v, want default failures and 1m", cfg.CircuitBreakerFailures, cfg.CircuitBreakerCooldown)
+	}
+	if cfg.Timeout != 2*time.Minute {
+		t.Errorf("timeout = %v, want 2m", cfg.Timeout)
+	}
+	if len(cfg.RequireApproval) != 1 || cfg.RequireApproval[0] != "delete" {
+		t.Errorf("require_approval = %v, want [delete]", cfg.RequireApproval)
+	}
+	if !cfg.Sampling {
+		t.Error("sampling not copied")
+	}
+}
+
 func TestServerRegistrar_BuildConfigFromMCPServer_ContainerHTTP(t *testing.T) {
 	r := NewServerRegistrar(mcp.NewGateway(), false)
 
@@ -58,6 +58,7 @@ type ContainerSpawner struct {
 	transport string
 	ports     PortAllocator
 	logger    *slog.Logger
+	inCluster string // own container ID under serve --in-cluster
 
 	idCounter atomic.Int64
 
@@ -78,6 +79,7 @@ type ContainerSpawnerOptions struct {
 	Ports     PortAllocator
 	Logger    *slog.Logger
 	InitialID int // next replica id to assign (typically set.Size() at register time)
+	InCluster string // ID of the container gridctl runs in; dial replicas over their network
 }
 
 // NewContainerSpawner constructs a ContainerSpawner from explicit options.
@@ -96,6 +98,7 @@ func NewContainerSpawner(opts ContainerSpawnerOptions) *ContainerSpawner {
 		transport: opts.Transport,
 		ports:     opts.Ports,
 		logger:    logger,
+		inCluster: opts.InCluster,
 		workloads: make(map[mcp.AgentClient]runtime.WorkloadID),
 	}
 	cs.idCounter.Store(int64(opts.InitialID))
@@ -159,7 +162,17 @@ func (c *ContainerSpawner) Spawn(ctx context.Context) (mcp.AgentClient, error) {
 		}
 	}
 
-	client, err := c.builder.BuildAgentClient(ctx, c.buildClientConfig(actualHostPort, status.ID))
+	clientCfg := c.buildClientConfig(actualHostPort, status.ID)
+	if c.inCluster != "" && clientCfg.Transport != mcp.TransportStdio {
+		addr, err := reachContainer(ctx, c.rt, c.inCluster, status.ID, c.server.Port)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("reach container %s: %w", name, err)
+		}
+		clientCfg.Endpoint = "http://" + addr + "/mcp"
+	}
+
+	client, err := c.builder.BuildAgentClient(ctx, clientCfg)
 	if err != nil {
 		cleanup()
 		return nil, fmt.Errorf("build client %s: %w", name, err)
@@ -210,12 +223,15 @@ func (c *ContainerSpawner) Reap(ctx context.Context, r *mcp.Replica) error {
 // stdio containers consume containerID; http/sse consume the endpoint URL.
 func (c *ContainerSpawner) buildClientConfig(hostPort int, id runtime.WorkloadID) mcp.MCPServerConfig {
 	cfg := mcp.MCPServerConfig{
-		Name:         c.server.Name,
-		Transport:    mcp.Transport(c.transport),
-		Tools:        c.server.Tools,
-		OutputFormat: c.server.OutputFormat,
-		PinSchemas:   c.server.PinSchemas,
-		ReadyTimeout: c.server.ResolvedReadyTimeout(),
+		Name:              c.server.Name,
+		Transport:         mcp.Transport(c.transport),
+		Tools:             c.server.Tools,
+		OutputFormat:      c.server.OutputFormat,
+		PinSchemas:        c.server.PinSchemas,
+		ValidateArguments: c.server.ValidateArguments,
+		LenientResponses:  c.server.LenientResponses,
+		Roots:             c.server.Roots,
+		ReadyTimeout:      c.server.ResolvedReadyTimeout(),
 	}
 	if cfg.Transport == "" {
 		cfg.Transport = mcp.TransportHTTP
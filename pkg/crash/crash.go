@@ -0,0 +1,253 @@
+// Package crash recovers panics in daemon goroutines and records them as
+// structured crash reports, so one misbehaving reader or handler degrades
+// a single server or request instead of taking the whole gateway down.
+//
+// The daemon calls Configure once at startup; until then (and in the CLI)
+// recovered panics are still logged, just not written to disk.
+package crash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/logging"
+)
+
+const (
+	// recentEventCount is how many buffered log entries a report carries.
+	recentEventCount = 50
+	// maxReports caps the crash directory so a panic loop cannot fill the
+	// disk; the oldest reports are pruned first.
+	maxReports = 50
+)
+
+// Config wires the crash reporter to the running daemon.
+type Config struct {
+	// Dir is where crash reports are written. Empty disables report files.
+	Dir string
+	// Logger receives a structured error record for every recovered panic.
+	Logger *slog.Logger
+	// Recent returns the most recent n log entries for the report.
+	Recent func(n int) []logging.BufferedEntry
+	// Labels identify the process in every report (stack name, version).
+	Labels map[string]string
+}
+
+// Report is the on-disk crash report.
+type Report struct {
+	Time         time.Time               `json:"time"`
+	Goroutine    string                  `json:"goroutine"`
+	Panic        string                  `json:"panic"`
+	Attrs        map[string]string       `json:"attrs,omitempty"`
+	Labels       map[string]string       `json:"labels,omitempty"`
+	GoVersion    string                  `json:"go_version"`
+	Stack        string                  `json:"stack"`
+	RecentEvents []logging.BufferedEntry `json:"recent_events,omitempty"`
+}
+
+var (
+	mu  sync.RWMutex
+	cfg Config
+)
+
+// Configure installs the process-wide crash reporter configuration.
+func Configure(c Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	cfg = c
+}
+
+func current() Config {
+	mu.RLock()
+	defer mu.RUnlock()
+	return cfg
+}
+
+// Recover must be deferred directly at the top of a goroutine:
+//
+//	defer crash.Recover("stdio-reader", "server", name)
+//
+// It stops a panic from unwinding further, logs it, and writes a crash
+// report. attrs are key/value pairs identifying the goroutine's subject.
+// Defers registered after it still run first, so cleanup (draining
+// pending requests, closing pipes) happens before the report is written.
+func Recover(name string, attrs ...any) {
+	if v := recover(); v != nil {
+		handle(name, v, attrs)
+	}
+}
+
+// Go runs fn in a new goroutine with panic recovery.
+func Go(name string, fn func(), attrs ...any) {
+	go func() {
+		defer Recover(name, attrs...)
+		fn()
+	}()
+}
+
+// Guard runs fn on the calling goroutine with panic recovery and reports
+// whether it panicked. Loops use it per iteration so one bad tick does not
+// end the loop.
+func Guard(name string, fn func(), attrs ...any) (panicked bool) {
+	defer func() {
+		if v := recover(); v != nil {
+			panicked = true
+			handle(name, v, attrs)
+		}
+	}()
+	fn()
+	return false
+}
+
+// Handler wraps next so a panicking request (including a long-lived SSE
+// stream) is reported and answered with a 500 when nothing has been
+// written yet. http.ErrAbortHandler keeps its net/http meaning and is
+// re-raised untouched.
+func Handler(name string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &trackingWriter{ResponseWriter: w}
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(v)
+			}
+			handle(name, v, []any{"method", r.Method, "path", r.URL.Path})
+			if !rw.wrote {
+				http.Error(w, `{"error":"internal server error"}`, http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// trackingWriter records whether the response has started so Handler
+// knows if an error status can still be sent. Flush is forwarded so SSE
+// streaming keeps working through the wrapper.
+type trackingWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (t *trackingWriter) WriteHeader(code int) {
+	t.wrote = true
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *trackingWriter) Write(b []byte) (int, error) {
+	t.wrote = true
+	return t.ResponseWriter.Write(b)
+}
+
+func (t *trackingWriter) Flush() {
+	if f, ok := t.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (t *trackingWriter) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+// handle logs and persists one recovered panic. It never panics itself.
+func handle(name string, v any, attrs []any) {
+	c := current()
+	report := Report{
+		Time:      time.Now().UTC(),
+		Goroutine: name,
+		Panic:     fmt.Sprint(v),
+		Attrs:     attrMap(attrs),
+		Labels:    c.Labels,
+		GoVersion: runtime.Version(),
+		Stack:     string(debug.Stack()),
+	}
+	if c.Recent != nil {
+		report.RecentEvents = c.Recent(recentEventCount)
+	}
+
+	path, err := write(c.Dir, report)
+	logger := c.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	args := append([]any{"goroutine", name, "panic", report.Panic}, attrs...)
+	if path != "" {
+		args = append(args, "report", path)
+	}
+	if err != nil {
+		args = append(args, "report_error", err)
+	}
+	logger.Error("recovered panic", args...)
+}
+
+// write persists report under dir and prunes old reports. An empty dir
+// writes nothing.
+func write(dir string, report Report) (string, error) {
+	if dir == "" {
+		return "", nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("creating crash directory: %w", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding crash report: %w", err)
+	}
+	name := fmt.Sprintf("crash-%s-%s.json", report.Time.Format("20060102T150405.000000000Z"), sanitize(report.Goroutine))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return "", fmt.Errorf("writing crash report: %w", err)
+	}
+	prune(dir, maxReports)
+	return path, nil
+}
+
+// prune removes the oldest crash reports beyond keep. Report names sort
+// chronologically, so lexical order is age order.
+func prune(dir string, keep int) {
+	matches, err := filepath.Glob(filepath.Join(dir, "crash-*.json"))
+	if err != nil || len(matches) <= keep {
+		return
+	}
+	sort.Strings(matches)
+	for _, m := range matches[:len(matches)-keep] {
+		_ = os.Remove(m)
+	}
+}
+
+// sanitize makes a goroutine name safe for a file name.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '-'
+	}, s)
+}
+
+// attrMap flattens key/value pairs into strings for the report.
+func attrMap(attrs []any) map[string]string {
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(attrs)/2)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		m[fmt.Sprint(attrs[i])] = fmt.Sprint(attrs[i+1])
+	}
+	return m
+}
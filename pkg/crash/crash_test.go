@@ -0,0 +1,233 @@
+package crash
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/logging"
+)
+
+// configureForTest points the reporter at a temp dir and a captured
+// logger, restoring the previous configuration afterwards.
+func configureForTest(t *testing.T) (string, *bytes.Buffer) {
+	t.Helper()
+	dir := t.TempDir()
+	var logs bytes.Buffer
+	prev := current()
+	Configure(Config{
+		Dir:    dir,
+		Logger: slog.New(slog.NewTextHandler(&logs, nil)),
+		Recent: func(n int) []logging.BufferedEntry {
+			return []logging.BufferedEntry{{Level: "INFO", Message: "before the crash"}}
+		},
+		Labels: map[string]string{"stack": "demo"},
+	})
+	t.Cleanup(func() { Configure(prev) })
+	return dir, &logs
+}
+
+func readReports(t *testing.T, dir string) []Report {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, "crash-*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reports []Report
+	for _, m := range matches {
+		data, err := os.ReadFile(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var r Report
+		if err := json.Unmarshal(data, &r); err != nil {
+			t.Fatalf("report %s is not JSON: %v", m, err)
+		}
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+func TestRecoverWritesReport(t *testing.T) {
+	dir, logs := configureForTest(t)
+
+	func() {
+		defer Recover("stdio-reader", "server", "github")
+		panic("boom")
+	}()
+
+	reports := readReports(t, dir)
+	if len(reports) != 1 {
+		t.Fatalf("expected one crash report, got %d", len(reports))
+	}
+	r := reports[0]
+	if r.Goroutine != "stdio-reader" || r.Panic != "boom" {
+		t.Errorf("report = %+v", r)
+	}
+	if r.Attrs["server"] != "github" || r.Labels["stack"] != "demo" {
+		t.Errorf("attrs/labels = %v / %v", r.Attrs, r.Labels)
+	}
+	if !strings.Contains(r.Stack, "TestRecoverWritesReport") {
+		t.Errorf("expected the panicking frame in the stack trace:\n%s", r.Stack)
+	}
+	if len(r.RecentEvents) != 1 || r.RecentEvents[0].Message != "before the crash" {
+		t.Errorf("recent events = %+v", r.RecentEvents)
+	}
+	if !strings.Contains(logs.String(), "recovered panic") {
+		t.Errorf("expected a structured log record, got %q", logs.String())
+	}
+}
+
+func TestRecoverNoPanicIsNoOp(t *testing.T) {
+	dir, logs := configureForTest(t)
+	func() {
+		defer Recover("quiet")
+	}()
+	if len(readReports(t, dir)) != 0 || logs.Len() != 0 {
+		t.Error("expected no report and no log without a panic")
+	}
+}
+
+func TestGoKeepsProcessAlive(t *testing.T) {
+	dir, _ := configureForTest(t)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	Go("worker", func() {
+		defer wg.Done()
+		panic(errors.New("worker failed"))
+	})
+	wg.Wait()
+
+	// The report is written by the deferred Recover after wg.Done; poll
+	// briefly rather than racing it.
+	for i := 0; i < 100 && len(readReports(t, dir)) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := len(readReports(t, dir)); got != 1 {
+		t.Fatalf("expected one crash report, got %d", got)
+	}
+}
+
+func TestGuardReportsAndContinues(t *testing.T) {
+	dir, _ := configureForTest(t)
+
+	ticks := 0
+	for i := 0; i < 3; i++ {
+		panicked := Guard("health-monitor", func() {
+			ticks++
+			if ticks == 2 {
+				panic("bad tick")
+			}
+		})
+		if panicked != (i == 1) {
+			t.Errorf("tick %d: panicked = %v", i, panicked)
+		}
+	}
+	if ticks != 3 {
+		t.Errorf("expected the loop to continue past the panic, ran %d ticks", ticks)
+	}
+	if len(readReports(t, dir)) != 1 {
+		t.Error("expected one crash report")
+	}
+}
+
+func TestHandlerRecoversWith500(t *testing.T) {
+	dir, _ := configureForTest(t)
+
+	h := Handler("api", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("handler bug")
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	reports := readReports(t, dir)
+	if len(reports) != 1 || reports[0].Attrs["path"] != "/api/status" {
+		t.Errorf("reports = %+v", reports)
+	}
+}
+
+func TestHandlerPassesThroughAndFlushes(t *testing.T) {
+	configureForTest(t)
+
+	h := Handler("api", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Error("wrapped writer must still implement http.Flusher for SSE")
+			return
+		}
+		fmt.Fprint(w, "data: hi\n\n")
+		flusher.Flush()
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "data: hi\n\n" || !rec.Flushed {
+		t.Errorf("code=%d body=%q flushed=%v", rec.Code, rec.Body.String(), rec.Flushed)
+	}
+}
+
+func TestHandlerReraisesAbort(t *testing.T) {
+	configureForTest(t)
+
+	h := Handler("api", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("expected ErrAbortHandler to propagate, got %v", v)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestUnconfiguredWritesNoFiles(t *testing.T) {
+	prev := current()
+	Configure(Config{Logger: slog.New(logging.DiscardHandler{})})
+	t.Cleanup(func() { Configure(prev) })
+
+	if path, err := write("", Report{}); path != "" || err != nil {
+		t.Errorf("write with empty dir = %q, %v", path, err)
+	}
+	func() {
+		defer Recover("cli")
+		panic("no dir")
+	}()
+}
+
+func TestPruneKeepsNewest(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 5; i++ {
+		name := filepath.Join(dir, fmt.Sprintf("crash-2026010%dT000000.000000000Z-x.json", i))
+		if err := os.WriteFile(name, []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prune(dir, 2)
+	matches, _ := filepath.Glob(filepath.Join(dir, "crash-*.json"))
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 reports after prune, got %d", len(matches))
+	}
+	if !strings.Contains(matches[0], "20260103") || !strings.Contains(matches[1], "20260104") {
+		t.Errorf("expected the newest reports kept, got %v", matches)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	if got := sanitize("stdio reader/github"); got != "stdio-reader-github" {
+		t.Errorf("sanitize = %q", got)
+	}
+}
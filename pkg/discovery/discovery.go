@@ -0,0 +1,393 @@
+// Package discovery advertises running gateways on the local network over
+// multicast DNS (RFC 6762 / DNS-SD, RFC 6763) and finds them again. A
+// gateway with gateway.advertise enabled answers queries for the
+// _gridctl._tcp service type with its stack name, port, and version;
+// `gridctl discover` sends one such query and lists whoever replies.
+//
+// Only IPv4 is supported, and only what gridctl itself needs: the responder
+// answers PTR, SRV, TXT, and A questions about its own records and ignores
+// everything else, and the browser accepts legacy unicast replies, so no
+// other mDNS stack is required on either side.
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/logging"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// ServiceType is the DNS-SD service type gateways are advertised under.
+const ServiceType = "_gridctl._tcp"
+
+const (
+	mdnsPort = 5353
+	// recordTTL is the lifetime, in seconds, of advertised records.
+	// Goodbye packets send the same records with TTL 0.
+	recordTTL = 120
+	// maxPacket bounds a received mDNS message (RFC 6762 section 17).
+	maxPacket = 9000
+)
+
+var mdnsGroup = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: mdnsPort}
+
+// serviceName is the fully qualified DNS-SD service name browsed for.
+var serviceName = dnsmessage.MustNewName(ServiceType + ".local.")
+
+// Service describes the gateway being advertised.
+type Service struct {
+	// Instance is the DNS-SD instance label, normally the stack name.
+	Instance string
+	// Port is the gateway's HTTP port.
+	Port int
+	// Stack and Version are published in the TXT record.
+	Stack   string
+	Version string
+	// Host is the advertised host name without the ".local." suffix.
+	// Empty uses the machine's host name.
+	Host string
+	// IPs are the advertised IPv4 addresses. Empty uses every up,
+	// non-loopback interface address.
+	IPs []net.IP
+}
+
+// Gateway is one gateway found by Browse.
+type Gateway struct {
+	Instance string   `json:"instance"`
+	Stack    string   `json:"stack,omitempty"`
+	Version  string   `json:"version,omitempty"`
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Addrs    []string `json:"addrs"`
+	URL      string   `json:"url"`
+}
+
+// Advertiser answers mDNS queries for one Service until its context ends.
+type Advertiser struct {
+	svc    Service
+	conn   *net.UDPConn
+	logger *slog.Logger
+	done   chan struct{}
+}
+
+// Advertise joins the mDNS multicast group, announces svc, and answers
+// queries for it in the background. When ctx ends the advertiser sends a
+// goodbye so browsers drop the gateway at once, and closes its socket;
+// Done is closed after that.
+func Advertise(ctx context.Context, svc Service, logger *slog.Logger) (*Advertiser, error) {
+	if logger == nil {
+		logger = logging.NewDiscardLogger()
+	}
+	svc, err := svc.normalize()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenMulticastUDP("udp4", nil, mdnsGroup)
+	if err != nil {
+		return nil, fmt.Errorf("mdns: joining multicast group: %w", err)
+	}
+	a := &Advertiser{svc: svc, conn: conn, logger: logger, done: make(chan struct{})}
+
+	a.announce(recordTTL)
+	go a.serve()
+	go func() {
+		<-ctx.Done()
+		a.announce(0)
+		_ = a.conn.Close()
+	}()
+	return a, nil
+}
+
+// Done is closed once the advertiser has stopped.
+func (a *Advertiser) Done() <-chan struct{} { return a.done }
+
+func (a *Advertiser) announce(ttl uint32) {
+	msg := a.svc.response(0, nil, ttl)
+	if packet, err := msg.Pack(); err == nil {
+		if _, err := a.conn.WriteToUDP(packet, mdnsGroup); err != nil {
+			a.logger.Debug("mdns announce failed", "error", err)
+		}
+	}
+}
+
+func (a *Advertiser) serve() {
+	defer close(a.done)
+	buf := make([]byte, maxPacket)
+	for {
+		n, src, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			a.logger.Debug("mdns read failed", "error", err)
+			continue
+		}
+		var q dnsmessage.Message
+		if err := q.Unpack(buf[:n]); err != nil || q.Header.Response {
+			continue
+		}
+		if !a.svc.answers(q.Questions) {
+			continue
+		}
+		// A query from a port other than 5353 comes from a simple resolver
+		// (such as Browse) that only hears unicast: reply to it directly,
+		// echoing the ID and questions (RFC 6762 section 6.7).
+		dst, id, questions := mdnsGroup, uint16(0), []dnsmessage.Question(nil)
+		if src.Port != mdnsPort {
+			dst, id, questions = src, q.Header.ID, q.Questions
+		}
+		resp := a.svc.response(id, questions, recordTTL)
+		packet, err := resp.Pack()
+		if err != nil {
+			continue
+		}
+		if _, err := a.conn.WriteToUDP(packet, dst); err != nil {
+			a.logger.Debug("mdns reply failed", "to", dst.String(), "error", err)
+		}
+	}
+}
+
+// normalize fills in the host name and addresses and checks the rest.
+func (s Service) normalize() (Service, error) {
+	s.Instance = strings.ReplaceAll(strings.TrimSpace(s.Instance), ".", "-")
+	if s.Instance == "" {
+		return s, fmt.Errorf("mdns: instance name is required")
+	}
+	if s.Port <= 0 || s.Port > 65535 {
+		return s, fmt.Errorf("mdns: invalid port %d", s.Port)
+	}
+	if s.Host == "" {
+		h, err := os.Hostname()
+		if err != nil || h == "" {
+			h = "gridctl"
+		}
+		s.Host, _, _ = strings.Cut(h, ".")
+	}
+	if len(s.IPs) == 0 {
+		s.IPs = localIPv4s()
+	}
+	return s, nil
+}
+
+func (s Service) instanceName() dnsmessage.Name {
+	return dnsmessage.MustNewName(s.Instance + "." + ServiceType + ".local.")
+}
+
+func (s Service) hostName() dnsmessage.Name {
+	return dnsmessage.MustNewName(s.Host + ".local.")
+}
+
+// answers reports whether any question is about this service's records.
+func (s Service) answers(questions []dnsmessage.Question) bool {
+	instance, host := s.instanceName(), s.hostName()
+	for _, q := range questions {
+		name := strings.ToLower(q.Name.String())
+		switch {
+		case name == strings.ToLower(serviceName.String()) && (q.Type == dnsmessage.TypePTR || q.Type == dnsmessage.TypeALL):
+			return true
+		case name == strings.ToLower(instance.String()):
+			return true
+		case name == strings.ToLower(host.String()) && (q.Type == dnsmessage.TypeA || q.Type == dnsmessage.TypeALL):
+			return true
+		}
+	}
+	return false
+}
+
+// response builds the full record set: the PTR answer with SRV, TXT, and A
+// records as additionals, so one reply is enough to reach the gateway.
+func (s Service) response(id uint16, questions []dnsmessage.Question, ttl uint32) dnsmessage.Message {
+	instance, host := s.instanceName(), s.hostName()
+	hdr := func(name dnsmessage.Name, typ dnsmessage.Type) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: name, Type: typ, Class: dnsmessage.ClassINET, TTL: ttl}
+	}
+	txt := []string{"txtvers=1", "stack=" + s.Stack}
+	if s.Version != "" {
+		txt = append(txt, "version="+s.Version)
+	}
+	msg := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id, Response: true, Authoritative: true},
+		Questions: questions,
+		Answers: []dnsmessage.Resource{
+			{Header: hdr(serviceName, dnsmessage.TypePTR), Body: &dnsmessage.PTRResource{PTR: instance}},
+		},
+		Additionals: []dnsmessage.Resource{
+			{Header: hdr(instance, dnsmessage.TypeSRV), Body: &dnsmessage.SRVResource{Port: uint16(s.Port), Target: host}},
+			{Header: hdr(instance, dnsmessage.TypeTXT), Body: &dnsmessage.TXTResource{TXT: txt}},
+		},
+	}
+	for _, ip := range s.IPs {
+		if v4 := ip.To4(); v4 != nil {
+			var a [4]byte
+			copy(a[:], v4)
+			msg.Additionals = append(msg.Additionals, dnsmessage.Resource{Header: hdr(host, dnsmessage.TypeA), Body: &dnsmessage.AResource{A: a}})
+		}
+	}
+	return msg
+}
+
+// localIPv4s returns the IPv4 addresses of every up, non-loopback interface.
+func localIPv4s() []net.IP {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	var out []net.IP
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok && ipnet.IP.To4() != nil {
+				out = append(out, ipnet.IP.To4())
+			}
+		}
+	}
+	return out
+}
+
+// Browse sends one query for ServiceType and collects replies for wait, or
+// until ctx ends. Gateways are returned sorted by instance name.
+func Browse(ctx context.Context, wait time.Duration) ([]Gateway, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero})
+	if err != nil {
+		return nil, fmt.Errorf("mdns: opening socket: %w", err)
+	}
+	defer conn.Close()
+
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: 1},
+		Questions: []dnsmessage.Question{{Name: serviceName, Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET}},
+	}
+	packet, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.WriteToUDP(packet, mdnsGroup); err != nil {
+		return nil, fmt.Errorf("mdns: sending query: %w", err)
+	}
+
+	deadline := time.Now().Add(wait)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetReadDeadline(deadline)
+
+	c := newCollector()
+	buf := make([]byte, maxPacket)
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				break
+			}
+			return nil, fmt.Errorf("mdns: reading replies: %w", err)
+		}
+		var msg dnsmessage.Message
+		if err := msg.Unpack(buf[:n]); err != nil || !msg.Header.Response {
+			continue
+		}
+		c.add(msg)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return c.gateways(), nil
+}
+
+// collector assembles Gateways from the records of any number of replies.
+type collector struct {
+	instances map[string]string // lower-cased name -> name as advertised
+	srv       map[string]dnsmessage.SRVResource
+	txt       map[string][]string
+	addrs     map[string][]string
+}
+
+func newCollector() *collector {
+	return &collector{
+		instances: map[string]string{},
+		srv:       map[string]dnsmessage.SRVResource{},
+		txt:       map[string][]string{},
+		addrs:     map[string][]string{},
+	}
+}
+
+func (c *collector) add(msg dnsmessage.Message) {
+	records := append(append([]dnsmessage.Resource{}, msg.Answers...), msg.Additionals...)
+	for _, r := range records {
+		name := strings.ToLower(r.Header.Name.String())
+		switch body := r.Body.(type) {
+		case *dnsmessage.PTRResource:
+			if name == strings.ToLower(serviceName.String()) {
+				c.instances[strings.ToLower(body.PTR.String())] = body.PTR.String()
+			}
+		case *dnsmessage.SRVResource:
+			c.srv[name] = *body
+		case *dnsmessage.TXTResource:
+			c.txt[name] = body.TXT
+		case *dnsmessage.AResource:
+			ip := net.IP(body.A[:]).String()
+			for _, existing := range c.addrs[name] {
+				if existing == ip {
+					ip = ""
+				}
+			}
+			if ip != "" {
+				c.addrs[name] = append(c.addrs[name], ip)
+			}
+		}
+	}
+}
+
+func (c *collector) gateways() []Gateway {
+	out := make([]Gateway, 0, len(c.instances))
+	suffix := "." + serviceName.String()
+	for instance, display := range c.instances {
+		srv, ok := c.srv[instance]
+		if !ok {
+			continue
+		}
+		host := strings.ToLower(srv.Target.String())
+		gw := Gateway{
+			Instance: display[:len(display)-min(len(suffix), len(display))],
+			Host:     strings.TrimSuffix(host, "."),
+			Port:     int(srv.Port),
+			Addrs:    c.addrs[host],
+		}
+		if gw.Addrs == nil {
+			gw.Addrs = []string{}
+		}
+		for _, kv := range c.txt[instance] {
+			k, v, _ := strings.Cut(kv, "=")
+			switch k {
+			case "stack":
+				gw.Stack = v
+			case "version":
+				gw.Version = v
+			}
+		}
+		addr := gw.Host
+		if len(gw.Addrs) > 0 {
+			addr = gw.Addrs[0]
+		}
+		gw.URL = "http://" + net.JoinHostPort(addr, strconv.Itoa(gw.Port))
+		out = append(out, gw)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Instance < out[j].Instance })
+	return out
+}
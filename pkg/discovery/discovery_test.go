@@ -0,0 +1,135 @@
+package discovery
+
+import (
+	"net"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func testService(t *testing.T) Service {
+	t.Helper()
+	svc, err := Service{
+		Instance: "Prod",
+		Port:     8180,
+		Stack:    "prod",
+		Version:  "v1.2.3",
+		Host:     "lab-box",
+		IPs:      []net.IP{net.IPv4(192, 168, 1, 20)},
+	}.normalize()
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	return svc
+}
+
+// roundTrip packs and unpacks msg, as it would cross the wire.
+func roundTrip(t *testing.T, msg dnsmessage.Message) dnsmessage.Message {
+	t.Helper()
+	packet, err := msg.Pack()
+	if err != nil {
+		t.Fatalf("pack: %v", err)
+	}
+	var out dnsmessage.Message
+	if err := out.Unpack(packet); err != nil {
+		t.Fatalf("unpack: %v", err)
+	}
+	return out
+}
+
+func TestResponseIsBrowsable(t *testing.T) {
+	svc := testService(t)
+	c := newCollector()
+	c.add(roundTrip(t, svc.response(7, nil, recordTTL)))
+
+	gws := c.gateways()
+	if len(gws) != 1 {
+		t.Fatalf("gateways = %+v, want one", gws)
+	}
+	gw := gws[0]
+	if gw.Instance != "Prod" || gw.Stack != "prod" || gw.Version != "v1.2.3" {
+		t.Errorf("identity = %+v", gw)
+	}
+	if gw.Host != "lab-box.local" || gw.Port != 8180 {
+		t.Errorf("host/port = %s/%d", gw.Host, gw.Port)
+	}
+	if len(gw.Addrs) != 1 || gw.Addrs[0] != "192.168.1.20" {
+		t.Errorf("addrs = %v", gw.Addrs)
+	}
+	if gw.URL != "http://192.168.1.20:8180" {
+		t.Errorf("url = %q", gw.URL)
+	}
+}
+
+func TestCollectorMergesRepliesAndSkipsIncomplete(t *testing.T) {
+	svc := testService(t)
+	c := newCollector()
+	// The same gateway answering twice (e.g. announce plus query reply)
+	// is listed once, with its address once.
+	c.add(roundTrip(t, svc.response(0, nil, recordTTL)))
+	c.add(roundTrip(t, svc.response(0, nil, recordTTL)))
+
+	// A PTR with no SRV cannot be reached and is dropped.
+	other := dnsmessage.Message{
+		Header: dnsmessage.Header{Response: true},
+		Answers: []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: serviceName, Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET, TTL: 1},
+			Body:   &dnsmessage.PTRResource{PTR: dnsmessage.MustNewName("orphan." + ServiceType + ".local.")},
+		}},
+	}
+	c.add(roundTrip(t, other))
+
+	gws := c.gateways()
+	if len(gws) != 1 || len(gws[0].Addrs) != 1 {
+		t.Fatalf("gateways = %+v, want one with one address", gws)
+	}
+}
+
+func TestAnswers(t *testing.T) {
+	svc := testService(t)
+	q := func(name string, typ dnsmessage.Type) []dnsmessage.Question {
+		return []dnsmessage.Question{{Name: dnsmessage.MustNewName(name), Type: typ, Class: dnsmessage.ClassINET}}
+	}
+	tests := []struct {
+		name      string
+		questions []dnsmessage.Question
+		want      bool
+	}{
+		{"service browse", q("_gridctl._tcp.local.", dnsmessage.TypePTR), true},
+		{"service browse any case", q("_GRIDCTL._tcp.local.", dnsmessage.TypePTR), true},
+		{"instance srv", q("prod._gridctl._tcp.local.", dnsmessage.TypeSRV), true},
+		{"host address", q("lab-box.local.", dnsmessage.TypeA), true},
+		{"other service", q("_http._tcp.local.", dnsmessage.TypePTR), false},
+		{"other host", q("printer.local.", dnsmessage.TypeA), false},
+	}
+	for _, tc := range tests {
+		if got := svc.answers(tc.questions); got != tc.want {
+			t.Errorf("%s: answers = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	svc, err := Service{Instance: " my.stack ", Port: 8180, Host: "h"}.normalize()
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if svc.Instance != "my-stack" {
+		t.Errorf("instance = %q, want dots replaced", svc.Instance)
+	}
+	if _, err := (Service{Port: 8180}).normalize(); err == nil {
+		t.Error("empty instance accepted")
+	}
+	if _, err := (Service{Instance: "x", Port: 70000}).normalize(); err == nil {
+		t.Error("out-of-range port accepted")
+	}
+}
+
+func TestGoodbyeHasZeroTTL(t *testing.T) {
+	msg := roundTrip(t, testService(t).response(0, nil, 0))
+	for _, r := range append(msg.Answers, msg.Additionals...) {
+		if r.Header.TTL != 0 {
+			t.Errorf("%s %s TTL = %d, want 0", r.Header.Name, r.Header.Type, r.Header.TTL)
+		}
+	}
+}
@@ -30,6 +30,7 @@ type DockerClient interface {
 	NetworkList(ctx context.Context, options network.ListOptions) ([]network.Summary, error)
 	NetworkCreate(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error)
 	NetworkRemove(ctx context.Context, networkID string) error
+	NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error
 
 	// Image operations
 	ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error)
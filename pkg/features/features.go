@@ -0,0 +1,143 @@
+// Package features implements experimental feature flags, so risky
+// capabilities can ship dark and be switched on per deployment without a
+// separate build.
+//
+// A subsystem declares its flag once with Register (typically from an init
+// function) and checks Enabled where it would take the new path. The daemon
+// resolves the stack's `features:` list and the GRIDCTL_FEATURES override
+// with Resolve and installs the result with SetEnabled before it builds any
+// subsystem, so every check sees the same set for the life of the process.
+package features
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EnvVar overrides the stack's features list: a comma-separated list of
+// flag names, where "name" enables a flag and "-name" disables it.
+const EnvVar = "GRIDCTL_FEATURES"
+
+// namePattern is the flag name format: lowercase words joined by hyphens.
+var namePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Flag is a registered experimental feature.
+type Flag struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Flag{}
+	enabled  = map[string]bool{}
+)
+
+// ValidName reports whether name is a well-formed flag name.
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Register declares a flag. It panics on a malformed or duplicate name:
+// both are programming errors caught by the first test run.
+func Register(name, description string) {
+	if !ValidName(name) {
+		panic(fmt.Sprintf("features: invalid flag name %q", name))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("features: flag %q registered twice", name))
+	}
+	registry[name] = Flag{Name: name, Description: description}
+}
+
+// Registered returns every registered flag, sorted by name.
+func Registered() []Flag {
+	mu.RLock()
+	defer mu.RUnlock()
+	flags := make([]Flag, 0, len(registry))
+	for _, f := range registry {
+		flags = append(flags, f)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+// IsRegistered reports whether a flag named name has been registered.
+func IsRegistered(name string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := registry[name]
+	return ok
+}
+
+// Resolve combines the stack's configured flags with an EnvVar value and
+// returns the enabled registered flags, sorted. The environment wins: it
+// can enable flags the stack omits and disable ones it lists. Names no
+// subsystem registered are returned as unknown and left out of the set,
+// so a stack written for a newer gridctl still starts.
+func Resolve(configured []string, env string) (on, unknown []string) {
+	want := make(map[string]bool)
+	for _, name := range configured {
+		want[strings.TrimSpace(name)] = true
+	}
+	for _, entry := range strings.Split(env, ",") {
+		entry = strings.TrimSpace(entry)
+		if name, ok := strings.CutPrefix(entry, "-"); ok {
+			want[name] = false
+		} else if entry != "" {
+			want[entry] = true
+		}
+	}
+
+	for name, wanted := range want {
+		switch {
+		case name == "":
+		case !IsRegistered(name):
+			unknown = append(unknown, name)
+		case wanted:
+			on = append(on, name)
+		}
+	}
+	sort.Strings(on)
+	sort.Strings(unknown)
+	return on, unknown
+}
+
+// SetEnabled replaces the set of enabled flags. Unregistered names are
+// ignored.
+func SetEnabled(names []string) {
+	next := make(map[string]bool, len(names))
+	mu.Lock()
+	defer mu.Unlock()
+	for _, name := range names {
+		if _, ok := registry[name]; ok {
+			next[name] = true
+		}
+	}
+	enabled = next
+}
+
+// Enabled reports whether the flag named name is switched on. Flags are off
+// until SetEnabled turns them on.
+func Enabled(name string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return enabled[name]
+}
+
+// EnabledFlags returns the names of the enabled flags, sorted.
+func EnabledFlags() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(enabled))
+	for name := range enabled {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,87 @@
+package features
+
+import (
+	"slices"
+	"testing"
+)
+
+// withRegistry runs the test against a registry holding only names, and
+// restores the package state afterwards.
+func withRegistry(t *testing.T, names ...string) {
+	t.Helper()
+	mu.Lock()
+	prevRegistry, prevEnabled := registry, enabled
+	registry, enabled = map[string]Flag{}, map[string]bool{}
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		registry, enabled = prevRegistry, prevEnabled
+		mu.Unlock()
+	})
+	for _, name := range names {
+		Register(name, "test flag "+name)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	withRegistry(t, "lazy-start", "streamable-http", "fast-path")
+
+	tests := []struct {
+		name        string
+		configured  []string
+		env         string
+		wantOn      []string
+		wantUnknown []string
+	}{
+		{name: "none"},
+		{name: "config only", configured: []string{"lazy-start", "streamable-http"}, wantOn: []string{"lazy-start", "streamable-http"}},
+		{name: "env enables", env: "fast-path", wantOn: []string{"fast-path"}},
+		{name: "env disables", configured: []string{"lazy-start", "fast-path"}, env: " -lazy-start , ", wantOn: []string{"fast-path"}},
+		{name: "unknown reported", configured: []string{"warp-drive"}, env: "-old-flag", wantUnknown: []string{"old-flag", "warp-drive"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			on, unknown := Resolve(tt.configured, tt.env)
+			if !slices.Equal(on, tt.wantOn) {
+				t.Errorf("on = %v, want %v", on, tt.wantOn)
+			}
+			if !slices.Equal(unknown, tt.wantUnknown) {
+				t.Errorf("unknown = %v, want %v", unknown, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	withRegistry(t, "lazy-start", "fast-path")
+
+	if Enabled("lazy-start") {
+		t.Fatal("flags should start disabled")
+	}
+	SetEnabled([]string{"lazy-start", "not-registered"})
+	if !Enabled("lazy-start") || Enabled("fast-path") || Enabled("not-registered") {
+		t.Errorf("enabled = %v, want only lazy-start", EnabledFlags())
+	}
+	SetEnabled(nil)
+	if len(EnabledFlags()) != 0 {
+		t.Errorf("SetEnabled(nil) should clear, got %v", EnabledFlags())
+	}
+}
+
+func TestRegister_Panics(t *testing.T) {
+	withRegistry(t, "lazy-start")
+
+	for _, name := range []string{"lazy-start", "Lazy_Start", "", "-x"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) should panic", name)
+				}
+			}()
+			Register(name, "")
+		}()
+	}
+	if got := Registered(); len(got) != 1 || got[0].Name != "lazy-start" {
+		t.Errorf("Registered() = %+v", got)
+	}
+}
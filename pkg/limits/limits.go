@@ -1,6 +1,6 @@
 // Package limits enforces the stack.yaml `limits:` block: dollar budget caps
-// with calendar-aligned windows and token-bucket rate limits, both scoped to
-// one client, server, or tool. It implements the gateway's CallGate seam for
+// with calendar-aligned windows, token-bucket rate limits, and time-of-week
+// access schedules, each scoped to one client, server, or tool. It implements the gateway's CallGate seam for
 // pre-call checks and CostSettler for post-call spend settlement, and owns a
 // small durable ledger so budget spend survives daemon restarts.
 //
@@ -103,8 +103,9 @@ func (e *rateEntry) carryKey() string {
 // nil-safe and permissive. Entries are immutable after compile; only the
 // per-entry window state mutates, under per-entry locks.
 type Policy struct {
-	budgets []*budgetEntry
-	rates   []*rateEntry
+	budgets   []*budgetEntry
+	rates     []*rateEntry
+	schedules []*scheduleEntry
 
 	ledgerPath string
 	logger     *slog.Logger
@@ -145,7 +146,7 @@ func DefaultBurst(callsPerMinute int) int {
 // whose stored window matches the current one resume, stale windows reset.
 // A corrupt or missing ledger logs a WARN and starts fresh; it never fails.
 func NewPolicy(cfg *config.LimitsConfig, ledgerPath string, logger *slog.Logger) *Policy {
-	if cfg == nil || (len(cfg.Budgets) == 0 && len(cfg.RateLimits) == 0) {
+	if cfg == nil || (len(cfg.Budgets) == 0 && len(cfg.RateLimits) == 0 && len(cfg.Schedules) == 0) {
 		return nil
 	}
 	if logger == nil {
@@ -223,6 +224,36 @@ func NewPolicy(cfg *config.LimitsConfig, ledgerPath string, logger *slog.Logger)
 			limiter:   rate.NewLimiter(rate.Limit(float64(r.CallsPerMinute)/60.0), burst),
 		})
 	}
+	seenSchedules := make(map[string]bool, len(cfg.Schedules))
+	for _, sc := range cfg.Schedules {
+		scope, key, ok := sc.ScopeKey()
+		if !ok {
+			continue
+		}
+		days, errDays := sc.AllowedDays()
+		start, end, errHours := sc.AllowedHours()
+		if errDays != nil || errHours != nil {
+			continue // validation rejects this; skip defensively
+		}
+		matchKey := key
+		if scope == scopeClient {
+			matchKey = mcp.NormalizeClientID(key)
+		}
+		if seenSchedules[scope+"|"+matchKey] {
+			logger.Warn("limits: duplicate schedule scope after client normalization; keeping the first",
+				"scope", scope, "key", key)
+			continue
+		}
+		seenSchedules[scope+"|"+matchKey] = true
+		p.schedules = append(p.schedules, &scheduleEntry{
+			scope:  scope,
+			key:    matchKey,
+			rawKey: key,
+			days:   days,
+			start:  start,
+			end:    end,
+		})
+	}
 	now := p.now()
 	for _, e := range p.budgets {
 		e.windowStart = windowStart(e.period, now)
@@ -254,14 +285,19 @@ func (e *rateEntry) matches(call mcp.GateCall, normClient string) bool {
 	return scopeMatches(e.scope, e.key, call, normClient)
 }
 
-// Gates returns the policy's pre-call gates in canonical order: rate limits
-// before budgets, so a rate-limited caller gets the cheaper check's message.
-// A nil policy returns nil.
+// Gates returns the policy's pre-call gates in canonical order: schedules,
+// then rate limits, then budgets. Schedules go first because they are
+// stateless, so a call outside its window never drains a rate bucket; rate
+// limits precede budgets so a rate-limited caller gets the cheaper check's
+// message. A nil policy returns nil.
 func (p *Policy) Gates() []mcp.CallGate {
 	if p == nil {
 		return nil
 	}
 	var gates []mcp.CallGate
+	if len(p.schedules) > 0 {
+		gates = append(gates, &scheduleGate{p})
+	}
 	if len(p.rates) > 0 {
 		gates = append(gates, &rateGate{p})
 	}
@@ -0,0 +1,117 @@
+package limits
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// scheduleEntry is one compiled access window. It holds no mutable state:
+// whether a call is allowed depends only on the clock.
+type scheduleEntry struct {
+	scope  string
+	key    string
+	rawKey string
+
+	days       [7]bool // indexed by time.Weekday
+	start, end time.Duration
+}
+
+func (e *scheduleEntry) matches(call mcp.GateCall, normClient string) bool {
+	return scopeMatches(e.scope, e.key, call, normClient)
+}
+
+// open reports whether now falls inside the window. A wrapping range
+// ("22:00-06:00") belongs to the day it opens on, so its early-morning tail
+// is checked against the previous day.
+func (e *scheduleEntry) open(now time.Time) bool {
+	h, m, s := now.Clock()
+	tod := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	wd := now.Weekday()
+	if e.start < e.end {
+		return e.days[wd] && tod >= e.start && tod < e.end
+	}
+	prev := (wd + 6) % 7
+	return (e.days[wd] && tod >= e.start) || (e.days[prev] && tod < e.end)
+}
+
+// nextOpen returns when the window next opens after now, or the zero time
+// when no day is allowed (validation rejects that).
+func (e *scheduleEntry) nextOpen(now time.Time) time.Time {
+	y, mo, d := now.Date()
+	for i := 0; i <= 7; i++ {
+		day := time.Date(y, mo, d+i, 0, 0, 0, 0, now.Location())
+		if !e.days[day.Weekday()] {
+			continue
+		}
+		if c := day.Add(e.start); c.After(now) {
+			return c
+		}
+	}
+	return time.Time{}
+}
+
+// describe renders the window for denial messages and status, e.g.
+// "Mon-Fri 09:00-17:00".
+func (e *scheduleEntry) describe() string {
+	return e.dayList() + " " + e.hourRange()
+}
+
+// dayList renders the allowed days in Monday-first order, collapsing
+// consecutive runs: "Mon-Fri", "Mon, Wed, Sat-Sun", "every day".
+func (e *scheduleEntry) dayList() string {
+	order := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+	var parts []string
+	for i := 0; i < len(order); {
+		if !e.days[order[i]] {
+			i++
+			continue
+		}
+		j := i
+		for j+1 < len(order) && e.days[order[j+1]] {
+			j++
+		}
+		if i == 0 && j == len(order)-1 {
+			return "every day"
+		}
+		part := order[i].String()[:3]
+		if j > i {
+			part += "-" + order[j].String()[:3]
+		}
+		parts = append(parts, part)
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (e *scheduleEntry) hourRange() string {
+	if e.start == 0 && e.end == 24*time.Hour {
+		return "all day"
+	}
+	clock := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return clock(e.start) + "-" + clock(e.end)
+}
+
+// scheduleGate implements mcp.CallGate over the policy's schedule entries.
+type scheduleGate struct{ p *Policy }
+
+func (g *scheduleGate) Name() string { return "schedules" }
+
+func (g *scheduleGate) CheckToolCall(_ context.Context, call mcp.GateCall) mcp.GateDecision {
+	normClient := mcp.NormalizeClientID(call.ClientAccessID)
+	now := g.p.now()
+	for _, e := range g.p.schedules {
+		if !e.matches(call, normClient) || e.open(now) {
+			continue
+		}
+		return mcp.GateDeny(fmt.Sprintf(
+			"Outside the allowed schedule for %s %q: calls are allowed %s (local). Next allowed %s (local). Do not retry until then.",
+			e.scope, e.rawKey, e.describe(), e.nextOpen(now).Format("2006-01-02T15:04")))
+	}
+	return mcp.GateAllow()
+}
@@ -0,0 +1,124 @@
+package limits
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/config"
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// at returns a local time in the Sunday-first week of 2026-10-11.
+func at(weekday time.Weekday, hour, minute int) time.Time {
+	return time.Date(2026, 10, 11+int(weekday), hour, minute, 0, 0, time.Local)
+}
+
+func newScheduleEntry(t *testing.T, days []string, hours string) *scheduleEntry {
+	t.Helper()
+	p := newTestPolicy(t, &config.LimitsConfig{
+		Schedules: []config.ScheduleLimit{{Tool: "github__delete_repo", Days: days, Hours: hours}},
+	}, "")
+	return p.schedules[0]
+}
+
+func TestSchedule_BusinessHours(t *testing.T) {
+	e := newScheduleEntry(t, []string{"mon", "tue", "wed", "thu", "fri"}, "09:00-17:00")
+	tests := []struct {
+		now  time.Time
+		open bool
+	}{
+		{at(time.Monday, 9, 0), true},
+		{at(time.Wednesday, 16, 59), true},
+		{at(time.Friday, 17, 0), false},
+		{at(time.Tuesday, 8, 59), false},
+		{at(time.Saturday, 12, 0), false},
+	}
+	for _, tt := range tests {
+		if got := e.open(tt.now); got != tt.open {
+			t.Errorf("open(%s) = %v, want %v", tt.now.Format("Mon 15:04"), got, tt.open)
+		}
+	}
+	if got := e.nextOpen(at(time.Friday, 18, 0)); !got.Equal(at(time.Monday, 9, 0).AddDate(0, 0, 7)) {
+		t.Errorf("nextOpen(Fri 18:00) = %s, want Monday 09:00", got)
+	}
+	if got := e.describe(); got != "Mon-Fri 09:00-17:00" {
+		t.Errorf("describe() = %q", got)
+	}
+}
+
+func TestSchedule_WrapsPastMidnight(t *testing.T) {
+	e := newScheduleEntry(t, []string{"fri"}, "22:00-06:00")
+	if !e.open(at(time.Friday, 23, 0)) || !e.open(at(time.Saturday, 5, 59)) {
+		t.Error("window should cover Friday night into Saturday morning")
+	}
+	if e.open(at(time.Friday, 5, 0)) || e.open(at(time.Saturday, 23, 0)) {
+		t.Error("window must belong to the day it opens on")
+	}
+}
+
+func TestSchedule_DaysOnly(t *testing.T) {
+	e := newScheduleEntry(t, []string{"Mon", "tue", "wed", "thu", "sat", "sun"}, "")
+	if e.open(at(time.Friday, 12, 0)) || !e.open(at(time.Thursday, 23, 59)) {
+		t.Error("days-only schedule should close exactly on Friday")
+	}
+	if got := e.nextOpen(at(time.Friday, 12, 0)); !got.Equal(at(time.Saturday, 0, 0)) {
+		t.Errorf("nextOpen = %s, want Saturday midnight", got)
+	}
+	if got := e.describe(); got != "Mon-Thu, Sat-Sun all day" {
+		t.Errorf("describe() = %q", got)
+	}
+}
+
+func TestScheduleGate_DeniesOutsideWindow(t *testing.T) {
+	p := newTestPolicy(t, &config.LimitsConfig{
+		Schedules:  []config.ScheduleLimit{{Server: "github", Days: []string{"mon", "tue", "wed", "thu", "fri"}, Hours: "09:00-17:00"}},
+		RateLimits: []config.RateLimit{{Server: "github", CallsPerMinute: 1, Burst: 1}},
+	}, "")
+	now := at(time.Saturday, 10, 0)
+	p.now = func() time.Time { return now }
+	gates := p.Gates()
+	if len(gates) != 2 || gates[0].Name() != "schedules" {
+		t.Fatalf("schedules must be the first gate, got %d gates", len(gates))
+	}
+
+	d := gates[0].CheckToolCall(context.Background(), githubCall("claude-code"))
+	if d.Allow {
+		t.Fatal("Saturday call should be denied")
+	}
+	for _, want := range []string{`server "github"`, "Mon-Fri 09:00-17:00", "Next allowed 2026-10-19T09:00"} {
+		if !strings.Contains(d.Message, want) {
+			t.Errorf("denial %q missing %q", d.Message, want)
+		}
+	}
+	if d := gates[0].CheckToolCall(context.Background(), mcp.GateCall{PrefixedTool: "slack__post", ServerName: "slack"}); !d.Allow {
+		t.Error("calls outside the schedule's scope must be allowed")
+	}
+
+	now = at(time.Monday, 10, 0)
+	if d := gates[0].CheckToolCall(context.Background(), githubCall("claude-code")); !d.Allow {
+		t.Errorf("Monday 10:00 call denied: %s", d.Message)
+	}
+}
+
+func TestStatus_ScheduleClosed(t *testing.T) {
+	p := newTestPolicy(t, &config.LimitsConfig{
+		Schedules: []config.ScheduleLimit{{Tool: "github__delete_repo", Hours: "09:00-17:00"}},
+	}, "")
+	p.now = func() time.Time { return at(time.Tuesday, 20, 0) }
+	entries := p.Status().Entries
+	if len(entries) != 1 {
+		t.Fatalf("entries = %+v", entries)
+	}
+	e := entries[0]
+	if e.Kind != "schedule" || e.State != "closed" || e.Schedule == nil || e.Schedule.Open {
+		t.Fatalf("entry = %+v", e)
+	}
+	if e.Schedule.NextOpen == nil || !e.Schedule.NextOpen.Equal(at(time.Wednesday, 9, 0)) {
+		t.Errorf("NextOpen = %v, want Wednesday 09:00", e.Schedule.NextOpen)
+	}
+	if e.Schedule.Days != "every day" || e.Schedule.Hours != "09:00-17:00" {
+		t.Errorf("schedule = %+v", e.Schedule)
+	}
+}
@@ -21,19 +21,31 @@ type RateStatus struct {
 	Burst          int `json:"burst"`
 }
 
+// ScheduleStatus is one schedule's window and whether it is open now.
+// NextOpen is set only while the window is closed.
+type ScheduleStatus struct {
+	Days     string     `json:"days"`
+	Hours    string     `json:"hours"`
+	Open     bool       `json:"open"`
+	NextOpen *time.Time `json:"next_open,omitempty"`
+}
+
 // EntryStatus is one limit's snapshot, shared by GET /api/limits and
-// `gridctl limits`. Exactly one of Budget or Rate is set, matching Kind.
+// `gridctl limits`. Exactly one of Budget, Rate, or Schedule is set,
+// matching Kind.
 type EntryStatus struct {
-	// Kind is "budget" or "rate".
+	// Kind is "budget", "rate", or "schedule".
 	Kind string `json:"kind"`
 	// Scope is "client", "server", or "tool"; Key is the configured value.
 	Scope string `json:"scope"`
 	Key   string `json:"key"`
-	// State is "ok", "warn" (budget past its warn threshold), or "exceeded".
+	// State is "ok", "warn" (budget past its warn threshold), "exceeded",
+	// or "closed" (schedule outside its window).
 	State string `json:"state"`
 
-	Budget *BudgetStatus `json:"budget,omitempty"`
-	Rate   *RateStatus   `json:"rate,omitempty"`
+	Budget   *BudgetStatus   `json:"budget,omitempty"`
+	Rate     *RateStatus     `json:"rate,omitempty"`
+	Schedule *ScheduleStatus `json:"schedule,omitempty"`
 }
 
 // StatusReport is the full limits status payload.
@@ -93,5 +105,15 @@ func (p *Policy) Status() StatusReport {
 		}
 		report.Entries = append(report.Entries, st)
 	}
+	for _, e := range p.schedules {
+		sched := &ScheduleStatus{Days: e.dayList(), Hours: e.hourRange(), Open: e.open(now)}
+		st := EntryStatus{Kind: "schedule", Scope: e.scope, Key: e.rawKey, State: "ok", Schedule: sched}
+		if !sched.Open {
+			next := e.nextOpen(now)
+			sched.NextOpen = &next
+			st.State = "closed"
+		}
+		report.Entries = append(report.Entries, st)
+	}
 	return report
 }
@@ -18,10 +18,13 @@ type FileOpts struct {
 	MaxAgeDays int
 	// MaxBackups is the maximum number of compressed old log files to keep (default: 3).
 	MaxBackups int
+	// Output sets the entry format and timestamps (default: JSON with
+	// RFC3339Nano local timestamps).
+	Output OutputOpts
 }
 
-// NewFileHandler creates a slog.Handler that writes JSON-formatted log entries to a
-// lumberjack-backed rotating log file. Returns an error if the file cannot be opened
+// NewFileHandler creates a slog.Handler that writes JSON-formatted (or, per
+// opts.Output, text) log entries to a lumberjack-backed rotating log file. Returns an error if the file cannot be opened
 // or the directory does not exist.
 func NewFileHandler(path string, opts FileOpts) (slog.Handler, error) {
 	// Validate that the parent directory exists before handing off to lumberjack.
@@ -62,11 +65,11 @@ func NewFileHandler(path string, opts FileOpts) (slog.Handler, error) {
 	}
 	f.Close()
 
-	return slog.NewJSONHandler(lj, &slog.HandlerOptions{
+	hopts := &slog.HandlerOptions{
 		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
 			if a.Key == slog.TimeKey {
 				if t, ok := a.Value.Any().(time.Time); ok {
-					return slog.String("ts", t.Format(time.RFC3339Nano))
+					return slog.Attr{Key: "ts", Value: opts.Output.formatTime(t, time.RFC3339Nano)}
 				}
 			}
 			if a.Key == slog.MessageKey {
@@ -74,7 +77,11 @@ func NewFileHandler(path string, opts FileOpts) (slog.Handler, error) {
 			}
 			return a
 		},
-	}), nil
+	}
+	if opts.Output.Format == FormatText {
+		return slog.NewTextHandler(lj, hopts), nil
+	}
+	return slog.NewJSONHandler(lj, hopts), nil
 }
 
 // dirOf returns the directory component of path, defaulting to "." for bare filenames.
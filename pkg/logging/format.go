@@ -0,0 +1,142 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Named timestamp formats accepted by ParseTimeFormat. Anything else is
+// taken as a Go time layout.
+const (
+	TimeFormatRFC3339     = "rfc3339"
+	TimeFormatRFC3339Nano = "rfc3339nano"
+	// TimeFormatUnix writes integer seconds since the epoch.
+	TimeFormatUnix = "unix"
+	// TimeFormatUnixMilli writes integer milliseconds since the epoch.
+	TimeFormatUnixMilli = "unixmilli"
+)
+
+// OutputOpts controls how a log output renders records. The zero value keeps
+// each output's default: JSON with RFC3339Nano local timestamps for files,
+// the handler's own rendering for the console.
+type OutputOpts struct {
+	// Format is json or text.
+	Format LogFormat
+	// TimeFormat is a named format (rfc3339, rfc3339nano, unix, unixmilli)
+	// or a Go time layout.
+	TimeFormat string
+	// Location converts timestamps before formatting. Nil keeps local time.
+	Location *time.Location
+}
+
+// ParseOutputFormat validates a log format name. Unlike ParseFormat it
+// rejects unknown names; empty means the output's default.
+func ParseOutputFormat(s string) (LogFormat, error) {
+	switch f := LogFormat(strings.ToLower(strings.TrimSpace(s))); f {
+	case "", FormatJSON, FormatText:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid log format %q: want json or text", s)
+	}
+}
+
+// ParseTimeFormat resolves a timestamp format to a Go layout, or to
+// TimeFormatUnix / TimeFormatUnixMilli for numeric timestamps. Empty is
+// returned unchanged. A custom layout must reference at least one time
+// element, which catches typos of the named formats.
+func ParseTimeFormat(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return "", nil
+	case TimeFormatRFC3339:
+		return time.RFC3339, nil
+	case TimeFormatRFC3339Nano:
+		return time.RFC3339Nano, nil
+	case TimeFormatUnix:
+		return TimeFormatUnix, nil
+	case TimeFormatUnixMilli:
+		return TimeFormatUnixMilli, nil
+	}
+	ref := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	if ref.Format(s) == s {
+		return "", fmt.Errorf("invalid time format %q: want rfc3339, rfc3339nano, unix, unixmilli, or a Go time layout", s)
+	}
+	return s, nil
+}
+
+// LoadLocation resolves a timezone name: "UTC", "Local", or an IANA zone
+// such as "Europe/Berlin". Empty returns nil, meaning local time.
+func LoadLocation(name string) (*time.Location, error) {
+	switch {
+	case name == "":
+		return nil, nil
+	case strings.EqualFold(name, "utc"):
+		return time.UTC, nil
+	case strings.EqualFold(name, "local"):
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// NewOutputOpts parses the string settings of a log output.
+func NewOutputOpts(format, timeFormat, timezone string) (OutputOpts, error) {
+	f, err := ParseOutputFormat(format)
+	if err != nil {
+		return OutputOpts{}, err
+	}
+	layout, err := ParseTimeFormat(timeFormat)
+	if err != nil {
+		return OutputOpts{}, err
+	}
+	loc, err := LoadLocation(timezone)
+	if err != nil {
+		return OutputOpts{}, err
+	}
+	return OutputOpts{Format: f, TimeFormat: layout, Location: loc}, nil
+}
+
+// formatTime renders t per the options, defaulting to defaultLayout.
+func (o OutputOpts) formatTime(t time.Time, defaultLayout string) slog.Value {
+	if o.Location != nil {
+		t = t.In(o.Location)
+	}
+	layout := o.TimeFormat
+	if layout == "" {
+		layout = defaultLayout
+	}
+	switch layout {
+	case TimeFormatUnix:
+		return slog.Int64Value(t.Unix())
+	case TimeFormatUnixMilli:
+		return slog.Int64Value(t.UnixMilli())
+	}
+	return slog.StringValue(t.Format(layout))
+}
+
+// NewOutputHandler creates a handler writing to w in the configured format
+// (text when unset). Timestamps keep the handler's rendering unless a time
+// format or timezone is set.
+func NewOutputHandler(w io.Writer, level slog.Leveler, opts OutputOpts) slog.Handler {
+	hopts := &slog.HandlerOptions{Level: level}
+	if opts.TimeFormat != "" || opts.Location != nil {
+		hopts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				if t, ok := a.Value.Any().(time.Time); ok {
+					a.Value = opts.formatTime(t, time.RFC3339Nano)
+				}
+			}
+			return a
+		}
+	}
+	if opts.Format == FormatJSON {
+		return slog.NewJSONHandler(w, hopts)
+	}
+	return slog.NewTextHandler(w, hopts)
+}
@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"rfc3339", time.RFC3339, false},
+		{"RFC3339Nano", time.RFC3339Nano, false},
+		{"unix", TimeFormatUnix, false},
+		{"unixmilli", TimeFormatUnixMilli, false},
+		{"2006-01-02 15:04:05", "2006-01-02 15:04:05", false},
+		{"iso", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTimeFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewOutputOpts_Invalid(t *testing.T) {
+	_, err := NewOutputOpts("xml", "", "")
+	assert.ErrorContains(t, err, "log format")
+	_, err = NewOutputOpts("", "", "Mars/Olympus")
+	assert.ErrorContains(t, err, "timezone")
+}
+
+func TestNewFileHandler_UTCRFC3339(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	opts, err := NewOutputOpts("json", "rfc3339", "UTC")
+	require.NoError(t, err)
+
+	h, err := NewFileHandler(path, FileOpts{Output: opts})
+	require.NoError(t, err)
+	slog.New(h).Info("shipped")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(data, &entry))
+	ts, _ := entry["ts"].(string)
+	parsed, err := time.Parse(time.RFC3339, ts)
+	require.NoError(t, err, "ts %q is not RFC3339", ts)
+	assert.True(t, strings.HasSuffix(ts, "Z"), "ts %q is not UTC", ts)
+	assert.Equal(t, time.UTC, parsed.Location())
+}
+
+func TestNewFileHandler_TextUnixMilli(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	h, err := NewFileHandler(path, FileOpts{Output: OutputOpts{Format: FormatText, TimeFormat: TimeFormatUnixMilli}})
+	require.NoError(t, err)
+	slog.New(h).Info("plain")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Regexp(t, `^ts=\d{13} level=INFO msg=plain`, string(data))
+}
+
+func TestNewOutputHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewOutputHandler(&buf, slog.LevelInfo, OutputOpts{Format: FormatJSON, TimeFormat: TimeFormatUnix})
+	slog.New(h).Debug("hidden")
+	slog.New(h).Info("shown")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "shown", entry["msg"])
+	assert.IsType(t, float64(0), entry["time"])
+
+	// The zero value is a plain text handler.
+	buf.Reset()
+	slog.New(NewOutputHandler(&buf, slog.LevelInfo, OutputOpts{})).Info("text")
+	assert.Contains(t, buf.String(), "msg=text")
+}
@@ -178,6 +178,36 @@ func RedactString(s string) string {
 	return s
 }
 
+// RedactValue returns a copy of a decoded JSON value (as produced by
+// json.Unmarshal into any) with values under secret-like keys replaced by
+// [REDACTED] and the default patterns applied to every other string. Use it
+// for structured payloads, where RedactString's key=value patterns do not
+// see through the JSON quoting.
+func RedactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, inner := range val {
+			if _, nested := inner.(map[string]any); !nested && isSensitiveKey(k) {
+				out[k] = "[REDACTED]"
+				continue
+			}
+			out[k] = RedactValue(inner)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, inner := range val {
+			out[i] = RedactValue(inner)
+		}
+		return out
+	case string:
+		return RedactString(val)
+	default:
+		return v
+	}
+}
+
 // attrsToAny converts []slog.Attr to []any for slog.Group().
 func attrsToAny(attrs []slog.Attr) []any {
 	result := make([]any, len(attrs))
@@ -258,3 +258,34 @@ func TestRedactEnv_Nil(t *testing.T) {
 		t.Error("expected nil for nil input")
 	}
 }
+
+func TestRedactValue(t *testing.T) {
+	in := map[string]any{
+		"name": "search",
+		"arguments": map[string]any{
+			"query":     "open issues",
+			"api_key":   "sk-abc",
+			"header":    "Authorization: Bearer xyz",
+			"providers": []any{map[string]any{"token": "t-1", "id": float64(3)}},
+		},
+	}
+
+	out := RedactValue(in).(map[string]any)
+	args := out["arguments"].(map[string]any)
+	if args["query"] != "open issues" {
+		t.Errorf("query changed: %v", args["query"])
+	}
+	if args["api_key"] != "[REDACTED]" {
+		t.Errorf("api_key not redacted: %v", args["api_key"])
+	}
+	if strings.Contains(args["header"].(string), "xyz") {
+		t.Errorf("header secret survived: %v", args["header"])
+	}
+	provider := args["providers"].([]any)[0].(map[string]any)
+	if provider["token"] != "[REDACTED]" || provider["id"] != float64(3) {
+		t.Errorf("nested provider = %v", provider)
+	}
+	if in["arguments"].(map[string]any)["api_key"] != "sk-abc" {
+		t.Error("RedactValue must not modify its input")
+	}
+}
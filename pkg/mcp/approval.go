@@ -0,0 +1,249 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// DefaultApprovalTimeout is how long an approval-required tool call waits
+// for a decision before it is rejected.
+const DefaultApprovalTimeout = 5 * time.Minute
+
+// maxApprovalHistory bounds the decided approvals kept for GET /api/approvals.
+const maxApprovalHistory = 100
+
+// Approval decisions recorded on an ApprovalRecord.
+const (
+	ApprovalApproved  = "approved"
+	ApprovalDenied    = "denied"
+	ApprovalTimedOut  = "timed_out"
+	ApprovalCancelled = "cancelled"
+)
+
+// ErrApprovalNotFound is returned when deciding an approval that is not
+// pending: unknown, already decided, timed out, or abandoned by its caller.
+var ErrApprovalNotFound = errors.New("no pending approval with that id")
+
+// PendingApproval is a tool call parked until a human approves or denies it.
+// Arguments are included so the approver can see what the call would do.
+type PendingApproval struct {
+	ID          string         `json:"id"`
+	Tool        string         `json:"tool"`
+	Server      string         `json:"server"`
+	Client      string         `json:"client,omitempty"`
+	Arguments   map[string]any `json:"arguments,omitempty"`
+	RequestedAt time.Time      `json:"requestedAt"`
+	ExpiresAt   time.Time      `json:"expiresAt"`
+}
+
+// ApprovalRecord is a decided approval: the parked call and its outcome.
+type ApprovalRecord struct {
+	PendingApproval
+	Decision  string    `json:"decision"`
+	DecidedAt time.Time `json:"decidedAt"`
+	DecidedBy string    `json:"decidedBy,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// ApprovalsStatus lists the calls waiting for approval, oldest first, and
+// the most recent decisions, newest first.
+type ApprovalsStatus struct {
+	Pending []PendingApproval `json:"pending"`
+	Recent  []ApprovalRecord  `json:"recent"`
+}
+
+// approvalVerdict is delivered to a parked call when a human decides it.
+type approvalVerdict struct {
+	approved bool
+	by       string
+	reason   string
+}
+
+type pendingCall struct {
+	info    PendingApproval
+	verdict chan approvalVerdict // buffered; receives at most one verdict
+}
+
+// approvalState holds parked calls and recent decisions. Like maintenance
+// mode it lives only in memory: a restart rejects every parked call with
+// its caller's connection.
+type approvalState struct {
+	pending map[string]*pendingCall
+	recent  []ApprovalRecord
+}
+
+// SetApprovalTimeout sets how long approval-required calls wait for a
+// decision. Zero uses DefaultApprovalTimeout.
+func (g *Gateway) SetApprovalTimeout(d time.Duration) {
+	g.approvalMu.Lock()
+	defer g.approvalMu.Unlock()
+	g.approvalTimeout = d
+}
+
+// approvalRequired reports whether calls to a server's tool must be
+// approved, per the server's RequireApproval list.
+func (g *Gateway) approvalRequired(serverName, toolName string) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	cfg, ok := g.serverMeta[serverName]
+	return ok && slices.Contains(cfg.RequireApproval, toolName)
+}
+
+// awaitApproval parks an approval-required call until it is approved,
+// denied, times out, or its caller goes away. It returns nil when the call
+// may proceed, or the tool error to return instead.
+func (g *Gateway) awaitApproval(ctx context.Context, params ToolCallParams, serverName string) *ToolCallResult {
+	g.approvalMu.Lock()
+	timeout := g.approvalTimeout
+	if timeout <= 0 {
+		timeout = DefaultApprovalTimeout
+	}
+	now := time.Now()
+	call := &pendingCall{
+		info: PendingApproval{
+			ID:          newApprovalID(),
+			Tool:        params.Name,
+			Server:      serverName,
+			Client:      ClientAccessIDFromContext(ctx),
+			Arguments:   params.Arguments,
+			RequestedAt: now,
+			ExpiresAt:   now.Add(timeout),
+		},
+		verdict: make(chan approvalVerdict, 1),
+	}
+	if g.approvals.pending == nil {
+		g.approvals.pending = make(map[string]*pendingCall)
+	}
+	g.approvals.pending[call.info.ID] = call
+	g.approvalMu.Unlock()
+
+	g.logger.Info("tool call awaiting approval",
+		"id", call.info.ID, "tool", params.Name, "client", call.info.Client, "timeout", timeout)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var v approvalVerdict
+	decision := ApprovalApproved
+	select {
+	case v = <-call.verdict:
+		if !v.approved {
+			decision = ApprovalDenied
+		}
+	case <-timer.C:
+		decision = ApprovalTimedOut
+	case <-ctx.Done():
+		decision = ApprovalCancelled
+	}
+	if decision == ApprovalTimedOut || decision == ApprovalCancelled {
+		// A verdict may have landed between the timeout and this point;
+		// the removal under the lock decides which one wins.
+		if !g.finishApproval(call.info.ID) {
+			v = <-call.verdict
+			decision = ApprovalApproved
+			if !v.approved {
+				decision = ApprovalDenied
+			}
+		}
+	}
+	g.recordApproval(call.info, decision, v)
+
+	switch decision {
+	case ApprovalApproved:
+		return nil
+	case ApprovalDenied:
+		msg := fmt.Sprintf("Tool call %q was denied by an approver.", params.Name)
+		if v.reason != "" {
+			msg = fmt.Sprintf("Tool call %q was denied by an approver: %s", params.Name, v.reason)
+		}
+		return &ToolCallResult{Content: []Content{NewTextContent(msg + " Do not retry it unchanged.")}, IsError: true}
+	default:
+		return &ToolCallResult{
+			Content: []Content{NewTextContent(fmt.Sprintf(
+				"Tool call %q requires approval and was not approved within %s. Ask the user to approve it before retrying.",
+				params.Name, timeout))},
+			IsError: true,
+		}
+	}
+}
+
+// DecideApproval approves or denies a pending call. by and reason are
+// optional and recorded in the audit entry; reason is also shown to the
+// caller on denial.
+func (g *Gateway) DecideApproval(id string, approve bool, by, reason string) error {
+	g.approvalMu.Lock()
+	call, ok := g.approvals.pending[id]
+	if ok {
+		delete(g.approvals.pending, id)
+	}
+	g.approvalMu.Unlock()
+	if !ok {
+		return ErrApprovalNotFound
+	}
+	call.verdict <- approvalVerdict{approved: approve, by: by, reason: reason}
+	return nil
+}
+
+// finishApproval removes a pending call that timed out or was abandoned.
+// It returns false when a decision already claimed it.
+func (g *Gateway) finishApproval(id string) bool {
+	g.approvalMu.Lock()
+	defer g.approvalMu.Unlock()
+	if _, ok := g.approvals.pending[id]; !ok {
+		return false
+	}
+	delete(g.approvals.pending, id)
+	return true
+}
+
+// recordApproval logs the audit entry for a decided call and adds it to the
+// recent history.
+func (g *Gateway) recordApproval(info PendingApproval, decision string, v approvalVerdict) {
+	rec := ApprovalRecord{
+		PendingApproval: info,
+		Decision:        decision,
+		DecidedAt:       time.Now(),
+		DecidedBy:       v.by,
+		Reason:          v.reason,
+	}
+	g.logger.Info("tool call approval",
+		"id", info.ID, "tool", info.Tool, "client", info.Client,
+		"decision", decision, "by", v.by, "reason", v.reason,
+		"waited", rec.DecidedAt.Sub(info.RequestedAt).Round(time.Millisecond))
+
+	g.approvalMu.Lock()
+	defer g.approvalMu.Unlock()
+	g.approvals.recent = append(g.approvals.recent, rec)
+	if n := len(g.approvals.recent); n > maxApprovalHistory {
+		g.approvals.recent = slices.Clone(g.approvals.recent[n-maxApprovalHistory:])
+	}
+}
+
+// Approvals returns the parked calls and recent decisions.
+func (g *Gateway) Approvals() ApprovalsStatus {
+	g.approvalMu.Lock()
+	defer g.approvalMu.Unlock()
+	st := ApprovalsStatus{
+		Pending: make([]PendingApproval, 0, len(g.approvals.pending)),
+		Recent:  make([]ApprovalRecord, 0, len(g.approvals.recent)),
+	}
+	for _, call := range g.approvals.pending {
+		st.Pending = append(st.Pending, call.info)
+	}
+	sort.Slice(st.Pending, func(i, j int) bool { return st.Pending[i].RequestedAt.Before(st.Pending[j].RequestedAt) })
+	for i := len(g.approvals.recent) - 1; i >= 0; i-- {
+		st.Recent = append(st.Recent, g.approvals.recent[i])
+	}
+	return st
+}
+
+func newApprovalID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
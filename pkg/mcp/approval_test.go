@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// newApprovalTestGateway returns a gateway with one "github" server whose
+// delete_repo tool requires approval and whose search tool does not.
+func newApprovalTestGateway(t *testing.T, wantDispatches int) *Gateway {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	client := setupMockAgentClient(ctrl, "github", []Tool{
+		{Name: "delete_repo", Description: "Delete"},
+		{Name: "search", Description: "Search"},
+	})
+	client.EXPECT().CallTool(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&ToolCallResult{Content: []Content{NewTextContent("ok")}}, nil).
+		Times(wantDispatches)
+	g.Router().AddClient(client)
+	g.Router().RefreshTools()
+	g.SetServerMeta(MCPServerConfig{Name: "github", RequireApproval: []string{"delete_repo"}})
+	return g
+}
+
+// callAsync runs a tools/call in the background and returns its result
+// channel once the call is parked.
+func callAsync(t *testing.T, g *Gateway, ctx context.Context, tool string) <-chan *ToolCallResult {
+	t.Helper()
+	out := make(chan *ToolCallResult, 1)
+	go func() {
+		res, err := g.HandleToolsCall(ctx, ToolCallParams{Name: tool, Arguments: map[string]any{"repo": "gridctl"}})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		out <- res
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(g.Approvals().Pending) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("call was never parked for approval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return out
+}
+
+func TestApproval_ApprovedCallProceeds(t *testing.T) {
+	g := newApprovalTestGateway(t, 1)
+	done := callAsync(t, g, WithClientAccessID(context.Background(), "claude-code"), "github__delete_repo")
+
+	pending := g.Approvals().Pending[0]
+	if pending.Tool != "github__delete_repo" || pending.Client != "claude-code" || pending.Arguments["repo"] != "gridctl" {
+		t.Errorf("pending = %+v", pending)
+	}
+	if err := g.DecideApproval(pending.ID, true, "alice", ""); err != nil {
+		t.Fatal(err)
+	}
+	if res := <-done; res.IsError {
+		t.Fatalf("approved call failed: %+v", res)
+	}
+	recent := g.Approvals().Recent
+	if len(recent) != 1 || recent[0].Decision != ApprovalApproved || recent[0].DecidedBy != "alice" {
+		t.Errorf("recent = %+v", recent)
+	}
+	if err := g.DecideApproval(pending.ID, false, "", ""); !errors.Is(err, ErrApprovalNotFound) {
+		t.Errorf("deciding twice: err = %v, want ErrApprovalNotFound", err)
+	}
+}
+
+func TestApproval_DeniedCallReturnsReason(t *testing.T) {
+	g := newApprovalTestGateway(t, 0)
+	done := callAsync(t, g, context.Background(), "github__delete_repo")
+
+	if err := g.DecideApproval(g.Approvals().Pending[0].ID, false, "", "not during the freeze"); err != nil {
+		t.Fatal(err)
+	}
+	res := <-done
+	if !res.IsError || !strings.Contains(res.Content[0].Text, "not during the freeze") {
+		t.Errorf("denied result = %+v", res)
+	}
+}
+
+func TestApproval_TimesOut(t *testing.T) {
+	g := newApprovalTestGateway(t, 0)
+	g.SetApprovalTimeout(20 * time.Millisecond)
+
+	res, err := g.HandleToolsCall(context.Background(), ToolCallParams{Name: "github__delete_repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.IsError || !strings.Contains(res.Content[0].Text, "not approved within 20ms") {
+		t.Errorf("timed-out result = %+v", res)
+	}
+	st := g.Approvals()
+	if len(st.Pending) != 0 || len(st.Recent) != 1 || st.Recent[0].Decision != ApprovalTimedOut {
+		t.Errorf("approvals = %+v", st)
+	}
+}
+
+func TestApproval_CallerCancelled(t *testing.T) {
+	g := newApprovalTestGateway(t, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := callAsync(t, g, ctx, "github__delete_repo")
+	cancel()
+	<-done
+	st := g.Approvals()
+	if len(st.Pending) != 0 || len(st.Recent) != 1 || st.Recent[0].Decision != ApprovalCancelled {
+		t.Errorf("approvals = %+v", st)
+	}
+}
+
+func TestApproval_OtherToolsNotParked(t *testing.T) {
+	g := newApprovalTestGateway(t, 1)
+	res, err := g.HandleToolsCall(context.Background(), ToolCallParams{Name: "github__search"})
+	if err != nil || res.IsError {
+		t.Fatalf("search call = %+v, %v", res, err)
+	}
+	if st := g.Approvals(); len(st.Recent) != 0 {
+		t.Errorf("search should not go through approval: %+v", st)
+	}
+}
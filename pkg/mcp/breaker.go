@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCircuitBreakerCooldown is how long an open circuit rejects calls
+// before letting a trial call through, when the server sets no cooldown.
+const DefaultCircuitBreakerCooldown = 30 * time.Second
+
+// Circuit states as reported in MCPServerStatus.CircuitState.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// circuitBreaker fails calls to one server fast after consecutive transport
+// failures, so a hung backend costs callers one request timeout per cooldown
+// instead of one per call. After the cooldown a single trial call is let
+// through: success closes the circuit, failure reopens it.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	trial    bool // a half-open trial call is in flight
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if cooldown <= 0 {
+		cooldown = DefaultCircuitBreakerCooldown
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now, state: CircuitClosed}
+}
+
+// allow reports whether a call may proceed, and otherwise how long until the
+// next trial call. The returned transition is non-empty when the call moved
+// the circuit to half-open.
+func (b *circuitBreaker) allow() (ok bool, retryIn time.Duration, transition string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitOpen:
+		if wait := b.cooldown - b.now().Sub(b.openedAt); wait > 0 {
+			return false, wait, ""
+		}
+		b.state = CircuitHalfOpen
+		b.trial = true
+		return true, 0, CircuitHalfOpen
+	case CircuitHalfOpen:
+		if b.trial {
+			return false, b.cooldown, ""
+		}
+		b.trial = true
+		return true, 0, ""
+	default:
+		return true, 0, ""
+	}
+}
+
+// record reports a call's outcome and returns the new state when it changed.
+func (b *circuitBreaker) record(failed bool) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		if b.state != CircuitClosed {
+			b.state = CircuitClosed
+			return CircuitClosed
+		}
+		return ""
+	}
+	b.failures++
+	if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.threshold) {
+		b.state = CircuitOpen
+		b.openedAt = b.now()
+		return CircuitOpen
+	}
+	return ""
+}
+
+func (b *circuitBreaker) currentState() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// circuitBreakerFor returns the breaker for a server, creating it on first
+// use, or nil when the server has no circuit breaker configured. A breaker
+// whose settings changed (hot reload) is replaced.
+func (g *Gateway) circuitBreakerFor(name string) *circuitBreaker {
+	g.mu.RLock()
+	meta, ok := g.serverMeta[name]
+	g.mu.RUnlock()
+	if !ok || meta.CircuitBreakerFailures <= 0 {
+		return nil
+	}
+	g.breakerMu.Lock()
+	defer g.breakerMu.Unlock()
+	b := g.breakers[name]
+	if b == nil || b.threshold != meta.CircuitBreakerFailures || (meta.CircuitBreakerCooldown > 0 && b.cooldown != meta.CircuitBreakerCooldown) {
+		b = newCircuitBreaker(meta.CircuitBreakerFailures, meta.CircuitBreakerCooldown)
+		g.breakers[name] = b
+	}
+	return b
+}
+
+// circuitState returns a server's circuit state for Status, or "" when it
+// has no breaker or has not been called yet.
+func (g *Gateway) circuitState(name string) string {
+	g.breakerMu.Lock()
+	b := g.breakers[name]
+	g.breakerMu.Unlock()
+	if b == nil {
+		return ""
+	}
+	return b.currentState()
+}
+
+// logCircuitTransition records a breaker state change in the server's logs.
+func (g *Gateway) logCircuitTransition(name, state string, b *circuitBreaker) {
+	switch state {
+	case CircuitOpen:
+		g.logger.Warn("circuit breaker opened", "server", name, "failures", b.threshold, "cooldown", b.cooldown)
+	case CircuitHalfOpen:
+		g.logger.Info("circuit breaker half-open, sending a trial call", "server", name)
+	case CircuitClosed:
+		g.logger.Info("circuit breaker closed", "server", name)
+	}
+}
+
+// circuitOpenMessage is the tool error returned while a server's circuit is
+// open, written for the model that reads it.
+func circuitOpenMessage(name string, b *circuitBreaker, retryIn time.Duration) string {
+	if retryIn < time.Second {
+		retryIn = time.Second
+	}
+	return fmt.Sprintf("Error: server %q is unavailable: its last %d calls failed, so calls are paused. Retry in %s.",
+		name, b.threshold, retryIn.Round(time.Second))
+}
@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	now := time.Unix(1000, 0)
+	b := newCircuitBreaker(2, 10*time.Second)
+	b.now = func() time.Time { return now }
+
+	if ok, _, _ := b.allow(); !ok {
+		t.Fatal("a closed circuit should allow calls")
+	}
+	if got := b.record(true); got != "" {
+		t.Errorf("first failure: transition = %q, want none", got)
+	}
+	if got := b.record(true); got != CircuitOpen {
+		t.Errorf("second failure: transition = %q, want open", got)
+	}
+
+	ok, retryIn, _ := b.allow()
+	if ok || retryIn != 10*time.Second {
+		t.Errorf("open circuit: allow = %v, %v; want false, 10s", ok, retryIn)
+	}
+
+	now = now.Add(10 * time.Second)
+	ok, _, transition := b.allow()
+	if !ok || transition != CircuitHalfOpen {
+		t.Fatalf("after cooldown: allow = %v, %q; want a half-open trial", ok, transition)
+	}
+	if ok, _, _ := b.allow(); ok {
+		t.Error("only one trial call should run while half-open")
+	}
+	if got := b.record(true); got != CircuitOpen {
+		t.Errorf("failed trial: transition = %q, want open", got)
+	}
+
+	now = now.Add(10 * time.Second)
+	b.allow()
+	if got := b.record(false); got != CircuitClosed {
+		t.Errorf("successful trial: transition = %q, want closed", got)
+	}
+	if got := b.record(true); got != "" {
+		t.Errorf("closing should reset the failure count, got %q", got)
+	}
+}
+
+func TestHandleToolsCall_CircuitBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	client := setupMockAgentClient(ctrl, "slow", []Tool{{Name: "query", Description: "Query"}})
+	client.EXPECT().CallTool(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("context deadline exceeded")).Times(2)
+	g.Router().AddClient(client)
+	g.Router().RefreshTools()
+	g.SetServerMeta(MCPServerConfig{Name: "slow", CircuitBreakerFailures: 2, CircuitBreakerCooldown: time.Minute})
+
+	call := func() *ToolCallResult {
+		t.Helper()
+		res, err := g.HandleToolsCall(context.Background(), ToolCallParams{Name: "slow__query"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	call()
+	call()
+
+	// The third call fails fast without reaching the client (Times(2) above).
+	res := call()
+	if !res.IsError || !strings.Contains(res.Content[0].Text, `server "slow" is unavailable`) {
+		t.Errorf("expected a fast-fail result, got %+v", res)
+	}
+
+	var state string
+	for _, s := range g.Status() {
+		if s.Name == "slow" {
+			state = s.CircuitState
+		}
+	}
+	if state != CircuitOpen {
+		t.Errorf("Status circuitState = %q, want open", state)
+	}
+
+	g.UnregisterMCPServer("slow")
+	if got := g.circuitState("slow"); got != "" {
+		t.Errorf("unregistering should drop the breaker, got %q", got)
+	}
+}
+
+func TestHandleToolsCall_NoCircuitBreakerByDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	client := setupMockAgentClient(ctrl, "slow", []Tool{{Name: "query", Description: "Query"}})
+	client.EXPECT().CallTool(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("connection refused")).Times(10)
+	g.Router().AddClient(client)
+	g.Router().RefreshTools()
+	g.SetServerMeta(MCPServerConfig{Name: "slow"})
+
+	for range 10 {
+		if _, err := g.HandleToolsCall(context.Background(), ToolCallParams{Name: "slow__query"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
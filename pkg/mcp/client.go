@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -72,9 +73,9 @@ func (c *Client) SetPingTimeout(d time.Duration) {
 func NewClient(name, endpoint string) *Client {
 	c := &Client{
 		endpoint: endpoint,
-		httpClient: &http.Client{
-			Timeout: DefaultRequestTimeout,
-		},
+		// No client-wide timeout: each request is bounded by its context
+		// (see requestTimeout), so a server's longer tool call timeout holds.
+		httpClient: &http.Client{},
 	}
 	initRPCClient(&c.RPCClient, name, c)
 	return c
@@ -162,37 +163,13 @@ func (c *Client) sendHTTP(ctx context.Context, req jsonrpc.Request) (*jsonrpc.Re
 
 // sendHTTPOnce performs a single HTTP round trip for a JSON-RPC request.
 func (c *Client) sendHTTPOnce(ctx context.Context, req jsonrpc.Request) (*jsonrpc.Response, error) {
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("marshaling request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(body))
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(ctx))
+	defer cancel()
+	httpReq, err := c.newPost(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json, text/event-stream")
-
-	if err := c.applyAuthHeader(ctx, httpReq); err != nil {
 		return nil, err
 	}
 
-	// Inject W3C traceparent/tracestate into outgoing request headers.
-	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
-
-	// Include session ID if we have one (for stateful MCP servers) and the
-	// protocol version negotiated at initialize (required by the spec on all
-	// post-initialize requests).
-	c.mu.RLock()
-	if c.sessionID != "" {
-		httpReq.Header.Set("Mcp-Session-Id", c.sessionID)
-	}
-	if c.protocolVersion != "" {
-		httpReq.Header.Set("MCP-Protocol-Version", c.protocolVersion)
-	}
-	c.mu.RUnlock()
-
 	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("sending request: %w", err)
@@ -217,7 +194,7 @@ func (c *Client) sendHTTPOnce(ctx context.Context, req jsonrpc.Request) (*jsonrp
 	// Check if response is SSE format (text/event-stream)
 	contentType := httpResp.Header.Get("Content-Type")
 	if strings.HasPrefix(contentType, "text/event-stream") {
-		return c.parseSSEResponse(httpResp.Body)
+		return c.parseSSEResponse(ctx, httpResp.Body)
 	}
 
 	var resp jsonrpc.Response
@@ -228,38 +205,115 @@ func (c *Client) sendHTTPOnce(ctx context.Context, req jsonrpc.Request) (*jsonrp
 	return &resp, nil
 }
 
-// parseSSEResponse parses a Server-Sent Events formatted response.
-// SSE streams may contain multiple events (notifications + result).
-// We look for the response with an ID field (the actual result), skipping notifications.
-func (c *Client) parseSSEResponse(body io.Reader) (*jsonrpc.Response, error) {
-	data, err := io.ReadAll(body)
+// newPost builds a POST of a JSON-RPC message to the endpoint, carrying the
+// auth header, trace context, session ID, and negotiated protocol version.
+func (c *Client) newPost(ctx context.Context, msg any) (*http.Request, error) {
+	body, err := json.Marshal(msg)
 	if err != nil {
-		return nil, fmt.Errorf("reading SSE response: %w", err)
-	}
-
-	// Parse SSE format: look for "data: " lines
-	// Some MCP servers send multiple SSE events (notifications followed by result).
-	// We need to find the response with an ID field (not a notification).
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "data: ") {
-			jsonData := strings.TrimPrefix(line, "data: ")
-			var resp jsonrpc.Response
-			if err := json.Unmarshal([]byte(jsonData), &resp); err != nil {
-				// Skip malformed lines
-				continue
-			}
-			// Return the response that has an ID (actual result), not notifications
-			// Notifications have a "method" field but no "id" field
-			if resp.ID != nil {
-				return &resp, nil
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
+
+	if err := c.applyAuthHeader(ctx, httpReq); err != nil {
+		return nil, err
+	}
+
+	// Inject W3C traceparent/tracestate into outgoing request headers.
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
+
+	// Include session ID if we have one (for stateful MCP servers) and the
+	// protocol version negotiated at initialize (required by the spec on all
+	// post-initialize requests).
+	c.mu.RLock()
+	if c.sessionID != "" {
+		httpReq.Header.Set("Mcp-Session-Id", c.sessionID)
+	}
+	if c.protocolVersion != "" {
+		httpReq.Header.Set("MCP-Protocol-Version", c.protocolVersion)
+	}
+	c.mu.RUnlock()
+
+	return httpReq, nil
+}
+
+// postResponse sends the answer to a server-sent request. Servers
+// acknowledge it with 202 Accepted (or 200 from older implementations).
+func (c *Client) postResponse(ctx context.Context, resp jsonrpc.Response) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(ctx))
+	defer cancel()
+	httpReq, err := c.newPost(ctx, resp)
+	if err != nil {
+		return err
+	}
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("sending response: %w", err)
+	}
+	defer httpResp.Body.Close()
+	_, _ = io.Copy(io.Discard, httpResp.Body)
+	if httpResp.StatusCode != http.StatusAccepted && httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", httpResp.StatusCode)
+	}
+	return nil
+}
+
+// parseSSEResponse parses a Server-Sent Events formatted response.
+// SSE streams may contain multiple events (notifications, server requests,
+// and the result). We look for the response with an ID field (the actual
+// result); notifications sent ahead of it are passed to the notification
+// handler, and server requests are answered as they arrive, since the server
+// may be waiting on the answer before it sends the result.
+func (c *Client) parseSSEResponse(ctx context.Context, body io.Reader) (*jsonrpc.Response, error) {
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadString('\n')
+		if data, ok := strings.CutPrefix(strings.TrimRight(line, "\r\n"), "data: "); ok {
+			if resp := c.handleSSEData(ctx, []byte(data)); resp != nil {
+				return resp, nil
 			}
 		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading SSE response: %w", err)
+		}
 	}
 
 	return nil, fmt.Errorf("no response with ID found in SSE stream")
 }
 
+// handleSSEData handles one SSE data payload, returning it when it is the
+// response the caller is waiting for.
+func (c *Client) handleSSEData(ctx context.Context, data []byte) *jsonrpc.Response {
+	if req, ok := serverRequest(data); ok {
+		if err := c.postResponse(ctx, c.answerServerRequest(ctx, req)); err != nil {
+			c.logger.Debug("failed to answer server request", "method", req.Method, "error", err)
+		}
+		return nil
+	}
+	var resp jsonrpc.Response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		// Skip malformed lines
+		return nil
+	}
+	// Return the response that has an ID (actual result); hand
+	// notifications (a "method" field but no "id") to the handler.
+	if resp.ID != nil {
+		return &resp
+	}
+	if method, params, ok := parseNotification(data); ok {
+		c.handleNotification(method, params)
+	}
+	return nil
+}
+
 // Ping checks if the agent is reachable.
 func (c *Client) Ping(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, pingTimeoutOrDefault(c.pingTimeout))
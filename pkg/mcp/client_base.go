@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gridctl/gridctl/pkg/jsonrpc"
 	"github.com/gridctl/gridctl/pkg/logging"
@@ -27,6 +28,8 @@ type ClientBase struct {
 	// protocolVersion is the MCP protocol version the downstream server
 	// reported at initialize; empty for lax servers that omit it.
 	protocolVersion string
+	// capabilities is what the downstream server declared at initialize.
+	capabilities Capabilities
 }
 
 // Tools returns the cached tool list filtered by the whitelist, if any.
@@ -105,6 +108,22 @@ func (b *ClientBase) ProtocolVersion() string {
 	return b.protocolVersion
 }
 
+// SetCapabilities records the capabilities the downstream server declared at
+// initialize.
+func (b *ClientBase) SetCapabilities(c Capabilities) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.capabilities = c
+}
+
+// ServerCapabilities returns the capabilities the downstream server declared
+// at initialize; zero when the handshake has not completed.
+func (b *ClientBase) ServerCapabilities() Capabilities {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.capabilities
+}
+
 // filterTools returns only tools whose names are in the whitelist.
 func filterTools(tools []Tool, whitelist []string) []Tool {
 	allowed := make(map[string]bool, len(whitelist))
@@ -159,6 +178,10 @@ type RPCClient struct {
 	name      string
 	logger    *slog.Logger
 	transport transporter
+	lenient   bool                                // normalize non-compliant tools/call results (see compat.go)
+	sampling  bool                                // declare the sampling capability (see SetSampling)
+	notify    atomic.Pointer[NotificationHandler] // server-sent notification handler; nil drops them
+	requests  atomic.Pointer[RequestHandler]      // server-sent request handler; nil rejects them
 }
 
 // initRPCClient initializes the RPCClient fields. Called by transport constructors.
@@ -180,6 +203,20 @@ func (r *RPCClient) SetLogger(logger *slog.Logger) {
 	}
 }
 
+// SetLenientResponses enables normalization of non-compliant tools/call
+// results (bare strings, content objects instead of arrays, string isError,
+// and so on) into proper ToolCallResults. Call before the client is used.
+func (r *RPCClient) SetLenientResponses(enabled bool) {
+	r.lenient = enabled
+}
+
+// SetSampling makes the client declare the sampling capability at
+// initialize, so the server may send sampling/createMessage. It only takes
+// effect while a request handler is set. Call before Initialize.
+func (r *RPCClient) SetSampling(enabled bool) {
+	r.sampling = enabled
+}
+
 // Initialize performs the MCP initialize handshake.
 // If the transport implements connector, Connect() is called first.
 func (r *RPCClient) Initialize(ctx context.Context) error {
@@ -199,6 +236,13 @@ func (r *RPCClient) Initialize(ctx context.Context) error {
 			Tools: &ToolsCapability{},
 		},
 	}
+	if r.requests.Load() != nil {
+		params.Capabilities.Roots = &RootsCapability{ListChanged: true}
+		params.Capabilities.Elicitation = &ElicitationCapability{}
+		if r.sampling {
+			params.Capabilities.Sampling = &SamplingCapability{}
+		}
+	}
 
 	var result InitializeResult
 	if err := r.transport.call(ctx, "initialize", params, &result); err != nil {
@@ -223,6 +267,7 @@ func (r *RPCClient) Initialize(ctx context.Context) error {
 	}
 
 	r.SetProtocolVersion(result.ProtocolVersion)
+	r.SetCapabilities(result.Capabilities)
 	r.SetInitialized(result.ServerInfo)
 
 	// Send initialized notification (non-fatal)
@@ -258,6 +303,18 @@ func (r *RPCClient) CallTool(ctx context.Context, name string, arguments map[str
 		Arguments: arguments,
 	}
 
+	if r.lenient {
+		var raw json.RawMessage
+		if err := r.transport.call(ctx, "tools/call", params, &raw); err != nil {
+			return nil, fmt.Errorf("tools/call: %w", err)
+		}
+		result, fixes := normalizeToolCallResult(raw)
+		if len(fixes) > 0 {
+			r.logger.Debug("normalized non-compliant tool result", "tool", name, "fixes", fixes)
+		}
+		return result, nil
+	}
+
 	var result ToolCallResult
 	if err := r.transport.call(ctx, "tools/call", params, &result); err != nil {
 		return nil, fmt.Errorf("tools/call: %w", err)
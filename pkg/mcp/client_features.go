@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// Protocol versions that introduced fields the gateway forwards. Clients
+// that negotiated an earlier version are sent payloads without them: some
+// reject unknown fields outright instead of ignoring them.
+const (
+	// protocolToolAnnotations introduced tool annotations.
+	protocolToolAnnotations = "2025-03-26"
+	// protocolStructuredContent introduced structuredContent, outputSchema,
+	// and tool titles.
+	protocolStructuredContent = "2025-06-18"
+)
+
+// ClientFeatures is what a connected client can handle, derived at
+// initialize from the protocol version it negotiated and the capabilities
+// it declared. The gateway consults it before sending anything a client
+// did not opt into.
+type ClientFeatures struct {
+	// StructuredContent covers tool outputSchema and title in tools/list
+	// and structuredContent in tools/call results.
+	StructuredContent bool
+	// ToolAnnotations covers tool behavior hints in tools/list.
+	ToolAnnotations bool
+	// Roots means the client answers roots/list.
+	Roots bool
+	// Elicitation means the client answers elicitation/create.
+	Elicitation bool
+	// Sampling means the client answers sampling/createMessage.
+	Sampling bool
+}
+
+// NegotiateClientFeatures derives a client's features from the negotiated
+// protocol version and the capabilities it declared.
+func NegotiateClientFeatures(protocolVersion string, caps Capabilities) ClientFeatures {
+	return ClientFeatures{
+		StructuredContent: protocolAtLeast(protocolVersion, protocolStructuredContent),
+		ToolAnnotations:   protocolAtLeast(protocolVersion, protocolToolAnnotations),
+		Roots:             caps.Roots != nil,
+		Elicitation:       caps.Elicitation != nil,
+		Sampling:          caps.Sampling != nil,
+	}
+}
+
+// Limited reports whether the client is sent downgraded tool payloads.
+func (f ClientFeatures) Limited() bool {
+	return !f.StructuredContent || !f.ToolAnnotations
+}
+
+// protocolAtLeast reports whether version is introduced or a later
+// supported version. Order comes from SupportedProtocolVersions, never from
+// comparing version strings; an unknown version is treated as the latest.
+func protocolAtLeast(version, introduced string) bool {
+	v := slices.Index(SupportedProtocolVersions, version)
+	i := slices.Index(SupportedProtocolVersions, introduced)
+	return v < 0 || v <= i
+}
+
+// adaptTools strips the tool fields the client cannot handle. The input is
+// left untouched; tools are copied only when something is dropped.
+func (f ClientFeatures) adaptTools(tools []Tool) []Tool {
+	if !f.Limited() {
+		return tools
+	}
+	out := make([]Tool, len(tools))
+	for i, t := range tools {
+		if !f.StructuredContent {
+			t.Title = ""
+			t.OutputSchema = nil
+		}
+		if !f.ToolAnnotations {
+			t.Annotations = nil
+		}
+		out[i] = t
+	}
+	return out
+}
+
+// adaptToolResult drops structuredContent for clients that predate it. The
+// spec asks servers to mirror structured results as text, but not all do,
+// so when no text content carries it the JSON is appended as text rather
+// than lost.
+func (f ClientFeatures) adaptToolResult(result *ToolCallResult) *ToolCallResult {
+	if f.StructuredContent || result == nil || len(result.StructuredContent) == 0 {
+		return result
+	}
+	adapted := *result
+	adapted.StructuredContent = nil
+	if !hasTextContent(result.Content) {
+		adapted.Content = append(slices.Clone(result.Content), NewTextContent(string(compactJSON(result.StructuredContent))))
+	}
+	return &adapted
+}
+
+// hasTextContent reports whether any content item is non-empty text.
+func hasTextContent(content []Content) bool {
+	for _, c := range content {
+		if c.Type == "text" && c.Text != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// compactJSON removes insignificant whitespace, returning raw unchanged if
+// it is not valid JSON.
+func compactJSON(raw json.RawMessage) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return raw
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestNegotiateClientFeatures(t *testing.T) {
+	tests := []struct {
+		version     string
+		caps        Capabilities
+		structured  bool
+		annotations bool
+	}{
+		{"2025-11-25", Capabilities{}, true, true},
+		{"2025-06-18", Capabilities{}, true, true},
+		{"2025-03-26", Capabilities{}, false, true},
+		{"2024-11-05", Capabilities{}, false, false},
+		{"2099-01-01", Capabilities{}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			f := NegotiateClientFeatures(tt.version, tt.caps)
+			if f.StructuredContent != tt.structured || f.ToolAnnotations != tt.annotations {
+				t.Errorf("features = %+v, want structured=%v annotations=%v", f, tt.structured, tt.annotations)
+			}
+			if f.Limited() == (tt.structured && tt.annotations) {
+				t.Errorf("Limited() = %v for %+v", f.Limited(), f)
+			}
+		})
+	}
+
+	f := NegotiateClientFeatures(MCPProtocolVersion, Capabilities{Roots: &RootsCapability{}, Elicitation: &ElicitationCapability{}, Sampling: &SamplingCapability{}})
+	if !f.Roots || !f.Elicitation || !f.Sampling {
+		t.Errorf("features = %+v, want roots, elicitation, and sampling from the declared capabilities", f)
+	}
+}
+
+func TestClientFeatures_AdaptTools(t *testing.T) {
+	readOnly := true
+	tools := []Tool{{
+		Name:         "search",
+		Title:        "Search",
+		OutputSchema: json.RawMessage(`{"type":"object"}`),
+		Annotations:  &ToolAnnotations{ReadOnlyHint: &readOnly},
+	}}
+
+	got := NegotiateClientFeatures("2025-03-26", Capabilities{}).adaptTools(tools)
+	if got[0].Title != "" || got[0].OutputSchema != nil || got[0].Annotations == nil {
+		t.Errorf("2025-03-26 tool = %+v, want title and outputSchema dropped, annotations kept", got[0])
+	}
+	got = NegotiateClientFeatures("2024-11-05", Capabilities{}).adaptTools(tools)
+	if got[0].Annotations != nil {
+		t.Errorf("2024-11-05 tool annotations = %+v, want none", got[0].Annotations)
+	}
+	if tools[0].Title != "Search" || tools[0].OutputSchema == nil || tools[0].Annotations == nil {
+		t.Errorf("adaptTools modified its input: %+v", tools[0])
+	}
+}
+
+func TestClientFeatures_AdaptToolResult(t *testing.T) {
+	old := NegotiateClientFeatures("2025-03-26", Capabilities{})
+	structured := json.RawMessage(`{ "count": 2 }`)
+
+	// Text already mirrors the structured result: just drop it.
+	result := old.adaptToolResult(&ToolCallResult{Content: []Content{NewTextContent("2 results")}, StructuredContent: structured})
+	if result.StructuredContent != nil || len(result.Content) != 1 {
+		t.Errorf("result = %+v, want structuredContent dropped and content unchanged", result)
+	}
+
+	// No text: the structured result is kept as text.
+	orig := &ToolCallResult{Content: []Content{}, StructuredContent: structured}
+	result = old.adaptToolResult(orig)
+	if result.StructuredContent != nil || len(result.Content) != 1 || result.Content[0].Text != `{"count":2}` {
+		t.Errorf("result = %+v, want the structured result as text", result)
+	}
+	if orig.StructuredContent == nil {
+		t.Error("adaptToolResult modified its input")
+	}
+
+	current := NegotiateClientFeatures(MCPProtocolVersion, Capabilities{})
+	if result := current.adaptToolResult(orig); result != orig {
+		t.Error("a current client should get the result unchanged")
+	}
+}
+
+func TestStreamable_DowngradesToolsForOlderClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	client := setupMockAgentClient(ctrl, "svc", []Tool{{
+		Name:         "report",
+		Title:        "Report",
+		InputSchema:  json.RawMessage(`{"type":"object"}`),
+		OutputSchema: json.RawMessage(`{"type":"object"}`),
+	}})
+	client.EXPECT().CallTool(gomock.Any(), "report", gomock.Any()).Return(&ToolCallResult{
+		Content:           []Content{},
+		StructuredContent: json.RawMessage(`{"ok":true}`),
+	}, nil).AnyTimes()
+	g.Router().AddClient(client)
+	g.Router().RefreshTools()
+	srv := NewStreamableHTTPServer(g, nil)
+
+	older := initializeWithVersion(t, srv, "2025-03-26").Header().Get("Mcp-Session-Id")
+	resp := streamablePost(t, srv, older, "tools/list", nil)
+	if strings.Contains(string(resp.Result), "outputSchema") || strings.Contains(string(resp.Result), `"title"`) {
+		t.Errorf("tools/list for 2025-03-26 = %s, want no outputSchema or title", resp.Result)
+	}
+	resp = streamablePost(t, srv, older, "tools/call", map[string]any{"name": "svc__report", "arguments": map[string]any{}})
+	if strings.Contains(string(resp.Result), "structuredContent") || !strings.Contains(string(resp.Result), `{\"ok\":true}`) {
+		t.Errorf("tools/call for 2025-03-26 = %s, want the structured result as text only", resp.Result)
+	}
+
+	current := initializeWithVersion(t, srv, MCPProtocolVersion).Header().Get("Mcp-Session-Id")
+	resp = streamablePost(t, srv, current, "tools/call", map[string]any{"name": "svc__report", "arguments": map[string]any{}})
+	if !strings.Contains(string(resp.Result), "structuredContent") {
+		t.Errorf("tools/call for %s = %s, want structuredContent", MCPProtocolVersion, resp.Result)
+	}
+}
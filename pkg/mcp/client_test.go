@@ -70,7 +70,7 @@ data: {"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"succes
 `
 
 	client := &Client{}
-	resp, err := client.parseSSEResponse(strings.NewReader(sseBody))
+	resp, err := client.parseSSEResponse(context.Background(), strings.NewReader(sseBody))
 	if err != nil {
 		t.Fatalf("parseSSEResponse failed: %v", err)
 	}
@@ -103,7 +103,7 @@ data: {"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info"
 `
 
 	client := &Client{}
-	_, err := client.parseSSEResponse(strings.NewReader(sseBody))
+	_, err := client.parseSSEResponse(context.Background(), strings.NewReader(sseBody))
 	if err == nil {
 		t.Fatal("expected error when no response with ID is found")
 	}
@@ -122,7 +122,7 @@ data: {"jsonrpc":"2.0","id":1,"result":{}}
 `
 
 	client := &Client{}
-	resp, err := client.parseSSEResponse(strings.NewReader(sseBody))
+	resp, err := client.parseSSEResponse(context.Background(), strings.NewReader(sseBody))
 	if err != nil {
 		t.Fatalf("parseSSEResponse failed with malformed data skipped: %v", err)
 	}
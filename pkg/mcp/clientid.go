@@ -52,6 +52,50 @@ func ClientAccessIDFromContext(ctx context.Context) string {
 	return v
 }
 
+// authenticatedClientKey is the context key under which the HTTP auth
+// middleware records the client identity a request's credential maps to
+// (the name of a gateway.auth.keys entry).
+type authenticatedClientKey struct{}
+
+// WithAuthenticatedClient returns a child context carrying the client
+// identity proven by the request's credential. An empty name leaves the
+// context unchanged.
+func WithAuthenticatedClient(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, authenticatedClientKey{}, name)
+}
+
+// AuthenticatedClientFromContext returns the identity previously stored on
+// ctx via WithAuthenticatedClient, or "" when the request's credential
+// carries none (no auth, or the shared token).
+func AuthenticatedClientFromContext(ctx context.Context) string {
+	v, _ := ctx.Value(authenticatedClientKey{}).(string)
+	return v
+}
+
+// sessionIDKey is the context key under which the gateway propagates the
+// MCP session a request arrived on, so a downstream server's request made
+// mid-call (elicitation) can be routed back to the session that made it.
+type sessionIDKey struct{}
+
+// WithSessionID returns a child context carrying the originating session ID.
+// An empty id leaves the context unchanged.
+func WithSessionID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, sessionIDKey{}, id)
+}
+
+// SessionIDFromContext returns the session ID previously stored on ctx via
+// WithSessionID, or "" when the request did not arrive on an MCP session.
+func SessionIDFromContext(ctx context.Context) string {
+	v, _ := ctx.Value(sessionIDKey{}).(string)
+	return v
+}
+
 // groupKey is the context key under which the gateway propagates the tool
 // group a session is bound to (from the /groups/{name}/mcp endpoint the
 // client connected through). Empty means the default full-surface /mcp
@@ -81,14 +125,19 @@ func GroupFromContext(ctx context.Context) string {
 // the `client` query parameter on the gateway URL it writes.
 const ClientAccessIDHeader = "X-Gridctl-Client-Id"
 
-// clientAccessIDFromRequest extracts the explicit, link-time-assigned client
-// identifier from a request: the `client` query parameter takes precedence,
-// then the X-Gridctl-Client-Id header. Returns "" when neither is present, in
-// which case the gateway falls back to NormalizeClientID(clientInfo.name).
+// clientAccessIDFromRequest extracts the explicit client identifier from a
+// request. An identity proven by the request's API key wins, so a keyed
+// client cannot claim another name; otherwise the link-time-assigned `client`
+// query parameter takes precedence, then the X-Gridctl-Client-Id header.
+// Returns "" when none is present, in which case the gateway falls back to
+// NormalizeClientID(clientInfo.name).
 func clientAccessIDFromRequest(r *http.Request) string {
 	if r == nil {
 		return ""
 	}
+	if v := AuthenticatedClientFromContext(r.Context()); v != "" {
+		return v
+	}
 	if r.URL != nil {
 		if v := strings.TrimSpace(r.URL.Query().Get("client")); v != "" {
 			return v
@@ -56,6 +56,15 @@ func TestClientAccessIDFromRequest(t *testing.T) {
 	}
 }
 
+func TestClientAccessIDFromRequest_AuthenticatedClientWins(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "http://x/mcp?client=claimed", nil)
+	r.Header.Set(ClientAccessIDHeader, "also-claimed")
+	r = r.WithContext(WithAuthenticatedClient(r.Context(), "ci-bot"))
+	if got := clientAccessIDFromRequest(r); got != "ci-bot" {
+		t.Errorf("clientAccessIDFromRequest() = %q, want ci-bot", got)
+	}
+}
+
 // TestSessionAccessIDReconciliation covers the wire-vs-config identity
 // reconciliation: the explicit link-time identifier wins, otherwise the
 // normalized clientInfo.name is used, and both are normalized to a single form.
@@ -12,10 +12,16 @@ import "sort"
 // Aliases are raw clientInfo.name values that should resolve to this profile,
 // letting an operator reconcile a wire identity that differs from the profile
 // key without depending on the built-in NormalizeClientID alias table.
+//
+// Skills is an allow-list of registry skill names, applied to the prompts and
+// skills:// resources the gateway serves. Unlike the tool axes it only ever
+// narrows: an empty list, like an unlisted client, leaves every active skill
+// visible, as it was before skills could be scoped.
 type ClientProfileSpec struct {
 	Aliases []string
 	Servers []string
 	Tools   []string
+	Skills  []string
 }
 
 // ClientAccessSpec is the config-agnostic description of the whole `clients:`
@@ -31,6 +37,7 @@ type ClientAccessSpec struct {
 type clientProfile struct {
 	servers map[string]bool // allowed server names; empty = all servers
 	tools   map[string]bool // allowed prefixed tool names; empty = all tools within servers
+	skills  map[string]bool // allowed registry skill names; empty = all skills
 }
 
 // allowsTool reports whether the given prefixed tool name is permitted by this
@@ -54,6 +61,8 @@ func (p clientProfile) allowsTool(prefixedName string) bool {
 // every gateway exposure path (tools/list, tools/call, and the code-mode tool
 // universe). It is a read-time filter modeled on the per-server tool whitelist
 // in client_base.go, keyed on the connecting client's stable access identifier.
+// Profiles with a skills allow-list also scope prompts/list, prompts/get, and
+// the skills:// resources (see AllowsSkill).
 //
 // A nil *ClientAccessPolicy means no `clients:` block was configured: every
 // client sees every tool (legacy behavior, Article IX). A non-nil policy
@@ -83,6 +92,7 @@ func NewClientAccessPolicy(spec *ClientAccessSpec) *ClientAccessPolicy {
 		cp := clientProfile{
 			servers: make(map[string]bool, len(prof.Servers)),
 			tools:   make(map[string]bool, len(prof.Tools)),
+			skills:  make(map[string]bool, len(prof.Skills)),
 		}
 		for _, s := range prof.Servers {
 			cp.servers[s] = true
@@ -90,6 +100,9 @@ func NewClientAccessPolicy(spec *ClientAccessSpec) *ClientAccessPolicy {
 		for _, t := range prof.Tools {
 			cp.tools[t] = true
 		}
+		for _, sk := range prof.Skills {
+			cp.skills[sk] = true
+		}
 		p.profiles[key] = cp
 		for _, alias := range prof.Aliases {
 			if na := NormalizeClientID(alias); na != "" {
@@ -128,6 +141,21 @@ func (p *ClientAccessPolicy) Allows(accessID, prefixedName string) bool {
 	return p.profiles[key].allowsTool(prefixedName)
 }
 
+// AllowsSkill reports whether the client identified by accessID may see the
+// named registry skill. Only a profile with a skills allow-list restricts:
+// a nil policy, an unlisted client, and a profile without skills all allow.
+func (p *ClientAccessPolicy) AllowsSkill(accessID, name string) bool {
+	if p == nil {
+		return true
+	}
+	key, listed := p.resolveKey(accessID)
+	if !listed {
+		return true
+	}
+	skills := p.profiles[key].skills
+	return len(skills) == 0 || skills[name]
+}
+
 // Filter returns the subset of tools visible to the client identified by
 // accessID. A nil policy returns the tools unchanged.
 func (p *ClientAccessPolicy) Filter(accessID string, tools []Tool) []Tool {
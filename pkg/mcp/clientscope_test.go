@@ -152,6 +152,36 @@ func TestClientAccessPolicy_Allows(t *testing.T) {
 	}
 }
 
+func TestClientAccessPolicy_AllowsSkill(t *testing.T) {
+	reviewer := &ClientAccessSpec{Profiles: map[string]ClientProfileSpec{
+		"reviewer": {Aliases: []string{"Review Bot"}, Servers: []string{"github"}, Skills: []string{"code-review"}},
+		"cursor":   {Servers: []string{"github"}},
+	}}
+	tests := []struct {
+		name     string
+		spec     *ClientAccessSpec
+		accessID string
+		skill    string
+		want     bool
+	}{
+		{"no block allows any skill", nil, "anyone", "deploy", true},
+		{"skill allow-list accepts listed skill", reviewer, "reviewer", "code-review", true},
+		{"skill allow-list rejects unlisted skill", reviewer, "reviewer", "deploy", false},
+		{"alias resolves to the profile's skills", reviewer, "Review Bot", "deploy", false},
+		{"profile without skills allows every skill", reviewer, "cursor", "deploy", true},
+		{"unlisted client under default deny still sees skills", reviewer, "windsurf", "deploy", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := NewClientAccessPolicy(tt.spec)
+			if got := policy.AllowsSkill(tt.accessID, tt.skill); got != tt.want {
+				t.Errorf("AllowsSkill(%q, %q) = %v, want %v", tt.accessID, tt.skill, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestClientAccessPolicy_ScopeResult(t *testing.T) {
 	t.Run("nil policy is unscoped and lists everything", func(t *testing.T) {
 		var policy *ClientAccessPolicy
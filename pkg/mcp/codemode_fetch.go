@@ -14,6 +14,8 @@ import (
 
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/eventloop"
+
+	"github.com/gridctl/gridctl/pkg/crash"
 )
 
 // DefaultFetchMaxResponseBytes is the default response size cap (1MB).
@@ -197,6 +199,7 @@ func (sf *sandboxedFetch) inject(vm *goja.Runtime, loop *eventloop.EventLoop) {
 		capturedHTTPSOnly := sf.config.HTTPSOnly
 
 		go func() {
+			defer crash.Recover("codemode-fetch")
 			deliver := func(fn func(*goja.Runtime)) {
 				loop.RunOnLoop(func(vm *goja.Runtime) {
 					loop.ClearTimeout(keepAlive)
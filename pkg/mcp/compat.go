@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Lenient response handling for downstream servers that bend the MCP spec.
+// Community servers commonly return a bare string instead of a content
+// array, put a single content object where an array belongs, omit the
+// content item's type, or spell isError as a string or in snake_case. With
+// lenient responses enabled for a server (MCPServerConfig.LenientResponses),
+// RPCClient.CallTool accepts those shapes and rewrites them into a proper
+// ToolCallResult instead of failing the call with a decode error.
+
+// normalizeToolCallResult decodes a tools/call result, tolerating the
+// common spec violations described above. fixes names each repair applied,
+// for debug logging; it is empty for a compliant result.
+func normalizeToolCallResult(raw json.RawMessage) (result *ToolCallResult, fixes []string) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return &ToolCallResult{Content: []Content{}}, []string{"null result"}
+	}
+
+	var strict ToolCallResult
+	if err := json.Unmarshal(trimmed, &strict); err == nil && strict.Content != nil &&
+		contentTyped(strict.Content) && (strict.IsError || !bytes.Contains(trimmed, []byte(`"is_error"`))) {
+		return &strict, nil
+	}
+
+	var v any
+	if err := json.Unmarshal(trimmed, &v); err != nil {
+		// Not JSON at all: pass the bytes through as text.
+		return &ToolCallResult{Content: []Content{NewTextContent(string(trimmed))}}, []string{"non-JSON result"}
+	}
+
+	switch val := v.(type) {
+	case string:
+		return &ToolCallResult{Content: []Content{NewTextContent(val)}}, []string{"string result"}
+	case []any:
+		return &ToolCallResult{Content: normalizeContentItems(val)}, []string{"bare content array"}
+	case map[string]any:
+		return normalizeResultObject(val, trimmed)
+	default:
+		// Numbers and booleans.
+		return &ToolCallResult{Content: []Content{NewTextContent(string(trimmed))}}, []string{"scalar result"}
+	}
+}
+
+// normalizeResultObject handles an object result whose content, isError,
+// or content items are malformed.
+func normalizeResultObject(obj map[string]any, raw json.RawMessage) (*ToolCallResult, []string) {
+	result := &ToolCallResult{}
+	var fixes []string
+
+	switch content := obj["content"].(type) {
+	case []any:
+		result.Content = normalizeContentItems(content)
+		if !itemsAllTyped(content) {
+			fixes = append(fixes, "untyped content items")
+		}
+	case string:
+		result.Content = []Content{NewTextContent(content)}
+		fixes = append(fixes, "string content")
+	case map[string]any:
+		result.Content = normalizeContentItems([]any{content})
+		fixes = append(fixes, "single content object")
+	case nil:
+		// No content: fall back to a text field, an error message, or the
+		// whole object as JSON so the model still sees the payload.
+		switch {
+		case isString(obj["text"]):
+			result.Content = []Content{NewTextContent(obj["text"].(string))}
+		case isString(obj["error"]):
+			result.Content = []Content{NewTextContent(obj["error"].(string))}
+			result.IsError = true
+		default:
+			result.Content = []Content{NewTextContent(string(raw))}
+		}
+		fixes = append(fixes, "missing content")
+	default:
+		result.Content = []Content{NewTextContent(jsonText(content))}
+		fixes = append(fixes, "non-array content")
+	}
+
+	if isErr, fixed, ok := lenientBool(obj["isError"]); ok {
+		result.IsError = result.IsError || isErr
+		if fixed {
+			fixes = append(fixes, "non-boolean isError")
+		}
+	} else if isErr, _, ok := lenientBool(obj["is_error"]); ok {
+		result.IsError = result.IsError || isErr
+		fixes = append(fixes, "is_error spelling")
+	}
+
+	if sc, ok := obj["structuredContent"]; ok && sc != nil {
+		if b, err := json.Marshal(sc); err == nil {
+			result.StructuredContent = b
+		}
+	}
+	return result, fixes
+}
+
+// normalizeContentItems converts loosely shaped content items: a plain
+// string becomes text, an object missing "type" is text when it carries
+// "text", and anything else is rendered as JSON text.
+func normalizeContentItems(items []any) []Content {
+	out := make([]Content, 0, len(items))
+	for _, item := range items {
+		switch it := item.(type) {
+		case string:
+			out = append(out, NewTextContent(it))
+		case map[string]any:
+			typ, _ := it["type"].(string)
+			text, hasText := it["text"].(string)
+			switch {
+			case typ != "":
+				out = append(out, Content{Type: typ, Text: text})
+			case hasText:
+				out = append(out, NewTextContent(text))
+			default:
+				out = append(out, NewTextContent(jsonText(it)))
+			}
+		case nil:
+			// Drop null entries.
+		default:
+			out = append(out, NewTextContent(jsonText(it)))
+		}
+	}
+	return out
+}
+
+// contentTyped reports whether every content item carries a type.
+func contentTyped(content []Content) bool {
+	for _, c := range content {
+		if c.Type == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// itemsAllTyped reports whether every raw content item is an object with a
+// string "type".
+func itemsAllTyped(items []any) bool {
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return false
+		}
+		if typ, _ := obj["type"].(string); typ == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// lenientBool reads a boolean that some servers send as a string or a
+// number. ok is false when the value is absent or unintelligible; fixed is
+// true when it was not already a JSON boolean.
+func lenientBool(v any) (value, fixed, ok bool) {
+	switch b := v.(type) {
+	case bool:
+		return b, false, true
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(b))
+		if err != nil {
+			return false, false, false
+		}
+		return parsed, true, true
+	case float64:
+		return b != 0, true, true
+	}
+	return false, false, false
+}
+
+// responseID extracts the gateway-issued request ID from a response. The
+// gateway only issues integer IDs, so a server that echoes the ID back as a
+// string ("7" for 7) is matched too rather than leaving the caller to time
+// out.
+func responseID(raw *json.RawMessage) (int64, bool) {
+	if raw == nil {
+		return 0, false
+	}
+	var id int64
+	if err := json.Unmarshal(*raw, &id); err == nil {
+		return id, true
+	}
+	var s string
+	if err := json.Unmarshal(*raw, &s); err != nil {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+func isString(v any) bool {
+	_, ok := v.(string)
+	return ok
+}
+
+func jsonText(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
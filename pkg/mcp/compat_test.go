@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalizeToolCallResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		wantText  []string
+		wantError bool
+		wantFixed bool
+	}{
+		{name: "compliant", raw: `{"content":[{"type":"text","text":"hi"}]}`, wantText: []string{"hi"}},
+		{name: "compliant error", raw: `{"content":[{"type":"text","text":"no"}],"isError":true}`, wantText: []string{"no"}, wantError: true},
+		{name: "compliant empty", raw: `{"content":[]}`, wantText: []string{}},
+		{name: "null", raw: `null`, wantText: []string{}, wantFixed: true},
+		{name: "bare string", raw: `"plain answer"`, wantText: []string{"plain answer"}, wantFixed: true},
+		{name: "bare number", raw: `42`, wantText: []string{"42"}, wantFixed: true},
+		{name: "bare content array", raw: `[{"type":"text","text":"a"},"b"]`, wantText: []string{"a", "b"}, wantFixed: true},
+		{name: "string content", raw: `{"content":"inline"}`, wantText: []string{"inline"}, wantFixed: true},
+		{name: "single content object", raw: `{"content":{"type":"text","text":"one"}}`, wantText: []string{"one"}, wantFixed: true},
+		{name: "untyped items", raw: `{"content":[{"text":"x"},{"value":1}]}`, wantText: []string{"x", `{"value":1}`}, wantFixed: true},
+		{name: "text field", raw: `{"text":"t"}`, wantText: []string{"t"}, wantFixed: true},
+		{name: "error field", raw: `{"error":"boom"}`, wantText: []string{"boom"}, wantError: true, wantFixed: true},
+		{name: "arbitrary object", raw: `{"temp":21}`, wantText: []string{`{"temp":21}`}, wantFixed: true},
+		{name: "string isError", raw: `{"content":[{"type":"text","text":"e"}],"isError":"true"}`, wantText: []string{"e"}, wantError: true, wantFixed: true},
+		{name: "snake case is_error", raw: `{"content":[{"type":"text","text":"e"}],"is_error":true}`, wantText: []string{"e"}, wantError: true, wantFixed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, fixes := normalizeToolCallResult(json.RawMessage(tt.raw))
+			if (len(fixes) > 0) != tt.wantFixed {
+				t.Errorf("fixes = %v, wantFixed %v", fixes, tt.wantFixed)
+			}
+			if result.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", result.IsError, tt.wantError)
+			}
+			if len(result.Content) != len(tt.wantText) {
+				t.Fatalf("content = %+v, want %d items", result.Content, len(tt.wantText))
+			}
+			for i, want := range tt.wantText {
+				if result.Content[i].Type != "text" || result.Content[i].Text != want {
+					t.Errorf("content[%d] = %+v, want text %q", i, result.Content[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeToolCallResult_KeepsStructuredContent(t *testing.T) {
+	result, _ := normalizeToolCallResult(json.RawMessage(`{"content":"x","structuredContent":{"n":1}}`))
+	if string(result.StructuredContent) != `{"n":1}` {
+		t.Errorf("structuredContent = %s", result.StructuredContent)
+	}
+}
+
+func TestResponseID(t *testing.T) {
+	tests := []struct {
+		raw    string
+		want   int64
+		wantOK bool
+	}{
+		{`7`, 7, true},
+		{`"7"`, 7, true},
+		{`" 12 "`, 12, true},
+		{`"abc"`, 0, false},
+		{`{}`, 0, false},
+	}
+	for _, tt := range tests {
+		raw := json.RawMessage(tt.raw)
+		got, ok := responseID(&raw)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("responseID(%s) = %d, %v; want %d, %v", tt.raw, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if _, ok := responseID(nil); ok {
+		t.Error("nil id should not match")
+	}
+}
+
+func TestRPCClient_CallTool_LenientResponses(t *testing.T) {
+	ft := &fakeTransport{
+		callFn: func(_ context.Context, _ string, _ any, result any) error {
+			return json.Unmarshal([]byte(`"bare string result"`), result)
+		},
+	}
+	r := newFakeRPCClient("loose", ft)
+
+	if _, err := r.CallTool(context.Background(), "echo", nil); err == nil {
+		t.Fatal("strict mode should reject a bare string result")
+	}
+
+	r.SetLenientResponses(true)
+	result, err := r.CallTool(context.Background(), "echo", nil)
+	if err != nil {
+		t.Fatalf("lenient CallTool() error = %v", err)
+	}
+	if len(result.Content) != 1 || result.Content[0].Text != "bare string result" {
+		t.Errorf("content = %+v", result.Content)
+	}
+}
@@ -0,0 +1,237 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MethodCompletionComplete asks a server for suggested values of a prompt
+// or resource-template argument (MCP spec, client -> server).
+const MethodCompletionComplete = "completion/complete"
+
+// Completion reference types. CompletionRefTool is a gridctl extension that
+// completes a tool argument from the enum in its input schema.
+const (
+	CompletionRefPrompt   = "ref/prompt"
+	CompletionRefResource = "ref/resource"
+	CompletionRefTool     = "ref/tool"
+)
+
+const (
+	// maxCompletionValues is the most values a completion result may carry
+	// (MCP spec); the rest are reported through total and hasMore.
+	maxCompletionValues = 100
+	// completionTimeout bounds a completion/complete forwarded to a
+	// downstream server. Completions back interactive autocomplete, so a
+	// slow server is dropped rather than waited on.
+	completionTimeout = 5 * time.Second
+)
+
+// Complete asks the server for argument completions. Callers check the
+// server declared the completions capability first.
+func (r *RPCClient) Complete(ctx context.Context, params CompleteParams) (*CompleteResult, error) {
+	var result CompleteResult
+	if err := r.transport.call(ctx, MethodCompletionComplete, params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// completer is implemented by clients that can forward completion/complete.
+type completer interface {
+	Complete(ctx context.Context, params CompleteParams) (*CompleteResult, error)
+}
+
+// HandleComplete answers completion/complete. Registry prompts complete from
+// their arguments' declared values; a server-prefixed prompt name
+// ("server__prompt") is forwarded to that server; a resource template is
+// asked of every server that declared completions, merging the answers; and
+// a tool reference completes from the enum in the tool's input schema.
+// Unknown references complete to nothing rather than failing, so clients
+// can ask freely while the user types.
+func (g *Gateway) HandleComplete(ctx context.Context, params CompleteParams) (*CompleteResult, error) {
+	if params.Argument.Name == "" {
+		return nil, fmt.Errorf("argument name is required")
+	}
+	switch params.Ref.Type {
+	case CompletionRefPrompt:
+		return g.completePrompt(ctx, params), nil
+	case CompletionRefResource:
+		return g.completeResource(ctx, params), nil
+	case CompletionRefTool:
+		return g.completeTool(ctx, params), nil
+	default:
+		return nil, fmt.Errorf("unsupported completion reference type %q", params.Ref.Type)
+	}
+}
+
+// completePrompt completes a registry prompt's argument, or forwards the
+// request for a server-prefixed prompt.
+func (g *Gateway) completePrompt(ctx context.Context, params CompleteParams) *CompleteResult {
+	if pp := g.promptProvider(); pp != nil {
+		if !g.clientAllowsSkill(ctx, params.Ref.Name) {
+			return completionOf(nil, "")
+		}
+		if p, err := pp.GetPromptData(params.Ref.Name); err == nil {
+			for _, arg := range p.Arguments {
+				if arg.Name == params.Argument.Name {
+					return completionOf(arg.Values, params.Argument.Value)
+				}
+			}
+			return completionOf(nil, "")
+		}
+	}
+
+	client, prompt, err := g.router.RouteToolCall(params.Ref.Name)
+	if err != nil {
+		return completionOf(nil, "")
+	}
+	server, _, _ := ParsePrefixedTool(params.Ref.Name)
+	forwarded := params
+	forwarded.Ref.Name = prompt
+	return completionOf(g.forwardComplete(ctx, server, client, forwarded), "")
+}
+
+// completeResource asks every downstream server that declared completions
+// and merges their suggestions in server-name order. The skills:// resources
+// the gateway serves itself take no arguments.
+func (g *Gateway) completeResource(ctx context.Context, params CompleteParams) *CompleteResult {
+	if strings.HasPrefix(params.Ref.URI, "skills://") {
+		return completionOf(nil, "")
+	}
+
+	sets := g.router.ReplicaSets()
+	answers := make([][]string, len(sets))
+	var wg sync.WaitGroup
+	for i, set := range sets {
+		replica, err := set.Pick()
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, name string, client AgentClient) {
+			defer wg.Done()
+			answers[i] = g.forwardComplete(ctx, name, client, params)
+		}(i, set.Name(), replica.Client())
+	}
+	wg.Wait()
+
+	var merged []string
+	for _, values := range answers {
+		merged = append(merged, values...)
+	}
+	return completionOf(dedupeStrings(merged), "")
+}
+
+// completeTool completes a tool argument from the enum in the tool's input
+// schema, honouring the caller's group aliases and client scope.
+func (g *Gateway) completeTool(ctx context.Context, params CompleteParams) *CompleteResult {
+	name := params.Ref.Name
+	if group := GroupFromContext(ctx); group != "" {
+		canonical, ok := g.CurrentGroupPolicy().ResolveAlias(group, name, g.router.HasTool)
+		if !ok {
+			return completionOf(nil, "")
+		}
+		name = canonical
+	}
+	if !g.clientAllowsToolCall(ctx, name) {
+		return completionOf(nil, "")
+	}
+	for _, tool := range g.router.CatalogTools() {
+		if tool.Name == name {
+			return completionOf(schemaEnum(tool.InputSchema, params.Argument.Name), params.Argument.Value)
+		}
+	}
+	return completionOf(nil, "")
+}
+
+// forwardComplete sends completion/complete to one downstream server. It
+// returns nothing for servers that did not declare completions; failures are
+// logged and swallowed so one slow or broken server cannot break
+// autocomplete.
+func (g *Gateway) forwardComplete(ctx context.Context, server string, client AgentClient, params CompleteParams) []string {
+	c, ok := client.(completer)
+	if !ok {
+		return nil
+	}
+	if caps := capabilitiesOf(client); caps == nil || caps.Completions == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+	result, err := c.Complete(ctx, params)
+	if err != nil {
+		g.logger.Debug("completion/complete failed", "server", server, "error", err)
+		return nil
+	}
+	return result.Completion.Values
+}
+
+// completionOf builds a completion result from the values that start with
+// prefix (case-insensitively), capped at maxCompletionValues.
+func completionOf(values []string, prefix string) *CompleteResult {
+	matches := make([]string, 0, len(values))
+	lower := strings.ToLower(prefix)
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), lower) {
+			matches = append(matches, v)
+		}
+	}
+	c := Completion{Values: matches}
+	if len(matches) > maxCompletionValues {
+		c = Completion{Values: matches[:maxCompletionValues], Total: len(matches), HasMore: true}
+	}
+	return &CompleteResult{Completion: c}
+}
+
+// schemaEnum returns the enum values of a property in a JSON Schema object,
+// looking through array items. Non-string values are rendered as JSON.
+func schemaEnum(schema json.RawMessage, property string) []string {
+	type enumSchema struct {
+		Enum  []json.RawMessage `json:"enum"`
+		Items *struct {
+			Enum []json.RawMessage `json:"enum"`
+		} `json:"items"`
+	}
+	var s struct {
+		Properties map[string]enumSchema `json:"properties"`
+	}
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil
+	}
+	prop, ok := s.Properties[property]
+	if !ok {
+		return nil
+	}
+	raw := prop.Enum
+	if len(raw) == 0 && prop.Items != nil {
+		raw = prop.Items.Enum
+	}
+	values := make([]string, 0, len(raw))
+	for _, r := range raw {
+		var str string
+		if json.Unmarshal(r, &str) == nil {
+			values = append(values, str)
+		} else {
+			values = append(values, string(r))
+		}
+	}
+	return values
+}
+
+// dedupeStrings drops repeated values, keeping first occurrences in order.
+func dedupeStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+	"go.uber.org/mock/gomock"
+)
+
+// completingClient wraps a MockAgentClient with a server that declared the
+// completions capability and answers with fixed values.
+type completingClient struct {
+	AgentClient
+	values []string
+	got    []CompleteParams
+}
+
+func (c *completingClient) ServerCapabilities() Capabilities {
+	return Capabilities{Completions: &CompletionsCapability{}}
+}
+
+func (c *completingClient) Complete(_ context.Context, params CompleteParams) (*CompleteResult, error) {
+	c.got = append(c.got, params)
+	return &CompleteResult{Completion: Completion{Values: c.values}}, nil
+}
+
+func TestGateway_HandleComplete_RegistryPromptValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	g.Router().AddClient(&promptProviderClient{
+		AgentClient: setupMockAgentClient(ctrl, "registry", nil),
+		prompts: []PromptData{{
+			Name:      "deploy",
+			Arguments: []PromptArgumentData{{Name: "variant", Values: []string{"default", "Terse", "verbose"}}},
+		}},
+	})
+
+	result, err := g.HandleComplete(context.Background(), CompleteParams{
+		Ref:      CompletionRef{Type: CompletionRefPrompt, Name: "deploy"},
+		Argument: CompletionArgument{Name: "variant", Value: "t"},
+	})
+	if err != nil {
+		t.Fatalf("HandleComplete: %v", err)
+	}
+	if want := []string{"Terse"}; !reflect.DeepEqual(result.Completion.Values, want) {
+		t.Errorf("values = %v, want %v", result.Completion.Values, want)
+	}
+}
+
+func TestGateway_HandleComplete_ForwardsPrefixedPrompt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	backend := &completingClient{AgentClient: setupMockAgentClient(ctrl, "github", nil), values: []string{"main", "release"}}
+	g.Router().AddClient(backend)
+
+	result, err := g.HandleComplete(context.Background(), CompleteParams{
+		Ref:      CompletionRef{Type: CompletionRefPrompt, Name: "github__review"},
+		Argument: CompletionArgument{Name: "branch", Value: "ma"},
+	})
+	if err != nil {
+		t.Fatalf("HandleComplete: %v", err)
+	}
+	if len(backend.got) != 1 || backend.got[0].Ref.Name != "review" || backend.got[0].Argument.Value != "ma" {
+		t.Fatalf("forwarded = %+v, want the unprefixed prompt and partial value", backend.got)
+	}
+	// The server's answer is passed through; it did its own filtering.
+	if want := []string{"main", "release"}; !reflect.DeepEqual(result.Completion.Values, want) {
+		t.Errorf("values = %v, want %v", result.Completion.Values, want)
+	}
+}
+
+func TestGateway_HandleComplete_MergesResourceCompletions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	g.Router().AddClient(&completingClient{AgentClient: setupMockAgentClient(ctrl, "a", nil), values: []string{"x", "y"}})
+	g.Router().AddClient(&completingClient{AgentClient: setupMockAgentClient(ctrl, "b", nil), values: []string{"y", "z"}})
+	// A server without the capability is not asked.
+	g.Router().AddClient(setupMockAgentClient(ctrl, "c", nil))
+
+	result, err := g.HandleComplete(context.Background(), CompleteParams{
+		Ref:      CompletionRef{Type: CompletionRefResource, URI: "file:///{path}"},
+		Argument: CompletionArgument{Name: "path"},
+	})
+	if err != nil {
+		t.Fatalf("HandleComplete: %v", err)
+	}
+	if want := []string{"x", "y", "z"}; !reflect.DeepEqual(result.Completion.Values, want) {
+		t.Errorf("values = %v, want %v", result.Completion.Values, want)
+	}
+}
+
+func TestGateway_HandleComplete_ToolEnum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	g.Router().AddClient(setupMockAgentClient(ctrl, "k8s", []Tool{{
+		Name:        "scale",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"env":{"type":"string","enum":["staging","prod","preview"]},"replicas":{"type":"integer"}}}`),
+	}}))
+	g.Router().RefreshTools()
+
+	tests := []struct {
+		arg, value string
+		want       []string
+	}{
+		{"env", "p", []string{"prod", "preview"}},
+		{"env", "", []string{"staging", "prod", "preview"}},
+		{"replicas", "", []string{}},
+		{"missing", "", []string{}},
+	}
+	for _, tt := range tests {
+		result, err := g.HandleComplete(context.Background(), CompleteParams{
+			Ref:      CompletionRef{Type: CompletionRefTool, Name: "k8s__scale"},
+			Argument: CompletionArgument{Name: tt.arg, Value: tt.value},
+		})
+		if err != nil {
+			t.Fatalf("HandleComplete(%s=%q): %v", tt.arg, tt.value, err)
+		}
+		if !reflect.DeepEqual(result.Completion.Values, tt.want) {
+			t.Errorf("HandleComplete(%s=%q) = %v, want %v", tt.arg, tt.value, result.Completion.Values, tt.want)
+		}
+	}
+}
+
+func TestGateway_HandleComplete_UnsupportedRef(t *testing.T) {
+	g := NewGateway()
+	if _, err := g.HandleComplete(context.Background(), CompleteParams{
+		Ref:      CompletionRef{Type: "ref/unknown"},
+		Argument: CompletionArgument{Name: "x"},
+	}); err == nil {
+		t.Error("expected an error for an unsupported reference type")
+	}
+}
+
+func TestCompletionOf_CapsValues(t *testing.T) {
+	values := make([]string, 150)
+	for i := range values {
+		values[i] = fmt.Sprintf("v%d", i)
+	}
+	c := completionOf(values, "").Completion
+	if len(c.Values) != maxCompletionValues || c.Total != 150 || !c.HasMore {
+		t.Errorf("completion = %d values, total %d, hasMore %v; want %d, 150, true", len(c.Values), c.Total, c.HasMore, maxCompletionValues)
+	}
+}
+
+func TestStreamable_CompletionComplete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	g.Router().AddClient(setupMockAgentClient(ctrl, "k8s", []Tool{{
+		Name:        "scale",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"env":{"enum":["staging","prod"]}}}`),
+	}}))
+	g.Router().RefreshTools()
+	srv := NewStreamableHTTPServer(g, nil)
+	sessionID := initializeWithCapabilities(t, srv, `{}`)
+
+	resp := streamablePost(t, srv, sessionID, MethodCompletionComplete, map[string]any{
+		"ref":      map[string]any{"type": CompletionRefTool, "name": "k8s__scale"},
+		"argument": map[string]any{"name": "env", "value": "st"},
+	})
+	if resp.Error != nil {
+		t.Fatalf("completion/complete error: %+v", resp.Error)
+	}
+	var result CompleteResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"staging"}; !reflect.DeepEqual(result.Completion.Values, want) {
+		t.Errorf("values = %v, want %v", result.Completion.Values, want)
+	}
+
+	resp = streamablePost(t, srv, sessionID, MethodCompletionComplete, map[string]any{"ref": map[string]any{"type": "ref/other"}, "argument": map[string]any{"name": "x"}})
+	if resp.Error == nil || resp.Error.Code != jsonrpc.InvalidParams {
+		t.Errorf("error = %+v, want invalid params for an unknown reference type", resp.Error)
+	}
+}
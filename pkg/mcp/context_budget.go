@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/gridctl/gridctl/pkg/token"
+)
+
+// DefaultClientKey names the unlisted-client entry in ContextBudgets: the
+// surface a client matching no profile sees (everything when no clients:
+// block is configured, otherwise the default policy).
+const DefaultClientKey = "*"
+
+// ContextBudget is the size of the tools/list and prompts/list payloads one
+// client receives, which the client pays for in context on every session.
+// Tokens is an estimate from the gateway's token counter (~4 bytes per token
+// when none is configured); Budget is the configured per-client ceiling in
+// tokens, 0 when unset.
+type ContextBudget struct {
+	Client      string `json:"client"`
+	Tools       int    `json:"tools"`
+	ToolBytes   int    `json:"toolBytes"`
+	Prompts     int    `json:"prompts"`
+	PromptBytes int    `json:"promptBytes"`
+	TotalBytes  int    `json:"totalBytes"`
+	Tokens      int    `json:"tokens"`
+	Budget      int    `json:"budget,omitempty"`
+	OverBudget  bool   `json:"overBudget"`
+}
+
+// SetContextBudget sets the per-client context budget in tokens. Clients
+// whose tool and prompt surface estimates above it are flagged OverBudget.
+// Zero disables the check.
+func (g *Gateway) SetContextBudget(tokens int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.contextBudget = tokens
+}
+
+// ContextBudget measures the tools/list and prompts/list payloads the client
+// identified by accessID would receive, after client scoping. Prompts are
+// scoped only by a profile's skills allow-list. Code mode reports the
+// meta-tools, since that is what the client is sent.
+func (g *Gateway) ContextBudget(accessID string) ContextBudget {
+	g.mu.RLock()
+	cm := g.codeMode
+	counter := g.tokenCounter
+	budget := g.contextBudget
+	g.mu.RUnlock()
+	if counter == nil {
+		counter = token.NewHeuristicCounter(4)
+	}
+
+	ctx := WithClientAccessID(context.Background(), accessID)
+	var tools []Tool
+	if cm != nil {
+		tools = cm.ToolsList().Tools
+	} else {
+		tools = g.scopeToolsForContext(ctx, g.router.AggregatedTools())
+	}
+	toolsList, _ := json.Marshal(ToolsListResult{Tools: tools})
+
+	report := ContextBudget{
+		Client:    accessID,
+		Tools:     len(tools),
+		ToolBytes: len(toolsList),
+		Budget:    budget,
+	}
+	tokens := counter.Count(string(toolsList))
+	if prompts, err := g.HandlePromptsList(ctx); err == nil && len(prompts.Prompts) > 0 {
+		promptsList, _ := json.Marshal(prompts)
+		report.Prompts = len(prompts.Prompts)
+		report.PromptBytes = len(promptsList)
+		tokens += counter.Count(string(promptsList))
+	}
+	report.TotalBytes = report.ToolBytes + report.PromptBytes
+	report.Tokens = tokens
+	report.OverBudget = budget > 0 && tokens > budget
+	return report
+}
+
+// ContextBudgets reports ContextBudget for every configured client profile,
+// sorted by name, followed by the DefaultClientKey entry for unlisted
+// clients. With no clients: block there is only the DefaultClientKey entry.
+func (g *Gateway) ContextBudgets() []ContextBudget {
+	keys := g.clientAccessPolicy().profileKeys()
+	out := make([]ContextBudget, 0, len(keys)+1)
+	for _, key := range keys {
+		out = append(out, g.ContextBudget(key))
+	}
+	unlisted := g.ContextBudget("")
+	unlisted.Client = DefaultClientKey
+	return append(out, unlisted)
+}
+
+// profileKeys returns the configured profile keys, sorted. A nil policy has
+// none.
+func (p *ClientAccessPolicy) profileKeys() []string {
+	if p == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(p.profiles))
+	for key := range p.profiles {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,51 @@
+package mcp
+
+import "testing"
+
+func TestGateway_ContextBudget_ScopedPerClient(t *testing.T) {
+	g := newScopeTestGateway(t)
+	g.SetClientAccessPolicy(NewClientAccessPolicy(&ClientAccessSpec{
+		Default: "allow",
+		Profiles: map[string]ClientProfileSpec{
+			"cursor": {Servers: []string{"github"}},
+		},
+	}))
+
+	scoped := g.ContextBudget("cursor")
+	full := g.ContextBudget("windsurf")
+	if scoped.Tools != 2 || full.Tools != 4 {
+		t.Fatalf("tools = %d scoped, %d full; want 2 and 4", scoped.Tools, full.Tools)
+	}
+	if scoped.ToolBytes >= full.ToolBytes || scoped.Tokens >= full.Tokens {
+		t.Errorf("scoped surface should be smaller: %+v vs %+v", scoped, full)
+	}
+	if scoped.TotalBytes != scoped.ToolBytes+scoped.PromptBytes {
+		t.Errorf("totalBytes = %d, want tool+prompt bytes", scoped.TotalBytes)
+	}
+	if scoped.OverBudget || full.OverBudget {
+		t.Error("no budget configured: nothing should be over budget")
+	}
+}
+
+func TestGateway_ContextBudget_OverBudget(t *testing.T) {
+	g := newScopeTestGateway(t)
+	g.SetClientAccessPolicy(NewClientAccessPolicy(&ClientAccessSpec{
+		Profiles: map[string]ClientProfileSpec{
+			"cursor": {Tools: []string{"github__search-repos"}},
+		},
+	}))
+	full := g.ContextBudget("cursor")
+	g.SetContextBudget(full.Tokens - 1)
+
+	budgets := g.ContextBudgets()
+	if len(budgets) != 2 || budgets[0].Client != "cursor" || budgets[1].Client != DefaultClientKey {
+		t.Fatalf("budgets = %+v, want cursor then %q", budgets, DefaultClientKey)
+	}
+	if !budgets[0].OverBudget || budgets[0].Budget != full.Tokens-1 {
+		t.Errorf("cursor should be over budget: %+v", budgets[0])
+	}
+	// Default deny: unlisted clients see nothing, which fits any budget.
+	if budgets[1].Tools != 0 || budgets[1].OverBudget {
+		t.Errorf("unlisted entry = %+v, want empty and under budget", budgets[1])
+	}
+}
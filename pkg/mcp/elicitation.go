@@ -0,0 +1,135 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+)
+
+// MethodElicitationCreate is the request a server sends when it needs input
+// from the user mid-call (MCP spec, server -> client).
+const MethodElicitationCreate = "elicitation/create"
+
+// ErrElicitationUnsupported is returned to a downstream server whose
+// elicitation came from a call made by a client that did not declare the
+// elicitation capability.
+var ErrElicitationUnsupported = errors.New("the calling client does not support elicitation")
+
+// setClientRequester installs the function the gateway uses to send a
+// request to a connected client session. The Streamable HTTP server installs
+// it at construction.
+func (g *Gateway) setClientRequester(fn func(ctx context.Context, sessionID, method string, params json.RawMessage) (json.RawMessage, error)) {
+	g.callersMu.Lock()
+	defer g.callersMu.Unlock()
+	g.clientRequester = fn
+}
+
+// trackCaller records that sessionID has a tool call in flight on client,
+// so a server request made mid-call can be routed back to it. The returned
+// function ends the record. Calls without a session are not tracked.
+func (g *Gateway) trackCaller(client AgentClient, sessionID string) func() {
+	if sessionID == "" {
+		return func() {}
+	}
+	g.callersMu.Lock()
+	if g.callers[client] == nil {
+		g.callers[client] = make(map[string]int)
+	}
+	g.callers[client][sessionID]++
+	g.callersMu.Unlock()
+
+	return func() {
+		g.callersMu.Lock()
+		defer g.callersMu.Unlock()
+		if g.callers[client][sessionID]--; g.callers[client][sessionID] <= 0 {
+			delete(g.callers[client], sessionID)
+		}
+		if len(g.callers[client]) == 0 {
+			delete(g.callers, client)
+		}
+	}
+}
+
+// callerOf returns the session with a tool call in flight on client. Stdio
+// transports carry no per-call context for server requests, so the caller is
+// only known when exactly one session is waiting on that client.
+func (g *Gateway) callerOf(client AgentClient) (string, bool) {
+	g.callersMu.Lock()
+	defer g.callersMu.Unlock()
+	if len(g.callers[client]) != 1 {
+		return "", false
+	}
+	for id := range g.callers[client] {
+		return id, true
+	}
+	return "", false
+}
+
+// forwardElicitation relays a downstream server's elicitation/create to the
+// client session whose tool call triggered it and returns the client's
+// answer (accept, decline, or cancel) unchanged.
+func (g *Gateway) forwardElicitation(ctx context.Context, name string, client AgentClient, params json.RawMessage) (any, *jsonrpc.Error) {
+	return g.forwardToCaller(ctx, name, client, MethodElicitationCreate, "elicitation", ErrElicitationUnsupported, params)
+}
+
+// forwardToCaller relays a downstream server's request to the client
+// session whose tool call triggered it and returns the client's result
+// unchanged. kind names the request in logs and errors; unsupported is the
+// error the session reports when its client did not declare the capability.
+// The wait is bounded by callerWaitTimeout.
+func (g *Gateway) forwardToCaller(ctx context.Context, name string, client AgentClient, method, kind string, unsupported error, params json.RawMessage) (any, *jsonrpc.Error) {
+	// HTTP transports answer server requests on the call's own context;
+	// stdio transports fall back to the single session with a call in flight.
+	sessionID := SessionIDFromContext(ctx)
+	if sessionID == "" {
+		sessionID, _ = g.callerOf(client)
+	}
+	g.callersMu.Lock()
+	requester := g.clientRequester
+	g.callersMu.Unlock()
+	if sessionID == "" || requester == nil {
+		g.logger.Warn(kind+" rejected: no originating client session", "server", name)
+		return nil, &jsonrpc.Error{Code: jsonrpc.InvalidRequest, Message: kind + " is only available during a tool call made by a connected MCP client"}
+	}
+
+	timeout := g.callerWaitTimeout(ctx, name)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := requester(ctx, sessionID, method, params)
+	switch {
+	case err == nil:
+		return result, nil
+	case errors.Is(err, unsupported):
+		g.logger.Info(kind+" rejected: client does not support it", "server", name, "session", sessionID)
+		return nil, &jsonrpc.Error{Code: jsonrpc.MethodNotFound, Message: err.Error()}
+	case errors.Is(err, context.DeadlineExceeded):
+		timeout = timeout.Round(time.Second)
+		g.logger.Warn(kind+" timed out", "server", name, "session", sessionID, "timeout", timeout)
+		return nil, &jsonrpc.Error{Code: jsonrpc.InternalError, Message: fmt.Sprintf("the client did not answer the %s within %s", kind, timeout)}
+	default:
+		g.logger.Warn(kind+" failed", "server", name, "session", sessionID, "error", err)
+		return nil, &jsonrpc.Error{Code: jsonrpc.InternalError, Message: kind + " failed: " + err.Error()}
+	}
+}
+
+// callerWaitTimeout returns how long to wait for a client to answer a request
+// server name made mid-call: the time left on the tools/call when ctx is that
+// call's context (HTTP transports), otherwise the server's tool call timeout,
+// which bounds the call waiting on the answer. Waiting longer would answer a
+// call nobody is waiting on.
+func (g *Gateway) callerWaitTimeout(ctx context.Context, name string) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	g.mu.RLock()
+	cfg, ok := g.serverMeta[name]
+	g.mu.RUnlock()
+	if ok && cfg.Timeout > 0 {
+		return cfg.Timeout
+	}
+	return DefaultRequestTimeout
+}
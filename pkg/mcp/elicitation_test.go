@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+)
+
+type elicitationOutcome struct {
+	result any
+	err    *jsonrpc.Error
+}
+
+func TestGateway_ForwardElicitation_RoutesToCallingSession(t *testing.T) {
+	g := NewGateway()
+	srv := NewStreamableHTTPServer(g, nil)
+	sessionID := initializeWithCapabilities(t, srv, `{"elicitation":{}}`)
+
+	params := json.RawMessage(`{"message":"Which branch?","requestedSchema":{"type":"object","properties":{"branch":{"type":"string"}}}}`)
+	done := make(chan elicitationOutcome, 1)
+	go func() {
+		result, err := g.forwardElicitation(WithSessionID(context.Background(), sessionID), "git", nil, params)
+		done <- elicitationOutcome{result, err}
+	}()
+
+	req := awaitClientRequest(t, srv, sessionID, MethodElicitationCreate)
+	if string(req.Params) != string(params) {
+		t.Errorf("forwarded params = %s, want %s", req.Params, params)
+	}
+	answerClientRequest(t, srv, sessionID, req, map[string]any{"action": "accept", "content": map[string]any{"branch": "main"}})
+
+	select {
+	case out := <-done:
+		if out.err != nil {
+			t.Fatalf("forwardElicitation error: %+v", out.err)
+		}
+		raw, _ := json.Marshal(out.result)
+		if !strings.Contains(string(raw), `"action":"accept"`) || !strings.Contains(string(raw), `"branch":"main"`) {
+			t.Errorf("result = %s, want the client's accept answer", raw)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("forwardElicitation did not return")
+	}
+}
+
+func TestGateway_ForwardElicitation_ClientWithoutCapability(t *testing.T) {
+	g := NewGateway()
+	srv := NewStreamableHTTPServer(g, nil)
+	sessionID := initializeWithCapabilities(t, srv, `{}`)
+
+	_, err := g.forwardElicitation(WithSessionID(context.Background(), sessionID), "git", nil, json.RawMessage(`{}`))
+	if err == nil || err.Code != jsonrpc.MethodNotFound || !strings.Contains(err.Message, "does not support elicitation") {
+		t.Errorf("error = %+v, want method-not-found naming elicitation support", err)
+	}
+}
+
+func TestGateway_ForwardElicitation_StdioFallsBackToSoleCaller(t *testing.T) {
+	g := NewGateway()
+	srv := NewStreamableHTTPServer(g, nil)
+	first := initializeWithCapabilities(t, srv, `{"elicitation":{}}`)
+	second := initializeWithCapabilities(t, srv, `{"elicitation":{}}`)
+	client := NewProcessClient("git", nil, "", nil)
+
+	// One session calling: the elicitation reaches it without a session in ctx.
+	untrack := g.trackCaller(client, first)
+	done := make(chan elicitationOutcome, 1)
+	go func() {
+		result, err := g.forwardElicitation(context.Background(), "git", client, json.RawMessage(`{"message":"ok?"}`))
+		done <- elicitationOutcome{result, err}
+	}()
+	req := awaitClientRequest(t, srv, first, MethodElicitationCreate)
+	answerClientRequest(t, srv, first, req, map[string]any{"action": "decline"})
+	if out := <-done; out.err != nil {
+		t.Fatalf("forwardElicitation error: %+v", out.err)
+	}
+
+	// Two sessions calling: the caller is ambiguous and the request is refused.
+	untrackSecond := g.trackCaller(client, second)
+	_, err := g.forwardElicitation(context.Background(), "git", client, json.RawMessage(`{}`))
+	if err == nil || err.Code != jsonrpc.InvalidRequest {
+		t.Errorf("error = %+v, want invalid-request for an ambiguous caller", err)
+	}
+	untrack()
+	untrackSecond()
+	if _, ok := g.callerOf(client); ok {
+		t.Error("callerOf reports a caller after every call finished")
+	}
+}
+
+func TestGateway_CallerWaitTimeout(t *testing.T) {
+	g := NewGateway()
+	g.SetServerMeta(MCPServerConfig{Name: "slow", Timeout: 5 * time.Minute})
+
+	if got := g.callerWaitTimeout(context.Background(), "git"); got != DefaultRequestTimeout {
+		t.Errorf("unconfigured server: got %s, want %s", got, DefaultRequestTimeout)
+	}
+	if got := g.callerWaitTimeout(context.Background(), "slow"); got != 5*time.Minute {
+		t.Errorf("configured server: got %s, want the server timeout", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if got := g.callerWaitTimeout(ctx, "slow"); got > 2*time.Minute || got < time.Minute {
+		t.Errorf("call deadline: got %s, want the time left on the call", got)
+	}
+}
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Reverse proxy headers consulted when the gateway hands out its own URLs.
+const (
+	headerForwardedProto  = "X-Forwarded-Proto"
+	headerForwardedHost   = "X-Forwarded-Host"
+	headerForwardedPrefix = "X-Forwarded-Prefix"
+)
+
+// ExternalBaseURL returns the URL a client reached the gateway at, as
+// scheme://host[/prefix] with no trailing slash. Behind nginx or traefik the
+// X-Forwarded-Proto, X-Forwarded-Host, and X-Forwarded-Prefix headers win
+// over the request's own scheme and Host, so URLs built from it point at the
+// proxy rather than at the gateway's loopback listener. Malformed header
+// values are ignored.
+func ExternalBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if p := strings.ToLower(firstHeaderValue(r, headerForwardedProto)); p == "http" || p == "https" {
+		scheme = p
+	}
+	host := r.Host
+	if h := firstHeaderValue(r, headerForwardedHost); h != "" && !strings.ContainsAny(h, "/\\@ ") {
+		host = h
+	}
+	return scheme + "://" + host + ForwardedPrefix(r)
+}
+
+// ForwardedPrefix returns the path prefix a reverse proxy mounted the gateway
+// under (X-Forwarded-Prefix), normalized to a leading slash and no trailing
+// slash, or "" when there is none.
+func ForwardedPrefix(r *http.Request) string {
+	p := strings.TrimRight(firstHeaderValue(r, headerForwardedPrefix), "/")
+	if p == "" || !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") || strings.ContainsAny(p, "?#\\ ") {
+		return ""
+	}
+	return p
+}
+
+// firstHeaderValue returns the first comma-separated value of a header, as
+// set by the outermost proxy in a chain.
+func firstHeaderValue(r *http.Request, name string) string {
+	v, _, _ := strings.Cut(r.Header.Get(name), ",")
+	return strings.TrimSpace(v)
+}
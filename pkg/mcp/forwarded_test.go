@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"crypto/tls"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExternalBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		tls     bool
+		want    string
+	}{
+		{name: "direct", want: "http://localhost:8180"},
+		{name: "direct tls", tls: true, want: "https://localhost:8180"},
+		{
+			name:    "all forwarded headers",
+			headers: map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "tools.example.com", "X-Forwarded-Prefix": "/gridctl/"},
+			want:    "https://tools.example.com/gridctl",
+		},
+		{
+			name:    "proxy chain uses outermost values",
+			headers: map[string]string{"X-Forwarded-Proto": "https, http", "X-Forwarded-Host": "edge.example.com, internal:8080"},
+			want:    "https://edge.example.com",
+		},
+		{
+			name:    "malformed values ignored",
+			headers: map[string]string{"X-Forwarded-Proto": "gopher", "X-Forwarded-Host": "evil.com/path", "X-Forwarded-Prefix": "//evil.com"},
+			want:    "http://localhost:8180",
+		},
+		{
+			name:    "relative prefix ignored",
+			headers: map[string]string{"X-Forwarded-Prefix": "gridctl"},
+			want:    "http://localhost:8180",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "http://localhost:8180/api/groups", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			if tc.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			if got := ExternalBaseURL(req); got != tc.want {
+				t.Errorf("ExternalBaseURL = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
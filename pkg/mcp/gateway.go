@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"encoding/json"
@@ -20,6 +23,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/gridctl/gridctl/pkg/crash"
 	"github.com/gridctl/gridctl/pkg/dockerclient"
 	"github.com/gridctl/gridctl/pkg/format"
 	"github.com/gridctl/gridctl/pkg/logging"
@@ -50,12 +54,19 @@ type MCPServerConfig struct {
 	SSHIdentityFile   string               // SSH identity file path (for SSH servers)
 	SSHKnownHostsFile string               // SSH known_hosts file path; enables StrictHostKeyChecking=yes
 	SSHJumpHost       string               // SSH jump/bastion host ([user@]host[:port])
+	SSHDeployBinary   string               // Local binary synced to SSHDeployPath before an SSH server starts
+	SSHDeployPath     string               // Remote path of SSHDeployBinary
 	OpenAPIConfig     *OpenAPIClientConfig // OpenAPI configuration (for OpenAPI servers)
 	Auth              *ServerAuthConfig    // Downstream auth for external URL servers (nil = none)
 	HeaderSource      HeaderSource         // Live auth header source (OAuth broker); overrides Auth's static mapping
 	Tools             []string             // Tool whitelist (empty = all tools)
 	OutputFormat      string               // Output format: "json", "toon", "csv", "text"
 	PinSchemas        *bool                // Override gateway schema pinning (nil = inherit gateway default)
+	ValidateArguments *bool                // Override gateway argument validation (nil = inherit gateway default)
+	LenientResponses  bool                 // Normalize non-compliant tools/call results (see compat.go)
+	Sampling          bool                 // Forward the server's sampling/createMessage requests to the calling client
+	Roots             []string             // Restrict the client roots this server sees (absolute paths or file:// URIs; empty = all)
+	RequireApproval   []string             // Tools whose calls wait for human approval (unprefixed names)
 
 	// ReadyTimeout overrides the HTTP/SSE readiness wait. Zero uses DefaultReadyTimeout.
 	// Applies only to HTTP and SSE transports; stdio and other paths ignore it.
@@ -66,6 +77,18 @@ type MCPServerConfig struct {
 	// with many tools) where the 5s default can flake under autoscale spawn load.
 	PingTimeout time.Duration
 
+	// Timeout bounds each tool call dispatched to the server. Zero uses
+	// DefaultRequestTimeout. A call that runs out reports a timeout error
+	// and counts as a circuit breaker failure.
+	Timeout time.Duration
+
+	// CircuitBreakerFailures is how many consecutive failed tool calls open
+	// the server's circuit, failing further calls fast until
+	// CircuitBreakerCooldown (zero = DefaultCircuitBreakerCooldown) passes.
+	// Zero disables the breaker.
+	CircuitBreakerFailures int
+	CircuitBreakerCooldown time.Duration
+
 	// CleanupOnReadyFailure runs when waitForHTTPServer returns ErrReadyTimeout.
 	// Callers that manage the underlying container populate this with a closure
 	// that stops and removes it, so a retry starts from a clean slate. nil means
@@ -84,6 +107,8 @@ type OpenAPIClientConfig struct {
 	Include    []string // Operation IDs to include
 	Exclude    []string // Operation IDs to exclude
 	NoExpand   bool     // If true, skip environment variable expansion in spec file
+	GroupBy    string   // "tag" or "path" to expose one composite tool per group
+	Mock       bool     // If true, serve example responses from the spec instead of calling the API
 
 	// Query param auth fields
 	AuthQueryParam string // Query parameter name for type: query
@@ -124,12 +149,14 @@ type Gateway struct {
 	dockerCli dockerclient.DockerClient
 	logger    *slog.Logger
 	cancel    context.CancelFunc
+	closed    atomic.Bool // set by Close so supervisors stop reconnecting
 
 	mu          sync.RWMutex
 	serverInfo  ServerInfo
 	serverMeta  map[string]MCPServerConfig // name -> config for status reporting
 	codeMode    *CodeMode                  // nil when code mode is off
 	codeModeStr string                     // "off", "on" — for status reporting
+	monitorCtx  context.Context            // health monitor context; nil until StartHealthMonitor
 
 	healthMu      sync.RWMutex
 	health        map[string]*HealthStatus         // name -> rollup health (public API)
@@ -141,6 +168,19 @@ type Gateway struct {
 	authStateMu sync.RWMutex
 	authState   map[string]ServerAuthState // name -> downstream authorization state
 
+	notifyMu             sync.Mutex
+	toolRefreshes        map[AgentClient]bool // client -> refresh queued behind the running one
+	listChangedListeners []func(method string)
+	announcedRoots       string // rootsKey of the client roots downstream servers were last told about
+
+	callersMu       sync.Mutex
+	callers         map[AgentClient]map[string]int // client -> session ID -> tool calls in flight
+	clientRequester func(ctx context.Context, sessionID, method string, params json.RawMessage) (json.RawMessage, error)
+
+	identityMu      sync.RWMutex
+	identities      map[string]serverIdentity // name -> identity declared at registration
+	identityChanges map[string][]string       // name -> changes seen on reconnect since registration
+
 	toolCallObserver  ToolCallObserver  // optional observer for tool call metrics
 	promptGetObserver PromptGetObserver // optional observer for prompt-get (skill usage) metrics
 
@@ -150,6 +190,10 @@ type Gateway struct {
 
 	maxToolResultBytes int // maximum tool result size before truncation (0 = default 64KB)
 
+	validateArguments bool // check tools/call arguments against input schemas before forwarding
+
+	contextBudget int // per-client tools/prompts context budget in tokens (0 = unchecked)
+
 	toolCountWarned bool // whether the tool count hint has been logged
 
 	schemaVerifier SchemaVerifier // optional TOFU schema verifier (pins.GatewayAdapter)
@@ -157,6 +201,16 @@ type Gateway struct {
 	blockedMu      sync.RWMutex
 	blockedServers map[string]bool // servers blocked due to unacknowledged schema drift
 
+	maintenanceMu sync.RWMutex
+	maintenance   maintenanceState // maintenance mode and paused servers
+
+	approvalMu      sync.Mutex
+	approvals       approvalState // calls parked for human approval
+	approvalTimeout time.Duration // zero = DefaultApprovalTimeout
+
+	breakerMu sync.Mutex
+	breakers  map[string]*circuitBreaker // per-server circuit breakers, created on first call
+
 	autoMu      sync.RWMutex
 	autoscalers map[string]*Autoscaler // name -> scaler for autoscaled replica sets
 
@@ -174,6 +228,11 @@ type Gateway struct {
 	// replaced wholesale on apply and hot-reload.
 	callGates []CallGate
 
+	// interceptors wrap the direct tools/call path (see ToolCallInterceptor
+	// in interceptor.go). Guarded by mu; replaced wholesale on apply and
+	// hot-reload.
+	interceptors []ToolCallInterceptor
+
 	// costSettler receives each priced call's cost after observation so
 	// budget windows settle synchronously. Guarded by mu.
 	costSettler CostSettler
@@ -199,9 +258,14 @@ func NewGateway() *Gateway {
 		health:               make(map[string]*HealthStatus),
 		replicaHealth:        make(map[string]map[int]*HealthStatus),
 		blockedServers:       make(map[string]bool),
+		breakers:             make(map[string]*circuitBreaker),
 		autoscalers:          make(map[string]*Autoscaler),
 		registrationFailures: make(map[string]string),
 		authState:            make(map[string]ServerAuthState),
+		toolRefreshes:        make(map[AgentClient]bool),
+		callers:              make(map[AgentClient]map[string]int),
+		identities:           make(map[string]serverIdentity),
+		identityChanges:      make(map[string][]string),
 	}
 }
 
@@ -350,6 +414,30 @@ func (g *Gateway) clientAllowsToolCall(ctx context.Context, prefixedName string)
 	return policy.Allows(ClientAccessIDFromContext(ctx), prefixedName)
 }
 
+// clientAllowsSkill reports whether the connecting client (resolved from ctx)
+// may see the named registry skill. Always true when no policy is set.
+func (g *Gateway) clientAllowsSkill(ctx context.Context, name string) bool {
+	return g.clientAccessPolicy().AllowsSkill(ClientAccessIDFromContext(ctx), name)
+}
+
+// errSkillNotFound is returned for a skill hidden from the connecting client,
+// worded like the registry's own miss so it does not reveal the skill exists.
+func errSkillNotFound(name string) error {
+	return fmt.Errorf("skill %q: not found", name)
+}
+
+// visiblePrompts returns the registry prompts the connecting client may see.
+func (g *Gateway) visiblePrompts(ctx context.Context, pp PromptProvider) []PromptData {
+	prompts := pp.ListPromptData()
+	visible := make([]PromptData, 0, len(prompts))
+	for _, p := range prompts {
+		if g.clientAllowsSkill(ctx, p.Name) {
+			visible = append(visible, p)
+		}
+	}
+	return visible
+}
+
 // ClientAccessConfigured reports whether a `clients:` access block is in effect.
 func (g *Gateway) ClientAccessConfigured() bool {
 	return g.clientAccessPolicy() != nil
@@ -407,6 +495,34 @@ func (g *Gateway) SetDefaultOutputFormat(format string) {
 	g.defaultOutputFormat = format
 }
 
+// SetSchemaRepair enables auto-repair of trivially broken downstream tool
+// input schemas (missing schema, or an object schema without "type").
+// Repaired tools are still reported in Status so the server can be fixed.
+func (g *Gateway) SetSchemaRepair(enabled bool) {
+	g.router.SetSchemaRepair(enabled)
+}
+
+// SetArgumentValidation enables checking tools/call arguments against the
+// tool's input schema before forwarding. Servers opt out individually via
+// MCPServerConfig.ValidateArguments.
+func (g *Gateway) SetArgumentValidation(enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.validateArguments = enabled
+}
+
+// argumentValidationForServer reports whether tools/call arguments for
+// serverName are validated: the server's ValidateArguments override when
+// set, otherwise the gateway default.
+func (g *Gateway) argumentValidationForServer(serverName string) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if cfg, ok := g.serverMeta[serverName]; ok && cfg.ValidateArguments != nil {
+		return *cfg.ValidateArguments
+	}
+	return g.validateArguments
+}
+
 // SetMaxToolResultBytes sets the maximum tool result size in bytes before truncation.
 // When set to 0, the default of 65536 (64KB) is used.
 func (g *Gateway) SetMaxToolResultBytes(n int) {
@@ -582,18 +698,29 @@ func (g *Gateway) StartCleanup(ctx context.Context) {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				removed := g.sessions.Cleanup(30 * time.Minute)
-				if removed > 0 {
-					g.logger.Info("cleaned up stale sessions", "removed", removed)
-				}
+				crash.Guard("session-cleanup", func() {
+					removed := g.sessions.Cleanup(30 * time.Minute)
+					if removed > 0 {
+						g.logger.Info("cleaned up stale sessions", "removed", removed)
+					}
+				})
 			}
 		}
 	}()
 }
 
-// StartHealthMonitor starts periodic health checking for all registered MCP servers.
+// StartHealthMonitor starts periodic health checking for all registered MCP servers,
+// plus a connection supervisor per replica that reconnects a process or
+// container as soon as its connection drops (see superviseReplica).
 // It runs alongside StartCleanup and stops when the gateway context is cancelled.
 func (g *Gateway) StartHealthMonitor(ctx context.Context, interval time.Duration) {
+	g.mu.Lock()
+	g.monitorCtx = ctx
+	g.mu.Unlock()
+	for _, set := range g.router.ReplicaSets() {
+		g.superviseSet(set)
+	}
+
 	go func() {
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
@@ -602,7 +729,7 @@ func (g *Gateway) StartHealthMonitor(ctx context.Context, interval time.Duration
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				g.checkHealth(ctx)
+				crash.Guard("health-monitor", func() { g.checkHealth(ctx) })
 			}
 		}
 	}()
@@ -629,12 +756,19 @@ func (g *Gateway) checkHealth(ctx context.Context) {
 			g.checkReplicaHealth(ctx, name, replica)
 		}
 		g.recomputeRollup(name, set)
+		// Pick up replicas added since the last tick (autoscaling).
+		g.superviseSet(set)
 	}
 }
 
 // checkReplicaHealth runs one health cycle for a single replica: ping, update
 // per-replica status, and optionally trigger a backoff-gated Reconnect.
+// Cycles for one replica never overlap, so the health monitor and the
+// connection supervisor cannot restart it twice.
 func (g *Gateway) checkReplicaHealth(ctx context.Context, serverName string, replica *Replica) {
+	replica.checkMu.Lock()
+	defer replica.checkMu.Unlock()
+
 	client := replica.Client()
 	pingable, ok := client.(Pingable)
 	if !ok {
@@ -705,6 +839,7 @@ func (g *Gateway) checkReplicaHealth(ctx context.Context, serverName string, rep
 	}
 
 	// Reconnect succeeded — back in rotation.
+	replica.restarts.Add(1)
 	replica.Restart().Reset()
 	replica.SetHealthy(true)
 	replica.MarkStarted(time.Now())
@@ -718,7 +853,8 @@ func (g *Gateway) checkReplicaHealth(ctx context.Context, serverName string, rep
 	g.healthMu.Unlock()
 
 	g.router.RefreshTools()
-	logger.Info("MCP server reconnected", "name", serverName)
+	logger.Info("MCP server reconnected", "name", serverName, "restarts", replica.Restarts())
+	g.checkServerIdentity(serverName, client)
 
 	// Verify pins after reconnection using replica-0's tool surface if we
 	// can get it; otherwise use this replica's tools. Drift on reconnect is
@@ -847,6 +983,7 @@ func (g *Gateway) ReplicaStatuses(serverName string) []ReplicaStatus {
 		}
 		attempts := r.Restart().Attempts()
 		rs.RestartAttempts = attempts
+		rs.Restarts = r.Restarts()
 		if nextAt := r.Restart().NextAt(); !nextAt.IsZero() {
 			t := nextAt
 			rs.NextRetryAt = &t
@@ -890,6 +1027,7 @@ func replicaStateString(healthy bool, hasAttempts bool) string {
 
 // Close stops the cleanup goroutine and closes all agent client connections.
 func (g *Gateway) Close() {
+	g.closed.Store(true)
 	if g.cancel != nil {
 		g.cancel()
 	}
@@ -1029,10 +1167,12 @@ func (g *Gateway) StartAutoscaler(ctx context.Context, interval time.Duration) {
 				return
 			case now := <-ticker.C:
 				for _, a := range g.Autoscalers() {
-					if _, err := a.Tick(ctx, now); err != nil {
-						g.logger.Debug("autoscaler tick error",
-							"server", a.Name(), "error", err)
-					}
+					crash.Guard("autoscaler", func() {
+						if _, err := a.Tick(ctx, now); err != nil {
+							g.logger.Debug("autoscaler tick error",
+								"server", a.Name(), "error", err)
+						}
+					}, "server", a.Name())
 				}
 			}
 		}
@@ -1096,13 +1236,31 @@ func (g *Gateway) RegisterMCPReplicaSet(ctx context.Context, name, policy string
 		}
 	}
 
-	g.router.AddReplicaSet(NewReplicaSet(name, policy, clients))
+	g.recordServerIdentity(name, clients[0])
+	set := NewReplicaSet(name, policy, clients)
+	g.router.AddReplicaSet(set)
+	g.superviseSet(set)
 	g.router.RefreshTools()
+	g.logSchemaIssues(name)
+	g.notifyListChanged(NotificationToolsListChanged)
 
 	g.logger.Info("registered MCP server", "name", name, "transport", cfgs[0].Transport, "replicas", len(clients), "tools", len(clients[0].Tools()), "duration", time.Since(start))
 	return nil
 }
 
+// logSchemaIssues warns once per registration about tools with missing or
+// invalid input schemas, so the operator sees the offending server in the
+// daemon log rather than a confusing failure in an LLM client.
+func (g *Gateway) logSchemaIssues(name string) {
+	for _, issue := range g.router.SchemaIssues(name) {
+		if issue.Repaired {
+			g.logger.Warn("tool input schema repaired", "server", name, "tool", issue.Tool, "problem", issue.Problem)
+			continue
+		}
+		g.logger.Warn("tool input schema invalid", "server", name, "tool", issue.Tool, "problem", issue.Problem)
+	}
+}
+
 // BuildAgentClient creates, connects, and initializes an AgentClient from a
 // single MCPServerConfig. It does NOT touch serverMeta, pins, health, or the
 // router — callers compose that separately. Exported so Spawner implementations
@@ -1137,6 +1295,11 @@ func (g *Gateway) buildAgentClient(ctx context.Context, cfg MCPServerConfig) (Ag
 		agentClient = openAPIClient
 	} else if cfg.SSH {
 		// Handle SSH servers (they use stdio over SSH)
+		if cfg.SSHDeployBinary != "" {
+			if err := syncSSHBinary(ctx, cfg, clientLogger); err != nil {
+				return nil, fmt.Errorf("deploying %s to SSH server %s: %w", cfg.SSHDeployBinary, cfg.Name, err)
+			}
+		}
 		sshCommand := buildSSHCommand(cfg)
 		processClient := NewProcessClient(cfg.Name, sshCommand, cfg.WorkDir, cfg.Env)
 		processClient.SetLogger(clientLogger)
@@ -1218,6 +1381,21 @@ func (g *Gateway) buildAgentClient(ctx context.Context, cfg MCPServerConfig) (Ag
 		}
 	}
 
+	if cfg.LenientResponses {
+		if l, ok := agentClient.(interface{ SetLenientResponses(bool) }); ok {
+			l.SetLenientResponses(true)
+		}
+	}
+
+	if cfg.Sampling {
+		if sc, ok := agentClient.(interface{ SetSampling(bool) }); ok {
+			sc.SetSampling(true)
+		}
+	}
+
+	g.watchNotifications(cfg.Name, agentClient)
+	g.answerRequests(cfg.Name, cfg.Roots, cfg.Sampling, agentClient)
+
 	// Initialize MCP connection. Close the client on failure: for stdio,
 	// process, and SSH transports Connect() has already spawned a child that
 	// would otherwise be orphaned (a downstream server rejected for an
@@ -1262,12 +1440,17 @@ func (g *Gateway) UnregisterMCPServer(name string) {
 	g.mu.Lock()
 	delete(g.serverMeta, name)
 	g.mu.Unlock()
+	g.breakerMu.Lock()
+	delete(g.breakers, name)
+	g.breakerMu.Unlock()
 	g.ClearRegistrationFailure(name)
 	// Auth state follows the same lifecycle as registration failures:
 	// without this, a removed server would keep a ghost needs-auth row in
 	// Status() (stored grants are unaffected; they are keyed by resource
 	// URL, not server name).
 	g.ClearServerAuthState(name)
+	g.forgetServerIdentity(name)
+	g.notifyListChanged(NotificationToolsListChanged)
 }
 
 // RecordRegistrationFailure records why a server could not be registered so
@@ -1368,8 +1551,12 @@ func (g *Gateway) RestartMCPServer(ctx context.Context, name string) error {
 
 	g.logger.Info("restarting MCP server", "name", name, "transport", cfg.Transport)
 
-	// Close the existing client connection
-	if client := g.router.GetClient(name); client != nil {
+	// Unregister from router (removes client + cleans tool registry), then
+	// close the existing connection. Unregistering first stops the
+	// connection supervisor from reconnecting the client being closed.
+	client := g.router.GetClient(name)
+	g.UnregisterMCPServer(name)
+	if client != nil {
 		if closer, ok := client.(io.Closer); ok {
 			if err := closer.Close(); err != nil {
 				g.logger.Warn("error closing MCP server connection", "name", name, "error", err)
@@ -1377,9 +1564,6 @@ func (g *Gateway) RestartMCPServer(ctx context.Context, name string) error {
 		}
 	}
 
-	// Unregister from router (removes client + cleans tool registry)
-	g.UnregisterMCPServer(name)
-
 	// For stdio (container) transport, restart the Docker container
 	if cfg.Transport == TransportStdio && !cfg.External && !cfg.LocalProcess && !cfg.SSH && !cfg.OpenAPI {
 		if g.dockerCli != nil && cfg.ContainerID != "" {
@@ -1554,11 +1738,20 @@ func (g *Gateway) HandleInitialize(params InitializeParams, accessID, group stri
 	// the client decides whether to disconnect). Never fail for version reasons.
 	protocolVersion := NegotiateProtocolVersion(params.ProtocolVersion)
 	session := g.sessions.Create(params.ClientInfo, accessID, group, protocolVersion)
+	// Set before the session ID reaches the client, so no reader races it.
+	session.Features = NegotiateClientFeatures(protocolVersion, params.Capabilities)
+	if session.Features.Limited() {
+		g.logger.Info("client negotiated an older protocol; downgrading tool payloads",
+			"client", session.ClientID, "protocol_version", protocolVersion,
+			"structured_content", session.Features.StructuredContent,
+			"tool_annotations", session.Features.ToolAnnotations)
+	}
 
 	caps := Capabilities{
 		Tools: &ToolsCapability{
 			ListChanged: true,
 		},
+		Completions: &CompletionsCapability{},
 	}
 
 	// Advertise Prompts and Resources if registry is available
@@ -1640,6 +1833,14 @@ func (g *Gateway) HandleToolsCatalog() (*ToolsListResult, error) {
 // HandleToolsCall routes a tool call to the appropriate MCP server.
 // When code mode is active and the tool is a meta-tool, delegates to code mode.
 func (g *Gateway) HandleToolsCall(ctx context.Context, params ToolCallParams) (*ToolCallResult, error) {
+	// Maintenance mode rejects every call before any routing or gating.
+	if msg := g.maintenanceRejection(); msg != "" {
+		return &ToolCallResult{
+			Content: []Content{NewTextContent(msg)},
+			IsError: true,
+		}, nil
+	}
+
 	g.mu.RLock()
 	cm := g.codeMode
 	g.mu.RUnlock()
@@ -1679,6 +1880,12 @@ func (g *Gateway) HandleToolsCall(ctx context.Context, params ToolCallParams) (*
 		return cm.HandleCall(ctx, params, g, allTools)
 	}
 
+	return g.interceptToolCall(ctx, params, g.dispatchToolCall)
+}
+
+// dispatchToolCall runs the direct tools/call path: access scope, pause,
+// gates, argument validation, routing, and the backend call itself.
+func (g *Gateway) dispatchToolCall(ctx context.Context, params ToolCallParams) (*ToolCallResult, error) {
 	// Enforce the per-client access scope on the direct tools/call path. A
 	// denied call is rejected before routing; denials are logged at debug.
 	if !g.clientAllowsToolCall(ctx, params.Name) {
@@ -1690,6 +1897,17 @@ func (g *Gateway) HandleToolsCall(ctx context.Context, params ToolCallParams) (*
 		}, nil
 	}
 
+	// Reject calls to servers paused for a change window, before gates
+	// spend budget or routing cold-starts a replica.
+	if serverName, _, parseErr := ParsePrefixedTool(params.Name); parseErr == nil {
+		if msg := g.pausedRejection(serverName); msg != "" {
+			return &ToolCallResult{
+				Content: []Content{NewTextContent(msg)},
+				IsError: true,
+			}, nil
+		}
+	}
+
 	// Run the pre-call policy gates (rate limits, budgets; see callGates).
 	// The first denial short-circuits with the gate's model-readable message.
 	// Code-mode inner calls re-enter this function per callTool, so gates
@@ -1703,6 +1921,26 @@ func (g *Gateway) HandleToolsCall(ctx context.Context, params ToolCallParams) (*
 		}, nil
 	}
 
+	// Reject arguments the tool's own input schema rules out, sparing a
+	// round trip to a backend that would refuse them anyway. The result is
+	// a tool error (not a protocol error) so the model can correct itself.
+	if serverName, _, parseErr := ParsePrefixedTool(params.Name); parseErr == nil && g.argumentValidationForServer(serverName) {
+		if err := g.router.ValidateArguments(params.Name, params.Arguments); err != nil {
+			g.logger.Debug("tool call rejected by argument validation",
+				"server", serverName, "tool", params.Name, "error", err)
+			return argumentErrorResult(err), nil
+		}
+	}
+
+	// Park approval-required calls until a human decides them. This runs
+	// after every automatic check so an approver is never asked about a
+	// call that would be rejected anyway.
+	if serverName, toolName, parseErr := ParsePrefixedTool(params.Name); parseErr == nil && g.approvalRequired(serverName, toolName) {
+		if rejection := g.awaitApproval(ctx, params, serverName); rejection != nil {
+			return rejection, nil
+		}
+	}
+
 	// Child span: routing decision.
 	tracer := otel.Tracer("gridctl.gateway")
 	_, routeSpan := tracer.Start(ctx, "mcp.routing")
@@ -1753,6 +1991,22 @@ func (g *Gateway) HandleToolsCall(ctx context.Context, params ToolCallParams) (*
 		}, nil
 	}
 
+	// Fail fast while the server's circuit is open instead of letting every
+	// caller wait out a hung backend.
+	breaker := g.circuitBreakerFor(client.Name())
+	if breaker != nil {
+		ok, retryIn, transition := breaker.allow()
+		if transition != "" {
+			g.logCircuitTransition(client.Name(), transition, breaker)
+		}
+		if !ok {
+			return &ToolCallResult{
+				Content: []Content{NewTextContent(circuitOpenMessage(client.Name(), breaker, retryIn))},
+				IsError: true,
+			}, nil
+		}
+	}
+
 	// Propagate the resolved server name to the root span so the trace-level
 	// record (built from root span attrs) carries it for UI filtering.
 	if rootSpan := trace.SpanFromContext(ctx); rootSpan.IsRecording() {
@@ -1788,10 +2042,26 @@ func (g *Gateway) HandleToolsCall(ctx context.Context, params ToolCallParams) (*
 	logger.Info("tool call started", "server", client.Name(), "tool", toolName)
 	start := time.Now()
 
+	timeout := DefaultRequestTimeout
+	if hasMeta && serverCfg.Timeout > 0 {
+		timeout = serverCfg.Timeout
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
 	replica.IncInFlight()
-	result, err := client.CallTool(ctx, toolName, params.Arguments)
+	untrack := g.trackCaller(client, SessionIDFromContext(ctx))
+	result, err := client.CallTool(callCtx, toolName, params.Arguments)
+	untrack()
 	replica.DecInFlight()
 	duration := time.Since(start)
+	if err != nil && callCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	cancel()
+	if breaker != nil {
+		if transition := breaker.record(err != nil); transition != "" {
+			g.logCircuitTransition(client.Name(), transition, breaker)
+		}
+	}
 
 	if err != nil {
 		span.RecordError(err)
@@ -1808,6 +2078,10 @@ func (g *Gateway) HandleToolsCall(ctx context.Context, params ToolCallParams) (*
 	}
 	logger.Info("tool call finished", "server", client.Name(), "tool", toolName, "duration", duration, "is_error", result.IsError)
 
+	if result.Usage == nil {
+		result.Usage = callUsageFromMeta(result.Meta)
+	}
+
 	// Truncation: clamp oversized results before logging or format conversion
 	g.applyTruncation(client.Name(), toolName, result)
 
@@ -1849,13 +2123,35 @@ func (g *Gateway) HandleToolsCall(ctx context.Context, params ToolCallParams) (*
 				}
 			}
 		} else {
-			go obs.ObserveToolCall(client.Name(), replicaID, params.Arguments, result)
+			crash.Go("tool-call-observer", func() {
+				obs.ObserveToolCall(client.Name(), replicaID, params.Arguments, result)
+			}, "server", client.Name())
 		}
 	}
 
 	return result, nil
 }
 
+// argumentErrorResult renders an argument validation failure as a tool
+// error: a readable message in content, and the violations as
+// structuredContent for clients that act on them programmatically.
+func argumentErrorResult(err error) *ToolCallResult {
+	result := &ToolCallResult{
+		Content: []Content{NewTextContent(fmt.Sprintf("Error: %v", err))},
+		IsError: true,
+	}
+	var verr *ArgumentValidationError
+	if errors.As(err, &verr) {
+		if raw, mErr := json.Marshal(struct {
+			Error string `json:"error"`
+			*ArgumentValidationError
+		}{"invalid_arguments", verr}); mErr == nil {
+			result.StructuredContent = raw
+		}
+	}
+	return result
+}
+
 // groupDenialMessage builds the model-readable rejection for a call outside
 // a group's surface. It names the group and its current tool count (or the
 // group's removal) so an agent stops retrying instead of burning tokens.
@@ -2079,14 +2375,15 @@ func (g *Gateway) promptProvider() PromptProvider {
 	return nil
 }
 
-// HandlePromptsList returns all active prompts as MCP Prompts.
-func (g *Gateway) HandlePromptsList() (*PromptsListResult, error) {
+// HandlePromptsList returns the active prompts visible to the connecting
+// client as MCP Prompts.
+func (g *Gateway) HandlePromptsList(ctx context.Context) (*PromptsListResult, error) {
 	pp := g.promptProvider()
 	if pp == nil {
 		return &PromptsListResult{Prompts: []MCPPrompt{}}, nil
 	}
 
-	prompts := pp.ListPromptData()
+	prompts := g.visiblePrompts(ctx, pp)
 	result := make([]MCPPrompt, len(prompts))
 	for i, p := range prompts {
 		args := make([]PromptArgument, len(p.Arguments))
@@ -2114,25 +2411,24 @@ func (g *Gateway) HandlePromptsGet(ctx context.Context, params PromptsGetParams)
 	if pp == nil {
 		return nil, fmt.Errorf("registry not available")
 	}
+	if !g.clientAllowsSkill(ctx, params.Name) {
+		return nil, errSkillNotFound(params.Name)
+	}
 
-	p, err := pp.GetPromptData(params.Name)
+	var p *PromptData
+	var err error
+	if vp, ok := pp.(PromptVariantProvider); ok {
+		p, err = vp.GetPromptVariant(params.Name, params.Arguments["variant"])
+	} else {
+		p, err = pp.GetPromptData(params.Name)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// Perform argument substitution on content
-	content := p.Content
-	for _, arg := range p.Arguments {
-		placeholder := "{{" + arg.Name + "}}"
-		value, ok := params.Arguments[arg.Name]
-		if !ok {
-			if arg.Default != "" {
-				value = arg.Default
-			} else if arg.Required {
-				return nil, fmt.Errorf("required argument %q not provided", arg.Name)
-			}
-		}
-		content = strings.ReplaceAll(content, placeholder, value)
+	content, err := substitutePromptArguments(p.Content, p.Arguments, params.Arguments)
+	if err != nil {
+		return nil, err
 	}
 
 	// Notify the prompt-get observer that this skill was served. Recording is
@@ -2144,10 +2440,12 @@ func (g *Gateway) HandlePromptsGet(ctx context.Context, params PromptsGetParams)
 	pObs := g.promptGetObserver
 	g.mu.RUnlock()
 	if pObs != nil {
-		go pObs.ObservePromptGet(PromptGetObservation{
+		obs := PromptGetObservation{
 			PromptName: params.Name,
+			Variant:    p.Variant,
 			ClientID:   ClientIDFromContext(ctx),
-		})
+		}
+		crash.Go("prompt-get-observer", func() { pObs.ObservePromptGet(obs) })
 	}
 
 	return &PromptsGetResult{
@@ -2161,14 +2459,47 @@ func (g *Gateway) HandlePromptsGet(ctx context.Context, params PromptsGetParams)
 	}, nil
 }
 
-// HandleResourcesList returns prompts as MCP Resources.
-func (g *Gateway) HandleResourcesList() (*ResourcesListResult, error) {
+// promptPlaceholder matches a {{name}} placeholder in prompt content.
+var promptPlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+
+// substitutePromptArguments replaces the {{name}} placeholders of declared
+// arguments with the supplied value, falling back to the default. A value
+// outside an argument's Values is rejected. Substitution is a single pass,
+// so placeholders inside supplied values are left as written, and
+// placeholders naming undeclared arguments are kept literally.
+func substitutePromptArguments(content string, args []PromptArgumentData, supplied map[string]string) (string, error) {
+	values := make(map[string]string, len(args))
+	for _, arg := range args {
+		value, ok := supplied[arg.Name]
+		if !ok {
+			if arg.Default != "" {
+				value = arg.Default
+			} else if arg.Required {
+				return "", fmt.Errorf("required argument %q not provided", arg.Name)
+			}
+		} else if len(arg.Values) > 0 && !slices.Contains(arg.Values, value) {
+			return "", fmt.Errorf("argument %q must be one of %s (got %q)", arg.Name, strings.Join(arg.Values, ", "), value)
+		}
+		values[arg.Name] = value
+	}
+	return promptPlaceholder.ReplaceAllStringFunc(content, func(m string) string {
+		name := promptPlaceholder.FindStringSubmatch(m)[1]
+		if v, ok := values[name]; ok {
+			return v
+		}
+		return m
+	}), nil
+}
+
+// HandleResourcesList returns the prompts visible to the connecting client as
+// MCP Resources.
+func (g *Gateway) HandleResourcesList(ctx context.Context) (*ResourcesListResult, error) {
 	pp := g.promptProvider()
 	if pp == nil {
 		return &ResourcesListResult{Resources: []MCPResource{}}, nil
 	}
 
-	prompts := pp.ListPromptData()
+	prompts := g.visiblePrompts(ctx, pp)
 	resources := make([]MCPResource, len(prompts))
 	for i, p := range prompts {
 		resources[i] = MCPResource{
@@ -2182,7 +2513,7 @@ func (g *Gateway) HandleResourcesList() (*ResourcesListResult, error) {
 }
 
 // HandleResourcesRead returns the content of a prompt resource.
-func (g *Gateway) HandleResourcesRead(params ResourcesReadParams) (*ResourcesReadResult, error) {
+func (g *Gateway) HandleResourcesRead(ctx context.Context, params ResourcesReadParams) (*ResourcesReadResult, error) {
 	pp := g.promptProvider()
 	if pp == nil {
 		return nil, fmt.Errorf("registry not available")
@@ -2200,6 +2531,9 @@ func (g *Gateway) HandleResourcesRead(params ResourcesReadParams) (*ResourcesRea
 	if name == "" {
 		return nil, fmt.Errorf("empty resource name in URI: %s", params.URI)
 	}
+	if !g.clientAllowsSkill(ctx, name) {
+		return nil, errSkillNotFound(name)
+	}
 
 	p, err := pp.GetPromptData(name)
 	if err != nil {
@@ -2217,7 +2551,8 @@ func (g *Gateway) HandleResourcesRead(params ResourcesReadParams) (*ResourcesRea
 	}, nil
 }
 
-// RefreshAllTools refreshes tools from all registered MCP servers.
+// RefreshAllTools refreshes tools from all registered MCP servers and tells
+// connected clients to re-list.
 func (g *Gateway) RefreshAllTools(ctx context.Context) error {
 	for _, client := range g.router.Clients() {
 		if err := client.RefreshTools(ctx); err != nil {
@@ -2225,6 +2560,7 @@ func (g *Gateway) RefreshAllTools(ctx context.Context) error {
 		}
 	}
 	g.router.RefreshTools()
+	g.notifyListChanged(NotificationToolsListChanged)
 	return nil
 }
 
@@ -2254,6 +2590,23 @@ type MCPServerStatus struct {
 	// have not completed a handshake.
 	ProtocolVersion string `json:"protocolVersion,omitempty"`
 
+	// ServerName and ServerVersion are the serverInfo the downstream server
+	// reported at initialize, for bug reports and support bundles. Empty
+	// when the server omitted them or has not completed a handshake.
+	ServerName    string `json:"serverName,omitempty"`
+	ServerVersion string `json:"serverVersion,omitempty"`
+
+	// Capabilities are what the downstream server declared at initialize.
+	// Nil for clients without an MCP handshake and servers that have not
+	// completed one.
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+
+	// IdentityChanges lists how the server's version, protocol version, or
+	// capabilities differ after a reconnect from what it declared when it
+	// registered, one human-readable entry per change. Empty when nothing
+	// changed; cleared when the server registers again.
+	IdentityChanges []string `json:"identityChanges,omitempty"`
+
 	// RegistrationFailed marks a server that never registered with the
 	// gateway (initialize failure, unsupported protocol version, unreachable
 	// endpoint). Such entries carry only Name, Healthy=false, and HealthError;
@@ -2268,6 +2621,26 @@ type MCPServerStatus struct {
 	// set that the gateway doesn't retain.
 	ToolWhitelist []string `json:"toolWhitelist,omitempty"`
 
+	// SchemaIssues lists tools whose inputSchema is missing or not a valid
+	// JSON Schema object. Strict clients reject such tools (sometimes the
+	// whole tools/list), so these are surfaced per server. Entries marked
+	// repaired are advertised with the gateway's fixed schema.
+	SchemaIssues []SchemaIssue `json:"schemaIssues,omitempty"`
+
+	// CircuitState is the server's circuit breaker state: "closed", "open",
+	// or "half-open". Empty when no breaker is configured or no call has
+	// been made yet.
+	CircuitState string `json:"circuitState,omitempty"`
+
+	// Paused marks a server whose tool calls are rejected for maintenance
+	// (see Gateway.SetServerPaused).
+	Paused bool `json:"paused,omitempty"`
+
+	// Restarts counts the automatic reconnects of the server's replicas
+	// after a crash, dropped connection, or failed health check, summed
+	// across replicas. Manual restarts are not counted.
+	Restarts uint64 `json:"restarts,omitempty"`
+
 	Replicas []ReplicaStatus `json:"replicas,omitempty"` // Per-replica status; always populated
 
 	// Autoscale is non-nil only for servers with an autoscale block in
@@ -2297,6 +2670,7 @@ type ReplicaStatus struct {
 	LastHealthy     *time.Time `json:"lastHealthy,omitempty"`
 	LastError       string     `json:"lastError,omitempty"`
 	RestartAttempts uint32     `json:"restartAttempts,omitempty"`
+	Restarts        uint64     `json:"restarts,omitempty"` // successful automatic reconnects
 	NextRetryAt     *time.Time `json:"nextRetryAt,omitempty"`
 	PID             int        `json:"pid,omitempty"`
 	ContainerID     string     `json:"containerId,omitempty"`
@@ -2472,10 +2846,20 @@ func (g *Gateway) Status() []MCPServerStatus {
 			OpenAPI:       meta.OpenAPI,
 			OutputFormat:  outputFormat,
 			ToolWhitelist: meta.Tools,
+			SchemaIssues:  g.router.SchemaIssues(name),
+			CircuitState:  g.circuitState(name),
+			Paused:        g.ServerPaused(name),
 		}
 		if client != nil {
 			status.ProtocolVersion = protocolVersionOf(client)
+			info := client.ServerInfo()
+			status.ServerName = info.Name
+			status.ServerVersion = info.Version
+			if client.IsInitialized() {
+				status.Capabilities = capabilitiesOf(client)
+			}
 		}
+		status.IdentityChanges = g.serverIdentityChanges(name)
 		if meta.OpenAPIConfig != nil {
 			status.OpenAPISpec = meta.OpenAPIConfig.Spec
 		}
@@ -2498,6 +2882,9 @@ func (g *Gateway) Status() []MCPServerStatus {
 		g.authStateMu.RUnlock()
 
 		status.Replicas = g.ReplicaStatuses(name)
+		for _, rs := range status.Replicas {
+			status.Restarts += rs.Restarts
+		}
 
 		if scaler := g.GetAutoscaler(name); scaler != nil {
 			st := scaler.Status()
@@ -168,3 +168,53 @@ func TestGateway_ClientScope_API(t *testing.T) {
 		t.Errorf("servers = %v, want [gitlab]", scope.Servers)
 	}
 }
+
+func TestGateway_Prompts_ScopedBySkills(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	g.Router().AddClient(&promptProviderClient{
+		AgentClient: setupMockAgentClient(ctrl, "registry", nil),
+		prompts: []PromptData{
+			{Name: "code-review", Content: "Review {{context}}", Arguments: []PromptArgumentData{{Name: "context"}}},
+			{Name: "deploy", Content: "Deploy it"},
+		},
+	})
+	g.SetClientAccessPolicy(NewClientAccessPolicy(&ClientAccessSpec{
+		Profiles: map[string]ClientProfileSpec{
+			"reviewer": {Skills: []string{"code-review"}},
+		},
+	}))
+	ctx := ctxWithAccess("reviewer")
+
+	prompts, err := g.HandlePromptsList(ctx)
+	if err != nil {
+		t.Fatalf("HandlePromptsList: %v", err)
+	}
+	if len(prompts.Prompts) != 1 || prompts.Prompts[0].Name != "code-review" {
+		t.Errorf("scoped prompts/list = %+v, want only code-review", prompts.Prompts)
+	}
+	resources, err := g.HandleResourcesList(ctx)
+	if err != nil {
+		t.Fatalf("HandleResourcesList: %v", err)
+	}
+	if len(resources.Resources) != 1 || resources.Resources[0].Name != "code-review" {
+		t.Errorf("scoped resources/list = %+v, want only code-review", resources.Resources)
+	}
+
+	if _, err := g.HandlePromptsGet(ctx, PromptsGetParams{Name: "code-review"}); err != nil {
+		t.Errorf("allowed prompts/get: %v", err)
+	}
+	_, err = g.HandlePromptsGet(ctx, PromptsGetParams{Name: "deploy"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("hidden prompts/get error = %v, want not found", err)
+	}
+	if _, err := g.HandleResourcesRead(ctx, ResourcesReadParams{URI: "skills://registry/deploy"}); err == nil {
+		t.Error("hidden resources/read should fail")
+	}
+
+	// A client without a skills allow-list keeps the full skill surface.
+	prompts, _ = g.HandlePromptsList(ctxWithAccess("windsurf"))
+	if len(prompts.Prompts) != 2 {
+		t.Errorf("unlisted client should see both prompts, got %d", len(prompts.Prompts))
+	}
+}
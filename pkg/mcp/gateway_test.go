@@ -1367,7 +1367,7 @@ func TestGateway_HandleInitialize_WithoutRegistry(t *testing.T) {
 func TestGateway_HandlePromptsList_Empty(t *testing.T) {
 	g := NewGateway()
 
-	result, err := g.HandlePromptsList()
+	result, err := g.HandlePromptsList(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1404,7 +1404,7 @@ func TestGateway_HandlePromptsList_WithPrompts(t *testing.T) {
 	}
 	g.Router().AddClient(client)
 
-	result, err := g.HandlePromptsList()
+	result, err := g.HandlePromptsList(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1513,6 +1513,38 @@ func TestGateway_HandlePromptsGet_DefaultArguments(t *testing.T) {
 	}
 }
 
+func TestGateway_HandlePromptsGet_ValuesAreNotReexpanded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+
+	mock := setupMockAgentClient(ctrl, "registry", nil)
+	client := &promptProviderClient{
+		AgentClient: mock,
+		prompts: []PromptData{
+			{
+				Name:    "greet",
+				Content: "Hello {{name}}, welcome to {{place}}!",
+				Arguments: []PromptArgumentData{
+					{Name: "name", Required: true},
+					{Name: "place", Default: "the world"},
+				},
+			},
+		},
+	}
+	g.Router().AddClient(client)
+
+	result, err := g.HandlePromptsGet(context.Background(), PromptsGetParams{
+		Name:      "greet",
+		Arguments: map[string]string{"name": "{{place}}"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Messages[0].Content.Text != "Hello {{place}}, welcome to the world!" {
+		t.Errorf("expected the supplied value verbatim, got %q", result.Messages[0].Content.Text)
+	}
+}
+
 func TestGateway_HandlePromptsGet_NotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	g := NewGateway()
@@ -1556,7 +1588,7 @@ func TestGateway_HandleResourcesList(t *testing.T) {
 	}
 	g.Router().AddClient(client)
 
-	result, err := g.HandleResourcesList()
+	result, err := g.HandleResourcesList(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1578,7 +1610,7 @@ func TestGateway_HandleResourcesList(t *testing.T) {
 func TestGateway_HandleResourcesList_Empty(t *testing.T) {
 	g := NewGateway()
 
-	result, err := g.HandleResourcesList()
+	result, err := g.HandleResourcesList(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1607,7 +1639,7 @@ func TestGateway_HandleResourcesRead(t *testing.T) {
 	}
 	g.Router().AddClient(client)
 
-	result, err := g.HandleResourcesRead(ResourcesReadParams{URI: "skills://registry/code-review"})
+	result, err := g.HandleResourcesRead(context.Background(), ResourcesReadParams{URI: "skills://registry/code-review"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1637,7 +1669,7 @@ func TestGateway_HandleResourcesRead_InvalidURI(t *testing.T) {
 	}
 	g.Router().AddClient(client)
 
-	_, err := g.HandleResourcesRead(ResourcesReadParams{URI: "https://example.com/foo"})
+	_, err := g.HandleResourcesRead(context.Background(), ResourcesReadParams{URI: "https://example.com/foo"})
 	if err == nil {
 		t.Fatal("expected error for non-prompt:// URI")
 	}
@@ -1657,7 +1689,7 @@ func TestGateway_HandleResourcesRead_NotFound(t *testing.T) {
 	}
 	g.Router().AddClient(client)
 
-	_, err := g.HandleResourcesRead(ResourcesReadParams{URI: "prompt://nonexistent"})
+	_, err := g.HandleResourcesRead(context.Background(), ResourcesReadParams{URI: "prompt://nonexistent"})
 	if err == nil {
 		t.Fatal("expected error for nonexistent prompt")
 	}
@@ -1666,7 +1698,7 @@ func TestGateway_HandleResourcesRead_NotFound(t *testing.T) {
 func TestGateway_HandleResourcesRead_NoRegistry(t *testing.T) {
 	g := NewGateway()
 
-	_, err := g.HandleResourcesRead(ResourcesReadParams{URI: "prompt://anything"})
+	_, err := g.HandleResourcesRead(context.Background(), ResourcesReadParams{URI: "prompt://anything"})
 	if err == nil {
 		t.Fatal("expected error when no registry")
 	}
@@ -1743,7 +1775,7 @@ func TestGateway_HandleResourcesRead_EmptyName(t *testing.T) {
 	}
 	g.Router().AddClient(client)
 
-	_, err := g.HandleResourcesRead(ResourcesReadParams{URI: "skills://registry/"})
+	_, err := g.HandleResourcesRead(context.Background(), ResourcesReadParams{URI: "skills://registry/"})
 	if err == nil {
 		t.Fatal("expected error for empty resource name")
 	}
@@ -1952,7 +1984,7 @@ func TestGateway_HandleResourcesRead_LegacyPromptURI(t *testing.T) {
 	g.Router().AddClient(pp)
 
 	// Legacy prompt:// URI should work
-	result, err := g.HandleResourcesRead(ResourcesReadParams{URI: "prompt://test-prompt"})
+	result, err := g.HandleResourcesRead(context.Background(), ResourcesReadParams{URI: "prompt://test-prompt"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1976,7 +2008,7 @@ func TestGateway_HandleResourcesRead_EmptyNameInURI(t *testing.T) {
 	g.Router().AddClient(pp)
 
 	// Empty name after prefix strip
-	_, err := g.HandleResourcesRead(ResourcesReadParams{URI: "skills://registry/"})
+	_, err := g.HandleResourcesRead(context.Background(), ResourcesReadParams{URI: "skills://registry/"})
 	if err == nil {
 		t.Fatal("expected error for empty resource name")
 	}
@@ -3655,3 +3687,164 @@ func TestGateway_HandleInitialize_NormalizesClientID(t *testing.T) {
 		t.Errorf("Session.ClientID = %q, want claude-code", sess.ClientID)
 	}
 }
+
+func TestGateway_HandleToolsCall_ArgumentValidation(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object","properties":{"message":{"type":"string"}},"required":["message"]}`)
+	newGateway := func(t *testing.T, override *bool) (*Gateway, *int) {
+		ctrl := gomock.NewController(t)
+		g := NewGateway()
+		g.SetArgumentValidation(true)
+		calls := 0
+		client := setupMockAgentClient(ctrl, "agent1", []Tool{{Name: "echo", InputSchema: schema}})
+		client.EXPECT().CallTool(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, name string, args map[string]any) (*ToolCallResult, error) {
+				calls++
+				return &ToolCallResult{Content: []Content{NewTextContent("ok")}}, nil
+			},
+		).AnyTimes()
+		g.Router().AddClient(client)
+		g.Router().RefreshTools()
+		g.serverMeta["agent1"] = MCPServerConfig{Name: "agent1", ValidateArguments: override}
+		return g, &calls
+	}
+	bad := ToolCallParams{Name: "agent1__echo", Arguments: map[string]any{"message": 42}}
+
+	t.Run("rejects before forwarding", func(t *testing.T) {
+		g, calls := newGateway(t, nil)
+		result, err := g.HandleToolsCall(context.Background(), bad)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError || *calls != 0 {
+			t.Fatalf("expected a local rejection, got isError=%v calls=%d", result.IsError, *calls)
+		}
+		if !strings.Contains(result.Content[0].Text, "/message") {
+			t.Errorf("message should name the offending path: %q", result.Content[0].Text)
+		}
+		var structured struct {
+			Error      string              `json:"error"`
+			Violations []ArgumentViolation `json:"violations"`
+		}
+		if err := json.Unmarshal(result.StructuredContent, &structured); err != nil {
+			t.Fatalf("structuredContent: %v", err)
+		}
+		if structured.Error != "invalid_arguments" || len(structured.Violations) == 0 {
+			t.Errorf("structuredContent = %s", result.StructuredContent)
+		}
+	})
+
+	t.Run("valid arguments forward", func(t *testing.T) {
+		g, calls := newGateway(t, nil)
+		result, _ := g.HandleToolsCall(context.Background(), ToolCallParams{Name: "agent1__echo", Arguments: map[string]any{"message": "hi"}})
+		if result.IsError || *calls != 1 {
+			t.Errorf("expected the call forwarded, got isError=%v calls=%d", result.IsError, *calls)
+		}
+	})
+
+	t.Run("per-server opt-out", func(t *testing.T) {
+		off := false
+		g, calls := newGateway(t, &off)
+		result, _ := g.HandleToolsCall(context.Background(), bad)
+		if result.IsError || *calls != 1 {
+			t.Errorf("expected the opted-out server to receive the call, got isError=%v calls=%d", result.IsError, *calls)
+		}
+	})
+}
+
+func TestHandleToolsCall_ServerTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	client := setupMockAgentClient(ctrl, "local", []Tool{{Name: "hang", Description: "Hang"}})
+	var deadline time.Time
+	client.EXPECT().CallTool(gomock.Any(), "hang", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string, _ map[string]any) (*ToolCallResult, error) {
+			deadline, _ = ctx.Deadline()
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	g.Router().AddClient(client)
+	g.Router().RefreshTools()
+	g.SetServerMeta(MCPServerConfig{Name: "local", Timeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	res, err := g.HandleToolsCall(context.Background(), ToolCallParams{Name: "local__hang"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("call took %v, want the 50ms server timeout", elapsed)
+	}
+	if deadline.Sub(start) > time.Second {
+		t.Errorf("call deadline %v after start, want about 50ms", deadline.Sub(start))
+	}
+	if !res.IsError || !strings.Contains(res.Content[0].Text, "timed out after 50ms") {
+		t.Errorf("expected a timeout result, got %+v", res)
+	}
+}
+
+func TestHandleToolsCall_DefaultTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	client := setupMockAgentClient(ctrl, "remote", []Tool{{Name: "query", Description: "Query"}})
+	client.EXPECT().CallTool(gomock.Any(), "query", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string, _ map[string]any) (*ToolCallResult, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok || time.Until(deadline) > DefaultRequestTimeout {
+				t.Errorf("deadline = %v (set %v), want within DefaultRequestTimeout", deadline, ok)
+			}
+			return &ToolCallResult{Content: []Content{NewTextContent("ok")}}, nil
+		})
+	g.Router().AddClient(client)
+	g.Router().RefreshTools()
+
+	if _, err := g.HandleToolsCall(context.Background(), ToolCallParams{Name: "remote__query"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandleToolsCall_Maintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	for _, name := range []string{"github", "local"} {
+		client := setupMockAgentClient(ctrl, name, []Tool{{Name: "query", Description: "Query"}})
+		client.EXPECT().CallTool(gomock.Any(), "query", gomock.Any()).
+			Return(&ToolCallResult{Content: []Content{NewTextContent("ok")}}, nil).AnyTimes()
+		g.Router().AddClient(client)
+	}
+	g.Router().RefreshTools()
+
+	call := func(name string) *ToolCallResult {
+		t.Helper()
+		res, err := g.HandleToolsCall(context.Background(), ToolCallParams{Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	g.SetServerPaused("github", true)
+	if res := call("github__query"); !res.IsError || !strings.Contains(res.Content[0].Text, `server "github" is paused`) {
+		t.Errorf("paused server: got %+v", res)
+	}
+	if res := call("local__query"); res.IsError {
+		t.Errorf("other servers should keep serving, got %+v", res)
+	}
+
+	g.SetMaintenance(true, "upgrading backends until 18:00 UTC")
+	if res := call("local__query"); !res.IsError || res.Content[0].Text != "upgrading backends until 18:00 UTC" {
+		t.Errorf("maintenance mode: got %+v", res)
+	}
+	st := g.Maintenance()
+	if !st.Enabled || st.Since == nil || len(st.PausedServers) != 1 || st.PausedServers[0] != "github" {
+		t.Errorf("Maintenance() = %+v", st)
+	}
+
+	g.SetMaintenance(false, "")
+	g.SetServerPaused("github", false)
+	if res := call("github__query"); res.IsError {
+		t.Errorf("after resuming: got %+v", res)
+	}
+	if st := g.Maintenance(); st.Enabled || len(st.PausedServers) != 0 {
+		t.Errorf("Maintenance() after resuming = %+v", st)
+	}
+}
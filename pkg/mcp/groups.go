@@ -276,6 +276,9 @@ type GroupStatus struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
 	Endpoint    string `json:"endpoint"`
+	// URL is the absolute endpoint URL as seen by the requesting client,
+	// honoring reverse proxy headers. Set by the REST API, not GroupsStatus.
+	URL         string `json:"url,omitempty"`
 	MemberCount int    `json:"member_count"`
 	// Tools are the exposed (post-rewrite) tool names, sorted.
 	Tools []string `json:"tools"`
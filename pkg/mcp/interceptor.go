@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"time"
+)
+
+// InterceptedCall is one tools/call as seen by a ToolCallInterceptor. The
+// embedded GateCall carries the identity; Arguments may be rewritten by
+// Before (argument normalization, redaction of inputs).
+type InterceptedCall struct {
+	GateCall
+	Arguments map[string]any
+}
+
+// ToolCallOutcome is what an interceptor's After hook sees once a call
+// finishes. After may replace Result, for example to redact output.
+type ToolCallOutcome struct {
+	// Result is the call's result; nil only when Err is set.
+	Result *ToolCallResult
+	Err    error
+	// Duration is the time spent dispatching the call, excluding Before
+	// hooks. Zero when the call was short-circuited.
+	Duration time.Duration
+	// ShortCircuitedBy names the interceptor whose Before answered the call
+	// without dispatching it, or is empty.
+	ShortCircuitedBy string
+}
+
+// ToolCallInterceptor wraps the direct tools/call dispatch path. Before
+// hooks run in slice order ahead of the access-scope check, gates, and
+// routing; After hooks run in reverse order once the call completes, so the
+// first interceptor sees the final result.
+//
+// Interceptors complement CallGate rather than replace it: gates are
+// veto-only policy checks with a fixed position, while interceptors observe
+// or transform every call and its outcome (auditing, latency recording,
+// redaction). Like gates they are first-party and installed as a fixed
+// slice; implementations must be safe for concurrent calls. Calls code mode
+// makes from its sandbox are intercepted individually; the code-mode
+// meta-tools themselves are not.
+type ToolCallInterceptor interface {
+	// Name identifies the interceptor in logs and outcomes.
+	Name() string
+	// Before runs before dispatch. A non-nil result short-circuits the
+	// call: later interceptors and dispatch are skipped, and the After
+	// hooks of interceptors that already ran see that result.
+	Before(ctx context.Context, call *InterceptedCall) *ToolCallResult
+	// After runs once the call completes or is short-circuited.
+	After(ctx context.Context, call *InterceptedCall, outcome *ToolCallOutcome)
+}
+
+// SetToolCallInterceptors installs the interceptor chain, replacing any
+// previous one. Passing nil removes all interceptors. Like SetCallGates, the
+// slice is read fresh on every call, so a hot-reload swap takes effect on the
+// next request.
+func (g *Gateway) SetToolCallInterceptors(interceptors []ToolCallInterceptor) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.interceptors = interceptors
+}
+
+// interceptToolCall runs dispatch wrapped in the installed interceptors.
+func (g *Gateway) interceptToolCall(ctx context.Context, params ToolCallParams, dispatch func(context.Context, ToolCallParams) (*ToolCallResult, error)) (*ToolCallResult, error) {
+	g.mu.RLock()
+	interceptors := g.interceptors
+	g.mu.RUnlock()
+	if len(interceptors) == 0 {
+		return dispatch(ctx, params)
+	}
+
+	serverName, _, err := ParsePrefixedTool(params.Name)
+	if err != nil {
+		serverName = ""
+	}
+	call := &InterceptedCall{
+		GateCall: GateCall{
+			PrefixedTool:   params.Name,
+			ServerName:     serverName,
+			ClientAccessID: ClientAccessIDFromContext(ctx),
+		},
+		Arguments: params.Arguments,
+	}
+
+	outcome := &ToolCallOutcome{}
+	ran := 0
+	for _, ic := range interceptors {
+		ran++
+		if result := ic.Before(ctx, call); result != nil {
+			outcome.Result = result
+			outcome.ShortCircuitedBy = ic.Name()
+			break
+		}
+	}
+	if outcome.ShortCircuitedBy == "" {
+		params.Arguments = call.Arguments
+		start := time.Now()
+		outcome.Result, outcome.Err = dispatch(ctx, params)
+		outcome.Duration = time.Since(start)
+	}
+	for i := ran - 1; i >= 0; i-- {
+		interceptors[i].After(ctx, call, outcome)
+	}
+	return outcome.Result, outcome.Err
+}
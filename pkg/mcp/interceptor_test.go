@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	_ ToolCallInterceptor = (*AuditInterceptor)(nil)
+	_ ToolCallInterceptor = (*LatencyRecorder)(nil)
+)
+
+// scriptedInterceptor records its hook calls into a shared trace and can
+// short-circuit, rewrite arguments, or replace the result.
+type scriptedInterceptor struct {
+	name      string
+	trace     *[]string
+	answer    *ToolCallResult
+	setArg    string
+	replace   *ToolCallResult
+	lastAfter *ToolCallOutcome
+}
+
+func (s *scriptedInterceptor) Name() string { return s.name }
+
+func (s *scriptedInterceptor) Before(_ context.Context, call *InterceptedCall) *ToolCallResult {
+	*s.trace = append(*s.trace, "before:"+s.name)
+	if s.setArg != "" {
+		call.Arguments = map[string]any{"q": s.setArg}
+	}
+	return s.answer
+}
+
+func (s *scriptedInterceptor) After(_ context.Context, _ *InterceptedCall, outcome *ToolCallOutcome) {
+	*s.trace = append(*s.trace, "after:"+s.name)
+	s.lastAfter = outcome
+	if s.replace != nil {
+		outcome.Result = s.replace
+	}
+}
+
+// newInterceptorTestGateway returns a gateway with one "github" server whose
+// search tool echoes its q argument.
+func newInterceptorTestGateway(t *testing.T, wantCalls int) *Gateway {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	client := setupMockAgentClient(ctrl, "github", []Tool{{Name: "search", Description: "Search"}})
+	client.EXPECT().CallTool(gomock.Any(), "search", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, args map[string]any) (*ToolCallResult, error) {
+			q, _ := args["q"].(string)
+			return &ToolCallResult{Content: []Content{NewTextContent("echo:" + q)}}, nil
+		},
+	).Times(wantCalls)
+	g.Router().AddClient(client)
+	g.Router().RefreshTools()
+	return g
+}
+
+func TestToolCallInterceptors_OrderAndArgumentRewrite(t *testing.T) {
+	g := newInterceptorTestGateway(t, 1)
+	var trace []string
+	first := &scriptedInterceptor{name: "first", trace: &trace}
+	second := &scriptedInterceptor{name: "second", trace: &trace, setArg: "rewritten"}
+	g.SetToolCallInterceptors([]ToolCallInterceptor{first, second})
+
+	result, err := g.HandleToolsCall(context.Background(), ToolCallParams{
+		Name:      "github__search",
+		Arguments: map[string]any{"q": "original"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Content[0].Text; got != "echo:rewritten" {
+		t.Errorf("result = %q, want the rewritten argument", got)
+	}
+	want := "before:first,before:second,after:second,after:first"
+	if got := strings.Join(trace, ","); got != want {
+		t.Errorf("hook order = %s, want %s", got, want)
+	}
+	if first.lastAfter.ShortCircuitedBy != "" || first.lastAfter.Duration <= 0 {
+		t.Errorf("outcome = %+v, want a dispatched call with a duration", first.lastAfter)
+	}
+}
+
+func TestToolCallInterceptors_ShortCircuit(t *testing.T) {
+	g := newInterceptorTestGateway(t, 0)
+	var trace []string
+	first := &scriptedInterceptor{name: "first", trace: &trace}
+	cache := &scriptedInterceptor{name: "cache", trace: &trace, answer: &ToolCallResult{Content: []Content{NewTextContent("cached")}}}
+	last := &scriptedInterceptor{name: "last", trace: &trace}
+	g.SetToolCallInterceptors([]ToolCallInterceptor{first, cache, last})
+
+	result, err := g.HandleToolsCall(context.Background(), ToolCallParams{Name: "github__search"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Content[0].Text; got != "cached" {
+		t.Errorf("result = %q, want cached", got)
+	}
+	want := "before:first,before:cache,after:cache,after:first"
+	if got := strings.Join(trace, ","); got != want {
+		t.Errorf("hook order = %s, want %s", got, want)
+	}
+	if first.lastAfter.ShortCircuitedBy != "cache" {
+		t.Errorf("ShortCircuitedBy = %q, want cache", first.lastAfter.ShortCircuitedBy)
+	}
+}
+
+func TestToolCallInterceptors_AfterReplacesResult(t *testing.T) {
+	g := newInterceptorTestGateway(t, 1)
+	var trace []string
+	redact := &scriptedInterceptor{name: "redact", trace: &trace, replace: &ToolCallResult{Content: []Content{NewTextContent("[redacted]")}}}
+	g.SetToolCallInterceptors([]ToolCallInterceptor{redact})
+
+	result, err := g.HandleToolsCall(context.Background(), ToolCallParams{Name: "github__search"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Content[0].Text; got != "[redacted]" {
+		t.Errorf("result = %q, want [redacted]", got)
+	}
+}
+
+func TestAuditInterceptor_LogsArgumentNamesNotValues(t *testing.T) {
+	g := newInterceptorTestGateway(t, 1)
+	var buf bytes.Buffer
+	g.SetToolCallInterceptors([]ToolCallInterceptor{NewAuditInterceptor(slog.New(slog.NewTextHandler(&buf, nil)))})
+
+	ctx := WithClientAccessID(context.Background(), "claude-code")
+	if _, err := g.HandleToolsCall(ctx, ToolCallParams{
+		Name:      "github__search",
+		Arguments: map[string]any{"q": "secret-value"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	line := buf.String()
+	for _, want := range []string{"tool call audit", "tool=github__search", "server=github", "client=claude-code", "arguments=[q]", "outcome=ok"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("audit line missing %q: %s", want, line)
+		}
+	}
+	if strings.Contains(line, "secret-value") {
+		t.Errorf("audit line leaked an argument value: %s", line)
+	}
+}
+
+func TestLatencyRecorder_Snapshot(t *testing.T) {
+	rec := NewLatencyRecorder()
+	call := &InterceptedCall{GateCall: GateCall{PrefixedTool: "github__search", ServerName: "github"}}
+	for i := 1; i <= 100; i++ {
+		rec.After(context.Background(), call, &ToolCallOutcome{
+			Result:   &ToolCallResult{IsError: i%10 == 0},
+			Duration: time.Duration(i) * time.Millisecond,
+		})
+	}
+	rec.After(context.Background(), call, &ToolCallOutcome{ShortCircuitedBy: "cache"})
+
+	snap := rec.Snapshot()
+	if len(snap) != 1 {
+		t.Fatalf("snapshot = %+v, want one tool", snap)
+	}
+	got := snap[0]
+	if got.Tool != "github__search" || got.Server != "github" || got.Calls != 100 || got.Errors != 10 {
+		t.Errorf("counts = %+v", got)
+	}
+	if got.P50Ms != 50 || got.P95Ms != 95 || got.P99Ms != 99 || got.MaxMs != 100 || got.MeanMs != 50.5 {
+		t.Errorf("latencies = %+v", got)
+	}
+}
+
+func TestLatencyRecorder_WindowKeepsRecentCalls(t *testing.T) {
+	rec := NewLatencyRecorder()
+	call := &InterceptedCall{GateCall: GateCall{PrefixedTool: "github__search", ServerName: "github"}}
+	rec.After(context.Background(), call, &ToolCallOutcome{Result: &ToolCallResult{}, Duration: time.Second})
+	for i := 0; i < latencyWindow; i++ {
+		rec.After(context.Background(), call, &ToolCallOutcome{Result: &ToolCallResult{}, Duration: time.Millisecond})
+	}
+
+	got := rec.Snapshot()[0]
+	if got.P99Ms != 1 {
+		t.Errorf("P99Ms = %v, want the old slow call aged out of the window", got.P99Ms)
+	}
+	if got.MaxMs != 1000 || got.Calls != int64(latencyWindow)+1 {
+		t.Errorf("MaxMs = %v, Calls = %d; want all-time values", got.MaxMs, got.Calls)
+	}
+}
+
+func TestLatencyRecorder_SampleRateKeepsCountsExact(t *testing.T) {
+	rec := NewLatencyRecorder()
+	rec.SetSampleRate(0.5)
+	draws := []float64{0.1, 0.9}
+	n := 0
+	rec.sample = func() float64 { n++; return draws[n%2] }
+	call := &InterceptedCall{GateCall: GateCall{PrefixedTool: "github__search", ServerName: "github"}}
+	for i := 1; i <= 10; i++ {
+		rec.After(context.Background(), call, &ToolCallOutcome{Result: &ToolCallResult{}, Duration: time.Duration(i) * time.Millisecond})
+	}
+
+	got := rec.Snapshot()[0]
+	if got.Calls != 10 || got.MaxMs != 10 {
+		t.Errorf("Calls = %d, MaxMs = %v; want every call counted", got.Calls, got.MaxMs)
+	}
+	if sampled := len(rec.tools["github__search"].samples); sampled != 5 {
+		t.Errorf("sampled %d calls, want 5", sampled)
+	}
+}
@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AuditInterceptor logs one structured "tool call audit" record per call:
+// who called which tool, with which argument names, and how it ended. Values
+// of arguments are never logged, since they routinely carry secrets and
+// personal data.
+type AuditInterceptor struct {
+	logger *slog.Logger
+}
+
+// NewAuditInterceptor returns an audit interceptor that logs to logger.
+func NewAuditInterceptor(logger *slog.Logger) *AuditInterceptor {
+	return &AuditInterceptor{logger: logger}
+}
+
+// Name implements ToolCallInterceptor.
+func (a *AuditInterceptor) Name() string { return "audit" }
+
+// Before implements ToolCallInterceptor; it never short-circuits.
+func (a *AuditInterceptor) Before(context.Context, *InterceptedCall) *ToolCallResult { return nil }
+
+// After implements ToolCallInterceptor.
+func (a *AuditInterceptor) After(ctx context.Context, call *InterceptedCall, outcome *ToolCallOutcome) {
+	argNames := make([]string, 0, len(call.Arguments))
+	for name := range call.Arguments {
+		argNames = append(argNames, name)
+	}
+	sort.Strings(argNames)
+
+	attrs := []any{
+		"tool", call.PrefixedTool,
+		"server", call.ServerName,
+		"client", call.ClientAccessID,
+		"session", SessionIDFromContext(ctx),
+		"arguments", argNames,
+		"duration", outcome.Duration,
+	}
+	switch {
+	case outcome.Err != nil:
+		attrs = append(attrs, "outcome", "error", "error", outcome.Err)
+	case outcome.ShortCircuitedBy != "":
+		attrs = append(attrs, "outcome", "short-circuited", "by", outcome.ShortCircuitedBy)
+	case outcome.Result != nil && outcome.Result.IsError:
+		attrs = append(attrs, "outcome", "tool-error")
+	default:
+		attrs = append(attrs, "outcome", "ok")
+	}
+	a.logger.Info("tool call audit", attrs...)
+}
+
+// latencyWindow is how many recent calls per tool the latency percentiles
+// are computed over.
+const latencyWindow = 256
+
+// ToolLatency summarizes one tool's recorded call latencies. Percentiles and
+// the mean cover the most recent sampled calls (up to 256); Calls, Errors,
+// and Max cover every call since the gateway started.
+type ToolLatency struct {
+	Tool   string  `json:"tool"`
+	Server string  `json:"server"`
+	Calls  int64   `json:"calls"`
+	Errors int64   `json:"errors"`
+	MeanMs float64 `json:"meanMs"`
+	P50Ms  float64 `json:"p50Ms"`
+	P95Ms  float64 `json:"p95Ms"`
+	P99Ms  float64 `json:"p99Ms"`
+	MaxMs  float64 `json:"maxMs"`
+}
+
+// LatencyRecorder is a ToolCallInterceptor that records how long each tool
+// call took, per prefixed tool name. Short-circuited calls are not recorded.
+type LatencyRecorder struct {
+	mu         sync.Mutex
+	tools      map[string]*toolLatencies
+	sampleRate float64        // fraction of calls entering the percentile window; 0 means all
+	sample     func() float64 // uniform in [0, 1)
+}
+
+type toolLatencies struct {
+	server  string
+	calls   int64
+	errors  int64
+	max     time.Duration
+	samples []time.Duration // ring buffer of the last latencyWindow calls
+	next    int
+}
+
+// NewLatencyRecorder returns an empty latency recorder.
+func NewLatencyRecorder() *LatencyRecorder {
+	return &LatencyRecorder{tools: make(map[string]*toolLatencies), sample: rand.Float64}
+}
+
+// SetSampleRate sets the fraction of calls, in (0, 1], whose latency enters
+// the percentile window. Counts and the maximum always cover every call.
+// Zero or one records every call.
+func (l *LatencyRecorder) SetSampleRate(rate float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sampleRate = rate
+}
+
+// Name implements ToolCallInterceptor.
+func (l *LatencyRecorder) Name() string { return "latency" }
+
+// Before implements ToolCallInterceptor; it never short-circuits.
+func (l *LatencyRecorder) Before(context.Context, *InterceptedCall) *ToolCallResult { return nil }
+
+// After implements ToolCallInterceptor.
+func (l *LatencyRecorder) After(_ context.Context, call *InterceptedCall, outcome *ToolCallOutcome) {
+	if outcome.ShortCircuitedBy != "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t := l.tools[call.PrefixedTool]
+	if t == nil {
+		t = &toolLatencies{server: call.ServerName}
+		l.tools[call.PrefixedTool] = t
+	}
+	t.calls++
+	if outcome.Err != nil || (outcome.Result != nil && outcome.Result.IsError) {
+		t.errors++
+	}
+	t.max = max(t.max, outcome.Duration)
+	if l.sampleRate > 0 && l.sampleRate < 1 && l.sample() >= l.sampleRate {
+		return
+	}
+	if len(t.samples) < latencyWindow {
+		t.samples = append(t.samples, outcome.Duration)
+	} else {
+		t.samples[t.next] = outcome.Duration
+		t.next = (t.next + 1) % latencyWindow
+	}
+}
+
+// Snapshot returns the latency summary of every recorded tool, sorted by
+// tool name.
+func (l *LatencyRecorder) Snapshot() []ToolLatency {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]ToolLatency, 0, len(l.tools))
+	for name, t := range l.tools {
+		sorted := append([]time.Duration(nil), t.samples...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		var total, mean time.Duration
+		for _, d := range sorted {
+			total += d
+		}
+		if len(sorted) > 0 {
+			mean = total / time.Duration(len(sorted))
+		}
+		out = append(out, ToolLatency{
+			Tool:   name,
+			Server: t.server,
+			Calls:  t.calls,
+			Errors: t.errors,
+			MeanMs: millis(mean),
+			P50Ms:  millis(latencyPercentile(sorted, 50)),
+			P95Ms:  millis(latencyPercentile(sorted, 95)),
+			P99Ms:  millis(latencyPercentile(sorted, 99)),
+			MaxMs:  millis(t.max),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Tool < out[j].Tool })
+	return out
+}
+
+// latencyPercentile returns the nearest-rank percentile of sorted samples.
+func latencyPercentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultMaintenanceMessage is the tool error returned while the gateway is
+// in maintenance mode and no message was given.
+const DefaultMaintenanceMessage = "the gateway is in maintenance mode; tool calls are temporarily unavailable"
+
+// MaintenanceStatus reports the gateway's maintenance mode and the servers
+// paused on their own.
+type MaintenanceStatus struct {
+	// Enabled rejects every tools/call with Message. Listing tools, status,
+	// and log APIs keep working.
+	Enabled bool       `json:"enabled"`
+	Message string     `json:"message,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+	// PausedServers rejects tool calls to these servers only, whether or not
+	// maintenance mode is enabled.
+	PausedServers []string `json:"pausedServers"`
+}
+
+// maintenanceState is the gateway's mutable maintenance configuration. It
+// lives only in memory: a restarted gateway serves tool calls again.
+type maintenanceState struct {
+	enabled bool
+	message string
+	since   time.Time
+	paused  map[string]time.Time
+}
+
+// SetMaintenance turns maintenance mode on or off. While on, every tool call
+// returns message (DefaultMaintenanceMessage when empty) as a tool error.
+func (g *Gateway) SetMaintenance(enabled bool, message string) {
+	g.maintenanceMu.Lock()
+	defer g.maintenanceMu.Unlock()
+	if enabled && !g.maintenance.enabled {
+		g.maintenance.since = time.Now()
+	}
+	g.maintenance.enabled = enabled
+	g.maintenance.message = ""
+	if enabled {
+		g.maintenance.message = message
+	}
+	g.logger.Info("maintenance mode changed", "enabled", enabled, "message", message)
+}
+
+// SetServerPaused pauses or resumes tool calls to one server. A pause is kept
+// across the server's restarts and reloads until it is resumed.
+func (g *Gateway) SetServerPaused(name string, paused bool) {
+	g.maintenanceMu.Lock()
+	defer g.maintenanceMu.Unlock()
+	if paused {
+		if g.maintenance.paused == nil {
+			g.maintenance.paused = make(map[string]time.Time)
+		}
+		if _, ok := g.maintenance.paused[name]; !ok {
+			g.maintenance.paused[name] = time.Now()
+		}
+	} else {
+		delete(g.maintenance.paused, name)
+	}
+	g.logger.Info("server pause changed", "server", name, "paused", paused)
+}
+
+// ServerPaused reports whether tool calls to a server are paused.
+func (g *Gateway) ServerPaused(name string) bool {
+	g.maintenanceMu.RLock()
+	defer g.maintenanceMu.RUnlock()
+	_, ok := g.maintenance.paused[name]
+	return ok
+}
+
+// Maintenance returns the current maintenance mode and paused servers.
+func (g *Gateway) Maintenance() MaintenanceStatus {
+	g.maintenanceMu.RLock()
+	defer g.maintenanceMu.RUnlock()
+	st := MaintenanceStatus{Enabled: g.maintenance.enabled, PausedServers: []string{}}
+	if st.Enabled {
+		since := g.maintenance.since
+		st.Since = &since
+		st.Message = g.maintenanceMessage()
+	}
+	for name := range g.maintenance.paused {
+		st.PausedServers = append(st.PausedServers, name)
+	}
+	sort.Strings(st.PausedServers)
+	return st
+}
+
+// maintenanceMessage returns the configured message or the default. Callers
+// hold maintenanceMu.
+func (g *Gateway) maintenanceMessage() string {
+	if g.maintenance.message != "" {
+		return g.maintenance.message
+	}
+	return DefaultMaintenanceMessage
+}
+
+// maintenanceRejection returns the tool error for a call rejected by
+// maintenance mode, or "" when the gateway is serving calls. Checked before
+// routing, so it covers every tool including code mode's.
+func (g *Gateway) maintenanceRejection() string {
+	g.maintenanceMu.RLock()
+	defer g.maintenanceMu.RUnlock()
+	if !g.maintenance.enabled {
+		return ""
+	}
+	return g.maintenanceMessage()
+}
+
+// pausedRejection returns the tool error for a call to a paused server, or ""
+// when the server is not paused.
+func (g *Gateway) pausedRejection(server string) string {
+	if !g.ServerPaused(server) {
+		return ""
+	}
+	return fmt.Sprintf("server %q is paused for maintenance; tool calls resume when it is unpaused", server)
+}
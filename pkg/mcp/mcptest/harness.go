@@ -0,0 +1,56 @@
+package mcptest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// Harness is a gateway served over httptest at /mcp (Streamable HTTP), the
+// same endpoint clients reach on a running gridctl gateway.
+type Harness struct {
+	Gateway *mcp.Gateway
+	Server  *httptest.Server
+}
+
+// NewHarness starts a gateway aggregating clients and serves it until the
+// test ends. Each client is registered as an HTTP server so it also appears
+// in Gateway.Status.
+func NewHarness(t testing.TB, clients ...mcp.AgentClient) *Harness {
+	t.Helper()
+	g := mcp.NewGateway()
+	for _, c := range clients {
+		g.Router().AddClient(c)
+		g.SetServerMeta(mcp.MCPServerConfig{Name: c.Name(), Transport: mcp.TransportHTTP})
+	}
+	g.Router().RefreshTools()
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", mcp.NewStreamableHTTPServer(g, nil))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		srv.Close()
+		g.Close()
+	})
+	return &Harness{Gateway: g, Server: srv}
+}
+
+// URL returns the gateway's MCP endpoint.
+func (h *Harness) URL() string {
+	return h.Server.URL + "/mcp"
+}
+
+// Connect returns an MCP client initialized against the harness, as an
+// agent or an upstream gateway would connect. Its tools are the gateway's
+// aggregated, server-prefixed tools.
+func (h *Harness) Connect(ctx context.Context, t testing.TB) *mcp.Client {
+	t.Helper()
+	c := mcp.NewClient("mcptest", h.URL())
+	if err := c.Initialize(ctx); err != nil {
+		t.Fatalf("mcptest: initialize: %v", err)
+	}
+	return c
+}
@@ -0,0 +1,97 @@
+// Package mcptest provides test doubles for code that embeds the gridctl
+// gateway or is exercised through it: a gomock AgentClient
+// (NewMockAgentClient), a hand-rolled FakeClient for tests that would rather
+// not set expectations, a Router pre-loaded with clients, and a Harness that
+// serves a gateway over httptest so tests can drive it as a real MCP client
+// would.
+package mcptest
+
+//go:generate mockgen -destination=mock_agent_client.go -package=mcptest github.com/gridctl/gridctl/pkg/mcp AgentClient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+
+	"go.uber.org/mock/gomock"
+)
+
+// NewStubAgentClient returns a MockAgentClient that reports name, tools, and
+// an initialized state for any number of calls, leaving only CallTool for
+// the test to set expectations on.
+func NewStubAgentClient(ctrl *gomock.Controller, name string, tools []mcp.Tool) *MockAgentClient {
+	m := NewMockAgentClient(ctrl)
+	m.EXPECT().Name().Return(name).AnyTimes()
+	m.EXPECT().Tools().Return(tools).AnyTimes()
+	m.EXPECT().IsInitialized().Return(true).AnyTimes()
+	m.EXPECT().ServerInfo().Return(mcp.ServerInfo{Name: name, Version: "1.0.0"}).AnyTimes()
+	m.EXPECT().Initialize(gomock.Any()).Return(nil).AnyTimes()
+	m.EXPECT().RefreshTools(gomock.Any()).Return(nil).AnyTimes()
+	return m
+}
+
+// ToolCall records one CallTool invocation on a FakeClient.
+type ToolCall struct {
+	Name      string
+	Arguments map[string]any
+}
+
+// FakeClient is an mcp.AgentClient that serves a fixed tool list. Calls are
+// recorded, and answered by CallToolFunc when set or with a text result
+// echoing the tool name otherwise. It is safe for concurrent use.
+type FakeClient struct {
+	// CallToolFunc, when set, answers every CallTool. name is the tool's
+	// unprefixed name, as the gateway forwards it downstream.
+	CallToolFunc func(ctx context.Context, name string, arguments map[string]any) (*mcp.ToolCallResult, error)
+
+	name  string
+	tools []mcp.Tool
+
+	mu    sync.Mutex
+	calls []ToolCall
+}
+
+// NewFakeClient returns an initialized FakeClient named name.
+func NewFakeClient(name string, tools ...mcp.Tool) *FakeClient {
+	return &FakeClient{name: name, tools: tools}
+}
+
+func (f *FakeClient) Name() string                         { return f.name }
+func (f *FakeClient) Initialize(_ context.Context) error   { return nil }
+func (f *FakeClient) RefreshTools(_ context.Context) error { return nil }
+func (f *FakeClient) Tools() []mcp.Tool                    { return f.tools }
+func (f *FakeClient) IsInitialized() bool                  { return true }
+func (f *FakeClient) ServerInfo() mcp.ServerInfo {
+	return mcp.ServerInfo{Name: f.name, Version: "1.0.0"}
+}
+
+// CallTool records the call and answers it.
+func (f *FakeClient) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.ToolCallResult, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, ToolCall{Name: name, Arguments: arguments})
+	f.mu.Unlock()
+	if f.CallToolFunc != nil {
+		return f.CallToolFunc(ctx, name, arguments)
+	}
+	return &mcp.ToolCallResult{Content: []mcp.Content{mcp.NewTextContent(fmt.Sprintf("%s called", name))}}, nil
+}
+
+// Calls returns the calls received so far, oldest first.
+func (f *FakeClient) Calls() []ToolCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]ToolCall(nil), f.calls...)
+}
+
+// NewRouter returns an mcp.Router with clients registered and their tools
+// indexed, ready for RouteToolCall and AggregatedTools.
+func NewRouter(clients ...mcp.AgentClient) *mcp.Router {
+	r := mcp.NewRouter()
+	for _, c := range clients {
+		r.AddClient(c)
+	}
+	r.RefreshTools()
+	return r
+}
@@ -0,0 +1,74 @@
+package mcptest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewRouter(t *testing.T) {
+	r := NewRouter(
+		NewFakeClient("github", mcp.Tool{Name: "search"}),
+		NewFakeClient("slack", mcp.Tool{Name: "post"}),
+	)
+	client, tool, err := r.RouteToolCall("slack__post")
+	if err != nil {
+		t.Fatalf("RouteToolCall: %v", err)
+	}
+	if client.Name() != "slack" || tool != "post" {
+		t.Errorf("routed to %s/%s", client.Name(), tool)
+	}
+	if n := len(r.AggregatedTools()); n != 2 {
+		t.Errorf("aggregated %d tools, want 2", n)
+	}
+}
+
+func TestHarness_CallsReachFakeClient(t *testing.T) {
+	fake := NewFakeClient("github", mcp.Tool{Name: "search", Description: "Search"})
+	fake.CallToolFunc = func(_ context.Context, name string, args map[string]any) (*mcp.ToolCallResult, error) {
+		if args["q"] != "bug" {
+			return nil, errors.New("unexpected arguments")
+		}
+		return &mcp.ToolCallResult{Content: []mcp.Content{mcp.NewTextContent("found")}}, nil
+	}
+	h := NewHarness(t, fake)
+
+	ctx := context.Background()
+	c := h.Connect(ctx, t)
+	if err := c.RefreshTools(ctx); err != nil {
+		t.Fatalf("RefreshTools: %v", err)
+	}
+	if tools := c.Tools(); len(tools) != 1 || tools[0].Name != "github__search" {
+		t.Fatalf("tools = %+v", tools)
+	}
+
+	result, err := c.CallTool(ctx, "github__search", map[string]any{"q": "bug"})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if result.IsError || result.Content[0].Text != "found" {
+		t.Errorf("result = %+v", result)
+	}
+	if calls := fake.Calls(); len(calls) != 1 || calls[0].Name != "search" {
+		t.Errorf("calls = %+v", calls)
+	}
+}
+
+func TestNewStubAgentClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewStubAgentClient(ctrl, "db", []mcp.Tool{{Name: "query"}})
+	m.EXPECT().CallTool(gomock.Any(), "query", gomock.Any()).
+		Return(&mcp.ToolCallResult{Content: []mcp.Content{mcp.NewTextContent("rows")}}, nil)
+
+	g := mcp.NewGateway()
+	g.Router().AddClient(m)
+	g.Router().RefreshTools()
+	result, err := g.HandleToolsCall(context.Background(), mcp.ToolCallParams{Name: "db__query"})
+	if err != nil || result.IsError {
+		t.Fatalf("HandleToolsCall = %+v, %v", result, err)
+	}
+}
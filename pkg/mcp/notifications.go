@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/crash"
+)
+
+// List-changed notification methods (MCP spec, server -> client).
+const (
+	NotificationToolsListChanged     = "notifications/tools/list_changed"
+	NotificationPromptsListChanged   = "notifications/prompts/list_changed"
+	NotificationResourcesListChanged = "notifications/resources/list_changed"
+)
+
+// Other server-sent notifications the gateway handles.
+const (
+	NotificationResourcesUpdated = "notifications/resources/updated"
+	NotificationMessage          = "notifications/message"
+)
+
+// notificationRefreshTimeout bounds the tools/list a list_changed
+// notification triggers.
+const notificationRefreshTimeout = 30 * time.Second
+
+// NotificationHandler receives the method and params of each notification a
+// downstream server sends. It runs on the client's read loop, so it must
+// not block or call back into the same client synchronously.
+type NotificationHandler func(method string, params json.RawMessage)
+
+// SetNotificationHandler sets the handler for server-sent notifications.
+// Nil drops notifications (the default).
+func (r *RPCClient) SetNotificationHandler(h NotificationHandler) {
+	if h == nil {
+		r.notify.Store(nil)
+		return
+	}
+	r.notify.Store(&h)
+}
+
+// handleNotification passes a server-sent notification to the handler.
+func (r *RPCClient) handleNotification(method string, params json.RawMessage) {
+	if h := r.notify.Load(); h != nil {
+		(*h)(method, params)
+	}
+}
+
+// parseNotification returns the method and params of a JSON-RPC
+// notification: a message with a method and no id. Server-to-client
+// requests (which carry an id) and responses report false.
+func parseNotification(msg []byte) (string, json.RawMessage, bool) {
+	var n struct {
+		ID     *json.RawMessage `json:"id"`
+		Method string           `json:"method"`
+		Params json.RawMessage  `json:"params"`
+	}
+	if err := json.Unmarshal(msg, &n); err != nil || n.ID != nil || n.Method == "" {
+		return "", nil, false
+	}
+	return n.Method, n.Params, true
+}
+
+// watchNotifications routes a client's server-sent notifications to the
+// gateway. Clients without a notification channel (OpenAPI) are skipped.
+func (g *Gateway) watchNotifications(name string, client AgentClient) {
+	src, ok := client.(interface{ SetNotificationHandler(NotificationHandler) })
+	if !ok {
+		return
+	}
+	src.SetNotificationHandler(func(method string, params json.RawMessage) {
+		g.handleServerNotification(name, client, method, params)
+	})
+}
+
+// handleServerNotification reacts to a downstream server's notification.
+// tools/list_changed refreshes that client's tools and tells connected
+// clients; log messages land in the gateway log under the server's name.
+// The gateway does not proxy downstream prompts or resources, so their
+// change notifications are only logged: no client can have listed or
+// subscribed to them through the gateway.
+func (g *Gateway) handleServerNotification(name string, client AgentClient, method string, params json.RawMessage) {
+	switch method {
+	case NotificationToolsListChanged:
+		g.scheduleToolRefresh(name, client)
+	case NotificationMessage:
+		g.logServerMessage(name, params)
+	case NotificationPromptsListChanged, NotificationResourcesListChanged, NotificationResourcesUpdated:
+		g.logger.Debug("ignoring notification for capability the gateway does not proxy", "server", name, "method", method)
+	}
+}
+
+// logServerMessage writes a notifications/message from a downstream server
+// to the gateway log. MCP's syslog levels map onto the nearest slog level.
+func (g *Gateway) logServerMessage(name string, params json.RawMessage) {
+	var msg struct {
+		Level  string          `json:"level"`
+		Logger string          `json:"logger"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(params, &msg); err != nil {
+		g.logger.Debug("malformed notifications/message", "server", name, "error", err)
+		return
+	}
+	level := slog.LevelInfo
+	switch msg.Level {
+	case "debug":
+		level = slog.LevelDebug
+	case "warning":
+		level = slog.LevelWarn
+	case "error", "critical", "alert", "emergency":
+		level = slog.LevelError
+	}
+	attrs := []any{"server", name, "mcp_level", msg.Level}
+	if msg.Logger != "" {
+		attrs = append(attrs, "logger", msg.Logger)
+	}
+	// String data is logged as text, anything else as its JSON.
+	var text string
+	if err := json.Unmarshal(msg.Data, &text); err == nil {
+		attrs = append(attrs, "data", text)
+	} else if len(msg.Data) > 0 {
+		attrs = append(attrs, "data", string(msg.Data))
+	}
+	g.logger.Log(context.Background(), level, "MCP server log message", attrs...)
+}
+
+// scheduleToolRefresh refreshes a client's tools off the read loop. A burst
+// of notifications coalesces: while a refresh runs, further notifications
+// queue at most one more.
+func (g *Gateway) scheduleToolRefresh(name string, client AgentClient) {
+	g.notifyMu.Lock()
+	if _, running := g.toolRefreshes[client]; running {
+		g.toolRefreshes[client] = true
+		g.notifyMu.Unlock()
+		return
+	}
+	g.toolRefreshes[client] = false
+	g.notifyMu.Unlock()
+
+	go func() {
+		defer crash.Recover("tools-list-changed", "server", name)
+		for {
+			g.refreshServerTools(name, client)
+
+			g.notifyMu.Lock()
+			again := g.toolRefreshes[client]
+			if !again {
+				delete(g.toolRefreshes, client)
+				g.notifyMu.Unlock()
+				return
+			}
+			g.toolRefreshes[client] = false
+			g.notifyMu.Unlock()
+		}
+	}()
+}
+
+// refreshServerTools re-fetches one server's tools after it announced a
+// change, re-verifies its schema pins, and notifies connected clients.
+func (g *Gateway) refreshServerTools(name string, client AgentClient) {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationRefreshTimeout)
+	defer cancel()
+	if err := client.RefreshTools(ctx); err != nil {
+		g.logger.Warn("tools refresh after list_changed failed", "server", name, "error", err)
+		return
+	}
+	g.router.RefreshTools()
+	g.logger.Info("MCP server tools changed", "server", name, "tools", len(client.Tools()))
+
+	if g.pinningEnabledForServer(name) {
+		drifts, err := g.schemaVerifier.VerifyOrPin(name, client.Tools())
+		if err != nil {
+			g.logger.Warn("pins: verification failed after list_changed", "server", name, "error", err)
+		} else {
+			g.handlePinDrift(name, drifts)
+		}
+	}
+
+	g.notifyListChanged(NotificationToolsListChanged)
+}
+
+// OnListChanged registers fn to be called with a list_changed notification
+// method whenever the gateway's own tool list changes: a downstream server
+// announced a change, or a server was registered, removed, or refreshed.
+// Transports use it to forward the notification to connected clients.
+func (g *Gateway) OnListChanged(fn func(method string)) {
+	g.notifyMu.Lock()
+	defer g.notifyMu.Unlock()
+	g.listChangedListeners = append(g.listChangedListeners, fn)
+}
+
+// notifyListChanged calls every OnListChanged listener.
+func (g *Gateway) notifyListChanged(method string) {
+	g.notifyMu.Lock()
+	listeners := append([]func(string){}, g.listChangedListeners...)
+	g.notifyMu.Unlock()
+	for _, fn := range listeners {
+		fn(method)
+	}
+}
@@ -0,0 +1,143 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseNotification(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    string
+		method string
+		params string
+		ok     bool
+	}{
+		{"notification", `{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`, NotificationToolsListChanged, "", true},
+		{"with params", `{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info"}}`, NotificationMessage, `{"level":"info"}`, true},
+		{"response", `{"jsonrpc":"2.0","id":1,"result":{}}`, "", "", false},
+		{"server request", `{"jsonrpc":"2.0","id":7,"method":"ping"}`, "", "", false},
+		{"malformed", `not json`, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, params, ok := parseNotification([]byte(tt.msg))
+			if method != tt.method || string(params) != tt.params || ok != tt.ok {
+				t.Errorf("parseNotification(%s) = %q, %s, %v; want %q, %s, %v", tt.msg, method, params, ok, tt.method, tt.params, tt.ok)
+			}
+		})
+	}
+}
+
+func TestProcessClient_ReadResponses_DispatchesNotifications(t *testing.T) {
+	c := NewProcessClient("proc", nil, "", nil)
+	var got []string
+	c.SetNotificationHandler(func(method string, _ json.RawMessage) { got = append(got, method) })
+
+	out := strings.Join([]string{
+		`{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`,
+		`{"jsonrpc":"2.0","id":99,"result":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info"}}`,
+	}, "\n")
+	c.readResponses(context.Background(), strings.NewReader(out))
+
+	want := []string{NotificationToolsListChanged, "notifications/message"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("notifications = %v, want %v", got, want)
+	}
+}
+
+func TestGateway_ToolsListChanged_RefreshesAndForwards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+
+	refreshed := make(chan struct{}, 1)
+	client := NewMockAgentClient(ctrl)
+	client.EXPECT().Name().Return("server1").AnyTimes()
+	client.EXPECT().Tools().Return([]Tool{{Name: "tool1"}, {Name: "tool2"}}).AnyTimes()
+	client.EXPECT().IsInitialized().Return(true).AnyTimes()
+	client.EXPECT().ServerInfo().Return(ServerInfo{Name: "server1", Version: "1.0.0"}).AnyTimes()
+	client.EXPECT().RefreshTools(gomock.Any()).DoAndReturn(func(context.Context) error {
+		refreshed <- struct{}{}
+		return nil
+	}).MinTimes(1)
+	g.Router().AddClient(client)
+	g.SetServerMeta(MCPServerConfig{Name: "server1", Transport: TransportStdio})
+
+	srv := NewStreamableHTTPServer(g, nil)
+	sessionID := initializeStreamable(t, srv)
+
+	forwarded := make(chan string, 1)
+	g.OnListChanged(func(method string) { forwarded <- method })
+
+	g.handleServerNotification("server1", client, NotificationToolsListChanged, nil)
+
+	select {
+	case <-refreshed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("tools/list_changed did not refresh the client")
+	}
+	select {
+	case method := <-forwarded:
+		if method != NotificationToolsListChanged {
+			t.Errorf("forwarded %q, want %q", method, NotificationToolsListChanged)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("tools/list_changed was not forwarded")
+	}
+
+	srv.mu.RLock()
+	session := srv.sessions[sessionID]
+	srv.mu.RUnlock()
+	events := session.eventsAfter(0)
+	if len(events) == 0 || !strings.Contains(string(events[len(events)-1].Data), NotificationToolsListChanged) {
+		t.Errorf("session events = %+v, want a tools/list_changed notification", events)
+	}
+}
+
+func TestGateway_ServerLogMessage_LoggedWithServer(t *testing.T) {
+	var buf bytes.Buffer
+	g := NewGateway()
+	g.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	g.handleServerNotification("db", nil, NotificationMessage, json.RawMessage(`{"level":"warning","logger":"pool","data":"connections exhausted"}`))
+	g.handleServerNotification("db", nil, NotificationMessage, json.RawMessage(`{"level":"debug","data":{"open":3}}`))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("log lines = %d, want 2:\n%s", len(lines), buf.String())
+	}
+	var first, second map[string]any
+	_ = json.Unmarshal([]byte(lines[0]), &first)
+	_ = json.Unmarshal([]byte(lines[1]), &second)
+	if first["level"] != "WARN" || first["server"] != "db" || first["logger"] != "pool" || first["data"] != "connections exhausted" {
+		t.Errorf("warning message = %v", first)
+	}
+	if second["level"] != "DEBUG" || second["data"] != `{"open":3}` {
+		t.Errorf("debug message = %v", second)
+	}
+}
+
+func TestGateway_UnregisterServer_ForwardsToolsListChanged(t *testing.T) {
+	g := NewGateway()
+	forwarded := make(chan string, 1)
+	g.OnListChanged(func(method string) { forwarded <- method })
+
+	g.UnregisterMCPServer("gone")
+
+	select {
+	case method := <-forwarded:
+		if method != NotificationToolsListChanged {
+			t.Errorf("forwarded %q, want %q", method, NotificationToolsListChanged)
+		}
+	default:
+		t.Fatal("removing a server did not forward tools/list_changed")
+	}
+}
@@ -13,6 +13,9 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,12 +25,16 @@ import (
 	"golang.org/x/oauth2/clientcredentials"
 )
 
-// Default HTTP timeout for OpenAPI requests
-const defaultOpenAPITimeout = 30 * time.Second
-
 // Maximum response body size (10MB) to prevent memory exhaustion
 const maxResponseBodySize = 10 * 1024 * 1024
 
+// maxDescriptionExample caps the example body quoted in a tool description;
+// a larger example is still attached to the input schema.
+const maxDescriptionExample = 1024
+
+// maxExampleDepth bounds example synthesis so recursive schemas terminate.
+const maxExampleDepth = 4
+
 // OpenAPIClient implements AgentClient by transforming OpenAPI operations to MCP tools.
 // It parses an OpenAPI specification and converts each operation into an MCP tool,
 // proxying tool calls to HTTP requests against the target API.
@@ -42,9 +49,11 @@ type OpenAPIClient struct {
 	authValue  string
 	includeOps map[string]bool
 	excludeOps map[string]bool
+	groupBy    string // "tag" or "path" folds operations into composite tools; empty = one tool per operation
 	httpClient *http.Client
 	logger     *slog.Logger
 	noExpand   bool // If true, skip environment variable expansion in spec file
+	mock       bool // If true, answer calls with the spec's example responses instead of the API
 
 	// Query param auth
 	authQueryParam string
@@ -59,6 +68,7 @@ type OpenAPIClient struct {
 
 	operations map[string]*OpenAPIOperation // toolName -> operation (protected by ClientBase.mu)
 	cachedDoc  *openapi3.T                  // Cached OpenAPI document (protected by ClientBase.mu)
+	groups     map[string][]string          // composite tool name -> operation tool names (protected by ClientBase.mu)
 
 	pingTimeout time.Duration // 0 = use DefaultPingTimeout
 }
@@ -77,6 +87,7 @@ type OpenAPIOperation struct {
 	QueryParams  map[string]*openapi3.Parameter
 	HeaderParams map[string]*openapi3.Parameter
 	RequestBody  *openapi3.RequestBodyRef
+	Responses    *openapi3.Responses // Declared responses, for mock mode
 }
 
 // NewOpenAPIClient creates an OpenAPI-based MCP client.
@@ -96,6 +107,8 @@ func NewOpenAPIClient(name string, cfg *OpenAPIClientConfig) (*OpenAPIClient, er
 		logger:         logging.NewDiscardLogger(),
 		operations:     make(map[string]*OpenAPIOperation),
 		noExpand:       cfg.NoExpand,
+		groupBy:        cfg.GroupBy,
+		mock:           cfg.Mock,
 	}
 
 	if len(cfg.Include) > 0 {
@@ -138,7 +151,9 @@ func NewOpenAPIClient(name string, cfg *OpenAPIClientConfig) (*OpenAPIClient, er
 		transport.TLSClientConfig = tlsCfg
 	}
 
-	c.httpClient = &http.Client{Timeout: defaultOpenAPITimeout, Transport: transport}
+	// Requests are bounded by their context (see requestTimeout) rather than
+	// a client-wide timeout, so a server's tool call timeout applies.
+	c.httpClient = &http.Client{Transport: transport}
 
 	// Build OAuth2 token source for client credentials flow
 	if cfg.AuthType == "oauth2" {
@@ -188,11 +203,15 @@ func (c *OpenAPIClient) Initialize(ctx context.Context) error {
 		c.baseURL = doc.Servers[0].URL
 	}
 
-	// Validate that we have a base URL
-	if c.baseURL == "" {
+	// Validate that we have a base URL; mock mode never sends a request
+	if c.baseURL == "" && !c.mock {
 		return fmt.Errorf("no base URL: either configure baseUrl or ensure the OpenAPI spec has a servers entry")
 	}
 
+	if c.mock {
+		c.logger.Info("OpenAPI server in mock mode: serving example responses from the spec")
+	}
+
 	c.mu.Lock()
 	c.cachedDoc = doc
 	c.initialized = true
@@ -224,11 +243,13 @@ func (c *OpenAPIClient) RefreshTools(ctx context.Context) error {
 
 	var tools []Tool
 	operations := make(map[string]*OpenAPIOperation)
+	groupKeys := make(map[string]string)
 
 	if doc.Paths == nil {
 		c.mu.Lock()
 		c.allTools = tools
 		c.operations = operations
+		c.groups = nil
 		c.mu.Unlock()
 		return nil
 	}
@@ -257,12 +278,19 @@ func (c *OpenAPIClient) RefreshTools(ctx context.Context) error {
 
 			tools = append(tools, tool)
 			operations[tool.Name] = operation
+			groupKeys[tool.Name] = openAPIGroupKey(c.groupBy, path, op)
 		}
 	}
 
+	var groups map[string][]string
+	if c.groupBy != "" {
+		tools, groups = groupOperationTools(c.groupBy, tools, groupKeys)
+	}
+
 	c.mu.Lock()
 	c.allTools = tools
 	c.operations = operations
+	c.groups = groups
 	c.mu.Unlock()
 
 	return nil
@@ -272,6 +300,16 @@ func (c *OpenAPIClient) RefreshTools(ctx context.Context) error {
 func (c *OpenAPIClient) CallTool(ctx context.Context, name string, args map[string]any) (*ToolCallResult, error) {
 	c.logger.Debug("sending request", "method", "tools/call", "tool", name)
 
+	c.mu.RLock()
+	members, grouped := c.groups[name]
+	c.mu.RUnlock()
+	if grouped {
+		var errResult *ToolCallResult
+		if name, args, errResult = resolveGroupedCall(name, members, args); errResult != nil {
+			return errResult, nil
+		}
+	}
+
 	c.mu.RLock()
 	op, ok := c.operations[name]
 	c.mu.RUnlock()
@@ -293,8 +331,15 @@ func (c *OpenAPIClient) CallTool(ctx context.Context, name string, args map[stri
 		}
 	}
 
-	// Build and execute HTTP request
-	resp, statusCode, err := c.executeOperation(ctx, op, args)
+	// Build and execute HTTP request, or answer from the spec in mock mode
+	var resp string
+	var statusCode int
+	var err error
+	if c.mock {
+		resp, statusCode = mockResponse(op)
+	} else {
+		resp, statusCode, err = c.executeOperation(ctx, op, args)
+	}
 	if err != nil {
 		c.logger.Debug("request failed", "tool", name, "error", err)
 		return &ToolCallResult{
@@ -319,7 +364,11 @@ func (c *OpenAPIClient) CallTool(ctx context.Context, name string, args map[stri
 }
 
 // Ping checks if the OpenAPI backend is reachable by making a HEAD request to the base URL.
+// A mock server has no backend and is always reachable.
 func (c *OpenAPIClient) Ping(ctx context.Context) error {
+	if c.mock {
+		return nil
+	}
 	ctx, cancel := context.WithTimeout(ctx, pingTimeoutOrDefault(c.pingTimeout))
 	defer cancel()
 
@@ -379,6 +428,8 @@ func (c *OpenAPIClient) loadSpec(ctx context.Context) (*openapi3.T, error) {
 
 // fetchSpec fetches an OpenAPI spec from a URL with content-type validation.
 func (c *OpenAPIClient) fetchSpec(ctx context.Context) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(ctx))
+	defer cancel()
 	req, err := http.NewRequestWithContext(ctx, "GET", c.spec, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating spec request: %w", err)
@@ -433,7 +484,7 @@ func (c *OpenAPIClient) shouldInclude(operationID string) bool {
 // operationToTool converts an OpenAPI operation to an MCP tool.
 func (c *OpenAPIClient) operationToTool(method, path string, op *openapi3.Operation) (Tool, *OpenAPIOperation) {
 	pathParams := extractPathParams(path)
-	properties, required := c.buildParameterSchema(op, pathParams)
+	properties, required, bodyExample := c.buildParameterSchema(op, pathParams)
 	operation := c.buildOperation(method, path, pathParams, op)
 
 	// Path parameters are always required in OpenAPI
@@ -455,7 +506,7 @@ func (c *OpenAPIClient) operationToTool(method, path string, op *openapi3.Operat
 	// Marshal is safe here - inputSchema contains only primitives
 	inputSchemaBytes, _ := json.Marshal(inputSchema)
 
-	description := buildDescription(op)
+	description := describeOperation(op, bodyExample)
 
 	return Tool{
 		Name:        sanitizeOpenAPIToolName(op.OperationID),
@@ -464,6 +515,131 @@ func (c *OpenAPIClient) operationToTool(method, path string, op *openapi3.Operat
 	}, operation
 }
 
+// openAPIGroupUngrouped names the composite tool for operations with no tag
+// (groupBy: tag) or no literal path segment (groupBy: path).
+const openAPIGroupUngrouped = "other"
+
+// openAPIGroupKey returns the group an operation folds into: its first tag,
+// or the first literal segment of its path ("/pets/{id}" groups as "pets").
+func openAPIGroupKey(groupBy, path string, op *openapi3.Operation) string {
+	switch groupBy {
+	case "tag":
+		if len(op.Tags) > 0 && op.Tags[0] != "" {
+			return op.Tags[0]
+		}
+	case "path":
+		for _, seg := range strings.Split(path, "/") {
+			if seg != "" && !strings.HasPrefix(seg, "{") {
+				return seg
+			}
+		}
+	}
+	return openAPIGroupUngrouped
+}
+
+// groupOperationTools folds per-operation tools into one composite tool per
+// group. A composite tool takes the operation to run as an enum and that
+// operation's parameters under arguments; its description lists each
+// operation with its parameters so the full API stays reachable. Returns the
+// composite tools sorted by name and the operation tool names behind each.
+func groupOperationTools(groupBy string, tools []Tool, keys map[string]string) ([]Tool, map[string][]string) {
+	members := make(map[string][]Tool)
+	for _, t := range tools {
+		name := sanitizeOpenAPIToolName(keys[t.Name])
+		if name == "" {
+			name = openAPIGroupUngrouped
+		}
+		members[name] = append(members[name], t)
+	}
+
+	grouped := make([]Tool, 0, len(members))
+	groups := make(map[string][]string, len(members))
+	for name, ops := range members {
+		sort.Slice(ops, func(a, b int) bool { return ops[a].Name < ops[b].Name })
+		names := make([]string, len(ops))
+		var desc strings.Builder
+		fmt.Fprintf(&desc, "%d operations grouped by %s %q. Set operation to one of them and pass its parameters in arguments (* = required).", len(ops), groupBy, name)
+		for i, t := range ops {
+			names[i] = t.Name
+			fmt.Fprintf(&desc, "\n- %s", t.Name)
+			if params := describeToolParams(t.InputSchema); params != "" {
+				fmt.Fprintf(&desc, "(%s)", params)
+			}
+			// Parameters are already listed; keep only the summary.
+			if summary, _, _ := strings.Cut(t.Description, "\n\n"); summary != "" && !strings.HasPrefix(summary, "Parameters:") && !strings.HasPrefix(summary, "Example body:") {
+				desc.WriteString(": " + summary)
+			}
+		}
+		schema, _ := json.Marshal(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"operation": map[string]any{
+					"type":        "string",
+					"description": "The operation to run",
+					"enum":        names,
+				},
+				"arguments": map[string]any{
+					"type":        "object",
+					"description": "Parameters of the chosen operation",
+				},
+			},
+			"required": []string{"operation"},
+		})
+		grouped = append(grouped, Tool{Name: name, Description: desc.String(), InputSchema: schema})
+		groups[name] = names
+	}
+	sort.Slice(grouped, func(a, b int) bool { return grouped[a].Name < grouped[b].Name })
+	return grouped, groups
+}
+
+// describeToolParams renders an operation tool's parameters as a compact
+// "petId*, limit" list, required parameters marked and listed first.
+func describeToolParams(inputSchema json.RawMessage) string {
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+	}
+	if json.Unmarshal(inputSchema, &schema) != nil {
+		return ""
+	}
+	parts := make([]string, 0, len(schema.Properties))
+	for _, r := range schema.Required {
+		parts = append(parts, r+"*")
+	}
+	optional := make([]string, 0, len(schema.Properties))
+	for p := range schema.Properties {
+		if !slices.Contains(schema.Required, p) {
+			optional = append(optional, p)
+		}
+	}
+	sort.Strings(optional)
+	return strings.Join(append(parts, optional...), ", ")
+}
+
+// resolveGroupedCall maps a composite tool call to the operation tool it
+// names and that operation's arguments. A bad call yields an error result.
+func resolveGroupedCall(name string, members []string, args map[string]any) (string, map[string]any, *ToolCallResult) {
+	operation, _ := args["operation"].(string)
+	if !slices.Contains(members, operation) {
+		return "", nil, &ToolCallResult{
+			Content: []Content{NewTextContent(fmt.Sprintf("%s: operation must be one of %s", name, strings.Join(members, ", ")))},
+			IsError: true,
+		}
+	}
+	opArgs := map[string]any{}
+	if raw, ok := args["arguments"]; ok && raw != nil {
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return "", nil, &ToolCallResult{
+				Content: []Content{NewTextContent(fmt.Sprintf("%s: arguments must be an object", name))},
+				IsError: true,
+			}
+		}
+		opArgs = m
+	}
+	return operation, opArgs, nil
+}
+
 // extractPathParams extracts parameter names from a URL path template.
 func extractPathParams(path string) []string {
 	pathParamRegex := regexp.MustCompile(`\{([^}]+)\}`)
@@ -475,10 +651,13 @@ func extractPathParams(path string) []string {
 	return params
 }
 
-// buildParameterSchema builds the JSON Schema properties and required list from operation parameters.
-func (c *OpenAPIClient) buildParameterSchema(op *openapi3.Operation, pathParams []string) (map[string]any, []string) {
+// buildParameterSchema builds the JSON Schema properties and required list from
+// operation parameters, and returns the request body example attached to the
+// body property (nil when the operation takes no JSON body).
+func (c *OpenAPIClient) buildParameterSchema(op *openapi3.Operation, pathParams []string) (map[string]any, []string, any) {
 	properties := make(map[string]any)
 	var required []string
+	var bodyExample any
 
 	// Process parameters
 	for _, paramRef := range op.Parameters {
@@ -502,6 +681,16 @@ func (c *OpenAPIClient) buildParameterSchema(op *openapi3.Operation, pathParams
 		// Look for JSON content type
 		if content, ok := rb.Content["application/json"]; ok && content.Schema != nil {
 			bodySchema := c.schemaToJSONSchema(content.Schema)
+			// Prefer an example the spec gives; otherwise synthesize one
+			// from the schema so agents see the expected shape.
+			example, ok := firstExample(content.Example, content.Examples)
+			if !ok {
+				example = synthesizeExample(content.Schema, 0)
+			}
+			if example != nil {
+				bodySchema["examples"] = []any{example}
+				bodyExample = example
+			}
 			properties["body"] = bodySchema
 
 			if rb.Required {
@@ -510,7 +699,7 @@ func (c *OpenAPIClient) buildParameterSchema(op *openapi3.Operation, pathParams
 		}
 	}
 
-	return properties, required
+	return properties, required, bodyExample
 }
 
 // buildOperation creates the operation struct from an OpenAPI operation.
@@ -522,6 +711,7 @@ func (c *OpenAPIClient) buildOperation(method, path string, pathParams []string,
 		QueryParams:  make(map[string]*openapi3.Parameter),
 		HeaderParams: make(map[string]*openapi3.Parameter),
 		RequestBody:  op.RequestBody,
+		Responses:    op.Responses,
 	}
 
 	// Store parameter info for execution
@@ -554,6 +744,125 @@ func buildDescription(op *openapi3.Operation) string {
 	return description
 }
 
+// describeOperation builds a tool description from the operation's summary
+// and description, its parameter docs, and an example request body.
+func describeOperation(op *openapi3.Operation, bodyExample any) string {
+	var b strings.Builder
+	b.WriteString(buildDescription(op))
+
+	var params []string
+	for _, paramRef := range op.Parameters {
+		if paramRef == nil || paramRef.Value == nil {
+			continue
+		}
+		p := paramRef.Value
+		line := fmt.Sprintf("- %s (%s", p.Name, p.In)
+		if p.Required || p.In == "path" {
+			line += ", required"
+		}
+		line += ")"
+		if p.Description != "" {
+			line += ": " + p.Description
+		}
+		params = append(params, line)
+	}
+	if len(params) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString("Parameters:\n" + strings.Join(params, "\n"))
+	}
+
+	if bodyExample != nil {
+		if data, err := json.Marshal(bodyExample); err == nil && len(data) <= maxDescriptionExample {
+			if b.Len() > 0 {
+				b.WriteString("\n\n")
+			}
+			b.WriteString("Example body:\n" + string(data))
+		}
+	}
+	return b.String()
+}
+
+// firstExample returns a spec-provided example: the inline example, or else
+// the first named example in key order.
+func firstExample(example any, examples openapi3.Examples) (any, bool) {
+	if example != nil {
+		return example, true
+	}
+	keys := make([]string, 0, len(examples))
+	for k := range examples {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if ref := examples[k]; ref != nil && ref.Value != nil && ref.Value.Value != nil {
+			return ref.Value.Value, true
+		}
+	}
+	return nil, false
+}
+
+// synthesizeExample builds a representative value for a schema from its
+// example, default, or first enum value, falling back to a placeholder for
+// its type. Objects and arrays recurse up to maxExampleDepth.
+func synthesizeExample(ref *openapi3.SchemaRef, depth int) any {
+	if ref == nil || ref.Value == nil || depth > maxExampleDepth {
+		return nil
+	}
+	schema := ref.Value
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	}
+	if len(schema.AllOf) > 0 {
+		merged := map[string]any{}
+		for _, part := range schema.AllOf {
+			if m, ok := synthesizeExample(part, depth+1).(map[string]any); ok {
+				for k, v := range m {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	for _, alt := range append(schema.OneOf, schema.AnyOf...) {
+		if v := synthesizeExample(alt, depth+1); v != nil {
+			return v
+		}
+	}
+
+	switch {
+	case schema.Type == nil || schema.Type.Is("object"):
+		obj := map[string]any{}
+		for name, prop := range schema.Properties {
+			if v := synthesizeExample(prop, depth+1); v != nil {
+				obj[name] = v
+			}
+		}
+		return obj
+	case schema.Type.Is("array"):
+		if item := synthesizeExample(schema.Items, depth+1); item != nil {
+			return []any{item}
+		}
+		return []any{}
+	case schema.Type.Is("string"):
+		if schema.Format != "" {
+			return "<" + schema.Format + ">"
+		}
+		return "string"
+	case schema.Type.Is("integer"), schema.Type.Is("number"):
+		return 0
+	case schema.Type.Is("boolean"):
+		return false
+	}
+	return nil
+}
+
 // parameterToProperty converts an OpenAPI parameter to a JSON Schema property.
 func (c *OpenAPIClient) parameterToProperty(param *openapi3.Parameter) map[string]any {
 	prop := make(map[string]any)
@@ -574,6 +883,14 @@ func (c *OpenAPIClient) parameterToProperty(param *openapi3.Parameter) map[strin
 		if schema.Default != nil {
 			prop["default"] = schema.Default
 		}
+		if schema.Format != "" {
+			prop["format"] = schema.Format
+		}
+		if example, ok := firstExample(param.Example, param.Examples); ok {
+			prop["examples"] = []any{example}
+		} else if schema.Example != nil {
+			prop["examples"] = []any{schema.Example}
+		}
 	} else if param.Description != "" {
 		prop["description"] = param.Description
 		prop["type"] = "string" // Default to string if no schema
@@ -625,11 +942,24 @@ func (c *OpenAPIClient) schemaToJSONSchema(schemaRef *openapi3.SchemaRef) map[st
 		result["enum"] = schema.Enum
 	}
 
+	// Handle format, default, and example
+	if schema.Format != "" {
+		result["format"] = schema.Format
+	}
+	if schema.Default != nil {
+		result["default"] = schema.Default
+	}
+	if schema.Example != nil {
+		result["examples"] = []any{schema.Example}
+	}
+
 	return result
 }
 
 // executeOperation executes an HTTP request for the given operation.
 func (c *OpenAPIClient) executeOperation(ctx context.Context, op *OpenAPIOperation, args map[string]any) (string, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(ctx))
+	defer cancel()
 	// Build URL with path parameters substituted
 	path := op.Path
 	for _, paramName := range op.PathParams {
@@ -710,6 +1040,45 @@ func (c *OpenAPIClient) executeOperation(ctx context.Context, op *OpenAPIOperati
 	return string(respBody), resp.StatusCode, nil
 }
 
+// mockResponse answers an operation from its declared success response: the
+// lowest 2xx status (else "default"), with the spec's JSON example or one
+// synthesized from the response schema. An operation without a JSON body
+// returns an empty body.
+func mockResponse(op *OpenAPIOperation) (string, int) {
+	if op.Responses == nil {
+		return "", http.StatusOK
+	}
+	status, ref := http.StatusOK, op.Responses.Default()
+	keys := op.Responses.Keys()
+	sort.Strings(keys)
+	for _, k := range keys {
+		if code, err := strconv.Atoi(k); err == nil && code >= 200 && code < 300 {
+			status, ref = code, op.Responses.Value(k)
+			break
+		}
+	}
+	if ref == nil || ref.Value == nil {
+		return "", status
+	}
+
+	mt := ref.Value.Content.Get("application/json")
+	if mt == nil {
+		return "", status
+	}
+	example, ok := firstExample(mt.Example, mt.Examples)
+	if !ok {
+		example = synthesizeExample(mt.Schema, 0)
+	}
+	if example == nil {
+		return "", status
+	}
+	data, err := json.Marshal(example)
+	if err != nil {
+		return "", status
+	}
+	return string(data), status
+}
+
 // applyAuth applies authentication to the request.
 func (c *OpenAPIClient) applyAuth(req *http.Request) error {
 	switch c.authType {
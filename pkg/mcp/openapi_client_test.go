@@ -276,6 +276,110 @@ func TestBuildDescription(t *testing.T) {
 	}
 }
 
+func TestDescribeOperation(t *testing.T) {
+	op := &openapi3.Operation{
+		Summary: "Create a pet",
+		Parameters: openapi3.Parameters{
+			{Value: &openapi3.Parameter{Name: "store", In: "path", Description: "Store ID"}},
+			{Value: &openapi3.Parameter{Name: "dryRun", In: "query"}},
+		},
+	}
+	got := describeOperation(op, map[string]any{"name": "Rex"})
+	want := "Create a pet\n\nParameters:\n- store (path, required): Store ID\n- dryRun (query)\n\nExample body:\n{\"name\":\"Rex\"}"
+	if got != want {
+		t.Errorf("describeOperation() = %q, want %q", got, want)
+	}
+
+	// An oversized example stays out of the description.
+	big := map[string]any{"blob": strings.Repeat("x", maxDescriptionExample)}
+	if got := describeOperation(&openapi3.Operation{Summary: "Upload"}, big); got != "Upload" {
+		t.Errorf("describeOperation() with oversized example = %q, want %q", got, "Upload")
+	}
+}
+
+func TestSynthesizeExample(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0.0"},
+		"paths": {},
+		"components": {"schemas": {
+			"Pet": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "example": "Rex"},
+					"status": {"type": "string", "enum": ["available", "sold"]},
+					"born": {"type": "string", "format": "date"},
+					"age": {"type": "integer"},
+					"tags": {"type": "array", "items": {"type": "string"}},
+					"parent": {"$ref": "#/components/schemas/Pet"}
+				}
+			}
+		}}
+	}`))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	got, ok := synthesizeExample(doc.Components.Schemas["Pet"], 0).(map[string]any)
+	if !ok {
+		t.Fatalf("expected an object example, got %T", got)
+	}
+	if got["name"] != "Rex" || got["status"] != "available" || got["born"] != "<date>" || got["age"] != 0 {
+		t.Errorf("unexpected example fields: %v", got)
+	}
+	if tags, _ := got["tags"].([]any); len(tags) != 1 || tags[0] != "string" {
+		t.Errorf("tags = %v, want [string]", got["tags"])
+	}
+	if _, ok := got["parent"].(map[string]any); !ok {
+		t.Errorf("recursive property should synthesize to a bounded object, got %v", got["parent"])
+	}
+}
+
+func TestRefreshTools_AttachesBodyExample(t *testing.T) {
+	spec := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0.0"},
+		"servers": [{"url": "http://localhost"}],
+		"paths": {
+			"/pets": {
+				"post": {
+					"operationId": "createPet",
+					"summary": "Create a pet",
+					"requestBody": {"content": {"application/json": {
+						"schema": {"type": "object", "properties": {"name": {"type": "string"}}},
+						"examples": {"rex": {"value": {"name": "Rex"}}}
+					}}},
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(spec))
+	}))
+	defer srv.Close()
+
+	client, _ := NewOpenAPIClient("test", &OpenAPIClientConfig{Spec: srv.URL + "/openapi.json"})
+	if err := client.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := client.RefreshTools(context.Background()); err != nil {
+		t.Fatalf("RefreshTools: %v", err)
+	}
+
+	tools := client.Tools()
+	if len(tools) != 1 {
+		t.Fatalf("expected 1 tool, got %d", len(tools))
+	}
+	if !strings.Contains(string(tools[0].InputSchema), `"examples":[{"name":"Rex"}]`) {
+		t.Errorf("body schema should carry the spec example, got %s", tools[0].InputSchema)
+	}
+	if !strings.Contains(tools[0].Description, "Example body:\n{\"name\":\"Rex\"}") {
+		t.Errorf("description should quote the example body, got %q", tools[0].Description)
+	}
+}
+
 func TestInitialize_WithOperations(t *testing.T) {
 	spec := `{
 		"openapi": "3.0.3",
@@ -469,6 +573,159 @@ func TestCallTool_WithHTTPServer(t *testing.T) {
 	}
 }
 
+func TestCallTool_GroupedOperations(t *testing.T) {
+	var gotPath string
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer apiSrv.Close()
+
+	spec := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0.0"},
+		"paths": {
+			"/pets": {
+				"get": {"operationId": "listPets", "tags": ["pets"], "summary": "List pets", "responses": {"200": {"description": "OK"}}}
+			},
+			"/pets/{petId}": {
+				"get": {
+					"operationId": "getPet", "tags": ["pets"], "summary": "Get a pet",
+					"parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "string"}}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			},
+			"/health": {
+				"get": {"operationId": "health", "responses": {"200": {"description": "OK"}}}
+			}
+		}
+	}`
+	specSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(spec))
+	}))
+	defer specSrv.Close()
+
+	client, _ := NewOpenAPIClient("test", &OpenAPIClientConfig{
+		Spec:    specSrv.URL + "/openapi.json",
+		BaseURL: apiSrv.URL,
+		GroupBy: "tag",
+	})
+	if err := client.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := client.RefreshTools(context.Background()); err != nil {
+		t.Fatalf("RefreshTools: %v", err)
+	}
+
+	tools := client.Tools()
+	if len(tools) != 2 || tools[0].Name != "other" || tools[1].Name != "pets" {
+		t.Fatalf("grouped tools = %v, want [other pets]", toolNames(tools))
+	}
+	if !strings.Contains(tools[1].Description, "getPet(petId*): Get a pet") {
+		t.Errorf("composite description should list operations with parameters, got %q", tools[1].Description)
+	}
+
+	result, err := client.CallTool(context.Background(), "pets", map[string]any{
+		"operation": "getPet",
+		"arguments": map[string]any{"petId": "42"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("grouped call failed: %v %+v", err, result)
+	}
+	if gotPath != "/pets/42" {
+		t.Errorf("request path = %q, want /pets/42", gotPath)
+	}
+
+	result, _ = client.CallTool(context.Background(), "pets", map[string]any{"operation": "health"})
+	if !result.IsError {
+		t.Error("an operation outside the group should be rejected")
+	}
+}
+
+func TestOpenAPIGroupKey(t *testing.T) {
+	tests := []struct {
+		groupBy string
+		path    string
+		tags    []string
+		want    string
+	}{
+		{"tag", "/pets", []string{"pets", "store"}, "pets"},
+		{"tag", "/pets", nil, "other"},
+		{"path", "/pets/{petId}/photos", nil, "pets"},
+		{"path", "/{tenant}/orders", nil, "orders"},
+		{"path", "/", nil, "other"},
+	}
+	for _, tt := range tests {
+		op := &openapi3.Operation{Tags: tt.tags}
+		if got := openAPIGroupKey(tt.groupBy, tt.path, op); got != tt.want {
+			t.Errorf("openAPIGroupKey(%q, %q, %v) = %q, want %q", tt.groupBy, tt.path, tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestCallTool_MockMode(t *testing.T) {
+	spec := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0.0"},
+		"paths": {
+			"/pets/{petId}": {
+				"get": {
+					"operationId": "getPet",
+					"parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "string"}}],
+					"responses": {
+						"404": {"description": "Missing"},
+						"200": {"description": "OK", "content": {"application/json": {"example": {"id": "42", "name": "Rex"}}}}
+					}
+				}
+			},
+			"/pets": {
+				"post": {
+					"operationId": "createPet",
+					"responses": {"201": {"description": "Created", "content": {"application/json": {
+						"schema": {"type": "object", "properties": {"id": {"type": "integer"}}}
+					}}}}
+				}
+			}
+		}
+	}`
+	dir := t.TempDir()
+	specPath := dir + "/spec.json"
+	if err := os.WriteFile(specPath, []byte(spec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// No servers entry and no baseUrl: mock mode never dials the API.
+	client, _ := NewOpenAPIClient("test", &OpenAPIClientConfig{Spec: specPath, Mock: true})
+	if err := client.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if err := client.RefreshTools(context.Background()); err != nil {
+		t.Fatalf("RefreshTools: %v", err)
+	}
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("Ping in mock mode: %v", err)
+	}
+
+	result, err := client.CallTool(context.Background(), "getPet", map[string]any{"petId": "42"})
+	if err != nil || result.IsError {
+		t.Fatalf("mock call failed: %v %+v", err, result)
+	}
+	if got := result.Content[0].Text; got != `{"id":"42","name":"Rex"}` {
+		t.Errorf("mock body = %s, want the spec example", got)
+	}
+
+	result, _ = client.CallTool(context.Background(), "createPet", nil)
+	if got := result.Content[0].Text; got != `{"id":0}` {
+		t.Errorf("mock body = %s, want one synthesized from the schema", got)
+	}
+
+	result, _ = client.CallTool(context.Background(), "getPet", map[string]any{})
+	if !result.IsError {
+		t.Error("mock mode should still require path parameters")
+	}
+}
+
 func TestApplyAuth_Bearer(t *testing.T) {
 	c, _ := NewOpenAPIClient("test", &OpenAPIClientConfig{
 		Spec:      "http://example.com/spec.json",
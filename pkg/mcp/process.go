@@ -13,6 +13,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/gridctl/gridctl/pkg/crash"
 	"github.com/gridctl/gridctl/pkg/jsonrpc"
 )
 
@@ -33,6 +34,7 @@ type ProcessClient struct {
 	stdout  io.Reader
 	started bool
 	cancel  context.CancelFunc
+	exited  chan struct{} // closed once the process has been reaped
 
 	// Reconnection serialization
 	reconnMu sync.Mutex
@@ -120,18 +122,49 @@ func (c *ProcessClient) Connect(ctx context.Context) error {
 	// Start reading responses and stderr with cancellation
 	readerCtx, cancel := context.WithCancel(context.Background())
 	c.cancel = cancel
-	go c.readResponses(readerCtx, c.stdout)
+	var readers sync.WaitGroup
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		c.readResponses(readerCtx, c.stdout)
+	}()
 	if stderr != nil {
-		go c.readStderr(readerCtx, stderr)
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			c.readStderr(readerCtx, stderr)
+		}()
 	}
+	c.exited = make(chan struct{})
+	go c.waitProcess(readerCtx, c.cmd, &readers, c.exited)
 
 	return nil
 }
 
+// waitProcess reaps the process once its output has been read and logs an
+// exit gridctl did not ask for, so a server that dies (or, over SSH, a
+// dropped connection) leaves a record in the server's logs.
+func (c *ProcessClient) waitProcess(ctx context.Context, cmd *exec.Cmd, readers *sync.WaitGroup, exited chan struct{}) {
+	defer crash.Recover("process-wait", "server", c.name)
+	// Wait closes the pipes, so let the readers drain them first.
+	readers.Wait()
+	err := cmd.Wait()
+	close(exited)
+	if ctx.Err() != nil {
+		return // stopped by Close
+	}
+	attrs := []any{"exit_code", cmd.ProcessState.ExitCode()}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	c.logger.Error("server process exited", attrs...)
+}
+
 // readResponses reads JSON-RPC responses from stdout.
 // stdout is passed as a parameter to capture the value at goroutine launch
 // time (under procMu), avoiding a data race with Reconnect clearing c.stdout.
 func (c *ProcessClient) readResponses(ctx context.Context, stdout io.Reader) {
+	defer crash.Recover("process-reader", "server", c.name)
 	defer c.drainPendingRequests()
 
 	scanner := bufio.NewScanner(stdout)
@@ -157,17 +190,28 @@ func (c *ProcessClient) readResponses(ctx context.Context, stdout io.Reader) {
 			continue
 		}
 
+		// Notifications carry a method and no id
+		if resp.ID == nil {
+			if method, params, ok := parseNotification(line); ok {
+				c.handleNotification(method, params)
+			}
+			continue
+		}
+
+		// Server-to-client requests carry a method and an id
+		if req, ok := serverRequest(line); ok {
+			go c.replyToServer(ctx, req, c.sendStdio)
+			continue
+		}
+
 		// Route response to waiting caller
-		if resp.ID != nil {
-			var id int64
-			if err := json.Unmarshal(*resp.ID, &id); err == nil {
-				c.responsesMu.Lock()
-				if ch, ok := c.responses[id]; ok {
-					ch <- &resp
-					delete(c.responses, id)
-				}
-				c.responsesMu.Unlock()
+		if id, ok := responseID(resp.ID); ok {
+			c.responsesMu.Lock()
+			if ch, ok := c.responses[id]; ok {
+				ch <- &resp
+				delete(c.responses, id)
 			}
+			c.responsesMu.Unlock()
 		}
 	}
 }
@@ -192,6 +236,7 @@ func (c *ProcessClient) drainPendingRequests() {
 
 // readStderr reads lines from the process stderr and logs them.
 func (c *ProcessClient) readStderr(ctx context.Context, r io.Reader) {
+	defer crash.Recover("process-stderr", "server", c.name)
 	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		select {
@@ -246,8 +291,8 @@ func (c *ProcessClient) call(ctx context.Context, method string, params any, res
 	}
 
 	// Wait for response with timeout to prevent hanging on dead processes
-	timeout := time.NewTimer(DefaultRequestTimeout)
-	defer timeout.Stop()
+	timeout, stopTimeout := requestTimer(ctx)
+	defer stopTimeout()
 
 	select {
 	case <-ctx.Done():
@@ -255,7 +300,7 @@ func (c *ProcessClient) call(ctx context.Context, method string, params any, res
 		delete(c.responses, id)
 		c.responsesMu.Unlock()
 		return ctx.Err()
-	case <-timeout.C:
+	case <-timeout:
 		c.responsesMu.Lock()
 		delete(c.responses, id)
 		c.responsesMu.Unlock()
@@ -286,8 +331,9 @@ func (c *ProcessClient) send(_ context.Context, method string, params any) error
 	return c.sendStdio(req)
 }
 
-// sendStdio writes a request to stdin.
-func (c *ProcessClient) sendStdio(req jsonrpc.Request) error {
+// sendStdio writes a JSON-RPC message (a request, or a response to a
+// server-sent request) to stdin.
+func (c *ProcessClient) sendStdio(msg any) error {
 	c.procMu.Lock()
 	defer c.procMu.Unlock()
 
@@ -295,7 +341,7 @@ func (c *ProcessClient) sendStdio(req jsonrpc.Request) error {
 		return fmt.Errorf("not connected")
 	}
 
-	data, err := json.Marshal(req)
+	data, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("marshaling request: %w", err)
 	}
@@ -327,6 +373,7 @@ func (c *ProcessClient) Reconnect(ctx context.Context) error {
 	c.cmd = nil
 	c.stdin = nil
 	c.stdout = nil
+	c.started = false
 	c.procMu.Unlock()
 
 	// Re-start the process
@@ -348,6 +395,13 @@ func (c *ProcessClient) Reconnect(ctx context.Context) error {
 	return nil
 }
 
+// Disconnected returns a channel closed when the current process exits.
+func (c *ProcessClient) Disconnected() <-chan struct{} {
+	c.procMu.Lock()
+	defer c.procMu.Unlock()
+	return c.exited
+}
+
 // PID returns the operating-system process id of the running child. Returns 0
 // when the process has not been started (or has exited and been cleared).
 func (c *ProcessClient) PID() int {
@@ -412,19 +466,19 @@ func (c *ProcessClient) Close() error {
 	}
 
 	// Wait with timeout
-	done := make(chan error, 1)
-	go func() {
-		done <- c.cmd.Wait()
-	}()
-
 	select {
-	case <-done:
+	case <-c.exited:
 		// Process exited gracefully
 		return nil
 	case <-time.After(processKillGracePeriod):
-		// Force kill - ignore error since process may have already exited
+		// Force kill - ignore error since process may have already exited.
+		// A child that inherited the output pipes can keep them open after
+		// the kill, so don't wait on the reaper indefinitely.
 		_ = c.cmd.Process.Kill()
-		<-done
+		select {
+		case <-c.exited:
+		case <-time.After(processKillGracePeriod):
+		}
 		return nil
 	}
 }
@@ -424,6 +424,61 @@ func TestProcessClient_StartAndClose(t *testing.T) {
 	}
 }
 
+func TestProcessClient_LogsUnexpectedExit(t *testing.T) {
+	buffer := logging.NewLogBuffer(10)
+	logger := slog.New(logging.NewBufferHandler(buffer, nil)).With("server", "remote")
+
+	client := NewProcessClient("remote", []string{"sh", "-c", "echo 'connection refused' >&2; exit 255"}, "", nil)
+	client.SetLogger(logger)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	var exit *logging.BufferedEntry
+	deadline := time.Now().Add(2 * time.Second)
+	for exit == nil && time.Now().Before(deadline) {
+		for _, e := range buffer.GetRecent(10) {
+			if e.Message == "server process exited" {
+				exit = &e
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if exit == nil {
+		t.Fatal("expected an exit entry")
+	}
+	if exit.Level != "ERROR" || fmt.Sprint(exit.Attrs["exit_code"]) != "255" || exit.Attrs["server"] != "remote" {
+		t.Errorf("exit entry = %+v", *exit)
+	}
+
+	// The last stderr line is read before the pipes are closed.
+	entries := buffer.GetRecent(10)
+	if entries[0].Message != "server stderr" || entries[0].Attrs["output"] != "connection refused" {
+		t.Errorf("expected the stderr line before the exit, got %+v", entries[0])
+	}
+
+	if err := client.Ping(context.Background()); err == nil {
+		t.Error("Ping should fail once the process has exited")
+	}
+}
+
+func TestProcessClient_CloseDoesNotLogExit(t *testing.T) {
+	buffer := logging.NewLogBuffer(10)
+	client := NewProcessClient("test", []string{"cat"}, "", nil)
+	client.SetLogger(slog.New(logging.NewBufferHandler(buffer, nil)))
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	for _, e := range buffer.GetRecent(10) {
+		if e.Message == "server process exited" {
+			t.Errorf("a requested stop should not be logged as an exit: %+v", e)
+		}
+	}
+}
+
 func TestProcessClient_CallTimeout(t *testing.T) {
 	// Create pipes that simulate a process that accepts input but never responds
 	stdinR, stdinW := io.Pipe()
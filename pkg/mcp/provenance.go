@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"maps"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ProvenanceMetaKey is the result _meta key provenance is attached under.
+const ProvenanceMetaKey = "gridctl/provenance"
+
+// Provenance records where a tool result came from, so systems ingesting
+// agent output can trace each piece of data back to its origin.
+type Provenance struct {
+	Server    string    `json:"server"`
+	Tool      string    `json:"tool"`
+	Timestamp time.Time `json:"timestamp"`
+	GatewayID string    `json:"gatewayId"`
+	// RunID is the MCP session the call arrived on: one agent run.
+	RunID string `json:"runId,omitempty"`
+	// CallID is unique per call.
+	CallID  string `json:"callId"`
+	Client  string `json:"client,omitempty"`
+	TraceID string `json:"traceId,omitempty"`
+}
+
+// ProvenanceInterceptor is a ToolCallInterceptor that stamps every
+// dispatched tool result with a Provenance record in its _meta. Results
+// answered by another interceptor without dispatching are left alone: no
+// server produced them.
+type ProvenanceInterceptor struct {
+	gatewayID string
+	now       func() time.Time
+}
+
+// NewProvenanceInterceptor returns a provenance interceptor identifying this
+// gateway as gatewayID.
+func NewProvenanceInterceptor(gatewayID string) *ProvenanceInterceptor {
+	return &ProvenanceInterceptor{gatewayID: gatewayID, now: time.Now}
+}
+
+// Name implements ToolCallInterceptor.
+func (p *ProvenanceInterceptor) Name() string { return "provenance" }
+
+// Before implements ToolCallInterceptor; it never short-circuits.
+func (p *ProvenanceInterceptor) Before(context.Context, *InterceptedCall) *ToolCallResult { return nil }
+
+// After implements ToolCallInterceptor.
+func (p *ProvenanceInterceptor) After(ctx context.Context, call *InterceptedCall, outcome *ToolCallOutcome) {
+	if outcome.Result == nil || outcome.ShortCircuitedBy != "" {
+		return
+	}
+	_, tool, err := ParsePrefixedTool(call.PrefixedTool)
+	if err != nil {
+		tool = call.PrefixedTool
+	}
+	prov := Provenance{
+		Server:    call.ServerName,
+		Tool:      tool,
+		Timestamp: p.now().UTC(),
+		GatewayID: p.gatewayID,
+		RunID:     SessionIDFromContext(ctx),
+		CallID:    newCallID(),
+		Client:    call.ClientAccessID,
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		prov.TraceID = sc.TraceID().String()
+	}
+
+	// Copy rather than mutate: the result may be shared with a cache.
+	result := *outcome.Result
+	result.Meta = maps.Clone(result.Meta)
+	if result.Meta == nil {
+		result.Meta = make(map[string]any, 1)
+	}
+	result.Meta[ProvenanceMetaKey] = prov
+	outcome.Result = &result
+}
+
+func newCallID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var _ ToolCallInterceptor = (*ProvenanceInterceptor)(nil)
+
+func TestProvenanceInterceptor_StampsResultMeta(t *testing.T) {
+	g := newInterceptorTestGateway(t, 1)
+	prov := NewProvenanceInterceptor("prod-eu")
+	fixed := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	prov.now = func() time.Time { return fixed }
+	g.SetToolCallInterceptors([]ToolCallInterceptor{prov})
+
+	ctx := WithSessionID(WithClientAccessID(context.Background(), "ci-bot"), "sess-1")
+	result, err := g.HandleToolsCall(ctx, ToolCallParams{Name: "github__search", Arguments: map[string]any{"q": "x"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var wire struct {
+		Meta map[string]Provenance `json:"_meta"`
+	}
+	if err := json.Unmarshal(raw, &wire); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	got, ok := wire.Meta[ProvenanceMetaKey]
+	if !ok {
+		t.Fatalf("result _meta lacks %q: %s", ProvenanceMetaKey, raw)
+	}
+	if got.Server != "github" || got.Tool != "search" || got.GatewayID != "prod-eu" ||
+		got.RunID != "sess-1" || got.Client != "ci-bot" || !got.Timestamp.Equal(fixed) {
+		t.Errorf("unexpected provenance: %+v", got)
+	}
+	if got.CallID == "" {
+		t.Error("expected a call id")
+	}
+}
+
+func TestProvenanceInterceptor_PreservesMetaAndSkipsShortCircuit(t *testing.T) {
+	prov := NewProvenanceInterceptor("gw")
+	call := &InterceptedCall{GateCall: GateCall{PrefixedTool: "github__search", ServerName: "github"}}
+
+	original := &ToolCallResult{Meta: map[string]any{"other": 1}}
+	outcome := &ToolCallOutcome{Result: original}
+	prov.After(context.Background(), call, outcome)
+	if _, ok := outcome.Result.Meta[ProvenanceMetaKey]; !ok {
+		t.Fatal("expected provenance on the result")
+	}
+	if outcome.Result.Meta["other"] != 1 {
+		t.Error("existing _meta keys must be kept")
+	}
+	if _, ok := original.Meta[ProvenanceMetaKey]; ok {
+		t.Error("the original result must not be mutated")
+	}
+
+	answered := &ToolCallResult{}
+	outcome = &ToolCallOutcome{Result: answered, ShortCircuitedBy: "cache"}
+	prov.After(context.Background(), call, outcome)
+	if outcome.Result.Meta != nil {
+		t.Error("short-circuited results must not be stamped")
+	}
+}
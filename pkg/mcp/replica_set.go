@@ -111,6 +111,10 @@ type Replica struct {
 	healthy  atomic.Bool
 	inFlight atomic.Int64
 	restart  *backoffState
+	restarts atomic.Uint64 // successful automatic reconnects
+
+	checkMu    sync.Mutex  // serializes health checks and reconnects
+	supervised atomic.Bool // a connection supervisor is watching this replica
 
 	startedMu sync.Mutex
 	startedAt time.Time
@@ -140,6 +144,10 @@ func (r *Replica) InFlight() int64 { return r.inFlight.Load() }
 // Restart returns the replica's restart-backoff state. Never nil.
 func (r *Replica) Restart() *backoffState { return r.restart }
 
+// Restarts returns how many times the replica has been reconnected after a
+// failure since it was added.
+func (r *Replica) Restarts() uint64 { return r.restarts.Load() }
+
 // StartedAt returns the time this replica was initialized or most recently
 // restarted. Zero value means the replica has not yet started.
 func (r *Replica) StartedAt() time.Time {
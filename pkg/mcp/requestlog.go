@@ -0,0 +1,142 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+	"github.com/gridctl/gridctl/pkg/logging"
+)
+
+// RecordedRequest is one inbound JSON-RPC request as written by
+// RequestLogger: a line of the session's JSONL file. Params are redacted
+// before they are written.
+type RecordedRequest struct {
+	Time    time.Time        `json:"time"`
+	Session string           `json:"session"`
+	Method  string           `json:"method"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+// RequestLogger records every inbound MCP request, one JSONL file per
+// session under dir (<dir>/<session-id>.jsonl), so a session can be
+// replayed against a gateway after an upgrade. Values under secret-like
+// keys and secret-looking strings in params are redacted on the way in.
+// Write failures are logged once and otherwise ignored: recording must
+// never fail a request.
+type RequestLogger struct {
+	dir    string
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	warned bool
+}
+
+// NewRequestLogger creates a recorder writing under dir. The directory is
+// created on first write.
+func NewRequestLogger(dir string) *RequestLogger {
+	return &RequestLogger{dir: dir, logger: logging.NewDiscardLogger()}
+}
+
+// SetLogger sets the logger used to report write failures.
+func (l *RequestLogger) SetLogger(logger *slog.Logger) {
+	if logger != nil {
+		l.logger = logger
+	}
+}
+
+// Dir returns the directory session files are written to.
+func (l *RequestLogger) Dir() string { return l.dir }
+
+// Record appends req to the session's file.
+func (l *RequestLogger) Record(sessionID string, req *jsonrpc.Request) {
+	if l == nil || sessionID == "" || req == nil {
+		return
+	}
+	rec := RecordedRequest{
+		Time:    time.Now().UTC(),
+		Session: sessionID,
+		Method:  req.Method,
+		ID:      req.ID,
+		Params:  redactParams(req.Params),
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.appendLine(sessionID, append(line, '\n')); err != nil && !l.warned {
+		l.warned = true
+		l.logger.Warn("request log write failed; further failures are not reported", "dir", l.dir, "error", err)
+	}
+}
+
+func (l *RequestLogger) appendLine(sessionID string, line []byte) error {
+	if err := os.MkdirAll(l.dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.sessionPath(sessionID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// sessionPath maps a session ID to its file. Session IDs are
+// gateway-issued, but path separators are stripped regardless.
+func (l *RequestLogger) sessionPath(sessionID string) string {
+	safe := strings.NewReplacer("/", "_", `\`, "_", "..", "_").Replace(sessionID)
+	return filepath.Join(l.dir, safe+".jsonl")
+}
+
+// redactParams redacts decoded params; params that do not decode are
+// redacted as text.
+func redactParams(params json.RawMessage) json.RawMessage {
+	if len(params) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(params, &v); err != nil {
+		return json.RawMessage(fmt.Sprintf("%q", logging.RedactString(string(params))))
+	}
+	out, err := json.Marshal(logging.RedactValue(v))
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// ReadRecordedRequests parses a session file written by RequestLogger.
+// Blank lines are skipped; a malformed line is an error naming its line
+// number.
+func ReadRecordedRequests(data []byte) ([]RecordedRequest, error) {
+	var out []RecordedRequest
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var rec RecordedRequest
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if rec.Method == "" {
+			return nil, fmt.Errorf("line %d: missing method", i+1)
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
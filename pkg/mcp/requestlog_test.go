@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRequestLogger_RecordsPerSessionRedacted(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "requests")
+	srv := NewStreamableHTTPServer(NewGateway(), nil)
+	srv.SetRequestLogger(NewRequestLogger(dir))
+
+	sessionID := initializeStreamable(t, srv)
+	streamablePost(t, srv, sessionID, "ping", nil)
+	streamablePost(t, srv, sessionID, "tools/call", map[string]any{
+		"name":      "github__search",
+		"arguments": map[string]any{"query": "open issues", "api_token": "ghp_secret"},
+	})
+
+	data, err := os.ReadFile(filepath.Join(dir, sessionID+".jsonl"))
+	if err != nil {
+		t.Fatalf("session file: %v", err)
+	}
+	if strings.Contains(string(data), "ghp_secret") {
+		t.Errorf("secret argument was recorded:\n%s", data)
+	}
+
+	records, err := ReadRecordedRequests(data)
+	if err != nil {
+		t.Fatalf("ReadRecordedRequests: %v", err)
+	}
+	var methods []string
+	for _, r := range records {
+		methods = append(methods, r.Method)
+		if r.Session != sessionID {
+			t.Errorf("record session = %q, want %q", r.Session, sessionID)
+		}
+	}
+	if got := strings.Join(methods, ","); got != "initialize,ping,tools/call" {
+		t.Errorf("methods = %s", got)
+	}
+	if !strings.Contains(string(records[2].Params), `"query":"open issues"`) {
+		t.Errorf("non-secret argument lost: %s", records[2].Params)
+	}
+}
+
+func TestReadRecordedRequests_Malformed(t *testing.T) {
+	_, err := ReadRecordedRequests([]byte("{\"method\":\"ping\"}\n\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("err = %v, want a line 3 error", err)
+	}
+}
@@ -0,0 +1,197 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/crash"
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+)
+
+// Roots (MCP spec): a client tells servers which filesystem locations they
+// may operate on. The gateway is a client to every downstream server and a
+// server to every connected client, so it collects the roots its clients
+// report, answers roots/list from downstream servers with their union, and
+// narrows that per server to the stack's `roots:` restriction.
+const (
+	MethodRootsList              = "roots/list"
+	NotificationRootsListChanged = "notifications/roots/list_changed"
+)
+
+// rootsRequestTimeout bounds a roots/list sent to a connected client, which
+// only answers once it has opened its SSE stream.
+const rootsRequestTimeout = 30 * time.Second
+
+// answerRequests routes a client's server-sent requests to the gateway.
+// allowed is the server's configured roots restriction; sampling is whether
+// the server opted into sampling passthrough. Clients without a request
+// channel (OpenAPI) are skipped.
+func (g *Gateway) answerRequests(name string, allowed []string, sampling bool, client AgentClient) {
+	dst, ok := client.(interface{ SetRequestHandler(RequestHandler) })
+	if !ok {
+		return
+	}
+	dst.SetRequestHandler(func(ctx context.Context, method string, params json.RawMessage) (any, *jsonrpc.Error) {
+		switch method {
+		case MethodRootsList:
+			return RootsListResult{Roots: g.RootsFor(allowed)}, nil
+		case MethodElicitationCreate:
+			return g.forwardElicitation(ctx, name, client, params)
+		case MethodSamplingCreateMessage:
+			if !sampling {
+				g.logger.Info("sampling rejected: server has not opted in", "server", name)
+				return nil, &jsonrpc.Error{Code: jsonrpc.MethodNotFound, Message: "sampling is not enabled for this server (set sampling: true in the stack)"}
+			}
+			return g.forwardSampling(ctx, name, client, params)
+		case "ping":
+			return struct{}{}, nil
+		default:
+			g.logger.Debug("rejecting unsupported server request", "server", name, "method", method)
+			return nil, &jsonrpc.Error{Code: jsonrpc.MethodNotFound, Message: fmt.Sprintf("Unknown method: %s", method)}
+		}
+	})
+}
+
+// RootsFor returns the roots a downstream server sees given its configured
+// restriction (absolute paths or file:// URIs). Without a restriction it sees
+// every client root; with one, client roots are narrowed to it, and when no
+// client reported roots the restriction itself is the list.
+func (g *Gateway) RootsFor(allowed []string) []Root {
+	return restrictRoots(g.sessions.Roots(), allowed)
+}
+
+// SetClientRoots records the roots a connected client reported and tells
+// downstream servers when the union changes.
+func (g *Gateway) SetClientRoots(sessionID string, roots []Root) {
+	if g.sessions.SetRoots(sessionID, roots) {
+		g.clientRootsChanged()
+	}
+}
+
+// clientRootsChanged sends notifications/roots/list_changed to every
+// downstream server when the union of client roots differs from the last
+// one announced. Call it after sessions come and go.
+func (g *Gateway) clientRootsChanged() {
+	key := rootsKey(g.sessions.Roots())
+	g.notifyMu.Lock()
+	changed := key != g.announcedRoots
+	g.announcedRoots = key
+	g.notifyMu.Unlock()
+	if !changed {
+		return
+	}
+
+	for _, set := range g.router.ReplicaSets() {
+		for _, replica := range set.Replicas() {
+			n, ok := replica.Client().(interface{ NotifyRootsChanged(context.Context) error })
+			if !ok {
+				continue
+			}
+			go func(name string) {
+				defer crash.Recover("roots-list-changed", "server", name)
+				ctx, cancel := context.WithTimeout(context.Background(), notificationRefreshTimeout)
+				defer cancel()
+				if err := n.NotifyRootsChanged(ctx); err != nil {
+					g.logger.Debug("roots list_changed notification failed", "server", name, "error", err)
+				}
+			}(set.Name())
+		}
+	}
+}
+
+// NotifyRootsChanged tells the server its roots changed. It is a no-op for
+// clients that did not declare the roots capability.
+func (r *RPCClient) NotifyRootsChanged(ctx context.Context) error {
+	if r.requests.Load() == nil || !r.IsInitialized() {
+		return nil
+	}
+	return r.transport.send(ctx, NotificationRootsListChanged, nil)
+}
+
+// restrictRoots narrows client roots to the allowed locations: a client root
+// inside an allowed one is kept, and an allowed root inside a client root
+// replaces it. The result is sorted by URI and never nil.
+func restrictRoots(client []Root, allowed []string) []Root {
+	if len(allowed) == 0 {
+		return dedupeRoots(client)
+	}
+	limits := make([]Root, 0, len(allowed))
+	for _, a := range allowed {
+		limits = append(limits, RootForPath(a))
+	}
+	if len(client) == 0 {
+		return dedupeRoots(limits)
+	}
+
+	var out []Root
+	for _, c := range client {
+		for _, l := range limits {
+			switch {
+			case rootWithin(c.URI, l.URI):
+				out = append(out, c)
+			case rootWithin(l.URI, c.URI):
+				out = append(out, l)
+			}
+		}
+	}
+	return dedupeRoots(out)
+}
+
+// RootForPath converts an absolute path or file:// URI to a Root.
+func RootForPath(p string) Root {
+	if strings.HasPrefix(p, "file://") {
+		return Root{URI: p}
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(p)}
+	return Root{URI: u.String()}
+}
+
+// rootWithin reports whether child is parent or lies beneath it. Only
+// file:// URIs nest; anything else must match exactly.
+func rootWithin(child, parent string) bool {
+	if child == parent {
+		return true
+	}
+	c, err := url.Parse(child)
+	if err != nil || c.Scheme != "file" {
+		return false
+	}
+	p, err := url.Parse(parent)
+	if err != nil || p.Scheme != "file" || c.Host != p.Host {
+		return false
+	}
+	cp, pp := path.Clean("/"+c.Path), path.Clean("/"+p.Path)
+	return cp == pp || pp == "/" || strings.HasPrefix(cp, pp+"/")
+}
+
+// dedupeRoots drops repeated URIs, keeping the first name seen, and sorts
+// by URI. The result is never nil so it encodes as an empty JSON array.
+func dedupeRoots(roots []Root) []Root {
+	seen := make(map[string]bool, len(roots))
+	out := make([]Root, 0, len(roots))
+	for _, r := range roots {
+		if seen[r.URI] {
+			continue
+		}
+		seen[r.URI] = true
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].URI < out[j].URI })
+	return out
+}
+
+// rootsKey fingerprints a sorted root list for change detection.
+func rootsKey(roots []Root) string {
+	uris := make([]string, len(roots))
+	for i, r := range roots {
+		uris[i] = r.URI
+	}
+	return strings.Join(uris, "\n")
+}
@@ -0,0 +1,158 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+)
+
+func TestRestrictRoots(t *testing.T) {
+	proj := Root{URI: "file:///home/me/proj", Name: "proj"}
+	other := Root{URI: "file:///srv/data"}
+	tests := []struct {
+		name    string
+		client  []Root
+		allowed []string
+		want    []Root
+	}{
+		{"no restriction forwards client roots", []Root{other, proj}, nil, []Root{proj, other}},
+		{"no roots at all", nil, nil, []Root{}},
+		{"restriction without client roots", nil, []string{"/srv/data", "file:///opt"}, []Root{{URI: "file:///opt"}, other}},
+		{"client root inside restriction kept", []Root{proj, other}, []string{"/home/me"}, []Root{proj}},
+		{"restriction inside client root narrows it", []Root{{URI: "file:///home/me"}}, []string{"/home/me/proj/sub"}, []Root{{URI: "file:///home/me/proj/sub"}}},
+		{"sibling prefix is not inside", []Root{{URI: "file:///home/me/project"}}, []string{"/home/me/proj"}, []Root{}},
+		{"no overlap", []Root{other}, []string{"/home"}, []Root{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := restrictRoots(tt.client, tt.allowed)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("restrictRoots() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessClient_AnswersRootsList(t *testing.T) {
+	g := NewGateway()
+	session := g.sessions.Create(ClientInfo{Name: "editor"}, "", "", MCPProtocolVersion)
+	g.SetClientRoots(session.ID, []Root{{URI: "file:///home/me/proj"}, {URI: "file:///tmp/scratch"}})
+
+	c := NewProcessClient("fs", nil, "", nil)
+	g.answerRequests("fs", []string{"/home/me"}, false, c)
+	pr, pw := io.Pipe()
+	c.stdin, c.started = pw, true
+
+	go c.readResponses(context.Background(), strings.NewReader(`{"jsonrpc":"2.0","id":5,"method":"roots/list"}`))
+
+	line := make(chan string, 1)
+	go func() {
+		s, _ := bufio.NewReader(pr).ReadString('\n')
+		line <- s
+	}()
+	var resp jsonrpc.Response
+	select {
+	case s := <-line:
+		if err := json.Unmarshal([]byte(s), &resp); err != nil {
+			t.Fatalf("decoding reply %q: %v", s, err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("roots/list was not answered")
+	}
+
+	if resp.ID == nil || string(*resp.ID) != "5" || resp.Error != nil {
+		t.Fatalf("reply = %+v, want a result for id 5", resp)
+	}
+	var result RootsListResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if want := []Root{{URI: "file:///home/me/proj"}}; !reflect.DeepEqual(result.Roots, want) {
+		t.Errorf("roots = %+v, want %+v", result.Roots, want)
+	}
+}
+
+func TestStreamable_CollectsClientRoots(t *testing.T) {
+	g := NewGateway()
+	srv := NewStreamableHTTPServer(g, nil)
+
+	sessionID := initializeWithCapabilities(t, srv, `{"roots":{"listChanged":true}}`)
+	streamablePost(t, srv, sessionID, "notifications/initialized", nil)
+
+	// The gateway asks the client for its roots over the SSE stream.
+	req := awaitClientRequest(t, srv, sessionID, MethodRootsList)
+	answerClientRequest(t, srv, sessionID, req, RootsListResult{Roots: []Root{{URI: "file:///home/me/proj", Name: "proj"}}})
+
+	want := []Root{{URI: "file:///home/me/proj", Name: "proj"}}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && len(g.RootsFor(nil)) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := g.RootsFor(nil); !reflect.DeepEqual(got, want) {
+		t.Fatalf("RootsFor(nil) = %+v, want %+v", got, want)
+	}
+
+	// Ending the session drops its roots.
+	del := httptest.NewRequest(http.MethodDelete, "/mcp", nil)
+	del.Header.Set("Mcp-Session-Id", sessionID)
+	srv.ServeHTTP(httptest.NewRecorder(), del)
+	if got := g.RootsFor(nil); len(got) != 0 {
+		t.Errorf("RootsFor(nil) after delete = %+v, want none", got)
+	}
+}
+
+// initializeWithCapabilities opens a Streamable HTTP session whose client
+// declares the given capabilities JSON.
+func initializeWithCapabilities(t *testing.T, srv *StreamableHTTPServer, caps string) string {
+	t.Helper()
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","clientInfo":{"name":"editor","version":"1"},"capabilities":` + caps + `}}`
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body)))
+	sessionID := w.Header().Get("Mcp-Session-Id")
+	if sessionID == "" {
+		t.Fatalf("initialize failed: %d %s", w.Code, w.Body.String())
+	}
+	return sessionID
+}
+
+// awaitClientRequest waits for the gateway to queue a request with the given
+// method on the session's SSE stream.
+func awaitClientRequest(t *testing.T, srv *StreamableHTTPServer, sessionID, method string) jsonrpc.Request {
+	t.Helper()
+	srv.mu.RLock()
+	session := srv.sessions[sessionID]
+	srv.mu.RUnlock()
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		for _, evt := range session.eventsAfter(0) {
+			var req jsonrpc.Request
+			if json.Unmarshal(evt.Data, &req) == nil && req.Method == method && req.ID != nil {
+				return req
+			}
+		}
+	}
+	t.Fatalf("no %s request queued for session %s", method, sessionID)
+	return jsonrpc.Request{}
+}
+
+// answerClientRequest POSTs the client's result for a gateway request.
+func answerClientRequest(t *testing.T, srv *StreamableHTTPServer, sessionID string, req jsonrpc.Request, result any) {
+	t.Helper()
+	reply, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	post := httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewReader(reply))
+	post.Header.Set("Mcp-Session-Id", sessionID)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, post)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("posting %s result: expected 202, got %d", req.Method, w.Code)
+	}
+}
@@ -1,10 +1,13 @@
 package mcp
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
 )
 
 // Router routes tool calls to the appropriate MCP server.
@@ -17,6 +20,12 @@ type Router struct {
 	mu    sync.RWMutex
 	sets  map[string]*ReplicaSet // serverName -> replica set
 	tools map[string]string      // prefixedToolName -> serverName
+
+	// Tool schema validation, recomputed on every RefreshTools.
+	schemaRepair    bool
+	schemaIssues    map[string][]SchemaIssue              // serverName -> issues
+	repairedSchemas map[string]map[string]json.RawMessage // serverName -> tool -> fixed inputSchema
+	argSchemas      map[string]*jsonschema.Schema         // prefixedToolName -> compiled inputSchema
 }
 
 // NewRouter creates a new tool router.
@@ -47,11 +56,14 @@ func (r *Router) RemoveClient(name string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	delete(r.sets, name)
+	delete(r.schemaIssues, name)
+	delete(r.repairedSchemas, name)
 
 	// Remove tools for this server
 	for tool, server := range r.tools {
 		if server == name {
 			delete(r.tools, tool)
+			delete(r.argSchemas, tool)
 		}
 	}
 }
@@ -133,20 +145,76 @@ func toolsOf(set *ReplicaSet) []Tool {
 	return tools
 }
 
-// RefreshTools updates the tool registry from all servers.
+// SetSchemaRepair enables auto-repair of trivially broken tool input
+// schemas (see RepairToolSchema). Takes effect on the next RefreshTools.
+func (r *Router) SetSchemaRepair(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemaRepair = enabled
+}
+
+// SchemaIssues returns the tool schema problems found for the named server
+// at the last RefreshTools, or nil when every schema is valid.
+func (r *Router) SchemaIssues(name string) []SchemaIssue {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.schemaIssues[name]
+}
+
+// RefreshTools updates the tool registry from all servers and revalidates
+// every tool's input schema.
 func (r *Router) RefreshTools() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Clear existing tool mappings
 	r.tools = make(map[string]string)
+	r.schemaIssues = make(map[string][]SchemaIssue)
+	r.repairedSchemas = make(map[string]map[string]json.RawMessage)
+	r.argSchemas = make(map[string]*jsonschema.Schema)
 
 	for name, set := range r.sets {
-		for _, tool := range toolsOf(set) {
+		tools := toolsOf(set)
+		for _, tool := range tools {
 			prefixedName := PrefixTool(name, tool.Name)
 			r.tools[prefixedName] = name
 		}
+		check := checkToolSchemas(tools, r.schemaRepair)
+		if len(check.issues) > 0 {
+			r.schemaIssues[name] = check.issues
+		}
+		if len(check.repaired) > 0 {
+			r.repairedSchemas[name] = check.repaired
+		}
+		for tool, sch := range check.compiled {
+			r.argSchemas[PrefixTool(name, tool)] = sch
+		}
+	}
+}
+
+// ValidateArguments checks tools/call arguments against the tool's
+// advertised input schema and returns an *ArgumentValidationError when they
+// do not conform. Unknown tools and tools without a usable schema pass, so
+// the downstream server stays the authority for anything the gateway
+// cannot judge.
+func (r *Router) ValidateArguments(prefixedName string, args map[string]any) error {
+	r.mu.RLock()
+	sch := r.argSchemas[prefixedName]
+	r.mu.RUnlock()
+	if sch == nil {
+		return nil
+	}
+	return validateArguments(sch, prefixedName, args)
+}
+
+// inputSchemaOf returns the schema to advertise for a tool: the repaired
+// one when auto-repair fixed it, otherwise the server's own. Callers hold
+// r.mu.
+func (r *Router) inputSchemaOf(server string, tool Tool) json.RawMessage {
+	if fixed, ok := r.repairedSchemas[server][tool.Name]; ok {
+		return fixed
 	}
+	return tool.InputSchema
 }
 
 // HasTool reports whether a prefixed name routes to a live aggregated tool.
@@ -178,7 +246,7 @@ func (r *Router) AggregatedTools() []Tool {
 				Name:         prefixedName,
 				Title:        prefixedName,
 				Description:  fmt.Sprintf("MCP server: %s. Call using the exact tool name %q. %s", name, prefixedName, tool.Description),
-				InputSchema:  tool.InputSchema,
+				InputSchema:  r.inputSchemaOf(name, tool),
 				OutputSchema: tool.OutputSchema,
 				Annotations:  tool.Annotations,
 			}
@@ -210,7 +278,7 @@ func (r *Router) CatalogTools() []Tool {
 				Name:         PrefixTool(name, tool.Name),
 				Title:        tool.Title,
 				Description:  tool.Description,
-				InputSchema:  tool.InputSchema,
+				InputSchema:  r.inputSchemaOf(name, tool),
 				OutputSchema: tool.OutputSchema,
 				Annotations:  tool.Annotations,
 			})
@@ -0,0 +1,26 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+)
+
+// MethodSamplingCreateMessage is the request a server sends when it wants
+// the client's LLM to generate a message (MCP spec, server -> client).
+const MethodSamplingCreateMessage = "sampling/createMessage"
+
+// ErrSamplingUnsupported is returned to a downstream server whose sampling
+// request came from a call made by a client that did not declare the
+// sampling capability.
+var ErrSamplingUnsupported = errors.New("the calling client does not support sampling")
+
+// forwardSampling relays a downstream server's sampling/createMessage to the
+// client session whose tool call triggered it and returns the client's
+// completion unchanged. Only servers that opted in with `sampling: true`
+// are offered the capability, and the client decides whether to run it.
+func (g *Gateway) forwardSampling(ctx context.Context, name string, client AgentClient, params json.RawMessage) (any, *jsonrpc.Error) {
+	return g.forwardToCaller(ctx, name, client, MethodSamplingCreateMessage, "sampling request", ErrSamplingUnsupported, params)
+}
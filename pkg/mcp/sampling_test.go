@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+)
+
+func TestGateway_ForwardSampling_RoutesToCallingSession(t *testing.T) {
+	g := NewGateway()
+	srv := NewStreamableHTTPServer(g, nil)
+	sessionID := initializeWithCapabilities(t, srv, `{"sampling":{}}`)
+
+	params := json.RawMessage(`{"messages":[{"role":"user","content":{"type":"text","text":"Summarize the diff"}}],"maxTokens":200}`)
+	done := make(chan elicitationOutcome, 1)
+	go func() {
+		result, err := g.forwardSampling(WithSessionID(context.Background(), sessionID), "git", nil, params)
+		done <- elicitationOutcome{result, err}
+	}()
+
+	req := awaitClientRequest(t, srv, sessionID, MethodSamplingCreateMessage)
+	if string(req.Params) != string(params) {
+		t.Errorf("forwarded params = %s, want %s", req.Params, params)
+	}
+	answerClientRequest(t, srv, sessionID, req, map[string]any{
+		"role": "assistant", "model": "claude-sonnet", "content": map[string]any{"type": "text", "text": "Two files changed."},
+	})
+
+	select {
+	case out := <-done:
+		if out.err != nil {
+			t.Fatalf("forwardSampling error: %+v", out.err)
+		}
+		raw, _ := json.Marshal(out.result)
+		if !strings.Contains(string(raw), `"Two files changed."`) {
+			t.Errorf("result = %s, want the client's completion", raw)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("forwardSampling did not return")
+	}
+}
+
+func TestGateway_ForwardSampling_ClientWithoutCapability(t *testing.T) {
+	g := NewGateway()
+	srv := NewStreamableHTTPServer(g, nil)
+	sessionID := initializeWithCapabilities(t, srv, `{"elicitation":{}}`)
+
+	_, err := g.forwardSampling(WithSessionID(context.Background(), sessionID), "git", nil, json.RawMessage(`{}`))
+	if err == nil || err.Code != jsonrpc.MethodNotFound || !strings.Contains(err.Message, "does not support sampling") {
+		t.Errorf("error = %+v, want method-not-found naming sampling support", err)
+	}
+}
+
+func TestGateway_AnswerRequests_SamplingRequiresOptIn(t *testing.T) {
+	g := NewGateway()
+	c := NewProcessClient("git", nil, "", nil)
+	g.answerRequests("git", nil, false, c)
+
+	h := c.requests.Load()
+	if h == nil {
+		t.Fatal("no request handler installed")
+	}
+	_, err := (*h)(context.Background(), MethodSamplingCreateMessage, json.RawMessage(`{}`))
+	if err == nil || err.Code != jsonrpc.MethodNotFound || !strings.Contains(err.Message, "sampling: true") {
+		t.Errorf("error = %+v, want method-not-found pointing at the opt-in", err)
+	}
+}
@@ -0,0 +1,220 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// SchemaIssue describes a downstream tool whose inputSchema is missing or
+// not a valid JSON Schema object. Broken schemas otherwise surface as
+// opaque client-side failures (clients reject the whole tools/list, or
+// the model cannot form arguments), far from the server that caused them.
+type SchemaIssue struct {
+	Tool    string `json:"tool"`
+	Problem string `json:"problem"`
+	// Repaired is true when the gateway rewrote the schema (see
+	// RepairToolSchema) and now advertises the fixed version.
+	Repaired bool `json:"repaired,omitempty"`
+}
+
+// ValidateToolSchema reports whether raw is a compilable JSON Schema that
+// describes an object, as MCP requires for tool inputs and structured
+// outputs. The error text is a short, user-facing problem description.
+func ValidateToolSchema(raw json.RawMessage) error {
+	_, err := compileToolSchema(raw)
+	return err
+}
+
+// compileToolSchema validates raw as ValidateToolSchema does and returns
+// the compiled schema for argument validation.
+func compileToolSchema(raw json.RawMessage) (*jsonschema.Schema, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, errors.New("schema is missing")
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(trimmed))
+	if err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return nil, errors.New("schema is not a JSON object")
+	}
+	if obj["type"] != "object" {
+		if _, has := obj["type"]; !has {
+			return nil, errors.New(`schema has no "type": "object"`)
+		}
+		return nil, fmt.Errorf(`schema type must be "object", got %v`, obj["type"])
+	}
+	c := jsonschema.NewCompiler()
+	c.UseLoader(noExternalRefs{})
+	if err := c.AddResource("tool.json", doc); err != nil {
+		return nil, fmt.Errorf("schema is invalid: %w", err)
+	}
+	sch, err := c.Compile("tool.json")
+	if err != nil {
+		return nil, fmt.Errorf("schema does not compile: %s", firstErrorLine(err.Error()))
+	}
+	return sch, nil
+}
+
+// noExternalRefs is the loader for downstream tool schemas. Those schemas
+// come from servers the gateway does not trust, so a $ref may only point
+// inside the schema itself: the default loader would read local files
+// (file://) on the gateway host, and fetching remote URLs would let a
+// server make the gateway issue requests on its behalf.
+type noExternalRefs struct{}
+
+func (noExternalRefs) Load(url string) (any, error) {
+	return nil, fmt.Errorf("external $ref %q is not allowed", url)
+}
+
+// RepairToolSchema fixes the trivial, unambiguous schema defects: a missing
+// or null schema becomes an empty object schema, and an object schema with
+// no "type" gains "type": "object". Anything else (wrong type, bad JSON,
+// invalid keywords) is left alone, since guessing would change meaning. It
+// returns the repaired schema and whether it changed anything.
+func RepairToolSchema(raw json.RawMessage) (json.RawMessage, bool) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return json.RawMessage(`{"type":"object"}`), true
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(trimmed, &obj); err != nil || obj == nil {
+		return raw, false
+	}
+	if _, has := obj["type"]; has {
+		return raw, false
+	}
+	obj["type"] = "object"
+	fixed, err := json.Marshal(obj)
+	if err != nil {
+		return raw, false
+	}
+	return fixed, true
+}
+
+// toolSchemaCheck is the outcome of checkToolSchemas for one server.
+type toolSchemaCheck struct {
+	issues   []SchemaIssue
+	repaired map[string]json.RawMessage    // tool -> advertised replacement
+	compiled map[string]*jsonschema.Schema // tool -> schema for argument validation
+}
+
+// checkToolSchemas validates every tool's inputSchema. With repair set,
+// trivially broken schemas are fixed on a copy and the replacement is
+// advertised instead. Tools whose schema is usable (valid or repaired) get a
+// compiled schema; the rest are skipped by argument validation.
+func checkToolSchemas(tools []Tool, repair bool) toolSchemaCheck {
+	var out toolSchemaCheck
+	for _, t := range tools {
+		sch, err := compileToolSchema(t.InputSchema)
+		if err == nil {
+			if out.compiled == nil {
+				out.compiled = make(map[string]*jsonschema.Schema)
+			}
+			out.compiled[t.Name] = sch
+			continue
+		}
+		issue := SchemaIssue{Tool: t.Name, Problem: err.Error()}
+		if repair {
+			if fixed, ok := RepairToolSchema(t.InputSchema); ok {
+				if sch, err := compileToolSchema(fixed); err == nil {
+					if out.repaired == nil {
+						out.repaired = make(map[string]json.RawMessage)
+					}
+					if out.compiled == nil {
+						out.compiled = make(map[string]*jsonschema.Schema)
+					}
+					out.repaired[t.Name] = fixed
+					out.compiled[t.Name] = sch
+					issue.Repaired = true
+				}
+			}
+		}
+		out.issues = append(out.issues, issue)
+	}
+	return out
+}
+
+// ArgumentViolation is one way a tools/call argument object fails the
+// tool's input schema.
+type ArgumentViolation struct {
+	// Path is the JSON pointer of the offending value ("" is the whole
+	// arguments object).
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ArgumentValidationError reports tools/call arguments rejected by the
+// gateway before the call reached the downstream server.
+type ArgumentValidationError struct {
+	Tool       string              `json:"tool"`
+	Violations []ArgumentViolation `json:"violations"`
+}
+
+func (e *ArgumentValidationError) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		path := v.Path
+		if path == "" {
+			path = "arguments"
+		}
+		parts = append(parts, path+": "+v.Message)
+	}
+	return fmt.Sprintf("invalid arguments for tool %q: %s", e.Tool, strings.Join(parts, "; "))
+}
+
+// validateArguments checks args against a compiled input schema. A nil
+// argument map is validated as an empty object so missing required
+// properties are still reported.
+func validateArguments(sch *jsonschema.Schema, tool string, args map[string]any) error {
+	var instance any = map[string]any{}
+	if args != nil {
+		// Round-trip through JSON so Go-typed values (ints, structs) take
+		// the same shape the downstream server would decode.
+		raw, err := json.Marshal(args)
+		if err != nil {
+			return &ArgumentValidationError{Tool: tool, Violations: []ArgumentViolation{{Message: err.Error()}}}
+		}
+		if instance, err = jsonschema.UnmarshalJSON(bytes.NewReader(raw)); err != nil {
+			return &ArgumentValidationError{Tool: tool, Violations: []ArgumentViolation{{Message: err.Error()}}}
+		}
+	}
+	err := sch.Validate(instance)
+	if err == nil {
+		return nil
+	}
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return &ArgumentValidationError{Tool: tool, Violations: []ArgumentViolation{{Message: err.Error()}}}
+	}
+	out := &ArgumentValidationError{Tool: tool}
+	collectViolations(verr.BasicOutput(), out)
+	if len(out.Violations) == 0 {
+		out.Violations = []ArgumentViolation{{Message: firstErrorLine(verr.Error())}}
+	}
+	return out
+}
+
+// collectViolations flattens the leaf errors of a basic output unit.
+func collectViolations(unit *jsonschema.OutputUnit, out *ArgumentValidationError) {
+	if unit.Error != nil && len(unit.Errors) == 0 {
+		out.Violations = append(out.Violations, ArgumentViolation{Path: unit.InstanceLocation, Message: unit.Error.String()})
+	}
+	for i := range unit.Errors {
+		collectViolations(&unit.Errors[i], out)
+	}
+}
+
+func firstErrorLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,238 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateToolSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{name: "valid", schema: `{"type":"object","properties":{"q":{"type":"string"}}}`},
+		{name: "empty object schema", schema: `{"type":"object"}`},
+		{name: "missing", schema: ``, wantErr: "schema is missing"},
+		{name: "null", schema: `null`, wantErr: "schema is missing"},
+		{name: "not json", schema: `{"type":`, wantErr: "not valid JSON"},
+		{name: "not an object", schema: `[1,2]`, wantErr: "not a JSON object"},
+		{name: "no type", schema: `{"properties":{}}`, wantErr: `no "type": "object"`},
+		{name: "wrong type", schema: `{"type":"array"}`, wantErr: `got array`},
+		{name: "local ref", schema: `{"type":"object","$defs":{"q":{"type":"string"}},"properties":{"q":{"$ref":"#/$defs/q"}}}`},
+		{name: "remote ref", schema: `{"type":"object","properties":{"a":{"$ref":"http://127.0.0.1:1/s.json"}}}`, wantErr: "not allowed"},
+		{name: "bad keyword", schema: `{"type":"object","properties":{"a":{"type":7}}}`, wantErr: "does not compile"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateToolSchema(json.RawMessage(tt.schema))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateToolSchema_FileRefNotLoaded(t *testing.T) {
+	// A readable, valid schema on the gateway host: the default loader would
+	// resolve this $ref, so the rejection proves the loader is replaced.
+	path := filepath.Join(t.TempDir(), "s.json")
+	if err := os.WriteFile(path, []byte(`{"type":"string"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ref := (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+	schema := `{"type":"object","properties":{"a":{"$ref":"` + ref + `"}}}`
+
+	err := ValidateToolSchema(json.RawMessage(schema))
+	if err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Fatalf("err = %v, want external $ref rejected", err)
+	}
+}
+
+func TestRepairToolSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   bool
+	}{
+		{name: "missing", schema: ``, want: true},
+		{name: "null", schema: `null`, want: true},
+		{name: "no type", schema: `{"properties":{"q":{"type":"string"}}}`, want: true},
+		{name: "already typed", schema: `{"type":"object"}`},
+		{name: "wrong type left alone", schema: `{"type":"array"}`},
+		{name: "bad json left alone", schema: `{"type":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed, changed := RepairToolSchema(json.RawMessage(tt.schema))
+			if changed != tt.want {
+				t.Fatalf("changed = %v, want %v", changed, tt.want)
+			}
+			if changed {
+				if err := ValidateToolSchema(fixed); err != nil {
+					t.Errorf("repaired schema still invalid: %v (%s)", err, fixed)
+				}
+			} else if string(fixed) != tt.schema {
+				t.Errorf("unrepaired schema was rewritten: %s", fixed)
+			}
+		})
+	}
+
+	fixed, _ := RepairToolSchema(json.RawMessage(`{"properties":{"q":{"type":"string"}}}`))
+	if !strings.Contains(string(fixed), `"properties"`) {
+		t.Errorf("repair dropped existing keywords: %s", fixed)
+	}
+}
+
+func TestCheckToolSchemas(t *testing.T) {
+	tools := []Tool{
+		{Name: "good", InputSchema: json.RawMessage(`{"type":"object"}`)},
+		{Name: "missing"},
+		{Name: "array", InputSchema: json.RawMessage(`{"type":"array"}`)},
+	}
+
+	check := checkToolSchemas(tools, false)
+	if len(check.issues) != 2 || check.repaired != nil {
+		t.Fatalf("issues = %+v, repaired = %v", check.issues, check.repaired)
+	}
+	for _, issue := range check.issues {
+		if issue.Repaired {
+			t.Errorf("%s marked repaired without repair enabled", issue.Tool)
+		}
+	}
+	if len(check.compiled) != 1 || check.compiled["good"] == nil {
+		t.Errorf("compiled = %v, want only the valid schema", check.compiled)
+	}
+
+	check = checkToolSchemas(tools, true)
+	if len(check.issues) != 2 {
+		t.Fatalf("issues = %+v", check.issues)
+	}
+	if !check.issues[0].Repaired || check.issues[0].Tool != "missing" {
+		t.Errorf("expected missing schema repaired, got %+v", check.issues[0])
+	}
+	if check.issues[1].Repaired {
+		t.Errorf("wrong-type schema must not be repaired: %+v", check.issues[1])
+	}
+	if _, ok := check.repaired["missing"]; !ok || len(check.repaired) != 1 {
+		t.Errorf("repaired = %v", check.repaired)
+	}
+	if check.compiled["missing"] == nil {
+		t.Error("repaired schema should be compiled for argument validation")
+	}
+}
+
+func TestRouter_ValidateArguments(t *testing.T) {
+	r := NewRouter()
+	r.AddClient(setupMockAgentClient(gomock.NewController(t), "agent", []Tool{
+		{Name: "search", InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {"query": {"type": "string"}, "limit": {"type": "integer", "minimum": 1}},
+			"required": ["query"]
+		}`)},
+		{Name: "loose"},
+	}))
+	r.RefreshTools()
+
+	tests := []struct {
+		name      string
+		tool      string
+		args      map[string]any
+		wantPaths []string
+	}{
+		{name: "valid", tool: "agent__search", args: map[string]any{"query": "x", "limit": 5}},
+		{name: "json number", tool: "agent__search", args: map[string]any{"query": "x", "limit": float64(2)}},
+		{name: "missing required", tool: "agent__search", args: nil, wantPaths: []string{""}},
+		{name: "wrong types", tool: "agent__search", args: map[string]any{"query": 7, "limit": 0}, wantPaths: []string{"/query", "/limit"}},
+		{name: "no usable schema", tool: "agent__loose", args: map[string]any{"anything": true}},
+		{name: "unknown tool", tool: "agent__nope", args: map[string]any{"query": 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.ValidateArguments(tt.tool, tt.args)
+			if len(tt.wantPaths) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			verr, ok := err.(*ArgumentValidationError)
+			if !ok {
+				t.Fatalf("err = %v (%T), want *ArgumentValidationError", err, err)
+			}
+			got := make(map[string]bool)
+			for _, v := range verr.Violations {
+				got[v.Path] = true
+			}
+			for _, p := range tt.wantPaths {
+				if !got[p] {
+					t.Errorf("missing violation at %q: %+v", p, verr.Violations)
+				}
+			}
+			if verr.Tool != tt.tool {
+				t.Errorf("tool = %q", verr.Tool)
+			}
+		})
+	}
+}
+
+func TestRouter_SchemaIssues(t *testing.T) {
+	tools := []Tool{
+		{Name: "good", InputSchema: json.RawMessage(`{"type":"object"}`)},
+		{Name: "untyped", InputSchema: json.RawMessage(`{"properties":{}}`)},
+	}
+	advertised := func(r *Router) string {
+		for _, tool := range r.AggregatedTools() {
+			if tool.Name == "agent__untyped" {
+				return string(tool.InputSchema)
+			}
+		}
+		return ""
+	}
+
+	t.Run("report only", func(t *testing.T) {
+		r := NewRouter()
+		r.AddClient(setupMockAgentClient(gomock.NewController(t), "agent", tools))
+		r.RefreshTools()
+
+		issues := r.SchemaIssues("agent")
+		if len(issues) != 1 || issues[0].Tool != "untyped" || issues[0].Repaired {
+			t.Fatalf("issues = %+v", issues)
+		}
+		if got := advertised(r); got != `{"properties":{}}` {
+			t.Errorf("schema rewritten without repair enabled: %s", got)
+		}
+
+		r.RemoveClient("agent")
+		if len(r.SchemaIssues("agent")) != 0 {
+			t.Error("issues should be cleared with the client")
+		}
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		r := NewRouter()
+		r.SetSchemaRepair(true)
+		r.AddClient(setupMockAgentClient(gomock.NewController(t), "agent", tools))
+		r.RefreshTools()
+
+		issues := r.SchemaIssues("agent")
+		if len(issues) != 1 || !issues[0].Repaired {
+			t.Fatalf("issues = %+v", issues)
+		}
+		if got := advertised(r); ValidateToolSchema(json.RawMessage(got)) != nil {
+			t.Errorf("advertised schema not repaired: %s", got)
+		}
+	})
+}
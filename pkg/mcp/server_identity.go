@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"fmt"
+	"slices"
+)
+
+// serverIdentity is what a downstream server declared at initialize: its
+// serverInfo, negotiated protocol version, and capabilities. The gateway
+// records it when a server registers (the stack was validated against that
+// server) and compares it after every reconnect, so a backend silently
+// upgraded or downgraded behind a restart is surfaced instead of discovered
+// through failing tool calls.
+type serverIdentity struct {
+	info            ServerInfo
+	protocolVersion string
+	capabilities    []string
+}
+
+// identityOf captures a client's current identity.
+func identityOf(client AgentClient) serverIdentity {
+	id := serverIdentity{
+		info:            client.ServerInfo(),
+		protocolVersion: protocolVersionOf(client),
+	}
+	if c := capabilitiesOf(client); c != nil {
+		id.capabilities = capabilityNames(*c)
+	}
+	return id
+}
+
+// capabilitiesOf returns the capabilities a client's server declared at
+// initialize, or nil for clients without an MCP handshake to report.
+func capabilitiesOf(client AgentClient) *Capabilities {
+	if cc, ok := client.(interface{ ServerCapabilities() Capabilities }); ok {
+		c := cc.ServerCapabilities()
+		return &c
+	}
+	return nil
+}
+
+// capabilityNames flattens capabilities into sorted dotted names, e.g.
+// "tools", "tools.listChanged", "resources.subscribe".
+func capabilityNames(c Capabilities) []string {
+	var names []string
+	if c.Tools != nil {
+		names = append(names, "tools")
+		if c.Tools.ListChanged {
+			names = append(names, "tools.listChanged")
+		}
+	}
+	if c.Resources != nil {
+		names = append(names, "resources")
+		if c.Resources.Subscribe {
+			names = append(names, "resources.subscribe")
+		}
+		if c.Resources.ListChanged {
+			names = append(names, "resources.listChanged")
+		}
+	}
+	if c.Prompts != nil {
+		names = append(names, "prompts")
+		if c.Prompts.ListChanged {
+			names = append(names, "prompts.listChanged")
+		}
+	}
+	if c.Completions != nil {
+		names = append(names, "completions")
+	}
+	slices.Sort(names)
+	return names
+}
+
+// changesFrom describes how id differs from baseline, one entry per change;
+// empty when they match.
+func (id serverIdentity) changesFrom(baseline serverIdentity) []string {
+	var changes []string
+	if id.info.Name != baseline.info.Name {
+		changes = append(changes, fmt.Sprintf("server name %q -> %q", baseline.info.Name, id.info.Name))
+	}
+	if id.info.Version != baseline.info.Version {
+		changes = append(changes, fmt.Sprintf("server version %q -> %q", baseline.info.Version, id.info.Version))
+	}
+	if id.protocolVersion != baseline.protocolVersion {
+		changes = append(changes, fmt.Sprintf("protocol version %q -> %q", baseline.protocolVersion, id.protocolVersion))
+	}
+	for _, c := range baseline.capabilities {
+		if !slices.Contains(id.capabilities, c) {
+			changes = append(changes, "capability removed: "+c)
+		}
+	}
+	for _, c := range id.capabilities {
+		if !slices.Contains(baseline.capabilities, c) {
+			changes = append(changes, "capability added: "+c)
+		}
+	}
+	return changes
+}
+
+// recordServerIdentity stores the identity a server registered with as the
+// baseline later reconnects are compared against, clearing any changes
+// recorded against a previous registration.
+func (g *Gateway) recordServerIdentity(name string, client AgentClient) {
+	g.identityMu.Lock()
+	defer g.identityMu.Unlock()
+	g.identities[name] = identityOf(client)
+	delete(g.identityChanges, name)
+}
+
+// checkServerIdentity compares a reconnected client against the server's
+// registration baseline and warns when the backend came back as a different
+// version or with a different capability set. Changes stay reported in
+// Status until the gateway registers the server again.
+func (g *Gateway) checkServerIdentity(name string, client AgentClient) {
+	current := identityOf(client)
+
+	g.identityMu.Lock()
+	baseline, ok := g.identities[name]
+	var changes []string
+	if ok {
+		changes = current.changesFrom(baseline)
+		if len(changes) > 0 {
+			g.identityChanges[name] = changes
+		}
+	} else {
+		g.identities[name] = current
+	}
+	g.identityMu.Unlock()
+
+	if len(changes) > 0 {
+		g.logger.Warn("MCP server changed after reconnect",
+			"server", name,
+			"changes", changes,
+			"hint", "pin the server's image or package version in stack.yaml to keep it stable across restarts")
+	}
+}
+
+// serverIdentityChanges returns the changes recorded for a server since it
+// registered; nil when none.
+func (g *Gateway) serverIdentityChanges(name string) []string {
+	g.identityMu.RLock()
+	defer g.identityMu.RUnlock()
+	return g.identityChanges[name]
+}
+
+// forgetServerIdentity drops a removed server's baseline and changes.
+func (g *Gateway) forgetServerIdentity(name string) {
+	g.identityMu.Lock()
+	defer g.identityMu.Unlock()
+	delete(g.identities, name)
+	delete(g.identityChanges, name)
+}
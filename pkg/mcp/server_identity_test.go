@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/logging"
+
+	"go.uber.org/mock/gomock"
+)
+
+// upgradingClient is a reconnectable client whose serverInfo and
+// capabilities change on Reconnect, as a backend upgraded behind a
+// container restart would.
+type upgradingClient struct {
+	reconnectableClient
+	info ServerInfo
+	caps Capabilities
+}
+
+func (c *upgradingClient) ServerInfo() ServerInfo           { return c.info }
+func (c *upgradingClient) ServerCapabilities() Capabilities { return c.caps }
+
+func TestServerIdentity_ChangesFrom(t *testing.T) {
+	baseline := serverIdentity{
+		info:            ServerInfo{Name: "github", Version: "1.2.0"},
+		protocolVersion: "2025-06-18",
+		capabilities:    capabilityNames(Capabilities{Tools: &ToolsCapability{ListChanged: true}}),
+	}
+
+	if changes := baseline.changesFrom(baseline); len(changes) != 0 {
+		t.Errorf("identical identities reported changes: %v", changes)
+	}
+
+	current := serverIdentity{
+		info:            ServerInfo{Name: "github", Version: "1.3.0"},
+		protocolVersion: "2025-06-18",
+		capabilities:    capabilityNames(Capabilities{Tools: &ToolsCapability{}, Prompts: &PromptsCapability{}}),
+	}
+	want := []string{
+		`server version "1.2.0" -> "1.3.0"`,
+		"capability removed: tools.listChanged",
+		"capability added: prompts",
+	}
+	if got := current.changesFrom(baseline); !slices.Equal(got, want) {
+		t.Errorf("changes = %q, want %q", got, want)
+	}
+}
+
+func TestGateway_Reconnect_ReportsIdentityChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	logBuffer := logging.NewLogBuffer(20)
+	g.SetLogger(slog.New(logging.NewBufferHandler(logBuffer, nil)))
+
+	client := &upgradingClient{
+		info: ServerInfo{Name: "server1", Version: "1.0.0"},
+		caps: Capabilities{Tools: &ToolsCapability{}},
+	}
+	client.reconnectableClient = reconnectableClient{
+		AgentClient: setupMockAgentClient(ctrl, "server1", []Tool{{Name: "tool1"}}),
+		pingFn:      func(ctx context.Context) error { return fmt.Errorf("connection refused") },
+		reconnectFn: func(ctx context.Context) error {
+			client.info.Version = "2.0.0"
+			client.caps.Resources = &ResourcesCapability{}
+			return nil
+		},
+	}
+	g.Router().AddClient(client)
+	g.SetServerMeta(MCPServerConfig{Name: "server1", Transport: TransportStdio})
+	g.recordServerIdentity("server1", client)
+
+	g.checkHealth(context.Background())
+
+	statuses := g.Status()
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 status, got %d", len(statuses))
+	}
+	want := []string{`server version "1.0.0" -> "2.0.0"`, "capability added: resources"}
+	if !slices.Equal(statuses[0].IdentityChanges, want) {
+		t.Errorf("IdentityChanges = %q, want %q", statuses[0].IdentityChanges, want)
+	}
+	if statuses[0].Capabilities == nil || statuses[0].Capabilities.Resources == nil {
+		t.Errorf("Capabilities = %+v, want the reconnected set", statuses[0].Capabilities)
+	}
+
+	found := false
+	for _, entry := range logBuffer.GetRecent(20) {
+		if entry.Level == "WARN" && entry.Message == "MCP server changed after reconnect" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected 'MCP server changed after reconnect' warning")
+	}
+
+	// Registering again accepts the new identity.
+	g.recordServerIdentity("server1", client)
+	if changes := g.Status()[0].IdentityChanges; len(changes) != 0 {
+		t.Errorf("IdentityChanges after re-registration = %q, want none", changes)
+	}
+}
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gridctl/gridctl/pkg/crash"
+	"github.com/gridctl/gridctl/pkg/jsonrpc"
+)
+
+// RequestHandler answers a request a downstream server sends to the gateway
+// (server -> client, e.g. roots/list). It returns the result to send back or
+// a JSON-RPC error. Transports call it off their read loop, so it may block.
+type RequestHandler func(ctx context.Context, method string, params json.RawMessage) (any, *jsonrpc.Error)
+
+// SetRequestHandler sets the handler for server-sent requests. While a
+// handler is set the client declares the roots and elicitation capabilities
+// at initialize, and sampling when enabled with SetSampling. Nil answers
+// every server request with method-not-found (the default).
+func (r *RPCClient) SetRequestHandler(h RequestHandler) {
+	if h == nil {
+		r.requests.Store(nil)
+		return
+	}
+	r.requests.Store(&h)
+}
+
+// answerServerRequest runs the request handler and builds the response to
+// send back to the server.
+func (r *RPCClient) answerServerRequest(ctx context.Context, req *jsonrpc.Request) jsonrpc.Response {
+	h := r.requests.Load()
+	if h == nil {
+		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.MethodNotFound, fmt.Sprintf("Unknown method: %s", req.Method))
+	}
+	result, rpcErr := (*h)(ctx, req.Method, req.Params)
+	if rpcErr != nil {
+		return jsonrpc.Response{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+	return jsonrpc.NewSuccessResponse(req.ID, result)
+}
+
+// replyToServer answers a server-sent request over a line-delimited stdio
+// transport. write is the transport's stdin writer.
+func (r *RPCClient) replyToServer(ctx context.Context, req *jsonrpc.Request, write func(any) error) {
+	defer crash.Recover("server-request", "server", r.name, "method", req.Method)
+	if err := write(r.answerServerRequest(ctx, req)); err != nil {
+		r.logger.Debug("failed to answer server request", "method", req.Method, "error", err)
+	}
+}
+
+// serverRequest parses a server-to-client request: a message carrying both
+// an id and a method. Responses and notifications report false.
+func serverRequest(msg []byte) (*jsonrpc.Request, bool) {
+	var req jsonrpc.Request
+	if err := json.Unmarshal(msg, &req); err != nil || req.ID == nil || req.Method == "" {
+		return nil, false
+	}
+	return &req, true
+}
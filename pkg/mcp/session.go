@@ -32,9 +32,15 @@ type Session struct {
 	// (echo of the client's requested version when supported, otherwise the
 	// latest supported version).
 	ProtocolVersion string
-	Initialized     bool
-	CreatedAt       time.Time
-	LastSeen        time.Time
+	// Features is what the client can handle, from its protocol version and
+	// the capabilities it declared at initialize (see ClientFeatures).
+	Features ClientFeatures
+	// Roots are the filesystem roots the client reported via roots/list;
+	// empty until it answers, and for clients without the roots capability.
+	Roots       []Root
+	Initialized bool
+	CreatedAt   time.Time
+	LastSeen    time.Time
 }
 
 // SessionManager manages client sessions.
@@ -124,6 +130,29 @@ func (m *SessionManager) Touch(id string) {
 	}
 }
 
+// SetRoots records the roots a session's client reported. It returns false
+// when the session no longer exists.
+func (m *SessionManager) SetRoots(id string, roots []Root) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if ok {
+		s.Roots = roots
+	}
+	return ok
+}
+
+// Roots returns the union of every session's roots, sorted by URI.
+func (m *SessionManager) Roots() []Root {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var all []Root
+	for _, s := range m.sessions {
+		all = append(all, s.Roots...)
+	}
+	return dedupeRoots(all)
+}
+
 // Delete removes a session.
 func (m *SessionManager) Delete(id string) {
 	m.mu.Lock()
@@ -32,8 +32,9 @@ func (s *SSEServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Send a single negotiation event directing the client to the Streamable HTTP endpoint.
 	// Clients should POST an initialize request to /mcp to start a new session.
+	// Behind a reverse proxy the path carries the proxy's X-Forwarded-Prefix.
 	fmt.Fprint(w, "event: endpoint\n")
-	fmt.Fprint(w, "data: POST /mcp\n\n")
+	fmt.Fprintf(w, "data: POST %s/mcp\n\n", ForwardedPrefix(r))
 	flusher.Flush()
 }
 
@@ -43,6 +43,19 @@ func TestSSEServer_NegotiationEvent(t *testing.T) {
 	}
 }
 
+func TestSSEServer_NegotiationEventHonorsForwardedPrefix(t *testing.T) {
+	sse := NewSSEServer(NewGateway())
+
+	req := httptest.NewRequest(http.MethodGet, "/sse", nil)
+	req.Header.Set("X-Forwarded-Prefix", "/gridctl")
+	w := httptest.NewRecorder()
+	sse.ServeHTTP(w, req)
+
+	if body := w.Body.String(); !strings.Contains(body, "data: POST /gridctl/mcp") {
+		t.Errorf("expected 'data: POST /gridctl/mcp' in response, got: %s", body)
+	}
+}
+
 func TestSSEServer_NegotiationResponse_IsImmediate(t *testing.T) {
 	g := NewGateway()
 	sse := NewSSEServer(g)
@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// runSSH runs an ssh command line with stdin and returns its stdout; a seam
+// so tests run without a remote host.
+var runSSH = func(ctx context.Context, args []string, stdin io.Reader) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// syncSSHBinary copies cfg.SSHDeployBinary to cfg.SSHDeployPath on the
+// remote host unless the remote file already has the same SHA-256. The file
+// is streamed over the server's own ssh connection settings and renamed into
+// place, so a running copy is never half-written.
+func syncSSHBinary(ctx context.Context, cfg MCPServerConfig, logger *slog.Logger) error {
+	data, err := os.ReadFile(cfg.SSHDeployBinary)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	want := hex.EncodeToString(sum[:])
+
+	dest := shellQuote(cfg.SSHDeployPath)
+	out, err := runSSH(ctx, remoteSSHCommand(cfg,
+		"sha256sum "+dest+" 2>/dev/null || shasum -a 256 "+dest+" 2>/dev/null || true"), nil)
+	if err != nil {
+		return fmt.Errorf("checking remote binary: %w", err)
+	}
+	if fields := strings.Fields(string(out)); len(fields) > 0 && fields[0] == want {
+		logger.Debug("remote binary up to date", "path", cfg.SSHDeployPath)
+		return nil
+	}
+
+	tmp := shellQuote(cfg.SSHDeployPath + ".gridctl-tmp")
+	upload := fmt.Sprintf("mkdir -p %s && cat > %s && chmod 755 %s && mv -f %s %s",
+		shellQuote(path.Dir(cfg.SSHDeployPath)), tmp, tmp, tmp, dest)
+	if _, err := runSSH(ctx, remoteSSHCommand(cfg, upload), bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("uploading binary: %w", err)
+	}
+	logger.Info("deployed binary to SSH host", "host", cfg.SSHHost, "path", cfg.SSHDeployPath, "sha256", want)
+	return nil
+}
+
+// remoteSSHCommand returns the ssh command line that runs script on the
+// server's host with the server's connection options.
+func remoteSSHCommand(cfg MCPServerConfig, script string) []string {
+	cfg.Command = []string{script}
+	return buildSSHCommand(cfg)
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncSSHBinary(t *testing.T) {
+	bin := filepath.Join(t.TempDir(), "server")
+	content := []byte("#!/bin/sh\necho mcp\n")
+	if err := os.WriteFile(bin, content, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+
+	var calls [][]string
+	var uploaded []byte
+	remoteSum := "0000"
+	orig := runSSH
+	t.Cleanup(func() { runSSH = orig })
+	runSSH = func(_ context.Context, args []string, stdin io.Reader) ([]byte, error) {
+		calls = append(calls, args)
+		if stdin != nil {
+			uploaded, _ = io.ReadAll(stdin)
+			return nil, nil
+		}
+		return []byte(remoteSum + "  .gridctl/bin/tools\n"), nil
+	}
+
+	cfg := MCPServerConfig{
+		Name:            "tools",
+		SSH:             true,
+		SSHHost:         "10.0.0.5",
+		SSHUser:         "mcp",
+		SSHPort:         2222,
+		SSHDeployBinary: bin,
+		SSHDeployPath:   ".gridctl/bin/tools",
+	}
+	if err := syncSSHBinary(context.Background(), cfg, slog.Default()); err != nil {
+		t.Fatalf("syncSSHBinary: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected checksum and upload calls, got %d", len(calls))
+	}
+	if string(uploaded) != string(content) {
+		t.Errorf("uploaded %q, want %q", uploaded, content)
+	}
+	upload := calls[1]
+	if got := strings.Join(upload[:len(upload)-2], " "); !strings.Contains(got, "-p 2222") {
+		t.Errorf("upload should reuse the server's ssh options, got %q", got)
+	}
+	if script := upload[len(upload)-1]; !strings.Contains(script, "mv -f '.gridctl/bin/tools.gridctl-tmp' '.gridctl/bin/tools'") {
+		t.Errorf("upload script = %q", script)
+	}
+
+	calls = nil
+	remoteSum = hex.EncodeToString(sum[:])
+	if err := syncSSHBinary(context.Background(), cfg, slog.Default()); err != nil {
+		t.Fatalf("syncSSHBinary: %v", err)
+	}
+	if len(calls) != 1 {
+		t.Errorf("an up-to-date binary should only be checked, got %d calls", len(calls))
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got := shellQuote("it's"); got != `'it'\''s'` {
+		t.Errorf("shellQuote = %s", got)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/gridctl/gridctl/pkg/crash"
 	"github.com/gridctl/gridctl/pkg/dockerclient"
 	"github.com/gridctl/gridctl/pkg/jsonrpc"
 
@@ -30,6 +31,7 @@ type StdioClient struct {
 	stdout   io.Reader
 	attached bool
 	cancel   context.CancelFunc
+	done     chan struct{} // closed once the container's output stream ends
 
 	// Reconnection serialization
 	reconnMu sync.Mutex
@@ -93,6 +95,7 @@ func (c *StdioClient) Connect(ctx context.Context) error {
 
 	// Demultiplex the stream in the background
 	go func() {
+		defer crash.Recover("stdio-demux", "server", c.name)
 		defer stdoutWriter.Close()
 		// StdCopy reads the multiplexed stream and writes stdout to the first writer
 		_, _ = stdcopy.StdCopy(stdoutWriter, io.Discard, resp.Reader)
@@ -101,7 +104,12 @@ func (c *StdioClient) Connect(ctx context.Context) error {
 	// Start reading responses with cancellation
 	readerCtx, cancel := context.WithCancel(context.Background())
 	c.cancel = cancel
-	go c.readResponses(readerCtx, c.stdout)
+	done := make(chan struct{})
+	c.done = done
+	go func() {
+		defer close(done)
+		c.readResponses(readerCtx, c.stdout)
+	}()
 
 	return nil
 }
@@ -110,6 +118,7 @@ func (c *StdioClient) Connect(ctx context.Context) error {
 // stdout is passed as a parameter to capture the value at goroutine launch
 // time (under connMu), avoiding a data race with Reconnect.
 func (c *StdioClient) readResponses(ctx context.Context, stdout io.Reader) {
+	defer crash.Recover("stdio-reader", "server", c.name)
 	defer c.drainPendingRequests()
 
 	scanner := bufio.NewScanner(stdout)
@@ -135,17 +144,28 @@ func (c *StdioClient) readResponses(ctx context.Context, stdout io.Reader) {
 			continue
 		}
 
+		// Notifications carry a method and no id
+		if resp.ID == nil {
+			if method, params, ok := parseNotification(line); ok {
+				c.handleNotification(method, params)
+			}
+			continue
+		}
+
+		// Server-to-client requests carry a method and an id
+		if req, ok := serverRequest(line); ok {
+			go c.replyToServer(ctx, req, c.sendStdio)
+			continue
+		}
+
 		// Route response to waiting caller
-		if resp.ID != nil {
-			var id int64
-			if err := json.Unmarshal(*resp.ID, &id); err == nil {
-				c.responsesMu.Lock()
-				if ch, ok := c.responses[id]; ok {
-					ch <- &resp
-					delete(c.responses, id)
-				}
-				c.responsesMu.Unlock()
+		if id, ok := responseID(resp.ID); ok {
+			c.responsesMu.Lock()
+			if ch, ok := c.responses[id]; ok {
+				ch <- &resp
+				delete(c.responses, id)
 			}
+			c.responsesMu.Unlock()
 		}
 	}
 }
@@ -211,8 +231,8 @@ func (c *StdioClient) call(ctx context.Context, method string, params any, resul
 	}
 
 	// Wait for response with timeout to prevent hanging on dead containers
-	timeout := time.NewTimer(DefaultRequestTimeout)
-	defer timeout.Stop()
+	timeout, stopTimeout := requestTimer(ctx)
+	defer stopTimeout()
 
 	select {
 	case <-ctx.Done():
@@ -220,7 +240,7 @@ func (c *StdioClient) call(ctx context.Context, method string, params any, resul
 		delete(c.responses, id)
 		c.responsesMu.Unlock()
 		return ctx.Err()
-	case <-timeout.C:
+	case <-timeout:
 		c.responsesMu.Lock()
 		delete(c.responses, id)
 		c.responsesMu.Unlock()
@@ -251,8 +271,9 @@ func (c *StdioClient) send(_ context.Context, method string, params any) error {
 	return c.sendStdio(req)
 }
 
-// sendStdio writes a request to stdin.
-func (c *StdioClient) sendStdio(req jsonrpc.Request) error {
+// sendStdio writes a JSON-RPC message (a request, or a response to a
+// server-sent request) to stdin.
+func (c *StdioClient) sendStdio(msg any) error {
 	c.connMu.Lock()
 	defer c.connMu.Unlock()
 
@@ -260,7 +281,7 @@ func (c *StdioClient) sendStdio(req jsonrpc.Request) error {
 		return fmt.Errorf("not connected")
 	}
 
-	data, err := json.Marshal(req)
+	data, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("marshaling request: %w", err)
 	}
@@ -273,6 +294,13 @@ func (c *StdioClient) sendStdio(req jsonrpc.Request) error {
 	return nil
 }
 
+// Disconnected returns a channel closed when the current attach stream ends.
+func (c *StdioClient) Disconnected() <-chan struct{} {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.done
+}
+
 // Reconnect closes the existing connection and re-establishes it, including the
 // MCP handshake and tool refresh. Thread-safe: concurrent callers will block until
 // reconnection completes.
@@ -11,6 +11,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/gridctl/gridctl/pkg/crash"
 	"github.com/gridctl/gridctl/pkg/jsonrpc"
 )
 
@@ -34,15 +35,79 @@ type StreamableSession struct {
 	events    chan streamableEvent
 	streamMu  sync.Mutex
 	sseCancel context.CancelFunc // cancels the active GET SSE stream; nil if none
+
+	// features is what the client can handle, negotiated at initialize.
+	features ClientFeatures
+
+	// Requests the gateway sent to the client, awaiting its POSTed response.
+	pendingMu sync.Mutex
+	pending   map[string]chan jsonrpc.Response
+	nextReqID atomic.Int64
 }
 
 func newStreamableSession(id string) *StreamableSession {
 	return &StreamableSession{
-		ID:     id,
-		events: make(chan streamableEvent, maxEventHistory),
+		ID:      id,
+		events:  make(chan streamableEvent, maxEventHistory),
+		pending: make(map[string]chan jsonrpc.Response),
 	}
 }
 
+// request sends a server-to-client request over the session's SSE stream
+// and waits for the client to POST the response.
+func (s *StreamableSession) request(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	id := fmt.Sprintf("gridctl-%d", s.nextReqID.Add(1))
+	req, err := buildNotification(method, params)
+	if err != nil {
+		return nil, err
+	}
+	rawID, _ := json.Marshal(id)
+	req.ID = (*json.RawMessage)(&rawID)
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan jsonrpc.Response, 1)
+	s.pendingMu.Lock()
+	s.pending[id] = ch
+	s.pendingMu.Unlock()
+	defer func() {
+		s.pendingMu.Lock()
+		delete(s.pending, id)
+		s.pendingMu.Unlock()
+	}()
+
+	s.pushEvent("message", data)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case resp := <-ch:
+		if resp.Error != nil {
+			return nil, fmt.Errorf("RPC error %d: %s", resp.Error.Code, resp.Error.Message)
+		}
+		return resp.Result, nil
+	}
+}
+
+// deliver hands a client's response to the request waiting on it. It
+// returns false when no request with that id is pending.
+func (s *StreamableSession) deliver(resp jsonrpc.Response) bool {
+	var id string
+	if resp.ID == nil || json.Unmarshal(*resp.ID, &id) != nil {
+		return false
+	}
+	s.pendingMu.Lock()
+	ch, ok := s.pending[id]
+	delete(s.pending, id)
+	s.pendingMu.Unlock()
+	if ok {
+		ch <- resp
+	}
+	return ok
+}
+
 // pushEvent adds an event to the session history and enqueues it for the active SSE stream.
 func (s *StreamableSession) pushEvent(eventType string, data []byte) int64 {
 	id := s.nextID.Add(1)
@@ -97,18 +162,41 @@ func (s *StreamableSession) clearStream() {
 type StreamableHTTPServer struct {
 	gateway        *Gateway
 	allowedOrigins []string
+	requestLog     *RequestLogger // optional inbound request recorder
 
 	mu       sync.RWMutex
 	sessions map[string]*StreamableSession
 }
 
 // NewStreamableHTTPServer creates a new Streamable HTTP server.
+// Gateway list_changed notifications are forwarded to every session's SSE
+// stream.
 func NewStreamableHTTPServer(gateway *Gateway, allowedOrigins []string) *StreamableHTTPServer {
-	return &StreamableHTTPServer{
+	s := &StreamableHTTPServer{
 		gateway:        gateway,
 		allowedOrigins: allowedOrigins,
 		sessions:       make(map[string]*StreamableSession),
 	}
+	if gateway != nil {
+		gateway.OnListChanged(s.broadcastNotification)
+		gateway.setClientRequester(s.requestClient)
+	}
+	return s
+}
+
+// broadcastNotification queues a JSON-RPC notification on every session's
+// SSE stream. Sessions without an open stream keep it in their history for
+// Last-Event-ID replay.
+func (s *StreamableHTTPServer) broadcastNotification(method string) {
+	data, err := json.Marshal(jsonrpc.Request{JSONRPC: "2.0", Method: method})
+	if err != nil {
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, session := range s.sessions {
+		session.pushEvent("message", data)
+	}
 }
 
 // SetAllowedOrigins updates the list of allowed origins for DNS rebinding protection.
@@ -116,6 +204,12 @@ func (s *StreamableHTTPServer) SetAllowedOrigins(origins []string) {
 	s.allowedOrigins = origins
 }
 
+// SetRequestLogger enables recording of every inbound JSON-RPC request,
+// per session, for later replay. Call before serving.
+func (s *StreamableHTTPServer) SetRequestLogger(l *RequestLogger) {
+	s.requestLog = l
+}
+
 // ServeHTTP routes /mcp requests based on HTTP method.
 func (s *StreamableHTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err := s.validateOrigin(r); err != nil {
@@ -163,12 +257,19 @@ func (s *StreamableHTTPServer) validateOrigin(r *http.Request) error {
 func (s *StreamableHTTPServer) handlePost(w http.ResponseWriter, r *http.Request) {
 	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestBodySize)
 
-	var req jsonrpc.Request
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	// A client POSTs either a request/notification or the response to a
+	// request the gateway sent it; decode enough to tell them apart.
+	var msg struct {
+		jsonrpc.Request
+		Result json.RawMessage `json:"result,omitempty"`
+		Error  *jsonrpc.Error  `json:"error,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(jsonrpc.NewErrorResponse(nil, jsonrpc.ParseError, "Invalid JSON"))
 		return
 	}
+	req := msg.Request
 	if req.JSONRPC != "2.0" {
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(jsonrpc.NewErrorResponse(req.ID, jsonrpc.InvalidRequest, "Invalid JSON-RPC version"))
@@ -200,11 +301,26 @@ func (s *StreamableHTTPServer) handlePost(w http.ResponseWriter, r *http.Request
 
 	s.gateway.sessions.Touch(sessionID)
 
+	if req.Method == "" && req.ID != nil {
+		if !session.deliver(jsonrpc.Response{JSONRPC: req.JSONRPC, ID: req.ID, Result: msg.Result, Error: msg.Error}) {
+			s.gateway.logger.Debug("dropping response to unknown request", "session", sessionID)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	s.requestLog.Record(sessionID, &req)
+
 	// Thread the originating client ID into the request context so tool-call
 	// observers can attribute calls per client. Sessions created before
 	// PR 2 may have an empty ClientID; WithClientID is a no-op in that case.
 	ctx := r.Context()
 	if gSession := s.gateway.sessions.Get(sessionID); gSession != nil {
+		if !sessionOwnedBy(gSession, r) {
+			http.Error(w, "session belongs to a different client", http.StatusForbidden)
+			return
+		}
+		ctx = WithSessionID(ctx, sessionID)
 		ctx = WithClientID(ctx, gSession.ClientID)
 		ctx = WithClientAccessID(ctx, gSession.AccessID)
 		// The session's group is authoritative over the request path, in
@@ -260,9 +376,11 @@ func (s *StreamableHTTPServer) handleInitialize(w http.ResponseWriter, r *http.R
 
 	// Create transport-level session using the gateway-assigned session ID
 	session := newStreamableSession(gSession.ID)
+	session.features = gSession.Features
 	s.mu.Lock()
 	s.sessions[gSession.ID] = session
 	s.mu.Unlock()
+	s.requestLog.Record(gSession.ID, req)
 
 	w.Header().Set("Mcp-Session-Id", gSession.ID)
 	w.Header().Set("Content-Type", "application/json")
@@ -289,6 +407,10 @@ func (s *StreamableHTTPServer) handleGet(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "session not found", http.StatusNotFound)
 		return
 	}
+	if !sessionOwnedBy(s.gateway.sessions.Get(sessionID), r) {
+		http.Error(w, "session belongs to a different client", http.StatusForbidden)
+		return
+	}
 
 	flusher, ok := w.(http.Flusher)
 	if !ok {
@@ -359,11 +481,28 @@ func (s *StreamableHTTPServer) handleDelete(w http.ResponseWriter, r *http.Reque
 		http.Error(w, "session not found", http.StatusNotFound)
 		return
 	}
+	if !sessionOwnedBy(s.gateway.sessions.Get(sessionID), r) {
+		http.Error(w, "session belongs to a different client", http.StatusForbidden)
+		return
+	}
 
 	s.deleteSession(sessionID)
 	w.WriteHeader(http.StatusOK)
 }
 
+// sessionOwnedBy reports whether r may use session. A session is bound to
+// the identity it initialized with, so a request authenticated by a
+// different API key must not ride on it and act under the first key's name.
+// Requests without a keyed identity, and unknown sessions, are not refused
+// here.
+func sessionOwnedBy(session *Session, r *http.Request) bool {
+	authed := AuthenticatedClientFromContext(r.Context())
+	if authed == "" || session == nil {
+		return true
+	}
+	return NormalizeClientID(authed) == NormalizeClientID(session.AccessID)
+}
+
 // deleteSession tears down a session, cancels any active SSE stream,
 // and removes it from both the transport and gateway session managers.
 func (s *StreamableHTTPServer) deleteSession(sessionID string) {
@@ -383,25 +522,31 @@ func (s *StreamableHTTPServer) deleteSession(sessionID string) {
 		session.streamMu.Unlock()
 	}
 	s.gateway.sessions.Delete(sessionID)
+	s.gateway.clientRootsChanged()
 }
 
 // handleRequest dispatches a JSON-RPC request to the appropriate gateway handler.
 func (s *StreamableHTTPServer) handleRequest(ctx context.Context, session *StreamableSession, req *jsonrpc.Request) jsonrpc.Response {
 	switch req.Method {
-	case "notifications/initialized":
+	case "notifications/initialized", NotificationRootsListChanged:
+		if session.features.Roots {
+			go s.fetchRoots(session)
+		}
 		return jsonrpc.NewSuccessResponse(req.ID, nil)
 	case "tools/list":
 		return s.handleToolsList(ctx, session, req)
 	case "tools/call":
 		return s.handleToolsCall(ctx, session, req)
 	case "prompts/list":
-		return s.handlePromptsList(req)
+		return s.handlePromptsList(ctx, req)
 	case "prompts/get":
 		return s.handlePromptsGet(ctx, req)
 	case "resources/list":
-		return s.handleResourcesList(req)
+		return s.handleResourcesList(ctx, req)
 	case "resources/read":
-		return s.handleResourcesRead(req)
+		return s.handleResourcesRead(ctx, req)
+	case MethodCompletionComplete:
+		return s.handleComplete(ctx, req)
 	case "ping":
 		return jsonrpc.NewSuccessResponse(req.ID, struct{}{})
 	default:
@@ -409,15 +554,54 @@ func (s *StreamableHTTPServer) handleRequest(ctx context.Context, session *Strea
 	}
 }
 
-func (s *StreamableHTTPServer) handleToolsList(ctx context.Context, _ *StreamableSession, req *jsonrpc.Request) jsonrpc.Response {
+// requestClient sends a gateway-to-client request on a session and waits
+// for the answer. Elicitation and sampling are refused up front for clients
+// that did not declare them.
+func (s *StreamableHTTPServer) requestClient(ctx context.Context, sessionID, method string, params json.RawMessage) (json.RawMessage, error) {
+	s.mu.RLock()
+	session, ok := s.sessions[sessionID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("client session %s has ended", sessionID)
+	}
+	if method == MethodElicitationCreate && !session.features.Elicitation {
+		return nil, ErrElicitationUnsupported
+	}
+	if method == MethodSamplingCreateMessage && !session.features.Sampling {
+		return nil, ErrSamplingUnsupported
+	}
+	return session.request(ctx, method, params)
+}
+
+// fetchRoots asks the client for its roots and hands them to the gateway.
+func (s *StreamableHTTPServer) fetchRoots(session *StreamableSession) {
+	defer crash.Recover("roots-list", "session", session.ID)
+	ctx, cancel := context.WithTimeout(context.Background(), rootsRequestTimeout)
+	defer cancel()
+
+	raw, err := session.request(ctx, MethodRootsList, nil)
+	if err != nil {
+		s.gateway.logger.Debug("roots/list to client failed", "session", session.ID, "error", err)
+		return
+	}
+	var result RootsListResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		s.gateway.logger.Debug("invalid roots/list result from client", "session", session.ID, "error", err)
+		return
+	}
+	s.gateway.SetClientRoots(session.ID, result.Roots)
+}
+
+func (s *StreamableHTTPServer) handleToolsList(ctx context.Context, session *StreamableSession, req *jsonrpc.Request) jsonrpc.Response {
 	result, err := s.gateway.HandleToolsList(ctx)
 	if err != nil {
 		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InternalError, err.Error())
 	}
+	result.Tools = session.features.adaptTools(result.Tools)
 	return jsonrpc.NewSuccessResponse(req.ID, result)
 }
 
-func (s *StreamableHTTPServer) handleToolsCall(ctx context.Context, _ *StreamableSession, req *jsonrpc.Request) jsonrpc.Response {
+func (s *StreamableHTTPServer) handleToolsCall(ctx context.Context, session *StreamableSession, req *jsonrpc.Request) jsonrpc.Response {
 	var params ToolCallParams
 	if err := json.Unmarshal(req.Params, &params); err != nil {
 		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InvalidParams, "Invalid tools/call params")
@@ -426,11 +610,11 @@ func (s *StreamableHTTPServer) handleToolsCall(ctx context.Context, _ *Streamabl
 	if err != nil {
 		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InternalError, err.Error())
 	}
-	return jsonrpc.NewSuccessResponse(req.ID, result)
+	return jsonrpc.NewSuccessResponse(req.ID, session.features.adaptToolResult(result))
 }
 
-func (s *StreamableHTTPServer) handlePromptsList(req *jsonrpc.Request) jsonrpc.Response {
-	result, err := s.gateway.HandlePromptsList()
+func (s *StreamableHTTPServer) handlePromptsList(ctx context.Context, req *jsonrpc.Request) jsonrpc.Response {
+	result, err := s.gateway.HandlePromptsList(ctx)
 	if err != nil {
 		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InternalError, err.Error())
 	}
@@ -452,15 +636,15 @@ func (s *StreamableHTTPServer) handlePromptsGet(ctx context.Context, req *jsonrp
 	return jsonrpc.NewSuccessResponse(req.ID, result)
 }
 
-func (s *StreamableHTTPServer) handleResourcesList(req *jsonrpc.Request) jsonrpc.Response {
-	result, err := s.gateway.HandleResourcesList()
+func (s *StreamableHTTPServer) handleResourcesList(ctx context.Context, req *jsonrpc.Request) jsonrpc.Response {
+	result, err := s.gateway.HandleResourcesList(ctx)
 	if err != nil {
 		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InternalError, err.Error())
 	}
 	return jsonrpc.NewSuccessResponse(req.ID, result)
 }
 
-func (s *StreamableHTTPServer) handleResourcesRead(req *jsonrpc.Request) jsonrpc.Response {
+func (s *StreamableHTTPServer) handleResourcesRead(ctx context.Context, req *jsonrpc.Request) jsonrpc.Response {
 	if req.Params == nil {
 		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InvalidParams, "params required for resources/read")
 	}
@@ -468,13 +652,28 @@ func (s *StreamableHTTPServer) handleResourcesRead(req *jsonrpc.Request) jsonrpc
 	if err := json.Unmarshal(req.Params, &params); err != nil {
 		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InvalidParams, "Invalid resources/read params")
 	}
-	result, err := s.gateway.HandleResourcesRead(params)
+	result, err := s.gateway.HandleResourcesRead(ctx, params)
 	if err != nil {
 		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InternalError, err.Error())
 	}
 	return jsonrpc.NewSuccessResponse(req.ID, result)
 }
 
+func (s *StreamableHTTPServer) handleComplete(ctx context.Context, req *jsonrpc.Request) jsonrpc.Response {
+	if req.Params == nil {
+		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InvalidParams, "params required for completion/complete")
+	}
+	var params CompleteParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InvalidParams, "Invalid completion/complete params")
+	}
+	result, err := s.gateway.HandleComplete(ctx, params)
+	if err != nil {
+		return jsonrpc.NewErrorResponse(req.ID, jsonrpc.InvalidParams, err.Error())
+	}
+	return jsonrpc.NewSuccessResponse(req.ID, result)
+}
+
 // SessionCount returns the number of active Streamable HTTP sessions.
 func (s *StreamableHTTPServer) SessionCount() int {
 	s.mu.RLock()
@@ -507,4 +706,5 @@ func (s *StreamableHTTPServer) Close() {
 		s.gateway.sessions.Delete(id)
 	}
 	s.sessions = make(map[string]*StreamableSession)
+	s.gateway.clientRootsChanged()
 }
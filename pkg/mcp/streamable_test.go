@@ -1049,3 +1049,35 @@ func TestStreamableHTTPServer_ResourcesRead_NilParams(t *testing.T) {
 		t.Fatal("expected error for nil params on resources/read")
 	}
 }
+
+func TestStreamableHTTPServer_SessionBoundToAuthenticatedClient(t *testing.T) {
+	srv := NewStreamableHTTPServer(NewGateway(), nil)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"agent"}}}`
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req = req.WithContext(WithAuthenticatedClient(req.Context(), "ci-bot"))
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("initialize: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	sessionID := w.Header().Get("Mcp-Session-Id")
+	if sess := srv.gateway.sessions.Get(sessionID); sess == nil || sess.AccessID != "ci-bot" {
+		t.Fatalf("session access id = %+v, want ci-bot", sess)
+	}
+
+	post := func(identity string) int {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`))
+		req.Header.Set("Mcp-Session-Id", sessionID)
+		req = req.WithContext(WithAuthenticatedClient(req.Context(), identity))
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := post("ci-bot"); code != http.StatusOK {
+		t.Errorf("same key: expected 200, got %d", code)
+	}
+	if code := post("other-bot"); code != http.StatusForbidden {
+		t.Errorf("different key: expected 403, got %d", code)
+	}
+}
@@ -0,0 +1,100 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/crash"
+)
+
+// superviseSet starts a connection supervisor for each replica of set that
+// does not have one yet. A no-op until StartHealthMonitor has run.
+func (g *Gateway) superviseSet(set *ReplicaSet) {
+	g.mu.RLock()
+	ctx := g.monitorCtx
+	g.mu.RUnlock()
+	if ctx == nil {
+		return
+	}
+	for _, replica := range set.Replicas() {
+		g.superviseReplica(ctx, set.Name(), replica)
+	}
+}
+
+// superviseReplica watches a replica whose client reports lost connections
+// (Disconnectable). When the process exits or the container stream closes,
+// it runs the health cycle at once, which reconnects, re-initializes, and
+// refreshes tools, and keeps retrying on the replica's restart backoff until
+// the replica is healthy again. The periodic health monitor remains the
+// fallback for hangs that never drop the connection.
+func (g *Gateway) superviseReplica(ctx context.Context, serverName string, replica *Replica) {
+	watcher, ok := replica.Client().(Disconnectable)
+	if !ok || !replica.supervised.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer crash.Recover("connection-supervisor", "server", serverName)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-watcher.Disconnected():
+			}
+			if !g.supervising(ctx, serverName, replica) {
+				return
+			}
+			g.logger.Warn("MCP server connection lost", "name", serverName, "replica_id", replica.ID())
+			if !g.recoverReplica(ctx, serverName, replica) {
+				return
+			}
+		}
+	}()
+}
+
+// recoverReplica runs health cycles on the replica's restart backoff until it
+// is healthy. It returns false when supervision should stop instead.
+func (g *Gateway) recoverReplica(ctx context.Context, serverName string, replica *Replica) bool {
+	for {
+		g.checkReplicaHealth(ctx, serverName, replica)
+		if set := g.router.GetReplicaSet(serverName); set != nil {
+			g.recomputeRollup(serverName, set)
+		}
+		if replica.Healthy() {
+			return true
+		}
+
+		wait := time.Until(replica.Restart().NextAt())
+		if wait <= 0 {
+			wait = restartBackoffInitial
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+		if !g.supervising(ctx, serverName, replica) {
+			return false
+		}
+	}
+}
+
+// supervising reports whether the replica should still be kept alive: the
+// gateway is running and the replica has not been removed (unregistered,
+// restarted by hand, or scaled down).
+func (g *Gateway) supervising(ctx context.Context, serverName string, replica *Replica) bool {
+	if ctx.Err() != nil || g.closed.Load() {
+		return false
+	}
+	set := g.router.GetReplicaSet(serverName)
+	if set == nil {
+		return false
+	}
+	for _, r := range set.Replicas() {
+		if r == replica {
+			return true
+		}
+	}
+	return false
+}
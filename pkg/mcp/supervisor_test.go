@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// disconnectableClient is a reconnectable client whose connection can be
+// dropped by the test; Reconnect opens a fresh one.
+type disconnectableClient struct {
+	AgentClient
+	mu         sync.Mutex
+	done       chan struct{}
+	up         bool
+	reconnects int
+}
+
+func (c *disconnectableClient) Ping(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.up {
+		return errors.New("process not running")
+	}
+	return nil
+}
+
+func (c *disconnectableClient) Reconnect(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reconnects++
+	c.up = true
+	c.done = make(chan struct{})
+	return nil
+}
+
+func (c *disconnectableClient) Disconnected() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done
+}
+
+func (c *disconnectableClient) drop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.up = false
+	close(c.done)
+}
+
+func TestSupervisor_ReconnectsOnDisconnect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	client := &disconnectableClient{
+		AgentClient: setupMockAgentClient(ctrl, "server1", []Tool{{Name: "tool1"}}),
+		done:        make(chan struct{}),
+		up:          true,
+	}
+	g.Router().AddClient(client)
+	g.SetServerMeta(MCPServerConfig{Name: "server1", LocalProcess: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// An hour-long interval leaves recovery entirely to the supervisor.
+	g.StartHealthMonitor(ctx, time.Hour)
+
+	for want := uint64(1); want <= 2; want++ {
+		client.drop()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			statuses := g.ReplicaStatuses("server1")
+			if len(statuses) == 1 && statuses[0].Restarts == want && statuses[0].Healthy {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("restart %d not recorded, replica status %+v", want, statuses)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestSupervisor_StopsWhenGatewayCloses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := NewGateway()
+	client := &disconnectableClient{
+		AgentClient: setupMockAgentClient(ctrl, "server1", []Tool{{Name: "tool1"}}),
+		done:        make(chan struct{}),
+		up:          true,
+	}
+	g.Router().AddClient(client)
+	g.SetServerMeta(MCPServerConfig{Name: "server1", LocalProcess: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	g.StartHealthMonitor(ctx, time.Hour)
+
+	g.closed.Store(true)
+	client.drop()
+	time.Sleep(100 * time.Millisecond)
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.reconnects != 0 {
+		t.Errorf("a closing gateway should not reconnect, got %d reconnects", client.reconnects)
+	}
+}
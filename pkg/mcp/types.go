@@ -42,6 +42,16 @@ type Reconnectable interface {
 	Reconnect(ctx context.Context) error
 }
 
+// Disconnectable is an optional interface for AgentClients that can signal a
+// lost connection (process exit, closed container stream) as it happens. The
+// connection supervisor waits on the channel so a dead replica is restarted
+// right away instead of at the next health check.
+type Disconnectable interface {
+	// Disconnected returns a channel closed when the current connection
+	// ends. It is nil before the first Connect; Reconnect replaces it.
+	Disconnected() <-chan struct{}
+}
+
 // ToolCaller allows calling tools across the gateway's aggregated servers.
 // This interface decouples the registry from the gateway to avoid circular dependencies.
 // The gateway implements this interface and passes it to components that need
@@ -154,7 +164,8 @@ func GateDeny(message string) GateDecision { return GateDecision{Allow: false, M
 // gridctl hosts no third-party plugins on this path, and configurable
 // ordering is a bug class of its own. Future enforcement features (approval
 // gates, trifecta policy, CEL conditions) implement this same interface and
-// take a hard-coded position in the slice.
+// take a hard-coded position in the slice. Hooks that observe or transform
+// every call and its result belong in a ToolCallInterceptor instead.
 type CallGate interface {
 	// Name identifies the gate in logs ("rate-limits", "budgets").
 	Name() string
@@ -188,6 +199,9 @@ type PromptGetObservation struct {
 	// PromptName is the served prompt name, which equals the registry
 	// skill's Name.
 	PromptName string
+	// Variant is the content variant served, or empty when the prompt has
+	// no variants.
+	Variant string
 	// ClientID is the normalized identifier of the originating MCP client
 	// (for example "claude-code", "cursor"). Empty when no session
 	// attribution was available; observers should treat that as anonymous.
@@ -305,7 +319,8 @@ func NegotiateProtocolVersion(requested string) string {
 
 // Default timeouts for MCP transport clients.
 const (
-	// DefaultRequestTimeout is the timeout for individual MCP JSON-RPC requests.
+	// DefaultRequestTimeout is the timeout for individual MCP JSON-RPC
+	// requests, and for tool calls to servers without a configured timeout.
 	DefaultRequestTimeout = 30 * time.Second
 
 	// DefaultReadyPollInterval is the interval between readiness checks.
@@ -315,6 +330,28 @@ const (
 	DefaultReadyTimeout = 30 * time.Second
 )
 
+// requestTimer returns a channel that fires after DefaultRequestTimeout, for
+// transports that wait on a response channel. When ctx has a deadline of its
+// own (the gateway sets one from the server's tool call timeout) the channel
+// is nil and ctx alone governs the wait. Call stop once done waiting.
+func requestTimer(ctx context.Context) (c <-chan time.Time, stop func()) {
+	if _, ok := ctx.Deadline(); ok {
+		return nil, func() {}
+	}
+	t := time.NewTimer(DefaultRequestTimeout)
+	return t.C, func() { t.Stop() }
+}
+
+// requestTimeout returns how long a transport waits for a response: the time
+// left before ctx's deadline when it has one (the gateway sets it from the
+// server's tool call timeout), otherwise DefaultRequestTimeout.
+func requestTimeout(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return DefaultRequestTimeout
+}
+
 // MaxRequestBodySize is the maximum allowed size for incoming JSON-RPC request bodies (1MB).
 const MaxRequestBodySize = 1 * 1024 * 1024
 
@@ -337,9 +374,13 @@ type ClientInfo struct {
 
 // Capabilities describes what the server/client can do.
 type Capabilities struct {
-	Tools     *ToolsCapability     `json:"tools,omitempty"`
-	Resources *ResourcesCapability `json:"resources,omitempty"`
-	Prompts   *PromptsCapability   `json:"prompts,omitempty"`
+	Tools       *ToolsCapability       `json:"tools,omitempty"`
+	Resources   *ResourcesCapability   `json:"resources,omitempty"`
+	Prompts     *PromptsCapability     `json:"prompts,omitempty"`
+	Roots       *RootsCapability       `json:"roots,omitempty"`       // client-side: the client answers roots/list
+	Elicitation *ElicitationCapability `json:"elicitation,omitempty"` // client-side: the client answers elicitation/create
+	Sampling    *SamplingCapability    `json:"sampling,omitempty"`    // client-side: the client answers sampling/createMessage
+	Completions *CompletionsCapability `json:"completions,omitempty"` // server-side: the server answers completion/complete
 }
 
 // ToolsCapability indicates tools support.
@@ -358,6 +399,34 @@ type PromptsCapability struct {
 	ListChanged bool `json:"listChanged,omitempty"`
 }
 
+// RootsCapability indicates a client answers roots/list.
+type RootsCapability struct {
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// ElicitationCapability indicates a client can ask its user for input on a
+// server's behalf (elicitation/create).
+type ElicitationCapability struct{}
+
+// SamplingCapability indicates a client can run an LLM completion on a
+// server's behalf (sampling/createMessage).
+type SamplingCapability struct{}
+
+// CompletionsCapability indicates a server offers argument autocompletion
+// (completion/complete).
+type CompletionsCapability struct{}
+
+// Root is a filesystem boundary a client exposes to servers.
+type Root struct {
+	URI  string `json:"uri"` // a file:// URI
+	Name string `json:"name,omitempty"`
+}
+
+// RootsListResult is the response to roots/list.
+type RootsListResult struct {
+	Roots []Root `json:"roots"`
+}
+
 // InitializeParams contains parameters for the initialize request.
 type InitializeParams struct {
 	ProtocolVersion string       `json:"protocolVersion"`
@@ -465,13 +534,11 @@ type ToolCallResult struct {
 	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
 
 	// Usage carries optional usage metadata reported alongside a tool
-	// result — model ID, cache-read/cache-write tokens. The field is
-	// nil for tool calls that do not report usage. Skipped during
-	// JSON marshalling: the MCP wire shape for usage metadata is
-	// finalized in a follow-up PR (the spec's `_meta` field is used
-	// by tracing and pipelock for keyed extensions, so we cannot
-	// claim the entire object here). Internal observers populate
-	// Usage in memory only.
+	// result — model ID, token counts, cost. The field is nil for tool
+	// calls that do not report usage. On the wire it travels in Meta under
+	// UsageMetaKey (the spec's `_meta` is shared by keyed extensions, so
+	// gridctl claims one key rather than the whole object); the gateway
+	// decodes it into Usage after each call and forwards Meta unchanged.
 	Usage *CallUsage `json:"-"`
 
 	// Meta is the MCP-spec extension point on result objects. Keyed
@@ -481,9 +548,11 @@ type ToolCallResult struct {
 }
 
 // CallUsage is the optional per-call usage metadata that an MCP server may
-// report alongside a tool result. All fields are optional: zero values
-// indicate "not reported" rather than "zero usage." The metrics observer
-// reads these to price cache traffic separately from input traffic.
+// report alongside a tool result, typically an LLM-backed tool reporting
+// its own inference. All fields are optional: zero values indicate "not
+// reported" rather than "zero usage." The metrics observer reads these to
+// price cache traffic separately from input traffic, and prefers reported
+// tokens and cost over its own estimates.
 type CallUsage struct {
 	// Model is the canonical model ID used to service the call (e.g.
 	// "claude-opus-4-7"). When empty, the observer falls back to the
@@ -498,6 +567,16 @@ type CallUsage struct {
 	// prompt cache. Priced via the provider's
 	// cache_creation_input_token_cost rate.
 	CacheCreationTokens int `json:"cache_creation_tokens,omitempty"`
+
+	// InputTokens and OutputTokens are the tokens the tool's own model
+	// consumed and produced. When set they replace the gateway's count of
+	// the call's arguments and result for pricing.
+	InputTokens  int `json:"input_tokens,omitempty"`
+	OutputTokens int `json:"output_tokens,omitempty"`
+
+	// CostUSD is the call's cost as the tool computed it. When set it is
+	// recorded as the call's cost instead of the price of its tokens.
+	CostUSD float64 `json:"cost_usd,omitempty"`
 }
 
 // Content represents content in a tool response.
@@ -519,12 +598,22 @@ type PromptProvider interface {
 	GetPromptData(name string) (*PromptData, error)
 }
 
+// PromptVariantProvider is implemented by prompt providers that can serve
+// named content variants of a prompt (A/B testing). The gateway prefers it
+// over GetPromptData, passing the "variant" prompts/get argument; an empty
+// variant lets the provider choose.
+type PromptVariantProvider interface {
+	GetPromptVariant(name, variant string) (*PromptData, error)
+}
+
 // PromptData contains prompt information used by the MCP protocol layer.
 type PromptData struct {
 	Name        string
 	Description string
 	Content     string
 	Arguments   []PromptArgumentData
+	// Variant is the content variant served, when the provider has variants.
+	Variant string
 }
 
 // PromptArgumentData describes a prompt argument with default value support.
@@ -533,6 +622,9 @@ type PromptArgumentData struct {
 	Description string
 	Required    bool
 	Default     string
+	// Values lists the accepted values of an enum-like argument. The
+	// gateway offers them as completion/complete suggestions.
+	Values []string
 }
 
 // --- MCP Prompts Protocol Types ---
@@ -574,6 +666,46 @@ type PromptsGetResult struct {
 	Messages    []PromptMessage `json:"messages"`
 }
 
+// --- MCP Completion Protocol Types ---
+
+// CompletionRef identifies what is being completed: a prompt (Name), a
+// resource template (URI), or, as a gridctl extension, a tool (Name).
+type CompletionRef struct {
+	Type string `json:"type"` // CompletionRefPrompt, CompletionRefResource, or CompletionRefTool
+	Name string `json:"name,omitempty"`
+	URI  string `json:"uri,omitempty"`
+}
+
+// CompletionArgument is the argument being completed and its partial value.
+type CompletionArgument struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CompletionContext carries arguments the client has already resolved.
+type CompletionContext struct {
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// CompleteParams contains parameters for completion/complete.
+type CompleteParams struct {
+	Ref      CompletionRef      `json:"ref"`
+	Argument CompletionArgument `json:"argument"`
+	Context  *CompletionContext `json:"context,omitempty"`
+}
+
+// Completion is a list of suggested values.
+type Completion struct {
+	Values  []string `json:"values"`
+	Total   int      `json:"total,omitempty"`
+	HasMore bool     `json:"hasMore,omitempty"`
+}
+
+// CompleteResult is the response to completion/complete.
+type CompleteResult struct {
+	Completion Completion `json:"completion"`
+}
+
 // --- MCP Resources Protocol Types ---
 
 // MCPResource represents a resource in the resources/list response.
@@ -0,0 +1,36 @@
+package mcp
+
+import "encoding/json"
+
+// UsageMetaKey is the tool result _meta key under which a server reports
+// the call's usage, shaped like CallUsage:
+//
+//	"_meta": {"gridctl/usage": {"model": "claude-haiku-4-5", "input_tokens": 1200, "output_tokens": 300, "cost_usd": 0.0027}}
+const UsageMetaKey = "gridctl/usage"
+
+// callUsageFromMeta decodes the usage a server reported in a result's _meta.
+// It returns nil when none was reported or the value is malformed; negative
+// counts and costs are dropped rather than trusted.
+func callUsageFromMeta(meta map[string]any) *CallUsage {
+	v, ok := meta[UsageMetaKey]
+	if !ok {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var u CallUsage
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return nil
+	}
+	u.InputTokens = max(u.InputTokens, 0)
+	u.OutputTokens = max(u.OutputTokens, 0)
+	u.CacheReadTokens = max(u.CacheReadTokens, 0)
+	u.CacheCreationTokens = max(u.CacheCreationTokens, 0)
+	u.CostUSD = max(u.CostUSD, 0)
+	if u == (CallUsage{}) {
+		return nil
+	}
+	return &u
+}
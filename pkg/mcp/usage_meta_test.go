@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCallUsageFromMeta(t *testing.T) {
+	tests := []struct {
+		name string
+		meta string
+		want *CallUsage
+	}{
+		{name: "absent", meta: `{"other": 1}`, want: nil},
+		{
+			name: "reported",
+			meta: `{"gridctl/usage": {"model": "m", "input_tokens": 1200, "output_tokens": 300, "cost_usd": 0.0027}}`,
+			want: &CallUsage{Model: "m", InputTokens: 1200, OutputTokens: 300, CostUSD: 0.0027},
+		},
+		{name: "malformed", meta: `{"gridctl/usage": "lots"}`, want: nil},
+		{name: "empty", meta: `{"gridctl/usage": {}}`, want: nil},
+		{
+			name: "negatives dropped",
+			meta: `{"gridctl/usage": {"input_tokens": -5, "output_tokens": 7, "cost_usd": -1}}`,
+			want: &CallUsage{OutputTokens: 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var meta map[string]any
+			if err := json.Unmarshal([]byte(tt.meta), &meta); err != nil {
+				t.Fatal(err)
+			}
+			got := callUsageFromMeta(meta)
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("callUsageFromMeta() = %+v, want %+v", got, tt.want)
+			}
+			if got != nil && *got != *tt.want {
+				t.Errorf("callUsageFromMeta() = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
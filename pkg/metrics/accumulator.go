@@ -380,6 +380,14 @@ type Accumulator struct {
 	// prompt serving never pollutes Tools Audit Mode.
 	promptUsageMu sync.RWMutex
 	promptUsage   map[string]*promptUsage
+	// promptVariants holds per-variant prompts/get counters keyed by skill,
+	// then variant. In memory only: unlike promptUsage it is not persisted.
+	promptVariants map[string]map[string]*promptUsage
+
+	// Per-day usage rollup keyed by (local date, client, server, tool).
+	// In memory only, pruned to dailyRetentionDays (see daily.go).
+	dailyMu sync.Mutex
+	daily   map[dailyKey]*DailyUsage
 
 	// Format savings (atomic for lock-free reads)
 	savingsOriginal  atomic.Int64
@@ -422,6 +430,7 @@ func NewAccumulator(maxDataPoints int) *Accumulator {
 		clientModels: make(map[string]map[string]*modelCounters),
 		toolUsage:    make(map[string]map[string]*toolUsage),
 		promptUsage:  make(map[string]*promptUsage),
+		daily:        make(map[dailyKey]*DailyUsage),
 	}
 }
 
@@ -747,6 +756,54 @@ func (a *Accumulator) getOrCreatePromptUsage(name string) *promptUsage {
 	return pu
 }
 
+// RecordPromptVariant increments the counter for the variant of a skill
+// served via prompts/get, alongside RecordPromptGet. Empty names are no-ops.
+func (a *Accumulator) RecordPromptVariant(name, variant string) {
+	if name == "" || variant == "" {
+		return
+	}
+	a.promptUsageMu.Lock()
+	if a.promptVariants == nil {
+		a.promptVariants = make(map[string]map[string]*promptUsage)
+	}
+	variants, ok := a.promptVariants[name]
+	if !ok {
+		variants = make(map[string]*promptUsage)
+		a.promptVariants[name] = variants
+	}
+	pu, ok := variants[variant]
+	if !ok {
+		pu = &promptUsage{}
+		variants[variant] = pu
+	}
+	a.promptUsageMu.Unlock()
+	pu.calls.Add(1)
+	pu.lastCalledNanos.Store(time.Now().UnixNano())
+}
+
+// PromptVariantSnapshot returns a deep copy of the per-variant prompts/get
+// counters, keyed by skill then variant. Nil when no variant was served.
+func (a *Accumulator) PromptVariantSnapshot() map[string]map[string]ToolStat {
+	a.promptUsageMu.RLock()
+	defer a.promptUsageMu.RUnlock()
+	if len(a.promptVariants) == 0 {
+		return nil
+	}
+	out := make(map[string]map[string]ToolStat, len(a.promptVariants))
+	for name, variants := range a.promptVariants {
+		stats := make(map[string]ToolStat, len(variants))
+		for variant, pu := range variants {
+			var lastCalled time.Time
+			if nanos := pu.lastCalledNanos.Load(); nanos > 0 {
+				lastCalled = time.Unix(0, nanos)
+			}
+			stats[variant] = ToolStat{Calls: pu.calls.Load(), LastCalledAt: lastCalled}
+		}
+		out[name] = stats
+	}
+	return out
+}
+
 // PromptUsageSnapshot returns a deep copy of the per-skill prompts/get call
 // counters. Empty (nil) when no prompt has been served yet. Reuses the
 // ToolStat value shape so the persistence and API layers share one type.
@@ -1826,6 +1883,10 @@ func (a *Accumulator) Clear() {
 	a.promptUsage = make(map[string]*promptUsage)
 	a.promptUsageMu.Unlock()
 
+	a.dailyMu.Lock()
+	a.daily = make(map[dailyKey]*DailyUsage)
+	a.dailyMu.Unlock()
+
 	a.savingsOriginal.Store(0)
 	a.savingsFormatted.Store(0)
 
@@ -1884,6 +1945,12 @@ func (a *Accumulator) ClearCost() {
 	}
 	a.toolUsageMu.RUnlock()
 
+	a.dailyMu.Lock()
+	for _, row := range a.daily {
+		row.CostMicroUSD = 0
+	}
+	a.dailyMu.Unlock()
+
 	// Model histograms are pure cost data — drop them entirely (rather than
 	// zeroing) so a cleared entity reports provenance `none`, not a `mixed`
 	// histogram full of zero-cost models.
@@ -1138,6 +1138,26 @@ func TestAccumulator_RecordPromptGet_EmptyNameIsNoOp(t *testing.T) {
 	}
 }
 
+func TestAccumulator_RecordPromptVariant(t *testing.T) {
+	acc := NewAccumulator(100)
+
+	acc.RecordPromptVariant("code-review", "concise")
+	acc.RecordPromptVariant("code-review", "concise")
+	acc.RecordPromptVariant("code-review", "default")
+	acc.RecordPromptVariant("code-review", "")
+
+	snap := acc.PromptVariantSnapshot()
+	if got := snap["code-review"]["concise"].Calls; got != 2 {
+		t.Errorf("concise calls = %d, want 2", got)
+	}
+	if got := snap["code-review"]["default"].Calls; got != 1 {
+		t.Errorf("default calls = %d, want 1", got)
+	}
+	if len(snap["code-review"]) != 2 {
+		t.Errorf("expected an empty variant to be ignored; got %v", snap["code-review"])
+	}
+}
+
 func TestAccumulator_PromptUsageSnapshot_EmptyAccumulator(t *testing.T) {
 	acc := NewAccumulator(100)
 	if snap := acc.PromptUsageSnapshot(); snap != nil {
@@ -1417,3 +1437,31 @@ func TestAccumulator_CostMicroSnapshot_PerServer(t *testing.T) {
 		t.Errorf("cache-write micro = %d, want 5000", gh.CacheWriteMicroUSD)
 	}
 }
+
+func TestAccumulator_DailyUsage_WindowAndRetention(t *testing.T) {
+	acc := NewAccumulator(100)
+	now := time.Now()
+	acc.RecordDailyUsage(now.AddDate(0, 0, -dailyRetentionDays), "", "old", "tool", 1, 1, 0)
+	acc.RecordDailyUsage(now.AddDate(0, 0, -3), "", "github", "search", 1, 1, 0)
+	acc.RecordDailyUsage(now, "agent", "github", "search", 2, 3, 0.1)
+
+	if got := acc.DailyUsageSnapshot(1); len(got) != 1 || got[0].Client != "agent" {
+		t.Errorf("1-day window = %+v, want only today's row", got)
+	}
+	all := acc.DailyUsageSnapshot(0)
+	if len(all) != 2 {
+		t.Fatalf("all rows = %+v, want the out-of-retention day pruned", all)
+	}
+	if all[0].Date <= all[1].Date {
+		t.Errorf("rows should be newest first: %+v", all)
+	}
+
+	acc.ClearCost()
+	if got := acc.DailyUsageSnapshot(1)[0]; got.CostMicroUSD != 0 || got.Calls != 1 {
+		t.Errorf("ClearCost should zero cost and keep calls: %+v", got)
+	}
+	acc.Clear()
+	if got := acc.DailyUsageSnapshot(0); len(got) != 0 {
+		t.Errorf("Clear should drop daily rows: %+v", got)
+	}
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+)
+
+// dailyRetentionDays bounds how many local calendar days of per-day usage
+// the accumulator keeps.
+const dailyRetentionDays = 90
+
+// dailyDateLayout formats the local calendar day a call is attributed to.
+const dailyDateLayout = "2006-01-02"
+
+// DailyUsage is one (day, client, server, tool) row of the per-day usage
+// rollup. Tokens are the ones the call was priced on: the tool's reported
+// inference tokens when it reported them, otherwise the gateway's count.
+type DailyUsage struct {
+	Date         string `json:"date"`
+	Client       string `json:"client,omitempty"`
+	Server       string `json:"server"`
+	Tool         string `json:"tool"`
+	Calls        int64  `json:"calls"`
+	InputTokens  int64  `json:"input_tokens"`
+	OutputTokens int64  `json:"output_tokens"`
+	CostMicroUSD int64  `json:"cost_micro_usd,omitempty"`
+}
+
+// CostUSD returns the row's priced cost in USD.
+func (d DailyUsage) CostUSD() float64 {
+	return microToUSD(d.CostMicroUSD)
+}
+
+type dailyKey struct {
+	date, client, server, tool string
+}
+
+// RecordDailyUsage folds one tool call into the per-day rollup under the
+// local calendar day of ts. Days older than the retention window are
+// dropped as new days arrive. Calls without a tool name (the legacy
+// observer path) are not recorded.
+func (a *Accumulator) RecordDailyUsage(ts time.Time, clientID, serverName, toolName string, inputTokens, outputTokens int, costUSD float64) {
+	if serverName == "" || toolName == "" {
+		return
+	}
+	date := ts.Local().Format(dailyDateLayout)
+	key := dailyKey{date: date, client: clientID, server: serverName, tool: toolName}
+
+	a.dailyMu.Lock()
+	defer a.dailyMu.Unlock()
+	if a.daily == nil {
+		a.daily = make(map[dailyKey]*DailyUsage)
+	}
+	row, ok := a.daily[key]
+	if !ok {
+		row = &DailyUsage{Date: date, Client: clientID, Server: serverName, Tool: toolName}
+		a.daily[key] = row
+		a.pruneDailyLocked(ts)
+	}
+	row.Calls++
+	row.InputTokens += int64(inputTokens)
+	row.OutputTokens += int64(outputTokens)
+	if costUSD > 0 {
+		row.CostMicroUSD += usdToMicro(costUSD)
+	}
+}
+
+// pruneDailyLocked drops rows older than the retention window. Runs only
+// when a new row is created, so its cost is paid once per (day, key).
+func (a *Accumulator) pruneDailyLocked(now time.Time) {
+	cutoff := now.Local().AddDate(0, 0, -(dailyRetentionDays - 1)).Format(dailyDateLayout)
+	for k := range a.daily {
+		if k.date < cutoff {
+			delete(a.daily, k)
+		}
+	}
+}
+
+// DailyUsageSnapshot returns the rollup rows for the last days local
+// calendar days including today (all retained days when days <= 0),
+// newest day first, then by client, server, and tool.
+func (a *Accumulator) DailyUsageSnapshot(days int) []DailyUsage {
+	var cutoff string
+	if days > 0 {
+		cutoff = time.Now().Local().AddDate(0, 0, -(days - 1)).Format(dailyDateLayout)
+	}
+	a.dailyMu.Lock()
+	out := make([]DailyUsage, 0, len(a.daily))
+	for k, row := range a.daily {
+		if k.date >= cutoff {
+			out = append(out, *row)
+		}
+	}
+	a.dailyMu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		x, y := out[i], out[j]
+		if x.Date != y.Date {
+			return x.Date > y.Date
+		}
+		if x.Client != y.Client {
+			return x.Client < y.Client
+		}
+		if x.Server != y.Server {
+			return x.Server < y.Server
+		}
+		return x.Tool < y.Tool
+	})
+	return out
+}
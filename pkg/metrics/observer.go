@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"time"
 
 	"github.com/gridctl/gridctl/pkg/mcp"
 	"github.com/gridctl/gridctl/pkg/pricing"
@@ -68,9 +69,11 @@ func (o *Observer) ObserveToolCallWithClient(_ context.Context, obs mcp.ToolCall
 // ObservePromptGet records that a registry skill was served via prompts/get,
 // incrementing its cumulative count and last-used timestamp in the parallel
 // prompt-usage namespace. The token/cost path does not apply: prompts are
-// static content, not tool calls.
+// static content, not tool calls. Skills with variants also count the
+// variant served.
 func (o *Observer) ObservePromptGet(obs mcp.PromptGetObservation) {
 	o.accumulator.RecordPromptGet(obs.PromptName)
+	o.accumulator.RecordPromptVariant(obs.PromptName, obs.Variant)
 }
 
 // observe is the shared core of the legacy and client-aware observer entry
@@ -101,35 +104,65 @@ func (o *Observer) observe(serverName string, replicaID int, clientID, toolName
 	if usageMeta != nil {
 		summary.CacheReadTokens = usageMeta.CacheReadTokens
 		summary.CacheCreationTokens = usageMeta.CacheCreationTokens
+		// An LLM-backed tool's own inference is what the call cost;
+		// the gateway's count of its arguments and result is only a proxy.
+		if usageMeta.InputTokens > 0 || usageMeta.OutputTokens > 0 {
+			summary.InputTokens = usageMeta.InputTokens
+			summary.OutputTokens = usageMeta.OutputTokens
+		}
+	}
+
+	breakdown := o.price(serverName, clientID, usageMeta, &summary)
+	if !breakdown.IsZero() {
+		o.accumulator.RecordCostWithModel(serverName, replicaID, clientID, summary.Model, summary.InputTokens, summary.OutputTokens, breakdown)
+		o.accumulator.RecordToolCost(serverName, toolName, breakdown)
+		summary.CostUSD = breakdown.Input + breakdown.Output + breakdown.CacheRead + breakdown.CacheWrite
+		summary.HasCost = summary.CostUSD > 0
 	}
+	o.accumulator.RecordDailyUsage(time.Now(), clientID, serverName, toolName, summary.InputTokens, summary.OutputTokens, summary.CostUSD)
+	return summary
+}
 
-	model := o.resolveModel(serverName, clientID, usageMeta)
-	if model == "" {
-		return summary
+// price computes the call's cost breakdown and sets summary.Model. A cost
+// the tool reported wins over the price of its tokens: when the model is
+// priced the reported total is split across components in the priced
+// proportions, otherwise it is booked as output cost. The zero breakdown
+// means the call is unpriced.
+func (o *Observer) price(serverName, clientID string, usageMeta *mcp.CallUsage, summary *mcp.ToolCallSummary) CostBreakdown {
+	var breakdown CostBreakdown
+	if model := o.resolveModel(serverName, clientID, usageMeta); model != "" {
+		summary.Model = model
+		usage := pricing.Usage{
+			InputTokens:      summary.InputTokens,
+			OutputTokens:     summary.OutputTokens,
+			CacheReadTokens:  summary.CacheReadTokens,
+			CacheWriteTokens: summary.CacheCreationTokens,
+		}
+		if cost, ok := pricing.CalculateBreakdown(model, usage); ok {
+			breakdown = CostBreakdown{
+				Input:      cost.Input,
+				Output:     cost.Output,
+				CacheRead:  cost.CacheRead,
+				CacheWrite: cost.CacheWrite,
+			}
+		}
 	}
-	summary.Model = model
 
-	usage := pricing.Usage{
-		InputTokens:      inputTokens,
-		OutputTokens:     outputTokens,
-		CacheReadTokens:  summary.CacheReadTokens,
-		CacheWriteTokens: summary.CacheCreationTokens,
+	if usageMeta == nil || usageMeta.CostUSD <= 0 {
+		return breakdown
 	}
-	cost, ok := pricing.CalculateBreakdown(model, usage)
-	if !ok {
-		return summary
+	reported := usageMeta.CostUSD
+	priced := breakdown.Input + breakdown.Output + breakdown.CacheRead + breakdown.CacheWrite
+	if priced <= 0 {
+		return CostBreakdown{Output: reported}
 	}
-	breakdown := CostBreakdown{
-		Input:      cost.Input,
-		Output:     cost.Output,
-		CacheRead:  cost.CacheRead,
-		CacheWrite: cost.CacheWrite,
+	scale := reported / priced
+	return CostBreakdown{
+		Input:      breakdown.Input * scale,
+		Output:     breakdown.Output * scale,
+		CacheRead:  breakdown.CacheRead * scale,
+		CacheWrite: breakdown.CacheWrite * scale,
 	}
-	o.accumulator.RecordCostWithModel(serverName, replicaID, clientID, model, inputTokens, outputTokens, breakdown)
-	o.accumulator.RecordToolCost(serverName, toolName, breakdown)
-	summary.CostUSD = cost.Input + cost.Output + cost.CacheRead + cost.CacheWrite
-	summary.HasCost = summary.CostUSD > 0
-	return summary
 }
 
 // resolveModel picks the model ID for a call: the call-level model wins,
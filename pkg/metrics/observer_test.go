@@ -516,3 +516,98 @@ func TestObserver_NilResult(t *testing.T) {
 		t.Errorf("expected 0 output tokens for nil result, got %d", snap.Session.OutputTokens)
 	}
 }
+
+// TestObserver_ReportedUsage verifies tokens and cost a tool reports in its
+// result replace the gateway's estimate for pricing and budgets, and that
+// a reported cost is recorded even for a model the pricing source lacks.
+func TestObserver_ReportedUsage(t *testing.T) {
+	prev := pricing.CurrentSource()
+	defer pricing.SetSource(prev)
+	pricing.SetSource(staticSource{name: "fixture", rates: map[string]pricing.Rates{
+		"priced": {InputPerToken: 1e-6, OutputPerToken: 4e-6},
+	}})
+
+	t.Run("reported tokens are priced", func(t *testing.T) {
+		acc := NewAccumulator(100)
+		obs := NewObserver(token.NewHeuristicCounter(4), acc)
+		summary := obs.ObserveToolCallWithClient(context.Background(), mcp.ToolCallObservation{
+			ServerName: "llm", ReplicaID: -1, ToolName: "summarize",
+			Arguments: map[string]any{"q": "hi"},
+			Result: &mcp.ToolCallResult{
+				Content: []mcp.Content{mcp.NewTextContent("ok")},
+				Usage:   &mcp.CallUsage{Model: "priced", InputTokens: 1000, OutputTokens: 500},
+			},
+		})
+		if summary.InputTokens != 1000 || summary.OutputTokens != 500 {
+			t.Errorf("summary tokens = %d/%d, want 1000/500", summary.InputTokens, summary.OutputTokens)
+		}
+		if want := 1000*1e-6 + 500*4e-6; !approxUSDEq(summary.CostUSD, want) || !summary.HasCost {
+			t.Errorf("CostUSD = %v, want %v", summary.CostUSD, want)
+		}
+	})
+
+	t.Run("reported cost wins over priced tokens", func(t *testing.T) {
+		acc := NewAccumulator(100)
+		obs := NewObserver(token.NewHeuristicCounter(4), acc)
+		summary := obs.ObserveToolCallWithClient(context.Background(), mcp.ToolCallObservation{
+			ServerName: "llm", ReplicaID: -1, ToolName: "summarize",
+			Result: &mcp.ToolCallResult{
+				Usage: &mcp.CallUsage{Model: "priced", InputTokens: 1000, OutputTokens: 500, CostUSD: 0.03},
+			},
+		})
+		if !approxUSDEq(summary.CostUSD, 0.03) {
+			t.Errorf("CostUSD = %v, want 0.03", summary.CostUSD)
+		}
+		got := acc.CostSnapshot().Session
+		if !approxUSDEq(got.TotalUSD, 0.03) {
+			t.Errorf("session TotalUSD = %v, want 0.03", got.TotalUSD)
+		}
+		// Split in the priced proportions: input 1/3, output 2/3.
+		if !approxUSDEq(got.InputUSD, 0.01) || !approxUSDEq(got.OutputUSD, 0.02) {
+			t.Errorf("breakdown = %v input / %v output, want 0.01 / 0.02", got.InputUSD, got.OutputUSD)
+		}
+	})
+
+	t.Run("reported cost without a priced model", func(t *testing.T) {
+		acc := NewAccumulator(100)
+		obs := NewObserver(token.NewHeuristicCounter(4), acc)
+		summary := obs.ObserveToolCallWithClient(context.Background(), mcp.ToolCallObservation{
+			ServerName: "llm", ReplicaID: -1, ClientID: "agent", ToolName: "summarize",
+			Result: &mcp.ToolCallResult{Usage: &mcp.CallUsage{CostUSD: 0.5}},
+		})
+		if !summary.HasCost || !approxUSDEq(summary.CostUSD, 0.5) {
+			t.Errorf("summary = %+v, want CostUSD 0.5", summary)
+		}
+		if got := acc.CostSnapshot().PerClient["agent"].TotalUSD; !approxUSDEq(got, 0.5) {
+			t.Errorf("per-client cost = %v, want 0.5", got)
+		}
+		if got := acc.ToolUsageSnapshot()["llm"]["summarize"].CostUSD(); !approxUSDEq(got, 0.5) {
+			t.Errorf("per-tool cost = %v, want 0.5", got)
+		}
+	})
+}
+
+func TestObserver_RecordsDailyUsage(t *testing.T) {
+	acc := NewAccumulator(100)
+	obs := NewObserver(token.NewHeuristicCounter(4), acc)
+	for range 2 {
+		obs.ObserveToolCallWithClient(context.Background(), mcp.ToolCallObservation{
+			ServerName: "llm", ReplicaID: -1, ClientID: "agent", ToolName: "summarize",
+			Result: &mcp.ToolCallResult{Usage: &mcp.CallUsage{InputTokens: 10, OutputTokens: 5, CostUSD: 0.25}},
+		})
+	}
+	// The legacy path has no tool name and stays out of the rollup.
+	obs.ObserveToolCall("llm", -1, nil, &mcp.ToolCallResult{})
+
+	rows := acc.DailyUsageSnapshot(1)
+	if len(rows) != 1 {
+		t.Fatalf("rows = %+v, want 1", rows)
+	}
+	r := rows[0]
+	if r.Client != "agent" || r.Server != "llm" || r.Tool != "summarize" || r.Calls != 2 {
+		t.Errorf("row = %+v", r)
+	}
+	if r.InputTokens != 20 || r.OutputTokens != 10 || !approxUSDEq(r.CostUSD(), 0.5) {
+		t.Errorf("row totals = %+v", r)
+	}
+}
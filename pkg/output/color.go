@@ -16,16 +16,17 @@ func SetNoColor(disabled bool) {
 }
 
 // ColorEnabled reports whether styled output should be emitted on w.
-// Color is disabled when SetNoColor(true) was called, NO_COLOR is set and
-// non-empty (https://no-color.org/), TERM is "dumb", or w is not a terminal.
+// Color is disabled when SetNoColor(true) or SetPlainMode(true) was called,
+// NO_COLOR is set and non-empty (https://no-color.org/), TERM is "dumb", or w is not a terminal.
 func ColorEnabled(w io.Writer) bool {
 	return colorAllowedByEnv() && isTerminal(w)
 }
 
 // colorAllowedByEnv applies every color gate that does not depend on the
-// output stream: the --no-color kill switch, NO_COLOR, and TERM=dumb.
+// output stream: the --no-color kill switch, plain mode, NO_COLOR, and
+// TERM=dumb.
 func colorAllowedByEnv() bool {
-	if noColor {
+	if noColor || plainMode {
 		return false
 	}
 	if os.Getenv("NO_COLOR") != "" {
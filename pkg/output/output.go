@@ -40,11 +40,13 @@ func New() *Printer {
 
 // NewWithWriter creates a Printer with a custom writer.
 func NewWithWriter(w io.Writer) *Printer {
-	isTTY := isTerminal(w)
+	// Plain mode treats every writer as a non-terminal so banners, hints,
+	// and box-drawing tables degrade exactly as they do when piped.
+	isTTY := isTerminal(w) && !plainMode
 	color := ColorEnabled(w)
 
 	logger := log.NewWithOptions(w, log.Options{
-		ReportTimestamp: true,
+		ReportTimestamp: !plainMode,
 		TimeFormat:      time.TimeOnly, // HH:MM:SS
 		Level:           defaultLevel,
 	})
@@ -58,6 +60,7 @@ func NewWithWriter(w io.Writer) *Printer {
 		logger: logger,
 		isTTY:  isTTY,
 		color:  color,
+		plain:  plainMode,
 	}
 }
 
@@ -0,0 +1,40 @@
+package output
+
+import (
+	"io"
+	"os"
+)
+
+// plainMode is the process-wide plain output switch, set by the global
+// --plain flag (or GRIDCTL_PLAIN) before command output is produced.
+var plainMode bool
+
+// SetPlainMode enables (or disables) plain output for the whole process:
+// no color, no spinners or in-place redraws, no ASCII banner or hints,
+// borderless tables, and log lines without wall-clock timestamps, so two
+// runs of the same command produce diffable output. Per-writer TTY
+// detection still applies on top when plain mode is off.
+func SetPlainMode(enabled bool) {
+	plainMode = enabled
+}
+
+// PlainMode reports whether process-wide plain output is enabled.
+func PlainMode() bool {
+	return plainMode
+}
+
+// DetectPlainMode reports whether plain output should be enabled without
+// an explicit flag. GRIDCTL_PLAIN decides when set: "0" or "false" keeps
+// styled output, any other value enables plain mode. Otherwise plain mode
+// follows w: on whenever it is not a terminal (a pipe, a file, or a CI log
+// capture).
+func DetectPlainMode(w io.Writer) bool {
+	switch v := os.Getenv("GRIDCTL_PLAIN"); v {
+	case "":
+	case "0", "false":
+		return false
+	default:
+		return true
+	}
+	return !isTerminal(w)
+}
@@ -99,3 +99,60 @@ func TestPlainTableStyleDecisionMatrix(t *testing.T) {
 		})
 	}
 }
+
+func TestPlainModeDisablesColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+	SetPlainMode(true)
+	t.Cleanup(func() { SetPlainMode(false) })
+
+	if !PlainMode() {
+		t.Fatal("PlainMode() = false after SetPlainMode(true)")
+	}
+	if colorAllowedByEnv() {
+		t.Error("expected plain mode to disable color")
+	}
+}
+
+func TestPlainModePrinterDropsTimestamps(t *testing.T) {
+	SetPlainMode(true)
+	t.Cleanup(func() { SetPlainMode(false) })
+
+	var buf bytes.Buffer
+	p := NewWithWriter(&buf)
+	p.Info("deployed", "servers", 2)
+
+	out := strings.TrimSpace(buf.String())
+	if !strings.HasPrefix(out, "INFO") {
+		t.Errorf("plain log line should start with the level, got %q", out)
+	}
+	if p.tableStyle().Name != "gridctl-plain" {
+		t.Error("expected plain table style in plain mode")
+	}
+}
+
+func TestDetectPlainMode(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{name: "non-terminal", want: true},
+		{name: "non-terminal outside CI", env: map[string]string{"CI": ""}, want: true},
+		{name: "GRIDCTL_PLAIN set", env: map[string]string{"GRIDCTL_PLAIN": "1"}, want: true},
+		{name: "GRIDCTL_PLAIN false", env: map[string]string{"GRIDCTL_PLAIN": "false"}, want: false},
+		{name: "GRIDCTL_PLAIN 0", env: map[string]string{"GRIDCTL_PLAIN": "0"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GRIDCTL_PLAIN", "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			var buf bytes.Buffer
+			if got := DetectPlainMode(&buf); got != tt.want {
+				t.Errorf("DetectPlainMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -38,8 +38,8 @@ type Reporter struct {
 // NewReporter creates a phase reporter writing to w. Animation runs only
 // when w is an interactive terminal, the CI environment variable is
 // unset, styling is not globally disabled (NO_COLOR, TERM=dumb,
-// --no-color), and ACCESSIBLE is not requested; everything else gets
-// static lines.
+// --no-color, --plain), and ACCESSIBLE is not requested; everything else
+// gets static lines.
 func NewReporter(w io.Writer) *Reporter {
 	return &Reporter{
 		w: w,
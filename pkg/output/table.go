@@ -357,13 +357,13 @@ func plainTableStyle() table.Style {
 
 // NewTableWriter returns a go-pretty table writer bound to w using the
 // shared gridctl style. Plain rendering is used when forced by a --plain
-// flag or when w is not a terminal, so piped output never contains box
-// runes. Commands that render tables outside a Printer share this
+// flag, when process-wide plain mode is on, or when w is not a terminal,
+// so piped output never contains box runes. Commands that render tables outside a Printer share this
 // chokepoint instead of hand-rolling styles.
 func NewTableWriter(w io.Writer, plain bool) table.Writer {
 	t := table.NewWriter()
 	t.SetOutputMirror(w)
-	if plain || !isTerminal(w) {
+	if plain || plainMode || !isTerminal(w) {
 		t.SetStyle(plainTableStyle())
 	} else {
 		t.SetStyle(roundedTableStyle(ColorEnabled(w)))
@@ -81,3 +81,9 @@ func pruneBackups(originalPath string) error {
 func CreateBackup(path string) (string, error) {
 	return createBackup(path)
 }
+
+// BackupPattern returns the filepath.Match pattern for the base names of
+// path's backups, for callers that enforce their own retention on them.
+func BackupPattern(path string) string {
+	return filepath.Base(path) + backupSuffix + "*"
+}
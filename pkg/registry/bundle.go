@@ -0,0 +1,363 @@
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Bundle archive layout: a gzipped tar holding the manifest at the root and
+// the skill directory under a single top-level folder named after the skill,
+// so extracting one by hand also yields a usable skill directory.
+//
+//	gridctl-bundle.json
+//	<name>/SKILL.md
+//	<name>/scripts/...
+const (
+	BundleManifestName = "gridctl-bundle.json"
+	BundleFormat       = 1
+
+	// Limits applied when reading a bundle, so an archive from an untrusted
+	// source cannot exhaust memory or disk.
+	maxBundleFiles = 1000
+	maxBundleBytes = 50 << 20 // uncompressed total
+)
+
+// ErrExists is returned when importing a bundle over an existing skill
+// without Force.
+var ErrExists = errors.New("already exists")
+
+// BundleManifest describes a skill bundle. Files lists every file in the
+// skill directory, SKILL.md included, with a SHA-256 checked on import.
+type BundleManifest struct {
+	Format      int          `json:"format"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	ExportedAt  time.Time    `json:"exportedAt"`
+	Files       []BundleFile `json:"files"`
+}
+
+// BundleFile is one file entry in a bundle manifest.
+type BundleFile struct {
+	Path   string `json:"path"` // Slash-separated, relative to the skill directory
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Bundle is a skill read from a bundle archive, ready to install.
+type Bundle struct {
+	Manifest BundleManifest
+	Skill    *AgentSkill       // Parsed SKILL.md
+	Files    map[string][]byte // Supporting files by manifest path (SKILL.md excluded)
+	Modes    map[string]fs.FileMode
+}
+
+// ExportBundle writes the named skill as a bundle archive to w. Hidden files
+// (such as the .origin.json import sidecar) are left out: they describe this
+// gateway's copy, not the skill.
+func (s *Store) ExportBundle(name string, w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sk, ok := s.skills[name]
+	if !ok {
+		return fmt.Errorf("skill %q: %w", name, ErrNotFound)
+	}
+	skillDir := s.skillDirPath(name)
+
+	type entry struct {
+		path string
+		data []byte
+		mode fs.FileMode
+	}
+	var entries []entry
+	err := filepath.WalkDir(skillDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(skillDir, p)
+		if rel == "." {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") || strings.HasSuffix(d.Name(), ".tmp") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil // directories are implied; symlinks are not bundled
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{path: filepath.ToSlash(rel), data: data, mode: info.Mode().Perm()})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("reading skill %q: %w", name, err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+
+	manifest := BundleManifest{
+		Format:      BundleFormat,
+		Name:        sk.Name,
+		Description: sk.Description,
+		ExportedAt:  time.Now().UTC().Truncate(time.Second),
+		Files:       make([]BundleFile, 0, len(entries)),
+	}
+	for _, e := range entries {
+		sum := sha256.Sum256(e.data)
+		manifest.Files = append(manifest.Files, BundleFile{Path: e.path, Size: int64(len(e.data)), SHA256: hex.EncodeToString(sum[:])})
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling bundle manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	writeEntry := func(name string, data []byte, mode fs.FileMode) error {
+		hdr := &tar.Header{
+			Name:     name,
+			Mode:     int64(mode),
+			Size:     int64(len(data)),
+			ModTime:  manifest.ExportedAt,
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	if err := writeEntry(BundleManifestName, append(manifestData, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+	for _, e := range entries {
+		if err := writeEntry(sk.Name+"/"+e.path, e.data, e.mode); err != nil {
+			return fmt.Errorf("writing bundle: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+	return gz.Close()
+}
+
+// ReadBundle reads and verifies a bundle archive. Every entry must be a
+// regular file under the skill's folder and listed in the manifest with a
+// matching checksum; links, absolute paths, and ".." components are
+// rejected rather than skipped.
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundle: not a gzip archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	var (
+		manifestData []byte
+		files        = make(map[string][]byte)
+		modes        = make(map[string]fs.FileMode)
+		total        int64
+	)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading bundle: %w", err)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+		default:
+			return nil, fmt.Errorf("reading bundle: %q is not a regular file", hdr.Name)
+		}
+		if len(files) >= maxBundleFiles {
+			return nil, fmt.Errorf("reading bundle: more than %d files", maxBundleFiles)
+		}
+		total += hdr.Size
+		if hdr.Size < 0 || total > maxBundleBytes {
+			return nil, fmt.Errorf("reading bundle: contents exceed %d MB", maxBundleBytes>>20)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, hdr.Size))
+		if err != nil {
+			return nil, fmt.Errorf("reading bundle: %w", err)
+		}
+		if hdr.Name == BundleManifestName {
+			manifestData = data
+			continue
+		}
+		if !validBundlePath(hdr.Name) {
+			return nil, fmt.Errorf("reading bundle: unsafe path %q", hdr.Name)
+		}
+		files[hdr.Name] = data
+		modes[hdr.Name] = fs.FileMode(hdr.Mode).Perm()
+	}
+	if manifestData == nil {
+		return nil, fmt.Errorf("reading bundle: missing %s", BundleManifestName)
+	}
+
+	var manifest BundleManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("reading bundle: parsing manifest: %w", err)
+	}
+	if manifest.Format != BundleFormat {
+		return nil, fmt.Errorf("reading bundle: unsupported format %d (want %d)", manifest.Format, BundleFormat)
+	}
+	if err := ValidateSkillName(manifest.Name); err != nil {
+		return nil, fmt.Errorf("reading bundle: %w", err)
+	}
+
+	b := &Bundle{Manifest: manifest, Files: make(map[string][]byte), Modes: make(map[string]fs.FileMode)}
+	root := manifest.Name + "/"
+	for _, f := range manifest.Files {
+		data, ok := files[root+f.Path]
+		if !ok {
+			return nil, fmt.Errorf("reading bundle: %s listed in manifest but missing", f.Path)
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != f.SHA256 {
+			return nil, fmt.Errorf("reading bundle: checksum mismatch for %s", f.Path)
+		}
+		delete(files, root+f.Path)
+		if f.Path == "SKILL.md" {
+			sk, err := ParseSkillMD(data)
+			if err != nil {
+				return nil, fmt.Errorf("reading bundle: %w", err)
+			}
+			b.Skill = sk
+			continue
+		}
+		b.Files[f.Path] = data
+		b.Modes[f.Path] = modes[root+f.Path]
+	}
+	for name := range files {
+		return nil, fmt.Errorf("reading bundle: %q is not listed in the manifest", name)
+	}
+	if b.Skill == nil {
+		return nil, fmt.Errorf("reading bundle: missing SKILL.md")
+	}
+	b.Skill.Name = manifest.Name
+	return b, nil
+}
+
+// validBundlePath reports whether an archive entry name is a clean,
+// relative, slash-separated path.
+func validBundlePath(name string) bool {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") || path.Clean(name) != name {
+		return false
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." || part == "." {
+			return false
+		}
+	}
+	return true
+}
+
+// InstallBundle writes b into the store as a skill named b.Skill.Name,
+// rendering SKILL.md from b.Skill so a rename or state change is reflected
+// in its frontmatter. An existing skill of that name is replaced only when
+// force is set. The new directory is assembled beside the store and swapped
+// in with a rename, so a failed install leaves the previous skill intact.
+func (s *Store) InstallBundle(b *Bundle, force bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sk := *b.Skill
+	if err := sk.Validate(); err != nil {
+		return fmt.Errorf("validating skill: %w", err)
+	}
+	existing, exists := s.skills[sk.Name]
+	if exists && !force {
+		return fmt.Errorf("skill %q: %w", sk.Name, ErrExists)
+	}
+
+	tmpDir, err := os.MkdirTemp(s.baseDir, ".bundle-")
+	if err != nil {
+		return fmt.Errorf("creating staging directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	data, err := RenderSkillMD(&sk)
+	if err != nil {
+		return fmt.Errorf("rendering SKILL.md: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "SKILL.md"), data, 0644); err != nil {
+		return fmt.Errorf("writing SKILL.md: %w", err)
+	}
+	for rel, content := range b.Files {
+		dest := filepath.Join(tmpDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("creating directory: %w", err)
+		}
+		mode := fs.FileMode(0644)
+		if b.Modes[rel]&0111 != 0 {
+			mode = 0755
+		}
+		if err := os.WriteFile(dest, content, mode); err != nil {
+			return fmt.Errorf("writing %s: %w", rel, err)
+		}
+	}
+
+	sk.Dir = sk.Name
+	if exists {
+		sk.Dir = existing.Dir
+	}
+	skillDir := filepath.Join(s.baseDir, "skills", sk.Dir)
+	if err := os.MkdirAll(filepath.Dir(skillDir), 0755); err != nil {
+		return fmt.Errorf("creating skills directory: %w", err)
+	}
+	if exists {
+		old := tmpDir + ".old"
+		if err := os.Rename(skillDir, old); err != nil {
+			return fmt.Errorf("replacing skill %q: %w", sk.Name, err)
+		}
+		defer func() { _ = os.RemoveAll(old) }()
+		if err := os.Rename(tmpDir, skillDir); err != nil {
+			_ = os.Rename(old, skillDir)
+			return fmt.Errorf("installing skill %q: %w", sk.Name, err)
+		}
+	} else if err := os.Rename(tmpDir, skillDir); err != nil {
+		return fmt.Errorf("installing skill %q: %w", sk.Name, err)
+	}
+
+	sk.FileCount = countSupportingFiles(skillDir)
+	sk.Version = skillVersion(data)
+	s.skills[sk.Name] = &sk
+	s.rebuildTagIndexLocked()
+	return nil
+}
+
+// BundleBytes returns the named skill as a bundle archive in memory.
+func (s *Store) BundleBytes(name string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.ExportBundle(name, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
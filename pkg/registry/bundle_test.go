@@ -0,0 +1,206 @@
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBundle_RoundTrip(t *testing.T) {
+	src := newTestStore(t)
+	writeSkillMD(t, src.Dir(), "deploy", "---\nname: deploy\ndescription: Ship it\nstate: active\n---\n\n# Deploy\n")
+	skillDir := filepath.Join(src.Dir(), "skills", "deploy")
+	if err := os.MkdirAll(filepath.Join(skillDir, "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "scripts", "run.sh"), []byte("#!/bin/sh\necho hi\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, ".origin.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := src.ExportBundle("deploy", &buf); err != nil {
+		t.Fatalf("ExportBundle: %v", err)
+	}
+	b, err := ReadBundle(&buf)
+	if err != nil {
+		t.Fatalf("ReadBundle: %v", err)
+	}
+	if b.Manifest.Name != "deploy" || b.Skill.Description != "Ship it" {
+		t.Errorf("bundle = %+v / %+v", b.Manifest, b.Skill)
+	}
+	if _, ok := b.Files[".origin.json"]; ok {
+		t.Error("hidden origin sidecar was bundled")
+	}
+
+	dst := newTestStore(t)
+	b.Skill.Name = "deploy-copy"
+	if err := dst.InstallBundle(b, false); err != nil {
+		t.Fatalf("InstallBundle: %v", err)
+	}
+	sk, err := dst.GetSkill("deploy-copy")
+	if err != nil {
+		t.Fatalf("GetSkill: %v", err)
+	}
+	if sk.FileCount != 1 {
+		t.Errorf("FileCount = %d, want 1", sk.FileCount)
+	}
+	installedVersion := sk.Version
+	if installedVersion == "" {
+		t.Error("installed skill has no version")
+	}
+	info, err := os.Stat(filepath.Join(dst.Dir(), "skills", "deploy-copy", "scripts", "run.sh"))
+	if err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("script not installed executable: %v %v", info, err)
+	}
+
+	// The installed SKILL.md carries the new name, so a reload agrees.
+	if err := dst.Load(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := dst.GetSkill("deploy-copy")
+	if err != nil {
+		t.Fatalf("reload lost renamed skill: %v", err)
+	}
+	if reloaded.Version != installedVersion {
+		t.Errorf("Version after install = %q, reload = %q", installedVersion, reloaded.Version)
+	}
+
+	if err := dst.InstallBundle(b, false); !errors.Is(err, ErrExists) {
+		t.Errorf("second install err = %v, want ErrExists", err)
+	}
+	b.Skill.Description = "Replaced"
+	if err := dst.InstallBundle(b, true); err != nil {
+		t.Fatalf("forced install: %v", err)
+	}
+	sk, _ = dst.GetSkill("deploy-copy")
+	if sk.Description != "Replaced" {
+		t.Errorf("forced install did not replace: %q", sk.Description)
+	}
+
+	// A conditional update against the version the install reported succeeds.
+	edit := *sk
+	edit.Description = "Edited"
+	if err := dst.UpdateSkill(&edit, sk.Version); err != nil {
+		t.Errorf("UpdateSkill after install: %v", err)
+	}
+}
+
+func TestExportBundle_NotFound(t *testing.T) {
+	if err := newTestStore(t).ExportBundle("missing", &bytes.Buffer{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+// buildBundle writes a gzipped tar with the given entries and a manifest
+// covering files (path relative to the skill folder).
+func buildBundle(t *testing.T, name string, files map[string]string, extra ...*tar.Header) []byte {
+	t.Helper()
+	m := BundleManifest{Format: BundleFormat, Name: name}
+	for p, content := range files {
+		sum := sha256.Sum256([]byte(content))
+		m.Files = append(m.Files, BundleFile{Path: p, Size: int64(len(content)), SHA256: hex.EncodeToString(sum[:])})
+	}
+	manifest, _ := json.Marshal(m)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	write := func(hdr *tar.Header, data string) {
+		hdr.Size = int64(len(data))
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(&tar.Header{Name: BundleManifestName, Mode: 0644, Typeflag: tar.TypeReg}, string(manifest))
+	for p, content := range files {
+		write(&tar.Header{Name: name + "/" + p, Mode: 0644, Typeflag: tar.TypeReg}, content)
+	}
+	for _, hdr := range extra {
+		write(hdr, "")
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	return buf.Bytes()
+}
+
+func TestReadBundle_Rejects(t *testing.T) {
+	skillMD := "---\nname: deploy\ndescription: d\n---\n"
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"not gzip", []byte("plain text"), "not a gzip archive"},
+		{"no manifest", func() []byte {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			_ = tar.NewWriter(gz).Close()
+			_ = gz.Close()
+			return buf.Bytes()
+		}(), "missing " + BundleManifestName},
+		{"no SKILL.md", buildBundle(t, "deploy", map[string]string{"notes.md": "x"}), "missing SKILL.md"},
+		{"traversal", buildBundle(t, "deploy", map[string]string{"SKILL.md": skillMD},
+			&tar.Header{Name: "deploy/../../evil", Typeflag: tar.TypeReg}), "unsafe path"},
+		{"symlink", buildBundle(t, "deploy", map[string]string{"SKILL.md": skillMD},
+			&tar.Header{Name: "deploy/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}), "not a regular file"},
+		{"unlisted file", buildBundle(t, "deploy", map[string]string{"SKILL.md": skillMD},
+			&tar.Header{Name: "deploy/extra.sh", Typeflag: tar.TypeReg}), "not listed in the manifest"},
+		{"bad name", buildBundle(t, "Bad_Name", map[string]string{"SKILL.md": skillMD}), "lowercase"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ReadBundle(bytes.NewReader(tc.data))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("err = %v, want containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadBundle_ChecksumMismatch(t *testing.T) {
+	data := buildBundle(t, "deploy", map[string]string{"SKILL.md": "---\nname: deploy\ndescription: d\n---\n"})
+
+	// Rewrite the archive with SKILL.md content changed but the manifest kept.
+	gz, _ := gzip.NewReader(bytes.NewReader(data))
+	tr := tar.NewReader(gz)
+	var out bytes.Buffer
+	gzw := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gzw)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		content := new(bytes.Buffer)
+		_, _ = content.ReadFrom(tr)
+		if hdr.Name == "deploy/SKILL.md" {
+			content = bytes.NewBufferString("---\nname: deploy\ndescription: tampered\n---\n")
+		}
+		hdr.Size = int64(content.Len())
+		_ = tw.WriteHeader(hdr)
+		_, _ = tw.Write(content.Bytes())
+	}
+	_ = tw.Close()
+	_ = gzw.Close()
+
+	if _, err := ReadBundle(&out); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("err = %v, want checksum mismatch", err)
+	}
+}
@@ -0,0 +1,274 @@
+// Package registry — per-skill change history.
+//
+// Every edit saved through the API is summarized as a SkillDiff (which
+// frontmatter fields changed, which body sections and acceptance criteria
+// were added or removed) and appended to a changelog kept beside SKILL.md,
+// so reviewers can see what changed without comparing two full bodies.
+package registry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangelogFileName is the per-skill changelog, one JSON entry per line. The
+// leading dot keeps it out of bundles and supporting-file listings.
+const ChangelogFileName = ".changelog.jsonl"
+
+// maxChangelogEntries bounds a skill's changelog; older entries are dropped.
+const maxChangelogEntries = 200
+
+// FieldChange is one frontmatter field whose value changed.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// SkillDiff summarizes the difference between two versions of a skill.
+// Sections are the body's markdown headings.
+type SkillDiff struct {
+	Fields          []FieldChange `json:"fields,omitempty"`
+	SectionsAdded   []string      `json:"sectionsAdded,omitempty"`
+	SectionsRemoved []string      `json:"sectionsRemoved,omitempty"`
+	CriteriaAdded   []string      `json:"criteriaAdded,omitempty"`
+	CriteriaRemoved []string      `json:"criteriaRemoved,omitempty"`
+	BodyChanged     bool          `json:"bodyChanged"`
+}
+
+// Empty reports whether the two versions were identical.
+func (d SkillDiff) Empty() bool {
+	return len(d.Fields) == 0 && !d.BodyChanged &&
+		len(d.CriteriaAdded) == 0 && len(d.CriteriaRemoved) == 0
+}
+
+// ChangelogEntry is one saved edit.
+type ChangelogEntry struct {
+	Time time.Time `json:"time"`
+	SkillDiff
+}
+
+// DiffSkills compares two versions of a skill.
+func DiffSkills(old, updated *AgentSkill) SkillDiff {
+	var d SkillDiff
+	field := func(name, a, b string) {
+		if a != b {
+			d.Fields = append(d.Fields, FieldChange{Field: name, Old: a, New: b})
+		}
+	}
+	field("description", old.Description, updated.Description)
+	field("license", old.License, updated.License)
+	field("compatibility", old.Compatibility, updated.Compatibility)
+	field("allowed-tools", old.AllowedTools, updated.AllowedTools)
+	field("state", string(old.State), string(updated.State))
+	field("tags", strings.Join(old.Tags, ", "), strings.Join(updated.Tags, ", "))
+
+	for _, k := range sortedKeys(old.Metadata, updated.Metadata) {
+		field("metadata."+k, old.Metadata[k], updated.Metadata[k])
+	}
+
+	field("files.max_size", filesMaxSize(old.Files), filesMaxSize(updated.Files))
+	field("files.allow", filesAllow(old.Files), filesAllow(updated.Files))
+
+	for _, k := range sortedKeys(old.Variants, updated.Variants) {
+		field("variants."+k, weightString(old.Variants, k), weightString(updated.Variants, k))
+	}
+	field("arguments", argumentsString(old.Arguments), argumentsString(updated.Arguments))
+
+	d.CriteriaAdded, d.CriteriaRemoved = diffLists(old.AcceptanceCriteria, updated.AcceptanceCriteria)
+	if old.Body != updated.Body {
+		d.BodyChanged = true
+		d.SectionsAdded, d.SectionsRemoved = diffLists(bodySections(old.Body), bodySections(updated.Body))
+	}
+	return d
+}
+
+// sortedKeys returns the union of the maps' keys in sorted order.
+func sortedKeys[M ~map[string]V, V any](a, b M) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for _, m := range []M{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// weightString renders a variant weight for a FieldChange; "" when absent.
+func weightString(variants map[string]int, name string) string {
+	w, ok := variants[name]
+	if !ok {
+		return ""
+	}
+	return strconv.Itoa(w)
+}
+
+// argumentsString renders declared prompt arguments for a FieldChange,
+// e.g. "env (required), region=us-east-1".
+func argumentsString(args []SkillArgument) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		parts[i] = a.Name
+		if a.Required {
+			parts[i] += " (required)"
+		}
+		if a.Default != "" {
+			parts[i] += "=" + a.Default
+		}
+		if len(a.Values) > 0 {
+			parts[i] += " [" + strings.Join(a.Values, "|") + "]"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// filesMaxSize renders a file policy's size cap for a FieldChange.
+func filesMaxSize(p *FilePolicy) string {
+	if p == nil {
+		return ""
+	}
+	return p.MaxSize
+}
+
+// filesAllow renders a file policy's extension allowlist for a FieldChange.
+func filesAllow(p *FilePolicy) string {
+	if p == nil {
+		return ""
+	}
+	return strings.Join(p.Allow, ", ")
+}
+
+// diffLists returns the items only in b (added) and only in a (removed),
+// each in its original order.
+func diffLists(a, b []string) (added, removed []string) {
+	inA := make(map[string]bool, len(a))
+	for _, s := range a {
+		inA[s] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+		if !inA[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range a {
+		if !inB[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+// bodySections returns the markdown headings in body, skipping fenced code.
+func bodySections(body string) []string {
+	var sections []string
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		rest := strings.TrimLeft(trimmed, "#")
+		if len(trimmed)-len(rest) > 6 || !strings.HasPrefix(rest, " ") {
+			continue
+		}
+		if title := strings.TrimSpace(rest); title != "" {
+			sections = append(sections, title)
+		}
+	}
+	return sections
+}
+
+// AppendChangelog records an edit in the skill's changelog.
+func (s *Store) AppendChangelog(name string, entry ChangelogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.skills[name]; !ok {
+		return fmt.Errorf("skill %q: %w", name, ErrNotFound)
+	}
+	path := filepath.Join(s.skillDirPath(name), ChangelogFileName)
+	entries, err := readChangelog(path)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	if len(entries) > maxChangelogEntries {
+		entries = entries[len(entries)-maxChangelogEntries:]
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("encoding changelog entry: %w", err)
+		}
+	}
+	if err := atomicWriteBytes(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("writing changelog for %q: %w", name, err)
+	}
+	return nil
+}
+
+// Changelog returns a skill's recorded edits, newest first.
+func (s *Store) Changelog(name string) ([]ChangelogEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.skills[name]; !ok {
+		return nil, fmt.Errorf("skill %q: %w", name, ErrNotFound)
+	}
+	entries, err := readChangelog(filepath.Join(s.skillDirPath(name), ChangelogFileName))
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// readChangelog parses a changelog file, skipping malformed lines. A missing
+// file is an empty changelog.
+func readChangelog(path string) ([]ChangelogEntry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading changelog: %w", err)
+	}
+	defer f.Close()
+
+	var entries []ChangelogEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e ChangelogEntry
+		if json.Unmarshal(sc.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading changelog: %w", err)
+	}
+	return entries, nil
+}
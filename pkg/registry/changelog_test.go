@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffSkills(t *testing.T) {
+	old := &AgentSkill{
+		Name:               "deploy",
+		Description:        "Deploy the app",
+		State:              StateDraft,
+		Metadata:           SkillMetadata{"owner": "ops"},
+		AcceptanceCriteria: []string{"Given a tag, it deploys"},
+		Body:               "# Deploy\n\n## Build\n\n```sh\n# not a heading\n```\n\n## Verify\n",
+	}
+	updated := &AgentSkill{
+		Name:               "deploy",
+		Description:        "Deploy the app to production",
+		State:              StateDraft,
+		Metadata:           SkillMetadata{"owner": "ops", "tier": "1"},
+		AcceptanceCriteria: []string{"Given a tag, it deploys", "Given a failure, it rolls back"},
+		Body:               "# Deploy\n\n## Build\n\n## Rollback\n",
+	}
+
+	d := DiffSkills(old, updated)
+	wantFields := []FieldChange{
+		{Field: "description", Old: "Deploy the app", New: "Deploy the app to production"},
+		{Field: "metadata.tier", Old: "", New: "1"},
+	}
+	if !reflect.DeepEqual(d.Fields, wantFields) {
+		t.Errorf("Fields = %+v, want %+v", d.Fields, wantFields)
+	}
+	if !reflect.DeepEqual(d.SectionsAdded, []string{"Rollback"}) || !reflect.DeepEqual(d.SectionsRemoved, []string{"Verify"}) {
+		t.Errorf("sections added %v, removed %v", d.SectionsAdded, d.SectionsRemoved)
+	}
+	if !reflect.DeepEqual(d.CriteriaAdded, []string{"Given a failure, it rolls back"}) || d.CriteriaRemoved != nil {
+		t.Errorf("criteria added %v, removed %v", d.CriteriaAdded, d.CriteriaRemoved)
+	}
+	if !d.BodyChanged || d.Empty() {
+		t.Errorf("expected a non-empty diff with a body change: %+v", d)
+	}
+	if !DiffSkills(old, old).Empty() {
+		t.Error("expected identical skills to diff empty")
+	}
+}
+
+func TestStore_Changelog(t *testing.T) {
+	s := NewStore(t.TempDir())
+	if err := s.SaveSkill(&AgentSkill{Name: "deploy", Description: "Deploy", Body: "# Deploy\n"}); err != nil {
+		t.Fatal(err)
+	}
+	for i, state := range []string{"active", "disabled"} {
+		entry := ChangelogEntry{
+			Time:      time.Date(2026, 1, 1, i, 0, 0, 0, time.UTC),
+			SkillDiff: SkillDiff{Fields: []FieldChange{{Field: "state", New: state}}},
+		}
+		if err := s.AppendChangelog("deploy", entry); err != nil {
+			t.Fatalf("AppendChangelog: %v", err)
+		}
+	}
+
+	entries, err := s.Changelog("deploy")
+	if err != nil {
+		t.Fatalf("Changelog: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Fields[0].New != "disabled" {
+		t.Errorf("expected 2 entries newest first, got %+v", entries)
+	}
+
+	files, err := s.ListFiles("deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("changelog should not be listed as a supporting file: %+v", files)
+	}
+
+	if _, err := s.Changelog("ghost"); err == nil {
+		t.Error("expected error for unknown skill")
+	}
+}
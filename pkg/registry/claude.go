@@ -0,0 +1,356 @@
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Claude Skills layout: one directory per skill, named after it, holding
+// SKILL.md and its supporting files, as read from ~/.claude/skills/ or a
+// project's .claude/skills/:
+//
+//	<root>/<name>/SKILL.md
+//	<root>/<name>/scripts/...
+//
+// Its frontmatter is the agentskills.io core plus Claude-only keys such as
+// model or disable-model-invocation. On import those keys are kept in
+// metadata under a "claude-" prefix, and on export they are lifted back, so
+// a skill survives a round trip. gridctl's own extensions have no Claude
+// equivalent and are left out of exports.
+
+// claudeMetadataPrefix marks metadata entries holding Claude-only
+// frontmatter keys.
+const claudeMetadataPrefix = "claude-"
+
+// gridctlOnlyKeys are frontmatter keys Claude does not understand.
+var gridctlOnlyKeys = []string{"state", "tags", "variants", "arguments", "files", "acceptance_criteria"}
+
+// coreFrontmatterKeys are the agentskills.io keys both formats share.
+var coreFrontmatterKeys = map[string]bool{
+	"name": true, "description": true, "license": true,
+	"compatibility": true, "metadata": true, "allowed-tools": true,
+}
+
+// ExportClaude writes the named skill into root in the Claude Skills layout
+// and returns the skill directory it wrote. An existing directory is
+// replaced only when force is set. Hidden files and variants/ are left out.
+// dropped lists the gridctl-only frontmatter fields the skill sets, which
+// the export cannot carry.
+func (s *Store) ExportClaude(name, root string, force bool) (dir string, dropped []string, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sk, ok := s.skills[name]
+	if !ok {
+		return "", nil, fmt.Errorf("skill %q: %w", name, ErrNotFound)
+	}
+	data, err := renderClaudeSkillMD(sk)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir = filepath.Join(root, sk.Name)
+	if _, err := os.Lstat(dir); err == nil {
+		if !force {
+			return "", nil, fmt.Errorf("%s: %w", dir, ErrExists)
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return "", nil, fmt.Errorf("replacing %s: %w", dir, err)
+		}
+	}
+
+	skillDir := s.skillDirPath(name)
+	err = filepath.WalkDir(skillDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(skillDir, p)
+		if rel == "." {
+			return os.MkdirAll(dir, 0755)
+		}
+		if strings.HasPrefix(d.Name(), ".") || strings.HasSuffix(d.Name(), ".tmp") || rel == "variants" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		dest := filepath.Join(dir, rel)
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if rel == "SKILL.md" {
+			return os.WriteFile(dest, data, 0644)
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dest, content, info.Mode().Perm())
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("exporting skill %q: %w", name, err)
+	}
+	return dir, gridctlOnlyFields(sk), nil
+}
+
+// renderClaudeSkillMD renders sk's SKILL.md with only the frontmatter keys
+// Claude understands.
+func renderClaudeSkillMD(sk *AgentSkill) ([]byte, error) {
+	fm := &yaml.Node{Kind: yaml.MappingNode}
+	add := func(key string, value any) error {
+		var v yaml.Node
+		if err := v.Encode(value); err != nil {
+			return err
+		}
+		fm.Content = append(fm.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &v)
+		return nil
+	}
+	for _, kv := range []struct{ key, value string }{
+		{"name", sk.Name}, {"description", sk.Description}, {"license", sk.License},
+		{"compatibility", sk.Compatibility}, {"allowed-tools", sk.AllowedTools},
+	} {
+		if kv.value != "" {
+			if err := add(kv.key, kv.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(sk.Metadata))
+	for k := range sk.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	metadata := make(map[string]string)
+	for _, k := range keys {
+		v := sk.Metadata[k]
+		key, isClaude := strings.CutPrefix(k, claudeMetadataPrefix)
+		if !isClaude || key == "" || coreFrontmatterKeys[key] {
+			metadata[k] = v
+			continue
+		}
+		// Restore the original YAML type ("true" -> true, JSON lists).
+		var typed any
+		if err := yaml.Unmarshal([]byte(v), &typed); err != nil || typed == nil {
+			typed = v
+		}
+		if err := add(key, typed); err != nil {
+			return nil, err
+		}
+	}
+	if len(metadata) > 0 {
+		if err := add("metadata", metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	yamlBytes, err := yaml.Marshal(fm)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling frontmatter: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(yamlBytes)
+	buf.WriteString("---\n")
+	if sk.Body != "" {
+		buf.WriteString("\n")
+		buf.WriteString(sk.Body)
+	}
+	return buf.Bytes(), nil
+}
+
+// gridctlOnlyFields lists the gridctl-only frontmatter fields sk sets.
+// state is implied by every registry skill and not reported.
+func gridctlOnlyFields(sk *AgentSkill) []string {
+	var fields []string
+	if len(sk.Tags) > 0 {
+		fields = append(fields, "tags")
+	}
+	if len(sk.Variants) > 0 {
+		fields = append(fields, "variants")
+	}
+	if len(sk.Arguments) > 0 {
+		fields = append(fields, "arguments")
+	}
+	if sk.Files != nil {
+		fields = append(fields, "files")
+	}
+	if len(sk.AcceptanceCriteria) > 0 {
+		fields = append(fields, "acceptance_criteria")
+	}
+	return fields
+}
+
+// ReadClaudeSkills reads skills in the Claude Skills layout from path: a
+// single skill directory holding SKILL.md, or a skills root whose
+// subdirectories hold one skill each. Each skill is returned as a Bundle
+// ready for the same validation, security scan, and install as an archive.
+func ReadClaudeSkills(path string) ([]*Bundle, error) {
+	if _, err := os.Stat(filepath.Join(path, "SKILL.md")); err == nil {
+		b, err := readClaudeSkill(path)
+		if err != nil {
+			return nil, err
+		}
+		return []*Bundle{b}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var bundles []*Bundle
+	for _, e := range entries {
+		dir := filepath.Join(path, e.Name())
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); err != nil {
+			continue
+		}
+		b, err := readClaudeSkill(dir)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, b)
+	}
+	if len(bundles) == 0 {
+		return nil, fmt.Errorf("no SKILL.md found in %s or its subdirectories", path)
+	}
+	return bundles, nil
+}
+
+// readClaudeSkill reads one skill directory in the Claude Skills layout.
+func readClaudeSkill(dir string) (*Bundle, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		return nil, err
+	}
+	sk, err := parseClaudeSkillMD(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+	if sk.Name == "" {
+		sk.Name = filepath.Base(dir)
+	}
+
+	b := &Bundle{
+		Manifest: BundleManifest{Format: BundleFormat, Name: sk.Name, Description: sk.Description},
+		Skill:    sk,
+		Files:    make(map[string][]byte),
+		Modes:    make(map[string]fs.FileMode),
+	}
+	var total int64
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		if rel == "." {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || rel == "SKILL.md" {
+			return nil // symlinks are not followed
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += int64(len(content))
+		if len(b.Files) >= maxBundleFiles || total > maxBundleBytes {
+			return fmt.Errorf("skill directory %s is too large", dir)
+		}
+		rel = filepath.ToSlash(rel)
+		b.Files[rel] = content
+		b.Modes[rel] = info.Mode().Perm()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// parseClaudeSkillMD parses a Claude SKILL.md. allowed-tools may be a YAML
+// list (joined with spaces, the agentskills.io form), and keys outside the
+// agentskills.io core and gridctl's extensions are kept in metadata under
+// claudeMetadataPrefix.
+func parseClaudeSkillMD(data []byte) (*AgentSkill, error) {
+	frontmatter, body, ok := splitFrontmatter(data)
+	if !ok || strings.TrimSpace(frontmatter) == "" {
+		return ParseSkillMD(data)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatter), &doc); err != nil {
+		return nil, fmt.Errorf("parsing frontmatter: %w", err)
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parsing frontmatter: expected a mapping")
+	}
+	root := doc.Content[0]
+
+	known := make(map[string]bool, len(coreFrontmatterKeys)+len(gridctlOnlyKeys))
+	for k := range coreFrontmatterKeys {
+		known[k] = true
+	}
+	for _, k := range gridctlOnlyKeys {
+		known[k] = true
+	}
+	extra := make(map[string]string)
+	kept := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i], root.Content[i+1]
+		switch {
+		case key.Value == "allowed-tools" && val.Kind == yaml.SequenceNode:
+			tools := make([]string, 0, len(val.Content))
+			for _, t := range val.Content {
+				tools = append(tools, t.Value)
+			}
+			val = &yaml.Node{Kind: yaml.ScalarNode, Value: strings.Join(tools, " ")}
+		case !known[key.Value]:
+			extra[claudeMetadataPrefix+key.Value] = metadataNodeString(val)
+			continue
+		}
+		kept.Content = append(kept.Content, key, val)
+	}
+
+	var sk AgentSkill
+	if err := kept.Decode(&sk); err != nil {
+		return nil, fmt.Errorf("parsing frontmatter: %w", err)
+	}
+	if len(extra) > 0 && sk.Metadata == nil {
+		sk.Metadata = make(SkillMetadata, len(extra))
+	}
+	for k, v := range extra {
+		sk.Metadata[k] = v
+	}
+	sk.Body = body
+	_ = validateState(&sk.State)
+	return &sk, nil
+}
@@ -0,0 +1,103 @@
+package registry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClaude_RoundTrip(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "review")
+	if err := os.MkdirAll(filepath.Join(dir, "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	skillMD := "---\nname: review\ndescription: Review a diff\nallowed-tools:\n  - Read\n  - Grep\nmodel: sonnet\ndisable-model-invocation: true\n---\n\n# Review\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "scripts", "lint.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	bundles, err := ReadClaudeSkills(root)
+	if err != nil {
+		t.Fatalf("ReadClaudeSkills: %v", err)
+	}
+	if len(bundles) != 1 {
+		t.Fatalf("got %d bundles, want 1", len(bundles))
+	}
+	b := bundles[0]
+	if b.Skill.AllowedTools != "Read Grep" {
+		t.Errorf("AllowedTools = %q, want %q", b.Skill.AllowedTools, "Read Grep")
+	}
+	if b.Skill.Metadata["claude-model"] != "sonnet" || b.Skill.Metadata["claude-disable-model-invocation"] != "true" {
+		t.Errorf("Claude-only keys not kept in metadata: %v", b.Skill.Metadata)
+	}
+	if _, ok := b.Files["scripts/lint.sh"]; !ok {
+		t.Errorf("supporting file missing, got %v", b.Files)
+	}
+
+	store := newTestStore(t)
+	b.Skill.Tags = []string{"ci"}
+	if err := store.InstallBundle(b, false); err != nil {
+		t.Fatalf("InstallBundle: %v", err)
+	}
+
+	out := t.TempDir()
+	exported, dropped, err := store.ExportClaude("review", out, false)
+	if err != nil {
+		t.Fatalf("ExportClaude: %v", err)
+	}
+	if len(dropped) != 1 || dropped[0] != "tags" {
+		t.Errorf("dropped = %v, want [tags]", dropped)
+	}
+	data, err := os.ReadFile(filepath.Join(exported, "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{"model: sonnet\n", "disable-model-invocation: true\n", "allowed-tools: Read Grep\n", "# Review"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("exported SKILL.md missing %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"state:", "tags:", "metadata:"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("exported SKILL.md should not contain %q:\n%s", unwanted, got)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(exported, "scripts", "lint.sh")); err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("script not exported executable: %v %v", info, err)
+	}
+
+	if _, _, err := store.ExportClaude("review", out, false); !errors.Is(err, ErrExists) {
+		t.Errorf("second export err = %v, want ErrExists", err)
+	}
+	if _, _, err := store.ExportClaude("review", out, true); err != nil {
+		t.Errorf("forced export: %v", err)
+	}
+}
+
+func TestReadClaudeSkills_NameFromDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "notes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\ndescription: Take notes\n---\n\nBody\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bundles, err := ReadClaudeSkills(dir)
+	if err != nil {
+		t.Fatalf("ReadClaudeSkills: %v", err)
+	}
+	if bundles[0].Manifest.Name != "notes" || bundles[0].Skill.Name != "notes" {
+		t.Errorf("name = %q/%q, want notes", bundles[0].Manifest.Name, bundles[0].Skill.Name)
+	}
+
+	if _, err := ReadClaudeSkills(t.TempDir()); err == nil {
+		t.Error("expected an error for a directory without skills")
+	}
+}
@@ -0,0 +1,156 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultMaxFileSize caps a supporting file when the skill sets no
+	// files.max_size.
+	DefaultMaxFileSize = 1 << 20
+	// MaxFileSizeLimit is the largest files.max_size a skill may set.
+	MaxFileSizeLimit = 100 << 20
+)
+
+// ErrFileTooLarge is returned when a file exceeds the skill's size limit.
+var ErrFileTooLarge = errors.New("file too large")
+
+// ErrFileTypeNotAllowed is returned when a file's extension is not in the
+// skill's allowlist.
+var ErrFileTypeNotAllowed = errors.New("file type not allowed")
+
+// FilePolicy limits the supporting files written to a skill through the
+// file API. Gridctl extension; not part of agentskills.io spec.
+type FilePolicy struct {
+	// MaxSize caps each file, e.g. "10MB". Defaults to 1MB; at most 100MB.
+	MaxSize string `yaml:"max_size,omitempty" json:"maxSize,omitempty"`
+	// Allow lists the permitted extensions (".pdf", "csv"). Empty allows any.
+	Allow []string `yaml:"allow,omitempty" json:"allow,omitempty"`
+}
+
+// Limit returns the per-file size cap in bytes. A nil or unparseable policy
+// yields DefaultMaxFileSize; validation reports the latter.
+func (p *FilePolicy) Limit() int64 {
+	if p == nil || p.MaxSize == "" {
+		return DefaultMaxFileSize
+	}
+	n, err := ParseFileSize(p.MaxSize)
+	if err != nil || n > MaxFileSizeLimit {
+		return DefaultMaxFileSize
+	}
+	return n
+}
+
+// Allows reports whether the policy permits a file at path. Extensions
+// compare case-insensitively, with or without the leading dot.
+func (p *FilePolicy) Allows(path string) bool {
+	if p == nil || len(p.Allow) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == "" {
+		return false
+	}
+	for _, a := range p.Allow {
+		if normalizeExt(a) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFileSize parses a size such as "512KB", "10MB", "1GB", or a plain
+// byte count. Units are binary (1KB = 1024 bytes) and case-insensitive.
+func ParseFileSize(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	mult := int64(1)
+	for _, u := range []struct {
+		suffix string
+		mult   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(v, u.suffix) {
+			v, mult = strings.TrimSpace(strings.TrimSuffix(v, u.suffix)), u.mult
+			break
+		}
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 || n > math.MaxInt64/mult {
+		return 0, fmt.Errorf("invalid size %q: want a positive number with an optional B, KB, MB, or GB unit", s)
+	}
+	return n * mult, nil
+}
+
+// validateFilePolicy checks the files block of a skill's frontmatter.
+func validateFilePolicy(p *FilePolicy) []string {
+	if p == nil {
+		return nil
+	}
+	var errs []string
+	if p.MaxSize != "" {
+		n, err := ParseFileSize(p.MaxSize)
+		switch {
+		case err != nil:
+			errs = append(errs, "files.max_size: "+err.Error())
+		case n > MaxFileSizeLimit:
+			errs = append(errs, fmt.Sprintf("files.max_size %q exceeds the %dMB limit", p.MaxSize, MaxFileSizeLimit>>20))
+		}
+	}
+	for _, a := range p.Allow {
+		ext := normalizeExt(a)
+		if ext == "." || strings.ContainsAny(ext[1:], `./\ `) {
+			errs = append(errs, fmt.Sprintf("files.allow: invalid extension %q", a))
+		}
+	}
+	return errs
+}
+
+// normalizeExt lowercases an extension and gives it a leading dot.
+func normalizeExt(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// FileLimit returns the per-file size cap for a skill's supporting files.
+func (s *Store) FileLimit(skillName string) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if sk, ok := s.skills[skillName]; ok {
+		return sk.Files.Limit()
+	}
+	return DefaultMaxFileSize
+}
+
+// CheckFile reports whether a file of the given size may be written to the
+// skill at filePath (a safe path within the policy), without writing it. Callers writing several files use
+// it to reject a batch before any file lands.
+func (s *Store) CheckFile(skillName, filePath string, size int64) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, err := s.safeFilePath(skillName, filePath); err != nil {
+		return err
+	}
+	return s.checkFileLocked(skillName, filePath, size)
+}
+
+// checkFileLocked applies the skill's file policy. Callers hold s.mu.
+func (s *Store) checkFileLocked(skillName, filePath string, size int64) error {
+	var policy *FilePolicy
+	if sk, ok := s.skills[skillName]; ok {
+		policy = sk.Files
+	}
+	if limit := policy.Limit(); size > limit {
+		return fmt.Errorf("%q is %d bytes, over the %d byte limit: %w", filePath, size, limit, ErrFileTooLarge)
+	}
+	if !policy.Allows(filePath) {
+		return fmt.Errorf("%q: allowed extensions are %s: %w", filePath, strings.Join(policy.Allow, ", "), ErrFileTypeNotAllowed)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseFileSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"512", 512, false},
+		{"10B", 10, false},
+		{"64kb", 64 << 10, false},
+		{"10MB", 10 << 20, false},
+		{"1 GB", 1 << 30, false},
+		{"", 0, true},
+		{"0MB", 0, true},
+		{"-1KB", 0, true},
+		{"1.5MB", 0, true},
+		{"10TB", 0, true},
+		{"9223372036854775807GB", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseFileSize(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFileSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFileSize(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilePolicy_Allows(t *testing.T) {
+	p := &FilePolicy{Allow: []string{".pdf", "CSV"}}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"references/spec.pdf", true},
+		{"data/rows.csv", true},
+		{"data/ROWS.CSV", true},
+		{"scripts/run.sh", false},
+		{"Makefile", false},
+	}
+	for _, tt := range tests {
+		if got := p.Allows(tt.path); got != tt.want {
+			t.Errorf("Allows(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+	var none *FilePolicy
+	if !none.Allows("anything.bin") {
+		t.Error("nil policy should allow any extension")
+	}
+}
+
+func TestValidateFilePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *FilePolicy
+		wantErr string
+	}{
+		{"nil", nil, ""},
+		{"valid", &FilePolicy{MaxSize: "50MB", Allow: []string{".pdf", "parquet"}}, ""},
+		{"bad size", &FilePolicy{MaxSize: "lots"}, "files.max_size"},
+		{"over limit", &FilePolicy{MaxSize: "200MB"}, "exceeds the 100MB limit"},
+		{"bad extension", &FilePolicy{Allow: []string{"tar.gz"}}, "invalid extension"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateFilePolicy(tt.policy)
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0], tt.wantErr) {
+				t.Errorf("errors = %v, want one containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStore_WriteFile_EnforcesPolicy(t *testing.T) {
+	s := newTestStore(t)
+	sk := &AgentSkill{
+		Name:        "docs",
+		Description: "Ships reference PDFs",
+		State:       StateDraft,
+		Files:       &FilePolicy{MaxSize: "2MB", Allow: []string{".pdf"}},
+	}
+	if err := s.SaveSkill(sk); err != nil {
+		t.Fatal(err)
+	}
+
+	// Over the 1MB default but within the skill's own limit.
+	if err := s.WriteFile("docs", "references/big.pdf", make([]byte, 1536<<10)); err != nil {
+		t.Fatalf("WriteFile within policy: %v", err)
+	}
+	if err := s.WriteFile("docs", "references/huge.pdf", make([]byte, 3<<20)); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("expected ErrFileTooLarge, got %v", err)
+	}
+	if err := s.WriteFile("docs", "scripts/run.sh", []byte("echo")); !errors.Is(err, ErrFileTypeNotAllowed) {
+		t.Errorf("expected ErrFileTypeNotAllowed, got %v", err)
+	}
+	if got := s.FileLimit("docs"); got != 2<<20 {
+		t.Errorf("FileLimit = %d, want %d", got, 2<<20)
+	}
+	if got := s.FileLimit("unknown"); got != DefaultMaxFileSize {
+		t.Errorf("FileLimit for unknown skill = %d, want default", got)
+	}
+}
+
+func TestRenderSkillMD_RoundTripsFilePolicy(t *testing.T) {
+	sk := &AgentSkill{
+		Name:        "docs",
+		Description: "Ships reference PDFs",
+		Files:       &FilePolicy{MaxSize: "10MB", Allow: []string{".pdf"}},
+	}
+	data, err := RenderSkillMD(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseSkillMD(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Files == nil || got.Files.MaxSize != "10MB" || len(got.Files.Allow) != 1 || got.Files.Allow[0] != ".pdf" {
+		t.Errorf("Files = %+v, want the rendered policy back", got.Files)
+	}
+}
@@ -61,6 +61,31 @@ func metadataNodeString(n *yaml.Node) string {
 // ParseSkillMD parses a SKILL.md file into an AgentSkill.
 // The file format is YAML frontmatter between --- delimiters followed by a markdown body.
 func ParseSkillMD(data []byte) (*AgentSkill, error) {
+	frontmatter, body, ok := splitFrontmatter(data)
+	if !ok {
+		// No frontmatter — entire content is body
+		skill := &AgentSkill{Body: body}
+		_ = validateState(&skill.State)
+		return skill, nil
+	}
+
+	var skill AgentSkill
+	if frontmatter != "" {
+		if err := yaml.Unmarshal([]byte(frontmatter), &skill); err != nil {
+			return nil, fmt.Errorf("parsing frontmatter: %w", err)
+		}
+	}
+
+	skill.Body = body
+	_ = validateState(&skill.State)
+
+	return &skill, nil
+}
+
+// splitFrontmatter splits SKILL.md content into its frontmatter YAML and
+// markdown body. ok is false when the file has no complete frontmatter
+// block, in which case body is the whole (line-ending normalized) content.
+func splitFrontmatter(data []byte) (frontmatter, body string, ok bool) {
 	content := string(data)
 
 	// Normalize Windows line endings
@@ -69,10 +94,7 @@ func ParseSkillMD(data []byte) (*AgentSkill, error) {
 	// Check if the file starts with frontmatter delimiter
 	trimmed := strings.TrimLeft(content, " \t")
 	if !strings.HasPrefix(trimmed, "---") {
-		// No frontmatter — entire content is body
-		skill := &AgentSkill{Body: content}
-		_ = validateState(&skill.State)
-		return skill, nil
+		return "", content, false
 	}
 
 	// Find frontmatter boundaries by scanning lines
@@ -95,9 +117,7 @@ func ParseSkillMD(data []byte) (*AgentSkill, error) {
 
 	// If we found the opening --- but no closing ---, treat as no frontmatter
 	if closeIdx == -1 {
-		skill := &AgentSkill{Body: content}
-		_ = validateState(&skill.State)
-		return skill, nil
+		return "", content, false
 	}
 
 	// Extract frontmatter YAML (between the delimiters, exclusive)
@@ -105,29 +125,15 @@ func ParseSkillMD(data []byte) (*AgentSkill, error) {
 	for i := openIdx + 1; i < closeIdx; i++ {
 		fmBuilder.WriteString(lines[i])
 	}
-	frontmatter := fmBuilder.String()
 
 	// Extract body (everything after the closing ---)
 	var bodyBuilder strings.Builder
 	for i := closeIdx + 1; i < len(lines); i++ {
 		bodyBuilder.WriteString(lines[i])
 	}
-	body := bodyBuilder.String()
 
 	// Trim a single leading newline from the body
-	body = strings.TrimPrefix(body, "\n")
-
-	var skill AgentSkill
-	if frontmatter != "" {
-		if err := yaml.Unmarshal([]byte(frontmatter), &skill); err != nil {
-			return nil, fmt.Errorf("parsing frontmatter: %w", err)
-		}
-	}
-
-	skill.Body = body
-	_ = validateState(&skill.State)
-
-	return &skill, nil
+	return fmBuilder.String(), strings.TrimPrefix(bodyBuilder.String(), "\n"), true
 }
 
 // RenderSkillMD serializes an AgentSkill back to SKILL.md format.
@@ -142,6 +148,10 @@ func RenderSkillMD(skill *AgentSkill) ([]byte, error) {
 		AllowedTools       string            `yaml:"allowed-tools,omitempty"`
 		AcceptanceCriteria []string          `yaml:"acceptance_criteria,omitempty"`
 		State              ItemState         `yaml:"state,omitempty"`
+		Tags               []string          `yaml:"tags,omitempty"`
+		Variants           map[string]int    `yaml:"variants,omitempty"`
+		Arguments          []SkillArgument   `yaml:"arguments,omitempty"`
+		Files              *FilePolicy       `yaml:"files,omitempty"`
 	}{
 		Name:               skill.Name,
 		Description:        skill.Description,
@@ -151,6 +161,10 @@ func RenderSkillMD(skill *AgentSkill) ([]byte, error) {
 		AllowedTools:       skill.AllowedTools,
 		AcceptanceCriteria: skill.AcceptanceCriteria,
 		State:              skill.State,
+		Tags:               skill.Tags,
+		Variants:           skill.Variants,
+		Arguments:          skill.Arguments,
+		Files:              skill.Files,
 	}
 
 	yamlBytes, err := yaml.Marshal(fm)
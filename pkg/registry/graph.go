@@ -0,0 +1,288 @@
+package registry
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// Dependency graph node kinds.
+const (
+	GraphNodeSkill  = "skill"
+	GraphNodeTool   = "tool"
+	GraphNodeServer = "server"
+)
+
+// How a skill depends on a tool or server.
+const (
+	GraphViaAllowedTools = "allowed-tools"
+	GraphViaBody         = "body"
+)
+
+// GraphNode is a skill, a tool, or an MCP server.
+type GraphNode struct {
+	// ID is "<kind>:<name>", unique within the graph.
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	// Name is the skill name, the prefixed tool name ("server__tool"), or
+	// the server name.
+	Name  string    `json:"name"`
+	State ItemState `json:"state,omitempty"` // skills only
+	// Missing marks a tool or server a skill names in allowed-tools that
+	// the gateway does not have.
+	Missing bool `json:"missing,omitempty"`
+}
+
+// GraphEdge points from a dependent to its dependency: skill to tool, skill
+// to server (a "server__*" allowed-tools entry), or tool to server.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Via says how a skill edge was found: allowed-tools or a mention of
+	// the prefixed tool name in the body. Empty for tool-to-server edges.
+	Via string `json:"via,omitempty"`
+}
+
+// DependencyGraph maps skills to the tools they use and the servers that
+// provide them, for judging what breaks when a server goes away.
+type DependencyGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// BuildDependencyGraph builds the graph from skills and the gateway's
+// tools, keyed by server with unprefixed tool names. A skill depends on a
+// tool when it names the prefixed tool ("github__create_issue") in
+// allowed-tools or mentions it in its body; a "github__*" allowed-tools
+// entry depends on the whole server. Unprefixed allowed-tools entries are
+// client tools and add nothing. Every server is included; tools only when
+// a skill depends on them.
+func BuildDependencyGraph(skills []*AgentSkill, serverTools map[string][]string) *DependencyGraph {
+	g := &DependencyGraph{}
+	nodes := make(map[string]*GraphNode)
+	addNode := func(kind, name string) *GraphNode {
+		id := kind + ":" + name
+		if n, ok := nodes[id]; ok {
+			return n
+		}
+		n := &GraphNode{ID: id, Kind: kind, Name: name}
+		nodes[id] = n
+		return n
+	}
+	edges := make(map[[2]string]string)
+	addEdge := func(from, to, via string) {
+		key := [2]string{from, to}
+		if prev, ok := edges[key]; ok && prev == GraphViaAllowedTools {
+			return
+		}
+		edges[key] = via
+	}
+
+	known := make(map[string]bool) // prefixed tool names
+	var mentions []toolMention
+	for server, tools := range serverTools {
+		addNode(GraphNodeServer, server)
+		for _, tool := range tools {
+			prefixed := mcp.PrefixTool(server, tool)
+			known[prefixed] = true
+			mentions = append(mentions, toolMention{
+				server:   server,
+				prefixed: prefixed,
+				pattern:  regexp.MustCompile(`(^|[^a-zA-Z0-9_-])` + regexp.QuoteMeta(prefixed) + `($|[^a-zA-Z0-9_-])`),
+			})
+		}
+	}
+
+	// useTool records a skill's dependency on a prefixed tool name.
+	useTool := func(skillID, server, prefixed, via string) {
+		tool := addNode(GraphNodeTool, prefixed)
+		srv := addNode(GraphNodeServer, server)
+		if !known[prefixed] {
+			tool.Missing = true
+			if _, ok := serverTools[server]; !ok {
+				srv.Missing = true
+			}
+		}
+		addEdge(skillID, tool.ID, via)
+		addEdge(tool.ID, srv.ID, "")
+	}
+
+	for _, sk := range skills {
+		skillNode := addNode(GraphNodeSkill, sk.Name)
+		skillNode.State = sk.State
+		for _, entry := range sk.AllowedGatewayTools() {
+			server, tool, _ := mcp.ParsePrefixedTool(entry)
+			if tool == "*" {
+				srv := addNode(GraphNodeServer, server)
+				if _, ok := serverTools[server]; !ok {
+					srv.Missing = true
+				}
+				addEdge(skillNode.ID, srv.ID, GraphViaAllowedTools)
+				continue
+			}
+			useTool(skillNode.ID, server, entry, GraphViaAllowedTools)
+		}
+		for _, m := range mentions {
+			if m.pattern.MatchString(sk.Body) {
+				useTool(skillNode.ID, m.server, m.prefixed, GraphViaBody)
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		g.Nodes = append(g.Nodes, *n)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		a, b := g.Nodes[i], g.Nodes[j]
+		if a.Kind != b.Kind {
+			return graphKindOrder[a.Kind] < graphKindOrder[b.Kind]
+		}
+		return a.Name < b.Name
+	})
+	for key, via := range edges {
+		g.Edges = append(g.Edges, GraphEdge{From: key[0], To: key[1], Via: via})
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	if g.Nodes == nil {
+		g.Nodes = []GraphNode{}
+	}
+	if g.Edges == nil {
+		g.Edges = []GraphEdge{}
+	}
+	return g
+}
+
+type toolMention struct {
+	server   string
+	prefixed string
+	pattern  *regexp.Regexp
+}
+
+var graphKindOrder = map[string]int{GraphNodeSkill: 0, GraphNodeTool: 1, GraphNodeServer: 2}
+
+// ForServer returns the part of the graph that depends on one server: the
+// server, its tools that skills use, and those skills. This is what breaks
+// when the server is removed.
+func (g *DependencyGraph) ForServer(name string) *DependencyGraph {
+	serverID := GraphNodeServer + ":" + name
+	keep := map[string]bool{serverID: true}
+	for _, e := range g.Edges {
+		if e.To == serverID {
+			keep[e.From] = true
+		}
+	}
+	for _, e := range g.Edges {
+		if keep[e.To] && strings.HasPrefix(e.From, GraphNodeSkill+":") {
+			keep[e.From] = true
+		}
+	}
+
+	sub := &DependencyGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	for _, n := range g.Nodes {
+		if keep[n.ID] {
+			sub.Nodes = append(sub.Nodes, n)
+		}
+	}
+	for _, e := range g.Edges {
+		if keep[e.From] && keep[e.To] {
+			sub.Edges = append(sub.Edges, e)
+		}
+	}
+	return sub
+}
+
+// SkillsUsing returns the names of the skills that depend on a server's
+// tools, sorted: the skills removing the server would break.
+func (g *DependencyGraph) SkillsUsing(server string) []string {
+	var names []string
+	for _, n := range g.ForServer(server).Nodes {
+		if n.Kind == GraphNodeSkill {
+			names = append(names, n.Name)
+		}
+	}
+	return names
+}
+
+// ServerTools maps each gateway server to its unprefixed tool names, the
+// form BuildDependencyGraph takes.
+func ServerTools(status []mcp.MCPServerStatus) map[string][]string {
+	tools := make(map[string][]string, len(status))
+	for _, st := range status {
+		tools[st.Name] = st.Tools
+	}
+	return tools
+}
+
+// WriteDOT renders the graph in Graphviz DOT. Body mentions are dashed
+// edges; missing tools and servers are drawn red.
+func (g *DependencyGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph skills {\n  rankdir=LR;\n")
+	shapes := map[string]string{GraphNodeSkill: "box", GraphNodeTool: "ellipse", GraphNodeServer: "cylinder"}
+	for _, n := range g.Nodes {
+		attrs := fmt.Sprintf("label=%q, shape=%s", n.Name, shapes[n.Kind])
+		if n.Missing {
+			attrs += ", color=red, fontcolor=red"
+		} else if n.State == StateDisabled || n.State == StateDraft {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %q [%s];\n", n.ID, attrs)
+	}
+	for _, e := range g.Edges {
+		if e.Via == GraphViaBody {
+			fmt.Fprintf(&b, "  %q -> %q [style=dashed];\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&b, "  %q -> %q;\n", e.From, e.To)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid renders the graph as a Mermaid flowchart. Body mentions are
+// dotted edges; missing tools and servers get the "missing" class.
+func (g *DependencyGraph) WriteMermaid(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	ids := make(map[string]string, len(g.Nodes))
+	var missing []string
+	for i, n := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.ID] = id
+		label := strings.ReplaceAll(n.Name, `"`, "#quot;")
+		switch n.Kind {
+		case GraphNodeSkill:
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", id, label)
+		case GraphNodeTool:
+			fmt.Fprintf(&b, "  %s([\"%s\"])\n", id, label)
+		default:
+			fmt.Fprintf(&b, "  %s[(\"%s\")]\n", id, label)
+		}
+		if n.Missing {
+			missing = append(missing, id)
+		}
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Via == GraphViaBody {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", ids[e.From], arrow, ids[e.To])
+	}
+	if len(missing) > 0 {
+		b.WriteString("  classDef missing stroke:#d33,color:#d33\n")
+		fmt.Fprintf(&b, "  class %s missing\n", strings.Join(missing, ","))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
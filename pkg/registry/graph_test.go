@@ -0,0 +1,117 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildDependencyGraph(t *testing.T) {
+	skills := []*AgentSkill{
+		{Name: "triage", State: StateActive, AllowedTools: "github__list_issues Read", Body: "Label with github__add_label, then page via pager__notify."},
+		{Name: "cleanup", State: StateDisabled, AllowedTools: "github__* legacy__purge"},
+		{Name: "notes", State: StateActive, Body: "No tools here."},
+	}
+	serverTools := map[string][]string{
+		"github": {"list_issues", "add_label", "create_issue"},
+		"pager":  {"notify"},
+	}
+	g := BuildDependencyGraph(skills, serverTools)
+
+	nodes := make(map[string]GraphNode)
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+	if _, ok := nodes["tool:github__create_issue"]; ok {
+		t.Error("tools no skill uses should be left out")
+	}
+	if !nodes["tool:legacy__purge"].Missing || !nodes["server:legacy"].Missing {
+		t.Errorf("unregistered allowed-tools entries should be missing nodes, got %+v", nodes)
+	}
+	if _, ok := nodes["skill:notes"]; !ok {
+		t.Error("skills without dependencies should still be listed")
+	}
+
+	edges := make(map[string]string)
+	for _, e := range g.Edges {
+		edges[e.From+" "+e.To] = e.Via
+	}
+	for edge, via := range map[string]string{
+		"skill:triage tool:github__list_issues": GraphViaAllowedTools,
+		"skill:triage tool:github__add_label":   GraphViaBody,
+		"skill:triage tool:pager__notify":       GraphViaBody,
+		"skill:cleanup server:github":           GraphViaAllowedTools,
+		"tool:github__add_label server:github":  "",
+	} {
+		if got, ok := edges[edge]; !ok || got != via {
+			t.Errorf("edge %s: via = %q (present %v), want %q", edge, got, ok, via)
+		}
+	}
+
+	sub := g.ForServer("github")
+	var names []string
+	for _, n := range sub.Nodes {
+		names = append(names, n.ID)
+	}
+	want := "skill:cleanup skill:triage tool:github__add_label tool:github__list_issues server:github"
+	if got := strings.Join(names, " "); got != want {
+		t.Errorf("ForServer nodes = %s, want %s", got, want)
+	}
+	if got := strings.Join(g.SkillsUsing("github"), " "); got != "cleanup triage" {
+		t.Errorf("SkillsUsing(github) = %q, want cleanup triage", got)
+	}
+}
+
+func TestBuildDependencyGraph_AllowedToolsForms(t *testing.T) {
+	// Comma-separated lists (the examples' form) and client tools mixed in:
+	// only prefixed entries are gateway dependencies.
+	skills := []*AgentSkill{
+		{Name: "release", AllowedTools: "github__create_release, github__list_tags,Read Bash(git:*)"},
+	}
+	g := BuildDependencyGraph(skills, map[string][]string{"github": {"create_release", "list_tags"}})
+
+	var ids []string
+	for _, n := range g.Nodes {
+		if n.Missing {
+			t.Errorf("node %s marked missing", n.ID)
+		}
+		ids = append(ids, n.ID)
+	}
+	want := "skill:release tool:github__create_release tool:github__list_tags server:github"
+	if got := strings.Join(ids, " "); got != want {
+		t.Errorf("nodes = %s, want %s", got, want)
+	}
+	if got := strings.Join(g.SkillsUsing("github"), " "); got != "release" {
+		t.Errorf("SkillsUsing(github) = %q, want release", got)
+	}
+}
+
+func TestDependencyGraph_Render(t *testing.T) {
+	g := BuildDependencyGraph(
+		[]*AgentSkill{{Name: "triage", AllowedTools: "github__list_issues", Body: "see github__add_label"}},
+		map[string][]string{"github": {"list_issues", "add_label"}},
+	)
+
+	var dot strings.Builder
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"skill:triage" -> "tool:github__list_issues";`,
+		`"skill:triage" -> "tool:github__add_label" [style=dashed];`,
+		`"server:github" [label="github", shape=cylinder];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT missing %q:\n%s", want, dot.String())
+		}
+	}
+
+	var mermaid strings.Builder
+	if err := g.WriteMermaid(&mermaid); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"flowchart LR", `n0["triage"]`, `n3[("github")]`, "n0 -.-> n1", "n0 --> n2"} {
+		if !strings.Contains(mermaid.String(), want) {
+			t.Errorf("Mermaid missing %q:\n%s", want, mermaid.String())
+		}
+	}
+}
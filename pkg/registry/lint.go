@@ -0,0 +1,318 @@
+package registry
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LintSeverity classifies a lint finding.
+type LintSeverity string
+
+const (
+	// LintError marks a skill that will misbehave when served.
+	LintError LintSeverity = "error"
+	// LintWarning marks a likely mistake or a weak spot.
+	LintWarning LintSeverity = "warning"
+)
+
+// Lint rule IDs. They are stable: suppression comments and CI filters
+// refer to them.
+const (
+	LintRuleMissingDescription         = "missing-description"
+	LintRuleMissingArgumentDescription = "missing-argument-description"
+	LintRuleUnusedArgument             = "unused-argument"
+	LintRuleUndefinedPlaceholder       = "undefined-placeholder"
+	LintRuleUnreferencedFile           = "unreferenced-file"
+	LintRuleBroadAllowedTools          = "broad-allowed-tools"
+)
+
+// LintRules lists every rule with a one-line summary, in report order.
+var LintRules = []struct{ ID, Summary string }{
+	{LintRuleMissingDescription, "the skill has no description, so clients cannot tell when to use it"},
+	{LintRuleMissingArgumentDescription, "a declared argument has no description"},
+	{LintRuleUnusedArgument, "a declared argument is never used as a {{placeholder}}"},
+	{LintRuleUndefinedPlaceholder, "a {{placeholder}} names no declared argument and is served literally"},
+	{LintRuleUnreferencedFile, "a supporting file is never mentioned, so agents will not know to read it"},
+	{LintRuleBroadAllowedTools, "allowed-tools grants every tool, every shell command, or a whole server"},
+}
+
+// LintFinding is one rule violation.
+type LintFinding struct {
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Message  string       `json:"message"`
+	// File and Line locate the finding: "SKILL.md" or a variant body, and
+	// a 1-based line in that file. Both are empty for frontmatter-wide
+	// findings.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// Suppression comments, written in the body as HTML comments so they do
+// not render:
+//
+//	<!-- gridctl-lint-disable unused-argument -->       whole skill
+//	<!-- gridctl-lint-disable-next-line undefined-placeholder -->
+//
+// Without rule IDs every rule is disabled. Whole-skill comments are read
+// from SKILL.md only; next-line comments apply within their own file.
+var lintDirective = regexp.MustCompile(`<!--\s*gridctl-lint-(disable-next-line|disable)\b([^>]*?)-->`)
+
+// lintPlaceholder matches the {{name}} placeholders prompts/get
+// substitutes.
+var lintPlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+
+// LintTarget is the material a skill is linted against.
+type LintTarget struct {
+	Skill *AgentSkill
+	// BodyLine is the SKILL.md line the body starts on, so findings point
+	// at file lines. Zero means 1.
+	BodyLine int
+	// Variants maps variant names to their bodies.
+	Variants map[string]string
+	// Files lists the skill's supporting files, slash-separated and
+	// relative to the skill directory.
+	Files []string
+}
+
+// LintSkillMD parses raw SKILL.md content and lints it on its own, without
+// variants or supporting files.
+func LintSkillMD(data []byte) ([]LintFinding, error) {
+	sk, err := ParseSkillMD(data)
+	if err != nil {
+		return nil, err
+	}
+	return Lint(LintTarget{Skill: sk, BodyLine: bodyStartLine(data, sk.Body)}), nil
+}
+
+// Lint checks a skill against every lint rule and returns the findings
+// not suppressed by a comment, errors first.
+func Lint(t LintTarget) []LintFinding {
+	sk := t.Skill
+	bodyLine := max(t.BodyLine, 1)
+	bodies := []lintBody{{file: "SKILL.md", text: sk.Body, offset: bodyLine - 1}}
+	variantNames := make([]string, 0, len(t.Variants))
+	for name := range t.Variants {
+		variantNames = append(variantNames, name)
+	}
+	sort.Strings(variantNames)
+	for _, name := range variantNames {
+		bodies = append(bodies, lintBody{file: VariantPath(name), text: t.Variants[name]})
+	}
+
+	var findings []LintFinding
+	add := func(rule string, sev LintSeverity, file string, line int, format string, args ...any) {
+		findings = append(findings, LintFinding{Rule: rule, Severity: sev, Message: fmt.Sprintf(format, args...), File: file, Line: line})
+	}
+
+	if strings.TrimSpace(sk.Description) == "" {
+		add(LintRuleMissingDescription, LintError, "", 0, "skill has no description")
+	}
+	for _, a := range sk.Arguments {
+		if strings.TrimSpace(a.Description) == "" {
+			add(LintRuleMissingArgumentDescription, LintWarning, "", 0, "argument %q has no description", a.Name)
+		}
+	}
+
+	// Placeholders: every one outside code must name an argument, and every
+	// declared argument must be used somewhere (code included, since
+	// substitution does not skip it).
+	known := make(map[string]bool)
+	for _, a := range promptArguments(sk) {
+		known[a.Name] = true
+	}
+	used := make(map[string]bool)
+	for _, b := range bodies {
+		for _, m := range lintPlaceholder.FindAllStringSubmatch(b.text, -1) {
+			used[m[1]] = true
+		}
+		for i, line := range b.proseLines() {
+			for _, m := range lintPlaceholder.FindAllStringSubmatch(line, -1) {
+				if !known[m[1]] {
+					add(LintRuleUndefinedPlaceholder, LintWarning, b.file, b.offset+i+1,
+						"placeholder {{%s}} names no declared argument and is served as written", m[1])
+				}
+			}
+		}
+	}
+	for _, a := range sk.Arguments {
+		if !used[a.Name] {
+			add(LintRuleUnusedArgument, LintWarning, "", 0, "argument %q is never used as {{%s}}", a.Name, a.Name)
+		}
+	}
+
+	for _, f := range t.Files {
+		base := path.Base(f)
+		if strings.HasPrefix(f, "variants/") || strings.HasPrefix(base, ".") || strings.HasPrefix(base, "SKILL.md") {
+			continue
+		}
+		mentioned := false
+		for _, b := range bodies {
+			if strings.Contains(b.text, f) {
+				mentioned = true
+				break
+			}
+		}
+		if !mentioned {
+			add(LintRuleUnreferencedFile, LintWarning, "", 0, "supporting file %s is never mentioned in the body", f)
+		}
+	}
+
+	for _, tool := range sk.AllowedToolList() {
+		if reason := broadToolReason(tool); reason != "" {
+			add(LintRuleBroadAllowedTools, LintWarning, "", 0, "allowed-tools entry %q %s; list the tools or commands the skill needs", tool, reason)
+		}
+	}
+
+	findings = suppressFindings(findings, bodies)
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity == LintError && findings[j].Severity != LintError
+	})
+	return findings
+}
+
+// broadToolReason explains why an allowed-tools entry is too broad, or
+// returns "".
+func broadToolReason(tool string) string {
+	switch {
+	case tool == "*":
+		return "allows every tool"
+	case strings.HasSuffix(tool, "(*)"):
+		return "allows any use of " + strings.TrimSuffix(tool, "(*)")
+	case tool == "Bash":
+		return "allows every shell command"
+	case strings.HasSuffix(tool, "__*"):
+		return "allows every tool of server " + strings.TrimSuffix(tool, "__*")
+	}
+	return ""
+}
+
+// lintBody is one body a skill serves: SKILL.md's or a variant's.
+type lintBody struct {
+	file   string
+	text   string
+	offset int // file lines before the body
+}
+
+// proseLines returns the body's lines with fenced code blocks blanked, so
+// example templates in code are not read as placeholders.
+func (b lintBody) proseLines() []string {
+	lines := strings.Split(b.text, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			lines[i] = ""
+			continue
+		}
+		if inFence {
+			lines[i] = ""
+		}
+	}
+	return lines
+}
+
+// suppressFindings drops findings disabled by a suppression comment.
+func suppressFindings(findings []LintFinding, bodies []lintBody) []LintFinding {
+	skillWide := make(map[string]bool)           // rule -> disabled; "" disables all
+	nextLine := make(map[string]map[string]bool) // "file:line" -> rules
+	for i, b := range bodies {
+		for n, line := range strings.Split(b.text, "\n") {
+			for _, m := range lintDirective.FindAllStringSubmatch(line, -1) {
+				rules := strings.Fields(strings.ReplaceAll(m[2], ",", " "))
+				if len(rules) == 0 {
+					rules = []string{""}
+				}
+				if m[1] == "disable" {
+					if i == 0 {
+						for _, r := range rules {
+							skillWide[r] = true
+						}
+					}
+					continue
+				}
+				key := fmt.Sprintf("%s:%d", b.file, b.offset+n+2)
+				if nextLine[key] == nil {
+					nextLine[key] = make(map[string]bool)
+				}
+				for _, r := range rules {
+					nextLine[key][r] = true
+				}
+			}
+		}
+	}
+
+	kept := findings[:0]
+	for _, f := range findings {
+		if skillWide[""] || skillWide[f.Rule] {
+			continue
+		}
+		if rules := nextLine[fmt.Sprintf("%s:%d", f.File, f.Line)]; f.Line > 0 && (rules[""] || rules[f.Rule]) {
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept
+}
+
+// bodyStartLine returns the SKILL.md line the parsed body starts on.
+func bodyStartLine(data []byte, body string) int {
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasSuffix(content, body) {
+		return 1
+	}
+	return strings.Count(content[:len(content)-len(body)], "\n") + 1
+}
+
+// LintDir lints the skill in dir, a directory holding SKILL.md, together
+// with its variant bodies and supporting files.
+func LintDir(dir string) ([]LintFinding, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		return nil, err
+	}
+	sk, err := ParseSkillMD(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+	t := LintTarget{Skill: sk, BodyLine: bodyStartLine(raw, sk.Body), Variants: make(map[string]string)}
+	for variant := range sk.Variants {
+		if body, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(VariantPath(variant)))); err == nil {
+			t.Variants[variant] = string(body)
+		}
+	}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && d.IsDir() && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			rel, _ := filepath.Rel(dir, p)
+			t.Files = append(t.Files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Lint(t), nil
+}
+
+// Lint lints a stored skill (see LintDir).
+func (s *Store) Lint(name string) ([]LintFinding, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.skills[name]; !ok {
+		return nil, fmt.Errorf("skill %q: %w", name, ErrNotFound)
+	}
+	return LintDir(s.skillDirPath(name))
+}
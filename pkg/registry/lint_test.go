@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func lintRules(findings []LintFinding) map[string]LintFinding {
+	byRule := make(map[string]LintFinding)
+	for _, f := range findings {
+		byRule[f.Rule] = f
+	}
+	return byRule
+}
+
+func TestLintSkillMD_Rules(t *testing.T) {
+	content := `---
+name: deploy
+arguments:
+  - name: env
+  - name: region
+    description: Target region
+allowed-tools: Read Bash server__*
+---
+
+# Deploy
+
+Deploy to {{env}} using {{branch}}.
+
+` + "```" + `
+echo {{not_an_argument}}
+` + "```" + `
+`
+	findings, err := LintSkillMD([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	byRule := lintRules(findings)
+
+	if f, ok := byRule[LintRuleMissingDescription]; !ok || f.Severity != LintError {
+		t.Errorf("expected a missing-description error, got %+v", findings)
+	}
+	if findings[0].Severity != LintError {
+		t.Errorf("errors should sort first, got %+v", findings[0])
+	}
+	if f := byRule[LintRuleMissingArgumentDescription]; f.Message != `argument "env" has no description` {
+		t.Errorf("missing-argument-description = %+v", f)
+	}
+	if f := byRule[LintRuleUnusedArgument]; f.Message != `argument "region" is never used as {{region}}` {
+		t.Errorf("unused-argument = %+v", f)
+	}
+	f, ok := byRule[LintRuleUndefinedPlaceholder]
+	if !ok || f.File != "SKILL.md" || f.Line != 12 {
+		t.Errorf("undefined-placeholder = %+v, want SKILL.md line 12", f)
+	}
+	broad := 0
+	for _, f := range findings {
+		if f.Rule == LintRuleUndefinedPlaceholder && f.Line != 12 {
+			t.Errorf("placeholder in a code block reported: %+v", f)
+		}
+		if f.Rule == LintRuleBroadAllowedTools {
+			broad++
+		}
+	}
+	if broad != 2 {
+		t.Errorf("broad-allowed-tools findings = %d, want 2 (Bash, server__*)", broad)
+	}
+}
+
+func TestLint_Suppression(t *testing.T) {
+	content := `---
+name: deploy
+description: Deploy a service
+allowed-tools: Bash
+---
+<!-- gridctl-lint-disable broad-allowed-tools -->
+<!-- gridctl-lint-disable-next-line undefined-placeholder -->
+Render {{template}} as is.
+Also {{other}}.
+`
+	findings, err := LintSkillMD([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 || findings[0].Rule != LintRuleUndefinedPlaceholder || findings[0].Line != 9 {
+		t.Errorf("expected only the unsuppressed placeholder on line 9, got %+v", findings)
+	}
+}
+
+func TestLintDir_VariantsAndFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("SKILL.md", "---\nname: triage\ndescription: Triage incidents\nvariants:\n  terse: 50\narguments:\n  - name: service\n    description: Service name\n---\n\nRun scripts/collect.sh first.\n")
+	write("variants/terse.md", "Triage {{service}}.\n")
+	write("scripts/collect.sh", "#!/bin/sh\n")
+	write("references/runbook.md", "# Runbook\n")
+
+	findings, err := LintDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 || findings[0].Rule != LintRuleUnreferencedFile {
+		t.Fatalf("expected one unreferenced-file finding, got %+v", findings)
+	}
+	if findings[0].Message != "supporting file references/runbook.md is never mentioned in the body" {
+		t.Errorf("message = %q", findings[0].Message)
+	}
+}
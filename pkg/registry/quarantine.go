@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BrokenDirName is the directory under the registry root where SKILL.md
+// files that cannot be parsed are moved on load, mirroring their path under
+// skills/. Supporting files stay where they are, so restoring a skill is a
+// matter of fixing its SKILL.md and moving it back.
+const BrokenDirName = ".broken"
+
+// tmpSuffix names the temp file atomicWriteBytes renames into place.
+const tmpSuffix = ".tmp"
+
+// LoadWarning reports a SKILL.md the registry could not serve.
+type LoadWarning struct {
+	// Path is the skill directory relative to skills/.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+	// Quarantined is where the file was moved, relative to the registry
+	// root; empty when it was left in place.
+	Quarantined string `json:"quarantined,omitempty"`
+}
+
+// brokenSkill is an unparseable SKILL.md found during a load walk.
+type brokenSkill struct {
+	relDir string
+	err    error
+}
+
+// warn records a load warning. Callers must hold s.mu for writing.
+func (s *Store) warn(relDir, msg, quarantined string) {
+	s.warnings = append(s.warnings, LoadWarning{Path: relDir, Message: msg, Quarantined: quarantined})
+}
+
+// quarantine moves an unparseable SKILL.md out of the skills tree so it
+// stops failing every load, and records a warning either way. Callers must
+// hold s.mu for writing.
+func (s *Store) quarantine(relDir string, parseErr error) {
+	src := filepath.Join(s.baseDir, "skills", relDir, "SKILL.md")
+	rel := filepath.Join(BrokenDirName, relDir, "SKILL.md")
+	if _, err := os.Stat(filepath.Join(s.baseDir, rel)); err == nil {
+		rel = filepath.Join(BrokenDirName, relDir, fmt.Sprintf("SKILL.%s.md", time.Now().UTC().Format("20060102T150405Z")))
+	}
+	dst := filepath.Join(s.baseDir, rel)
+
+	err := os.MkdirAll(filepath.Dir(dst), 0755)
+	if err == nil {
+		err = os.Rename(src, dst)
+	}
+	if err != nil {
+		slog.Warn("skipping unparseable skill file", "path", src, "error", parseErr, "quarantine_error", err)
+		s.warn(relDir, parseErr.Error(), "")
+		return
+	}
+	slog.Warn("quarantined unparseable skill file", "path", src, "moved_to", dst, "error", parseErr)
+	s.warn(relDir, parseErr.Error(), rel)
+}
@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SkillQuery filters ListSkills results. Zero fields match everything.
+type SkillQuery struct {
+	// Tags keeps skills carrying every listed tag.
+	Tags []string
+	// State keeps skills in this lifecycle state.
+	State ItemState
+	// Text keeps skills whose name, description, or body contain every
+	// whitespace-separated term, case-insensitively.
+	Text string
+}
+
+// FindSkills returns the skills matching q, sorted by name. Returned
+// pointers are copies.
+func (s *Store) FindSkills(q SkillQuery) []*AgentSkill {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	candidates := s.taggedLocked(q.Tags)
+	terms := strings.Fields(strings.ToLower(q.Text))
+
+	var result []*AgentSkill
+	for _, name := range candidates {
+		sk := s.skills[name]
+		if q.State != "" && sk.State != q.State {
+			continue
+		}
+		if !matchesTerms(sk, terms) {
+			continue
+		}
+		cp := *sk
+		result = append(result, &cp)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// taggedLocked returns the names of skills carrying every tag, using the tag
+// index; with no tags it returns every skill name. Callers must hold s.mu.
+func (s *Store) taggedLocked(tags []string) []string {
+	if len(tags) == 0 {
+		names := make([]string, 0, len(s.skills))
+		for name := range s.skills {
+			names = append(names, name)
+		}
+		return names
+	}
+	names := s.tags[tags[0]]
+	for _, tag := range tags[1:] {
+		other := s.tags[tag]
+		names = slices.DeleteFunc(slices.Clone(names), func(n string) bool {
+			return !slices.Contains(other, n)
+		})
+	}
+	return names
+}
+
+// rebuildTagIndexLocked recomputes the tag -> skill names index after the
+// skill map changes. Callers must hold s.mu for writing.
+func (s *Store) rebuildTagIndexLocked() {
+	s.tags = make(map[string][]string)
+	for name, sk := range s.skills {
+		for _, tag := range sk.Tags {
+			s.tags[tag] = append(s.tags[tag], name)
+		}
+	}
+}
+
+// matchesTerms reports whether every term occurs in the skill's name,
+// description, or body. Terms are lowercase.
+func matchesTerms(sk *AgentSkill, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+	text := strings.ToLower(sk.Name + "\n" + sk.Description + "\n" + sk.Body)
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
@@ -27,8 +27,9 @@ var errToolsRemoved = errors.New("registry: typed-skill execution removed; skill
 
 // Compile-time checks.
 var (
-	_ mcp.AgentClient    = (*Server)(nil)
-	_ mcp.PromptProvider = (*Server)(nil)
+	_ mcp.AgentClient           = (*Server)(nil)
+	_ mcp.PromptProvider        = (*Server)(nil)
+	_ mcp.PromptVariantProvider = (*Server)(nil)
 )
 
 // New creates a registry server that serves skills as MCP prompts.
@@ -107,8 +108,8 @@ func (s *Server) HasContent() bool {
 }
 
 // ListPromptData returns active Agent Skills as MCP PromptData.
-// Each skill gets a single optional "context" argument for clients to pass
-// additional context when requesting the skill via prompts/get.
+// Each skill offers its declared arguments, or a single optional "context"
+// argument for clients to pass additional context when it declares none.
 func (s *Server) ListPromptData() []mcp.PromptData {
 	skills := s.store.ActiveSkills()
 	result := make([]mcp.PromptData, len(skills))
@@ -117,13 +118,7 @@ func (s *Server) ListPromptData() []mcp.PromptData {
 			Name:        sk.Name,
 			Description: sk.Description,
 			Content:     sk.Body,
-			Arguments: []mcp.PromptArgumentData{
-				{
-					Name:        "context",
-					Description: "Additional context for the skill",
-					Required:    false,
-				},
-			},
+			Arguments:   promptArguments(sk),
 		}
 	}
 	return result
@@ -142,12 +137,6 @@ func (s *Server) GetPromptData(name string) (*mcp.PromptData, error) {
 		Name:        sk.Name,
 		Description: sk.Description,
 		Content:     sk.Body,
-		Arguments: []mcp.PromptArgumentData{
-			{
-				Name:        "context",
-				Description: "Additional context for the skill",
-				Required:    false,
-			},
-		},
+		Arguments:   promptArguments(sk),
 	}, nil
 }
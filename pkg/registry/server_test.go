@@ -350,3 +350,48 @@ func TestServer_ImplementsInterfaces(t *testing.T) {
 	var _ mcp.PromptProvider = (*Server)(nil)
 }
 
+
+func TestServer_DeclaredArguments(t *testing.T) {
+	srv, _ := setupTestServer(t)
+	if err := srv.Store().SaveSkill(&AgentSkill{
+		Name:        "deploy",
+		Description: "Deploy",
+		State:       StateActive,
+		Body:        "Deploy to {{env}} in {{ region }}. Keep {{other}}.",
+		Arguments: []SkillArgument{
+			{Name: "env", Required: true, Values: []string{"prod", "staging"}},
+			{Name: "region", Default: "eu-west-1"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	_ = srv.Initialize(context.Background())
+
+	prompts := srv.ListPromptData()
+	if len(prompts) != 1 || len(prompts[0].Arguments) != 2 {
+		t.Fatalf("prompts = %+v, want one prompt with the two declared arguments", prompts)
+	}
+	if args := prompts[0].Arguments; args[0].Name != "env" || !args[0].Required || args[1].Default != "eu-west-1" {
+		t.Errorf("arguments = %+v", args)
+	}
+
+	g := mcp.NewGateway()
+	g.Router().AddClient(srv)
+	result, err := g.HandlePromptsGet(context.Background(), mcp.PromptsGetParams{
+		Name:      "deploy",
+		Arguments: map[string]string{"env": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("HandlePromptsGet: %v", err)
+	}
+	if got, want := result.Messages[0].Content.Text, "Deploy to prod in eu-west-1. Keep {{other}}."; got != want {
+		t.Errorf("content = %q, want %q", got, want)
+	}
+
+	if _, err := g.HandlePromptsGet(context.Background(), mcp.PromptsGetParams{
+		Name:      "deploy",
+		Arguments: map[string]string{"env": "qa"},
+	}); err == nil {
+		t.Error("expected a value outside the argument's values to be rejected")
+	}
+}
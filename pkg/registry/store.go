@@ -1,6 +1,8 @@
 package registry
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -15,6 +17,10 @@ import (
 // ErrNotFound is returned when a skill does not exist in the store.
 var ErrNotFound = errors.New("not found")
 
+// ErrVersionConflict is returned by UpdateSkill when the skill was saved by
+// someone else since the caller read it.
+var ErrVersionConflict = errors.New("version conflict")
+
 // Store manages skill directories on disk.
 // Each skill is a directory containing a required SKILL.md and optional
 // supporting files (scripts/, references/, assets/).
@@ -22,6 +28,10 @@ type Store struct {
 	baseDir string
 	mu      sync.RWMutex
 	skills  map[string]*AgentSkill
+	tags    map[string][]string // tag -> names of skills carrying it
+
+	// warnings lists the SKILL.md files the last Load could not serve.
+	warnings []LoadWarning
 }
 
 // NewStore creates a store rooted at the given directory.
@@ -29,6 +39,7 @@ func NewStore(baseDir string) *Store {
 	return &Store{
 		baseDir: baseDir,
 		skills:  make(map[string]*AgentSkill),
+		tags:    make(map[string][]string),
 	}
 }
 
@@ -44,10 +55,12 @@ func (s *Store) Load() error {
 	defer s.mu.Unlock()
 
 	s.skills = make(map[string]*AgentSkill)
+	s.warnings = nil
 
 	if err := s.loadSkills(); err != nil {
 		return err
 	}
+	s.rebuildTagIndexLocked()
 
 	s.checkLegacyFiles()
 
@@ -68,6 +81,7 @@ func (s *Store) Status() RegistryStatus {
 
 	st := RegistryStatus{
 		TotalSkills: len(s.skills),
+		Warnings:    append([]LoadWarning(nil), s.warnings...),
 	}
 	for _, sk := range s.skills {
 		if sk.State == StateActive {
@@ -108,7 +122,28 @@ func (s *Store) GetSkill(name string) (*AgentSkill, error) {
 func (s *Store) SaveSkill(sk *AgentSkill) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.saveSkillLocked(sk)
+}
 
+// UpdateSkill saves an existing skill only if its current Version is
+// version, so an editor working from a stale copy cannot overwrite a newer
+// save. It returns ErrNotFound for an unknown skill and ErrVersionConflict
+// on a mismatch.
+func (s *Store) UpdateSkill(sk *AgentSkill, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.skills[sk.Name]
+	if !ok {
+		return fmt.Errorf("skill %q: %w", sk.Name, ErrNotFound)
+	}
+	if existing.Version != version {
+		return fmt.Errorf("skill %q: %w", sk.Name, ErrVersionConflict)
+	}
+	return s.saveSkillLocked(sk)
+}
+
+func (s *Store) saveSkillLocked(sk *AgentSkill) error {
 	if err := sk.Validate(); err != nil {
 		return fmt.Errorf("validating skill: %w", err)
 	}
@@ -138,8 +173,10 @@ func (s *Store) SaveSkill(sk *AgentSkill) error {
 	}
 
 	sk.FileCount = countSupportingFiles(skillDir)
+	sk.Version = skillVersion(data)
 	cp := *sk
 	s.skills[cp.Name] = &cp
+	s.rebuildTagIndexLocked()
 	return nil
 }
 
@@ -154,6 +191,7 @@ func (s *Store) DeleteSkill(name string) error {
 	}
 
 	delete(s.skills, name)
+	s.rebuildTagIndexLocked()
 	return nil
 }
 
@@ -220,13 +258,16 @@ func (s *Store) RenameSkill(oldName, newName string) error {
 	skillsDir := filepath.Join(s.baseDir, "skills")
 	newRelDir, _ := filepath.Rel(skillsDir, newDir)
 	sk.Dir = newRelDir
+	sk.Version = skillVersion(data)
 
 	delete(s.skills, oldName)
 	s.skills[newName] = sk
+	s.rebuildTagIndexLocked()
 	return nil
 }
 
-// ListFiles returns all files in a skill directory (excluding SKILL.md).
+// ListFiles returns all files in a skill directory (excluding SKILL.md and
+// the changelog).
 func (s *Store) ListFiles(skillName string) ([]SkillFile, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -239,7 +280,7 @@ func (s *Store) ListFiles(skillName string) ([]SkillFile, error) {
 			return err
 		}
 		rel, _ := filepath.Rel(skillDir, path)
-		if rel == "." || rel == "SKILL.md" {
+		if rel == "." || rel == "SKILL.md" || rel == ChangelogFileName {
 			return nil
 		}
 		info, err := d.Info()
@@ -282,7 +323,9 @@ func (s *Store) ReadFile(skillName, filePath string) ([]byte, error) {
 	return data, nil
 }
 
-// WriteFile writes a file to a skill directory, creating parent directories as needed.
+// WriteFile writes a file to a skill directory, creating parent directories as
+// needed. The skill's file policy applies: see ErrFileTooLarge and
+// ErrFileTypeNotAllowed.
 func (s *Store) WriteFile(skillName, filePath string, data []byte) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -291,6 +334,9 @@ func (s *Store) WriteFile(skillName, filePath string, data []byte) error {
 	if err != nil {
 		return err
 	}
+	if err := s.checkFileLocked(skillName, filePath, int64(len(data))); err != nil {
+		return err
+	}
 
 	dir := filepath.Dir(absPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -379,6 +425,7 @@ func (s *Store) safeFilePath(skillName, filePath string) (string, error) {
 // (skills/git-workflow/branch-fork/SKILL.md).
 func (s *Store) loadSkills() error {
 	dir := filepath.Join(s.baseDir, "skills")
+	var broken []brokenSkill
 
 	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -392,27 +439,39 @@ func (s *Store) loadSkills() error {
 			return nil
 		}
 
-		if d.IsDir() || d.Name() != "SKILL.md" {
+		if d.IsDir() {
 			return nil
 		}
+		// A SKILL.md.tmp beside SKILL.md is a save interrupted before its
+		// rename; SKILL.md itself still holds the previous version.
+		if d.Name() == "SKILL.md"+tmpSuffix {
+			if err := os.Remove(path); err == nil {
+				slog.Info("removed interrupted skill save", "path", path)
+			}
+			return nil
+		}
+		if d.Name() != "SKILL.md" {
+			return nil
+		}
+
+		// Relative path from skills/ root to the skill directory
+		skillDir := filepath.Dir(path)
+		relDir, _ := filepath.Rel(dir, skillDir)
+		dirName := filepath.Base(skillDir)
 
 		data, err := os.ReadFile(path)
 		if err != nil {
 			slog.Warn("skipping skill file", "path", path, "error", err)
+			s.warn(relDir, err.Error(), "")
 			return nil
 		}
 
 		sk, err := ParseSkillMD(data)
 		if err != nil {
-			slog.Warn("skipping skill file", "path", path, "error", err)
+			broken = append(broken, brokenSkill{relDir: relDir, err: err})
 			return nil
 		}
 
-		// Relative path from skills/ root to the skill directory
-		skillDir := filepath.Dir(path)
-		relDir, _ := filepath.Rel(dir, skillDir)
-		dirName := filepath.Base(skillDir)
-
 		// Name mismatch: directory name takes precedence over frontmatter
 		if sk.Name == "" {
 			sk.Name = dirName
@@ -426,6 +485,7 @@ func (s *Store) loadSkills() error {
 
 		if err := sk.Validate(); err != nil {
 			slog.Warn("skipping invalid skill", "path", path, "error", err)
+			s.warn(relDir, err.Error(), "")
 			return nil
 		}
 
@@ -436,17 +496,24 @@ func (s *Store) loadSkills() error {
 				"kept", existing.Dir,
 				"skipped", relDir,
 			)
+			s.warn(relDir, fmt.Sprintf("duplicate skill name %q (kept %s)", sk.Name, existing.Dir), "")
 			return nil
 		}
 
 		sk.Dir = relDir
 		sk.FileCount = countSupportingFiles(skillDir)
+		sk.Version = skillVersion(data)
 		s.skills[sk.Name] = sk
 		return nil
 	})
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("walking skills directory: %w", err)
 	}
+
+	// Quarantine after the walk so moving files cannot disturb it.
+	for _, b := range broken {
+		s.quarantine(b.relDir, b.err)
+	}
 	return nil
 }
 
@@ -474,6 +541,12 @@ func (s *Store) checkLegacyFiles() {
 	}
 }
 
+// skillVersion returns the Version for SKILL.md content.
+func skillVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
 // countSupportingFiles counts files in the scripts/, references/, and assets/
 // subdirectories of a skill directory.
 func countSupportingFiles(skillDir string) int {
@@ -495,7 +568,7 @@ func countSupportingFiles(skillDir string) int {
 
 // atomicWriteBytes writes data atomically to path via temp file + rename.
 func atomicWriteBytes(path string, data []byte) error {
-	tmp := path + ".tmp"
+	tmp := path + tmpSuffix
 	if err := os.WriteFile(tmp, data, 0644); err != nil {
 		return fmt.Errorf("writing temp file: %w", err)
 	}
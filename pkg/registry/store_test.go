@@ -145,6 +145,51 @@ Good body.
 	if skills[0].Name != "good" {
 		t.Errorf("expected 'good', got %q", skills[0].Name)
 	}
+
+	// The broken file is quarantined and reported.
+	if _, err := os.Stat(filepath.Join(dir, "skills", "broken", "SKILL.md")); !os.IsNotExist(err) {
+		t.Errorf("broken SKILL.md still in skills tree: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, BrokenDirName, "broken", "SKILL.md")); err != nil {
+		t.Errorf("broken SKILL.md not quarantined: %v", err)
+	}
+	warnings := s.Status().Warnings
+	if len(warnings) != 1 || warnings[0].Path != "broken" || warnings[0].Quarantined != filepath.Join(BrokenDirName, "broken", "SKILL.md") {
+		t.Errorf("warnings = %+v, want one quarantine warning for broken", warnings)
+	}
+
+	// A second broken copy does not overwrite the first quarantined one.
+	writeSkillMD(t, dir, "broken", "---\nname: [still invalid\n---\n")
+	if err := s.Load(); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, BrokenDirName, "broken"))
+	if err != nil || len(entries) != 2 {
+		t.Errorf("quarantine entries = %v (%v), want 2", entries, err)
+	}
+}
+
+func TestStore_Load_RemovesInterruptedSave(t *testing.T) {
+	dir := t.TempDir()
+	writeSkillMD(t, dir, "good", "---\nname: good\ndescription: A good skill\n---\n\nBody.\n")
+	tmp := filepath.Join(dir, "skills", "good", "SKILL.md.tmp")
+	if err := os.WriteFile(tmp, []byte("---\nname: go"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewStore(dir)
+	if err := s.Load(); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+		t.Errorf("interrupted save not removed: %v", err)
+	}
+	if _, err := s.GetSkill("good"); err != nil {
+		t.Errorf("GetSkill: %v", err)
+	}
+	if w := s.Status().Warnings; len(w) != 0 {
+		t.Errorf("warnings = %+v, want none", w)
+	}
 }
 
 func TestStore_Load_MissingSkillMD(t *testing.T) {
@@ -527,6 +572,10 @@ Good body.
 	if skills[0].Name != "good-skill" {
 		t.Errorf("expected good-skill, got %s", skills[0].Name)
 	}
+	// Invalid but parseable skills stay in place and are reported.
+	if w := s.Status().Warnings; len(w) != 1 || w[0].Path != "BadSkill" || w[0].Quarantined != "" {
+		t.Errorf("warnings = %+v, want one in-place warning for BadSkill", w)
+	}
 }
 
 // --- CRUD Tests ---
@@ -792,6 +841,50 @@ func TestStore_ListSkills(t *testing.T) {
 	}
 }
 
+func TestStore_FindSkills_TagIndexFollowsChanges(t *testing.T) {
+	s := newTestStore(t)
+	for _, sk := range []*AgentSkill{
+		{Name: "ping-host", Description: "Ping a host", State: StateActive, Tags: []string{"network"}},
+		{Name: "deploy", Description: "Deploy the app", State: StateActive, Tags: []string{"release"}},
+	} {
+		if err := s.SaveSkill(sk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := func(q SkillQuery) string {
+		var out []string
+		for _, sk := range s.FindSkills(q) {
+			out = append(out, sk.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	if got := names(SkillQuery{Tags: []string{"network"}}); got != "ping-host" {
+		t.Errorf("tag network = %q, want ping-host", got)
+	}
+	if err := s.RenameSkill("ping-host", "ping"); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(SkillQuery{Tags: []string{"network"}}); got != "ping" {
+		t.Errorf("after rename, tag network = %q, want ping", got)
+	}
+	if err := s.DeleteSkill("ping"); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(SkillQuery{Tags: []string{"network"}}); got != "" {
+		t.Errorf("after delete, tag network = %q, want none", got)
+	}
+
+	reloaded := NewStore(s.Dir())
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.FindSkills(SkillQuery{Tags: []string{"release"}}); len(got) != 1 || got[0].Name != "deploy" {
+		t.Errorf("after reload, tag release = %v, want deploy", got)
+	}
+}
+
 // --- RenameSkill Tests ---
 
 func TestStore_RenameSkill(t *testing.T) {
@@ -1220,3 +1313,44 @@ func TestCountSupportingFiles_IgnoresSubdirectories(t *testing.T) {
 		t.Errorf("expected 1, got %d", count)
 	}
 }
+
+func TestStore_UpdateSkill_VersionConflict(t *testing.T) {
+	s := NewStore(t.TempDir())
+	sk := &AgentSkill{Name: "deploy", Description: "Deploy", Body: "# Deploy\n"}
+	if err := s.SaveSkill(sk); err != nil {
+		t.Fatal(err)
+	}
+	v1 := sk.Version
+	if v1 == "" {
+		t.Fatal("expected SaveSkill to set Version")
+	}
+
+	edit := &AgentSkill{Name: "deploy", Description: "Deploy v2", Body: "# Deploy\n"}
+	if err := s.UpdateSkill(edit, v1); err != nil {
+		t.Fatalf("UpdateSkill with current version: %v", err)
+	}
+	if edit.Version == v1 {
+		t.Error("expected Version to change with content")
+	}
+
+	stale := &AgentSkill{Name: "deploy", Description: "Stale", Body: "# Deploy\n"}
+	if err := s.UpdateSkill(stale, v1); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("expected ErrVersionConflict, got %v", err)
+	}
+	if err := s.UpdateSkill(&AgentSkill{Name: "ghost", Description: "x"}, v1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	// A fresh store loading the same directory reports the same version.
+	reloaded := NewStore(s.Dir())
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := reloaded.GetSkill("deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != edit.Version {
+		t.Errorf("reloaded version %q, want %q", got.Version, edit.Version)
+	}
+}
@@ -2,6 +2,9 @@ package registry
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
 )
 
 // ItemState represents the lifecycle state of a skill.
@@ -29,7 +32,12 @@ type AgentSkill struct {
 	License       string        `yaml:"license,omitempty" json:"license,omitempty"`
 	Compatibility string        `yaml:"compatibility,omitempty" json:"compatibility,omitempty"`
 	Metadata      SkillMetadata `yaml:"metadata,omitempty" json:"metadata,omitempty"`
-	AllowedTools  string        `yaml:"allowed-tools,omitempty" json:"allowedTools,omitempty"`
+	// AllowedTools lists the tools the skill may use, space- or
+	// comma-separated. Gateway tools are named with their server prefix
+	// ("github__create_issue", or "github__*" for every tool of a server);
+	// other entries ("Read", "Bash(git:*)") are client tools (see
+	// AllowedToolList).
+	AllowedTools string `yaml:"allowed-tools,omitempty" json:"allowedTools,omitempty"`
 	// AcceptanceCriteria documents expected skill behavior as human-readable
 	// Given/When/Then scenarios. Gridctl extension; not part of agentskills.io spec.
 	// See https://agentskills.io/specification
@@ -37,6 +45,21 @@ type AgentSkill struct {
 
 	// --- Gridctl extensions (not in agentskills.io spec) ---
 	State ItemState `yaml:"state,omitempty" json:"state"`
+	// Tags group skills for filtering in the registry API and web UI.
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	// Variants maps variant names to the percentage of prompts/get requests
+	// that receive them, for A/B testing a skill's wording. Each variant's
+	// body lives in variants/<name>.md; the remaining share is served the
+	// main body (variant "default").
+	Variants map[string]int `yaml:"variants,omitempty" json:"variants,omitempty"`
+	// Arguments declares the prompt arguments the skill takes. prompts/get
+	// substitutes each {{name}} placeholder in the body with the supplied
+	// value or the argument's default. Skills without arguments get a
+	// single optional "context" argument.
+	Arguments []SkillArgument `yaml:"arguments,omitempty" json:"arguments,omitempty"`
+	// Files limits the size and type of supporting files written through
+	// the file API (see FilePolicy).
+	Files *FilePolicy `yaml:"files,omitempty" json:"files,omitempty"`
 
 	// --- Parsed from file content (not in frontmatter YAML) ---
 	Body string `yaml:"-" json:"body"` // Markdown content after frontmatter
@@ -44,6 +67,43 @@ type AgentSkill struct {
 	// --- Computed fields (not serialized to YAML) ---
 	FileCount int    `yaml:"-" json:"fileCount"`     // Number of supporting files (scripts/, references/, assets/)
 	Dir       string `yaml:"-" json:"dir,omitempty"` // Relative path from skills/ root (e.g., "git-workflow/branch-fork")
+	// Version is a content hash of SKILL.md as last loaded or saved. Editors
+	// send it back on update so concurrent edits are detected (see UpdateSkill).
+	Version string `yaml:"-" json:"version,omitempty"`
+}
+
+// SkillArgument is a prompt argument declared in a skill's frontmatter.
+type SkillArgument struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty" json:"required,omitempty"`
+	// Default is substituted when the client omits the argument.
+	Default string `yaml:"default,omitempty" json:"default,omitempty"`
+	// Values, when set, restricts the argument to these values; they are
+	// also offered as completion/complete suggestions.
+	Values []string `yaml:"values,omitempty" json:"values,omitempty"`
+}
+
+// AllowedToolList splits AllowedTools into entries. The agentskills.io
+// form is space-separated; commas are accepted too, as older gridctl
+// examples used them.
+func (s *AgentSkill) AllowedToolList() []string {
+	return strings.FieldsFunc(s.AllowedTools, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+// AllowedGatewayTools returns the AllowedTools entries that name gateway
+// tools: those carrying a server prefix, "server__tool" or "server__*".
+// The rest are client tools the gateway neither serves nor checks.
+func (s *AgentSkill) AllowedGatewayTools() []string {
+	var tools []string
+	for _, entry := range s.AllowedToolList() {
+		if server, tool, err := mcp.ParsePrefixedTool(entry); err == nil && server != "" && tool != "" {
+			tools = append(tools, entry)
+		}
+	}
+	return tools
 }
 
 // Validate checks the skill against the agentskills.io specification.
@@ -62,6 +122,8 @@ type SkillFile struct {
 type RegistryStatus struct {
 	TotalSkills  int `json:"totalSkills"`
 	ActiveSkills int `json:"activeSkills"`
+	// Warnings lists SKILL.md files the last load skipped or quarantined.
+	Warnings []LoadWarning `json:"warnings,omitempty"`
 }
 
 // validateState checks that the state is valid, defaulting to draft if empty.
@@ -3,6 +3,8 @@ package registry
 import (
 	"fmt"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 )
 
@@ -10,6 +12,10 @@ import (
 // lowercase alphanumeric and hyphens, must start and end with alphanumeric.
 var skillNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
 
+// argumentNamePattern validates prompt argument names, which appear in the
+// body as {{name}} placeholders.
+var argumentNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
 const (
 	maxNameLength        = 64
 	maxDescriptionLength = 1024
@@ -66,6 +72,11 @@ func ValidateSkillFull(s *AgentSkill) *ValidationResult {
 		result.Errors = append(result.Errors, err.Error())
 	}
 
+	result.Errors = append(result.Errors, validateTags(s.Tags)...)
+	result.Errors = append(result.Errors, validateVariants(s.Variants)...)
+	result.Errors = append(result.Errors, validateArguments(s.Arguments)...)
+	result.Errors = append(result.Errors, validateFilePolicy(s.Files)...)
+
 	// Validate body (warnings only)
 	if s.Body != "" {
 		lineCount := strings.Count(s.Body, "\n") + 1
@@ -84,6 +95,77 @@ func ValidateSkillFull(s *AgentSkill) *ValidationResult {
 	return result
 }
 
+// validateTags checks that tags follow the skill name rules and are unique.
+func validateTags(tags []string) []string {
+	var errs []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if err := ValidateSkillName(tag); err != nil {
+			errs = append(errs, "tag "+err.Error())
+			continue
+		}
+		if seen[tag] {
+			errs = append(errs, fmt.Sprintf("tag %q is listed more than once", tag))
+		}
+		seen[tag] = true
+	}
+	return errs
+}
+
+// validateVariants checks variant names and that the weights are
+// percentages leaving a non-negative share for the main body.
+func validateVariants(variants map[string]int) []string {
+	var errs []string
+	names := make([]string, 0, len(variants))
+	for name := range variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	total := 0
+	for _, name := range names {
+		weight := variants[name]
+		if name == DefaultVariant {
+			errs = append(errs, fmt.Sprintf("variant name %q is reserved for the main body", DefaultVariant))
+		} else if err := ValidateSkillName(name); err != nil {
+			errs = append(errs, "variant "+err.Error())
+		}
+		if weight < 0 || weight > 100 {
+			errs = append(errs, fmt.Sprintf("variant %q weight must be between 0 and 100 (got %d)", name, weight))
+		}
+		total += weight
+	}
+	if total > 100 {
+		errs = append(errs, fmt.Sprintf("variant weights must add up to at most 100 (got %d)", total))
+	}
+	return errs
+}
+
+// validateArguments checks prompt argument names, which must be usable
+// as {{name}} placeholders, and that defaults fit the declaration.
+func validateArguments(args []SkillArgument) []string {
+	var errs []string
+	seen := make(map[string]bool, len(args))
+	for _, a := range args {
+		switch {
+		case !argumentNamePattern.MatchString(a.Name):
+			errs = append(errs, fmt.Sprintf("argument name %q must be lowercase alphanumeric with underscores (matching %s)", a.Name, argumentNamePattern.String()))
+			continue
+		case a.Name == variantArgument:
+			errs = append(errs, fmt.Sprintf("argument name %q is reserved for variant selection", variantArgument))
+		case seen[a.Name]:
+			errs = append(errs, fmt.Sprintf("argument %q is declared more than once", a.Name))
+		}
+		seen[a.Name] = true
+		if a.Required && a.Default != "" {
+			errs = append(errs, fmt.Sprintf("argument %q is required and cannot have a default", a.Name))
+		}
+		if a.Default != "" && len(a.Values) > 0 && !slices.Contains(a.Values, a.Default) {
+			errs = append(errs, fmt.Sprintf("argument %q default %q is not one of its values", a.Name, a.Default))
+		}
+	}
+	return errs
+}
+
 // ValidateSkillName validates a skill name against the agentskills.io spec.
 func ValidateSkillName(name string) error {
 	if name == "" {
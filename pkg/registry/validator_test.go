@@ -119,6 +119,22 @@ func TestValidateSkillFull_EmptyDescription(t *testing.T) {
 	}
 }
 
+func TestValidateSkillFull_InvalidTags(t *testing.T) {
+	skill := &AgentSkill{
+		Name:        "test",
+		Description: "A test skill",
+		Tags:        []string{"network", "Not Valid", "network"},
+	}
+
+	result := ValidateSkillFull(skill)
+	if !containsSubstring(result.Errors, `tag name "Not Valid"`) {
+		t.Errorf("expected invalid tag error, got: %v", result.Errors)
+	}
+	if !containsSubstring(result.Errors, `tag "network" is listed more than once`) {
+		t.Errorf("expected duplicate tag error, got: %v", result.Errors)
+	}
+}
+
 func TestValidateSkillFull_DescriptionTooLong(t *testing.T) {
 	skill := &AgentSkill{
 		Name:        "test",
@@ -241,6 +257,34 @@ func TestValidationResult_Error(t *testing.T) {
 	})
 }
 
+func TestValidateSkillFull_Arguments(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []SkillArgument
+		wantErr string
+	}{
+		{"valid", []SkillArgument{{Name: "env", Required: true}, {Name: "region", Default: "eu", Values: []string{"eu", "us"}}}, ""},
+		{"bad name", []SkillArgument{{Name: "Env"}}, "argument name"},
+		{"reserved name", []SkillArgument{{Name: "variant"}}, "reserved"},
+		{"duplicate", []SkillArgument{{Name: "env"}, {Name: "env"}}, "more than once"},
+		{"required with default", []SkillArgument{{Name: "env", Required: true, Default: "prod"}}, "cannot have a default"},
+		{"default outside values", []SkillArgument{{Name: "env", Default: "qa", Values: []string{"prod", "dev"}}}, "not one of its values"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ValidateSkillFull(&AgentSkill{Name: "deploy", Description: "Deploy", Arguments: tc.args})
+			if tc.wantErr == "" {
+				if !result.Valid() {
+					t.Errorf("unexpected errors: %v", result.Errors)
+				}
+				return
+			}
+			if !containsSubstring(result.Errors, tc.wantErr) {
+				t.Errorf("errors = %v, want one containing %q", result.Errors, tc.wantErr)
+			}
+		})
+	}
+}
 
 // containsSubstring checks if any string in the slice contains the given substring.
 func containsSubstring(strs []string, sub string) bool {
@@ -0,0 +1,120 @@
+package registry
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"path"
+	"sort"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+// DefaultVariant names a skill's main body when it has variants.
+const DefaultVariant = "default"
+
+// variantArgument is the prompts/get argument that requests a variant.
+const variantArgument = "variant"
+
+// VariantPath returns the skill-relative path of a variant's body.
+func VariantPath(variant string) string {
+	return path.Join("variants", variant+".md")
+}
+
+// pickVariant chooses a variant by weight. roll is in [0, 100); the share
+// not claimed by any variant goes to DefaultVariant. Variants are walked in
+// name order so a given roll always picks the same one.
+func pickVariant(variants map[string]int, roll int) string {
+	names := make([]string, 0, len(variants))
+	for name := range variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		roll -= variants[name]
+		if roll < 0 {
+			return name
+		}
+	}
+	return DefaultVariant
+}
+
+// GetPromptVariant implements mcp.PromptVariantProvider. An empty variant
+// picks one by weight; DefaultVariant or a skill without variants serves the
+// main body. A weighted pick whose body file is missing falls back to the
+// main body rather than failing the request.
+func (s *Server) GetPromptVariant(name, variant string) (*mcp.PromptData, error) {
+	p, err := s.GetPromptData(name)
+	if err != nil {
+		return nil, err
+	}
+	sk, err := s.store.GetSkill(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(sk.Variants) == 0 {
+		if variant != "" && variant != DefaultVariant {
+			return nil, fmt.Errorf("skill %q has no variant %q", name, variant)
+		}
+		return p, nil
+	}
+
+	requested := variant != ""
+	if !requested {
+		variant = pickVariant(sk.Variants, rand.IntN(100))
+	}
+	p.Variant = DefaultVariant
+	if variant == DefaultVariant {
+		return p, nil
+	}
+	if _, ok := sk.Variants[variant]; !ok {
+		return nil, fmt.Errorf("skill %q has no variant %q", name, variant)
+	}
+	body, err := s.store.ReadFile(name, VariantPath(variant))
+	if err != nil {
+		if requested {
+			return nil, fmt.Errorf("reading variant %q of skill %q: %w", variant, name, err)
+		}
+		slog.Warn("skill variant unreadable, serving main body", "skill", name, "variant", variant, "error", err)
+		return p, nil
+	}
+	p.Content = string(body)
+	p.Variant = variant
+	return p, nil
+}
+
+// promptArguments returns the prompt arguments for a skill: its declared
+// arguments, or the generic "context" argument when it declares none.
+func promptArguments(sk *AgentSkill) []mcp.PromptArgumentData {
+	var args []mcp.PromptArgumentData
+	for _, a := range sk.Arguments {
+		args = append(args, mcp.PromptArgumentData{
+			Name:        a.Name,
+			Description: a.Description,
+			Required:    a.Required,
+			Default:     a.Default,
+			Values:      a.Values,
+		})
+	}
+	if len(args) == 0 {
+		args = append(args, mcp.PromptArgumentData{
+			Name:        "context",
+			Description: "Additional context for the skill",
+			Required:    false,
+		})
+	}
+	if len(sk.Variants) > 0 {
+		names := []string{DefaultVariant}
+		for name := range sk.Variants {
+			names = append(names, name)
+		}
+		sort.Strings(names[1:])
+		args = append(args, mcp.PromptArgumentData{
+			Name:        variantArgument,
+			Description: "Variant to serve (default: picked by weight)",
+			Required:    false,
+			Values:      names,
+		})
+	}
+	return args
+}
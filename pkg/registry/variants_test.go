@@ -0,0 +1,182 @@
+package registry
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/mcp"
+)
+
+func TestPickVariant(t *testing.T) {
+	variants := map[string]int{"concise": 30, "stepwise": 20}
+	tests := []struct {
+		roll int
+		want string
+	}{
+		{0, "concise"},
+		{29, "concise"},
+		{30, "stepwise"},
+		{49, "stepwise"},
+		{50, DefaultVariant},
+		{99, DefaultVariant},
+	}
+	for _, tc := range tests {
+		if got := pickVariant(variants, tc.roll); got != tc.want {
+			t.Errorf("pickVariant(roll=%d) = %q, want %q", tc.roll, got, tc.want)
+		}
+	}
+}
+
+func TestValidateSkill_Variants(t *testing.T) {
+	tests := []struct {
+		name     string
+		variants map[string]int
+		wantErr  string
+	}{
+		{"valid", map[string]int{"concise": 50, "stepwise": 50}, ""},
+		{"reserved name", map[string]int{"default": 10}, "reserved"},
+		{"bad name", map[string]int{"Concise": 10}, "variant name"},
+		{"negative weight", map[string]int{"concise": -1}, "between 0 and 100"},
+		{"over 100 total", map[string]int{"a": 60, "b": 50}, "at most 100"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSkill(&AgentSkill{Name: "deploy", Description: "Deploy", Variants: tc.variants})
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestServer_GetPromptVariant(t *testing.T) {
+	srv, _ := setupTestServer(t)
+	store := srv.Store()
+	if err := store.SaveSkill(&AgentSkill{
+		Name:        "deploy",
+		Description: "Deploy",
+		State:       StateActive,
+		Body:        "Main body",
+		Variants:    map[string]int{"concise": 100, "missing": 0},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WriteFile("deploy", VariantPath("concise"), []byte("Concise body")); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Weight 100 always picks the variant.
+	p, err := srv.GetPromptVariant("deploy", "")
+	if err != nil {
+		t.Fatalf("GetPromptVariant: %v", err)
+	}
+	if p.Variant != "concise" || p.Content != "Concise body" {
+		t.Errorf("weighted pick = %q/%q", p.Variant, p.Content)
+	}
+
+	p, err = srv.GetPromptVariant("deploy", DefaultVariant)
+	if err != nil || p.Variant != DefaultVariant || p.Content != "Main body" {
+		t.Errorf("default = %+v, %v", p, err)
+	}
+
+	if _, err := srv.GetPromptVariant("deploy", "missing"); err == nil {
+		t.Error("expected an error for a requested variant without a body file")
+	}
+	if _, err := srv.GetPromptVariant("deploy", "unknown"); err == nil {
+		t.Error("expected an error for an undeclared variant")
+	}
+
+	var variantArg *mcp.PromptArgumentData
+	for i, a := range p.Arguments {
+		if a.Name == "variant" {
+			variantArg = &p.Arguments[i]
+		}
+	}
+	if variantArg == nil {
+		t.Fatalf("expected a variant argument, got %+v", p.Arguments)
+	}
+	if want := []string{DefaultVariant, "concise", "missing"}; !reflect.DeepEqual(variantArg.Values, want) {
+		t.Errorf("variant values = %v, want %v", variantArg.Values, want)
+	}
+}
+
+func TestGateway_HandleComplete_VariantArgument(t *testing.T) {
+	srv, _ := setupTestServer(t)
+	if err := srv.Store().SaveSkill(&AgentSkill{
+		Name:        "deploy",
+		Description: "Deploy",
+		State:       StateActive,
+		Variants:    map[string]int{"concise": 50, "checklist": 25},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	_ = srv.Initialize(context.Background())
+	g := mcp.NewGateway()
+	g.Router().AddClient(srv)
+
+	result, err := g.HandleComplete(context.Background(), mcp.CompleteParams{
+		Ref:      mcp.CompletionRef{Type: mcp.CompletionRefPrompt, Name: "deploy"},
+		Argument: mcp.CompletionArgument{Name: "variant", Value: "c"},
+	})
+	if err != nil {
+		t.Fatalf("HandleComplete: %v", err)
+	}
+	if want := []string{"checklist", "concise"}; !reflect.DeepEqual(result.Completion.Values, want) {
+		t.Errorf("values = %v, want %v", result.Completion.Values, want)
+	}
+}
+
+func TestGateway_HandlePromptsGet_ReportsVariant(t *testing.T) {
+	srv, _ := setupTestServer(t)
+	if err := srv.Store().SaveSkill(&AgentSkill{
+		Name:        "deploy",
+		Description: "Deploy",
+		State:       StateActive,
+		Body:        "Main body",
+		Variants:    map[string]int{"concise": 0},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Store().WriteFile("deploy", VariantPath("concise"), []byte("Concise body")); err != nil {
+		t.Fatal(err)
+	}
+	_ = srv.Initialize(context.Background())
+
+	g := mcp.NewGateway()
+	g.Router().AddClient(srv)
+	obs := &recordingPromptObserver{ch: make(chan mcp.PromptGetObservation, 1)}
+	g.SetPromptGetObserver(obs)
+
+	result, err := g.HandlePromptsGet(context.Background(), mcp.PromptsGetParams{
+		Name:      "deploy",
+		Arguments: map[string]string{"variant": "concise"},
+	})
+	if err != nil {
+		t.Fatalf("HandlePromptsGet: %v", err)
+	}
+	if result.Messages[0].Content.Text != "Concise body" {
+		t.Errorf("content = %q", result.Messages[0].Content.Text)
+	}
+	if got := <-obs.ch; got.Variant != "concise" {
+		t.Errorf("observed variant %q, want concise", got.Variant)
+	}
+}
+
+type recordingPromptObserver struct {
+	ch chan mcp.PromptGetObservation
+}
+
+func (r *recordingPromptObserver) ObservePromptGet(obs mcp.PromptGetObservation) {
+	r.ch <- obs
+}
@@ -350,6 +350,11 @@ func mcpServerEqual(a, b config.MCPServer) bool {
 		return false
 	}
 
+	// Re-registering applies new circuit breaker settings.
+	if !reflect.DeepEqual(a.CircuitBreaker, b.CircuitBreaker) {
+		return false
+	}
+
 	return true
 }
 
@@ -427,7 +432,8 @@ func sshEqual(a, b *config.SSHConfig) bool {
 		return false
 	}
 	return a.Host == b.Host && a.User == b.User &&
-		a.Port == b.Port && a.IdentityFile == b.IdentityFile
+		a.Port == b.Port && a.IdentityFile == b.IdentityFile &&
+		config.SSHDeployEqual(a.Deploy, b.Deploy)
 }
 
 func openAPIEqual(a, b *config.OpenAPIConfig) bool {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gridctl/gridctl/pkg/config"
 	"github.com/gridctl/gridctl/pkg/logging"
@@ -20,6 +23,24 @@ type ReloadResult struct {
 	Removed  []string `json:"removed,omitempty"`
 	Modified []string `json:"modified,omitempty"`
 	Errors   []string `json:"errors,omitempty"`
+	// Dependents lists, per removed MCP server, the skills that use its
+	// tools. Set when a reload is refused for removing them.
+	Dependents map[string][]string `json:"dependents,omitempty"`
+}
+
+// Refusal records a reload refused for removing MCP servers that skills
+// depend on.
+type Refusal struct {
+	At         time.Time           `json:"at"`
+	Message    string              `json:"message"`
+	Dependents map[string][]string `json:"dependents"`
+}
+
+// Options controls a single reload.
+type Options struct {
+	// Force applies the reload even when it removes MCP servers that skills
+	// depend on.
+	Force bool
 }
 
 // Handler manages hot reload for a running stack.
@@ -43,6 +64,16 @@ type Handler struct {
 	// does not need to re-enter the Handler mutex to look it up.
 	registerServer func(ctx context.Context, server config.MCPServer, replicas []ReplicaRuntime, stackPath string) error
 
+	// dependents returns the skills that use a server's tools. Reloads that
+	// remove a server with dependents are refused unless forced. nil
+	// disables the check.
+	dependents func(server string) []string
+
+	// refusal is the last refused reload, kept until a later reload applies
+	// or finds nothing to change. It is read without mu so status requests
+	// never wait on a reload in progress.
+	refusal atomic.Pointer[Refusal]
+
 	// onConfigApplied fires after currentCfg has been swapped to newCfg and
 	// per-server diffs have applied. Used by gateway_builder to refresh
 	// telemetry persistence wiring without rebuilding the gateway.
@@ -89,6 +120,12 @@ func (h *Handler) SetRegisterServerFunc(fn func(ctx context.Context, server conf
 	h.registerServer = fn
 }
 
+// SetDependentsFunc sets the lookup of skills that depend on a server, used
+// to refuse reloads that would remove a server skills still use.
+func (h *Handler) SetDependentsFunc(fn func(server string) []string) {
+	h.dependents = fn
+}
+
 // SetOnConfigApplied registers a hook fired after a successful reload swaps
 // currentCfg. Today only the telemetry persistence layer hooks this — it
 // uses the new stack to refresh per-server file writers without restarting
@@ -98,6 +135,13 @@ func (h *Handler) SetOnConfigApplied(fn func(*config.Stack)) {
 	h.onConfigApplied = fn
 }
 
+// LastRefusal returns the refused reload the stack file still reflects, or
+// nil. The --watch file watcher never forces a reload, so this is how a
+// refusal it hit reaches GET /api/status instead of only the log.
+func (h *Handler) LastRefusal() *Refusal {
+	return h.refusal.Load()
+}
+
 // CurrentConfig returns the current stack configuration.
 func (h *Handler) CurrentConfig() *config.Stack {
 	h.mu.Lock()
@@ -118,6 +162,11 @@ func (h *Handler) Initialize(ctx context.Context, stackPath string) (*ReloadResu
 
 // Reload reloads the configuration from disk and applies changes.
 func (h *Handler) Reload(ctx context.Context) (*ReloadResult, error) {
+	return h.ReloadWithOptions(ctx, Options{})
+}
+
+// ReloadWithOptions is Reload with per-call options.
+func (h *Handler) ReloadWithOptions(ctx context.Context, opts Options) (*ReloadResult, error) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -150,6 +199,7 @@ func (h *Handler) Reload(ctx context.Context) (*ReloadResult, error) {
 	diff := ComputeDiff(prevCfg, newCfg)
 
 	if diff.IsEmpty() {
+		h.refusal.Store(nil)
 		h.logger.Info("no configuration changes detected")
 		return &ReloadResult{
 			Success: true,
@@ -166,6 +216,15 @@ func (h *Handler) Reload(ctx context.Context) (*ReloadResult, error) {
 		}, nil
 	}
 
+	// Refuse to remove servers that skills still use, so their dependents
+	// are listed before anything is torn down.
+	if !opts.Force {
+		if refused := h.checkRemovalDependents(diff.MCPServers.Removed); refused != nil {
+			h.refusal.Store(&Refusal{At: time.Now(), Message: refused.Message, Dependents: refused.Dependents})
+			return refused, nil
+		}
+	}
+
 	result := &ReloadResult{Success: true}
 
 	// On initial load (stackless serve → /api/stack/initialize), the daemon
@@ -201,6 +260,7 @@ func (h *Handler) Reload(ctx context.Context) (*ReloadResult, error) {
 
 	// Update current config
 	h.currentCfg = newCfg
+	h.refusal.Store(nil)
 
 	// Notify any registered post-reload hook (telemetry writer refresh).
 	// Runs under h.mu — hooks must not re-enter the handler.
@@ -236,6 +296,33 @@ func (h *Handler) Reload(ctx context.Context) (*ReloadResult, error) {
 	return result, nil
 }
 
+// checkRemovalDependents returns a failed result naming the skills that use
+// any of the removed servers, or nil when none do.
+func (h *Handler) checkRemovalDependents(removed []config.MCPServer) *ReloadResult {
+	if h.dependents == nil {
+		return nil
+	}
+	deps := make(map[string][]string)
+	var parts []string
+	for _, server := range removed {
+		skills := h.dependents(server.Name)
+		if len(skills) == 0 {
+			continue
+		}
+		deps[server.Name] = skills
+		parts = append(parts, fmt.Sprintf("%s (used by %s)", server.Name, strings.Join(skills, ", ")))
+	}
+	if len(deps) == 0 {
+		return nil
+	}
+	h.logger.Warn("reload refused: removed servers have dependent skills", "servers", strings.Join(parts, "; "))
+	return &ReloadResult{
+		Success:    false,
+		Message:    fmt.Sprintf("removing MCP servers that skills depend on: %s - run 'gridctl reload --force' to remove them anyway", strings.Join(parts, "; ")),
+		Dependents: deps,
+	}
+}
+
 // applyAutoscalePolicyUpdates swaps the live scaler policy for each affected
 // server without restarting. In-flight tool calls are not disrupted.
 func (h *Handler) applyAutoscalePolicyUpdates(diff MCPServerDiff, result *ReloadResult) {
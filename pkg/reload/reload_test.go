@@ -372,6 +372,65 @@ mcp-servers:
 	}
 }
 
+func TestHandler_Reload_RemovalWithDependents(t *testing.T) {
+	content := `
+name: test
+network:
+  name: test-net
+mcp-servers:
+  - name: server1
+    image: alpine:latest
+    port: 3000
+`
+	stackPath := writeStackFile(t, content)
+
+	initialCfg := &config.Stack{
+		Name:    "test",
+		Network: config.Network{Name: "test-net", Driver: "bridge"},
+		MCPServers: []config.MCPServer{
+			{Name: "server1", Image: "alpine:latest", Port: 3000},
+			{Name: "server2", Image: "nginx:latest", Port: 3001},
+		},
+	}
+
+	h, _ := setupHandler(t, stackPath, initialCfg)
+	h.SetDependentsFunc(func(server string) []string {
+		if server == "server2" {
+			return []string{"deploy", "triage"}
+		}
+		return nil
+	})
+
+	result, err := h.Reload(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Success {
+		t.Fatal("expected the reload to be refused")
+	}
+	if got := result.Dependents["server2"]; len(got) != 2 || got[0] != "deploy" {
+		t.Errorf("dependents = %v, want [deploy triage]", result.Dependents)
+	}
+	if len(result.Removed) != 0 || len(h.CurrentConfig().MCPServers) != 2 {
+		t.Error("a refused reload should leave the current config in place")
+	}
+
+	if r := h.LastRefusal(); r == nil || len(r.Dependents["server2"]) != 2 {
+		t.Errorf("LastRefusal() = %+v, want the refused removal of server2", r)
+	}
+
+	result, err = h.ReloadWithOptions(context.Background(), Options{Force: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Success || len(result.Removed) != 1 {
+		t.Errorf("forced reload: success=%v removed=%v (%s)", result.Success, result.Removed, result.Message)
+	}
+	if r := h.LastRefusal(); r != nil {
+		t.Errorf("LastRefusal() = %+v after a forced reload, want nil", r)
+	}
+}
+
 func TestHandler_Reload_MCPServerModified(t *testing.T) {
 	content := `
 name: test
@@ -2,11 +2,15 @@ package reload
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/gridctl/gridctl/pkg/config"
 )
 
 func TestWatcher_DirectWrite(t *testing.T) {
@@ -147,3 +151,63 @@ func TestWatcher_MultipleWritesDebounced(t *testing.T) {
 	cancel()
 	<-errCh
 }
+
+func TestWatcher_RefusedReloadIsRecorded(t *testing.T) {
+	// Mirrors the --watch wiring in the gateway builder: the watcher reloads
+	// without force, so removing a server skills depend on is refused and
+	// must show up in LastRefusal rather than only in the log.
+	stackPath := writeStackFile(t, "name: test\nnetwork:\n  name: test-net\nmcp-servers:\n  - name: server1\n    image: alpine:latest\n    port: 3000\n  - name: server2\n    image: nginx:latest\n    port: 3001\n")
+	h, _ := setupHandler(t, stackPath, &config.Stack{
+		Name:    "test",
+		Network: config.Network{Name: "test-net", Driver: "bridge"},
+		MCPServers: []config.MCPServer{
+			{Name: "server1", Image: "alpine:latest", Port: 3000},
+			{Name: "server2", Image: "nginx:latest", Port: 3001},
+		},
+	})
+	h.SetDependentsFunc(func(server string) []string {
+		if server == "server2" {
+			return []string{"deploy"}
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	failures := make(chan error, 4)
+	watcher := NewWatcher(stackPath, func() error {
+		result, err := h.Reload(ctx)
+		if err == nil && !result.Success {
+			err = fmt.Errorf("%s", result.Message)
+		}
+		failures <- err
+		return err
+	})
+	watcher.SetDebounce(50 * time.Millisecond)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- watcher.Watch(ctx)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	if err := os.WriteFile(stackPath, []byte("name: test\nnetwork:\n  name: test-net\nmcp-servers:\n  - name: server1\n    image: alpine:latest\n    port: 3000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-failures:
+		if err == nil || !strings.Contains(err.Error(), "gridctl reload --force") {
+			t.Errorf("watcher reload error = %v, want a refusal pointing at gridctl reload --force", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("watcher did not reload")
+	}
+	if r := h.LastRefusal(); r == nil || len(r.Dependents["server2"]) != 1 {
+		t.Errorf("LastRefusal() = %+v, want the refused removal of server2", r)
+	}
+	if len(h.CurrentConfig().MCPServers) != 2 {
+		t.Error("a refused watcher reload should leave both servers in place")
+	}
+
+	cancel()
+	<-errCh
+}
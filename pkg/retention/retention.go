@@ -0,0 +1,248 @@
+// Package retention enforces age, size, and count limits on the history
+// gridctl accumulates on disk: recorded request sessions, crash reports,
+// daemon logs, skill backups, and client config backups. A Janitor sweeps
+// its targets on an interval and keeps running totals of what it deleted.
+package retention
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/crash"
+)
+
+// Policy bounds one category of files. Each limit applies per directory;
+// a zero field is unlimited.
+type Policy struct {
+	// MaxAge deletes files last modified longer ago than this.
+	MaxAge time.Duration
+	// MaxSize caps the total bytes kept, deleting the oldest files first.
+	MaxSize int64
+	// Keep caps the number of files kept, deleting the oldest first.
+	Keep int
+}
+
+// IsZero reports whether the policy sets no limit.
+func (p Policy) IsZero() bool {
+	return p.MaxAge <= 0 && p.MaxSize <= 0 && p.Keep <= 0
+}
+
+// Target is a set of files the janitor manages under one category.
+type Target struct {
+	// Category groups targets in logs and the report ("request_logs").
+	Category string
+	// Dir is the directory searched. A missing directory is skipped.
+	Dir string
+	// Pattern matches file base names (filepath.Match syntax).
+	Pattern string
+	// Recursive also searches subdirectories, each limited separately.
+	Recursive bool
+	// Skip, when set, exempts a matching file, e.g. one still in use.
+	Skip func(path string) bool
+	// Policy is applied to each directory's matches.
+	Policy Policy
+}
+
+// CategoryStats is what the janitor has deleted in one category since
+// the daemon started.
+type CategoryStats struct {
+	Category     string `json:"category"`
+	DeletedFiles int64  `json:"deletedFiles"`
+	DeletedBytes int64  `json:"deletedBytes"`
+	// Errors counts files that matched a limit but could not be removed.
+	Errors int64 `json:"errors"`
+}
+
+// Report is the janitor's state, served by GET /api/retention.
+type Report struct {
+	Configured bool            `json:"configured"`
+	Interval   string          `json:"interval,omitempty"`
+	LastSweep  *time.Time      `json:"lastSweep,omitempty"`
+	Categories []CategoryStats `json:"categories"`
+}
+
+// Janitor applies retention policies to a set of targets.
+type Janitor struct {
+	targets func() []Target
+	logger  *slog.Logger
+	now     func() time.Time
+
+	mu        sync.Mutex
+	interval  time.Duration
+	lastSweep time.Time
+	stats     map[string]*CategoryStats
+}
+
+// New creates a janitor. targets is called at the start of every sweep so
+// targets that depend on the machine (detected clients) stay current.
+func New(targets func() []Target, logger *slog.Logger) *Janitor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Janitor{
+		targets: targets,
+		logger:  logger,
+		now:     time.Now,
+		stats:   make(map[string]*CategoryStats),
+	}
+}
+
+// Start sweeps once immediately and then every interval until ctx is done.
+func (j *Janitor) Start(ctx context.Context, interval time.Duration) {
+	j.mu.Lock()
+	j.interval = interval
+	j.mu.Unlock()
+	go func() {
+		defer crash.Recover("retention-janitor")
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			j.Sweep()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Sweep applies every target's policy once and returns the files deleted
+// per category in this sweep.
+func (j *Janitor) Sweep() map[string]CategoryStats {
+	now := j.now()
+	swept := make(map[string]CategoryStats)
+	for _, t := range j.targets() {
+		if t.Policy.IsZero() {
+			continue
+		}
+		s := swept[t.Category]
+		s.Category = t.Category
+		for _, group := range collect(t) {
+			sweepGroup(group, t.Policy, now, &s, j.logger)
+		}
+		swept[t.Category] = s
+	}
+
+	j.mu.Lock()
+	j.lastSweep = now
+	for cat, s := range swept {
+		total, ok := j.stats[cat]
+		if !ok {
+			total = &CategoryStats{Category: cat}
+			j.stats[cat] = total
+		}
+		total.DeletedFiles += s.DeletedFiles
+		total.DeletedBytes += s.DeletedBytes
+		total.Errors += s.Errors
+	}
+	j.mu.Unlock()
+
+	for _, s := range swept {
+		if s.DeletedFiles > 0 || s.Errors > 0 {
+			j.logger.Info("retention sweep", "category", s.Category,
+				"deleted_files", s.DeletedFiles, "deleted_bytes", s.DeletedBytes, "errors", s.Errors)
+		}
+	}
+	return swept
+}
+
+// Report returns the running totals, categories sorted by name.
+func (j *Janitor) Report() Report {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	r := Report{Configured: true, Categories: make([]CategoryStats, 0, len(j.stats))}
+	if j.interval > 0 {
+		r.Interval = j.interval.String()
+	}
+	if !j.lastSweep.IsZero() {
+		t := j.lastSweep
+		r.LastSweep = &t
+	}
+	for _, s := range j.stats {
+		r.Categories = append(r.Categories, *s)
+	}
+	sort.Slice(r.Categories, func(a, b int) bool { return r.Categories[a].Category < r.Categories[b].Category })
+	return r
+}
+
+// file is one candidate for deletion.
+type file struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// collect returns the target's matching files grouped by directory.
+func collect(t Target) [][]file {
+	groups := make(map[string][]file)
+	add := func(path string, d fs.DirEntry) {
+		if d.IsDir() || !d.Type().IsRegular() {
+			return
+		}
+		if ok, _ := filepath.Match(t.Pattern, d.Name()); !ok {
+			return
+		}
+		if t.Skip != nil && t.Skip(path) {
+			return
+		}
+		info, err := d.Info()
+		if err != nil {
+			return
+		}
+		dir := filepath.Dir(path)
+		groups[dir] = append(groups[dir], file{path: path, size: info.Size(), modTime: info.ModTime()})
+	}
+
+	if t.Recursive {
+		_ = filepath.WalkDir(t.Dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			add(path, d)
+			return nil
+		})
+	} else if entries, err := os.ReadDir(t.Dir); err == nil {
+		for _, e := range entries {
+			add(filepath.Join(t.Dir, e.Name()), e)
+		}
+	}
+
+	out := make([][]file, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, g)
+	}
+	return out
+}
+
+// sweepGroup deletes the files in one directory that fall outside the
+// policy. Files are visited newest first, so Keep and MaxSize retain the
+// most recent ones.
+func sweepGroup(files []file, p Policy, now time.Time, s *CategoryStats, logger *slog.Logger) {
+	sort.Slice(files, func(a, b int) bool { return files[a].modTime.After(files[b].modTime) })
+	var kept int
+	var keptBytes int64
+	for _, f := range files {
+		expired := p.MaxAge > 0 && now.Sub(f.modTime) > p.MaxAge
+		overCount := p.Keep > 0 && kept >= p.Keep
+		overSize := p.MaxSize > 0 && keptBytes+f.size > p.MaxSize
+		if !expired && !overCount && !overSize {
+			kept++
+			keptBytes += f.size
+			continue
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			s.Errors++
+			logger.Warn("retention sweep could not remove file", "category", s.Category, "path", f.path, "error", err)
+			continue
+		}
+		s.DeletedFiles++
+		s.DeletedBytes += f.size
+	}
+}
@@ -0,0 +1,111 @@
+package retention
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeAged creates a file of size bytes last modified age ago.
+func writeAged(t *testing.T, path string, size int, age time.Duration) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("x", size)), 0o644))
+	mod := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, mod, mod))
+}
+
+func remaining(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestJanitor_MaxAge(t *testing.T) {
+	dir := t.TempDir()
+	writeAged(t, filepath.Join(dir, "old.jsonl"), 10, 10*24*time.Hour)
+	writeAged(t, filepath.Join(dir, "new.jsonl"), 10, time.Hour)
+	writeAged(t, filepath.Join(dir, "other.txt"), 10, 10*24*time.Hour)
+
+	j := New(func() []Target {
+		return []Target{{Category: "logs", Dir: dir, Pattern: "*.jsonl", Policy: Policy{MaxAge: 7 * 24 * time.Hour}}}
+	}, nil)
+	swept := j.Sweep()
+
+	assert.ElementsMatch(t, []string{"new.jsonl", "other.txt"}, remaining(t, dir))
+	assert.Equal(t, CategoryStats{Category: "logs", DeletedFiles: 1, DeletedBytes: 10}, swept["logs"])
+}
+
+func TestJanitor_KeepAndMaxSizePerDirectory(t *testing.T) {
+	root := t.TempDir()
+	for _, skill := range []string{"a", "b"} {
+		for i, age := range []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour} {
+			writeAged(t, filepath.Join(root, skill, "SKILL.md.pre-"+string(rune('1'+i))), 100, age)
+		}
+	}
+
+	j := New(func() []Target {
+		return []Target{{Category: "skills", Dir: root, Pattern: "SKILL.md.pre-*", Recursive: true, Policy: Policy{Keep: 2}}}
+	}, nil)
+	j.Sweep()
+	for _, skill := range []string{"a", "b"} {
+		assert.ElementsMatch(t, []string{"SKILL.md.pre-1", "SKILL.md.pre-2"}, remaining(t, filepath.Join(root, skill)))
+	}
+
+	// A size cap keeps the newest files that fit.
+	j = New(func() []Target {
+		return []Target{{Category: "skills", Dir: filepath.Join(root, "a"), Pattern: "*", Policy: Policy{MaxSize: 150}}}
+	}, nil)
+	j.Sweep()
+	assert.Equal(t, []string{"SKILL.md.pre-1"}, remaining(t, filepath.Join(root, "a")))
+}
+
+func TestJanitor_SkipAndReport(t *testing.T) {
+	dir := t.TempDir()
+	writeAged(t, filepath.Join(dir, "busy.log"), 5, 30*24*time.Hour)
+	writeAged(t, filepath.Join(dir, "idle.log"), 5, 30*24*time.Hour)
+
+	j := New(func() []Target {
+		return []Target{{
+			Category: "daemon_logs",
+			Dir:      dir,
+			Pattern:  "*.log",
+			Skip:     func(path string) bool { return filepath.Base(path) == "busy.log" },
+			Policy:   Policy{MaxAge: 24 * time.Hour},
+		}}
+	}, nil)
+	j.Sweep()
+	j.Sweep()
+
+	assert.Equal(t, []string{"busy.log"}, remaining(t, dir))
+	report := j.Report()
+	assert.True(t, report.Configured)
+	require.NotNil(t, report.LastSweep)
+	assert.Equal(t, []CategoryStats{{Category: "daemon_logs", DeletedFiles: 1, DeletedBytes: 5}}, report.Categories)
+}
+
+func TestJanitor_MissingDirAndZeroPolicy(t *testing.T) {
+	dir := t.TempDir()
+	writeAged(t, filepath.Join(dir, "x.log"), 1, 365*24*time.Hour)
+
+	j := New(func() []Target {
+		return []Target{
+			{Category: "gone", Dir: filepath.Join(dir, "missing"), Pattern: "*", Policy: Policy{Keep: 1}},
+			{Category: "unbounded", Dir: dir, Pattern: "*"},
+		}
+	}, nil)
+	swept := j.Sweep()
+
+	assert.Equal(t, []string{"x.log"}, remaining(t, dir))
+	assert.Zero(t, swept["gone"].DeletedFiles)
+}
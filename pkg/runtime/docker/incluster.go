@@ -0,0 +1,41 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gridctl/gridctl/pkg/runtime"
+)
+
+// ReachFromContainer implements runtime.ContainerReacher. It connects self
+// to each of the workload's networks it is not already on, then returns
+// the workload's container name, which the runtime's embedded DNS resolves
+// on those networks, with its container port.
+func (d *DockerRuntime) ReachFromContainer(ctx context.Context, self string, id runtime.WorkloadID, port int) (string, error) {
+	target, err := d.cli.ContainerInspect(ctx, string(id))
+	if err != nil {
+		return "", fmt.Errorf("inspecting container: %w", err)
+	}
+	if target.NetworkSettings == nil || len(target.NetworkSettings.Networks) == 0 {
+		return "", fmt.Errorf("container %s is not attached to a network", id)
+	}
+	own, err := d.cli.ContainerInspect(ctx, self)
+	if err != nil {
+		return "", fmt.Errorf("inspecting gridctl's own container %q: %w", self, err)
+	}
+
+	for name := range target.NetworkSettings.Networks {
+		if own.NetworkSettings != nil {
+			if _, ok := own.NetworkSettings.Networks[name]; ok {
+				continue
+			}
+		}
+		// Concurrent registrations may race to attach the same network.
+		if err := d.cli.NetworkConnect(ctx, name, self, nil); err != nil && !strings.Contains(err.Error(), "already exists") {
+			return "", fmt.Errorf("connecting gridctl's container to network %s: %w", name, err)
+		}
+		d.logger.Info("attached gridctl container to network", "network", name)
+	}
+	return fmt.Sprintf("%s:%d", strings.TrimPrefix(target.Name, "/"), port), nil
+}
@@ -0,0 +1,53 @@
+package docker
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/gridctl/gridctl/pkg/runtime"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+)
+
+func inspectOnNetworks(name string, networks ...string) container.InspectResponse {
+	eps := make(map[string]*network.EndpointSettings, len(networks))
+	for _, n := range networks {
+		eps[n] = &network.EndpointSettings{}
+	}
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{Name: "/" + name},
+		NetworkSettings:   &container.NetworkSettings{Networks: eps},
+	}
+}
+
+func TestDockerRuntime_ReachFromContainer(t *testing.T) {
+	mock := &MockDockerClient{ContainerDetails: map[string]container.InspectResponse{
+		"srv1":    inspectOnNetworks("demo-github", "demo-net", "shared"),
+		"gridctl": inspectOnNetworks("gridctl", "bridge", "shared"),
+	}}
+	rt := NewWithClient(mock)
+
+	addr, err := rt.ReachFromContainer(context.Background(), "gridctl", runtime.WorkloadID("srv1"), 3000)
+	if err != nil {
+		t.Fatalf("ReachFromContainer: %v", err)
+	}
+	if addr != "demo-github:3000" {
+		t.Errorf("addr = %q, want demo-github:3000", addr)
+	}
+	if want := []string{"demo-net/gridctl"}; !reflect.DeepEqual(mock.ConnectedNetworks, want) {
+		t.Errorf("connected = %v, want %v (already-shared networks are skipped)", mock.ConnectedNetworks, want)
+	}
+}
+
+func TestDockerRuntime_ReachFromContainer_NoNetwork(t *testing.T) {
+	mock := &MockDockerClient{ContainerDetails: map[string]container.InspectResponse{
+		"srv1": inspectOnNetworks("demo-github"),
+	}}
+	rt := NewWithClient(mock)
+
+	if _, err := rt.ReachFromContainer(context.Background(), "gridctl", runtime.WorkloadID("srv1"), 3000); err == nil {
+		t.Error("expected an error for a container without networks")
+	}
+}
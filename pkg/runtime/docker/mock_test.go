@@ -36,6 +36,7 @@ type MockDockerClient struct {
 	NetworkCreateError    error
 	NetworkRemoveError    error
 	NetworkListError      error
+	NetworkConnectError   error
 	ImageListError        error
 	ImagePullError        error
 
@@ -54,6 +55,8 @@ type MockDockerClient struct {
 	CreatedNetworks []string
 	// Removed networks
 	RemovedNetworks []string
+	// Network connections, as "network/container"
+	ConnectedNetworks []string
 	// Pulled images
 	PulledImages []string
 
@@ -178,6 +181,15 @@ func (m *MockDockerClient) NetworkRemove(ctx context.Context, networkID string)
 	return nil
 }
 
+func (m *MockDockerClient) NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+	m.recordCall("NetworkConnect")
+	if m.NetworkConnectError != nil {
+		return m.NetworkConnectError
+	}
+	m.ConnectedNetworks = append(m.ConnectedNetworks, networkID+"/"+containerID)
+	return nil
+}
+
 func (m *MockDockerClient) ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error) {
 	m.recordCall("ImageList")
 	if m.ImageListError != nil {
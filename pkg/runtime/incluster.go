@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ContainerIDEnvVar names the container gridctl runs in, overriding
+// detection (e.g. when the container's hostname was changed).
+const ContainerIDEnvVar = "GRIDCTL_CONTAINER_ID"
+
+// Paths probed to detect that gridctl runs in a container. Vars so tests
+// can point them at fixtures.
+var (
+	mountInfoPath    = "/proc/self/mountinfo"
+	containerEnvFile = []string{"/.dockerenv", "/run/.containerenv"}
+)
+
+// mountInfoContainerID matches the container ID in the bind mounts Docker
+// (/var/lib/docker/containers/<id>/hostname) and Podman
+// (.../overlay-containers/<id>/userdata/hostname) give every container.
+var mountInfoContainerID = regexp.MustCompile(`containers/([0-9a-f]{64})/`)
+
+// ErrNotInContainer is returned by SelfContainerID outside a container.
+var ErrNotInContainer = errors.New("gridctl is not running in a container; set " + ContainerIDEnvVar + " if it is")
+
+// SelfContainerID returns the ID of the container gridctl runs in: the
+// GRIDCTL_CONTAINER_ID environment variable, then the ID in the
+// container's mount table, then the hostname, which Docker and Podman set
+// to the short container ID unless the container was given a hostname.
+func SelfContainerID() (string, error) {
+	if id := strings.TrimSpace(os.Getenv(ContainerIDEnvVar)); id != "" {
+		return id, nil
+	}
+	if !inContainer() {
+		return "", ErrNotInContainer
+	}
+	if data, err := os.ReadFile(mountInfoPath); err == nil {
+		if m := mountInfoContainerID.FindSubmatch(data); m != nil {
+			return string(m[1]), nil
+		}
+	}
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "", ErrNotInContainer
+	}
+	return host, nil
+}
+
+// inContainer reports whether the process runs in a Docker or Podman
+// container, which both drop a marker file at the container root.
+func inContainer() bool {
+	for _, path := range containerEnvFile {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package runtime
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withContainerMarkers points detection at fixtures in a temp dir.
+func withContainerMarkers(t *testing.T, marker bool, mountInfo string) {
+	t.Helper()
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, ".dockerenv")
+	if marker {
+		if err := os.WriteFile(envFile, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mi := filepath.Join(dir, "mountinfo")
+	if err := os.WriteFile(mi, []byte(mountInfo), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	oldEnv, oldMI := containerEnvFile, mountInfoPath
+	containerEnvFile, mountInfoPath = []string{envFile}, mi
+	t.Cleanup(func() { containerEnvFile, mountInfoPath = oldEnv, oldMI })
+}
+
+func TestSelfContainerID_EnvOverride(t *testing.T) {
+	withContainerMarkers(t, false, "")
+	t.Setenv(ContainerIDEnvVar, "gridctl-gateway")
+
+	id, err := SelfContainerID()
+	if err != nil || id != "gridctl-gateway" {
+		t.Errorf("SelfContainerID() = %q, %v; want gridctl-gateway", id, err)
+	}
+}
+
+func TestSelfContainerID_NotInContainer(t *testing.T) {
+	withContainerMarkers(t, false, "")
+	t.Setenv(ContainerIDEnvVar, "")
+
+	if _, err := SelfContainerID(); !errors.Is(err, ErrNotInContainer) {
+		t.Errorf("err = %v, want ErrNotInContainer", err)
+	}
+}
+
+func TestSelfContainerID_FromMountInfo(t *testing.T) {
+	id := strings.Repeat("ab12", 16)
+	withContainerMarkers(t, true,
+		"1 0 0:1 / / rw - overlay overlay rw\n"+
+			"2 1 8:1 /var/lib/docker/containers/"+id+"/hostname /etc/hostname rw - ext4 /dev/sda1 rw\n")
+	t.Setenv(ContainerIDEnvVar, "")
+
+	got, err := SelfContainerID()
+	if err != nil || got != id {
+		t.Errorf("SelfContainerID() = %q, %v; want %q", got, err, id)
+	}
+}
+
+func TestSelfContainerID_FallsBackToHostname(t *testing.T) {
+	withContainerMarkers(t, true, "1 0 0:1 / / rw - overlay overlay rw\n")
+	t.Setenv(ContainerIDEnvVar, "")
+
+	host, _ := os.Hostname()
+	if got, err := SelfContainerID(); err != nil || got != host {
+		t.Errorf("SelfContainerID() = %q, %v; want hostname %q", got, err, host)
+	}
+}
@@ -136,6 +136,16 @@ type WorkloadRuntime interface {
 	Close() error
 }
 
+// ContainerReacher is implemented by runtimes that can route to a workload
+// from inside a sibling container, for gridctl running in a container (see
+// SelfContainerID). Host port mappings are not reachable from there, so the
+// gateway dials the workload by name on a network the two share.
+type ContainerReacher interface {
+	// ReachFromContainer attaches the container self to the workload's
+	// networks and returns the workload's address on them ("name:port").
+	ReachFromContainer(ctx context.Context, self string, id WorkloadID, port int) (string, error)
+}
+
 // Label constants for identifying gridctl-managed resources.
 const (
 	LabelManaged   = "gridctl.managed"
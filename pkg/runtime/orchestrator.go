@@ -259,12 +259,12 @@ func (o *Orchestrator) Up(ctx context.Context, stack *config.Stack, opts UpOptio
 				"name", server.Name,
 				"host", server.SSH.Host,
 				"user", server.SSH.User,
-				"command", server.Command,
+				"command", server.SSHRemoteCommand(),
 				"replicas", replicas)
 			result.MCPServers = append(result.MCPServers, MCPServerResult{
 				Name:            server.Name,
 				SSH:             true,
-				Command:         server.Command,
+				Command:         server.SSHRemoteCommand(),
 				SSHHost:         server.SSH.Host,
 				SSHUser:         server.SSH.User,
 				SSHPort:         server.SSH.Port,
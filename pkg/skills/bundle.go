@@ -0,0 +1,87 @@
+package skills
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gridctl/gridctl/pkg/registry"
+)
+
+// ImportBundle installs a skill read from a bundle archive (see
+// registry.ReadBundle). It applies the same checks as Import: the skill is
+// skipped when it already exists without opts.Force, fails validation, or
+// has security findings without opts.Trust. Rename, NoActivate, Force, and
+// Trust are honored; the git-only options are ignored.
+//
+// A bundled skill has no origin, so replacing a skill imported from git
+// also drops it from the lock file — 'skill update' would otherwise try to
+// refresh it from a source it no longer matches.
+func (imp *Importer) ImportBundle(b *registry.Bundle, opts ImportOptions) (*ImportResult, error) {
+	skillName := b.Manifest.Name
+	if opts.Rename != "" {
+		skillName = opts.Rename
+	}
+	if err := registry.ValidateSkillName(skillName); err != nil {
+		return nil, err
+	}
+	result := &ImportResult{}
+	skip := func(reason string) (*ImportResult, error) {
+		result.Skipped = append(result.Skipped, SkippedSkill{Name: skillName, Reason: reason})
+		return result, nil
+	}
+
+	_, err := imp.store.GetSkill(skillName)
+	replacing := err == nil
+	if replacing && !opts.Force {
+		return skip(fmt.Sprintf("skill %q already exists (use --force to overwrite or --rename to import with a different name)", skillName))
+	}
+
+	sk := *b.Skill
+	sk.Name = skillName
+	vr := registry.ValidateSkillFull(&sk)
+	if !vr.Valid() {
+		return skip(fmt.Sprintf("validation failed: %s", vr.Error()))
+	}
+	for _, w := range vr.Warnings {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s", skillName, w))
+	}
+
+	scanResult := ScanSkill(&sk)
+	if !scanResult.Safe && !opts.Trust {
+		return skip(fmt.Sprintf("security findings detected (use --trust to proceed):\n%s", FormatFindings(scanResult.Findings)))
+	}
+
+	sk.State = registry.StateActive
+	if opts.NoActivate {
+		sk.State = registry.StateDraft
+	}
+	install := *b
+	install.Skill = &sk
+	if err := imp.store.InstallBundle(&install, opts.Force); err != nil {
+		if errors.Is(err, registry.ErrExists) {
+			return skip(fmt.Sprintf("skill %q already exists", skillName))
+		}
+		return nil, err
+	}
+
+	if replacing {
+		imp.lockfileMu.Lock()
+		defer imp.lockfileMu.Unlock()
+		if lf, err := ReadLockFile(imp.lockPath); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to read lock file: %v", err))
+		} else if _, _, found := lf.FindSkillSource(skillName); found {
+			lf.RemoveSkill(skillName)
+			if err := WriteLockFile(imp.lockPath, lf); err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("failed to update lock file: %v", err))
+			}
+		}
+	}
+
+	imported := ImportedSkill{Name: skillName, Path: skillName}
+	if !scanResult.Safe {
+		imported.Findings = scanResult.Findings
+	}
+	result.Imported = append(result.Imported, imported)
+	imp.logger.Info("imported skill bundle", "name", skillName)
+	return result, nil
+}
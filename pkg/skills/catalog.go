@@ -0,0 +1,276 @@
+package skills
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	gitpkg "github.com/gridctl/gridctl/pkg/git"
+	"github.com/gridctl/gridctl/pkg/state"
+)
+
+// CatalogIndexFile is the index read from the root of a git or directory
+// catalog.
+const CatalogIndexFile = "catalog.json"
+
+// CatalogEnvVar overrides the catalog source configured in skills.yaml.
+const CatalogEnvVar = "GRIDCTL_SKILL_CATALOG"
+
+const (
+	catalogRequestTimeout = 15 * time.Second
+	catalogCacheTTL       = time.Hour
+	// catalogMaxBody caps an index download (well above any real catalog).
+	catalogMaxBody = 8 << 20
+)
+
+// ErrCatalogNotConfigured is returned when no catalog source is set.
+var ErrCatalogNotConfigured = errors.New("no skill catalog configured: set catalog in skills.yaml, " + CatalogEnvVar + ", or --catalog")
+
+// ErrCatalogEntryNotFound is returned when an ID is not in the catalog.
+var ErrCatalogEntryNotFound = errors.New("skill not found in catalog")
+
+// catalogIDPattern matches "org/skill" catalog IDs.
+var catalogIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*/[a-z0-9][a-z0-9._-]*$`)
+
+// CatalogEntry points at a skill published in a git repository.
+type CatalogEntry struct {
+	// ID is the install name, "org/skill".
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Repo        string   `json:"repo"`
+	Ref         string   `json:"ref,omitempty"`
+	Path        string   `json:"path,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// CatalogIndex is a catalog's index document.
+type CatalogIndex struct {
+	Version int            `json:"version"`
+	Skills  []CatalogEntry `json:"skills"`
+}
+
+// ParseCatalogIndex decodes and validates an index. Entries are sorted by ID.
+func ParseCatalogIndex(data []byte) (*CatalogIndex, error) {
+	var idx CatalogIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parsing catalog index: %w", err)
+	}
+	if idx.Version != 1 {
+		return nil, fmt.Errorf("unsupported catalog index version %d (want 1)", idx.Version)
+	}
+	seen := make(map[string]bool, len(idx.Skills))
+	for i, e := range idx.Skills {
+		switch {
+		case !catalogIDPattern.MatchString(e.ID):
+			return nil, fmt.Errorf("catalog entry %d: id %q must have the form org/skill", i, e.ID)
+		case seen[e.ID]:
+			return nil, fmt.Errorf("catalog entry %d: duplicate id %q", i, e.ID)
+		case e.Repo == "":
+			return nil, fmt.Errorf("catalog entry %q: repo is required", e.ID)
+		}
+		if e.Path != "" {
+			if err := SafeRepoPath(e.Path); err != nil {
+				return nil, fmt.Errorf("catalog entry %q: %w", e.ID, err)
+			}
+		}
+		seen[e.ID] = true
+	}
+	sort.Slice(idx.Skills, func(a, b int) bool { return idx.Skills[a].ID < idx.Skills[b].ID })
+	return &idx, nil
+}
+
+// Search returns the entries matching query as a case-insensitive substring
+// of the ID, description, or a tag. An empty query returns everything.
+func (idx *CatalogIndex) Search(query string) []CatalogEntry {
+	q := strings.ToLower(strings.TrimSpace(query))
+	matches := []CatalogEntry{}
+	for _, e := range idx.Skills {
+		if q == "" || strings.Contains(strings.ToLower(e.ID), q) || strings.Contains(strings.ToLower(e.Description), q) || hasTag(e.Tags, q) {
+			matches = append(matches, e)
+		}
+	}
+	return matches
+}
+
+// Find returns the entry with the given ID, matched case-insensitively.
+func (idx *CatalogIndex) Find(id string) (CatalogEntry, error) {
+	for _, e := range idx.Skills {
+		if strings.EqualFold(e.ID, id) {
+			return e, nil
+		}
+	}
+	return CatalogEntry{}, fmt.Errorf("%q: %w", id, ErrCatalogEntryNotFound)
+}
+
+func hasTag(tags []string, q string) bool {
+	for _, t := range tags {
+		if strings.Contains(strings.ToLower(t), q) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveCatalogSource picks the catalog source: flag, then the
+// GRIDCTL_SKILL_CATALOG environment variable, then catalog in skills.yaml.
+func ResolveCatalogSource(flag string) (string, error) {
+	if flag != "" {
+		return flag, nil
+	}
+	if env := os.Getenv(CatalogEnvVar); env != "" {
+		return env, nil
+	}
+	cfg, err := LoadSkillsConfig(SkillsConfigPath())
+	if err == nil && cfg.Catalog != "" {
+		return cfg.Catalog, nil
+	}
+	return "", ErrCatalogNotConfigured
+}
+
+// CatalogClient reads a catalog index from one of three sources: an
+// http(s) URL of a JSON file, a local file or directory, or a git
+// repository with catalog.json at its root. HTTP indexes are cached under
+// ~/.gridctl/cache/skill-catalog; git catalogs reuse the repository cache
+// the importer clones into.
+type CatalogClient struct {
+	source     string
+	logger     *slog.Logger
+	httpClient *http.Client
+	cacheDir   string
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// NewCatalogClient returns a client for source.
+func NewCatalogClient(source string, logger *slog.Logger) *CatalogClient {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &CatalogClient{
+		source:     source,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: catalogRequestTimeout},
+		cacheDir:   filepath.Join(state.BaseDir(), "cache", "skill-catalog"),
+		ttl:        catalogCacheTTL,
+		now:        time.Now,
+	}
+}
+
+// Index loads the catalog index. stale reports that an HTTP index came from
+// an expired cache after a fetch failure.
+func (c *CatalogClient) Index(ctx context.Context) (idx *CatalogIndex, stale bool, err error) {
+	var data []byte
+	switch {
+	case isHTTPIndex(c.source):
+		data, stale, err = c.fetchCached(ctx)
+	case gitpkg.DetectProtocol(c.source) == gitpkg.ProtocolLocal:
+		data, err = readLocalIndex(strings.TrimPrefix(c.source, "file://"))
+	default:
+		data, err = c.readGitIndex()
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	idx, err = ParseCatalogIndex(data)
+	return idx, stale, err
+}
+
+// isHTTPIndex reports whether source is an http(s) URL of a JSON file, as
+// opposed to an https git remote.
+func isHTTPIndex(source string) bool {
+	if gitpkg.DetectProtocol(source) != gitpkg.ProtocolHTTPS {
+		return false
+	}
+	path, _, _ := strings.Cut(source, "?")
+	return strings.HasSuffix(strings.ToLower(path), ".json")
+}
+
+// readLocalIndex reads a catalog file, or catalog.json in a directory.
+func readLocalIndex(path string) ([]byte, error) {
+	if strings.HasPrefix(path, "~/") {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, path[2:])
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, CatalogIndexFile)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading catalog index: %w", err)
+	}
+	return data, nil
+}
+
+// readGitIndex clones or updates the catalog repository and reads its index.
+func (c *CatalogClient) readGitIndex() ([]byte, error) {
+	repoPath, err := cloneShallow(c.source, "", AuthConfig{}, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("cloning catalog repository: %w", gitpkg.RedactError(err))
+	}
+	data, err := os.ReadFile(filepath.Join(repoPath, CatalogIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("catalog repository has no %s at its root: %w", CatalogIndexFile, err)
+	}
+	return data, nil
+}
+
+// fetchCached returns the HTTP index from a fresh cache, the network, or,
+// when the network fails, a stale cache.
+func (c *CatalogClient) fetchCached(ctx context.Context) ([]byte, bool, error) {
+	sum := sha256.Sum256([]byte(c.source))
+	cachePath := filepath.Join(c.cacheDir, fmt.Sprintf("index-%x.json", sum[:8]))
+	info, statErr := os.Stat(cachePath)
+	if statErr == nil && c.now().Sub(info.ModTime()) < c.ttl {
+		if data, err := os.ReadFile(cachePath); err == nil {
+			return data, false, nil
+		}
+	}
+
+	data, err := c.fetch(ctx)
+	if err == nil {
+		if _, parseErr := ParseCatalogIndex(data); parseErr != nil {
+			return nil, false, parseErr
+		}
+		if mkErr := os.MkdirAll(c.cacheDir, 0o750); mkErr == nil {
+			_ = os.WriteFile(cachePath, data, 0o600)
+		}
+		return data, false, nil
+	}
+	if cached, readErr := os.ReadFile(cachePath); readErr == nil {
+		c.logger.Debug("skill catalog unreachable; serving stale cache", "error", err)
+		return cached, true, nil
+	}
+	return nil, false, err
+}
+
+func (c *CatalogClient) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching skill catalog: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("skill catalog returned %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, catalogMaxBody))
+	if err != nil {
+		return nil, fmt.Errorf("reading skill catalog: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,130 @@
+package skills
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testCatalogIndex = `{
+  "version": 1,
+  "skills": [
+    {"id": "acme/deploy", "description": "Ship a release", "repo": "https://github.com/acme/skills", "path": "deploy"},
+    {"id": "acme/code-review", "description": "Review pull requests", "repo": "https://github.com/acme/skills", "ref": "v1.2.0", "tags": ["git", "quality"]}
+  ]
+}`
+
+func TestParseCatalogIndex(t *testing.T) {
+	idx, err := ParseCatalogIndex([]byte(testCatalogIndex))
+	if err != nil {
+		t.Fatalf("ParseCatalogIndex: %v", err)
+	}
+	if idx.Skills[0].ID != "acme/code-review" {
+		t.Errorf("entries not sorted by id: %+v", idx.Skills)
+	}
+
+	tests := []struct {
+		name, index, want string
+	}{
+		{"version", `{"version": 2, "skills": []}`, "version"},
+		{"bad id", `{"version": 1, "skills": [{"id": "deploy", "repo": "r"}]}`, "org/skill"},
+		{"duplicate", `{"version": 1, "skills": [{"id": "a/b", "repo": "r"}, {"id": "a/b", "repo": "r"}]}`, "duplicate"},
+		{"missing repo", `{"version": 1, "skills": [{"id": "a/b"}]}`, "repo is required"},
+		{"unsafe path", `{"version": 1, "skills": [{"id": "a/b", "repo": "r", "path": "../etc"}]}`, "a/b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCatalogIndex([]byte(tt.index))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogIndex_SearchAndFind(t *testing.T) {
+	idx, err := ParseCatalogIndex([]byte(testCatalogIndex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := idx.Search("QUALITY"); len(got) != 1 || got[0].ID != "acme/code-review" {
+		t.Errorf("Search by tag = %+v", got)
+	}
+	if got := idx.Search("release"); len(got) != 1 || got[0].ID != "acme/deploy" {
+		t.Errorf("Search by description = %+v", got)
+	}
+	if got := idx.Search(""); len(got) != 2 {
+		t.Errorf("empty query returned %d entries, want 2", len(got))
+	}
+	if e, err := idx.Find("ACME/Deploy"); err != nil || e.Path != "deploy" {
+		t.Errorf("Find = %+v, %v", e, err)
+	}
+	if _, err := idx.Find("acme/nope"); err == nil {
+		t.Error("expected an error for an unknown id")
+	}
+}
+
+func TestCatalogClient_HTTPCachesAndFallsBack(t *testing.T) {
+	fail := false
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if fail {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(testCatalogIndex))
+	}))
+	defer srv.Close()
+
+	c := NewCatalogClient(srv.URL+"/catalog.json", nil)
+	c.cacheDir = t.TempDir()
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	if _, stale, err := c.Index(context.Background()); err != nil || stale {
+		t.Fatalf("first Index: stale=%v err=%v", stale, err)
+	}
+	if _, _, err := c.Index(context.Background()); err != nil || hits != 1 {
+		t.Fatalf("second Index: hits=%d err=%v, want served from cache", hits, err)
+	}
+
+	// Past the TTL with the server down, the cached index is served stale.
+	fail = true
+	now = now.Add(2 * catalogCacheTTL)
+	idx, stale, err := c.Index(context.Background())
+	if err != nil || !stale || len(idx.Skills) != 2 {
+		t.Errorf("Index after failure: stale=%v err=%v", stale, err)
+	}
+}
+
+func TestCatalogClient_LocalDirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, CatalogIndexFile), []byte(testCatalogIndex), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	idx, _, err := NewCatalogClient(dir, nil).Index(context.Background())
+	if err != nil || len(idx.Skills) != 2 {
+		t.Fatalf("Index: %v", err)
+	}
+}
+
+func TestResolveCatalogSource(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(CatalogEnvVar, "")
+	if _, err := ResolveCatalogSource(""); err != ErrCatalogNotConfigured {
+		t.Errorf("err = %v, want ErrCatalogNotConfigured", err)
+	}
+	t.Setenv(CatalogEnvVar, "https://env.example.com/catalog.json")
+	if got, _ := ResolveCatalogSource(""); got != "https://env.example.com/catalog.json" {
+		t.Errorf("source = %q, want the environment variable", got)
+	}
+	if got, _ := ResolveCatalogSource("/flag"); got != "/flag" {
+		t.Errorf("source = %q, want the flag", got)
+	}
+}
@@ -8,12 +8,19 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"gopkg.in/yaml.v3"
+
+	"github.com/gridctl/gridctl/pkg/registry"
 )
 
-// SkillSource defines a remote skill source in skills.yaml.
+// SkillSource defines a remote skill source in skills.yaml: a git
+// repository (Repo) or an http(s) catalog index (Index) whose entries each
+// point at a repository. While the daemon runs, sources with auto_update
+// are synced every update_interval (see SourceSyncer).
 type SkillSource struct {
 	Name           string      `yaml:"name" json:"name"`
-	Repo           string      `yaml:"repo" json:"repo"`
+	Repo           string      `yaml:"repo,omitempty" json:"repo,omitempty"`
+	Index          string      `yaml:"index,omitempty" json:"index,omitempty"`
+	Namespace      string      `yaml:"namespace,omitempty" json:"namespace,omitempty"` // prefix for synced skill names ("<namespace>-<name>")
 	Ref            string      `yaml:"ref,omitempty" json:"ref,omitempty"`
 	Path           string      `yaml:"path,omitempty" json:"path,omitempty"`
 	AutoUpdate     *bool       `yaml:"auto_update,omitempty" json:"autoUpdate,omitempty"`
@@ -51,6 +58,10 @@ func (a *SourceAuth) ToAuthConfig() AuthConfig {
 type SkillsConfig struct {
 	Defaults SkillDefaults `yaml:"defaults,omitempty" json:"defaults,omitempty"`
 	Sources  []SkillSource `yaml:"sources" json:"sources"`
+	// Catalog is the skill catalog searched by 'gridctl skill search' and
+	// 'gridctl skill install': a JSON index URL, a local path, or a git
+	// repository with catalog.json at its root.
+	Catalog string `yaml:"catalog,omitempty" json:"catalog,omitempty"`
 }
 
 // SkillDefaults defines global defaults for skill sources.
@@ -108,11 +119,28 @@ func LoadSkillsConfig(path string) (*SkillsConfig, error) {
 	}
 
 	for i, src := range cfg.Sources {
-		if src.Repo == "" {
-			return nil, fmt.Errorf("source %d: repo is required", i)
+		switch {
+		case src.Repo == "" && src.Index == "":
+			return nil, fmt.Errorf("source %d: repo or index is required", i)
+		case src.Repo != "" && src.Index != "":
+			return nil, fmt.Errorf("source %d: repo and index are mutually exclusive", i)
+		case src.Index != "" && !isHTTPIndex(src.Index):
+			return nil, fmt.Errorf("source %d: index must be an http(s) URL of a .json catalog index", i)
+		}
+		if src.Namespace != "" {
+			if err := registry.ValidateSkillName(src.Namespace); err != nil {
+				return nil, fmt.Errorf("source %d: namespace: %w", i, err)
+			}
 		}
 		if src.Name == "" {
-			cfg.Sources[i].Name = RepoToName(src.Repo)
+			switch {
+			case src.Repo != "":
+				cfg.Sources[i].Name = RepoToName(src.Repo)
+			case src.Namespace != "":
+				cfg.Sources[i].Name = src.Namespace
+			default:
+				return nil, fmt.Errorf("source %d: name is required for an index source", i)
+			}
 		}
 	}
 
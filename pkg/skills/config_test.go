@@ -52,6 +52,44 @@ sources:
 			yaml: `
 sources:
   - name: broken
+`,
+			wantErr: true,
+		},
+		{
+			name: "index source with namespace",
+			yaml: `
+sources:
+  - index: https://prompts.example.com/catalog.json
+    namespace: acme
+`,
+			check: func(t *testing.T, cfg *SkillsConfig) {
+				assert.Equal(t, "acme", cfg.Sources[0].Name)
+				assert.Equal(t, "https://prompts.example.com/catalog.json", cfg.Sources[0].Index)
+			},
+		},
+		{
+			name: "repo and index together",
+			yaml: `
+sources:
+  - repo: https://github.com/org/skills
+    index: https://prompts.example.com/catalog.json
+`,
+			wantErr: true,
+		},
+		{
+			name: "index without a name or namespace",
+			yaml: `
+sources:
+  - index: https://prompts.example.com/catalog.json
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid namespace",
+			yaml: `
+sources:
+  - repo: https://github.com/org/skills
+    namespace: Not_Valid
 `,
 			wantErr: true,
 		},
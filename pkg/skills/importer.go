@@ -89,6 +89,13 @@ type ImportOptions struct {
 	// disabled) instead of resetting it. Used by Update so that re-syncing
 	// a source does not silently re-activate skills the user disabled.
 	PreserveState bool
+	// Namespace installs each skill as "<namespace>-<name>".
+	Namespace string
+	// Source marks the import as a scheduled sync of the named skills.yaml
+	// source. An existing skill is replaced only when it was imported from
+	// the same repository and, unless Force is set, has no local edits;
+	// anything else is reported as a conflict and left alone.
+	Source string
 }
 
 // ImportResult contains the results of an import operation.
@@ -189,6 +196,9 @@ func (imp *Importer) Import(opts ImportOptions) (*ImportResult, error) {
 
 	for _, discovered := range result.Skills {
 		skillName := discovered.Name
+		if opts.Namespace != "" {
+			skillName = opts.Namespace + "-" + discovered.Name
+		}
 		if opts.Rename != "" && len(result.Skills) == 1 {
 			skillName = opts.Rename
 		}
@@ -199,7 +209,12 @@ func (imp *Importer) Import(opts ImportOptions) (*ImportResult, error) {
 		}
 
 		// Check for existing skill; treat explicitly selected skills as force-overwrite
-		if _, err := imp.store.GetSkill(skillName); err == nil {
+		if _, err := imp.store.GetSkill(skillName); err == nil && opts.Source != "" {
+			if reason := imp.syncConflict(skillName, opts); reason != "" {
+				importResult.Skipped = append(importResult.Skipped, SkippedSkill{Name: skillName, Reason: reason})
+				continue
+			}
+		} else if err == nil {
 			force := opts.Force || (len(opts.Selected) > 0 && selectedSet[skillName])
 			if !force {
 				importResult.Skipped = append(importResult.Skipped, SkippedSkill{
@@ -277,6 +292,8 @@ func (imp *Importer) Import(opts ImportOptions) (*ImportResult, error) {
 			InstalledHash: installedHash,
 			Fingerprint:   fp,
 			CredentialRef: opts.Auth.CredentialRef,
+			Source:        opts.Source,
+			Namespace:     opts.Namespace,
 		}
 
 		if err := WriteOrigin(skillDir, origin); err != nil {
@@ -333,6 +350,25 @@ func (imp *Importer) Import(opts ImportOptions) (*ImportResult, error) {
 	return importResult, nil
 }
 
+// syncConflict returns why a scheduled sync must not replace the existing
+// skill skillName, or "" when the skill belongs to the synced repository.
+func (imp *Importer) syncConflict(skillName string, opts ImportOptions) string {
+	skillDir := imp.skillDir(skillName)
+	origin, err := ReadOrigin(skillDir)
+	if err != nil {
+		return fmt.Sprintf("conflicts with local skill %q; rename or remove it, or set a namespace on source %q", skillName, opts.Source)
+	}
+	if origin.Repo != opts.Repo {
+		return fmt.Sprintf("conflicts with %q imported from %s; set a namespace on source %q", skillName, gitpkg.RedactURL(origin.Repo), opts.Source)
+	}
+	if !opts.Force && origin.InstalledHash != "" {
+		if hash, err := ContentHashFile(filepath.Join(skillDir, "SKILL.md")); err == nil && hash != origin.InstalledHash {
+			return fmt.Sprintf("%q has local edits; reset or detach it to resume syncing", skillName)
+		}
+	}
+	return ""
+}
+
 // summarizeMalformed renders malformed SKILL.md entries for the zero-skills
 // error, capped so a repository full of bad files stays readable.
 func summarizeMalformed(malformed []MalformedSkill) string {
@@ -424,6 +460,8 @@ func (imp *Importer) Update(skillName string, dryRun, force bool) (*ImportResult
 		Force:         true,
 		Auth:          auth,
 		PreserveState: true,
+		Namespace:     origin.Namespace,
+		Source:        origin.Source,
 	})
 	if err != nil {
 		return result, err
@@ -29,6 +29,13 @@ type Origin struct {
 	// re-resolve credentials on skill update. Raw token values are never
 	// persisted — only the reference string.
 	CredentialRef string `json:"credentialRef,omitempty"`
+	// Source names the skills.yaml source that keeps this skill synced.
+	// Set only by scheduled sync; such skills are read-only through the
+	// API until detached.
+	Source string `json:"source,omitempty"`
+	// Namespace is the prefix the skill was installed under, kept so an
+	// update re-imports it under the same name.
+	Namespace string `json:"namespace,omitempty"`
 }
 
 const originFileName = ".origin.json"
@@ -231,7 +231,13 @@ func updateExisting(repoPath, url, ref string, auth AuthConfig, logger *slog.Log
 				return repoPath, nil
 			}
 		} else {
-			if err := gitpkg.Checkout(r, ref); err != nil {
+			// Check out the fetched commit: the cached clone's local branch
+			// of the same name is never advanced by a fetch.
+			target := ref
+			if sha, err := gitpkg.ResolveRef(r, ref); err == nil {
+				target = sha
+			}
+			if err := gitpkg.Checkout(r, target); err != nil {
 				logger.Warn("failed to checkout ref, using cached", "ref", ref, "error", err)
 			}
 		}
@@ -0,0 +1,200 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gridctl/gridctl/pkg/crash"
+	gitpkg "github.com/gridctl/gridctl/pkg/git"
+)
+
+// DefaultSourceSyncTick is how often SourceSyncer looks for sources whose
+// update_interval has elapsed.
+const DefaultSourceSyncTick = time.Minute
+
+// indexReader loads a catalog index (CatalogClient).
+type indexReader interface {
+	Index(ctx context.Context) (*CatalogIndex, bool, error)
+}
+
+// SourceSyncer keeps the skills of skills.yaml sources in sync while the
+// daemon runs. Every source with auto_update is re-imported each
+// update_interval: a git source as a whole, an index source entry by entry.
+// Synced skills are installed under the source's namespace and recorded as
+// belonging to it (Origin.Source), which makes them read-only through the
+// API. A sync never replaces a locally authored skill, a skill imported
+// from another repository, or a synced skill with local edits; those are
+// logged as conflicts and skipped. Skills with security findings are
+// skipped too, since nobody is there to confirm them.
+type SourceSyncer struct {
+	imp        *Importer
+	configPath string
+	logger     *slog.Logger
+	now        func() time.Time
+
+	// onChange runs after a sync imported at least one skill, so the
+	// caller can refresh whatever serves the registry.
+	onChange func()
+
+	// indexFor returns the catalog client for an index URL; a seam for tests.
+	indexFor func(url string) indexReader
+
+	mu       sync.Mutex
+	lastSync map[string]time.Time // source name -> last sync attempt
+}
+
+// NewSourceSyncer returns a syncer that reads sources from the skills.yaml
+// at configPath on every tick, so edits apply without a restart.
+func NewSourceSyncer(imp *Importer, configPath string, logger *slog.Logger) *SourceSyncer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	s := &SourceSyncer{
+		imp:        imp,
+		configPath: configPath,
+		logger:     logger,
+		now:        time.Now,
+		lastSync:   make(map[string]time.Time),
+	}
+	s.indexFor = func(url string) indexReader { return NewCatalogClient(url, logger) }
+	return s
+}
+
+// OnChange registers fn to run after a sync that imported skills.
+func (s *SourceSyncer) OnChange(fn func()) {
+	s.onChange = fn
+}
+
+// Start syncs due sources now and then every tick until ctx is cancelled.
+func (s *SourceSyncer) Start(ctx context.Context, tick time.Duration) {
+	go func() {
+		defer crash.Recover("skill-source-sync")
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for {
+			s.SyncDue(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// SyncDue syncs every auto-updating source whose update_interval has
+// elapsed since its last sync. A missing skills.yaml means no sources.
+func (s *SourceSyncer) SyncDue(ctx context.Context) {
+	cfg, err := LoadSkillsConfig(s.configPath)
+	if err != nil {
+		return
+	}
+	changed := false
+	for i := range cfg.Sources {
+		src := &cfg.Sources[i]
+		if ctx.Err() != nil {
+			return
+		}
+		if !cfg.EffectiveAutoUpdate(src) || !s.due(src.Name, cfg.EffectiveUpdateInterval(src)) {
+			continue
+		}
+		imported, err := s.syncSource(ctx, src)
+		if err != nil {
+			s.logger.Warn("skill source sync failed", "source", src.Name, "error", err)
+		}
+		changed = changed || imported > 0
+	}
+	if changed && s.onChange != nil {
+		s.onChange()
+	}
+}
+
+// due reports whether a source's interval has elapsed, and if so records
+// this attempt so a failing source is retried next interval, not next tick.
+func (s *SourceSyncer) due(name string, interval time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if last, ok := s.lastSync[name]; ok && now.Sub(last) < interval {
+		return false
+	}
+	s.lastSync[name] = now
+	return true
+}
+
+// syncSource imports one source and returns how many skills it installed.
+func (s *SourceSyncer) syncSource(ctx context.Context, src *SkillSource) (int, error) {
+	auth, err := s.sourceAuth(src)
+	if err != nil {
+		return 0, err
+	}
+	base := ImportOptions{
+		Ref:           src.Ref,
+		Path:          src.Path,
+		Auth:          auth,
+		PreserveState: true,
+		Namespace:     src.Namespace,
+		Source:        src.Name,
+	}
+
+	if src.Index == "" {
+		opts := base
+		opts.Repo = src.Repo
+		return s.importOne(src.Name, opts)
+	}
+
+	idx, stale, err := s.indexFor(src.Index).Index(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("reading index: %w", err)
+	}
+	if stale {
+		s.logger.Warn("skill source index unreachable; syncing from cached copy", "source", src.Name)
+	}
+	total := 0
+	var errs []error
+	for _, entry := range idx.Skills {
+		opts := base
+		opts.Repo, opts.Ref, opts.Path = entry.Repo, entry.Ref, entry.Path
+		n, err := s.importOne(src.Name, opts)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.ID, err))
+		}
+		total += n
+	}
+	if len(errs) > 0 {
+		return total, fmt.Errorf("%d of %d index entries failed: %w", len(errs), len(idx.Skills), errs[0])
+	}
+	return total, nil
+}
+
+// importOne runs one import and logs its outcome.
+func (s *SourceSyncer) importOne(source string, opts ImportOptions) (int, error) {
+	result, err := s.imp.Import(opts)
+	if err != nil {
+		return 0, err
+	}
+	for _, skipped := range result.Skipped {
+		s.logger.Warn("skill source sync skipped skill", "source", source, "skill", skipped.Name, "reason", skipped.Reason)
+	}
+	if len(result.Imported) > 0 {
+		s.logger.Info("skill source synced", "source", source, "repo", gitpkg.RedactURL(opts.Repo), "skills", len(result.Imported))
+	}
+	return len(result.Imported), nil
+}
+
+// sourceAuth builds the source's auth config, resolving a credential
+// reference through the importer's resolver.
+func (s *SourceSyncer) sourceAuth(src *SkillSource) (AuthConfig, error) {
+	auth := src.Auth.ToAuthConfig()
+	if auth.CredentialRef == "" {
+		return auth, nil
+	}
+	resolved, err := s.imp.authFromOrigin(&Origin{CredentialRef: auth.CredentialRef})
+	if err != nil {
+		return AuthConfig{}, err
+	}
+	return resolved, nil
+}
@@ -0,0 +1,134 @@
+package skills
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIndex struct{ idx *CatalogIndex }
+
+func (f fakeIndex) Index(context.Context) (*CatalogIndex, bool, error) { return f.idx, false, nil }
+
+func writeSkillsConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "skills.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestSourceSyncer_SyncsOnInterval(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	store, regDir := setupTestRegistry(t)
+	imp := NewImporter(store, regDir, filepath.Join(regDir, "skills.lock.yaml"), slog.Default())
+
+	r := initSkillRepoNamed(t, "review", "# Review\n\nv1.\n")
+	cfgPath := writeSkillsConfig(t, t.TempDir(), `
+sources:
+  - repo: `+r.dir+`
+    ref: master
+    namespace: team
+    auto_update: true
+    update_interval: 1h
+`)
+
+	s := NewSourceSyncer(imp, cfgPath, slog.Default())
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	changes := 0
+	s.OnChange(func() { changes++ })
+
+	s.SyncDue(context.Background())
+	sk, err := store.GetSkill("team-review")
+	require.NoError(t, err)
+	assert.Contains(t, sk.Body, "v1.")
+	origin, err := ReadOrigin(filepath.Join(regDir, "skills", "team-review"))
+	require.NoError(t, err)
+	assert.Equal(t, "team", origin.Namespace)
+	assert.NotEmpty(t, origin.Source)
+	assert.Equal(t, 1, changes)
+
+	// Within the interval an upstream change is not picked up.
+	commitChangeNamed(t, r, "review", "# Review\n\nv2.\n")
+	now = now.Add(30 * time.Minute)
+	s.SyncDue(context.Background())
+	sk, _ = store.GetSkill("team-review")
+	assert.Contains(t, sk.Body, "v1.")
+
+	now = now.Add(time.Hour)
+	s.SyncDue(context.Background())
+	sk, _ = store.GetSkill("team-review")
+	assert.Contains(t, sk.Body, "v2.")
+	assert.Equal(t, 2, changes)
+}
+
+func TestSourceSyncer_SkipsConflicts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	store, regDir := setupTestRegistry(t)
+	imp := NewImporter(store, regDir, filepath.Join(regDir, "skills.lock.yaml"), slog.Default())
+
+	// A locally authored skill with the same name is never replaced.
+	createTestSkill(t, store, "review")
+	r := initSkillRepoNamed(t, "review", "# Review\n\nupstream.\n")
+
+	result, err := imp.Import(ImportOptions{Repo: r.dir, Source: "team"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Imported)
+	require.Len(t, result.Skipped, 1)
+	assert.Contains(t, result.Skipped[0].Reason, "local skill")
+	sk, _ := store.GetSkill("review")
+	assert.Equal(t, "Test skill review", sk.Description)
+
+	// A synced skill with local edits is left alone until reset.
+	_, err = imp.Import(ImportOptions{Repo: r.dir, Source: "team", Namespace: "team"})
+	require.NoError(t, err)
+	skillMD := filepath.Join(regDir, "skills", "team-review", "SKILL.md")
+	data, err := os.ReadFile(skillMD)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(skillMD, append(data, []byte("\nlocal edit\n")...), 0644))
+
+	result, err = imp.Import(ImportOptions{Repo: r.dir, Source: "team", Namespace: "team"})
+	require.NoError(t, err)
+	require.Len(t, result.Skipped, 1)
+	assert.Contains(t, result.Skipped[0].Reason, "local edits")
+}
+
+func TestSourceSyncer_IndexSource(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	store, regDir := setupTestRegistry(t)
+	imp := NewImporter(store, regDir, filepath.Join(regDir, "skills.lock.yaml"), slog.Default())
+
+	a := initSkillRepoNamed(t, "alpha", "# Alpha\n")
+	b := initSkillRepoNamed(t, "beta", "# Beta\n")
+	cfgPath := writeSkillsConfig(t, t.TempDir(), `
+sources:
+  - name: catalog
+    index: https://prompts.example.com/catalog.json
+    auto_update: true
+`)
+
+	s := NewSourceSyncer(imp, cfgPath, slog.Default())
+	s.indexFor = func(url string) indexReader {
+		assert.True(t, strings.HasSuffix(url, "catalog.json"))
+		return fakeIndex{&CatalogIndex{Version: 1, Skills: []CatalogEntry{
+			{ID: "acme/alpha", Repo: a.dir},
+			{ID: "acme/beta", Repo: b.dir},
+		}}}
+	}
+	s.SyncDue(context.Background())
+
+	for _, name := range []string{"alpha", "beta"} {
+		_, err := store.GetSkill(name)
+		assert.NoError(t, err, name)
+		origin, err := ReadOrigin(filepath.Join(regDir, "skills", name))
+		require.NoError(t, err)
+		assert.Equal(t, "catalog", origin.Source)
+	}
+}
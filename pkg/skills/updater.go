@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/gridctl/gridctl/pkg/crash"
 )
 
 // UpdateStatus records the result of a background update check.
@@ -92,6 +94,7 @@ func CheckUpdatesBackground(registryDir string, logger *slog.Logger) {
 	}
 
 	go func() {
+		defer crash.Recover("skill-update-check")
 		status := checkAllUpdates(registryDir, logger)
 		if err := WriteUpdateCache(status); err != nil {
 			logger.Warn("failed to write update cache", "error", err)
@@ -127,6 +130,7 @@ func checkAllUpdates(registryDir string, logger *slog.Logger) *UpdateStatus {
 
 		wg.Add(1)
 		go func(dir, name string) {
+			defer crash.Recover("skill-update-check", "skill", name)
 			defer wg.Done()
 			sem <- struct{}{}
 			defer func() { <-sem }()
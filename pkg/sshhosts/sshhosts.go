@@ -0,0 +1,158 @@
+// Package sshhosts manages the SSH host keys gridctl trusts: fetching the key
+// a host presents so it can be reviewed, recording it in a known_hosts file,
+// and checking whether a host is listed before an SSH server is started.
+package sshhosts
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// DefaultPort is the SSH port used when none is configured.
+const DefaultPort = 22
+
+const fetchTimeout = 10 * time.Second
+
+var (
+	// ErrNotTrusted is returned when a known_hosts file has no entry for a host.
+	ErrNotTrusted = errors.New("host is not in known_hosts")
+	// ErrKeyMismatch is returned when a host is listed with a different key,
+	// which may mean the key was rotated or the connection is intercepted.
+	ErrKeyMismatch = errors.New("host key does not match the trusted key")
+)
+
+// errKeyCaptured aborts the handshake once the host key has been read.
+var errKeyCaptured = errors.New("host key captured")
+
+// Address returns host and port in known_hosts form: "host" on the default
+// port, "[host]:port" otherwise.
+func Address(host string, port int) string {
+	if port == 0 {
+		port = DefaultPort
+	}
+	return knownhosts.Normalize(net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// FetchHostKey connects to host and returns the key it presents. The
+// handshake stops before authentication, so no credentials are needed.
+func FetchHostKey(ctx context.Context, host string, port int) (ssh.PublicKey, error) {
+	if port == 0 {
+		port = DefaultPort
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	dialer := net.Dialer{Timeout: fetchTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", addr, err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(fetchTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	var key ssh.PublicKey
+	_, _, _, err = ssh.NewClientConn(conn, addr, &ssh.ClientConfig{
+		User: "gridctl",
+		HostKeyCallback: func(_ string, _ net.Addr, k ssh.PublicKey) error {
+			key = k
+			return errKeyCaptured
+		},
+	})
+	if key == nil {
+		return nil, fmt.Errorf("reading host key from %s: %w", addr, err)
+	}
+	return key, nil
+}
+
+// Fingerprint returns the key's SHA256 fingerprint as ssh-keygen prints it.
+func Fingerprint(key ssh.PublicKey) string {
+	return ssh.FingerprintSHA256(key)
+}
+
+// Check reports whether the known_hosts file at path trusts key for host:
+// nil when it does, ErrKeyMismatch when the host is listed with another key,
+// and ErrNotTrusted when the host is not listed or the file does not exist.
+func Check(path, host string, port int, key ssh.PublicKey) error {
+	if port == 0 {
+		port = DefaultPort
+	}
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNotTrusted
+		}
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	remote := &net.TCPAddr{IP: net.ParseIP(host), Port: port}
+	if remote.IP == nil {
+		remote.IP = net.IPv4zero
+	}
+	err = callback(net.JoinHostPort(host, strconv.Itoa(port)), remote, key)
+	var keyErr *knownhosts.KeyError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &keyErr) && len(keyErr.Want) > 0:
+		return ErrKeyMismatch
+	case errors.As(err, &keyErr):
+		return ErrNotTrusted
+	default:
+		return err
+	}
+}
+
+// probeKey is a throwaway key that no known_hosts entry can match, used to
+// ask whether a host is listed at all.
+var probeKey = sync.OnceValues(func() (ssh.PublicKey, error) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return nil, err
+	}
+	return ssh.NewPublicKey(pub)
+})
+
+// Listed reports whether the known_hosts file at path has any key for host.
+func Listed(path, host string, port int) (bool, error) {
+	key, err := probeKey()
+	if err != nil {
+		return false, err
+	}
+	switch err := Check(path, host, port, key); {
+	case err == nil, errors.Is(err, ErrKeyMismatch):
+		return true, nil
+	case errors.Is(err, ErrNotTrusted):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Append records key for host in the known_hosts file at path, creating the
+// file (mode 0600) and its directory as needed.
+func Append(path, host string, port int, key ssh.PublicKey) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, knownhosts.Line([]string{Address(host, port)}, key)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
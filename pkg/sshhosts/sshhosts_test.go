@@ -0,0 +1,114 @@
+package sshhosts
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newKey(t *testing.T) (ssh.PublicKey, ssh.Signer) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer.PublicKey(), signer
+}
+
+func TestAddress(t *testing.T) {
+	tests := []struct {
+		host string
+		port int
+		want string
+	}{
+		{"example.com", 0, "example.com"},
+		{"example.com", 22, "example.com"},
+		{"example.com", 2222, "[example.com]:2222"},
+		{"10.0.0.5", 2222, "[10.0.0.5]:2222"},
+	}
+	for _, tt := range tests {
+		if got := Address(tt.host, tt.port); got != tt.want {
+			t.Errorf("Address(%q, %d) = %q, want %q", tt.host, tt.port, got, tt.want)
+		}
+	}
+}
+
+func TestAppendAndCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "known_hosts")
+	key, _ := newKey(t)
+	other, _ := newKey(t)
+
+	if err := Check(path, "example.com", 2222, key); !errors.Is(err, ErrNotTrusted) {
+		t.Fatalf("missing file: Check = %v, want ErrNotTrusted", err)
+	}
+	if err := Append(path, "example.com", 2222, key); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("known_hosts mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if err := Check(path, "example.com", 2222, key); err != nil {
+		t.Errorf("trusted key: Check = %v, want nil", err)
+	}
+	if err := Check(path, "example.com", 2222, other); !errors.Is(err, ErrKeyMismatch) {
+		t.Errorf("other key: Check = %v, want ErrKeyMismatch", err)
+	}
+	if err := Check(path, "example.com", 22, key); !errors.Is(err, ErrNotTrusted) {
+		t.Errorf("other port: Check = %v, want ErrNotTrusted", err)
+	}
+
+	if listed, err := Listed(path, "example.com", 2222); err != nil || !listed {
+		t.Errorf("Listed = %v, %v; want true", listed, err)
+	}
+	if listed, err := Listed(path, "other.example.com", 2222); err != nil || listed {
+		t.Errorf("Listed unknown host = %v, %v; want false", listed, err)
+	}
+}
+
+func TestFetchHostKey(t *testing.T) {
+	hostKey, signer := newKey(t)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		cfg := &ssh.ServerConfig{NoClientAuth: true}
+		cfg.AddHostKey(signer)
+		_, _, _, _ = ssh.NewServerConn(conn, cfg)
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	key, err := FetchHostKey(context.Background(), "127.0.0.1", addr.Port)
+	if err != nil {
+		t.Fatalf("FetchHostKey: %v", err)
+	}
+	if Fingerprint(key) != Fingerprint(hostKey) {
+		t.Errorf("fetched %s, want %s", Fingerprint(key), Fingerprint(hostKey))
+	}
+	if !strings.HasPrefix(Fingerprint(key), "SHA256:") {
+		t.Errorf("fingerprint %q should be SHA256", Fingerprint(key))
+	}
+}
@@ -56,6 +56,34 @@ func LimitsDir() string {
 	return filepath.Join(BaseDir(), "limits")
 }
 
+// RequestsDir returns the directory for recorded MCP request logs
+// (~/.gridctl/requests/).
+func RequestsDir() string {
+	return filepath.Join(BaseDir(), "requests")
+}
+
+// RequestLogDir returns the directory holding a stack's per-session request
+// logs (~/.gridctl/requests/{name}/).
+func RequestLogDir(name string) string {
+	return filepath.Join(RequestsDir(), name)
+}
+
+// CrashDir returns the directory for daemon crash reports (~/.gridctl/crashes/).
+func CrashDir() string {
+	return filepath.Join(BaseDir(), "crashes")
+}
+
+// UsageDir returns the directory for opt-in CLI usage counters (~/.gridctl/usage/).
+func UsageDir() string {
+	return filepath.Join(BaseDir(), "usage")
+}
+
+// UsagePath returns the path to the aggregated usage counters file
+// (~/.gridctl/usage/usage.json).
+func UsagePath() string {
+	return filepath.Join(UsageDir(), "usage.json")
+}
+
 // LimitsLedgerPath returns the path to the budget spend ledger for a stack
 // (~/.gridctl/limits/{name}.json).
 func LimitsLedgerPath(name string) string {
@@ -67,6 +95,12 @@ func PinsPath(name string) string {
 	return filepath.Join(PinsDir(), name+".json")
 }
 
+// KnownHostsPath returns the gridctl-managed SSH known_hosts file
+// (~/.gridctl/known_hosts), written by 'gridctl ssh trust'.
+func KnownHostsPath() string {
+	return filepath.Join(BaseDir(), "known_hosts")
+}
+
 // StatePath returns the path to a state file for a stack.
 func StatePath(name string) string {
 	return filepath.Join(StateDir(), name+".json")
@@ -85,6 +85,31 @@ func TestTelemetryDir(t *testing.T) {
 	}
 }
 
+func TestCrashDir(t *testing.T) {
+	cleanup := setTempHome(t)
+	defer cleanup()
+
+	home := os.Getenv("HOME")
+	expected := filepath.Join(home, ".gridctl", "crashes")
+	if got := CrashDir(); got != expected {
+		t.Errorf("CrashDir() = %q, want %q", got, expected)
+	}
+}
+
+func TestUsagePath(t *testing.T) {
+	cleanup := setTempHome(t)
+	defer cleanup()
+
+	home := os.Getenv("HOME")
+	expected := filepath.Join(home, ".gridctl", "usage", "usage.json")
+	if got := UsagePath(); got != expected {
+		t.Errorf("UsagePath() = %q, want %q", got, expected)
+	}
+	if got := UsageDir(); got != filepath.Dir(expected) {
+		t.Errorf("UsageDir() = %q, want %q", got, filepath.Dir(expected))
+	}
+}
+
 func TestTelemetryServerPath(t *testing.T) {
 	cleanup := setTempHome(t)
 	defer cleanup()
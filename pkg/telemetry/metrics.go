@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gridctl/gridctl/pkg/crash"
 	"github.com/gridctl/gridctl/pkg/metrics"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
@@ -293,6 +294,7 @@ func (f *MetricsFlusher) Stop() {
 
 // run is the flush goroutine.
 func (f *MetricsFlusher) run() {
+	defer crash.Recover("metrics-flusher")
 	defer close(f.done)
 	ticker := time.NewTicker(f.interval)
 	defer ticker.Stop()
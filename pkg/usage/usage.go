@@ -0,0 +1,231 @@
+// Package usage records anonymous, opt-in CLI usage counters on the local
+// machine. Nothing is ever sent over the network: counters are aggregated
+// in place (no per-invocation events, arguments, names, or paths), and
+// `gridctl telemetry export` renders the aggregate as a report a user can
+// choose to attach to an issue.
+package usage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// SchemaVersion identifies the shape of the counters file and the exported
+// report. Evolution within a version is append-only.
+const SchemaVersion = 1
+
+// Counters is the on-disk aggregate. Every map is keyed by a coarse,
+// non-identifying label and holds an occurrence count.
+type Counters struct {
+	SchemaVersion int       `json:"schema_version"`
+	Enabled       bool      `json:"enabled"`
+	Since         time.Time `json:"since,omitzero"`
+
+	// Commands counts invocations by command path ("apply", "pins verify").
+	Commands map[string]int `json:"commands,omitempty"`
+	// ErrorClasses counts failed invocations by exit class.
+	ErrorClasses map[string]int `json:"error_classes,omitempty"`
+	// StackSizes counts deployed stacks by server-count bucket.
+	StackSizes map[string]int `json:"stack_sizes,omitempty"`
+	// Transports counts deployed servers by transport or adapter kind.
+	Transports map[string]int `json:"transports,omitempty"`
+}
+
+// Event is one command invocation to fold into the counters.
+type Event struct {
+	// Command is the command path without the binary name.
+	Command string
+	// ErrorClass is the exit class of a failed run; empty on success.
+	ErrorClass string
+	// Stack describes the deployed stack, when the command deployed one.
+	Stack *StackShape
+}
+
+// StackShape is the anonymous shape of a stack: how many servers, and the
+// transport or adapter kind of each.
+type StackShape struct {
+	Servers    int
+	Transports []string
+}
+
+// Report is the exported aggregate, with the environment facts a
+// maintainer needs to read it. Dates are day-granular.
+type Report struct {
+	SchemaVersion  int            `json:"schema_version"`
+	GridctlVersion string         `json:"gridctl_version"`
+	OS             string         `json:"os"`
+	Arch           string         `json:"arch"`
+	Since          string         `json:"since,omitempty"`
+	Generated      string         `json:"generated"`
+	Commands       map[string]int `json:"commands"`
+	ErrorClasses   map[string]int `json:"error_classes"`
+	StackSizes     map[string]int `json:"stack_sizes"`
+	Transports     map[string]int `json:"transports"`
+}
+
+// Load reads the counters at path. A missing file yields disabled, empty
+// counters rather than an error.
+func Load(path string) (*Counters, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is derived from the managed state directory
+	if errors.Is(err, os.ErrNotExist) {
+		return &Counters{SchemaVersion: SchemaVersion}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading usage counters: %w", err)
+	}
+	var c Counters
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing usage counters: %w", err)
+	}
+	return &c, nil
+}
+
+// Save writes c to path atomically (temp file + rename) with owner-only
+// permissions.
+func Save(path string, c *Counters) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating usage directory: %w", err)
+	}
+	c.SchemaVersion = SchemaVersion
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding usage counters: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".usage-*.json")
+	if err != nil {
+		return fmt.Errorf("writing usage counters: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing usage counters: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing usage counters: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing usage counters: %w", err)
+	}
+	return nil
+}
+
+// SetEnabled opts in or out. Opting in starts the collection window now;
+// opting out discards every counter so nothing lingers on disk.
+func SetEnabled(path string, enabled bool, now time.Time) error {
+	if !enabled {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("removing usage counters: %w", err)
+		}
+		return nil
+	}
+	c, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if c.Enabled {
+		return nil
+	}
+	c.Enabled = true
+	c.Since = now.UTC().Truncate(24 * time.Hour)
+	return Save(path, c)
+}
+
+// DoNotTrack reports whether the DO_NOT_TRACK convention
+// (https://consoledonottrack.com) is set, which overrides an opt-in.
+func DoNotTrack() bool {
+	v := os.Getenv("DO_NOT_TRACK")
+	return v != "" && v != "0" && v != "false"
+}
+
+// Record folds ev into the counters at path. It is a no-op unless the user
+// opted in and DO_NOT_TRACK is unset. Concurrent invocations race on the
+// read-modify-write; an occasional lost increment is acceptable for
+// aggregate counts.
+func Record(path string, ev Event) error {
+	if DoNotTrack() {
+		return nil
+	}
+	c, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if !c.Enabled {
+		return nil
+	}
+	c.add(ev)
+	return Save(path, c)
+}
+
+func (c *Counters) add(ev Event) {
+	if ev.Command != "" {
+		c.Commands = increment(c.Commands, ev.Command)
+	}
+	if ev.ErrorClass != "" {
+		c.ErrorClasses = increment(c.ErrorClasses, ev.ErrorClass)
+	}
+	if ev.Stack != nil {
+		c.StackSizes = increment(c.StackSizes, SizeBucket(ev.Stack.Servers))
+		for _, t := range ev.Stack.Transports {
+			c.Transports = increment(c.Transports, t)
+		}
+	}
+}
+
+func increment(m map[string]int, key string) map[string]int {
+	if m == nil {
+		m = map[string]int{}
+	}
+	m[key]++
+	return m
+}
+
+// SizeBucket maps a server count to a coarse bucket so the exact stack
+// size never leaves the machine.
+func SizeBucket(n int) string {
+	switch {
+	case n <= 0:
+		return "0"
+	case n == 1:
+		return "1"
+	case n <= 5:
+		return "2-5"
+	case n <= 10:
+		return "6-10"
+	case n <= 25:
+		return "11-25"
+	default:
+		return "26+"
+	}
+}
+
+// Export renders the counters as a shareable report. Empty maps are
+// emitted as {} so consumers never special-case missing keys.
+func (c *Counters) Export(version string, now time.Time) Report {
+	r := Report{
+		SchemaVersion:  SchemaVersion,
+		GridctlVersion: version,
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+		Generated:      now.UTC().Format(time.DateOnly),
+		Commands:       nonNil(c.Commands),
+		ErrorClasses:   nonNil(c.ErrorClasses),
+		StackSizes:     nonNil(c.StackSizes),
+		Transports:     nonNil(c.Transports),
+	}
+	if !c.Since.IsZero() {
+		r.Since = c.Since.UTC().Format(time.DateOnly)
+	}
+	return r
+}
+
+func nonNil(m map[string]int) map[string]int {
+	if m == nil {
+		return map[string]int{}
+	}
+	return m
+}
@@ -0,0 +1,178 @@
+package usage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+
+func tempPath(t *testing.T) string {
+	t.Helper()
+	t.Setenv("DO_NOT_TRACK", "")
+	return filepath.Join(t.TempDir(), "usage", "usage.json")
+}
+
+func TestLoad_MissingFileIsDisabled(t *testing.T) {
+	c, err := Load(tempPath(t))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.Enabled {
+		t.Error("expected counters to be disabled by default")
+	}
+}
+
+func TestLoad_InvalidJSON(t *testing.T) {
+	path := tempPath(t)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected a parse error")
+	}
+}
+
+func TestRecord_NoOpWhenDisabled(t *testing.T) {
+	path := tempPath(t)
+	if err := Record(path, Event{Command: "apply"}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected no counters file while opted out")
+	}
+}
+
+func TestRecord_Aggregates(t *testing.T) {
+	path := tempPath(t)
+	if err := SetEnabled(path, true, testNow); err != nil {
+		t.Fatalf("SetEnabled: %v", err)
+	}
+
+	events := []Event{
+		{Command: "apply", Stack: &StackShape{Servers: 3, Transports: []string{"container-http", "external", "container-http"}}},
+		{Command: "apply", ErrorClass: "config"},
+		{Command: "pins verify"},
+	}
+	for _, ev := range events {
+		if err := Record(path, ev); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.Commands["apply"] != 2 || c.Commands["pins verify"] != 1 {
+		t.Errorf("commands = %v", c.Commands)
+	}
+	if c.ErrorClasses["config"] != 1 {
+		t.Errorf("error classes = %v", c.ErrorClasses)
+	}
+	if c.StackSizes["2-5"] != 1 {
+		t.Errorf("stack sizes = %v", c.StackSizes)
+	}
+	if c.Transports["container-http"] != 2 || c.Transports["external"] != 1 {
+		t.Errorf("transports = %v", c.Transports)
+	}
+	if !c.Since.Equal(time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("since = %v, want the opt-in day", c.Since)
+	}
+}
+
+func TestRecord_DoNotTrack(t *testing.T) {
+	path := tempPath(t)
+	if err := SetEnabled(path, true, testNow); err != nil {
+		t.Fatalf("SetEnabled: %v", err)
+	}
+	t.Setenv("DO_NOT_TRACK", "1")
+	if err := Record(path, Event{Command: "apply"}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	c, _ := Load(path)
+	if len(c.Commands) != 0 {
+		t.Errorf("expected DO_NOT_TRACK to suppress recording, got %v", c.Commands)
+	}
+}
+
+func TestSetEnabled_OffDeletesCounters(t *testing.T) {
+	path := tempPath(t)
+	if err := SetEnabled(path, true, testNow); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(path, Event{Command: "apply"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetEnabled(path, false, testNow); err != nil {
+		t.Fatalf("SetEnabled(false): %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected opting out to delete the counters file")
+	}
+	// Opting out twice is not an error.
+	if err := SetEnabled(path, false, testNow); err != nil {
+		t.Errorf("second SetEnabled(false): %v", err)
+	}
+}
+
+func TestSave_OwnerOnlyPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX permissions")
+	}
+	path := tempPath(t)
+	if err := Save(path, &Counters{Enabled: true}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("permissions = %o, want 600", perm)
+	}
+}
+
+func TestSizeBucket(t *testing.T) {
+	for n, want := range map[int]string{0: "0", 1: "1", 2: "2-5", 5: "2-5", 6: "6-10", 11: "11-25", 26: "26+", 400: "26+"} {
+		if got := SizeBucket(n); got != want {
+			t.Errorf("SizeBucket(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	c := &Counters{
+		Enabled:  true,
+		Since:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Commands: map[string]int{"apply": 4},
+	}
+	r := c.Export("v1.2.3", testNow)
+	if r.GridctlVersion != "v1.2.3" || r.OS != runtime.GOOS || r.Arch != runtime.GOARCH {
+		t.Errorf("environment = %+v", r)
+	}
+	if r.Since != "2026-03-01" || r.Generated != "2026-03-14" {
+		t.Errorf("dates = %q / %q", r.Since, r.Generated)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"commands", "error_classes", "stack_sizes", "transports"} {
+		if _, ok := decoded[key].(map[string]any); !ok {
+			t.Errorf("expected %q to be an object, got %v", key, decoded[key])
+		}
+	}
+}
@@ -35,7 +35,7 @@ export function BudgetBar({ entry, className }: { entry: LimitEntry; className?:
 }
 
 // One row of the Limits panel: budget entries get the full bar, rate entries
-// a compact calls/min annotation.
+// a compact calls/min annotation, schedules their window.
 function LimitsPanelRow({ entry }: { entry: LimitEntry }) {
   return (
     <li className="flex items-center gap-2 px-3 py-1.5">
@@ -45,13 +45,17 @@ function LimitsPanelRow({ entry }: { entry: LimitEntry }) {
           entry.kind === 'budget' ? 'text-text-muted' : 'text-text-muted/70',
         )}
       >
-        {entry.kind === 'budget' ? entry.budget?.period ?? 'budget' : 'rate'}
+        {entry.kind === 'budget' ? entry.budget?.period ?? 'budget' : entry.kind}
       </span>
       <span className="w-40 flex-shrink-0 truncate font-mono text-[10px] text-text-secondary" title={entry.key}>
         <span className="text-text-muted/60">{entry.scope}:</span> {entry.key}
       </span>
       {entry.kind === 'budget' ? (
         <BudgetBar entry={entry} className="flex-1" />
+      ) : entry.kind === 'schedule' ? (
+        <span className="flex-1 text-[10px] tabular-nums text-text-muted">
+          {entry.schedule?.days} {entry.schedule?.hours}
+        </span>
       ) : (
         <span className="flex-1 text-[10px] tabular-nums text-text-muted">
           {entry.rate?.calls_per_minute} calls/min
@@ -77,7 +81,7 @@ function LimitsPanelRow({ entry }: { entry: LimitEntry }) {
 // without limits are visually unchanged.
 export function LimitsPanel({ summary }: { summary: LimitsSummary }) {
   if (!summary.configured || summary.entries.length === 0) return null;
-  const order = { exceeded: 0, warn: 1, ok: 2 } as const;
+  const order = { exceeded: 0, warn: 1, closed: 2, ok: 3 } as const;
   const sorted = [...summary.entries].sort(
     (a, b) => order[a.state] - order[b.state] || a.key.localeCompare(b.key),
   );
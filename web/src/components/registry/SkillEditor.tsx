@@ -521,6 +521,11 @@ export function SkillEditor({
         body,
         state,
         fileCount: skill?.fileCount ?? 0,
+        ...(skill?.version && { version: skill.version }),
+        // Tags and variants have no form fields yet; carry them through so a
+        // save from the editor does not drop them.
+        ...(skill?.tags && { tags: skill.tags }),
+        ...(skill?.variants && { variants: skill.variants }),
         ...(license && { license }),
         ...(compatibility && { compatibility }),
         ...(Object.keys(metadataRecord).length > 0 && { metadata: metadataRecord }),
@@ -85,7 +85,7 @@ function GroupDetail({ group, toolCatalog }: { group: GroupStatus; toolCatalog:
       <div>
         <div className="flex items-center gap-2 flex-wrap">
           <span className="text-sm font-mono text-text-primary">{group.name}</span>
-          <EndpointCopy endpoint={group.endpoint} />
+          <EndpointCopy endpoint={group.endpoint} url={group.url} />
         </div>
         {group.description && (
           <p className="text-[11px] text-text-muted mt-1">{group.description}</p>
@@ -172,9 +172,9 @@ function MemberRow({ member, toolCatalog }: { member: GroupToolStatus; toolCatal
 }
 
 // EndpointCopy renders the group's endpoint path with a copy-URL affordance.
-function EndpointCopy({ endpoint }: { endpoint: string }) {
+function EndpointCopy({ endpoint, url: reported }: { endpoint: string; url?: string }) {
   const [copied, setCopied] = useState(false);
-  const url = groupEndpointURL(endpoint);
+  const url = groupEndpointURL(endpoint, reported);
 
   const copy = async () => {
     try {
@@ -1,4 +1,5 @@
 import { useEffect, useRef } from 'react';
+import { withBasePath } from '../lib/basePath';
 
 /**
  * Lightweight SSE connection that only listens for shutdown events.
@@ -8,7 +9,7 @@ export function useSSEShutdown(onShutdown: () => void) {
   const eventSourceRef = useRef<EventSource | null>(null);
 
   useEffect(() => {
-    const es = new EventSource('/sse');
+    const es = new EventSource(withBasePath('/sse'));
     eventSourceRef.current = es;
 
     es.addEventListener('close', () => {
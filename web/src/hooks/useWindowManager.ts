@@ -1,6 +1,7 @@
 import { useCallback } from 'react';
 import { useUIStore } from '../stores/useUIStore';
 import { useBroadcastChannel, type BroadcastMessage } from './useBroadcastChannel';
+import { withBasePath } from '../lib/basePath';
 
 const WINDOW_TITLES: Record<string, string> = {
   logs: 'Gridctl - Logs',
@@ -110,7 +111,7 @@ export function useWindowManager() {
     }
 
     const basePath = WINDOW_PATHS[type] ?? `/${type}`;
-    const url = params ? `${basePath}?${params}` : basePath;
+    const url = withBasePath(params ? `${basePath}?${params}` : basePath);
     const newWindow = window.open(url, `gridctl-${type}`);
 
     if (!newWindow) {
@@ -1,7 +1,8 @@
-import type { GatewayStatus, MCPServerStatus, ServerAuthInfo, ServerAuthLogin, ClientStatus, ToolsListResult, ToolUsageResponse, SkillUsageResponse, RegistryStatus, AgentSkill, ItemState, SkillFile, SkillValidationResult, TokenMetricsResponse, CostMetricsResponse, OptimizeReport, ValidationResult, PlanDiff, SpecHealth, StackSpec, SkillSourceStatus, SkillPreviewResponse, ImportResult, SourceUpdateCheck, UpdateSummary, SourceSyncSummary, SkillSyncResult, SkillDiffResponse, InventoryRecord, TelemetryMutationResponse, TelemetryPersistDefaults, TelemetryRetention, PricingModelsResponse, UpdateClientModelResponse, UpdateServerModelResponse, UpdateDefaultModelResponse } from '../types';
+import type { GatewayStatus, MCPServerStatus, ServerAuthInfo, ServerAuthLogin, ClientStatus, ToolsListResult, ToolUsageResponse, SkillUsageResponse, RegistryStatus, AgentSkill, ItemState, SkillFile, SkillValidationResult, TokenMetricsResponse, CostMetricsResponse, OptimizeReport, ValidationResult, PlanDiff, SpecHealth, StackSpec, SkillSourceStatus, SkillPreviewResponse, ImportResult, SourceUpdateCheck, UpdateSummary, SourceSyncSummary, SkillSyncResult, SkillDiffResponse, InventoryRecord, TelemetryMutationResponse, TelemetryPersistDefaults, TelemetryRetention, PricingModelsResponse, UpdateClientModelResponse, UpdateServerModelResponse, UpdateDefaultModelResponse, ListEnvelope, Tool } from '../types';
+import { BASE_PATH } from './basePath';
 
-// Base URL for API calls - empty for same origin
-const API_BASE = '';
+// Base URL for API calls: the gateway's base path, empty at the root
+const API_BASE = BASE_PATH;
 
 // === Auth Token Management ===
 
@@ -118,7 +119,8 @@ export async function fetchMCPServers(): Promise<MCPServerStatus[]> {
  * GET /api/tools
  */
 export async function fetchTools(): Promise<ToolsListResult> {
-  return fetchJSON<ToolsListResult>('/api/tools');
+  const env = await fetchJSON<ListEnvelope<Tool>>('/api/tools');
+  return { tools: env.items };
 }
 
 /**
@@ -649,7 +651,8 @@ export async function fetchGatewayLogs(lines = 100, level?: string): Promise<Log
     throw new Error(`Logs fetch failed: ${response.status} ${response.statusText}`);
   }
 
-  return response.json();
+  const env: ListEnvelope<LogEntry> = await response.json();
+  return env.items;
 }
 
 // === Token Metrics API ===
@@ -806,8 +809,22 @@ export async function fetchRegistryStatus(): Promise<RegistryStatus> {
 
 // --- Agent Skills ---
 
-export async function fetchRegistrySkills(): Promise<AgentSkill[]> {
-  return fetchJSON<AgentSkill[]>('/api/registry/skills');
+// SkillFilter narrows the registry listing: every tag must match, and q is a
+// case-insensitive search over name, description, and body.
+export interface SkillFilter {
+  tags?: string[];
+  state?: ItemState;
+  q?: string;
+}
+
+export async function fetchRegistrySkills(filter: SkillFilter = {}): Promise<AgentSkill[]> {
+  const params = new URLSearchParams();
+  for (const tag of filter.tags ?? []) params.append('tag', tag);
+  if (filter.state) params.set('state', filter.state);
+  if (filter.q) params.set('q', filter.q);
+  const qs = params.toString();
+  const env = await fetchJSON<ListEnvelope<AgentSkill>>(`/api/registry/skills${qs ? `?${qs}` : ''}`);
+  return env.items;
 }
 
 /**
@@ -830,8 +847,54 @@ export async function createRegistrySkill(skill: AgentSkill): Promise<AgentSkill
   return mutateJSON<AgentSkill>('/api/registry/skills', 'POST', skill);
 }
 
+// SkillConflictError is thrown when a skill update is rejected because the
+// skill was saved by someone else since it was opened ("skill_modified", 409)
+// or because no version was sent ("version_required", 428). On 409 it carries
+// both versions so the editor can offer a merge.
+export class SkillConflictError extends Error {
+  code: string;
+  hint?: string;
+  current?: AgentSkill;
+  submitted?: AgentSkill;
+
+  constructor(code: string, message: string, hint?: string, current?: AgentSkill, submitted?: AgentSkill) {
+    super(message);
+    this.name = 'SkillConflictError';
+    this.code = code;
+    this.hint = hint;
+    this.current = current;
+    this.submitted = submitted;
+  }
+}
+
+/**
+ * Update a skill. `skill.version` must be the version the edit started from;
+ * a stale version rejects with SkillConflictError instead of overwriting.
+ * PUT /api/registry/skills/{name}
+ */
 export async function updateRegistrySkill(name: string, skill: AgentSkill): Promise<AgentSkill> {
-  return mutateJSON<AgentSkill>(`/api/registry/skills/${encodeURIComponent(name)}`, 'PUT', skill);
+  const response = await fetch(`${API_BASE}/api/registry/skills/${encodeURIComponent(name)}`, {
+    method: 'PUT',
+    headers: buildHeaders({ 'Content-Type': 'application/json' }),
+    body: JSON.stringify(skill),
+  });
+
+  if (response.status === 401) throw new AuthError('Authentication required');
+
+  const data = await response.json().catch(() => null);
+
+  if (!response.ok) {
+    const err = data?.error;
+    if (err && typeof err === 'object' && typeof err.code === 'string') {
+      throw new SkillConflictError(err.code, err.message ?? 'Save failed', err.hint, data.current, data.submitted);
+    }
+    throw new HTTPError(
+      response.status,
+      typeof err === 'string' ? err : `PUT /api/registry/skills/${name} failed: ${response.status}`,
+    );
+  }
+
+  return data as AgentSkill;
 }
 
 export async function deleteRegistrySkill(name: string): Promise<void> {
@@ -897,6 +960,30 @@ export async function writeSkillFile(skillName: string, filePath: string, conten
   }
 }
 
+export async function uploadSkillFiles(skillName: string, files: File[], dir?: string): Promise<SkillFile[]> {
+  const form = new FormData();
+  for (const file of files) form.append('file', file);
+  const query = dir ? `?dir=${encodeURIComponent(dir)}` : '';
+  const response = await fetch(
+    `${API_BASE}/api/registry/skills/${encodeURIComponent(skillName)}/files${query}`,
+    {
+      method: 'POST',
+      headers: buildHeaders(),
+      body: form,
+    }
+  );
+  if (response.status === 401) throw new AuthError('Authentication required');
+  if (!response.ok) {
+    const body = await response.json().catch(() => null);
+    throw new Error(body?.error || `Failed to upload files: ${response.status} ${response.statusText}`);
+  }
+  return response.json();
+}
+
+export function skillFileDownloadURL(skillName: string, filePath: string): string {
+  return `${API_BASE}/api/registry/skills/${encodeURIComponent(skillName)}/files/${filePath}?download=true`;
+}
+
 export async function deleteSkillFile(skillName: string, filePath: string): Promise<void> {
   const response = await fetch(
     `${API_BASE}/api/registry/skills/${encodeURIComponent(skillName)}/files/${filePath}`,
@@ -1241,19 +1328,31 @@ export interface LimitRateStatus {
   burst: number;
 }
 
-export type LimitState = 'ok' | 'warn' | 'exceeded';
+/**
+ * One access schedule's window and whether it is open now. next_open is set
+ * only while closed. Mirrors pkg/limits ScheduleStatus.
+ */
+export interface LimitScheduleStatus {
+  days: string;
+  hours: string;
+  open: boolean;
+  next_open?: string;
+}
+
+export type LimitState = 'ok' | 'warn' | 'exceeded' | 'closed';
 
 /**
- * One limit's snapshot. Exactly one of budget or rate is set, matching kind.
- * Mirrors pkg/limits EntryStatus.
+ * One limit's snapshot. Exactly one of budget, rate, or schedule is set,
+ * matching kind. Mirrors pkg/limits EntryStatus.
  */
 export interface LimitEntry {
-  kind: 'budget' | 'rate';
+  kind: 'budget' | 'rate' | 'schedule';
   scope: 'client' | 'server' | 'tool';
   key: string;
   state: LimitState;
   budget?: LimitBudgetStatus;
   rate?: LimitRateStatus;
+  schedule?: LimitScheduleStatus;
 }
 
 export interface LimitsReport {
@@ -1296,6 +1395,9 @@ export interface GroupStatus {
   name: string;
   description?: string;
   endpoint: string;
+  // Absolute endpoint URL as the gateway sees the browser reaching it,
+  // honoring reverse proxy X-Forwarded-* headers.
+  url?: string;
   member_count: number;
   tools: string[];
   members: GroupToolStatus[];
@@ -0,0 +1,17 @@
+// Path prefix the UI is mounted under when the gateway sets
+// gateway.base_path (e.g. "/gridctl"), or "" at the root. The gateway
+// injects it into index.html as <meta name="gridctl-base-path">; the Vite
+// dev server serves index.html untouched, so development runs at the root.
+function readBasePath(): string {
+  if (typeof document === 'undefined') return '';
+  const meta = document.querySelector('meta[name="gridctl-base-path"]');
+  return (meta?.getAttribute('content') ?? '').replace(/\/+$/, '');
+}
+
+export const BASE_PATH = readBasePath();
+
+// withBasePath prefixes an absolute gateway path ("/api/...", "/sse") with
+// BASE_PATH.
+export function withBasePath(path: string): string {
+  return `${BASE_PATH}${path}`;
+}
@@ -1,6 +1,7 @@
 // Pure helpers for the tool-groups overlay in the Tools workspace. JSX-free
 // (the metricsData/limitsData split) so Fast Refresh stays happy.
 import type { GroupsReport } from './api';
+import { withBasePath } from './basePath';
 
 // groupsForTool returns the names of groups whose surface includes the
 // canonical server__tool name, for the membership badges on tool rows.
@@ -13,10 +14,12 @@ export function groupsForTool(report: GroupsReport | null, canonical: string): s
   return names;
 }
 
-// groupEndpointURL builds the copyable absolute endpoint URL. The UI is
-// served by the gateway itself, so the page origin IS the gateway address.
-export function groupEndpointURL(endpoint: string): string {
-  return `${window.location.origin}${endpoint}`;
+// groupEndpointURL builds the copyable absolute endpoint URL, preferring the
+// gateway-reported url. Without one, the UI is served by the gateway itself,
+// so the page origin (plus any base path) IS the gateway address.
+export function groupEndpointURL(endpoint: string, url?: string): string {
+  if (url) return url;
+  return `${window.location.origin}${withBasePath(endpoint)}`;
 }
 
 // annotationChips flattens a member's hints into compact chip labels. Only
@@ -5,13 +5,14 @@ import './index.css';
 import { CommandRegistryProvider } from './hooks/useCommandRegistry';
 import { ErrorBoundary } from './components/ui/ErrorBoundary';
 import { AppRoutes } from './routes';
+import { BASE_PATH } from './lib/basePath';
 
 createRoot(document.getElementById('root')!).render(
   <StrictMode>
     {/* Last-resort net: a throw above the shell (router, providers) still
         renders a recoverable page instead of a blank #root. */}
     <ErrorBoundary variant="window">
-      <BrowserRouter>
+      <BrowserRouter basename={BASE_PATH || undefined}>
         <CommandRegistryProvider>
           <AppRoutes />
         </CommandRegistryProvider>
@@ -71,6 +71,15 @@ export interface MCPServerStatus {
   // MCP protocol version the downstream server reported at initialize; absent
   // for lax servers that omit it and for OpenAPI adapters (no MCP handshake).
   protocolVersion?: string;
+  // Capabilities the downstream server declared at initialize.
+  capabilities?: {
+    tools?: { listChanged?: boolean };
+    resources?: { subscribe?: boolean; listChanged?: boolean };
+    prompts?: { listChanged?: boolean };
+  };
+  // How the server differs after a reconnect from what it declared when it
+  // registered (version, protocol version, capabilities), one entry per change.
+  identityChanges?: string[];
   // True for servers that never registered with the gateway (initialize
   // failure, unsupported protocol version, unreachable endpoint). Such
   // entries carry only name/healthy/healthError.
@@ -332,7 +341,15 @@ export interface Tool {
   inputSchema: Record<string, unknown>;
 }
 
-// Tools list response from GET /api/tools
+// Envelope returned by every REST list endpoint (GET /api/tools, /api/logs,
+// /api/registry/skills). Pass nextCursor back as ?cursor= for the next page.
+export interface ListEnvelope<T> {
+  items: T[];
+  nextCursor?: string;
+  total: number;
+}
+
+// Tools list shape handed to the UI (MCP tools/list result)
 export interface ToolsListResult {
   tools: Tool[];
   nextCursor?: string;
@@ -369,6 +386,7 @@ export interface ToolUsageResponse {
 export interface SkillUsageStat {
   calls: number;
   lastCalledAt: string | null;
+  variants?: Record<string, SkillUsageStat>; // per-variant breakdown ("default" is the main body)
 }
 
 // GET /api/skills/usage: per-skill prompts/get call counts + last-called
@@ -476,9 +494,19 @@ export interface AgentSkill {
   allowedTools?: string;
   acceptanceCriteria?: string[]; // Given/When/Then scenarios (gridctl extension)
   state: ItemState;
+  tags?: string[]; // Filter labels (gridctl extension)
+  variants?: Record<string, number>; // Variant name -> % of prompts/get served it (gridctl extension)
+  files?: SkillFilePolicy; // Size and type limits for supporting files (gridctl extension)
   body: string;          // Markdown content (after frontmatter)
   fileCount: number;     // Supporting files count
   dir?: string;          // Relative path from skills/ root (e.g., "git-workflow/branch-fork")
+  version?: string;      // Content hash of SKILL.md; sent back on update to detect concurrent edits
+}
+
+// SkillFilePolicy limits supporting files written through the file API
+export interface SkillFilePolicy {
+  maxSize?: string;      // Per-file cap, e.g. "10MB" (default 1MB, at most 100MB)
+  allow?: string[];      // Permitted extensions (e.g. [".pdf", ".csv"]); empty allows any
 }
 
 // SkillFile represents a file within a skill directory
@@ -496,9 +524,16 @@ export interface SkillValidationResult {
   parsed?: AgentSkill;   // Parsed skill from content (when parseable)
 }
 
+export interface RegistryLoadWarning {
+  path: string;
+  message: string;
+  quarantined?: string;
+}
+
 export interface RegistryStatus {
   totalSkills: number;
   activeSkills: number;
+  warnings?: RegistryLoadWarning[];
 }
 
 // Skill canvas node data
@@ -4,7 +4,9 @@ import react from '@vitejs/plugin-react'
 // https://vite.dev/config/
 export default defineConfig({
   plugins: [react()],
-  base: '/',
+  // Relative asset URLs: the gateway injects <base href> into index.html so
+  // the UI works under gateway.base_path as well as at the root.
+  base: './',
   build: {
     chunkSizeWarningLimit: 1000,
     rollupOptions: {